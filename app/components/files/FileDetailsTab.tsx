@@ -7,6 +7,7 @@ import {
   FileRoleMain,
   FileRoleSupplement,
   FileTypeCBZ,
+  FileTypeEPUB,
   FileTypeM4B,
   FileTypePDF,
   type File,
@@ -86,6 +87,28 @@ const FileDetailsTab = ({ file }: FileDetailsTabProps) => {
             </div>
           )}
 
+        {/* Reading direction - CBZ only */}
+        {file.file_type === FileTypeCBZ && file.reading_direction && (
+          <div>
+            <p className="font-semibold">Reading Direction</p>
+            <p className="text-muted-foreground">
+              {file.reading_direction === "rtl"
+                ? "Right to left"
+                : "Left to right"}
+            </p>
+          </div>
+        )}
+
+        {/* Word count - EPUB only */}
+        {file.file_type === FileTypeEPUB && file.word_count != null && (
+          <div>
+            <p className="font-semibold">Word Count</p>
+            <p className="text-muted-foreground">
+              ~{file.word_count.toLocaleString()} words
+            </p>
+          </div>
+        )}
+
         {/* Duration - M4B only */}
         {file.file_type === FileTypeM4B &&
           file.audiobook_duration_seconds != null && (
@@ -116,6 +139,31 @@ const FileDetailsTab = ({ file }: FileDetailsTabProps) => {
           </div>
         )}
 
+        {/* Sample rate - M4B only */}
+        {file.file_type === FileTypeM4B &&
+          file.audiobook_sample_rate != null && (
+            <div>
+              <p className="font-semibold">Sample Rate</p>
+              <p className="text-muted-foreground">
+                {(file.audiobook_sample_rate / 1000).toFixed(1)} kHz
+              </p>
+            </div>
+          )}
+
+        {/* Channels - M4B only */}
+        {file.file_type === FileTypeM4B && file.audiobook_channels != null && (
+          <div>
+            <p className="font-semibold">Channels</p>
+            <p className="text-muted-foreground">
+              {file.audiobook_channels === 1
+                ? "Mono"
+                : file.audiobook_channels === 2
+                  ? "Stereo"
+                  : `${file.audiobook_channels} channels`}
+            </p>
+          </div>
+        )}
+
         {/* Language - all file types */}
         <div>
           <p className="font-semibold">Language</p>
@@ -304,7 +304,7 @@ const PagePicker = ({
                         alt={`Page ${page + 1}`}
                         className="w-full h-full object-contain"
                         loading="lazy"
-                        src={`/api/books/files/${fileId}/page/${page}`}
+                        src={`/api/books/files/${fileId}/page/${page}?w=144`}
                       />
                     ) : (
                       <div className="w-full h-full bg-muted animate-pulse" />
@@ -126,7 +126,7 @@ export const getNextChapterTitle = (previousTitle: string): string => {
 /**
  * Converts Chapter[] to ChapterInput[] for editing.
  * Strips out server-generated fields (id, file_id, parent_id, sort_order, created_at, updated_at)
- * and keeps only editable fields: title, start_page, start_timestamp_ms, href, children.
+ * and keeps only editable fields: title, start_page, start_timestamp_ms, href, cover_page_index, children.
  *
  * Note: sort_order is NOT included in ChapterInput - the API derives it from array order.
  */
@@ -136,6 +136,7 @@ export const chaptersToInputArray = (chapters: Chapter[]): ChapterInput[] => {
     href: chapter.href,
     start_page: chapter.start_page,
     start_timestamp_ms: chapter.start_timestamp_ms,
+    cover_page_index: chapter.cover_page_index,
     children: chapter.children
       ? chaptersToInputArray(chapter.children.filter(Boolean) as Chapter[])
       : [],
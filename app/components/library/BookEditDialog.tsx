@@ -64,6 +64,7 @@ interface SeriesEntry {
   name: string;
   number: string;
   unit: "" | "volume" | "chapter"; // "" means unspecified
+  readingOrder: string; // "" means same as the series number
 }
 
 export function BookEditDialog({
@@ -87,6 +88,7 @@ export function BookEditDialog({
         name: bs.series?.name || "",
         number: bs.series_number?.toString() || "",
         unit: bs.series_number_unit ?? "",
+        readingOrder: bs.reading_order?.toString() || "",
       }),
     ) || [],
   );
@@ -154,6 +156,7 @@ export function BookEditDialog({
         name: bs.series?.name || "",
         number: bs.series_number?.toString() || "",
         unit: bs.series_number_unit ?? "",
+        readingOrder: bs.reading_order?.toString() || "",
       })) || [];
     const initialGenres =
       book.book_genres?.map((bg) => bg.genre?.name || "").filter(Boolean) || [];
@@ -257,7 +260,7 @@ export function BookEditDialog({
     const name = "__create" in next ? next.__create : next.name;
     if (!name.trim()) return;
     if (seriesEntries.some((s) => s.name === name)) return;
-    setSeriesEntries([...seriesEntries, { name, number: "", unit: "" }]);
+    setSeriesEntries([...seriesEntries, { name, number: "", unit: "", readingOrder: "" }]);
   };
 
   const handleRemoveSeries = (index: number) => {
@@ -270,6 +273,12 @@ export function BookEditDialog({
     setSeriesEntries(updated);
   };
 
+  const handleSeriesReadingOrderChange = (index: number, value: string) => {
+    const updated = [...seriesEntries];
+    updated[index].readingOrder = value;
+    setSeriesEntries(updated);
+  };
+
   const handleSeriesUnitChange = (
     index: number,
     unit: "" | "volume" | "chapter",
@@ -326,6 +335,7 @@ export function BookEditDialog({
           name: bs.series?.name || "",
           number: bs.series_number?.toString() || "",
           unit: bs.series_number_unit ?? "",
+          readingOrder: bs.reading_order?.toString() || "",
         }),
       ) || [];
     if (JSON.stringify(seriesEntries) !== JSON.stringify(originalSeries)) {
@@ -335,6 +345,8 @@ export function BookEditDialog({
           name: s.name,
           number: s.number !== "" ? parseFloat(s.number) : undefined,
           series_number_unit: s.unit !== "" ? s.unit : undefined,
+          reading_order:
+            s.readingOrder !== "" ? parseFloat(s.readingOrder) : undefined,
         }));
     }
 
@@ -589,6 +601,16 @@ export function BookEditDialog({
                     type="number"
                     value={entry.number}
                   />
+                  <Input
+                    className="w-24"
+                    onChange={(e) =>
+                      handleSeriesReadingOrderChange(idx, e.target.value)
+                    }
+                    placeholder="Reading #"
+                    title="Position in reading order, if different from the series number"
+                    type="number"
+                    value={entry.readingOrder}
+                  />
                   <div className="w-32">
                     <Select
                       onValueChange={(value) =>
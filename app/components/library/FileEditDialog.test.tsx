@@ -319,23 +319,24 @@ describe("FileEditDialog", () => {
         expect(screen.queryByTestId("page-picker")).not.toBeInTheDocument();
       });
 
-      // At this point, pendingCoverPage should be 5, hasChanges should be true
+      // At this point, pendingCoverPage should be 6, hasChanges should be true
       // Now click Save
       const saveButton = screen.getByRole("button", { name: /save/i });
       await user.click(saveButton);
 
-      // Wait for the mutation to be called
+      // Wait for the mutation to be called. The picker is 0-indexed while
+      // cover_page is 1-indexed, so picker page 5 is saved as page 6.
       await waitFor(() => {
         expect(mockSetFileCoverPage).toHaveBeenCalledWith({
           id: 1,
-          page: 5,
+          page: 6,
         });
       });
 
       // After successful save, the dialog should close
       // This means requestClose() was called and hasChanges must have been false
       // If pendingCoverPage wasn't reset, hasChanges would still be true
-      // (because pendingCoverPage=5 !== file.cover_page=1),
+      // (because pendingCoverPage=6 !== file.cover_page=1),
       // and the unsaved changes dialog would appear instead of closing
       await waitFor(() => {
         expect(onOpenChange).toHaveBeenCalledWith(false);
@@ -359,8 +359,8 @@ export function FileEditDialog({
   };
 
   const handleCoverPageSelect = (page: number) => {
-    // Store the selected page for save
-    setPendingCoverPage(page);
+    // The picker works with 0-indexed pages; cover_page is 1-indexed
+    setPendingCoverPage(page + 1);
     setCoverPagePickerOpen(false);
   };
 
@@ -689,7 +689,7 @@ export function FileEditDialog({
                             <img
                               alt="Pending cover page"
                               className="w-full h-full object-cover"
-                              src={`/api/books/files/${file.id}/page/${pendingCoverPage}`}
+                              src={`/api/books/files/${file.id}/page/${pendingCoverPage - 1}`}
                             />
                           ) : file.cover_mime_type ||
                             file.cover_image_filename ? (
@@ -712,7 +712,7 @@ export function FileEditDialog({
                     {isPageBased &&
                       (pendingCoverPage ?? file.cover_page) != null && (
                         <div className="absolute bottom-1.5 left-1.5 px-1.5 py-0.5 rounded bg-black/70 text-white text-xs font-medium">
-                          Page {(pendingCoverPage ?? file.cover_page)! + 1}
+                          Page {pendingCoverPage ?? file.cover_page}
                         </div>
                       )}
                   </div>
@@ -782,7 +782,11 @@ export function FileEditDialog({
               {/* Page Picker Dialog */}
               {isPageBased && file.page_count != null && (
                 <PagePicker
-                  currentPage={pendingCoverPage ?? file.cover_page ?? null}
+                  currentPage={
+                    (pendingCoverPage ?? file.cover_page) != null
+                      ? (pendingCoverPage ?? file.cover_page)! - 1
+                      : null
+                  }
                   fileId={file.id}
                   onOpenChange={setCoverPagePickerOpen}
                   onSelect={handleCoverPageSelect}
@@ -17,6 +17,7 @@ import {
   DataSourceFilepath,
   DataSourceManual,
   FileRoleMain,
+  FileTypeCBZ,
   FileTypeEPUB,
   FileTypeM4B,
   type Book,
@@ -315,6 +316,35 @@ describe("IdentifyReviewForm component", () => {
     }
   });
 
+  it("treats a plugin cover page matching the file's 1-indexed cover_page as unchanged", async () => {
+    const user = createUser();
+    renderForm({
+      book: makeBook({
+        files: [
+          makeFile({
+            file_type: FileTypeCBZ,
+            cover_image_filename: "book.cbz.cover.jpg",
+            cover_page: 3,
+            page_count: 10,
+          }),
+        ],
+      }),
+      // Plugins return 0-indexed pages, so 2 is the file's page 3.
+      result: makeResult({ cover_page: 2 }),
+    });
+
+    expect(await screen.findAllByText("Page 3")).toHaveLength(2);
+
+    await user.click(getApplyButton());
+
+    await waitFor(() => {
+      expect(applyMock).toHaveBeenCalledTimes(1);
+    });
+
+    const payload = applyMock.mock.calls[0][0];
+    expect(payload.fields.cover_page).toBeUndefined();
+  });
+
   // -------------------------------------------------------------------------
   // New per-field decisions tests
   // -------------------------------------------------------------------------
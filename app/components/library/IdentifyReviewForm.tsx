@@ -103,6 +103,8 @@ interface SeriesEntry {
   name: string;
   number: string;
   unit: "" | "volume" | "chapter";
+  // Not editable here; carried through so applying doesn't drop it.
+  readingOrder?: number;
 }
 
 type BookFieldKey =
@@ -494,6 +496,7 @@ export function IdentifyReviewForm({
         name: bs.series?.name ?? "",
         number: bs.series_number?.toString() ?? "",
         unit: (bs.series_number_unit ?? "") as SeriesEntry["unit"],
+        readingOrder: bs.reading_order,
       })),
     [book.book_series],
   );
@@ -533,6 +536,7 @@ export function IdentifyReviewForm({
             name: result.series,
             number: result.series_number?.toString() ?? "",
             unit: (result.series_number_unit ?? "") as SeriesEntry["unit"],
+            readingOrder: result.series_reading_order,
           },
         ]
       : [];
@@ -661,7 +665,9 @@ export function IdentifyReviewForm({
   const currentCoverUrl = file?.cover_image_filename
     ? `/api/books/files/${file.id}/cover?v=${new Date(file.updated_at).getTime()}`
     : undefined;
-  const currentCoverPage = file?.cover_page ?? null;
+  // file.cover_page is 1-indexed; plugin results and page URLs are 0-indexed.
+  const currentCoverIndex =
+    file?.cover_page != null ? file.cover_page - 1 : null;
   const isPageBasedCoverChoice = isFilePageBased && newCoverPage != null;
   const currentCover = useImageDimensions(
     isPageBasedCoverChoice ? undefined : currentCoverUrl,
@@ -676,8 +682,8 @@ export function IdentifyReviewForm({
     : !!newCoverDims && currentCover.settled;
   const preferCurrentCover = isPageBasedCoverChoice
     ? !!currentCoverUrl &&
-      currentCoverPage !== null &&
-      currentCoverPage === newCoverPage
+      currentCoverIndex !== null &&
+      currentCoverIndex === newCoverPage
     : !!currentCoverDims &&
       !!newCoverDims &&
       currentCoverDims.w * currentCoverDims.h >=
@@ -1105,6 +1111,7 @@ export function IdentifyReviewForm({
           name: s.name,
           number: s.number !== "" ? parseFloat(s.number) : undefined,
           series_number_unit: s.unit !== "" ? s.unit : undefined,
+          reading_order: s.readingOrder,
         }));
     }
     if (decisions.genres) fields.genres = genres;
@@ -1716,8 +1723,8 @@ export function IdentifyReviewForm({
                       {currentCoverUrl && (
                         <span className="w-[calc(6rem+4px)] text-center">
                           {isPageBasedCoverChoice
-                            ? currentCoverPage !== null
-                              ? `Page ${currentCoverPage + 1}`
+                            ? currentCoverIndex !== null
+                              ? `Page ${currentCoverIndex + 1}`
                               : " "
                             : currentCoverDims
                               ? `${currentCoverDims.w} × ${currentCoverDims.h}`
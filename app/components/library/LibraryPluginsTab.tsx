@@ -25,6 +25,7 @@ const HOOK_TYPES: { label: string; value: PluginHookType }[] = [
   { label: "File Parser", value: "fileParser" },
   { label: "Output Generator", value: "outputGenerator" },
   { label: "Metadata Enricher", value: "metadataEnricher" },
+  { label: "Organizer", value: "organizer" },
 ];
 
 interface Props {
@@ -263,7 +263,7 @@ export function MergeBooksDialog({
                       This action cannot be undone
                     </p>
                     <p className="text-muted-foreground mt-1">
-                      {library.organize_file_structure
+                      {library.organize_folders
                         ? "Files will be physically moved to the target book's folder."
                         : "Files will be reassigned to the target book."}{" "}
                       Metadata from deleted books will not be transferred.
@@ -70,7 +70,7 @@ export function MergeIntoDialog({
 
   const sourceFileCount = sourceBook.files?.length || 0;
 
-  const warningMessage = library.organize_file_structure
+  const warningMessage = library.organize_folders
     ? "Files will be physically moved to the target book's folder. This book will be deleted."
     : "Files will be reassigned to the target book. This book will be deleted.";
 
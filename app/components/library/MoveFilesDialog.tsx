@@ -77,10 +77,10 @@ export function MoveFilesDialog({
     selectedFiles.length === (sourceBook.files?.length || 0);
 
   const warningMessage = willDeleteBook
-    ? library.organize_file_structure
+    ? library.organize_folders
       ? "The selected files will be moved to the target book's folder. This book will be deleted."
       : "The selected files will be reassigned to the target book. This book will be deleted."
-    : library.organize_file_structure
+    : library.organize_folders
       ? "The selected files will be moved to the target book's folder. Metadata from the source book will not be transferred."
       : "The selected files will be reassigned to the target book. Metadata from the source book will not be transferred.";
 
@@ -11,6 +11,7 @@ import {
 import { useReviewCriteria } from "@/hooks/queries/review";
 import { cn } from "@/libraries/utils";
 import {
+  DataSourceFilepath,
   FileRoleMain,
   FileTypeM4B,
   ReviewOverrideReviewed,
@@ -41,7 +42,8 @@ interface ReviewPanelProps {
  * active review criteria.
  *
  * Book-level fields: authors, description, genres, tags, series, subtitle
- * File-level fields: cover, publisher, identifiers, release_date, language, url
+ * File-level fields: cover, publisher, identifiers, release_date, language, url,
+ * confirmed_title (title not only from the filepath, or backed by an identifier)
  * Audio-only fields: narrators, chapters, abridged
  */
 function getMissingFields(
@@ -95,6 +97,13 @@ function getMissingFields(
       case "url":
         if (!file.url) missing.push(field);
         break;
+      case "confirmed_title":
+        if (
+          book.title_source === DataSourceFilepath &&
+          (!file.identifiers || file.identifiers.length === 0)
+        )
+          missing.push(field);
+        break;
     }
   }
 
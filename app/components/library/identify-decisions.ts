@@ -29,7 +29,10 @@ const SOURCE_PRIORITY: Record<string, number> = {
   cbz_metadata: 3,
   m4b_metadata: 3,
   pdf_metadata: 3,
-  filepath: 4,
+  mobi_metadata: 3,
+  nfo: 4,
+  generated: 5,
+  filepath: 5,
 };
 
 function getSourcePriority(source: string | undefined): number {
@@ -6,6 +6,8 @@ import {
   ChevronRight,
   Download,
   Edit,
+  Eye,
+  EyeOff,
   GitMerge,
   Headphones,
   List,
@@ -62,11 +64,13 @@ import {
 } from "@/components/ui/tooltip";
 import { getLanguageName } from "@/constants/languages";
 import {
+  useApproveBook,
   useBook,
   useDeleteBook,
   useDeleteFile,
   useResyncBook,
   useResyncFile,
+  useUpdateBook,
 } from "@/hooks/queries/books";
 import { useLibrary } from "@/hooks/queries/libraries";
 import { usePluginIdentifierTypes } from "@/hooks/queries/plugins";
@@ -685,6 +689,8 @@ const BookDetail = () => {
   usePageTitle(bookQuery.data?.title ?? "Book Details");
   const resyncFileMutation = useResyncFile();
   const resyncBookMutation = useResyncBook();
+  const approveBookMutation = useApproveBook();
+  const updateBookMutation = useUpdateBook();
   const deleteBookMutation = useDeleteBook();
   const deleteFileMutation = useDeleteFile();
   const setBookReviewMutation = useSetBookReview();
@@ -917,6 +923,30 @@ const BookDetail = () => {
     }
   };
 
+  const handleApproveBook = async () => {
+    if (!id) return;
+    try {
+      await approveBookMutation.mutateAsync(parseInt(id));
+      toast.success("Book approved");
+    } catch (error) {
+      toast.error(
+        error instanceof Error ? error.message : "Failed to approve book",
+      );
+    }
+  };
+
+  const handleSetHidden = async (hidden: boolean) => {
+    if (!id) return;
+    try {
+      await updateBookMutation.mutateAsync({ id, payload: { hidden } });
+      toast.success(hidden ? "Book hidden" : "Book unhidden");
+    } catch (error) {
+      toast.error(
+        error instanceof Error ? error.message : "Failed to update book",
+      );
+    }
+  };
+
   const handleDeleteBook = async () => {
     if (!id) return;
     try {
@@ -1102,6 +1132,17 @@ const BookDetail = () => {
                       <Search className="h-4 w-4 mr-2" />
                       Identify book
                     </DropdownMenuItem>
+                    <DropdownMenuItem
+                      disabled={updateBookMutation.isPending}
+                      onClick={() => handleSetHidden(!book.hidden)}
+                    >
+                      {book.hidden ? (
+                        <Eye className="h-4 w-4 mr-2" />
+                      ) : (
+                        <EyeOff className="h-4 w-4 mr-2" />
+                      )}
+                      {book.hidden ? "Unhide book" : "Hide book"}
+                    </DropdownMenuItem>
                     <DropdownMenuSeparator />
                     <DropdownMenuItem
                       onClick={() => setShowMergeIntoDialog(true)}
@@ -1131,6 +1172,39 @@ const BookDetail = () => {
                 />
               </div>
             </div>
+            {book.staged && (
+              <div className="flex items-center justify-between gap-3 rounded-md border px-3 py-2 mb-2">
+                <p className="text-sm text-muted-foreground">
+                  This book is staged. Its files won't be organized and no
+                  sidecars will be written until it's approved.
+                </p>
+                <Button
+                  disabled={approveBookMutation.isPending}
+                  onClick={handleApproveBook}
+                  size="sm"
+                >
+                  <Check className="h-4 w-4 mr-2" />
+                  Approve
+                </Button>
+              </div>
+            )}
+            {book.hidden && (
+              <div className="flex items-center justify-between gap-3 rounded-md border px-3 py-2 mb-2">
+                <p className="text-sm text-muted-foreground">
+                  This book is hidden. It won't appear in the library, search,
+                  OPDS, or eReader views until it's unhidden.
+                </p>
+                <Button
+                  disabled={updateBookMutation.isPending}
+                  onClick={() => handleSetHidden(false)}
+                  size="sm"
+                  variant="outline"
+                >
+                  <Eye className="h-4 w-4 mr-2" />
+                  Unhide
+                </Button>
+              </div>
+            )}
             {book.sort_title && book.sort_title !== book.title && (
               <p className="text-sm text-muted-foreground italic break-words">
                 Sort title: {book.sort_title}
@@ -1266,6 +1340,20 @@ const BookDetail = () => {
               </div>
             )}
 
+            {/* Collections */}
+            {book.book_collections && book.book_collections.length > 0 && (
+              <div>
+                <h3 className="font-semibold mb-2">Collections</h3>
+                <div className="flex flex-wrap gap-2">
+                  {book.book_collections.map((bc) => (
+                    <Badge key={bc.id} variant="outline">
+                      {bc.collection?.name ?? "Unknown"}
+                    </Badge>
+                  ))}
+                </div>
+              </div>
+            )}
+
             <Separator />
 
             {/* Metadata */}
@@ -32,7 +32,8 @@ import {
 // Initial values for the create form - stored once to compare against
 const INITIAL_VALUES = {
   name: "",
-  organizeFileStructure: true,
+  organizeFiles: true,
+  organizeFolders: true,
   coverAspectRatio: CoverAspectRatioBook as CoverAspectRatio,
   downloadFormatPreference: DownloadFormatOriginal as DownloadFormat,
   libraryPaths: [""],
@@ -44,8 +45,11 @@ const CreateLibrary = () => {
   const createLibraryMutation = useCreateLibrary();
 
   const [name, setName] = useState(INITIAL_VALUES.name);
-  const [organizeFileStructure, setOrganizeFileStructure] = useState(
-    INITIAL_VALUES.organizeFileStructure,
+  const [organizeFiles, setOrganizeFiles] = useState(
+    INITIAL_VALUES.organizeFiles,
+  );
+  const [organizeFolders, setOrganizeFolders] = useState(
+    INITIAL_VALUES.organizeFolders,
   );
   const [coverAspectRatio, setCoverAspectRatio] = useState<CoverAspectRatio>(
     INITIAL_VALUES.coverAspectRatio,
@@ -66,14 +70,16 @@ const CreateLibrary = () => {
     if (changesSaved) return false;
     return (
       name.trim() !== INITIAL_VALUES.name ||
-      organizeFileStructure !== INITIAL_VALUES.organizeFileStructure ||
+      organizeFiles !== INITIAL_VALUES.organizeFiles ||
+      organizeFolders !== INITIAL_VALUES.organizeFolders ||
       coverAspectRatio !== INITIAL_VALUES.coverAspectRatio ||
       downloadFormatPreference !== INITIAL_VALUES.downloadFormatPreference ||
       libraryPaths.some((p) => p.trim() !== "")
     );
   }, [
     name,
-    organizeFileStructure,
+    organizeFiles,
+    organizeFolders,
     coverAspectRatio,
     downloadFormatPreference,
     libraryPaths,
@@ -142,7 +148,8 @@ const CreateLibrary = () => {
       const library = await createLibraryMutation.mutateAsync({
         payload: {
           name: name.trim(),
-          organize_file_structure: organizeFileStructure,
+          organize_files: organizeFiles,
+          organize_folders: organizeFolders,
           cover_aspect_ratio: coverAspectRatio,
           download_format_preference: downloadFormatPreference,
           library_paths: validPaths,
@@ -230,28 +237,50 @@ const CreateLibrary = () => {
 
           <Separator />
 
-          {/* Organize File Structure Setting */}
+          {/* Organize Settings */}
           <div className="space-y-4 flex flex-col gap-0.5">
             <Label>Scanning Options</Label>
             <div className="flex flex-col leading-none">
               <div className="flex items-center space-x-2">
                 <Checkbox
-                  checked={organizeFileStructure}
+                  checked={organizeFiles}
                   id="organize-files"
                   onCheckedChange={(checked) =>
-                    setOrganizeFileStructure(checked as boolean)
+                    setOrganizeFiles(checked as boolean)
                   }
                 />
                 <Label
                   className="text-sm font-normal cursor-pointer"
                   htmlFor="organize-files"
                 >
-                  Organize file structure during scans
+                  Rename files
+                </Label>
+              </div>
+              <p className="text-xs text-muted-foreground">
+                When enabled, Shisho will rename files to a standardized name
+                based on their metadata.
+              </p>
+            </div>
+            <div className="flex flex-col leading-none">
+              <div className="flex items-center space-x-2">
+                <Checkbox
+                  checked={organizeFolders}
+                  id="organize-folders"
+                  onCheckedChange={(checked) =>
+                    setOrganizeFolders(checked as boolean)
+                  }
+                />
+                <Label
+                  className="text-sm font-normal cursor-pointer"
+                  htmlFor="organize-folders"
+                >
+                  Rename and create book folders
                 </Label>
               </div>
               <p className="text-xs text-muted-foreground">
-                When enabled, Shisho will reorganize files into a standardized
-                directory structure during scanning operations.
+                When enabled, Shisho will move each book into a standardized
+                folder, creating one for files found directly in a library
+                path.
               </p>
             </div>
           </div>
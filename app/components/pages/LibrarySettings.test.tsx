@@ -13,14 +13,16 @@ describe("LibrarySettings hasChanges logic", () => {
   const computeHasChanges = (
     formState: {
       name: string;
-      organizeFileStructure: boolean;
+      organizeFiles: boolean;
+      organizeFolders: boolean;
       coverAspectRatio: string;
       downloadFormatPreference: string;
       libraryPaths: string[];
     },
     initialValues: {
       name: string;
-      organizeFileStructure: boolean;
+      organizeFiles: boolean;
+      organizeFolders: boolean;
       coverAspectRatio: string;
       downloadFormatPreference: string;
       libraryPaths: string[];
@@ -30,7 +32,8 @@ describe("LibrarySettings hasChanges logic", () => {
     if (!initialValues || !isInitialized) return false;
     return (
       formState.name !== initialValues.name ||
-      formState.organizeFileStructure !== initialValues.organizeFileStructure ||
+      formState.organizeFiles !== initialValues.organizeFiles ||
+      formState.organizeFolders !== initialValues.organizeFolders ||
       formState.coverAspectRatio !== initialValues.coverAspectRatio ||
       formState.downloadFormatPreference !==
         initialValues.downloadFormatPreference ||
@@ -41,7 +44,8 @@ describe("LibrarySettings hasChanges logic", () => {
   // Simulate the handleSave behavior from LibrarySettings.tsx
   const simulateSave = (formState: {
     name: string;
-    organizeFileStructure: boolean;
+    organizeFiles: boolean;
+    organizeFolders: boolean;
     coverAspectRatio: string;
     downloadFormatPreference: string;
     libraryPaths: string[];
@@ -59,7 +63,8 @@ describe("LibrarySettings hasChanges logic", () => {
 
     const newInitialValues = {
       name: trimmedName,
-      organizeFileStructure: formState.organizeFileStructure,
+      organizeFiles: formState.organizeFiles,
+      organizeFolders: formState.organizeFolders,
       coverAspectRatio: formState.coverAspectRatio,
       downloadFormatPreference: formState.downloadFormatPreference,
       libraryPaths: validPaths,
@@ -72,7 +77,8 @@ describe("LibrarySettings hasChanges logic", () => {
     // User enters name with whitespace
     const formState = {
       name: "  Test Library  ",
-      organizeFileStructure: true,
+      organizeFiles: true,
+      organizeFolders: true,
       coverAspectRatio: "book",
       downloadFormatPreference: "original",
       libraryPaths: ["/path"],
@@ -81,7 +87,8 @@ describe("LibrarySettings hasChanges logic", () => {
     // Before save: hasChanges is true (compared to original initial values)
     const originalInitialValues = {
       name: "Original Library",
-      organizeFileStructure: true,
+      organizeFiles: true,
+      organizeFolders: true,
       coverAspectRatio: "book",
       downloadFormatPreference: "original",
       libraryPaths: ["/path"],
@@ -109,7 +116,8 @@ describe("LibrarySettings hasChanges logic", () => {
   it("should have hasChanges=false after save when libraryPaths had empty entries", () => {
     const formState = {
       name: "Library",
-      organizeFileStructure: true,
+      organizeFiles: true,
+      organizeFolders: true,
       coverAspectRatio: "book",
       downloadFormatPreference: "original",
       libraryPaths: ["/path1", "", "  ", "/path2"],
@@ -131,7 +139,8 @@ describe("LibrarySettings hasChanges logic", () => {
   it("should detect real changes after save", () => {
     const formState = {
       name: "Library",
-      organizeFileStructure: true,
+      organizeFiles: true,
+      organizeFolders: true,
       coverAspectRatio: "book",
       downloadFormatPreference: "original",
       libraryPaths: ["/path"],
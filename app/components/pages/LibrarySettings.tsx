@@ -21,18 +21,37 @@ import {
   SelectValue,
 } from "@/components/ui/select";
 import { Separator } from "@/components/ui/separator";
+import { Textarea } from "@/components/ui/textarea";
 import { UnsavedChangesDialog } from "@/components/ui/unsaved-changes-dialog";
 import { useLibrary, useUpdateLibrary } from "@/hooks/queries/libraries";
 import { useAuth } from "@/hooks/useAuth";
 import { usePageTitle } from "@/hooks/usePageTitle";
 import { useUnsavedChanges } from "@/hooks/useUnsavedChanges";
-import type { CoverAspectRatio, DownloadFormat } from "@/types";
+import {
+  FileTypeAZW3,
+  FileTypeCBZ,
+  FileTypeEPUB,
+  FileTypeM4B,
+  FileTypeMOBI,
+  FileTypePDF,
+  type CoverAspectRatio,
+  type DownloadFormat,
+} from "@/types";
 import {
   DownloadFormatAsk,
   DownloadFormatKepub,
   DownloadFormatOriginal,
 } from "@/types/generated/models";
 
+const builtInFileTypes = [
+  { value: FileTypeEPUB, label: "EPUB" },
+  { value: FileTypeCBZ, label: "CBZ" },
+  { value: FileTypeM4B, label: "M4B" },
+  { value: FileTypePDF, label: "PDF" },
+  { value: FileTypeMOBI, label: "MOBI" },
+  { value: FileTypeAZW3, label: "AZW3" },
+];
+
 const LibrarySettings = () => {
   const { libraryId } = useParams<{ libraryId: string }>();
   const libraryQuery = useLibrary(libraryId);
@@ -47,11 +66,30 @@ const LibrarySettings = () => {
   );
 
   const [name, setName] = useState("");
-  const [organizeFileStructure, setOrganizeFileStructure] = useState(true);
+  const [organizeFiles, setOrganizeFiles] = useState(true);
+  const [organizeFolders, setOrganizeFolders] = useState(true);
+  const [staging, setStaging] = useState(false);
+  const [inferSeriesFromParentDir, setInferSeriesFromParentDir] =
+    useState(false);
+  const [enrichFromOpenLibrary, setEnrichFromOpenLibrary] = useState(false);
+  const [autoPairFormats, setAutoPairFormats] = useState(false);
+  const [stripSeriesFromTitle, setStripSeriesFromTitle] = useState(false);
   const [coverAspectRatio, setCoverAspectRatio] =
     useState<CoverAspectRatio>("book");
+  const [cbzCoverPageDefault, setCbzCoverPageDefault] = useState(1);
+  const [preferExternalCover, setPreferExternalCover] = useState(false);
   const [downloadFormatPreference, setDownloadFormatPreference] =
     useState<DownloadFormat>(DownloadFormatOriginal);
+  // An empty list allows every file type.
+  const [allowedFileTypes, setAllowedFileTypes] = useState<string[]>([]);
+  const [filenamePatterns, setFilenamePatterns] = useState("");
+  // One separator per line. Surrounding spaces are part of the separator.
+  const [nameSeparators, setNameSeparators] = useState("");
+  const [scanSchedule, setScanSchedule] = useState("");
+  // File type to the subfolder organized root-level files of that type go into.
+  const [organizeSubfolders, setOrganizeSubfolders] = useState<
+    Record<string, string>
+  >({});
   const [libraryPaths, setLibraryPaths] = useState<string[]>([""]);
   const [isInitialized, setIsInitialized] = useState(false);
   const [pluginsHaveChanges, setPluginsHaveChanges] = useState(false);
@@ -64,9 +102,22 @@ const LibrarySettings = () => {
   // Store initial values for change detection
   const [initialValues, setInitialValues] = useState<{
     name: string;
-    organizeFileStructure: boolean;
+    organizeFiles: boolean;
+    organizeFolders: boolean;
+    staging: boolean;
+    inferSeriesFromParentDir: boolean;
+    enrichFromOpenLibrary: boolean;
+    autoPairFormats: boolean;
+    stripSeriesFromTitle: boolean;
     coverAspectRatio: CoverAspectRatio;
+    cbzCoverPageDefault: number;
+    preferExternalCover: boolean;
     downloadFormatPreference: DownloadFormat;
+    allowedFileTypes: string[];
+    filenamePatterns: string;
+    nameSeparators: string;
+    scanSchedule: string;
+    organizeSubfolders: Record<string, string>;
     libraryPaths: string[];
   } | null>(null);
 
@@ -86,27 +137,77 @@ const LibrarySettings = () => {
       !isInitialized
     ) {
       const initialName = libraryQuery.data.name;
-      const initialOrganize = libraryQuery.data.organize_file_structure;
+      const initialOrganizeFiles = libraryQuery.data.organize_files;
+      const initialOrganizeFolders = libraryQuery.data.organize_folders;
+      const initialStaging = libraryQuery.data.staging;
+      const initialInferSeries =
+        libraryQuery.data.infer_series_from_parent_dir;
+      const initialEnrichFromOpenLibrary =
+        libraryQuery.data.enrich_from_open_library;
+      const initialAutoPairFormats = libraryQuery.data.auto_pair_formats;
+      const initialStripSeriesFromTitle =
+        libraryQuery.data.strip_series_from_title;
       const initialCover = libraryQuery.data.cover_aspect_ratio;
+      const initialCbzCoverPage =
+        libraryQuery.data.cbz_cover_page_default || 1;
+      const initialPreferExternalCover =
+        libraryQuery.data.prefer_external_cover;
       const initialDownload =
         libraryQuery.data.download_format_preference || DownloadFormatOriginal;
+      const initialAllowedFileTypes =
+        libraryQuery.data.allowed_file_types ?? [];
+      const initialFilenamePatterns = (
+        libraryQuery.data.filename_patterns ?? []
+      ).join("\n");
+      const initialNameSeparators = (
+        libraryQuery.data.name_separators ?? []
+      ).join("\n");
+      const initialScanSchedule = libraryQuery.data.scan_schedule ?? "";
+      const initialOrganizeSubfolders =
+        libraryQuery.data.organize_subfolders ?? {};
       const initialPaths = libraryQuery.data.library_paths?.map(
         (lp) => lp.filepath,
       ) || [""];
 
       setName(initialName);
-      setOrganizeFileStructure(initialOrganize);
+      setOrganizeFiles(initialOrganizeFiles);
+      setOrganizeFolders(initialOrganizeFolders);
+      setStaging(initialStaging);
+      setInferSeriesFromParentDir(initialInferSeries);
+      setEnrichFromOpenLibrary(initialEnrichFromOpenLibrary);
+      setAutoPairFormats(initialAutoPairFormats);
+      setStripSeriesFromTitle(initialStripSeriesFromTitle);
       setCoverAspectRatio(initialCover);
+      setCbzCoverPageDefault(initialCbzCoverPage);
+      setPreferExternalCover(initialPreferExternalCover);
       setDownloadFormatPreference(initialDownload);
+      setAllowedFileTypes(initialAllowedFileTypes);
+      setFilenamePatterns(initialFilenamePatterns);
+      setNameSeparators(initialNameSeparators);
+      setScanSchedule(initialScanSchedule);
+      setOrganizeSubfolders(initialOrganizeSubfolders);
       setLibraryPaths(initialPaths);
       setIsInitialized(true);
 
       // Store initial values for comparison
       setInitialValues({
         name: initialName,
-        organizeFileStructure: initialOrganize,
+        organizeFiles: initialOrganizeFiles,
+        organizeFolders: initialOrganizeFolders,
+        staging: initialStaging,
+        inferSeriesFromParentDir: initialInferSeries,
+        enrichFromOpenLibrary: initialEnrichFromOpenLibrary,
+        autoPairFormats: initialAutoPairFormats,
+        stripSeriesFromTitle: initialStripSeriesFromTitle,
         coverAspectRatio: initialCover,
+        cbzCoverPageDefault: initialCbzCoverPage,
+        preferExternalCover: initialPreferExternalCover,
         downloadFormatPreference: initialDownload,
+        allowedFileTypes: initialAllowedFileTypes,
+        filenamePatterns: initialFilenamePatterns,
+        nameSeparators: initialNameSeparators,
+        scanSchedule: initialScanSchedule,
+        organizeSubfolders: initialOrganizeSubfolders,
         libraryPaths: initialPaths,
       });
     }
@@ -117,16 +218,42 @@ const LibrarySettings = () => {
     if (!initialValues || !isInitialized) return false;
     return (
       name !== initialValues.name ||
-      organizeFileStructure !== initialValues.organizeFileStructure ||
+      organizeFiles !== initialValues.organizeFiles ||
+      organizeFolders !== initialValues.organizeFolders ||
+      staging !== initialValues.staging ||
+      inferSeriesFromParentDir !== initialValues.inferSeriesFromParentDir ||
+      enrichFromOpenLibrary !== initialValues.enrichFromOpenLibrary ||
+      autoPairFormats !== initialValues.autoPairFormats ||
+      stripSeriesFromTitle !== initialValues.stripSeriesFromTitle ||
       coverAspectRatio !== initialValues.coverAspectRatio ||
+      cbzCoverPageDefault !== initialValues.cbzCoverPageDefault ||
+      preferExternalCover !== initialValues.preferExternalCover ||
       downloadFormatPreference !== initialValues.downloadFormatPreference ||
+      !equal(allowedFileTypes, initialValues.allowedFileTypes) ||
+      filenamePatterns !== initialValues.filenamePatterns ||
+      nameSeparators !== initialValues.nameSeparators ||
+      scanSchedule !== initialValues.scanSchedule ||
+      !equal(organizeSubfolders, initialValues.organizeSubfolders) ||
       !equal(libraryPaths, initialValues.libraryPaths)
     );
   }, [
     name,
-    organizeFileStructure,
+    organizeFiles,
+    organizeFolders,
+    staging,
+    inferSeriesFromParentDir,
+    enrichFromOpenLibrary,
+    autoPairFormats,
+    stripSeriesFromTitle,
     coverAspectRatio,
+    cbzCoverPageDefault,
+    preferExternalCover,
     downloadFormatPreference,
+    allowedFileTypes,
+    filenamePatterns,
+    nameSeparators,
+    scanSchedule,
+    organizeSubfolders,
     libraryPaths,
     isInitialized,
     initialValues,
@@ -138,6 +265,28 @@ const LibrarySettings = () => {
   const { showBlockerDialog, proceedNavigation, cancelNavigation } =
     useUnsavedChanges(hasChanges);
 
+  const isFileTypeAllowed = (fileType: string) =>
+    allowedFileTypes.length === 0 || allowedFileTypes.includes(fileType);
+
+  const handleFileTypeToggle = (fileType: string, checked: boolean) => {
+    const current =
+      allowedFileTypes.length === 0
+        ? builtInFileTypes.map((t) => t.value)
+        : allowedFileTypes;
+    const next = checked
+      ? [...current, fileType]
+      : current.filter((t) => t !== fileType);
+    if (next.length === 0) {
+      toast.error("At least one file type must be allowed");
+      return;
+    }
+    // Allowing every built-in type is the same as not restricting at all.
+    const allowsAll =
+      next.length === builtInFileTypes.length &&
+      builtInFileTypes.every((t) => next.includes(t.value));
+    setAllowedFileTypes(allowsAll ? [] : next);
+  };
+
   const handleAddPath = () => {
     setLibraryPaths([...libraryPaths, ""]);
   };
@@ -188,14 +337,40 @@ const LibrarySettings = () => {
         toast.error("At least one library path is required");
         return;
       }
+      const validPatterns = filenamePatterns
+        .split("\n")
+        .map((pattern) => pattern.trim())
+        .filter((pattern) => pattern !== "");
+      const validSeparators = nameSeparators
+        .split("\n")
+        .filter((separator) => separator.trim() !== "");
+      const trimmedScanSchedule = scanSchedule.trim();
+      const validSubfolders = Object.fromEntries(
+        Object.entries(organizeSubfolders)
+          .map(([fileType, folder]) => [fileType, folder.trim()] as const)
+          .filter(([, folder]) => folder !== ""),
+      );
 
       await updateLibraryMutation.mutateAsync({
         id: libraryId,
         payload: {
           name: name.trim(),
-          organize_file_structure: organizeFileStructure,
+          organize_files: organizeFiles,
+          organize_folders: organizeFolders,
+          staging,
+          infer_series_from_parent_dir: inferSeriesFromParentDir,
+          enrich_from_open_library: enrichFromOpenLibrary,
+          auto_pair_formats: autoPairFormats,
+          strip_series_from_title: stripSeriesFromTitle,
           cover_aspect_ratio: coverAspectRatio,
+          cbz_cover_page_default: cbzCoverPageDefault,
+          prefer_external_cover: preferExternalCover,
           download_format_preference: downloadFormatPreference,
+          allowed_file_types: allowedFileTypes,
+          filename_patterns: validPatterns,
+          name_separators: validSeparators,
+          scan_schedule: trimmedScanSchedule,
+          organize_subfolders: validSubfolders,
           library_paths: validPaths,
         },
       });
@@ -206,13 +381,30 @@ const LibrarySettings = () => {
       const trimmedName = name.trim();
       setName(trimmedName);
       setLibraryPaths(validPaths);
+      setFilenamePatterns(validPatterns.join("\n"));
+      setNameSeparators(validSeparators.join("\n"));
+      setScanSchedule(trimmedScanSchedule);
+      setOrganizeSubfolders(validSubfolders);
 
       // Update initial values to match saved values so hasChanges becomes false
       setInitialValues({
         name: trimmedName,
-        organizeFileStructure,
+        organizeFiles,
+        organizeFolders,
+        staging,
+        inferSeriesFromParentDir,
+        enrichFromOpenLibrary,
+        autoPairFormats,
+        stripSeriesFromTitle,
         coverAspectRatio,
+        cbzCoverPageDefault,
+        preferExternalCover,
         downloadFormatPreference,
+        allowedFileTypes,
+        filenamePatterns: validPatterns.join("\n"),
+        nameSeparators: validSeparators.join("\n"),
+        scanSchedule: trimmedScanSchedule,
+        organizeSubfolders: validSubfolders,
         libraryPaths: validPaths,
       });
     } catch (e) {
@@ -309,34 +501,290 @@ const LibrarySettings = () => {
 
         <Separator />
 
-        {/* Organize File Structure Setting */}
+        {/* Organize Settings */}
         <div className="space-y-4 flex flex-col gap-0.5">
           <Label>Scanning Options</Label>
           <div className="flex flex-col leading-none">
             <div className="flex items-center space-x-2">
               <Checkbox
-                checked={organizeFileStructure}
+                checked={organizeFiles}
                 id="organize-files"
                 onCheckedChange={(checked) =>
-                  setOrganizeFileStructure(checked as boolean)
+                  setOrganizeFiles(checked as boolean)
                 }
               />
               <Label
                 className="text-sm font-normal cursor-pointer"
                 htmlFor="organize-files"
               >
-                Organize file structure during scans
+                Rename files
+              </Label>
+            </div>
+            <p className="text-xs text-muted-foreground">
+              When enabled, Shisho will rename files to a standardized name
+              based on their metadata.
+            </p>
+          </div>
+          <div className="flex flex-col leading-none">
+            <div className="flex items-center space-x-2">
+              <Checkbox
+                checked={organizeFolders}
+                id="organize-folders"
+                onCheckedChange={(checked) =>
+                  setOrganizeFolders(checked as boolean)
+                }
+              />
+              <Label
+                className="text-sm font-normal cursor-pointer"
+                htmlFor="organize-folders"
+              >
+                Rename and create book folders
+              </Label>
+            </div>
+            <p className="text-xs text-muted-foreground">
+              When enabled, Shisho will move each book into a standardized
+              folder, creating one for files found directly in a library path.
+            </p>
+            {organizeFolders && (
+              <div className="space-y-2 pt-3 pl-6">
+                <p className="text-xs text-muted-foreground">
+                  Subfolders for new files at the library root, by type. Leave
+                  empty to organize them at the root.
+                </p>
+                {builtInFileTypes.map((fileType) => (
+                  <div
+                    className="flex items-center gap-2"
+                    key={fileType.value}
+                  >
+                    <Label
+                      className="w-14 text-sm font-normal"
+                      htmlFor={`organize-subfolder-${fileType.value}`}
+                    >
+                      {fileType.label}
+                    </Label>
+                    <Input
+                      className="w-48"
+                      id={`organize-subfolder-${fileType.value}`}
+                      onChange={(e) =>
+                        setOrganizeSubfolders({
+                          ...organizeSubfolders,
+                          [fileType.value]: e.target.value,
+                        })
+                      }
+                      placeholder="Library root"
+                      value={organizeSubfolders[fileType.value] ?? ""}
+                    />
+                  </div>
+                ))}
+              </div>
+            )}
+          </div>
+          <div className="flex flex-col leading-none">
+            <div className="flex items-center space-x-2">
+              <Checkbox
+                checked={staging}
+                id="staging"
+                onCheckedChange={(checked) => setStaging(checked as boolean)}
+              />
+              <Label
+                className="text-sm font-normal cursor-pointer"
+                htmlFor="staging"
+              >
+                Stage new books for review
+              </Label>
+            </div>
+            <p className="text-xs text-muted-foreground">
+              When enabled, newly scanned books are added without moving files
+              or writing sidecars. Approve a book to organize it.
+            </p>
+          </div>
+          <div className="flex flex-col leading-none">
+            <div className="flex items-center space-x-2">
+              <Checkbox
+                checked={inferSeriesFromParentDir}
+                id="infer_series_from_parent_dir"
+                onCheckedChange={(checked) =>
+                  setInferSeriesFromParentDir(checked as boolean)
+                }
+              />
+              <Label
+                className="text-sm font-normal cursor-pointer"
+                htmlFor="infer_series_from_parent_dir"
+              >
+                Detect series from parent folders
               </Label>
             </div>
             <p className="text-xs text-muted-foreground">
-              When enabled, Shisho will reorganize files into a standardized
-              directory structure during scanning operations.
+              When enabled, books in numbered folders like{" "}
+              <code>Series Name/01 - Title</code> get their series and number
+              from the folder names if the file doesn't provide them.
+            </p>
+          </div>
+          <div className="flex flex-col leading-none">
+            <div className="flex items-center space-x-2">
+              <Checkbox
+                checked={enrichFromOpenLibrary}
+                id="enrich_from_open_library"
+                onCheckedChange={(checked) =>
+                  setEnrichFromOpenLibrary(checked as boolean)
+                }
+              />
+              <Label
+                className="text-sm font-normal cursor-pointer"
+                htmlFor="enrich_from_open_library"
+              >
+                Fill missing metadata from Open Library
+              </Label>
+            </div>
+            <p className="text-xs text-muted-foreground">
+              When enabled, scans look up books with an ISBN on Open Library
+              and fill in an empty title, authors, description, publisher,
+              release date, or cover.
+            </p>
+          </div>
+          <div className="flex flex-col leading-none">
+            <div className="flex items-center space-x-2">
+              <Checkbox
+                checked={autoPairFormats}
+                id="auto_pair_formats"
+                onCheckedChange={(checked) =>
+                  setAutoPairFormats(checked as boolean)
+                }
+              />
+              <Label
+                className="text-sm font-normal cursor-pointer"
+                htmlFor="auto_pair_formats"
+              >
+                Pair audiobooks with ebooks
+              </Label>
+            </div>
+            <p className="text-xs text-muted-foreground">
+              When enabled, a new audiobook or ebook is added to an existing
+              book in the other format with the same ISBN or ASIN, or the
+              same title and author, even when they're in different
+              folders.
+            </p>
+          </div>
+          <div className="flex flex-col leading-none">
+            <div className="flex items-center space-x-2">
+              <Checkbox
+                checked={stripSeriesFromTitle}
+                id="strip_series_from_title"
+                onCheckedChange={(checked) =>
+                  setStripSeriesFromTitle(checked as boolean)
+                }
+              />
+              <Label
+                className="text-sm font-normal cursor-pointer"
+                htmlFor="strip_series_from_title"
+              >
+                Strip series from titles
+              </Label>
+            </div>
+            <p className="text-xs text-muted-foreground">
+              When enabled, scans move a leading series and number out of
+              titles, so Mistborn #2: The Well of Ascension becomes The Well of
+              Ascension in the Mistborn series. Turning it off restores the
+              original titles on the next scan.
             </p>
           </div>
         </div>
 
         <Separator />
 
+        {/* Allowed File Types Setting */}
+        <div className="space-y-2">
+          <Label>Allowed File Types</Label>
+          <p className="text-sm text-muted-foreground">
+            Scans only import books of these types. Other book files are
+            skipped, or kept as supplements when they sit next to an allowed
+            book.
+          </p>
+          <div className="flex flex-wrap gap-4">
+            {builtInFileTypes.map((fileType) => (
+              <div className="flex items-center space-x-2" key={fileType.value}>
+                <Checkbox
+                  checked={isFileTypeAllowed(fileType.value)}
+                  id={`allowed-file-type-${fileType.value}`}
+                  onCheckedChange={(checked) =>
+                    handleFileTypeToggle(fileType.value, checked as boolean)
+                  }
+                />
+                <Label
+                  className="text-sm font-normal cursor-pointer"
+                  htmlFor={`allowed-file-type-${fileType.value}`}
+                >
+                  {fileType.label}
+                </Label>
+              </div>
+            ))}
+          </div>
+        </div>
+
+        <Separator />
+
+        {/* Filename Patterns Setting */}
+        <div className="space-y-2">
+          <Label htmlFor="filename-patterns">Filename Patterns</Label>
+          <p className="text-sm text-muted-foreground">
+            Regular expressions, one per line, for reading metadata from file
+            and folder names when the files themselves don't have it. Use the
+            named groups <code>author</code>, <code>narrator</code>,{" "}
+            <code>series</code>, <code>number</code>, and <code>title</code>,
+            for example <code>{"^(?P<author>.+?) - (?P<title>.+)$"}</code>.
+          </p>
+          <Textarea
+            className="font-mono text-sm"
+            id="filename-patterns"
+            onChange={(e) => setFilenamePatterns(e.target.value)}
+            placeholder="^(?P<author>.+?) - (?P<title>.+)$"
+            rows={3}
+            value={filenamePatterns}
+          />
+        </div>
+
+        <Separator />
+
+        {/* Name Separators Setting */}
+        <div className="space-y-2">
+          <Label htmlFor="name-separators">Name Separators</Label>
+          <p className="text-sm text-muted-foreground">
+            What to split author and narrator lists on, one per line, like{" "}
+            <code>{" / "}</code> or <code>{" feat. "}</code>. Spaces around a
+            separator count. Leave empty to split on commas and semicolons.
+          </p>
+          <Textarea
+            className="font-mono text-sm"
+            id="name-separators"
+            onChange={(e) => setNameSeparators(e.target.value)}
+            placeholder=","
+            rows={3}
+            value={nameSeparators}
+          />
+        </div>
+
+        <Separator />
+
+        {/* Scan Schedule Setting */}
+        <div className="space-y-2">
+          <Label htmlFor="scan-schedule">Scan Schedule</Label>
+          <p className="text-sm text-muted-foreground">
+            A cron expression for scanning just this library, like{" "}
+            <code>*/15 * * * *</code> for every 15 minutes or{" "}
+            <code>@daily</code> for every night at midnight. Leave empty to
+            rely on the global sync interval.
+          </p>
+          <Input
+            className="font-mono text-sm"
+            id="scan-schedule"
+            onChange={(e) => setScanSchedule(e.target.value)}
+            placeholder="*/15 * * * *"
+            value={scanSchedule}
+          />
+        </div>
+
+        <Separator />
+
         {/* Cover Aspect Ratio Setting */}
         <div className="space-y-2">
           <Label htmlFor="cover-aspect-ratio">Cover Display Aspect Ratio</Label>
@@ -367,6 +815,54 @@ const LibrarySettings = () => {
 
         <Separator />
 
+        {/* CBZ Cover Page Setting */}
+        <div className="space-y-2">
+          <Label htmlFor="cbz-cover-page-default">
+            Default CBZ Cover Page
+          </Label>
+          <p className="text-sm text-muted-foreground">
+            Page newly scanned comics use as their cover, for releases that
+            start with credit pages. A cover set in ComicInfo.xml still wins.
+          </p>
+          <Input
+            className="w-32"
+            id="cbz-cover-page-default"
+            max={1000}
+            min={1}
+            onChange={(e) =>
+              setCbzCoverPageDefault(
+                Math.min(1000, Math.max(1, Number(e.target.value) || 1)),
+              )
+            }
+            type="number"
+            value={cbzCoverPageDefault}
+          />
+          <div className="flex flex-col leading-none pt-2">
+            <div className="flex items-center space-x-2">
+              <Checkbox
+                checked={preferExternalCover}
+                id="prefer_external_cover"
+                onCheckedChange={(checked) =>
+                  setPreferExternalCover(checked as boolean)
+                }
+              />
+              <Label
+                className="text-sm font-normal cursor-pointer"
+                htmlFor="prefer_external_cover"
+              >
+                Prefer cover images in the book folder
+              </Label>
+            </div>
+            <p className="text-xs text-muted-foreground">
+              When enabled, new comics use a <code>cover.jpg</code> (or{" "}
+              <code>folder.jpg</code>, <code>poster.jpg</code>) in their folder
+              instead of extracting a cover from the archive.
+            </p>
+          </div>
+        </div>
+
+        <Separator />
+
         {/* Download Format Preference Setting */}
         <div className="space-y-2">
           <Label htmlFor="download-format">Download Format Preference</Label>
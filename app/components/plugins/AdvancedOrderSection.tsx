@@ -30,6 +30,7 @@ const HOOK_TYPES: { label: string; value: PluginHookType }[] = [
   { label: "File Parser", value: "fileParser" },
   { label: "Input Converter", value: "inputConverter" },
   { label: "Output Generator", value: "outputGenerator" },
+  { label: "Organizer", value: "organizer" },
 ];
 
 export const AdvancedOrderSection = () => {
@@ -146,6 +146,7 @@ export const DiscoverTab = ({ canWrite }: DiscoverTabProps) => {
               <SelectItem value="inputConverter">Input converter</SelectItem>
               <SelectItem value="fileParser">File parser</SelectItem>
               <SelectItem value="outputGenerator">Output generator</SelectItem>
+              <SelectItem value="organizer">Organizer</SelectItem>
             </SelectContent>
           </Select>
           <Select onValueChange={setSource} value={source}>
@@ -46,6 +46,9 @@ export const PluginConfigForm = ({
   const [fieldSettings, setFieldSettings] = useState<Record<string, boolean>>(
     {},
   );
+  const [preferredFields, setPreferredFields] = useState<
+    Record<string, boolean>
+  >({});
   const [confidenceThreshold, setConfidenceThreshold] = useState<number | null>(
     null,
   );
@@ -54,6 +57,7 @@ export const PluginConfigForm = ({
   const [initialValues, setInitialValues] = useState<{
     formValues: Record<string, string>;
     fieldSettings: Record<string, boolean>;
+    preferredFields: Record<string, boolean>;
     confidenceThreshold: number | null;
   } | null>(null);
 
@@ -90,6 +94,8 @@ export const PluginConfigForm = ({
 
     const initialFieldSettings = data.fieldSettings ?? {};
     setFieldSettings(initialFieldSettings);
+    const initialPreferredFields = data.preferredFields ?? {};
+    setPreferredFields(initialPreferredFields);
 
     // API returns 0-1, convert to percentage for display.
     const threshold =
@@ -101,6 +107,7 @@ export const PluginConfigForm = ({
     setInitialValues({
       formValues: { ...initial },
       fieldSettings: { ...initialFieldSettings },
+      preferredFields: { ...initialPreferredFields },
       confidenceThreshold: threshold,
     });
   }, [data, dataUpdatedAt]);
@@ -111,9 +118,16 @@ export const PluginConfigForm = ({
     return (
       !equal(formValues, initialValues.formValues) ||
       !equal(fieldSettings, initialValues.fieldSettings) ||
+      !equal(preferredFields, initialValues.preferredFields) ||
       confidenceThreshold !== initialValues.confidenceThreshold
     );
-  }, [formValues, fieldSettings, confidenceThreshold, initialValues]);
+  }, [
+    formValues,
+    fieldSettings,
+    preferredFields,
+    confidenceThreshold,
+    initialValues,
+  ]);
 
   // Notify parent of dirty state
   useEffect(() => {
@@ -124,6 +138,10 @@ export const PluginConfigForm = ({
     setFieldSettings((prev) => ({ ...prev, [field]: enabled }));
   };
 
+  const handlePreferToggle = (field: string, prefer: boolean) => {
+    setPreferredFields((prev) => ({ ...prev, [field]: prefer }));
+  };
+
   const handleSave = async () => {
     if (!data) return;
 
@@ -149,6 +167,14 @@ export const PluginConfigForm = ({
         }
       }
     }
+    const changedPreferred: Record<string, boolean> = {};
+    for (const field of data.preferableFields ?? []) {
+      const original = data.preferredFields?.[field] ?? false;
+      const current = preferredFields[field] ?? false;
+      if (original !== current) {
+        changedPreferred[field] = current;
+      }
+    }
 
     // Await both mutations so initialValues is only reset when both succeed.
     // Otherwise a failed field-settings save would silently clear the dirty
@@ -165,9 +191,17 @@ export const PluginConfigForm = ({
             confidenceThreshold == null ? true : undefined,
         }),
       ];
-      if (Object.keys(changedFields).length > 0) {
+      if (
+        Object.keys(changedFields).length > 0 ||
+        Object.keys(changedPreferred).length > 0
+      ) {
         tasks.push(
-          saveFieldSettings.mutateAsync({ scope, id, fields: changedFields }),
+          saveFieldSettings.mutateAsync({
+            scope,
+            id,
+            fields: changedFields,
+            preferred: changedPreferred,
+          }),
         );
       }
       await Promise.all(tasks);
@@ -176,6 +210,7 @@ export const PluginConfigForm = ({
       setInitialValues({
         formValues: { ...formValues },
         fieldSettings: { ...fieldSettings },
+        preferredFields: { ...preferredFields },
         confidenceThreshold,
       });
     } catch (err) {
@@ -299,17 +334,39 @@ export const PluginConfigForm = ({
           </div>
           <div className="space-y-3">
             {data!.declaredFields!.map((field) => (
-              <div className="flex items-center justify-between" key={field}>
-                <span className="text-sm">
-                  {formatMetadataFieldLabel(field)}
-                </span>
-                <Switch
-                  checked={fieldSettings[field] ?? true}
-                  disabled={!canWrite}
-                  onCheckedChange={(checked) =>
-                    handleFieldToggle(field, checked)
-                  }
-                />
+              <div className="space-y-2" key={field}>
+                <div className="flex items-center justify-between">
+                  <span className="text-sm">
+                    {formatMetadataFieldLabel(field)}
+                  </span>
+                  <Switch
+                    checked={fieldSettings[field] ?? true}
+                    disabled={!canWrite}
+                    onCheckedChange={(checked) =>
+                      handleFieldToggle(field, checked)
+                    }
+                  />
+                </div>
+                {data!.preferableFields?.includes(field) && (
+                  <div className="flex items-center justify-between pl-4">
+                    <div>
+                      <span className="text-sm">Prefer over file metadata</span>
+                      <p className="text-xs text-muted-foreground">
+                        Replace covers embedded in files during scans, even
+                        when they're higher resolution. Manual and sidecar
+                        covers are kept.
+                      </p>
+                    </div>
+                    <Switch
+                      aria-label={`Prefer ${formatMetadataFieldLabel(field)}`}
+                      checked={preferredFields[field] ?? false}
+                      disabled={!canWrite || !(fieldSettings[field] ?? true)}
+                      onCheckedChange={(checked) =>
+                        handlePreferToggle(field, checked)
+                      }
+                    />
+                  </div>
+                )}
               </div>
             ))}
           </div>
@@ -21,7 +21,8 @@ const HOOK_TYPES: {
     | "metadataEnricher"
     | "inputConverter"
     | "fileParser"
-    | "outputGenerator";
+    | "outputGenerator"
+    | "organizer";
   label: string;
   value: PluginHookType;
 }[] = [
@@ -41,6 +42,7 @@ const HOOK_TYPES: {
     label: "Output generator",
     value: "outputGenerator",
   },
+  { capabilityKey: "organizer", label: "Organizer", value: "organizer" },
 ];
 
 const modeLabel = (mode: PluginMode | undefined): string => {
@@ -3,6 +3,7 @@ import {
   FileOutput,
   FileSearch,
   FolderOpen,
+  FolderTree,
   Globe,
   Search,
   Terminal,
@@ -61,6 +62,13 @@ export const CAPABILITY_DEFS: CapabilityDef[] = [
         ? `${cap.outputGenerator.sourceTypes.join(", ")} \u2192 ${cap.outputGenerator.name}`
         : undefined,
   },
+  {
+    key: "organizer",
+    icon: FolderTree,
+    label: "File Organization",
+    description: "Chooses where organized files are stored",
+    detail: () => undefined,
+  },
   {
     key: "httpAccess",
     icon: Globe,
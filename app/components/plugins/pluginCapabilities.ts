@@ -13,6 +13,7 @@ export const deriveCapabilityLabels = (
   if (caps.inputConverter) labels.push("Input converter");
   if (caps.fileParser) labels.push("File parser");
   if (caps.outputGenerator) labels.push("Output generator");
+  if (caps.organizer) labels.push("Organizer");
   return labels;
 };
 
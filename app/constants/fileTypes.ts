@@ -3,4 +3,6 @@ export const FILE_TYPE_OPTIONS = [
   { value: "m4b", label: "M4B" },
   { value: "cbz", label: "CBZ" },
   { value: "pdf", label: "PDF" },
+  { value: "mobi", label: "MOBI" },
+  { value: "azw3", label: "AZW3" },
 ] as const;
@@ -246,6 +246,21 @@ export const useMergeBooks = () => {
   });
 };
 
+// Approve a staged book so its files are organized and sidecars written
+export const useApproveBook = () => {
+  const queryClient = useQueryClient();
+
+  return useMutation<Book, ShishoAPIError, number>({
+    mutationFn: (bookId) => {
+      return API.request("POST", `/books/${bookId}/approve`, null, null);
+    },
+    onSuccess: (data: Book) => {
+      queryClient.invalidateQueries({ queryKey: [QueryKey.ListBooks] });
+      queryClient.setQueryData([QueryKey.RetrieveBook, String(data.id)], data);
+    },
+  });
+};
+
 // Delete book mutation
 export const useDeleteBook = () => {
   const queryClient = useQueryClient();
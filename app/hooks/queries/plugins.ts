@@ -446,12 +446,14 @@ export const useSavePluginFieldSettings = () => {
       scope,
       id,
       fields,
+      preferred,
     }: {
       scope: string;
       id: string;
       fields: SetPluginFieldSettingsPayload["fields"];
+      preferred?: SetPluginFieldSettingsPayload["preferred"];
     }) => {
-      const payload: SetPluginFieldSettingsPayload = { fields };
+      const payload: SetPluginFieldSettingsPayload = { fields, preferred };
       return API.request(
         "PUT",
         `/plugins/installed/${scope}/${id}/fields`,
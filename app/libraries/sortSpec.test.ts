@@ -89,7 +89,9 @@ describe("SORT_FIELDS", () => {
       "title",
       "author",
       "series",
+      "reading_order",
       "date_added",
+      "date_updated",
       "date_released",
       "page_count",
       "duration",
@@ -10,7 +10,9 @@ export type SortField =
   | "title"
   | "author"
   | "series"
+  | "reading_order"
   | "date_added"
+  | "date_updated"
   | "date_released"
   | "page_count"
   | "duration";
@@ -25,7 +27,9 @@ export const SORT_FIELDS: readonly SortField[] = [
   "title",
   "author",
   "series",
+  "reading_order",
   "date_added",
+  "date_updated",
   "date_released",
   "page_count",
   "duration",
@@ -36,7 +40,9 @@ export const SORT_FIELD_LABELS: Record<SortField, string> = {
   title: "Title",
   author: "Author",
   series: "Series",
+  reading_order: "Series (reading order)",
   date_added: "Date added",
+  date_updated: "Date updated",
   date_released: "Date released",
   page_count: "Page count",
   duration: "Duration",
@@ -3,7 +3,11 @@ import type { File } from "@/types";
 const isMainFile = (f: File): boolean => f.file_role !== "supplement";
 
 const isBookFile = (f: File): boolean =>
-  f.file_type === "epub" || f.file_type === "cbz" || f.file_type === "pdf";
+  f.file_type === "epub" ||
+  f.file_type === "cbz" ||
+  f.file_type === "pdf" ||
+  f.file_type === "mobi" ||
+  f.file_type === "azw3";
 
 const isAudiobookFile = (f: File): boolean => f.file_type === "m4b";
 
@@ -86,6 +86,7 @@ func main() {
 	// Plugin system
 	pluginService := plugins.NewService(db)
 	pluginManager := plugins.NewManager(pluginService, cfg.PluginDir, cfg.PluginDataDir)
+	pluginManager.SetExecLimits(cfg.PluginExecTimeout, cfg.PluginMaxOutputBytes())
 	if err := pluginManager.LoadAll(ctx); err != nil {
 		log.Warn("plugin load errors occurred", logger.Data{"error": err.Error()})
 	}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/config"
+	"github.com/shishobooks/shisho/pkg/database"
+	"github.com/shishobooks/shisho/pkg/libraries"
+)
+
+const usage = `go run ./cmd/scripts/check-library-paths [--fix]`
+
+func main() {
+	log := logger.New()
+
+	var opts struct {
+		Fix bool `long:"fix" description:"Rewrite library paths stored with trailing slashes or other redundant elements to their clean form"`
+	}
+
+	args, err := flags.Parse(&opts)
+	if err != nil {
+		log.Err(err).Fatal("flags parse error")
+	}
+	if len(args) != 0 {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		log.Err(err).Fatal("config error")
+	}
+
+	db, err := database.New(cfg)
+	if err != nil {
+		log.Err(err).Fatal("database error")
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	libraryService := libraries.NewService(db)
+
+	if opts.Fix {
+		normalized, err := libraryService.NormalizePaths(ctx)
+		if err != nil {
+			log.Err(err).Fatal("normalize error")
+		}
+		for _, lp := range normalized {
+			fmt.Printf("library %d, path %d: normalized to %s\n", lp.LibraryID, lp.ID, lp.Filepath)
+		}
+	}
+
+	problems, err := libraryService.CheckPaths(ctx)
+	if err != nil {
+		log.Err(err).Fatal("check error")
+	}
+	if len(problems) == 0 {
+		fmt.Println("All library paths look good")
+		return
+	}
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	os.Exit(1)
+}
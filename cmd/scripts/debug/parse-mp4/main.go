@@ -13,7 +13,8 @@ func main() {
 	log := logger.New()
 
 	var opts struct {
-		CoverOutput string `short:"o" long:"cover-output" description:"A path to output the cover image"`
+		CoverOutput      string   `short:"o" long:"cover-output" description:"A path to output the cover image"`
+		NarratorFallback []string `short:"n" long:"narrator-fallback" description:"An atom (composer or writer) to read narrators from when there's no narrator atom. Can be repeated"`
 	}
 
 	args, err := flags.Parse(&opts)
@@ -26,7 +27,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	metadata, err := mp4.Parse(args[0])
+	metadata, err := mp4.ParseWithOptions(args[0], mp4.ParseOptions{NarratorFallbackAtoms: opts.NarratorFallback})
 	if err != nil {
 		log.Err(err).Fatal("mp4 parse error")
 	}
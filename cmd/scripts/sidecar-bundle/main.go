@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/sidecar"
+)
+
+const usage = `go run ./cmd/scripts/sidecar-bundle export <library/path> <bundle.tar.gz>
+go run ./cmd/scripts/sidecar-bundle import [--overwrite] <bundle.tar.gz> <library/path>`
+
+func main() {
+	log := logger.New()
+
+	var opts struct {
+		Overwrite bool `long:"overwrite" description:"Replace sidecars that already exist in the library when importing"`
+	}
+
+	args, err := flags.Parse(&opts)
+	if err != nil {
+		log.Err(err).Fatal("flags parse error")
+	}
+
+	if len(args) != 3 {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "export":
+		f, err := os.Create(args[2])
+		if err != nil {
+			log.Err(err).Fatal("create bundle error")
+		}
+		n, err := sidecar.ExportBundle(f, args[1])
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			log.Err(err).Fatal("export error")
+		}
+		fmt.Printf("Exported %d sidecars to %s\n", n, args[2])
+	case "import":
+		f, err := os.Open(args[1])
+		if err != nil {
+			log.Err(err).Fatal("open bundle error")
+		}
+		defer f.Close()
+		result, err := sidecar.ImportBundle(f, args[2], sidecar.ImportBundleOptions{Overwrite: opts.Overwrite})
+		if err != nil {
+			log.Err(err).Fatal("import error")
+		}
+		fmt.Printf("Imported %d sidecars into %s (%d skipped)\n", result.Written, args[2], result.Skipped)
+	default:
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+}
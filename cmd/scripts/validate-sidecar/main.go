@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/sidecar"
+)
+
+const usage = `go run ./cmd/scripts/validate-sidecar <path.metadata.json> [<path.metadata.json> ...]`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+
+	failed := false
+	for _, path := range os.Args[1:] {
+		if err := sidecar.ValidateSidecar(path); err != nil {
+			fmt.Printf("%s: %s\n", path, describe(err))
+			failed = true
+			continue
+		}
+		fmt.Printf("%s: ok\n", path)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// describe returns the problem a sidecar failed validation with, or the
+// error itself when it couldn't be read at all.
+func describe(err error) string {
+	var validationErr *sidecar.ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Problem
+	}
+	return err.Error()
+}
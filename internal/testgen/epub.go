@@ -128,6 +128,10 @@ func generateOPF(opts EPUBOptions, coverFilename, coverMimeType string) string {
 		}
 	}
 
+	if opts.ModifiedDate != "" {
+		buf.WriteString(fmt.Sprintf("    <meta property=\"dcterms:modified\">%s</meta>\n", escapeXML(opts.ModifiedDate)))
+	}
+
 	// Cover reference
 	if coverFilename != "" {
 		buf.WriteString("    <meta name=\"cover\" content=\"cover-image\"/>\n")
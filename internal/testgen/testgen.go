@@ -16,6 +16,7 @@ type EPUBOptions struct {
 	SeriesNumber  *float64
 	HasCover      bool
 	CoverMimeType string // "image/jpeg" or "image/png", defaults to "image/png"
+	ModifiedDate  string // dcterms:modified value, omitted when empty
 }
 
 // CBZOptions configures the generated CBZ file.
@@ -104,6 +104,47 @@ export interface FingerprintContext {
   };
 }
 
+/** Context passed to organizer.organize(). */
+export interface OrganizerContext {
+  /** Absolute path of the library folder the file is in. */
+  libraryPath: string;
+  /** Book metadata. */
+  book: {
+    id: number;
+    title: string;
+    subtitle?: string;
+    description?: string;
+    authors?: Array<{ name: string; role?: string }>;
+    series?: Array<{ name: string; number?: number }>;
+    genres?: string[];
+    tags?: string[];
+  };
+  /** File metadata. */
+  file: {
+    id: number;
+    filepath: string;
+    fileType: string;
+    fileRole: string;
+    filesizeBytes: number;
+    name?: string;
+    url?: string;
+    publisher?: string;
+    releaseDate?: string;
+    narrators?: string[];
+    identifiers?: Array<{ type: string; value: string }>;
+  };
+}
+
+/** Result returned from organizer.organize(). */
+export interface OrganizerResult {
+  /**
+   * Where the file should go, relative to the library path, with "/"
+   * separators. Must include at least one folder. The file's extension is
+   * appended if the path doesn't already end with it.
+   */
+  path: string;
+}
+
 /** Input converter hook. */
 export interface InputConverterHook {
   convert(context: InputConverterContext): ConvertResult;
@@ -126,12 +167,19 @@ export interface OutputGeneratorHook {
   fingerprint(context: FingerprintContext): string;
 }
 
+/** Organizer hook. */
+export interface OrganizerHook {
+  /** Return where a file should go, or null to use the built-in naming. */
+  organize(context: OrganizerContext): OrganizerResult | null;
+}
+
 /** The plugin object exported by main.js via IIFE. */
 export interface ShishoPlugin {
   inputConverter?: InputConverterHook;
   fileParser?: FileParserHook;
   metadataEnricher?: MetadataEnricherHook;
   outputGenerator?: OutputGeneratorHook;
+  organizer?: OrganizerHook;
 
   /**
    * Optional lifecycle hook called before the plugin is uninstalled.
@@ -16,6 +16,13 @@ export interface FileParserCap {
   types: string[];
   /** MIME types this parser handles (e.g., ["application/pdf"]). */
   mimeTypes?: string[];
+  /**
+   * Hex-encoded prefixes a file may start with instead of having one of
+   * `mimeTypes` (e.g., ["52617221"] for RAR archives).
+   */
+  magicBytes?: string[];
+  /** Hand every file with one of `types` to the parser without checking its contents. */
+  skipMimeCheck?: boolean;
 }
 
 /** Output generator capability declaration. */
@@ -60,6 +67,11 @@ export interface MetadataEnricherCap {
   fields: MetadataField[];
 }
 
+/** Organizer capability declaration. */
+export interface OrganizerCap {
+  description?: string;
+}
+
 /** Custom identifier type declaration. */
 export interface IdentifierTypeCap {
   /** Unique identifier type ID (e.g., "goodreads"). */
@@ -104,6 +116,7 @@ export interface Capabilities {
   fileParser?: FileParserCap;
   outputGenerator?: OutputGeneratorCap;
   metadataEnricher?: MetadataEnricherCap;
+  organizer?: OrganizerCap;
   identifierTypes?: IdentifierTypeCap[];
   httpAccess?: HTTPAccessCap;
   fileAccess?: FileAccessCap;
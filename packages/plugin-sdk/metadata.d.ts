@@ -28,6 +28,18 @@ export interface ParsedChapter {
   children?: ParsedChapter[];
 }
 
+/** A series membership after the primary `series`, such as a story arc. */
+export interface ParsedSeries {
+  /** Series name. */
+  name: string;
+  /** Position in the series. */
+  number?: number;
+  /** Whether the number refers to a volume or a chapter. CBZ only. */
+  unit?: "volume" | "chapter";
+  /** Position in the series' reading order, when it differs from `number`. */
+  readingOrder?: number;
+}
+
 /** Full metadata object returned by file parsers and metadata enrichers. */
 export interface ParsedMetadata {
   title?: string;
@@ -38,6 +50,13 @@ export interface ParsedMetadata {
   seriesNumber?: number;
   /** Whether the series number refers to a volume or a chapter. CBZ only. */
   seriesNumberUnit?: "volume" | "chapter";
+  /**
+   * Position in the series' reading order, when it differs from publication
+   * order (`seriesNumber`). Leave unset when they're the same.
+   */
+  seriesReadingOrder?: number;
+  /** Extra series after `series`, in order. Ignored unless `series` is set. */
+  additionalSeries?: ParsedSeries[];
   genres?: string[];
   tags?: string[];
   description?: string;
@@ -24,6 +24,57 @@ type ResourceConfig struct {
 	ResourceTable string
 }
 
+// NormalizeName trims a resource name and collapses runs of whitespace into
+// single spaces, so names that only differ in spacing resolve to the same
+// resource.
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// MatchKey reduces a name to the key used to spot spelling variants of a
+// genre or tag: ASCII letters are lowercased and spaces, hyphens and
+// underscores are dropped, so "Sci-Fi", "sci fi" and "SciFi" share a key. It
+// mirrors MatchKeySQL, which is why only ASCII is lowercased (SQLite's LOWER
+// does the same).
+func MatchKey(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	for _, r := range name {
+		switch {
+		case r == ' ' || r == '-' || r == '_':
+			continue
+		case r >= 'A' && r <= 'Z':
+			b.WriteRune(r + ('a' - 'A'))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// MatchKeySQL returns the SQL expression that computes MatchKey for column.
+// It must stay in sync with the expression indexes on genres and tags.
+func MatchKeySQL(column string) string {
+	return "REPLACE(REPLACE(REPLACE(LOWER(" + column + "), ' ', ''), '-', ''), '_', '')"
+}
+
+// FindResourceIDByMatchKey looks up the oldest resource in the library whose
+// name has the same MatchKey as name. Returns sql.ErrNoRows if none does.
+func FindResourceIDByMatchKey(ctx context.Context, db bun.IDB, cfg ResourceConfig, name string, libraryID int) (int, error) {
+	var resourceID int
+	err := db.NewSelect().
+		TableExpr(cfg.ResourceTable).
+		Column("id").
+		Where("library_id = ? AND "+MatchKeySQL("name")+" = ?", libraryID, MatchKey(name)).
+		Order("id ASC").
+		Limit(1).
+		Scan(ctx, &resourceID)
+	if err != nil {
+		return 0, err
+	}
+	return resourceID, nil
+}
+
 // FindResourceIDByAlias looks up a resource ID by checking alias names (case-insensitive).
 // Returns the resource ID if found, or sql.ErrNoRows if no alias matches.
 func FindResourceIDByAlias(ctx context.Context, db bun.IDB, cfg ResourceConfig, name string, libraryID int) (int, error) {
@@ -417,3 +417,12 @@ func TestAddAlias_ReturnsValidationError(t *testing.T) {
 	require.ErrorAs(t, err, &validationErr)
 	assert.Equal(t, 422, validationErr.HTTPCode)
 }
+
+func TestMatchKey(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "scifi", MatchKey("Sci-Fi"))
+	assert.Equal(t, "scifi", MatchKey("sci fi"))
+	assert.Equal(t, "scifi", MatchKey("SCI_FI"))
+	assert.Equal(t, "c++", MatchKey("C++"))
+	assert.Equal(t, "Éclair", MatchKey("Éclair"), "only ASCII is lowercased, matching SQLite")
+}
@@ -0,0 +1,225 @@
+package books
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/aliases"
+	"github.com/shishobooks/shisho/pkg/genres"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/sidecar"
+	"github.com/shishobooks/shisho/pkg/tags"
+	"github.com/uptrace/bun"
+)
+
+// BulkUpdateResult is the outcome of a bulk tag or genre update for one book.
+type BulkUpdateResult struct {
+	BookID  int    `json:"book_id"`
+	Success bool   `json:"success"`
+	Changed bool   `json:"changed"`
+	Error   string `json:"error,omitempty"`
+
+	// book is the reloaded book when Changed is set, so callers can re-index
+	// it without another query.
+	book *models.Book
+}
+
+// bulkAssociation describes one of the book-to-name associations (tags or
+// genres) that BulkUpdateTags and BulkUpdateGenres edit.
+type bulkAssociation struct {
+	table        string // Join table, e.g. "book_tags"
+	idColumn     string // Join table column referencing the tag or genre
+	sourceColumn string // Books column tracking the field's data source
+	// current returns the book's existing associations as ID → name.
+	current func(book *models.Book) map[int]string
+	// findOrCreate returns the ID of the canonical tag or genre for name.
+	findOrCreate func(ctx context.Context, name string, libraryID int) (int, error)
+}
+
+// BulkUpdateTags adds and removes tags across many books. Each book's changes
+// are applied in their own transaction and recorded at manual priority, so
+// one failing book doesn't block the rest. Names are matched
+// case-insensitively; a name in both add and remove is removed. Changed books
+// get their sidecars rewritten (unless staged), metadata hash refreshed and
+// review state recomputed. Search indexing is left to the caller.
+func (svc *Service) BulkUpdateTags(ctx context.Context, bookIDs []int, add, remove []string) ([]*BulkUpdateResult, error) {
+	tagService := tags.NewService(svc.db)
+	return svc.bulkUpdateAssociations(ctx, bookIDs, add, remove, bulkAssociation{
+		table:        "book_tags",
+		idColumn:     "tag_id",
+		sourceColumn: "tag_source",
+		current: func(book *models.Book) map[int]string {
+			m := make(map[int]string, len(book.BookTags))
+			for _, bt := range book.BookTags {
+				if bt.Tag != nil {
+					m[bt.TagID] = bt.Tag.Name
+				}
+			}
+			return m
+		},
+		findOrCreate: func(ctx context.Context, name string, libraryID int) (int, error) {
+			tag, err := tagService.FindOrCreateTag(ctx, name, libraryID)
+			if err != nil {
+				return 0, err
+			}
+			return tag.ID, nil
+		},
+	})
+}
+
+// BulkUpdateGenres is BulkUpdateTags for genres.
+func (svc *Service) BulkUpdateGenres(ctx context.Context, bookIDs []int, add, remove []string) ([]*BulkUpdateResult, error) {
+	genreService := genres.NewService(svc.db)
+	return svc.bulkUpdateAssociations(ctx, bookIDs, add, remove, bulkAssociation{
+		table:        "book_genres",
+		idColumn:     "genre_id",
+		sourceColumn: "genre_source",
+		current: func(book *models.Book) map[int]string {
+			m := make(map[int]string, len(book.BookGenres))
+			for _, bg := range book.BookGenres {
+				if bg.Genre != nil {
+					m[bg.GenreID] = bg.Genre.Name
+				}
+			}
+			return m
+		},
+		findOrCreate: func(ctx context.Context, name string, libraryID int) (int, error) {
+			genre, err := genreService.FindOrCreateGenre(ctx, name, libraryID)
+			if err != nil {
+				return 0, err
+			}
+			return genre.ID, nil
+		},
+	})
+}
+
+func (svc *Service) bulkUpdateAssociations(ctx context.Context, bookIDs []int, add, remove []string, assoc bulkAssociation) ([]*BulkUpdateResult, error) {
+	removeSet := make(map[string]struct{}, len(remove))
+	for _, name := range remove {
+		if name = aliases.NormalizeName(name); name != "" {
+			removeSet[strings.ToLower(name)] = struct{}{}
+		}
+	}
+	var addNames []string
+	for _, name := range add {
+		name = aliases.NormalizeName(name)
+		if _, removed := removeSet[strings.ToLower(name)]; name != "" && !removed {
+			addNames = append(addNames, name)
+		}
+	}
+
+	results := make([]*BulkUpdateResult, 0, len(bookIDs))
+	for _, bookID := range bookIDs {
+		result := &BulkUpdateResult{BookID: bookID}
+		results = append(results, result)
+
+		changed, err := svc.bulkUpdateBook(ctx, bookID, addNames, removeSet, assoc)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil, err
+			}
+			result.Error = err.Error()
+			continue
+		}
+		result.Success = true
+		result.Changed = changed
+		if !changed {
+			continue
+		}
+
+		book, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &bookID})
+		if err != nil {
+			return nil, err
+		}
+		result.book = book
+		svc.afterBulkUpdate(ctx, book)
+	}
+	return results, nil
+}
+
+// bulkUpdateBook applies the additions and removals to one book and reports
+// whether anything changed.
+func (svc *Service) bulkUpdateBook(ctx context.Context, bookID int, addNames []string, removeSet map[string]struct{}, assoc bulkAssociation) (bool, error) {
+	book, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &bookID})
+	if err != nil {
+		return false, err
+	}
+	existing := assoc.current(book)
+
+	var removeIDs []int
+	for id, name := range existing {
+		if _, ok := removeSet[strings.ToLower(name)]; ok {
+			removeIDs = append(removeIDs, id)
+		}
+	}
+	// Resolving names may create tags or genres outside the transaction. If
+	// the book's transaction then fails they're left unused and removed by
+	// the next orphan cleanup.
+	var addIDs []int
+	seen := make(map[int]bool, len(addNames))
+	for _, name := range addNames {
+		id, err := assoc.findOrCreate(ctx, name, book.LibraryID)
+		if err != nil {
+			return false, err
+		}
+		if _, ok := existing[id]; ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		addIDs = append(addIDs, id)
+	}
+	if len(addIDs) == 0 && len(removeIDs) == 0 {
+		return false, nil
+	}
+
+	err = svc.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if len(removeIDs) > 0 {
+			_, err := tx.NewDelete().
+				TableExpr("?", bun.Ident(assoc.table)).
+				Where("book_id = ?", bookID).
+				Where("? IN (?)", bun.Ident(assoc.idColumn), bun.In(removeIDs)).
+				Exec(ctx)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		for _, id := range addIDs {
+			_, err := tx.NewRaw("INSERT INTO ? (book_id, ?) VALUES (?, ?)",
+				bun.Ident(assoc.table), bun.Ident(assoc.idColumn), bookID, id).
+				Exec(ctx)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		_, err := tx.NewUpdate().
+			Model((*models.Book)(nil)).
+			Set("? = ?", bun.Ident(assoc.sourceColumn), models.DataSourceManual).
+			Set("updated_at = ?", time.Now()).
+			Where("id = ?", bookID).
+			Exec(ctx)
+		return errors.WithStack(err)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// afterBulkUpdate keeps a changed book's sidecars, metadata hash and review
+// state in sync. Failures are logged since the update itself succeeded.
+func (svc *Service) afterBulkUpdate(ctx context.Context, book *models.Book) {
+	log := logger.FromContext(ctx)
+	if !book.Staged {
+		if err := sidecar.WriteBookSidecarFromModel(book); err != nil {
+			log.Warn("failed to write book sidecar", logger.Data{"book_id": book.ID, "error": err.Error()})
+		}
+	}
+	if err := svc.RefreshMetadataHash(ctx, book); err != nil {
+		log.Warn("failed to update book metadata hash", logger.Data{"book_id": book.ID, "error": err.Error()})
+	}
+	svc.RecomputeReviewedForBook(ctx, book.ID)
+}
@@ -0,0 +1,92 @@
+package books
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bookTagNames(t *testing.T, svc *Service, bookID int) []string {
+	t.Helper()
+	book, err := svc.RetrieveBook(context.Background(), RetrieveBookOptions{ID: &bookID})
+	require.NoError(t, err)
+	names := make([]string, 0, len(book.BookTags))
+	for _, bt := range book.BookTags {
+		names = append(names, bt.Tag.Name)
+	}
+	return names
+}
+
+func TestService_BulkUpdateTags(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	library, book := setupTestLibraryAndBook(t, db)
+	old, err := tags.NewService(db).FindOrCreateTag(ctx, "Old", library.ID)
+	require.NoError(t, err)
+	require.NoError(t, svc.CreateBookTag(ctx, &models.BookTag{BookID: book.ID, TagID: old.ID}))
+
+	results, err := svc.BulkUpdateTags(ctx, []int{book.ID, 999999}, []string{"Fantasy", " fantasy ", "Epic"}, []string{"old"})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, book.ID, results[0].BookID)
+	assert.True(t, results[0].Success)
+	assert.True(t, results[0].Changed)
+	assert.ElementsMatch(t, []string{"Fantasy", "Epic"}, bookTagNames(t, svc, book.ID))
+
+	assert.Equal(t, 999999, results[1].BookID)
+	assert.False(t, results[1].Success)
+	assert.NotEmpty(t, results[1].Error)
+
+	updated, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &book.ID})
+	require.NoError(t, err)
+	require.NotNil(t, updated.TagSource)
+	assert.Equal(t, models.DataSourceManual, *updated.TagSource)
+}
+
+func TestService_BulkUpdateTags_NoChange(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	_, book := setupTestLibraryAndBook(t, db)
+
+	results, err := svc.BulkUpdateTags(ctx, []int{book.ID}, nil, []string{"Missing"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Success)
+	assert.False(t, results[0].Changed)
+
+	updated, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &book.ID})
+	require.NoError(t, err)
+	assert.Nil(t, updated.TagSource)
+}
+
+func TestService_BulkUpdateGenres(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	_, book := setupTestLibraryAndBook(t, db)
+
+	results, err := svc.BulkUpdateGenres(ctx, []int{book.ID}, []string{"Mystery"}, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Changed)
+
+	updated, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &book.ID})
+	require.NoError(t, err)
+	require.Len(t, updated.BookGenres, 1)
+	assert.Equal(t, "Mystery", updated.BookGenres[0].Genre.Name)
+	require.NotNil(t, updated.GenreSource)
+	assert.Equal(t, models.DataSourceManual, *updated.GenreSource)
+}
@@ -0,0 +1,54 @@
+package books
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/fileutils"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// RevertCover restores the embedded cover kept for a file when another cover
+// replaced it (see config.KeepOriginalCover). The current cover is deleted,
+// the original takes its place, and the cover source goes back to the file's
+// embedded metadata.
+func (svc *Service) RevertCover(ctx context.Context, fileID int) (*models.File, error) {
+	file, err := svc.RetrieveFile(ctx, RetrieveFileOptions{ID: &fileID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Covers always live next to the file.
+	coverDir := filepath.Dir(file.Filepath)
+	originalPath := fileutils.CoverExistsWithBaseName(coverDir, fileutils.OriginalCoverBaseName(file.Filepath))
+	if originalPath == "" {
+		return nil, errcodes.NotFound("Original cover")
+	}
+
+	coverBaseName := filepath.Base(file.Filepath) + ".cover"
+	for _, ext := range fileutils.CoverImageExtensions {
+		if err := os.Remove(filepath.Join(coverDir, coverBaseName+ext)); err != nil && !os.IsNotExist(err) {
+			return nil, errors.WithStack(err)
+		}
+	}
+	ext := filepath.Ext(originalPath)
+	coverFilename := coverBaseName + ext
+	if err := os.Rename(originalPath, filepath.Join(coverDir, coverFilename)); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	mimeType := fileutils.MimeTypeFromExtension(ext)
+	source := models.EmbeddedDataSource(file.FileType)
+	file.CoverImageFilename = &coverFilename
+	file.CoverMimeType = &mimeType
+	file.CoverSource = &source
+	if err := svc.UpdateFile(ctx, file, UpdateFileOptions{
+		Columns: []string{"cover_image_filename", "cover_mime_type", "cover_source"},
+	}); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
@@ -0,0 +1,45 @@
+package books
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// BookCursor is a position in a book listing ordered by sort title and then
+// id. Cursor pagination resumes after it instead of skipping an offset, so
+// deep pages cost the same as the first one.
+type BookCursor struct {
+	SortTitle string `json:"t"`
+	ID        int    `json:"i"`
+}
+
+// BookCursorAfter returns the cursor for the page following book.
+func BookCursorAfter(book *models.Book) BookCursor {
+	return BookCursor{SortTitle: book.SortTitle, ID: book.ID}
+}
+
+// Encode returns the opaque string form of the cursor used in the API.
+func (c BookCursor) Encode() string {
+	// Marshaling a struct of a string and an int can't fail.
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParseBookCursor decodes a cursor produced by Encode.
+func ParseBookCursor(s string) (*BookCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var c BookCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if c.ID < 1 {
+		return nil, errors.New("cursor is missing a book id")
+	}
+	return &c, nil
+}
@@ -0,0 +1,22 @@
+package books
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookCursor_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	want := BookCursor{SortTitle: "Name of the Wind, The", ID: 42}
+	got, err := ParseBookCursor(want.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, want, *got)
+
+	for _, bad := range []string{"not-a-cursor!", "e30", "bnVsbA"} {
+		_, err := ParseBookCursor(bad)
+		assert.Error(t, err, bad)
+	}
+}
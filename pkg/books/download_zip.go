@@ -0,0 +1,94 @@
+package books
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/fileutils"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// bookZipFiles returns the files of book that go into its zip download: the
+// main files, followed by the supplements when includeSupplements is set.
+// Files missing from disk are left out.
+func bookZipFiles(book *models.Book, includeSupplements bool) []*models.File {
+	var mains, supplements []*models.File
+	for _, f := range book.Files {
+		if _, err := os.Stat(f.Filepath); err != nil {
+			continue
+		}
+		if f.FileRole == models.FileRoleSupplement {
+			supplements = append(supplements, f)
+		} else {
+			mains = append(mains, f)
+		}
+	}
+	if includeSupplements {
+		return append(mains, supplements...)
+	}
+	return mains
+}
+
+// bookZipFilename returns the download filename for a book's zip, derived
+// from its title.
+func bookZipFilename(book *models.Book) string {
+	name := fileutils.SanitizeFilename(book.Title, fileutils.SanitizeOptions{})
+	if name == "" {
+		name = fmt.Sprintf("book-%d", book.ID)
+	}
+	return name + ".zip"
+}
+
+// writeBookZip streams files into a zip archive written to w. Each file is
+// copied straight from disk into its entry, so the archive is never held in
+// memory. Entries are stored uncompressed since ebook and audiobook formats
+// are already compressed. Files sharing a name get a " (2)", " (3)", ...
+// suffix.
+func writeBookZip(w io.Writer, files []*models.File) error {
+	zw := zip.NewWriter(w)
+	used := make(map[string]int, len(files))
+	for _, f := range files {
+		name := filepath.Base(f.Filepath)
+		used[name]++
+		if n := used[name]; n > 1 {
+			ext := filepath.Ext(name)
+			name = fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), n, ext)
+		}
+		if err := addFileToZip(zw, f.Filepath, name); err != nil {
+			return err
+		}
+	}
+	return errors.Wrap(zw.Close(), "failed to finalize zip")
+}
+
+// addFileToZip copies the file at path into a new zip entry called name.
+func addFileToZip(zw *zip.Writer, path, name string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	header := &zip.FileHeader{
+		Name:     name,
+		Method:   zip.Store,
+		Modified: info.ModTime(),
+	}
+	dst, err := zw.CreateHeader(header)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create zip entry for %s", name)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		return errors.Wrapf(err, "failed to write %s to zip", name)
+	}
+	return nil
+}
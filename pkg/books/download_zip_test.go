@@ -0,0 +1,60 @@
+package books
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBookZip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile := func(sub, name, content string) string {
+		path := filepath.Join(dir, sub, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	book := &models.Book{
+		ID:    1,
+		Title: "Wind and Truth: Part 1/2",
+		Files: []*models.File{
+			{Filepath: writeFile("a", "book.epub", "epub"), FileRole: models.FileRoleMain},
+			{Filepath: writeFile("a", "guide.pdf", "guide"), FileRole: models.FileRoleSupplement},
+			{Filepath: writeFile("b", "book.epub", "other epub"), FileRole: models.FileRoleMain},
+			{Filepath: filepath.Join(dir, "missing.m4b"), FileRole: models.FileRoleMain},
+		},
+	}
+
+	assert.Equal(t, "Wind and Truth Part 12.zip", bookZipFilename(book))
+	assert.Len(t, bookZipFiles(book, false), 2, "supplements and missing files are left out")
+
+	var buf bytes.Buffer
+	require.NoError(t, writeBookZip(&buf, bookZipFiles(book, true)))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	contents := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		contents[f.Name] = string(data)
+	}
+	assert.Equal(t, map[string]string{
+		"book.epub":     "epub",
+		"book (2).epub": "other epub",
+		"guide.pdf":     "guide",
+	}, contents)
+}
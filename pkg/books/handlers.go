@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -19,6 +20,7 @@ import (
 	"github.com/shishobooks/shisho/pkg/config"
 	"github.com/shishobooks/shisho/pkg/covers"
 	"github.com/shishobooks/shisho/pkg/downloadcache"
+	"github.com/shishobooks/shisho/pkg/epub"
 	"github.com/shishobooks/shisho/pkg/errcodes"
 	"github.com/shishobooks/shisho/pkg/filegen"
 	"github.com/shishobooks/shisho/pkg/fileutils"
@@ -43,13 +45,15 @@ import (
 )
 
 // ScanOptions configures a scan operation.
-// Entry points are mutually exclusive - exactly one of FileID or BookID must be set.
+// Entry points are mutually exclusive - exactly one of FilePath, FileID, or BookID must be set.
 type ScanOptions struct {
-	FileID       int  // Single file resync: file already in DB
-	BookID       int  // Book resync: scan all files in book
-	ForceRefresh bool // Bypass priority checks, overwrite all metadata
-	SkipPlugins  bool // Skip enricher plugins, use only file-embedded metadata
-	Reset        bool // Wipe all metadata before scanning (reset to file-only state)
+	FilePath     string // New file: discover/create by path (requires LibraryID)
+	FileID       int    // Single file resync: file already in DB
+	BookID       int    // Book resync: scan all files in book
+	LibraryID    int    // Library the FilePath belongs to
+	ForceRefresh bool   // Bypass priority checks, overwrite all metadata
+	SkipPlugins  bool   // Skip enricher plugins, use only file-embedded metadata
+	Reset        bool   // Wipe all metadata before scanning (reset to file-only state)
 }
 
 // ScanResult contains the results of a scan operation.
@@ -64,6 +68,12 @@ type ScanResult struct {
 // This interface is implemented by the worker package to avoid circular dependencies.
 type Scanner interface {
 	Scan(ctx context.Context, opts ScanOptions) (*ScanResult, error)
+	// PreviewMetadata parses the file at path and runs metadata enrichers on
+	// it without creating any records.
+	PreviewMetadata(ctx context.Context, libraryID int, path string) (*mediafile.ParsedMetadata, error)
+	// ParseMetadata parses the metadata embedded in the file at path, without
+	// running enrichers or creating any records.
+	ParseMetadata(ctx context.Context, path string) (*mediafile.ParsedMetadata, error)
 }
 
 type handler struct {
@@ -82,6 +92,7 @@ type handler struct {
 	pageCache          *cbzpages.Cache
 	pdfPageCache       *pdfpages.Cache
 	scanner            Scanner
+	importer           *Importer
 	pluginManager      pluginManager
 }
 
@@ -158,11 +169,32 @@ func (h *handler) list(c echo.Context) error {
 		reviewedFilter = ""
 	}
 
+	// Cursor pagination has a fixed order, so it can't be combined with a
+	// sort or an offset.
+	var after *BookCursor
+	keyset := params.Cursor != nil
+	if keyset {
+		if params.Sort != "" {
+			return errcodes.ValidationError("cursor can't be combined with sort")
+		}
+		if params.Offset > 0 {
+			return errcodes.ValidationError("cursor can't be combined with offset")
+		}
+		if *params.Cursor != "" {
+			parsed, err := ParseBookCursor(*params.Cursor)
+			if err != nil {
+				return errcodes.ValidationError("cursor is invalid")
+			}
+			after = parsed
+		}
+	}
+
 	opts := ListBooksOptions{
 		Limit:          &params.Limit,
 		Offset:         &params.Offset,
 		LibraryID:      params.LibraryID,
 		SeriesID:       params.SeriesID,
+		SeriesOrder:    params.SeriesOrder,
 		Search:         params.Search,
 		FileTypes:      params.FileTypes,
 		GenreIDs:       params.GenreIDs,
@@ -170,6 +202,15 @@ func (h *handler) list(c echo.Context) error {
 		Language:       languageFilter,
 		IDs:            params.IDs,
 		ReviewedFilter: reviewedFilter,
+		IncludeHidden:  params.IncludeHidden,
+		Keyset:         keyset,
+		After:          after,
+	}
+	if keyset {
+		// Fetch one extra book to tell whether there's a next page.
+		limit := params.Limit + 1
+		opts.Limit = &limit
+		opts.Offset = nil
 	}
 
 	// Filter by user's library access if user is in context.
@@ -185,9 +226,12 @@ func (h *handler) list(c echo.Context) error {
 	// preference, then nil (service applies its hard-coded default). The
 	// resolver is only consulted when scoped to a single library — there is
 	// no natural "stored sort" for a multi-library or all-libraries listing.
-	if user != nil && params.LibraryID != nil {
+	// Cursor pagination ignores stored preferences since its order is fixed.
+	switch {
+	case keyset:
+	case user != nil && params.LibraryID != nil:
 		opts.Sort = sortspec.ResolveForLibrary(ctx, h.settingsService, user.ID, *params.LibraryID, explicitSort)
-	} else {
+	default:
 		opts.Sort = explicitSort
 	}
 
@@ -196,6 +240,12 @@ func (h *handler) list(c echo.Context) error {
 		return errors.WithStack(err)
 	}
 
+	var nextCursor string
+	if keyset && len(books) > params.Limit {
+		books = books[:params.Limit]
+		nextCursor = BookCursorAfter(books[len(books)-1]).Encode()
+	}
+
 	for _, b := range books {
 		aspectRatio := ""
 		if b.Library != nil {
@@ -204,7 +254,7 @@ func (h *handler) list(c echo.Context) error {
 		b.CoverCacheKey = covers.CacheKey(b.Files, aspectRatio)
 	}
 
-	resp := ListBooksResponse{Items: books, Total: total}
+	resp := ListBooksResponse{Items: books, Total: total, NextCursor: nextCursor}
 
 	return errors.WithStack(c.JSON(http.StatusOK, resp))
 }
@@ -343,6 +393,12 @@ func (h *handler) update(c echo.Context) error {
 		}
 	}
 
+	// Update hidden flag
+	if params.Hidden != nil && *params.Hidden != book.Hidden {
+		book.Hidden = *params.Hidden
+		opts.Columns = append(opts.Columns, "hidden")
+	}
+
 	// Update authors
 	if params.Authors != nil {
 		authorsChanged = true
@@ -440,6 +496,7 @@ func (h *handler) update(c echo.Context) error {
 				SeriesNumber:     seriesInput.Number,
 				SeriesNumberEnd:  seriesInput.NumberEnd,
 				SeriesNumberUnit: seriesInput.SeriesNumberUnit,
+				ReadingOrder:     seriesInput.ReadingOrder,
 				SortOrder:        i + 1,
 			}
 			if err := h.bookService.CreateBookSeries(ctx, bookSeries); err != nil {
@@ -631,17 +688,24 @@ func (h *handler) update(c echo.Context) error {
 		}
 	}
 
-	// Write sidecar files to keep them in sync with the database
-	if err := sidecar.WriteBookSidecarFromModel(book); err != nil {
-		log.Warn("failed to write book sidecar", logger.Data{"error": err.Error()})
-	}
-	// Also write file sidecars for all files in the book
-	for _, file := range book.Files {
-		if err := sidecar.WriteFileSidecarFromModel(file); err != nil {
-			log.Warn("failed to write file sidecar", logger.Data{"file_id": file.ID, "error": err.Error()})
+	// Write sidecar files to keep them in sync with the database. Staged books
+	// get theirs written when they're approved.
+	if !book.Staged {
+		if err := sidecar.WriteBookSidecarFromModel(book); err != nil {
+			log.Warn("failed to write book sidecar", logger.Data{"error": err.Error()})
+		}
+		// Also write file sidecars for all files in the book
+		for _, file := range book.Files {
+			if err := sidecar.WriteFileSidecarFromModel(file); err != nil {
+				log.Warn("failed to write file sidecar", logger.Data{"file_id": file.ID, "error": err.Error()})
+			}
 		}
 	}
 
+	if err := h.bookService.RefreshMetadataHash(ctx, book); err != nil {
+		log.Warn("failed to update book metadata hash", logger.Data{"book_id": book.ID, "error": err.Error()})
+	}
+
 	// Update FTS index for this book
 	if err := h.searchService.IndexBook(ctx, book); err != nil {
 		log.Warn("failed to update search index for book", logger.Data{"book_id": book.ID, "error": err.Error()})
@@ -751,7 +815,7 @@ func (h *handler) updateFile(c echo.Context) error {
 		}
 	}
 
-	// Get the library to check OrganizeFileStructure
+	// Get the library to check OrganizeFiles
 	library, err := h.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{
 		ID: &file.LibraryID,
 	})
@@ -782,6 +846,8 @@ func (h *handler) updateFile(c echo.Context) error {
 				models.FileTypeEPUB: true,
 				models.FileTypeM4B:  true,
 				models.FileTypePDF:  true,
+				models.FileTypeMOBI: true,
+				models.FileTypeAZW3: true,
 			}
 			if !supportedTypes[file.FileType] {
 				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("cannot upgrade to main file: file type '%s' is not supported as a main file", file.FileType))
@@ -803,6 +869,12 @@ func (h *handler) updateFile(c echo.Context) error {
 					})
 				}
 			}
+			if err := fileutils.RemoveOriginalCover(filepath.Dir(file.Filepath), file.Filepath); err != nil {
+				log.Warn("failed to delete original cover on downgrade", logger.Data{
+					"error":   err.Error(),
+					"file_id": file.ID,
+				})
+			}
 
 			// Clear cover fields
 			file.CoverImageFilename = nil
@@ -865,7 +937,12 @@ func (h *handler) updateFile(c echo.Context) error {
 		}
 
 		file.FileRole = newRole
-		opts.Columns = append(opts.Columns, "file_role")
+		file.SupplementKind = nil
+		if newRole == models.FileRoleSupplement {
+			kind := h.config.SupplementKind(file.FileType)
+			file.SupplementKind = &kind
+		}
+		opts.Columns = append(opts.Columns, "file_role", "supplement_kind")
 	}
 
 	// narratorNames tracks the authoritative narrator list for this file —
@@ -889,6 +966,13 @@ func (h *handler) updateFile(c echo.Context) error {
 		file.NarratorSource = strPtr(models.DataSourceManual)
 		opts.Columns = append(opts.Columns, "narrator_source")
 
+		// Narrators are edited by name, so keep the roles of the ones that
+		// stay.
+		existingRoles := make(map[int]*string, len(file.Narrators))
+		for _, n := range file.Narrators {
+			existingRoles[n.PersonID] = n.Role
+		}
+
 		// Delete existing narrator associations
 		if _, err := h.bookService.DeleteNarratorsForFile(ctx, file.ID); err != nil {
 			return errors.WithStack(err)
@@ -909,6 +993,7 @@ func (h *handler) updateFile(c echo.Context) error {
 				FileID:    file.ID,
 				PersonID:  person.ID,
 				SortOrder: i + 1,
+				Role:      existingRoles[person.ID],
 			}
 			if err := h.bookService.CreateNarrator(ctx, narrator); err != nil {
 				log.Error("failed to create narrator", logger.Data{"file_id": file.ID, "person_id": person.ID, "error": err.Error()})
@@ -916,9 +1001,9 @@ func (h *handler) updateFile(c echo.Context) error {
 			narratorNames = append(narratorNames, narratorName)
 		}
 
-		// For M4B files with OrganizeFileStructure enabled, reorganize so the
-		// new narrator appears in the filename/path.
-		if file.FileType == models.FileTypeM4B && library.OrganizeFileStructure && len(narratorNames) > 0 {
+		// For M4B files with OrganizeFiles enabled, reorganize so the new
+		// narrator appears in the filename/path.
+		if file.FileType == models.FileTypeM4B && library.OrganizeFiles && len(narratorNames) > 0 {
 			file = h.reorganizeFileAfterMetadataChange(ctx, library, book, file, narratorNames, &opts)
 		}
 	}
@@ -943,8 +1028,8 @@ func (h *handler) updateFile(c echo.Context) error {
 		}
 	}
 
-	// Reorganize file if name changed and library has OrganizeFileStructure enabled
-	if nameChanged && library.OrganizeFileStructure {
+	// Reorganize file if name changed and library has OrganizeFiles enabled
+	if nameChanged && library.OrganizeFiles {
 		// narratorNames is already authoritative — either set from the new
 		// narrator request above or populated from the pre-update in-memory
 		// relation (which is still current if narrators weren't touched).
@@ -1136,11 +1221,11 @@ func (h *handler) updateFile(c echo.Context) error {
 				return echo.NewHTTPError(http.StatusBadRequest, "cannot set preferred cover: file has no cover image")
 			}
 			// Clear is_preferred_cover on other files of the same type category
-			// in the same book. EPUB/CBZ/PDF = ebook, M4B = audiobook.
+			// in the same book. EPUB/CBZ/PDF/MOBI/AZW3 = ebook, M4B = audiobook.
 			var sameCategory []string
 			switch file.FileType {
-			case models.FileTypeEPUB, models.FileTypeCBZ, models.FileTypePDF:
-				sameCategory = []string{models.FileTypeEPUB, models.FileTypeCBZ, models.FileTypePDF}
+			case models.FileTypeEPUB, models.FileTypeCBZ, models.FileTypePDF, models.FileTypeMOBI, models.FileTypeAZW3:
+				sameCategory = []string{models.FileTypeEPUB, models.FileTypeCBZ, models.FileTypePDF, models.FileTypeMOBI, models.FileTypeAZW3}
 			case models.FileTypeM4B:
 				sameCategory = []string{models.FileTypeM4B}
 			}
@@ -1163,6 +1248,12 @@ func (h *handler) updateFile(c echo.Context) error {
 		opts.Columns = append(opts.Columns, "is_preferred_cover")
 	}
 
+	// Update hidden flag
+	if params.Hidden != nil && *params.Hidden != file.Hidden {
+		file.Hidden = *params.Hidden
+		opts.Columns = append(opts.Columns, "hidden")
+	}
+
 	// Update the file
 	if err := h.bookService.UpdateFile(ctx, file, opts); err != nil {
 		return errors.WithStack(err)
@@ -1174,16 +1265,23 @@ func (h *handler) updateFile(c echo.Context) error {
 		return errors.WithStack(err)
 	}
 
-	// Write file sidecar
-	if err := sidecar.WriteFileSidecarFromModel(file); err != nil {
-		log.Warn("failed to write file sidecar", logger.Data{"file_id": file.ID, "error": err.Error()})
+	// Write file sidecar. Staged books get theirs written when they're
+	// approved.
+	if !book.Staged {
+		if err := sidecar.WriteFileSidecarFromModel(file); err != nil {
+			log.Warn("failed to write file sidecar", logger.Data{"file_id": file.ID, "error": err.Error()})
+		}
 	}
 
-	// Re-index the parent book (narrators are indexed in books_fts)
+	// Re-index the parent book (narrators are indexed in books_fts) and
+	// refresh its metadata hash, which covers the file's identifiers
 	book, err = h.bookService.RetrieveBook(ctx, RetrieveBookOptions{
 		ID: &file.BookID,
 	})
 	if err == nil {
+		if err := h.bookService.RefreshMetadataHash(ctx, book); err != nil {
+			log.Warn("failed to update book metadata hash", logger.Data{"book_id": book.ID, "error": err.Error()})
+		}
 		if err := h.searchService.IndexBook(ctx, book); err != nil {
 			log.Warn("failed to update search index for book", logger.Data{"book_id": book.ID, "error": err.Error()})
 		}
@@ -1219,10 +1317,10 @@ func (h *handler) updateFile(c echo.Context) error {
 
 // reorganizeFileAfterMetadataChange reorganizes a file on disk after a
 // file-level metadata change (name or narrator) when the library has
-// OrganizeFileStructure enabled.
+// OrganizeFiles enabled.
 //
 // For files that already live inside their organized folder this does an
-// in-folder rename via RenameOrganizedFileOnly. For root-level files —
+// in-folder rename via RenameOrganizedFile. For root-level files —
 // which can happen when a library was previously organize=false or when
 // organize hasn't finished running after a toggle — it delegates to
 // OrganizeBookFiles so the file is moved into its organized folder and
@@ -1345,10 +1443,13 @@ func (h *handler) reorganizeFileAfterMetadataChange(
 		NarratorNames: narratorNames,
 		Title:         title,
 		FileType:      file.FileType,
+		Sanitize:      fileutils.SanitizeOptions{Mode: h.config.OrganizeFilenameMode},
 	}
-	// RenameOrganizedFileOnly leaves the book sidecar untouched — file-level
-	// changes must not rename the book sidecar.
-	newPath, err := fileutils.RenameOrganizedFileOnly(file.Filepath, organizeOpts)
+	// RenameOrganizedFile leaves the book sidecar untouched — file-level
+	// changes must not rename the book sidecar. When an organizer plugin
+	// picks the path instead, it saves the new path itself, so newPath
+	// already matches file.Filepath below.
+	newPath, err := h.bookService.RenameOrganizedFile(ctx, library, book, file, organizeOpts)
 	if err != nil {
 		log.Error("failed to rename file after metadata change", logger.Data{
 			"file_id": file.ID,
@@ -1474,10 +1575,23 @@ func (h *handler) fileCover(c echo.Context) error {
 	// root-level files. The cover always lives alongside the file.
 	coverPath := filepath.Join(filepath.Dir(file.Filepath), coverFilename)
 
-	c.Response().Header().Set("Cache-Control", covers.CacheControlImmutable)
-	return errors.WithStack(c.File(coverPath))
+	stat, err := os.Stat(coverPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errcodes.NotFound("Cover")
+		}
+		return errors.WithStack(err)
+	}
+
+	// Unlike book and series covers, this is always the same file's cover,
+	// so Last-Modified and If-Modified-Since are safe to use alongside the
+	// ETag.
+	return covers.ServeFile(c, file.ID, coverPath, stat, covers.CacheControlImmutable, stat.ModTime())
 }
 
+// maxCoverUploadBytes is the largest cover image that can be uploaded.
+const maxCoverUploadBytes = 20 << 20
+
 func (h *handler) uploadFileCover(c echo.Context) error {
 	ctx := c.Request().Context()
 	log := logger.FromContext(ctx)
@@ -1499,6 +1613,33 @@ func (h *handler) uploadFileCover(c echo.Context) error {
 		return errcodes.ValidationError("Invalid image type. Allowed types: JPEG, PNG, WebP")
 	}
 
+	if fileHeader.Size > maxCoverUploadBytes {
+		return errcodes.ValidationError(fmt.Sprintf("Cover image must be %d MB or smaller", maxCoverUploadBytes>>20))
+	}
+
+	// Read the uploaded file data before touching the existing cover so a
+	// bad upload leaves it in place.
+	src, err := fileHeader.Open()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer src.Close()
+
+	uploadedData, err := io.ReadAll(io.LimitReader(src, maxCoverUploadBytes+1))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if int64(len(uploadedData)) > maxCoverUploadBytes {
+		return errcodes.ValidationError(fmt.Sprintf("Cover image must be %d MB or smaller", maxCoverUploadBytes>>20))
+	}
+
+	// The declared content type comes from the client, so check that the
+	// data really is one of the allowed image types and trust that instead.
+	contentType = http.DetectContentType(uploadedData)
+	if !isValidImageType(contentType) {
+		return errcodes.ValidationError("Uploaded file is not a JPEG, PNG, or WebP image")
+	}
+
 	// Get extension from content type
 	ext := getExtensionFromMimeType(contentType)
 	if ext == "" {
@@ -1535,6 +1676,16 @@ func (h *handler) uploadFileCover(c echo.Context) error {
 	filename := filepath.Base(file.Filepath)
 	coverBaseName := filename + ".cover"
 
+	// Set an embedded cover aside so it can be restored later.
+	if h.config != nil && h.config.KeepOriginalCover &&
+		file.CoverSource != nil && models.IsEmbeddedDataSource(*file.CoverSource) {
+		if existingPath := fileutils.CoverExistsWithBaseName(coverDir, coverBaseName); existingPath != "" {
+			if err := fileutils.KeepOriginalCover(existingPath, file.Filepath); err != nil {
+				log.Warn("failed to keep original cover", logger.Data{"path": existingPath, "error": err.Error()})
+			}
+		}
+	}
+
 	// Delete any existing cover with this base name (regardless of extension)
 	for _, existingExt := range fileutils.CoverImageExtensions {
 		existingPath := filepath.Join(coverDir, coverBaseName+existingExt)
@@ -1545,18 +1696,6 @@ func (h *handler) uploadFileCover(c echo.Context) error {
 		}
 	}
 
-	// Read the uploaded file data
-	src, err := fileHeader.Open()
-	if err != nil {
-		return errors.WithStack(err)
-	}
-	defer src.Close()
-
-	uploadedData, err := io.ReadAll(src)
-	if err != nil {
-		return errors.WithStack(err)
-	}
-
 	// Normalize the image to strip problematic metadata
 	normalizedData, normalizedMime, _ := fileutils.NormalizeImage(uploadedData, contentType)
 
@@ -1612,6 +1751,42 @@ func (h *handler) uploadFileCover(c echo.Context) error {
 	return errors.WithStack(c.JSON(http.StatusOK, file))
 }
 
+// revertFileCover restores the embedded cover kept when the file's cover was
+// replaced.
+func (h *handler) revertFileCover(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("File")
+	}
+
+	file, err := h.bookService.RetrieveFile(ctx, RetrieveFileOptions{
+		ID: &id,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Check library access
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(file.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	if _, err := h.bookService.RevertCover(ctx, file.ID); err != nil {
+		return errors.WithStack(err)
+	}
+
+	file, err = h.bookService.RetrieveFileWithRelations(ctx, file.ID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, file))
+}
+
 // isValidImageType checks if the content type is a valid image type for covers.
 func isValidImageType(contentType string) bool {
 	validTypes := []string{"image/jpeg", "image/png", "image/webp"}
@@ -1742,7 +1917,7 @@ func (h *handler) downloadFile(c echo.Context) error {
 	httputil.SetAttachmentFilename(c.Response(), downloadFilename)
 	c.Response().Header().Set("Cache-Control", "private, no-store")
 
-	return c.File(cachedPath)
+	return httputil.ServeFile(c.Response(), c.Request(), cachedPath)
 }
 
 // downloadOriginalFile handles downloading the original file without any modifications.
@@ -1778,7 +1953,52 @@ func (h *handler) downloadOriginalFile(c echo.Context) error {
 	httputil.SetAttachmentFilename(c.Response(), filename)
 	c.Response().Header().Set("Cache-Control", "private, no-store")
 
-	return c.File(file.Filepath)
+	return httputil.ServeFile(c.Response(), c.Request(), file.Filepath)
+}
+
+// downloadBookZip streams a zip of a book's files on disk as they are, without
+// embedding generated metadata. Supplements are only included with
+// ?supplements=true.
+func (h *handler) downloadBookZip(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Book")
+	}
+
+	params := DownloadBookZipQuery{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	book, err := h.bookService.RetrieveBook(ctx, RetrieveBookOptions{
+		ID: &id,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Check library access
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(book.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	files := bookZipFiles(book, params.Supplements)
+	if len(files) == 0 {
+		return errcodes.NotFound("Files on disk")
+	}
+
+	httputil.SetAttachmentFilename(c.Response(), bookZipFilename(book))
+	c.Response().Header().Set(echo.HeaderContentType, "application/zip")
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+	c.Response().WriteHeader(http.StatusOK)
+
+	// The status has already been sent, so an error here can only cut the
+	// archive short. It's still returned so it gets logged.
+	return writeBookZip(c.Response(), files)
 }
 
 // downloadKepubFile handles downloading a file converted to KePub format.
@@ -1849,7 +2069,7 @@ func (h *handler) downloadKepubFile(c echo.Context) error {
 	httputil.SetAttachmentFilename(c.Response(), downloadFilename)
 	c.Response().Header().Set("Cache-Control", "private, no-store")
 
-	return c.File(cachedPath)
+	return httputil.ServeFile(c.Response(), c.Request(), cachedPath)
 }
 
 func (h *handler) resyncFile(c echo.Context) error {
@@ -1972,6 +2192,11 @@ func (h *handler) getPage(c echo.Context) error {
 		return errcodes.ValidationError("Invalid page number")
 	}
 
+	params := GetPageQuery{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
 	// Retrieve file with access check
 	file, err := h.bookService.RetrieveFile(ctx, RetrieveFileOptions{ID: &fileID})
 	if err != nil {
@@ -2002,7 +2227,11 @@ func (h *handler) getPage(c echo.Context) error {
 	var cachedPath, mimeType string
 	switch file.FileType {
 	case models.FileTypeCBZ:
-		cachedPath, mimeType, err = h.pageCache.GetPage(file.Filepath, file.ID, pageNum)
+		if params.Width > 0 {
+			cachedPath, mimeType, err = h.pageCache.GetResizedPage(file.Filepath, file.ID, pageNum, params.Width)
+		} else {
+			cachedPath, mimeType, err = h.pageCache.GetPage(file.Filepath, file.ID, pageNum)
+		}
 	case models.FileTypePDF:
 		cachedPath, mimeType, err = h.pdfPageCache.GetPage(file.Filepath, file.ID, pageNum)
 	}
@@ -2017,9 +2246,13 @@ func (h *handler) getPage(c echo.Context) error {
 	return c.File(cachedPath)
 }
 
-// streamFile streams an M4B audio file with support for Range headers (seeking).
-// This endpoint enables audio playback with seek functionality in the browser.
-func (h *handler) streamFile(c echo.Context) error {
+// epubResourceCSP is the Content-Security-Policy for resources served from
+// inside an EPUB.
+const epubResourceCSP = "sandbox; default-src 'none'; img-src 'self' data:; style-src 'self' 'unsafe-inline'; font-src 'self' data:; media-src 'self'"
+
+// epubResource streams a single resource (chapter, stylesheet, image, font)
+// out of an EPUB file. Only resources listed in the OPF manifest are served.
+func (h *handler) epubResource(c echo.Context) error {
 	ctx := c.Request().Context()
 
 	fileID, err := strconv.Atoi(c.Param("id"))
@@ -2027,15 +2260,14 @@ func (h *handler) streamFile(c echo.Context) error {
 		return errcodes.NotFound("File")
 	}
 
-	// Retrieve file
-	file, err := h.bookService.RetrieveFile(ctx, RetrieveFileOptions{ID: &fileID})
+	resourcePath, err := url.PathUnescape(c.Param("*"))
 	if err != nil {
-		return errors.WithStack(err)
+		return errcodes.NotFound("Resource")
 	}
 
-	// Only M4B files can be streamed
-	if file.FileType != models.FileTypeM4B {
-		return errcodes.NotFound("File")
+	file, err := h.bookService.RetrieveFile(ctx, RetrieveFileOptions{ID: &fileID})
+	if err != nil {
+		return errors.WithStack(err)
 	}
 
 	// Check library access
@@ -2045,79 +2277,69 @@ func (h *handler) streamFile(c echo.Context) error {
 		}
 	}
 
-	// Check if file exists on disk
-	if _, err := os.Stat(file.Filepath); os.IsNotExist(err) {
-		return errcodes.NotFound("File not found on disk")
+	if file.FileType != models.FileTypeEPUB {
+		return errcodes.ValidationError("Only EPUB files have resources")
 	}
 
-	// Set Accept-Ranges header to indicate we support range requests
-	c.Response().Header().Set("Accept-Ranges", "bytes")
-	c.Response().Header().Set("Content-Type", "audio/mp4")
-	c.Response().Header().Set("Cache-Control", "private, no-store")
-
-	// Check for Range header
-	rangeHeader := c.Request().Header.Get("Range")
-	if rangeHeader == "" {
-		// No range requested - serve full file
-		return c.File(file.Filepath)
+	res, err := epub.OpenResource(file.Filepath, resourcePath)
+	if err != nil {
+		return errors.WithStack(err)
 	}
+	defer res.Close()
+
+	// Resources are immutable for a given file ID unless the file is replaced
+	// on disk, so allow a short private cache rather than a permanent one.
+	c.Response().Header().Set("Cache-Control", "private, max-age=3600")
+	c.Response().Header().Set("Content-Length", strconv.FormatInt(res.Size, 10))
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	// Resources come from inside the book and are served on the app's
+	// origin, so sandbox them: scripts in a crafted EPUB must not run with
+	// the user's session, and the document only gets to load the book's own
+	// styles, images, fonts, and media.
+	c.Response().Header().Set("Content-Security-Policy", epubResourceCSP)
 
-	// Parse Range header (format: "bytes=start-end")
-	return h.serveRangeRequest(c, file.Filepath, rangeHeader)
+	return c.Stream(http.StatusOK, res.MediaType, res)
 }
 
-// serveRangeRequest handles HTTP Range requests for partial content delivery.
-func (h *handler) serveRangeRequest(c echo.Context, filePath, rangeHeader string) error {
-	// Open the file
-	f, err := os.Open(filePath)
+// streamFile streams an M4B audio file with support for Range headers (seeking).
+// This endpoint enables audio playback with seek functionality in the browser.
+func (h *handler) streamFile(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	fileID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		return errors.WithStack(err)
+		return errcodes.NotFound("File")
 	}
-	defer f.Close()
 
-	// Get file size
-	fileInfo, err := f.Stat()
+	// Retrieve file
+	file, err := h.bookService.RetrieveFile(ctx, RetrieveFileOptions{ID: &fileID})
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	fileSize := fileInfo.Size()
 
-	// Parse the range header (expecting format: "bytes=start-end")
-	var start, end int64
-	_, err = fmt.Sscanf(rangeHeader, "bytes=%d-%d", &start, &end)
-	if err != nil {
-		// Try parsing just start (e.g., "bytes=0-")
-		_, err = fmt.Sscanf(rangeHeader, "bytes=%d-", &start)
-		if err != nil {
-			return errcodes.ValidationError("Invalid Range header")
-		}
-		end = fileSize - 1
+	// Only M4B files can be streamed
+	if file.FileType != models.FileTypeM4B {
+		return errcodes.NotFound("File")
 	}
 
-	// Validate range
-	if start < 0 || start >= fileSize || end < start || end >= fileSize {
-		c.Response().Header().Set("Content-Range", fmt.Sprintf("bytes */%d", fileSize))
-		return c.NoContent(http.StatusRequestedRangeNotSatisfiable)
+	// Check library access
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(file.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
 	}
 
-	// Calculate content length
-	contentLength := end - start + 1
-
-	// Set response headers
-	c.Response().Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, fileSize))
-	c.Response().Header().Set("Content-Length", strconv.FormatInt(contentLength, 10))
-
-	// Seek to start position
-	_, err = f.Seek(start, io.SeekStart)
-	if err != nil {
-		return errors.WithStack(err)
+	// Check if file exists on disk
+	if _, err := os.Stat(file.Filepath); os.IsNotExist(err) {
+		return errcodes.NotFound("File not found on disk")
 	}
 
-	// Create a limited reader for the requested range
-	limitedReader := io.LimitReader(f, contentLength)
+	c.Response().Header().Set("Content-Type", "audio/mp4")
+	c.Response().Header().Set("Cache-Control", "private, no-store")
 
-	// Stream the content with 206 Partial Content status
-	return c.Stream(http.StatusPartialContent, "audio/mp4", limitedReader)
+	// ServeContent answers Range requests with 206 Partial Content, which is
+	// what lets the player seek.
+	return httputil.ServeFile(c.Response(), c.Request(), file.Filepath)
 }
 
 func (h *handler) bookLists(c echo.Context) error {
@@ -2196,7 +2418,6 @@ func (h *handler) updateBookLists(c echo.Context) error {
 // moveFiles moves files from this book to another book (or a new book).
 func (h *handler) moveFiles(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := logger.FromContext(ctx)
 
 	// Parse book ID from URL param
 	id, err := strconv.Atoi(c.Param("id"))
@@ -2262,6 +2483,75 @@ func (h *handler) moveFiles(c echo.Context) error {
 		return errcodes.ValidationError(err.Error())
 	}
 
+	h.reindexAfterMove(ctx, result, id)
+
+	// Return MoveFilesResponse
+	return c.JSON(http.StatusOK, MoveFilesResponse{
+		TargetBook:        result.TargetBook,
+		FilesMoved:        result.FilesMoved,
+		SourceBookDeleted: result.SourceBookDeleted,
+	})
+}
+
+// moveFile moves a single file to another existing book.
+func (h *handler) moveFile(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("File")
+	}
+
+	file, err := h.bookService.RetrieveFile(ctx, RetrieveFileOptions{ID: &id})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Check library access
+	user, ok := c.Get("user").(*models.User)
+	if ok && !user.HasLibraryAccess(file.LibraryID) {
+		return errcodes.Forbidden("You don't have access to this library")
+	}
+
+	params := MoveFilePayload{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	targetBook, err := h.bookService.RetrieveBook(ctx, RetrieveBookOptions{
+		ID: &params.TargetBookID,
+	})
+	if err != nil {
+		return errcodes.NotFound("Target book")
+	}
+	if targetBook.LibraryID != file.LibraryID {
+		return errcodes.ValidationError("Target book must be in the same library")
+	}
+	if targetBook.ID == file.BookID {
+		return errcodes.ValidationError("File already belongs to this book")
+	}
+
+	sourceBookID := file.BookID
+	result, err := h.bookService.MoveFileToBook(ctx, file.ID, targetBook.ID, h.config.SupplementExcludePatterns...)
+	if err != nil {
+		return errcodes.ValidationError(err.Error())
+	}
+
+	h.reindexAfterMove(ctx, result, sourceBookID)
+
+	return c.JSON(http.StatusOK, MoveFilesResponse{
+		TargetBook:        result.TargetBook,
+		FilesMoved:        result.FilesMoved,
+		SourceBookDeleted: result.SourceBookDeleted,
+	})
+}
+
+// reindexAfterMove updates the search index after files were moved out of
+// sourceBookID: the target book is re-indexed, the source book is re-indexed
+// if it still exists, and deleted books are removed from the index.
+func (h *handler) reindexAfterMove(ctx context.Context, result *MoveFilesResult, sourceBookID int) {
+	log := logger.FromContext(ctx)
+
 	// Update search indexes: IndexBook for target
 	if result.TargetBook != nil {
 		if err := h.searchService.IndexBook(ctx, result.TargetBook); err != nil {
@@ -2273,11 +2563,11 @@ func (h *handler) moveFiles(c echo.Context) error {
 	if !result.SourceBookDeleted {
 		// Reload source book to get fresh data
 		updatedSourceBook, err := h.bookService.RetrieveBook(ctx, RetrieveBookOptions{
-			ID: &id,
+			ID: &sourceBookID,
 		})
 		if err == nil {
 			if err := h.searchService.IndexBook(ctx, updatedSourceBook); err != nil {
-				log.Warn("failed to update search index for source book", logger.Data{"book_id": id, "error": err.Error()})
+				log.Warn("failed to update search index for source book", logger.Data{"book_id": sourceBookID, "error": err.Error()})
 			}
 		}
 	}
@@ -2288,13 +2578,6 @@ func (h *handler) moveFiles(c echo.Context) error {
 			log.Warn("failed to delete book from search index", logger.Data{"book_id": deletedBookID, "error": err.Error()})
 		}
 	}
-
-	// Return MoveFilesResponse
-	return c.JSON(http.StatusOK, MoveFilesResponse{
-		TargetBook:        result.TargetBook,
-		FilesMoved:        result.FilesMoved,
-		SourceBookDeleted: result.SourceBookDeleted,
-	})
 }
 
 // mergeBooks merges multiple books into a single target book.
@@ -2388,6 +2671,54 @@ func (h *handler) mergeBooks(c echo.Context) error {
 	})
 }
 
+// pairBooks moves the files of the book in the payload onto the book in the
+// URL, joining an audiobook and an ebook of the same title.
+func (h *handler) pairBooks(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Book")
+	}
+
+	params := PairBooksPayload{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	book, err := h.bookService.RetrieveBook(ctx, RetrieveBookOptions{ID: &id})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	user, ok := c.Get("user").(*models.User)
+	if ok && !user.HasLibraryAccess(book.LibraryID) {
+		return errcodes.Forbidden("You don't have access to this library")
+	}
+
+	result, err := h.bookService.PairBooks(ctx, id, params.BookID, h.config.SupplementExcludePatterns...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if result.TargetBook != nil {
+		if err := h.searchService.IndexBook(ctx, result.TargetBook); err != nil {
+			log.Warn("failed to update search index for target book", logger.Data{"book_id": result.TargetBook.ID, "error": err.Error()})
+		}
+	}
+	for _, deletedBookID := range result.DeletedBookIDs {
+		if err := h.searchService.DeleteFromBookIndex(ctx, deletedBookID); err != nil {
+			log.Warn("failed to delete book from search index", logger.Data{"book_id": deletedBookID, "error": err.Error()})
+		}
+	}
+
+	return c.JSON(http.StatusOK, MergeBooksResponse{
+		TargetBook:   result.TargetBook,
+		FilesMoved:   result.FilesMoved,
+		BooksDeleted: len(result.DeletedBookIDs),
+	})
+}
+
 // deleteBook handles DELETE /books/:id.
 func (h *handler) deleteBook(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -2516,6 +2847,8 @@ func (h *handler) deleteFile(c echo.Context) error {
 		models.FileTypeCBZ:  {},
 		models.FileTypeM4B:  {},
 		models.FileTypePDF:  {},
+		models.FileTypeMOBI: {},
+		models.FileTypeAZW3: {},
 	}
 	if h.pluginManager != nil {
 		for ext := range h.pluginManager.RegisteredFileExtensions() {
@@ -2718,3 +3051,127 @@ func (h *handler) listLibraryLanguages(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, languages)
 }
+
+// libraryStats reports how complete the metadata of a library's books is.
+func (h *handler) libraryStats(c echo.Context) error {
+	libraryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Library")
+	}
+
+	ctx := c.Request().Context()
+	if _, err := h.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{ID: &libraryID}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	stats, err := h.bookService.LibraryStats(ctx, libraryID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, stats))
+}
+
+func (h *handler) verifyLibrary(c echo.Context) error {
+	libraryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Library")
+	}
+
+	ctx := c.Request().Context()
+	if _, err := h.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{ID: &libraryID}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	report, err := h.bookService.VerifyLibraryIntegrity(ctx, libraryID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, report))
+}
+
+// previewFileMetadata returns the metadata a scan would extract from a file
+// (including plugin enrichment) without importing it.
+func (h *handler) previewFileMetadata(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params := PreviewFileMetadataPayload{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(params.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	library, err := h.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{
+		ID: &params.LibraryID,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	path, ok := resolvePathInLibrary(library, params.Filepath)
+	if !ok {
+		return errcodes.ValidationError("Filepath must be inside one of the library's paths")
+	}
+
+	metadata, err := h.scanner.PreviewMetadata(ctx, library.ID, path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return c.JSON(http.StatusOK, metadata)
+}
+
+// importFromURL downloads a book from a URL into a library and scans it.
+func (h *handler) importFromURL(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params := ImportFromURLPayload{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(params.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	result, err := h.importer.ImportFromURL(ctx, params.LibraryID, params.URL)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return c.JSON(http.StatusCreated, result.Book)
+}
+
+// resolvePathInLibrary resolves symlinks in path and reports whether the
+// result lies strictly inside one of the library's paths.
+func resolvePathInLibrary(library *models.Library, path string) (string, bool) {
+	if !filepath.IsAbs(path) {
+		return "", false
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Clean(path))
+	if err != nil {
+		// Nonexistent paths can't escape via symlinks; let the scanner
+		// report them as not found.
+		resolved = filepath.Clean(path)
+	}
+	for _, lp := range library.LibraryPaths {
+		root, err := filepath.EvalSymlinks(lp.Filepath)
+		if err != nil {
+			root = filepath.Clean(lp.Filepath)
+		}
+		rel, err := filepath.Rel(root, resolved)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return resolved, true
+	}
+	return "", false
+}
@@ -0,0 +1,85 @@
+package books
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// bookAudioQuality summarizes the audio properties of a book's M4B files.
+func (h *handler) bookAudioQuality(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Book")
+	}
+
+	book, err := h.bookService.RetrieveBook(ctx, RetrieveBookOptions{ID: &id})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(book.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, summarizeAudioQuality(book.Files)))
+}
+
+// summarizeAudioQuality builds the per-file audio details and the ranges
+// across them. Only main M4B files are considered.
+func summarizeAudioQuality(files []*models.File) AudioQualityResponse {
+	resp := AudioQualityResponse{Files: []AudioQualityFile{}, Summary: AudioQualitySummary{Codecs: []string{}}}
+	codecs := map[string]struct{}{}
+
+	for _, f := range files {
+		if f.FileType != models.FileTypeM4B || f.FileRole != models.FileRoleMain {
+			continue
+		}
+		resp.Files = append(resp.Files, AudioQualityFile{
+			FileID:     f.ID,
+			Filepath:   f.Filepath,
+			BitrateBps: f.AudiobookBitrateBps,
+			Codec:      f.AudiobookCodec,
+			SampleRate: f.AudiobookSampleRate,
+			Channels:   f.AudiobookChannels,
+		})
+
+		s := &resp.Summary
+		s.MinBitrateBps, s.MaxBitrateBps = expandRange(s.MinBitrateBps, s.MaxBitrateBps, f.AudiobookBitrateBps)
+		s.MinSampleRate, s.MaxSampleRate = expandRange(s.MinSampleRate, s.MaxSampleRate, f.AudiobookSampleRate)
+		s.MinChannels, s.MaxChannels = expandRange(s.MinChannels, s.MaxChannels, f.AudiobookChannels)
+		if f.AudiobookCodec != nil && *f.AudiobookCodec != "" {
+			codecs[*f.AudiobookCodec] = struct{}{}
+		}
+	}
+
+	resp.Summary.FileCount = len(resp.Files)
+	for codec := range codecs {
+		resp.Summary.Codecs = append(resp.Summary.Codecs, codec)
+	}
+	sort.Strings(resp.Summary.Codecs)
+
+	return resp
+}
+
+// expandRange widens the [lo, hi] range to include v. Nil values are skipped.
+func expandRange(lo, hi, v *int) (*int, *int) {
+	if v == nil {
+		return lo, hi
+	}
+	if lo == nil || *v < *lo {
+		lo = v
+	}
+	if hi == nil || *v > *hi {
+		hi = v
+	}
+	return lo, hi
+}
@@ -0,0 +1,56 @@
+package books
+
+import (
+	"testing"
+
+	"github.com/robinjoseph08/golib/pointerutil"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeAudioQuality(t *testing.T) {
+	t.Parallel()
+	aac := "AAC-LC"
+	heaac := "HE-AAC"
+	files := []*models.File{
+		{
+			ID: 1, FileType: models.FileTypeM4B, FileRole: models.FileRoleMain,
+			AudiobookBitrateBps: pointerutil.Int(128000), AudiobookCodec: &aac,
+			AudiobookSampleRate: pointerutil.Int(44100), AudiobookChannels: pointerutil.Int(2),
+		},
+		{
+			ID: 2, FileType: models.FileTypeM4B, FileRole: models.FileRoleMain,
+			AudiobookBitrateBps: pointerutil.Int(32000), AudiobookCodec: &heaac,
+			AudiobookSampleRate: pointerutil.Int(22050), AudiobookChannels: pointerutil.Int(1),
+		},
+		// Missing properties don't affect the ranges.
+		{ID: 3, FileType: models.FileTypeM4B, FileRole: models.FileRoleMain, AudiobookCodec: &aac},
+		// Non-audiobook and supplement files are excluded.
+		{ID: 4, FileType: models.FileTypeEPUB, FileRole: models.FileRoleMain},
+		{ID: 5, FileType: models.FileTypeM4B, FileRole: models.FileRoleSupplement, AudiobookBitrateBps: pointerutil.Int(8000)},
+	}
+
+	resp := summarizeAudioQuality(files)
+
+	require.Len(t, resp.Files, 3)
+	assert.Equal(t, 3, resp.Summary.FileCount)
+	assert.Equal(t, []string{"AAC-LC", "HE-AAC"}, resp.Summary.Codecs)
+	assert.Equal(t, 32000, *resp.Summary.MinBitrateBps)
+	assert.Equal(t, 128000, *resp.Summary.MaxBitrateBps)
+	assert.Equal(t, 22050, *resp.Summary.MinSampleRate)
+	assert.Equal(t, 44100, *resp.Summary.MaxSampleRate)
+	assert.Equal(t, 1, *resp.Summary.MinChannels)
+	assert.Equal(t, 2, *resp.Summary.MaxChannels)
+}
+
+func TestSummarizeAudioQuality_NoAudiobookFiles(t *testing.T) {
+	t.Parallel()
+	resp := summarizeAudioQuality([]*models.File{{ID: 1, FileType: models.FileTypeEPUB, FileRole: models.FileRoleMain}})
+
+	assert.Empty(t, resp.Files)
+	assert.Equal(t, 0, resp.Summary.FileCount)
+	assert.Empty(t, resp.Summary.Codecs)
+	assert.Nil(t, resp.Summary.MinBitrateBps)
+	assert.Nil(t, resp.Summary.MaxSampleRate)
+}
@@ -0,0 +1,151 @@
+package books
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/uptrace/bun"
+)
+
+type bulkUpdateFunc func(ctx context.Context, bookIDs []int, add, remove []string) ([]*BulkUpdateResult, error)
+
+// bulkUpdateTags adds and removes tags across multiple books.
+func (h *handler) bulkUpdateTags(c echo.Context) error {
+	return h.bulkUpdateNames(c, h.bookService.BulkUpdateTags, h.reindexBulkTags)
+}
+
+// bulkUpdateGenres adds and removes genres across multiple books.
+func (h *handler) bulkUpdateGenres(c echo.Context) error {
+	return h.bulkUpdateNames(c, h.bookService.BulkUpdateGenres, h.reindexBulkGenres)
+}
+
+func (h *handler) bulkUpdateNames(c echo.Context, update bulkUpdateFunc, reindex func(ctx context.Context, books []*models.Book)) error {
+	var payload BulkUpdateNamesPayload
+	if err := c.Bind(&payload); err != nil {
+		return err
+	}
+	if len(payload.Add) == 0 && len(payload.Remove) == 0 {
+		return errcodes.ValidationError("At least one name to add or remove is required.")
+	}
+
+	ctx := c.Request().Context()
+	user, _ := c.Get("user").(*models.User)
+
+	// Books the user can't access are reported the same way as missing ones.
+	var rows []*models.Book
+	err := h.bookService.DB().NewSelect().
+		Model(&rows).
+		Column("id", "library_id").
+		Where("id IN (?)", bun.In(payload.BookIDs)).
+		Scan(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	allowed := make(map[int]bool, len(rows))
+	for _, b := range rows {
+		allowed[b.ID] = user == nil || user.HasLibraryAccess(b.LibraryID)
+	}
+	var bookIDs []int
+	for _, id := range payload.BookIDs {
+		if allowed[id] {
+			bookIDs = append(bookIDs, id)
+		}
+	}
+
+	updated, err := update(ctx, bookIDs, payload.Add, payload.Remove)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]*BulkUpdateResult, len(updated))
+	var changed []*models.Book
+	for _, r := range updated {
+		byID[r.BookID] = r
+		if r.book != nil {
+			changed = append(changed, r.book)
+		}
+	}
+
+	resp := BulkUpdateResponse{Results: make([]*BulkUpdateResult, 0, len(payload.BookIDs))}
+	for _, id := range payload.BookIDs {
+		if r, ok := byID[id]; ok {
+			resp.Results = append(resp.Results, r)
+			continue
+		}
+		resp.Results = append(resp.Results, &BulkUpdateResult{
+			BookID: id,
+			Error:  errcodes.NotFound("Book").Error(),
+		})
+	}
+
+	if len(changed) > 0 {
+		reindex(ctx, changed)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// reindexBulkTags refreshes the search index after a bulk tag update: the
+// changed books, any tags they gained, and tags left without books.
+func (h *handler) reindexBulkTags(ctx context.Context, books []*models.Book) {
+	log := logger.FromContext(ctx)
+	indexed := make(map[int]bool)
+	for _, book := range books {
+		if err := h.searchService.IndexBook(ctx, book); err != nil {
+			log.Warn("failed to update search index for book", logger.Data{"book_id": book.ID, "error": err.Error()})
+		}
+		for _, bt := range book.BookTags {
+			if bt.Tag == nil || indexed[bt.TagID] {
+				continue
+			}
+			indexed[bt.TagID] = true
+			if err := h.searchService.IndexTag(ctx, bt.Tag); err != nil {
+				log.Warn("failed to update search index for tag", logger.Data{"tag_id": bt.TagID, "error": err.Error()})
+			}
+		}
+	}
+
+	orphanedTagIDs, err := h.tagService.CleanupOrphanedTags(ctx)
+	if err != nil {
+		log.Warn("failed to cleanup orphaned tags", logger.Data{"error": err.Error()})
+	}
+	for _, id := range orphanedTagIDs {
+		if err := h.searchService.DeleteFromTagIndex(ctx, id); err != nil {
+			log.Warn("failed to remove orphaned tag from search index", logger.Data{"tag_id": id, "error": err.Error()})
+		}
+	}
+}
+
+// reindexBulkGenres is reindexBulkTags for genres.
+func (h *handler) reindexBulkGenres(ctx context.Context, books []*models.Book) {
+	log := logger.FromContext(ctx)
+	indexed := make(map[int]bool)
+	for _, book := range books {
+		if err := h.searchService.IndexBook(ctx, book); err != nil {
+			log.Warn("failed to update search index for book", logger.Data{"book_id": book.ID, "error": err.Error()})
+		}
+		for _, bg := range book.BookGenres {
+			if bg.Genre == nil || indexed[bg.GenreID] {
+				continue
+			}
+			indexed[bg.GenreID] = true
+			if err := h.searchService.IndexGenre(ctx, bg.Genre); err != nil {
+				log.Warn("failed to update search index for genre", logger.Data{"genre_id": bg.GenreID, "error": err.Error()})
+			}
+		}
+	}
+
+	orphanedGenreIDs, err := h.genreService.CleanupOrphanedGenres(ctx)
+	if err != nil {
+		log.Warn("failed to cleanup orphaned genres", logger.Data{"error": err.Error()})
+	}
+	for _, id := range orphanedGenreIDs {
+		if err := h.searchService.DeleteFromGenreIndex(ctx, id); err != nil {
+			log.Warn("failed to remove orphaned genre from search index", logger.Data{"genre_id": id, "error": err.Error()})
+		}
+	}
+}
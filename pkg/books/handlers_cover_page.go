@@ -1,6 +1,7 @@
 package books
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"os"
@@ -16,7 +17,7 @@ import (
 
 // updateFileCoverPagePayload is the request body for setting a cover page.
 type updateFileCoverPagePayload struct {
-	Page int `json:"page"` // 0-indexed page number
+	Page int `json:"page"` // 1-indexed page number
 }
 
 // updateFileCoverPage handles PUT /files/:id/cover-page
@@ -56,14 +57,14 @@ func (h *handler) updateFileCoverPage(c echo.Context) error {
 	}
 
 	// Validate page is within bounds
-	if payload.Page < 0 || payload.Page >= *file.PageCount {
-		return errcodes.ValidationError("Page number is out of bounds")
+	if payload.Page < 1 || payload.Page > *file.PageCount {
+		return errcodes.ValidationError(fmt.Sprintf("Page number must be between 1 and %d", *file.PageCount))
 	}
 
 	coverFilename, mimeType, err := ExtractCoverPageToFile(
 		file,
 		file.Book.Filepath,
-		payload.Page,
+		payload.Page-1,
 		h.pageCache,
 		h.pdfPageCache,
 		log,
@@ -92,9 +93,12 @@ func (h *handler) updateFileCoverPage(c echo.Context) error {
 		return errors.WithStack(err)
 	}
 
-	// Write sidecar to persist the cover page choice
-	if err := sidecar.WriteFileSidecarFromModel(file); err != nil {
-		log.Warn("failed to write file sidecar", logger.Data{"error": err.Error()})
+	// Write sidecar to persist the cover page choice. Staged books get
+	// theirs written when they're approved.
+	if file.Book == nil || !file.Book.Staged {
+		if err := sidecar.WriteFileSidecarFromModel(file); err != nil {
+			log.Warn("failed to write file sidecar", logger.Data{"error": err.Error()})
+		}
 	}
 
 	return c.JSON(http.StatusOK, file)
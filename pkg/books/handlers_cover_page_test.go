@@ -227,7 +227,7 @@ func TestUpdateFileCoverPage(t *testing.T) {
 		require.Error(t, err)
 	})
 
-	t.Run("returns 400 for negative page number", func(t *testing.T) {
+	t.Run("returns 400 for page numbers below 1", func(t *testing.T) {
 		t.Parallel()
 		db := setupTestDB(t)
 		ctx := context.Background()
@@ -286,18 +286,21 @@ func TestUpdateFileCoverPage(t *testing.T) {
 		_, err = db.NewInsert().Model(file).Exec(ctx)
 		require.NoError(t, err)
 
-		// Request page -1 which is invalid
-		payload := map[string]int{"page": -1}
-		body, _ := json.Marshal(payload)
-		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(body))
-		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
-		rec := httptest.NewRecorder()
-		c := e.NewContext(req, rec)
-		c.SetParamNames("id")
-		c.SetParamValues(strconv.Itoa(file.ID))
-
-		err = h.updateFileCoverPage(c)
-		require.Error(t, err)
+		// Pages are 1-indexed, so both -1 and 0 are invalid
+		for _, page := range []int{-1, 0} {
+			payload := map[string]int{"page": page}
+			body, _ := json.Marshal(payload)
+			req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(strconv.Itoa(file.ID))
+
+			err = h.updateFileCoverPage(c)
+			require.Error(t, err, "page %d", page)
+			assert.Contains(t, err.Error(), "between 1 and 5")
+		}
 	})
 
 	t.Run("accepts PDF file type", func(t *testing.T) {
@@ -351,7 +354,7 @@ func TestUpdateFileCoverPage(t *testing.T) {
 		_, err = db.NewInsert().Model(file).Exec(ctx)
 		require.NoError(t, err)
 
-		payload := map[string]int{"page": 0}
+		payload := map[string]int{"page": 1}
 		body, _ := json.Marshal(payload)
 		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(body))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -428,7 +431,7 @@ func TestUpdateFileCoverPage(t *testing.T) {
 		require.NoError(t, err)
 
 		// Try to set cover page for EPUB file
-		payload := map[string]int{"page": 0}
+		payload := map[string]int{"page": 1}
 		body, _ := json.Marshal(payload)
 		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(body))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -454,7 +457,7 @@ func TestUpdateFileCoverPage(t *testing.T) {
 		}
 
 		// Request for non-existent file
-		payload := map[string]int{"page": 0}
+		payload := map[string]int{"page": 1}
 		body, _ := json.Marshal(payload)
 		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(body))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -525,7 +528,7 @@ func TestUpdateFileCoverPage(t *testing.T) {
 		require.NoError(t, err)
 
 		// Request should fail since page count is nil
-		payload := map[string]int{"page": 0}
+		payload := map[string]int{"page": 1}
 		body, _ := json.Marshal(payload)
 		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(body))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -582,7 +585,7 @@ func TestUploadFileCover_RejectsFileWithCoverPage(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create file with CoverPage set (simulates CBZ/PDF)
-	coverPage := 0
+	coverPage := 1
 	file := &models.File{
 		LibraryID:     library.ID,
 		BookID:        book.ID,
@@ -662,8 +665,8 @@ func TestUploadFileCover_RejectsPDFFile(t *testing.T) {
 	_, err = db.NewInsert().Model(book).Exec(ctx)
 	require.NoError(t, err)
 
-	// Create PDF file with CoverPage set (scanner sets cover_page=0 for PDFs)
-	pdfCoverPage := 0
+	// Create PDF file with CoverPage set (scanner sets cover_page=1 for PDFs)
+	pdfCoverPage := 1
 	file := &models.File{
 		LibraryID:     library.ID,
 		BookID:        book.ID,
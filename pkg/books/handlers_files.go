@@ -0,0 +1,46 @@
+package books
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// listFiles lists files across books, for auditing a library by file rather
+// than by book.
+func (h *handler) listFiles(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params := ListFilesQuery{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	opts := ListFilesOptions{
+		Limit:        &params.Limit,
+		Offset:       &params.Offset,
+		LibraryID:    params.LibraryID,
+		FileTypes:    params.FileTypes,
+		FileRole:     params.FileRole,
+		MissingCover: params.MissingCover,
+		Source:       params.Source,
+		Sort:         params.Sort,
+	}
+
+	if user, ok := c.Get("user").(*models.User); ok {
+		if params.LibraryID != nil && !user.HasLibraryAccess(*params.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+		opts.LibraryIDs = user.GetAccessibleLibraryIDs()
+	}
+
+	files, total, err := h.bookService.ListFilesWithTotal(ctx, opts)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, ListFilesResponse{Items: files, Total: total}))
+}
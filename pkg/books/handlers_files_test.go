@@ -0,0 +1,110 @@
+package books
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListFiles_Filters(t *testing.T) {
+	t.Parallel()
+
+	db := setupBooksTestDB(t)
+	svc := NewService(db)
+	lib := seedLibrary(t, db, "Files")
+	ctx := context.Background()
+
+	now := time.Now()
+	epub := seedFile(t, db, seedBook(t, db, lib, "Epub", "Epub", now), models.FileTypeEPUB, true)
+	bare := seedFile(t, db, seedBook(t, db, lib, "Bare", "Bare", now), models.FileTypeEPUB, false)
+	audio := seedFile(t, db, seedBook(t, db, lib, "Audio", "Audio", now), models.FileTypeM4B, false)
+
+	audio.FileRole = models.FileRoleSupplement
+	audio.FilesizeBytes = 5000
+	sidecar := models.DataSourceSidecar
+	audio.ChapterSource = &sidecar
+	_, err := db.NewUpdate().Model(audio).Column("file_role", "filesize_bytes", "chapter_source").WherePK().Exec(ctx)
+	require.NoError(t, err)
+
+	ids := func(files []*models.File) []int {
+		out := make([]int, 0, len(files))
+		for _, f := range files {
+			out = append(out, f.ID)
+		}
+		return out
+	}
+
+	files, total, err := svc.ListFilesWithTotal(ctx, ListFilesOptions{FileTypes: []string{models.FileTypeEPUB}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []int{epub.ID, bare.ID}, ids(files))
+
+	files, err = svc.ListFiles(ctx, ListFilesOptions{MissingCover: true})
+	require.NoError(t, err)
+	assert.Equal(t, []int{bare.ID, audio.ID}, ids(files))
+
+	role := models.FileRoleSupplement
+	files, err = svc.ListFiles(ctx, ListFilesOptions{FileRole: &role})
+	require.NoError(t, err)
+	assert.Equal(t, []int{audio.ID}, ids(files))
+
+	files, err = svc.ListFiles(ctx, ListFilesOptions{Source: &sidecar})
+	require.NoError(t, err)
+	assert.Equal(t, []int{audio.ID}, ids(files))
+
+	files, err = svc.ListFiles(ctx, ListFilesOptions{Sort: models.FileSortFilesizeDesc})
+	require.NoError(t, err)
+	assert.Equal(t, []int{audio.ID, epub.ID, bare.ID}, ids(files))
+
+	limit := 1
+	offset := 1
+	files, total, err = svc.ListFilesWithTotal(ctx, ListFilesOptions{Limit: &limit, Offset: &offset})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []int{bare.ID}, ids(files))
+}
+
+func TestListFilesHandler_ScopesToAccessibleLibraries(t *testing.T) {
+	t.Parallel()
+
+	db := setupBooksTestDB(t)
+	mine := seedLibrary(t, db, "Mine")
+	other := seedLibrary(t, db, "Other")
+	user := seedUserWithLibAccess(t, db, "carol", mine)
+
+	now := time.Now()
+	visible := seedFile(t, db, seedBook(t, db, mine, "Visible", "Visible", now), models.FileTypeEPUB, false)
+	seedFile(t, db, seedBook(t, db, other, "Hidden", "Hidden", now), models.FileTypeEPUB, false)
+
+	h := &handler{bookService: NewService(db)}
+	e := newTestEchoBooks(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/files?file_types=epub", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", user)
+	require.NoError(t, h.listFiles(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp ListFilesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Total)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, visible.ID, resp.Items[0].ID)
+
+	req = httptest.NewRequest(http.MethodGet, "/files?library_id="+strconv.Itoa(other.ID), nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+	c.Set("user", user)
+	var codedErr *errcodes.Error
+	require.ErrorAs(t, h.listFiles(c), &codedErr)
+	assert.Equal(t, http.StatusForbidden, codedErr.HTTPCode)
+}
@@ -0,0 +1,119 @@
+package books
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shishobooks/shisho/pkg/mediafile"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+// setupPreviewLibrary creates a library with a single on-disk library path
+// containing one file, returning the library, the library dir, and the file.
+func setupPreviewLibrary(t *testing.T, db *bun.DB) (*models.Library, string, string) {
+	t.Helper()
+	library, _ := setupTestLibraryAndBook(t, db)
+
+	libraryDir := t.TempDir()
+	_, err := db.NewInsert().Model(&models.LibraryPath{LibraryID: library.ID, Filepath: libraryDir}).Exec(context.Background())
+	require.NoError(t, err)
+
+	filePath := filepath.Join(libraryDir, "Book", "book.epub")
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+	require.NoError(t, os.WriteFile(filePath, []byte("epub"), 0600))
+
+	return library, libraryDir, filePath
+}
+
+func previewRequest(libraryID int, path string) *http.Request {
+	body, _ := json.Marshal(map[string]any{"library_id": libraryID, "filepath": path})
+	req := httptest.NewRequest(http.MethodPost, "/books/files/preview-metadata", strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	return req
+}
+
+func TestPreviewFileMetadata_ReturnsScannerMetadata(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	library, _, filePath := setupPreviewLibrary(t, db)
+	user := loadUserWithRole(t, db, setupTestUser(t, db, library.ID, true))
+
+	scanner := &recordingScanner{}
+	e := setupTestServerWithScanner(t, db, scanner)
+
+	rr := executeRequestWithUser(t, e, previewRequest(library.ID, filePath), user)
+
+	require.Equal(t, http.StatusOK, rr.Code, "response body: %s", rr.Body.String())
+	var metadata mediafile.ParsedMetadata
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &metadata))
+	assert.Equal(t, "Previewed", metadata.Title)
+	resolved, err := filepath.EvalSymlinks(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, resolved, scanner.previewPath)
+
+	// Previewing must not create any file records.
+	count, err := db.NewSelect().Model((*models.File)(nil)).Count(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestPreviewFileMetadata_RejectsPathsOutsideLibrary(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	library, libraryDir, _ := setupPreviewLibrary(t, db)
+	user := loadUserWithRole(t, db, setupTestUser(t, db, library.ID, true))
+
+	outside := filepath.Join(t.TempDir(), "outside.epub")
+	require.NoError(t, os.WriteFile(outside, []byte("epub"), 0600))
+	link := filepath.Join(libraryDir, "link.epub")
+	require.NoError(t, os.Symlink(outside, link))
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"outside library", outside},
+		{"relative path", "Book/book.epub"},
+		{"parent traversal", filepath.Join(libraryDir, "..", filepath.Base(outside))},
+		{"library root", libraryDir},
+		{"symlink escaping library", link},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			scanner := &recordingScanner{}
+			e := setupTestServerWithScanner(t, db, scanner)
+
+			rr := executeRequestWithUser(t, e, previewRequest(library.ID, tt.path), user)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, "response body: %s", rr.Body.String())
+			assert.False(t, scanner.called)
+		})
+	}
+}
+
+func TestPreviewFileMetadata_RequiresLibraryAccess(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	library, _, filePath := setupPreviewLibrary(t, db)
+	otherLibrary, _ := setupTestLibraryAndBook(t, db)
+	user := loadUserWithRole(t, db, setupTestUser(t, db, otherLibrary.ID, true))
+
+	scanner := &recordingScanner{}
+	e := setupTestServerWithScanner(t, db, scanner)
+
+	rr := executeRequestWithUser(t, e, previewRequest(library.ID, filePath), user)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code, "response body: %s", rr.Body.String())
+	assert.False(t, scanner.called)
+}
@@ -0,0 +1,96 @@
+package books
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamFile_MidFileRangeRequest(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	library, book := setupTestLibraryAndBook(t, db)
+	m4bPath := createTestM4BFile(t, 1000)
+	file := setupTestFile(t, db, book, models.FileTypeM4B, m4bPath)
+	user := setupTestUser(t, db, library.ID, true)
+
+	e := setupTestServer(t, db)
+	req := httptest.NewRequest(http.MethodGet, "/books/files/"+strconv.Itoa(file.ID)+"/stream", nil)
+	req.Header.Set("Range", "bytes=500-599")
+	rr := executeRequestWithUser(t, e, req, user)
+
+	require.Equal(t, http.StatusPartialContent, rr.Code)
+	assert.Equal(t, "bytes", rr.Header().Get("Accept-Ranges"))
+	assert.Equal(t, "bytes 500-599/1000", rr.Header().Get("Content-Range"))
+	assert.Equal(t, "100", rr.Header().Get("Content-Length"))
+	assert.Equal(t, "audio/mp4", rr.Header().Get("Content-Type"))
+
+	data, err := os.ReadFile(m4bPath)
+	require.NoError(t, err)
+	assert.Equal(t, data[500:600], rr.Body.Bytes())
+}
+
+func TestStreamFile_SuffixRangeRequest(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	library, book := setupTestLibraryAndBook(t, db)
+	m4bPath := createTestM4BFile(t, 1000)
+	file := setupTestFile(t, db, book, models.FileTypeM4B, m4bPath)
+	user := setupTestUser(t, db, library.ID, true)
+
+	e := setupTestServer(t, db)
+	req := httptest.NewRequest(http.MethodGet, "/books/files/"+strconv.Itoa(file.ID)+"/stream", nil)
+	// Players read the trailing moov atom this way.
+	req.Header.Set("Range", "bytes=-200")
+	rr := executeRequestWithUser(t, e, req, user)
+
+	require.Equal(t, http.StatusPartialContent, rr.Code)
+	assert.Equal(t, "bytes 800-999/1000", rr.Header().Get("Content-Range"))
+	assert.Len(t, rr.Body.Bytes(), 200)
+}
+
+func TestStreamFile_UnsatisfiableRange(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	library, book := setupTestLibraryAndBook(t, db)
+	m4bPath := createTestM4BFile(t, 1000)
+	file := setupTestFile(t, db, book, models.FileTypeM4B, m4bPath)
+	user := setupTestUser(t, db, library.ID, true)
+
+	e := setupTestServer(t, db)
+	req := httptest.NewRequest(http.MethodGet, "/books/files/"+strconv.Itoa(file.ID)+"/stream", nil)
+	req.Header.Set("Range", "bytes=5000-")
+	rr := executeRequestWithUser(t, e, req, user)
+
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, rr.Code)
+	assert.Equal(t, "bytes */1000", rr.Header().Get("Content-Range"))
+}
+
+func TestDownloadOriginalFile_MidFileRangeRequest(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	library, book := setupTestLibraryAndBook(t, db)
+	m4bPath := createTestM4BFile(t, 1000)
+	file := setupTestFile(t, db, book, models.FileTypeM4B, m4bPath)
+	user := setupTestUser(t, db, library.ID, true)
+
+	e := setupTestServer(t, db)
+	req := httptest.NewRequest(http.MethodGet, "/books/files/"+strconv.Itoa(file.ID)+"/download/original", nil)
+	req.Header.Set("Range", "bytes=250-349")
+	rr := executeRequestWithUser(t, e, req, user)
+
+	require.Equal(t, http.StatusPartialContent, rr.Code)
+	assert.Equal(t, "bytes", rr.Header().Get("Accept-Ranges"))
+	assert.Equal(t, "bytes 250-349/1000", rr.Header().Get("Content-Range"))
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "attachment")
+
+	data, err := os.ReadFile(m4bPath)
+	require.NoError(t, err)
+	assert.Equal(t, data[250:350], rr.Body.Bytes())
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/shishobooks/shisho/pkg/binder"
 	"github.com/shishobooks/shisho/pkg/config"
 	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/mediafile"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/uptrace/bun"
@@ -22,8 +23,9 @@ import (
 // recordingScanner implements the Scanner interface and records the options it
 // was invoked with, returning a canned successful result.
 type recordingScanner struct {
-	called bool
-	opts   ScanOptions
+	called      bool
+	opts        ScanOptions
+	previewPath string
 }
 
 func (s *recordingScanner) Scan(_ context.Context, opts ScanOptions) (*ScanResult, error) {
@@ -32,6 +34,16 @@ func (s *recordingScanner) Scan(_ context.Context, opts ScanOptions) (*ScanResul
 	return &ScanResult{}, nil
 }
 
+func (s *recordingScanner) PreviewMetadata(_ context.Context, _ int, path string) (*mediafile.ParsedMetadata, error) {
+	s.called = true
+	s.previewPath = path
+	return &mediafile.ParsedMetadata{Title: "Previewed"}, nil
+}
+
+func (s *recordingScanner) ParseMetadata(_ context.Context, _ string) (*mediafile.ParsedMetadata, error) {
+	return &mediafile.ParsedMetadata{Title: "Parsed"}, nil
+}
+
 // setupTestServerWithScanner sets up an Echo server with the book routes
 // registered against the provided scanner.
 func setupTestServerWithScanner(t *testing.T, db *bun.DB, scanner Scanner) *echo.Echo {
@@ -50,7 +62,7 @@ func setupTestServerWithScanner(t *testing.T, db *bun.DB, scanner Scanner) *echo
 	authMiddleware := auth.NewMiddleware(authService)
 
 	g := e.Group("/books")
-	RegisterRoutesWithGroup(g, db, cfg, authMiddleware, scanner, nil, nil, appsettings.NewService(db))
+	RegisterRoutesWithGroup(g, db, cfg, authMiddleware, scanner, nil, nil, appsettings.NewService(db), nil)
 
 	return e
 }
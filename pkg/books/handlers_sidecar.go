@@ -0,0 +1,101 @@
+package books
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/sidecar"
+)
+
+// bookSidecar returns a book's sidecar as it is on disk, to debug what scans
+// read and write without shell access.
+func (h *handler) bookSidecar(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Book")
+	}
+
+	book, err := h.bookService.RetrieveBook(ctx, RetrieveBookOptions{ID: &id})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(book.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	resp, err := readSidecar(sidecar.BookSidecarPathFromModel(book), func() (any, error) {
+		s, err := sidecar.ReadBookSidecarFromModel(book, nil)
+		return s, err
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(c.JSON(http.StatusOK, resp))
+}
+
+// fileSidecar returns a file's sidecar as it is on disk.
+func (h *handler) fileSidecar(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("File")
+	}
+
+	file, err := h.bookService.RetrieveFile(ctx, RetrieveFileOptions{ID: &id})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(file.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	resp, err := readSidecar(sidecar.FileSidecarPath(file.Filepath), func() (any, error) {
+		s, err := sidecar.ReadFileSidecar(file.Filepath)
+		return s, err
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(c.JSON(http.StatusOK, resp))
+}
+
+// readSidecar describes the sidecar at path, parsed with read. It returns a
+// 404 when there's no sidecar, and reports an invalid one in the response
+// instead of failing, since that's usually what's being debugged.
+func readSidecar(path string, read func() (any, error)) (*SidecarResponse, error) {
+	if path == "" {
+		return nil, errcodes.NotFound("Sidecar")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errcodes.NotFound("Sidecar")
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	resp := &SidecarResponse{Path: path, ModifiedAt: info.ModTime()}
+	parsed, err := read()
+	var validationErr *sidecar.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		resp.Error = validationErr.Problem
+	case err != nil:
+		return nil, errors.WithStack(err)
+	default:
+		resp.Sidecar = parsed
+	}
+	return resp, nil
+}
@@ -0,0 +1,56 @@
+package books
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/sidecar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSidecar(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "book.m4b")
+	read := func() (any, error) {
+		s, err := sidecar.ReadFileSidecar(filePath)
+		return s, err
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := readSidecar(sidecar.FileSidecarPath(filepath.Join(dir, "other.m4b")), read)
+		var codedErr *errcodes.Error
+		require.ErrorAs(t, err, &codedErr)
+		assert.Equal(t, 404, codedErr.HTTPCode)
+
+		_, err = readSidecar("", read)
+		require.Error(t, err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		publisher := "Tor"
+		require.NoError(t, sidecar.WriteFileSidecar(filePath, &sidecar.FileSidecar{Publisher: &publisher}))
+
+		resp, err := readSidecar(sidecar.FileSidecarPath(filePath), read)
+		require.NoError(t, err)
+		assert.Equal(t, sidecar.FileSidecarPath(filePath), resp.Path)
+		assert.False(t, resp.ModifiedAt.IsZero())
+		assert.Empty(t, resp.Error)
+		parsed, ok := resp.Sidecar.(*sidecar.FileSidecar)
+		require.True(t, ok)
+		assert.Equal(t, "Tor", *parsed.Publisher)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(sidecar.FileSidecarPath(filePath), []byte(`{"publsher": "Tor"}`), 0644))
+
+		resp, err := readSidecar(sidecar.FileSidecarPath(filePath), read)
+		require.NoError(t, err)
+		assert.Nil(t, resp.Sidecar)
+		assert.Contains(t, resp.Error, "publsher")
+	})
+}
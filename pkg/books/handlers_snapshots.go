@@ -0,0 +1,83 @@
+package books
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// listMetadataSnapshots returns the snapshots kept from the book's resyncs,
+// newest first.
+func (h *handler) listMetadataSnapshots(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	book, err := h.snapshotBook(c)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := h.bookService.ListMetadataSnapshots(ctx, book.ID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(c.JSON(http.StatusOK, snapshots))
+}
+
+// revertMetadataSnapshot puts the book's metadata back to a snapshot.
+func (h *handler) revertMetadataSnapshot(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	book, err := h.snapshotBook(c)
+	if err != nil {
+		return err
+	}
+
+	snapshotID, err := strconv.Atoi(c.Param("snapshotId"))
+	if err != nil {
+		return errcodes.NotFound("Snapshot")
+	}
+	snapshot, err := h.bookService.RetrieveMetadataSnapshot(ctx, snapshotID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if snapshot.BookID != book.ID {
+		return errcodes.NotFound("Snapshot")
+	}
+
+	book, err = h.bookService.RevertToSnapshot(ctx, snapshot.ID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := h.searchService.IndexBook(ctx, book); err != nil {
+		log.Warn("failed to update search index for book", logger.Data{"book_id": book.ID, "error": err.Error()})
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, book))
+}
+
+// snapshotBook loads the book in the :id param and checks the user can access
+// its library.
+func (h *handler) snapshotBook(c echo.Context) (*models.Book, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return nil, errcodes.NotFound("Book")
+	}
+
+	book, err := h.bookService.RetrieveBook(c.Request().Context(), RetrieveBookOptions{ID: &id})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(book.LibraryID) {
+			return nil, errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+	return book, nil
+}
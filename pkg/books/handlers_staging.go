@@ -0,0 +1,73 @@
+package books
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/sidecar"
+)
+
+// approveBook takes a book out of staging. Files are organized (if the
+// library organizes files or folders) and sidecars are written, which
+// is everything a scan skipped while the book was staged.
+func (h *handler) approveBook(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Book")
+	}
+
+	book, err := h.bookService.RetrieveBook(ctx, RetrieveBookOptions{ID: &id})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(book.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	if !book.Staged {
+		return errcodes.ValidationError("Book is not staged.")
+	}
+
+	book.Staged = false
+	if err := h.bookService.UpdateBook(ctx, book, UpdateBookOptions{Columns: []string{"staged"}}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Organize separately so a failure here doesn't leave the book staged;
+	// the next scan or edit will retry the organize.
+	if err := h.bookService.OrganizeBookFiles(ctx, book); err != nil {
+		log.Warn("failed to organize book files", logger.Data{"book_id": book.ID, "error": err.Error()})
+	}
+
+	// Reload to pick up any new file paths from organizing
+	book, err = h.bookService.RetrieveBook(ctx, RetrieveBookOptions{ID: &id})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := sidecar.WriteBookSidecarFromModel(book); err != nil {
+		log.Warn("failed to write book sidecar", logger.Data{"error": err.Error()})
+	}
+	for _, file := range book.Files {
+		if err := sidecar.WriteFileSidecarFromModel(file); err != nil {
+			log.Warn("failed to write file sidecar", logger.Data{"file_id": file.ID, "error": err.Error()})
+		}
+	}
+
+	if err := h.searchService.IndexBook(ctx, book); err != nil {
+		log.Warn("failed to update search index for book", logger.Data{"book_id": book.ID, "error": err.Error()})
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, book))
+}
@@ -0,0 +1,96 @@
+package books
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/sidecar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApproveBook_ClearsStagedAndWritesSidecars(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	library, book := setupTestLibraryAndBook(t, db)
+	user := loadUserWithRole(t, db, setupTestUser(t, db, library.ID, true))
+
+	book.Staged = true
+	_, err := db.NewUpdate().Model(book).Column("staged").WherePK().Exec(ctx)
+	require.NoError(t, err)
+
+	filePath := filepath.Join(book.Filepath, "book.epub")
+	require.NoError(t, os.WriteFile(filePath, []byte("epub"), 0600))
+	setupTestFile(t, db, book, models.FileTypeEPUB, filePath)
+
+	e := setupTestServerWithScanner(t, db, &recordingScanner{})
+	req := httptest.NewRequest(http.MethodPost, "/books/"+strconv.Itoa(book.ID)+"/approve", nil)
+	rr := executeRequestWithUser(t, e, req, user)
+
+	require.Equal(t, http.StatusOK, rr.Code, "response body: %s", rr.Body.String())
+	var resp models.Book
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.False(t, resp.Staged)
+
+	var stored models.Book
+	require.NoError(t, db.NewSelect().Model(&stored).Where("id = ?", book.ID).Scan(ctx))
+	assert.False(t, stored.Staged)
+
+	assert.FileExists(t, sidecar.BookSidecarPath(book.Filepath))
+	assert.FileExists(t, sidecar.FileSidecarPath(filePath))
+}
+
+func TestApproveBook_RejectsUnstagedBook(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	library, book := setupTestLibraryAndBook(t, db)
+	user := loadUserWithRole(t, db, setupTestUser(t, db, library.ID, true))
+
+	e := setupTestServerWithScanner(t, db, &recordingScanner{})
+	req := httptest.NewRequest(http.MethodPost, "/books/"+strconv.Itoa(book.ID)+"/approve", nil)
+	rr := executeRequestWithUser(t, e, req, user)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestUpdateFile_StagedBookWritesNoSidecars(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	library, book := setupTestLibraryAndBook(t, db)
+	user := loadUserWithRole(t, db, setupTestUser(t, db, library.ID, true))
+
+	book.Staged = true
+	_, err := db.NewUpdate().Model(book).Column("staged").WherePK().Exec(ctx)
+	require.NoError(t, err)
+
+	filePath := filepath.Join(book.Filepath, "book.epub")
+	require.NoError(t, os.WriteFile(filePath, []byte("epub"), 0600))
+	file := setupTestFile(t, db, book, models.FileTypeEPUB, filePath)
+
+	e := setupTestServerWithScanner(t, db, &recordingScanner{})
+	req := httptest.NewRequest(http.MethodPost, "/books/files/"+strconv.Itoa(file.ID), strings.NewReader(`{"url":"https://example.com/book"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rr := executeRequestWithUser(t, e, req, user)
+
+	require.Equal(t, http.StatusOK, rr.Code, "response body: %s", rr.Body.String())
+
+	var stored models.File
+	require.NoError(t, db.NewSelect().Model(&stored).Where("id = ?", file.ID).Scan(ctx))
+	require.NotNil(t, stored.URL)
+	assert.Equal(t, "https://example.com/book", *stored.URL)
+
+	// Sidecars wait for the book to be approved.
+	assert.NoFileExists(t, sidecar.FileSidecarPath(filePath))
+	assert.NoFileExists(t, sidecar.BookSidecarPath(book.Filepath))
+}
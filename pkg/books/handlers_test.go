@@ -21,6 +21,7 @@ import (
 	"github.com/shishobooks/shisho/pkg/binder"
 	"github.com/shishobooks/shisho/pkg/config"
 	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/mediafile"
 	"github.com/shishobooks/shisho/pkg/migrations"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -218,6 +219,14 @@ func (m *mockScanner) Scan(_ context.Context, _ ScanOptions) (*ScanResult, error
 	return nil, nil
 }
 
+func (m *mockScanner) PreviewMetadata(_ context.Context, _ int, _ string) (*mediafile.ParsedMetadata, error) {
+	return &mediafile.ParsedMetadata{}, nil
+}
+
+func (m *mockScanner) ParseMetadata(_ context.Context, _ string) (*mediafile.ParsedMetadata, error) {
+	return &mediafile.ParsedMetadata{}, nil
+}
+
 // setupTestServer sets up an Echo server with the book routes registered.
 func setupTestServer(t *testing.T, db *bun.DB) *echo.Echo {
 	t.Helper()
@@ -240,7 +249,7 @@ func setupTestServer(t *testing.T, db *bun.DB) *echo.Echo {
 	// real instance so RecomputeReviewedFor{File,Book} actually runs after
 	// mutations — otherwise reviewed flag tests give false greens.
 	g := e.Group("/books")
-	RegisterRoutesWithGroup(g, db, cfg, authMiddleware, &mockScanner{}, nil, nil, appsettings.NewService(db))
+	RegisterRoutesWithGroup(g, db, cfg, authMiddleware, &mockScanner{}, nil, nil, appsettings.NewService(db), nil)
 
 	return e
 }
@@ -994,7 +1003,7 @@ func TestUpdateFile_DowngradeMainToSupplement_DeletesCoverFile(t *testing.T) {
 			epubPath := filepath.Join(libraryDir, "root-book.epub")
 			// Synthetic organized-folder path — this is what scanFileCreateNew
 			// computes at pkg/worker/scan_unified.go:2106 for root-level files,
-			// regardless of whether OrganizeFileStructure is enabled.
+			// regardless of whether organizing is enabled.
 			syntheticBookPath := filepath.Join(libraryDir, "Author Name", "Book Title")
 			book := &models.Book{
 				LibraryID:       libraryID,
@@ -1011,7 +1020,7 @@ func TestUpdateFile_DowngradeMainToSupplement_DeletesCoverFile(t *testing.T) {
 }
 
 // Regression: when a user drops a file at the library root with
-// OrganizeFileStructure disabled, scanFileCreateNew writes a synthetic
+// organizing disabled, scanFileCreateNew writes a synthetic
 // organized-folder path into book.Filepath that never exists on disk. The
 // actual cover lives alongside the file at filepath.Dir(file.Filepath),
 // not under the synthetic book path. The bookCover and fileCover handlers
@@ -1037,7 +1046,7 @@ func TestServeCover_RootLevelFile_SyntheticBookPath_ServesCoverFromFileDir(t *te
 		require.NoError(t, os.WriteFile(epubPath, []byte("epub content"), 0644))
 
 		// Synthetic organized-folder path — same shape scanFileCreateNew
-		// produces for root-level files regardless of OrganizeFileStructure.
+		// produces for root-level files regardless of OrganizeFolders.
 		syntheticBookPath := filepath.Join(libraryDir, "Author Name", "Book Title")
 		book := &models.Book{
 			LibraryID:       library.ID,
@@ -1099,7 +1108,7 @@ func TestServeCover_RootLevelFile_SyntheticBookPath_ServesCoverFromFileDir(t *te
 }
 
 // Regression: when narrators are updated on a root-level M4B file in a
-// library with OrganizeFileStructure enabled, the handler must move the
+// library with organizing enabled, the handler must move the
 // file into its organized folder AND keep book.Filepath in sync. The
 // previous behavior called RenameOrganizedFileOnly, which renamed the file
 // in place at the library root and left book.Filepath pointing at the
@@ -1116,7 +1125,8 @@ func TestUpdateFile_Narrators_RootLevelM4B_OrganizesFileAndSyncsBookPath(t *test
 		Name:                     "Test Library",
 		CoverAspectRatio:         "audiobook",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -1203,7 +1213,8 @@ func TestUpdateFile_NarratorsAndName_DirectoryBacked_UsesNewNarratorsInFilename(
 		Name:                     "Test Library",
 		CoverAspectRatio:         "audiobook",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -1258,7 +1269,7 @@ func TestUpdateFile_NarratorsAndName_DirectoryBacked_UsesNewNarratorsInFilename(
 }
 
 // Regression: when file.Name is updated on a root-level file in a library
-// with OrganizeFileStructure enabled, the new name must be reflected in the
+// with organizing enabled, the new name must be reflected in the
 // organized filename. Previously the root-level branch of organizeBookFiles
 // always used book.Title (ignoring file.Name), and the handler persisted
 // file.Name after reorganize, so the new name was lost.
@@ -1273,7 +1284,8 @@ func TestUpdateFile_Name_RootLevelFile_OrganizesWithNewName(t *testing.T) {
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -1343,7 +1355,8 @@ func TestUpdateFile_Name_RootLevelFile_OrganizeFailure_RevertsDBState(t *testing
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -1429,7 +1442,8 @@ func TestUpdateFile_Name_DirectoryBacked_RenameFailure_RevertsDBState(t *testing
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -1563,6 +1577,67 @@ func TestUploadFileCover_RootLevelFile_SyntheticBookPath_WritesNextToFile(t *tes
 	assert.True(t, os.IsNotExist(err), "synthetic book dir must not be created for cover upload")
 }
 
+// An upload whose declared content type doesn't match its data is rejected,
+// and the existing cover is left alone.
+func TestUploadFileCover_RejectsDataThatIsNotAnImage(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	libraryDir := t.TempDir()
+	library := &models.Library{
+		Name:                     "Test Library",
+		CoverAspectRatio:         "book",
+		DownloadFormatPreference: models.DownloadFormatOriginal,
+	}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+
+	bookDir := filepath.Join(libraryDir, "Book")
+	require.NoError(t, os.MkdirAll(bookDir, 0755))
+	epubPath := filepath.Join(bookDir, "book.epub")
+	require.NoError(t, os.WriteFile(epubPath, []byte("epub content"), 0644))
+	existingCover := filepath.Join(bookDir, "book.epub.cover.jpg")
+	require.NoError(t, os.WriteFile(existingCover, []byte("existing cover"), 0644))
+
+	book := &models.Book{
+		LibraryID:       library.ID,
+		Title:           "Book",
+		TitleSource:     models.DataSourceFilepath,
+		SortTitle:       "Book",
+		SortTitleSource: models.DataSourceFilepath,
+		AuthorSource:    models.DataSourceFilepath,
+		Filepath:        bookDir,
+	}
+	_, err = db.NewInsert().Model(book).Exec(ctx)
+	require.NoError(t, err)
+
+	file := setupTestFile(t, db, book, models.FileTypeEPUB, epubPath)
+	user := loadUserWithRole(t, db, setupTestUser(t, db, library.ID, true))
+
+	var body strings.Builder
+	writer := multipart.NewWriter(&body)
+	partHeader := make(textproto.MIMEHeader)
+	partHeader.Set("Content-Disposition", `form-data; name="cover"; filename="cover.png"`)
+	partHeader.Set("Content-Type", "image/png")
+	part, err := writer.CreatePart(partHeader)
+	require.NoError(t, err)
+	_, err = part.Write([]byte("<html>not an image</html>"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	e := setupTestServer(t, db)
+	req := httptest.NewRequest(http.MethodPost, "/books/files/"+strconv.Itoa(file.ID)+"/cover", strings.NewReader(body.String()))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rr := executeRequestWithUser(t, e, req, user)
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code, "response body: %s", rr.Body.String())
+
+	data, err := os.ReadFile(existingCover)
+	require.NoError(t, err)
+	assert.Equal(t, "existing cover", string(data))
+}
+
 func TestUpdateBook_Title_UpdatesMainFileName_WhenMatchesOldTitle(t *testing.T) {
 	t.Parallel()
 
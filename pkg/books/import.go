@@ -0,0 +1,245 @@
+package books
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/fileutils"
+	"github.com/shishobooks/shisho/pkg/libraries"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// DefaultMaxImportBytes caps how much a URL import will download. It's sized
+// for large audiobooks.
+const DefaultMaxImportBytes = 4 << 30
+
+// importContentTypes maps the content types accepted by URL imports to the
+// file type they imply. Generic binary types are accepted too, in which case
+// the file type comes from the URL or filename extension.
+var importContentTypes = map[string]string{
+	"application/epub+zip": models.FileTypeEPUB,
+	"audio/mp4":            models.FileTypeM4B,
+	"audio/m4b":            models.FileTypeM4B,
+	"audio/x-m4b":          models.FileTypeM4B,
+	"video/mp4":            models.FileTypeM4B,
+}
+
+var genericContentTypes = map[string]struct{}{
+	"":                         {},
+	"application/octet-stream": {},
+	"binary/octet-stream":      {},
+	"application/zip":          {},
+}
+
+// errImportAddressBlocked is returned when a URL import would connect to an
+// address that isn't on the public internet.
+var errImportAddressBlocked = errors.New("address is not publicly routable")
+
+// carrierGradeNAT is the shared address space (RFC 6598) that ISPs and some
+// VPNs use internally. net.IP.IsPrivate doesn't cover it.
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// Importer downloads books from remote URLs into a library.
+type Importer struct {
+	libraryService *libraries.Service
+	scanner        Scanner
+	client         *http.Client
+	maxBytes       int64
+}
+
+// NewImporter creates an Importer that scans imported files with scanner.
+func NewImporter(libraryService *libraries.Service, scanner Scanner) *Importer {
+	return &Importer{
+		libraryService: libraryService,
+		scanner:        scanner,
+		client:         newImportClient(),
+		maxBytes:       DefaultMaxImportBytes,
+	}
+}
+
+// newImportClient returns the HTTP client used for URL imports. It refuses to
+// connect to loopback, link-local, private, and other non-public addresses.
+// The check runs on every connection after DNS resolution, so it also covers
+// redirects and hostnames that resolve to internal addresses.
+func newImportClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, Control: importDialControl}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would make the dialer connect to the proxy instead of the
+	// target, which would bypass the address check.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: 30 * time.Minute, Transport: transport}
+}
+
+func importDialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return errors.WithStack(errImportAddressBlocked)
+	}
+	return nil
+}
+
+// isPublicIP reports whether ip is a globally routable unicast address.
+func isPublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() &&
+		!ip.IsPrivate() &&
+		!carrierGradeNAT.Contains(ip)
+}
+
+// ImportFromURL downloads an EPUB or M4B from rawURL into a temp file,
+// verifies that its metadata can be parsed, then moves it into the library's
+// first path and scans it. The download is streamed to disk and capped at the
+// importer's size limit. The temp file is always cleaned up; once the file
+// has been moved into the library it stays there even if the scan fails, so
+// the next library scan can pick it up.
+func (i *Importer) ImportFromURL(ctx context.Context, libraryID int, rawURL string) (*ScanResult, error) {
+	library, err := i.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{
+		ID: &libraryID,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if len(library.LibraryPaths) == 0 {
+		return nil, errcodes.ValidationError("Library has no paths to import into.")
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errcodes.ValidationError("URL must be an absolute http or https URL.")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	resp, err := i.client.Do(req)
+	if err != nil {
+		if errors.Is(err, errImportAddressBlocked) {
+			return nil, errcodes.ValidationError("URL must point to a public address.")
+		}
+		return nil, errcodes.ValidationError("Failed to download URL: " + err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errcodes.ValidationError("Failed to download URL: server responded with " + resp.Status + ".")
+	}
+	if resp.ContentLength > i.maxBytes {
+		return nil, errcodes.ValidationError("The file at that URL is too large to import.")
+	}
+
+	filename := importFilename(resp, u)
+	fileType, err := importFileType(resp.Header.Get("Content-Type"), filename)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), ".")) != fileType {
+		filename += "." + fileType
+	}
+
+	tmp, err := os.CreateTemp("", "shisho-import-*."+fileType)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	// Read one byte past the limit so oversized bodies without a
+	// Content-Length are detected.
+	n, err := io.Copy(tmp, io.LimitReader(resp.Body, i.maxBytes+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to download file")
+	}
+	if n > i.maxBytes {
+		return nil, errcodes.ValidationError("The file at that URL is too large to import.")
+	}
+
+	if _, err := i.scanner.ParseMetadata(ctx, tmpPath); err != nil {
+		var unsupportedErr *errcodes.UnsupportedFileTypeError
+		if errors.As(err, &unsupportedErr) {
+			return nil, err
+		}
+		return nil, errcodes.ValidationError("The downloaded file couldn't be read: " + err.Error())
+	}
+
+	dest := fileutils.GenerateUniqueFilepathIfExists(filepath.Join(library.LibraryPaths[0].Filepath, filename))
+	if err := fileutils.MoveFile(tmpPath, dest); err != nil {
+		return nil, errors.Wrap(err, "failed to move file into library")
+	}
+
+	result, err := i.scanner.Scan(ctx, ScanOptions{
+		FilePath:  dest,
+		LibraryID: library.ID,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if result.File == nil {
+		return nil, errcodes.ValidationError("The imported file was skipped by the library's scan rules.")
+	}
+	return result, nil
+}
+
+// importFilename picks the name to save a downloaded file under, preferring
+// the Content-Disposition filename over the last segment of the URL path.
+func importFilename(resp *http.Response, u *url.URL) string {
+	name := ""
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		name = params["filename"]
+	}
+	if name == "" {
+		name = path.Base(u.Path)
+	}
+	// Only keep the final path element so a malicious name can't escape the
+	// library directory.
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" || strings.HasPrefix(name, ".") {
+		name = "import"
+	}
+	return name
+}
+
+// importFileType works out the file type of a download from its content type
+// and filename, rejecting anything that isn't an EPUB or M4B.
+func importFileType(contentType, filename string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = ""
+	}
+	mediaType = strings.ToLower(mediaType)
+
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+	if fileType, ok := importContentTypes[mediaType]; ok {
+		return fileType, nil
+	}
+	if _, ok := genericContentTypes[mediaType]; ok {
+		if ext == models.FileTypeEPUB || ext == models.FileTypeM4B {
+			return ext, nil
+		}
+		if ext == "" {
+			return "", errcodes.ValidationError("Couldn't tell the file type of the download. Use a URL ending in .epub or .m4b.")
+		}
+		return "", errors.WithStack(errcodes.UnsupportedFileType(ext))
+	}
+	return "", errcodes.ValidationError("URL returned unsupported content type " + mediaType + ".")
+}
@@ -0,0 +1,255 @@
+package books
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/libraries"
+	"github.com/shishobooks/shisho/pkg/mediafile"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+// importScanner is a Scanner that records the path-based scan an import
+// triggers and can be told to fail metadata parsing.
+type importScanner struct {
+	parseErr  error
+	parsed    bool
+	scanOpts  *ScanOptions
+	parsePath string
+}
+
+func (s *importScanner) Scan(_ context.Context, opts ScanOptions) (*ScanResult, error) {
+	s.scanOpts = &opts
+	return &ScanResult{File: &models.File{Filepath: opts.FilePath}, Book: &models.Book{}}, nil
+}
+
+func (s *importScanner) PreviewMetadata(_ context.Context, _ int, _ string) (*mediafile.ParsedMetadata, error) {
+	return &mediafile.ParsedMetadata{}, nil
+}
+
+func (s *importScanner) ParseMetadata(_ context.Context, path string) (*mediafile.ParsedMetadata, error) {
+	s.parsed = true
+	s.parsePath = path
+	if s.parseErr != nil {
+		return nil, s.parseErr
+	}
+	return &mediafile.ParsedMetadata{Title: "Imported"}, nil
+}
+
+func setupImportLibrary(t *testing.T, db *bun.DB) (*models.Library, string) {
+	t.Helper()
+	library, _ := setupTestLibraryAndBook(t, db)
+	libraryPath := t.TempDir()
+	_, err := db.NewInsert().Model(&models.LibraryPath{
+		LibraryID: library.ID,
+		Filepath:  libraryPath,
+	}).Exec(context.Background())
+	require.NoError(t, err)
+	return library, libraryPath
+}
+
+func TestImportFromURL_MovesFileIntoLibraryAndScans(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	library, libraryPath := setupImportLibrary(t, db)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/epub+zip")
+		w.Header().Set("Content-Disposition", `attachment; filename="My Book.epub"`)
+		_, _ = w.Write([]byte("epub bytes"))
+	}))
+	defer srv.Close()
+
+	scanner := &importScanner{}
+	importer := NewImporter(libraries.NewService(db), scanner)
+	// The test server listens on loopback, which the real client refuses.
+	importer.client = srv.Client()
+
+	result, err := importer.ImportFromURL(context.Background(), library.ID, srv.URL+"/download?id=1")
+	require.NoError(t, err)
+	require.NotNil(t, result.File)
+
+	dest := filepath.Join(libraryPath, "My Book.epub")
+	b, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "epub bytes", string(b))
+
+	require.NotNil(t, scanner.scanOpts)
+	assert.Equal(t, dest, scanner.scanOpts.FilePath)
+	assert.Equal(t, library.ID, scanner.scanOpts.LibraryID)
+
+	_, err = os.Stat(scanner.parsePath)
+	assert.True(t, os.IsNotExist(err), "temp file should be removed")
+}
+
+func TestImportFromURL_UnparseableFileIsDiscarded(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	library, libraryPath := setupImportLibrary(t, db)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("not really an epub"))
+	}))
+	defer srv.Close()
+
+	scanner := &importScanner{parseErr: errors.New("zip: not a valid zip file")}
+	importer := NewImporter(libraries.NewService(db), scanner)
+	// The test server listens on loopback, which the real client refuses.
+	importer.client = srv.Client()
+
+	_, err := importer.ImportFromURL(context.Background(), library.ID, srv.URL+"/broken.epub")
+	require.Error(t, err)
+	assert.True(t, scanner.parsed)
+	assert.Nil(t, scanner.scanOpts)
+
+	entries, err := os.ReadDir(libraryPath)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = os.Stat(scanner.parsePath)
+	assert.True(t, os.IsNotExist(err), "temp file should be removed")
+}
+
+func TestImportFromURL_TooLarge(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	library, libraryPath := setupImportLibrary(t, db)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/epub+zip")
+		// Flush before writing so no Content-Length is sent.
+		w.(http.Flusher).Flush()
+		_, _ = w.Write(make([]byte, 64))
+	}))
+	defer srv.Close()
+
+	scanner := &importScanner{}
+	importer := NewImporter(libraries.NewService(db), scanner)
+	// The test server listens on loopback, which the real client refuses.
+	importer.client = srv.Client()
+	importer.maxBytes = 16
+
+	_, err := importer.ImportFromURL(context.Background(), library.ID, srv.URL+"/big.epub")
+	require.Error(t, err)
+	assert.False(t, scanner.parsed)
+
+	entries, err := os.ReadDir(libraryPath)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestImportFileType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		contentType string
+		filename    string
+		want        string
+		wantErr     bool
+	}{
+		{"epub content type", "application/epub+zip", "download", models.FileTypeEPUB, false},
+		{"m4b content type with params", "audio/mp4; charset=binary", "book.m4b", models.FileTypeM4B, false},
+		{"generic type uses extension", "application/octet-stream", "book.epub", models.FileTypeEPUB, false},
+		{"missing type uses extension", "", "book.m4b", models.FileTypeM4B, false},
+		{"generic type with unsupported extension", "application/octet-stream", "book.pdf", "", true},
+		{"generic type without extension", "application/octet-stream", "download", "", true},
+		{"html is rejected", "text/html", "book.epub", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := importFileType(tt.contentType, tt.filename)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestImportFileType_UnsupportedExtensionIsTyped(t *testing.T) {
+	t.Parallel()
+	_, err := importFileType("application/octet-stream", "book.pdf")
+	var unsupportedErr *errcodes.UnsupportedFileTypeError
+	assert.True(t, errors.As(err, &unsupportedErr))
+}
+
+func TestImportFileType_MissingExtensionIsValidationError(t *testing.T) {
+	t.Parallel()
+	_, err := importFileType("application/octet-stream", "download")
+	var unsupportedErr *errcodes.UnsupportedFileTypeError
+	assert.False(t, errors.As(err, &unsupportedErr))
+	var codeErr *errcodes.Error
+	require.True(t, errors.As(err, &codeErr))
+	assert.Equal(t, http.StatusUnprocessableEntity, codeErr.HTTPCode)
+}
+
+func TestImportFromURL_RejectsNonPublicAddresses(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	library, libraryPath := setupImportLibrary(t, db)
+
+	requested := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requested = true
+		w.Header().Set("Content-Type", "application/epub+zip")
+		_, _ = w.Write([]byte("epub bytes"))
+	}))
+	defer srv.Close()
+
+	scanner := &importScanner{}
+	importer := NewImporter(libraries.NewService(db), scanner)
+
+	_, err := importer.ImportFromURL(context.Background(), library.ID, srv.URL+"/book.epub")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "public address")
+	assert.False(t, requested)
+	assert.False(t, scanner.parsed)
+
+	entries, err := os.ReadDir(libraryPath)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.10", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::ffff:127.0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, isPublicIP(net.ParseIP(tt.ip)))
+		})
+	}
+}
@@ -68,9 +68,27 @@ func firstNonExistentAncestor(dir string) string {
 	}
 }
 
+// MoveFileToBook moves a single file to an existing book in the same library.
+// The source book is deleted if the move leaves it without main files, and the
+// file is relocated on disk when the library has OrganizeFolders enabled.
+// See MoveFilesToBook for the details. ignoredPatterns are used when cleaning
+// up the source directory (e.g. config.SupplementExcludePatterns).
+func (svc *Service) MoveFileToBook(ctx context.Context, fileID, targetBookID int, ignoredPatterns ...string) (*MoveFilesResult, error) {
+	file, err := svc.RetrieveFile(ctx, RetrieveFileOptions{ID: &fileID})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return svc.MoveFilesToBook(ctx, MoveFilesOptions{
+		FileIDs:         []int{file.ID},
+		TargetBookID:    &targetBookID,
+		LibraryID:       file.LibraryID,
+		IgnoredPatterns: ignoredPatterns,
+	})
+}
+
 // MoveFilesToBook moves files from their current books to a target book.
 // If TargetBookID is nil, a new book is created from the first file's directory.
-// This method handles physical file relocation when the library has OrganizeFileStructure enabled.
+// This method handles physical file relocation when the library has OrganizeFolders enabled.
 func (svc *Service) MoveFilesToBook(ctx context.Context, opts MoveFilesOptions) (*MoveFilesResult, error) {
 	log := logger.FromContext(ctx)
 
@@ -96,7 +114,7 @@ func (svc *Service) MoveFilesToBook(ctx context.Context, opts MoveFilesOptions)
 		return nil, errors.New("some files not found or not in the specified library")
 	}
 
-	// Fetch library to check OrganizeFileStructure
+	// Fetch library to check OrganizeFolders
 	var library models.Library
 	err = svc.db.NewSelect().
 		Model(&library).
@@ -181,7 +199,7 @@ func (svc *Service) MoveFilesToBook(ctx context.Context, opts MoveFilesOptions)
 			}
 
 			// Handle physical file move if organize is enabled
-			if library.OrganizeFileStructure {
+			if library.OrganizeFolders {
 				newPath := filepath.Join(targetBook.Filepath, filepath.Base(file.Filepath))
 				// Generate unique path if a file already exists at the destination
 				newPath = fileutils.GenerateUniqueFilepathIfExists(newPath)
@@ -351,6 +369,8 @@ func (svc *Service) MoveFilesToBook(ctx context.Context, opts MoveFilesOptions)
 		// Don't fail the whole operation if cleanup fails, just log it
 	}
 
+	svc.sendBookDeleted(result.DeletedBookIDs...)
+
 	// Remove book sidecars for deleted books (best effort)
 	for bookID, bookPath := range deletedBookPaths {
 		if bookPath == "" {
@@ -372,7 +392,7 @@ func (svc *Service) MoveFilesToBook(ctx context.Context, opts MoveFilesOptions)
 	}
 
 	// Clean up empty source directories (best effort, don't fail operation)
-	if library.OrganizeFileStructure {
+	if library.OrganizeFolders {
 		// Collect unique source directories to clean up
 		dirsToClean := make(map[string]bool)
 		for _, move := range moves {
@@ -430,10 +450,10 @@ func (svc *Service) MoveFilesToBook(ctx context.Context, opts MoveFilesOptions)
 // createBookFromFile creates a new book based on a file.
 // The title is derived from the file's metadata name if available, otherwise from the filename on disk.
 // All other metadata (authors, series, genres, tags, description, etc.) is copied from the source book.
-// When OrganizeFileStructure is enabled:
+// When OrganizeFolders is enabled:
 //   - Creates a new directory using the [Author] Title format in the library path
 //
-// When OrganizeFileStructure is disabled:
+// When OrganizeFolders is disabled:
 //   - Uses the file's current directory; returns an error if a book already exists there
 func (svc *Service) createBookFromFile(ctx context.Context, file *models.File, sourceBookID int, library *models.Library) (*models.Book, error) {
 	now := time.Now()
@@ -474,7 +494,7 @@ func (svc *Service) createBookFromFile(ctx context.Context, file *models.File, s
 
 	// Determine book directory
 	var bookDir string
-	if library.OrganizeFileStructure {
+	if library.OrganizeFolders {
 		// Generate organized folder name: [Author] Title
 		var authorNames []string
 		for _, author := range sourceAuthors {
@@ -487,6 +507,8 @@ func (svc *Service) createBookFromFile(ctx context.Context, file *models.File, s
 			AuthorNames: authorNames,
 			Title:       title,
 			FileType:    file.FileType,
+			Subfolder:   library.OrganizeSubfolder(file.FileType),
+			Sanitize:    svc.sanitizeOptions,
 		})
 
 		// Use the first library path as the parent directory
@@ -534,10 +556,10 @@ func (svc *Service) createBookFromFile(ctx context.Context, file *models.File, s
 		}
 
 		if existingCount > 0 {
-			// Without organize_file_structure, we cannot create a new book because:
+			// Without organize_folders, we cannot create a new book because:
 			// 1. The directory is already used by another book
 			// 2. We cannot move the file to a new directory
-			return nil, errors.New("cannot create a new book: a book already exists at this location. Please select an existing book as the target, or enable 'Organize File Structure' in library settings to allow creating a subdirectory for the new book")
+			return nil, errors.New("cannot create a new book: a book already exists at this location. Please select an existing book as the target, or enable 'Organize folders' in library settings to allow creating a subdirectory for the new book")
 		}
 	}
 
@@ -608,6 +630,7 @@ func (svc *Service) createBookFromFile(ctx context.Context, file *models.File, s
 				SeriesNumber:     bs.SeriesNumber,
 				SeriesNumberEnd:  bs.SeriesNumberEnd,
 				SeriesNumberUnit: bs.SeriesNumberUnit,
+				ReadingOrder:     bs.ReadingOrder,
 				SortOrder:        bs.SortOrder,
 			}
 		}
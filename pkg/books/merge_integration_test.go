@@ -12,7 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestMoveFilesToBook_WithOrganizeFileStructure(t *testing.T) {
+func TestMoveFilesToBook_WithOrganizeFolders(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 	db := setupTestDB(t)
@@ -29,12 +29,13 @@ func TestMoveFilesToBook_WithOrganizeFileStructure(t *testing.T) {
 	sourceFile := filepath.Join(sourceDir, "test.epub")
 	require.NoError(t, os.WriteFile(sourceFile, []byte("test content"), 0644))
 
-	// Create library with OrganizeFileStructure enabled
+	// Create library with organizing enabled
 	library := &models.Library{
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -138,7 +139,8 @@ func TestMoveFilesToBook_TargetBookHasSyntheticPath_CreatesDirAndMoves(t *testin
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -234,7 +236,8 @@ func TestMoveFilesToBook_MoveFailure_CleansUpCreatedDestDirs(t *testing.T) {
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -305,7 +308,7 @@ func TestMoveFilesToBook_MoveFailure_CleansUpCreatedDestDirs(t *testing.T) {
 		"expected top-most created parent dir to also be cleaned up")
 }
 
-func TestMoveFilesToBook_WithoutOrganizeFileStructure_NoPhysicalMove(t *testing.T) {
+func TestMoveFilesToBook_WithoutOrganizeFolders_NoPhysicalMove(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 	db := setupTestDB(t)
@@ -322,12 +325,13 @@ func TestMoveFilesToBook_WithoutOrganizeFileStructure_NoPhysicalMove(t *testing.
 	sourceFile := filepath.Join(sourceDir, "test.epub")
 	require.NoError(t, os.WriteFile(sourceFile, []byte("test content"), 0644))
 
-	// Create library with OrganizeFileStructure DISABLED
+	// Create library with organizing disabled
 	library := &models.Library{
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    false,
+		OrganizeFiles:            false,
+		OrganizeFolders:          false,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -387,7 +391,7 @@ func TestMoveFilesToBook_WithoutOrganizeFileStructure_NoPhysicalMove(t *testing.
 
 	assert.Equal(t, 1, result.FilesMoved)
 
-	// Verify file was NOT physically moved (OrganizeFileStructure is disabled)
+	// Verify file was NOT physically moved (organizing is disabled)
 	_, err = os.Stat(sourceFile)
 	require.NoError(t, err, "file should still exist at original location")
 
@@ -398,7 +402,7 @@ func TestMoveFilesToBook_WithoutOrganizeFileStructure_NoPhysicalMove(t *testing.
 	assert.Equal(t, targetBook.ID, movedFile.BookID, "book_id should be updated")
 }
 
-func TestMoveFilesToBook_WithOrganizeFileStructure_HandlesDuplicateFilename(t *testing.T) {
+func TestMoveFilesToBook_WithOrganizeFolders_HandlesDuplicateFilename(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 	db := setupTestDB(t)
@@ -419,12 +423,13 @@ func TestMoveFilesToBook_WithOrganizeFileStructure_HandlesDuplicateFilename(t *t
 	existingFile := filepath.Join(targetDir, "book.epub")
 	require.NoError(t, os.WriteFile(existingFile, []byte("existing content"), 0644))
 
-	// Create library with OrganizeFileStructure enabled
+	// Create library with organizing enabled
 	library := &models.Library{
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -548,12 +553,13 @@ func TestMoveFilesToBook_MovesAssociatedFilesAndCleansUpDirectory(t *testing.T)
 
 	coverPath := "book.m4b.cover.jpg"
 
-	// Create library with OrganizeFileStructure enabled
+	// Create library with organizing enabled
 	library := &models.Library{
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -700,12 +706,13 @@ func TestMoveFilesToBook_MovesSupplementFiles(t *testing.T) {
 
 	now := time.Now()
 
-	// Create library with OrganizeFileStructure enabled
+	// Create library with organizing enabled
 	library := &models.Library{
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 		CreatedAt:                now,
 		UpdatedAt:                now,
 	}
@@ -825,7 +832,7 @@ func ptrString(s string) *string {
 	return &s
 }
 
-func TestMoveFilesToBook_CreateNewBook_WithOrganizeFileStructure_GeneratesUniqueDirectory(t *testing.T) {
+func TestMoveFilesToBook_CreateNewBook_WithOrganizeFolders_GeneratesUniqueDirectory(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 	db := setupTestDB(t)
@@ -842,12 +849,13 @@ func TestMoveFilesToBook_CreateNewBook_WithOrganizeFileStructure_GeneratesUnique
 	require.NoError(t, os.WriteFile(file1Path, []byte("content1"), 0644))
 	require.NoError(t, os.WriteFile(file2Path, []byte("content2"), 0644))
 
-	// Create library with OrganizeFileStructure enabled
+	// Create library with organizing enabled
 	library := &models.Library{
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -952,7 +960,7 @@ func TestMoveFilesToBook_CreateNewBook_WithOrganizeFileStructure_GeneratesUnique
 	assert.True(t, os.IsNotExist(err), "file should not exist at old location")
 }
 
-func TestMoveFilesToBook_CreateNewBook_WithoutOrganizeFileStructure_ReturnsError(t *testing.T) {
+func TestMoveFilesToBook_CreateNewBook_WithoutOrganizeFolders_ReturnsError(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 	db := setupTestDB(t)
@@ -969,12 +977,13 @@ func TestMoveFilesToBook_CreateNewBook_WithoutOrganizeFileStructure_ReturnsError
 	require.NoError(t, os.WriteFile(file1Path, []byte("content1"), 0644))
 	require.NoError(t, os.WriteFile(file2Path, []byte("content2"), 0644))
 
-	// Create library with OrganizeFileStructure DISABLED
+	// Create library with organizing disabled
 	library := &models.Library{
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    false,
+		OrganizeFiles:            false,
+		OrganizeFolders:          false,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -1022,17 +1031,100 @@ func TestMoveFilesToBook_CreateNewBook_WithoutOrganizeFileStructure_ReturnsError
 	require.NoError(t, err)
 
 	// Try to move file2 to a NEW book (TargetBookID = nil)
-	// This should fail because OrganizeFileStructure is disabled and a book
+	// This should fail because organizing is disabled and a book
 	// already exists at the file's directory
 	_, err = svc.MoveFilesToBook(ctx, MoveFilesOptions{
 		FileIDs:      []int{file2.ID},
 		TargetBookID: nil, // Create new book
 		LibraryID:    library.ID,
 	})
-	require.Error(t, err, "should fail when creating new book without OrganizeFileStructure")
+	require.Error(t, err, "should fail when creating new book without OrganizeFolders")
 	assert.Contains(t, err.Error(), "cannot create a new book", "error should explain the reason")
 
 	// Verify file was not moved
 	_, err = os.Stat(file2Path)
 	require.NoError(t, err, "file should still exist at original location")
 }
+
+func TestMoveFileToBook_DeletesEmptySourceBook(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupTestDB(t)
+	svc := NewService(db)
+
+	tmpDir := t.TempDir()
+	sourceDir := filepath.Join(tmpDir, "source")
+	targetDir := filepath.Join(tmpDir, "target")
+	require.NoError(t, os.MkdirAll(sourceDir, 0755))
+	require.NoError(t, os.MkdirAll(targetDir, 0755))
+	sourceFile := filepath.Join(sourceDir, "test.epub")
+	require.NoError(t, os.WriteFile(sourceFile, []byte("test content"), 0644))
+
+	library := &models.Library{
+		Name:                     "Test Library",
+		CoverAspectRatio:         "book",
+		DownloadFormatPreference: models.DownloadFormatOriginal,
+	}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+	_, err = db.NewInsert().Model(&models.LibraryPath{LibraryID: library.ID, Filepath: tmpDir}).Exec(ctx)
+	require.NoError(t, err)
+
+	now := time.Now()
+	newBook := func(title, path string) *models.Book {
+		book := &models.Book{
+			LibraryID:       library.ID,
+			Title:           title,
+			TitleSource:     models.DataSourceFilepath,
+			SortTitle:       title,
+			SortTitleSource: models.DataSourceFilepath,
+			AuthorSource:    models.DataSourceFilepath,
+			Filepath:        path,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		_, err := db.NewInsert().Model(book).Exec(ctx)
+		require.NoError(t, err)
+		return book
+	}
+	sourceBook := newBook("Source Book", sourceDir)
+	targetBook := newBook("Target Book", targetDir)
+
+	file := &models.File{
+		LibraryID:     library.ID,
+		BookID:        sourceBook.ID,
+		FileType:      models.FileTypeEPUB,
+		FileRole:      models.FileRoleMain,
+		Filepath:      sourceFile,
+		FilesizeBytes: 12,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	_, err = db.NewInsert().Model(file).Exec(ctx)
+	require.NoError(t, err)
+
+	result, err := svc.MoveFileToBook(ctx, file.ID, targetBook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.FilesMoved)
+	assert.True(t, result.SourceBookDeleted)
+	assert.Equal(t, []int{sourceBook.ID}, result.DeletedBookIDs)
+
+	// Organize is off, so the file stays where it is on disk.
+	movedFile, err := svc.RetrieveFile(ctx, RetrieveFileOptions{ID: &file.ID})
+	require.NoError(t, err)
+	assert.Equal(t, targetBook.ID, movedFile.BookID)
+	assert.Equal(t, sourceFile, movedFile.Filepath)
+
+	_, err = svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &sourceBook.ID})
+	require.Error(t, err)
+}
+
+func TestMoveFileToBook_FileNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupTestDB(t)
+	svc := NewService(db)
+
+	_, err := svc.MoveFileToBook(ctx, 999, 1)
+	require.Error(t, err)
+}
@@ -25,7 +25,8 @@ func setupTestLibrary(t *testing.T, db *bun.DB) *models.Library {
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    false,
+		OrganizeFiles:            false,
+		OrganizeFolders:          false,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
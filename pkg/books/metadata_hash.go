@@ -0,0 +1,156 @@
+package books
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// ComputeMetadataHash returns a stable hex-encoded SHA-256 of a book's
+// metadata so that external clients can cheaply tell whether it changed.
+// It covers the title, authors (with roles), series (with numbers), tags and
+// the identifiers of the book's main files. Values are whitespace-normalized,
+// and tags and identifiers are sorted and de-duplicated so that ordering
+// alone doesn't change the hash. Author and series order is meaningful and is
+// preserved. The book must be loaded with those relations (RetrieveBook does).
+func ComputeMetadataHash(book *models.Book) string {
+	h := sha256.New()
+	write := func(field string, values ...string) {
+		h.Write([]byte(field))
+		for _, v := range values {
+			// Unit separators keep e.g. ["ab", "c"] and ["a", "bc"] distinct.
+			h.Write([]byte{0x1f})
+			h.Write([]byte(v))
+		}
+		h.Write([]byte{0x1e})
+	}
+
+	write("title", normalizeHashValue(book.Title))
+
+	for _, a := range book.Authors {
+		if a.Person == nil {
+			continue
+		}
+		role := ""
+		if a.Role != nil {
+			role = *a.Role
+		}
+		write("author", normalizeHashValue(a.Person.Name), role)
+	}
+
+	for _, bs := range book.BookSeries {
+		if bs.Series == nil {
+			continue
+		}
+		write("series", normalizeHashValue(bs.Series.Name), formatHashNumber(bs.SeriesNumber), formatHashNumber(bs.SeriesNumberEnd))
+	}
+
+	tags := make([]string, 0, len(book.BookTags))
+	for _, bt := range book.BookTags {
+		if bt.Tag != nil {
+			tags = append(tags, normalizeHashValue(bt.Tag.Name))
+		}
+	}
+	write("tags", sortedUnique(tags)...)
+
+	var identifiers []string
+	for _, f := range book.Files {
+		if f.FileRole == models.FileRoleSupplement {
+			continue
+		}
+		for _, id := range f.Identifiers {
+			identifiers = append(identifiers, id.Type+":"+normalizeHashValue(id.Value))
+		}
+	}
+	write("identifiers", sortedUnique(identifiers)...)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// RefreshMetadataHash recomputes the book's metadata hash and stores it if it
+// changed. The book must be loaded with its relations.
+func (svc *Service) RefreshMetadataHash(ctx context.Context, book *models.Book) error {
+	hash := ComputeMetadataHash(book)
+	if hash == book.MetadataHash {
+		return nil
+	}
+	book.MetadataHash = hash
+	_, err := svc.db.NewUpdate().
+		Model(book).
+		Column("metadata_hash").
+		WherePK().
+		Exec(ctx)
+	return errors.WithStack(err)
+}
+
+// RefreshMetadataHashes reloads each of the books and refreshes its metadata
+// hash. It's for writes that change the hashed metadata of many books at
+// once, such as renaming a person or tag.
+func (svc *Service) RefreshMetadataHashes(ctx context.Context, bookIDs []int) error {
+	for _, id := range bookIDs {
+		book, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &id})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := svc.RefreshMetadataHash(ctx, book); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BackfillMetadataHashes computes the metadata hash for every book in the
+// library that doesn't have one yet (e.g. books scanned before the hash was
+// introduced). It returns the number of books updated.
+func (svc *Service) BackfillMetadataHashes(ctx context.Context, libraryID int) (int, error) {
+	var ids []int
+	err := svc.db.NewSelect().
+		Model((*models.Book)(nil)).
+		Column("id").
+		Where("library_id = ?", libraryID).
+		Where("metadata_hash IS NULL").
+		Scan(ctx, &ids)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	for _, id := range ids {
+		book, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &id})
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		if err := svc.RefreshMetadataHash(ctx, book); err != nil {
+			return 0, err
+		}
+	}
+	return len(ids), nil
+}
+
+func normalizeHashValue(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func formatHashNumber(n *float64) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*n, 'f', -1, 64)
+}
+
+func sortedUnique(values []string) []string {
+	sort.Strings(values)
+	out := values[:0]
+	for _, v := range values {
+		if len(out) > 0 && out[len(out)-1] == v {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package books
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hashTestBook() *models.Book {
+	one := 1.0
+	return &models.Book{
+		Title: "The Way of Kings",
+		Authors: []*models.Author{
+			{Person: &models.Person{Name: "Brandon Sanderson"}},
+		},
+		BookSeries: []*models.BookSeries{
+			{Series: &models.Series{Name: "The Stormlight Archive"}, SeriesNumber: &one},
+		},
+		BookTags: []*models.BookTag{
+			{Tag: &models.Tag{Name: "Epic"}},
+			{Tag: &models.Tag{Name: "Fantasy"}},
+		},
+		Files: []*models.File{
+			{
+				FileRole: models.FileRoleMain,
+				Identifiers: []*models.FileIdentifier{
+					{Type: models.IdentifierTypeISBN13, Value: "9780765326355"},
+					{Type: models.IdentifierTypeASIN, Value: "B003P2WO5E"},
+				},
+			},
+		},
+	}
+}
+
+func TestComputeMetadataHash(t *testing.T) {
+	t.Parallel()
+
+	base := ComputeMetadataHash(hashTestBook())
+	assert.Len(t, base, 64)
+	assert.Equal(t, base, ComputeMetadataHash(hashTestBook()), "hash should be deterministic")
+
+	t.Run("ignores tag and identifier order", func(t *testing.T) {
+		t.Parallel()
+		book := hashTestBook()
+		book.BookTags[0], book.BookTags[1] = book.BookTags[1], book.BookTags[0]
+		ids := book.Files[0].Identifiers
+		ids[0], ids[1] = ids[1], ids[0]
+		assert.Equal(t, base, ComputeMetadataHash(book))
+	})
+
+	t.Run("ignores extra whitespace", func(t *testing.T) {
+		t.Parallel()
+		book := hashTestBook()
+		book.Title = "  The Way  of Kings "
+		assert.Equal(t, base, ComputeMetadataHash(book))
+	})
+
+	t.Run("ignores supplement identifiers", func(t *testing.T) {
+		t.Parallel()
+		book := hashTestBook()
+		book.Files = append(book.Files, &models.File{
+			FileRole:    models.FileRoleSupplement,
+			Identifiers: []*models.FileIdentifier{{Type: models.IdentifierTypeOther, Value: "x"}},
+		})
+		assert.Equal(t, base, ComputeMetadataHash(book))
+	})
+
+	changes := []struct {
+		name   string
+		mutate func(*models.Book)
+	}{
+		{"title", func(b *models.Book) { b.Title = "Words of Radiance" }},
+		{"author", func(b *models.Book) { b.Authors[0].Person.Name = "Someone Else" }},
+		{"author role", func(b *models.Book) {
+			role := models.AuthorRoleWriter
+			b.Authors[0].Role = &role
+		}},
+		{"series number", func(b *models.Book) {
+			two := 2.0
+			b.BookSeries[0].SeriesNumber = &two
+		}},
+		{"tag removed", func(b *models.Book) { b.BookTags = b.BookTags[:1] }},
+		{"identifier", func(b *models.Book) { b.Files[0].Identifiers[0].Value = "9780765326362" }},
+	}
+	for _, tt := range changes {
+		t.Run("changes with "+tt.name, func(t *testing.T) {
+			t.Parallel()
+			book := hashTestBook()
+			tt.mutate(book)
+			assert.NotEqual(t, base, ComputeMetadataHash(book))
+		})
+	}
+}
+
+func TestBackfillMetadataHashes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupTestDB(t)
+	library, book := setupTestLibraryAndBook(t, db)
+	svc := NewService(db)
+
+	n, err := svc.BackfillMetadataHashes(ctx, library.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	stored, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &book.ID})
+	require.NoError(t, err)
+	assert.Equal(t, ComputeMetadataHash(stored), stored.MetadataHash)
+	originalHash := stored.MetadataHash
+
+	// Books that already have a hash are left alone.
+	n, err = svc.BackfillMetadataHashes(ctx, library.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	stored.Title = "Renamed"
+	require.NoError(t, svc.UpdateBook(ctx, stored, UpdateBookOptions{Columns: []string{"title"}}))
+	require.NoError(t, svc.RefreshMetadataHash(ctx, stored))
+
+	refreshed, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &book.ID})
+	require.NoError(t, err)
+	assert.Equal(t, ComputeMetadataHash(refreshed), refreshed.MetadataHash)
+	assert.NotEqual(t, originalHash, refreshed.MetadataHash)
+}
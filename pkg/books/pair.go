@@ -0,0 +1,176 @@
+package books
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/uptrace/bun"
+)
+
+// pairIdentifierTypes are the identifiers specific enough to pair an
+// audiobook with an ebook of the same title.
+var pairIdentifierTypes = []string{
+	models.IdentifierTypeISBN10,
+	models.IdentifierTypeISBN13,
+	models.IdentifierTypeASIN,
+}
+
+// PairBooks links the audiobook and ebook editions of a title by moving all of
+// bookB's files onto bookA, so both formats share bookA's metadata. bookB is
+// deleted once it has no files left. One book must hold only audiobook main
+// files and the other only ebook main files.
+func (svc *Service) PairBooks(ctx context.Context, bookAID, bookBID int, ignoredPatterns ...string) (*MoveFilesResult, error) {
+	if bookAID == bookBID {
+		return nil, errcodes.ValidationError("A book can't be paired with itself")
+	}
+	bookA, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &bookAID})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	bookB, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &bookBID})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if bookA.LibraryID != bookB.LibraryID {
+		return nil, errcodes.ValidationError("Only books in the same library can be paired")
+	}
+
+	aAudio, aEbook := mainFileKinds(bookA)
+	bAudio, bEbook := mainFileKinds(bookB)
+	if !(aAudio && !aEbook && bEbook && !bAudio) && !(aEbook && !aAudio && bAudio && !bEbook) {
+		return nil, errcodes.ValidationError("Pairing needs one audiobook-only book and one ebook-only book")
+	}
+
+	fileIDs := make([]int, 0, len(bookB.Files))
+	for _, f := range bookB.Files {
+		fileIDs = append(fileIDs, f.ID)
+	}
+	result, err := svc.MoveFilesToBook(ctx, MoveFilesOptions{
+		FileIDs:         fileIDs,
+		TargetBookID:    &bookA.ID,
+		LibraryID:       bookA.LibraryID,
+		IgnoredPatterns: ignoredPatterns,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return result, nil
+}
+
+// FindPairableBook looks for a book in the same library that holds the other
+// format of book: ebook files when book is an audiobook, or audiobook files
+// when it's an ebook. Books matching by ISBN or ASIN are preferred over ones
+// matching by title and author. Returns nil when nothing matches or when book
+// already has both formats.
+func (svc *Service) FindPairableBook(ctx context.Context, book *models.Book) (*models.Book, error) {
+	// Only books holding a single format can be paired.
+	audio, ebook := mainFileKinds(book)
+	if audio == ebook {
+		return nil, nil
+	}
+
+	var values []string
+	for _, f := range book.Files {
+		for _, id := range f.Identifiers {
+			for _, t := range pairIdentifierTypes {
+				if id.Type == t && id.Value != "" {
+					values = append(values, id.Value)
+				}
+			}
+		}
+	}
+	var authorNames []string
+	for _, a := range book.Authors {
+		if a.Person != nil {
+			authorNames = append(authorNames, a.Person.Name)
+		}
+	}
+
+	var candidateIDs []int
+	if len(values) > 0 {
+		err := svc.db.NewSelect().
+			Model((*models.FileIdentifier)(nil)).
+			ColumnExpr("DISTINCT f.book_id").
+			Join("JOIN files AS f ON f.id = fi.file_id").
+			Where("f.library_id = ?", book.LibraryID).
+			Where("f.book_id != ?", book.ID).
+			Where("f.file_role = ?", models.FileRoleMain).
+			Where("fi.type IN (?)", bun.In(pairIdentifierTypes)).
+			Where("fi.value IN (?)", bun.In(values)).
+			Scan(ctx, &candidateIDs)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	title := normalizePairName(book.Title)
+	if title != "" && len(authorNames) > 0 {
+		var titleMatches []*models.Book
+		err := svc.db.NewSelect().
+			Model(&titleMatches).
+			Relation("Authors.Person").
+			Where("b.library_id = ?", book.LibraryID).
+			Where("b.id != ?", book.ID).
+			Where("lower(trim(b.title)) = ?", title).
+			Order("b.id ASC").
+			Scan(ctx)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		for _, b := range titleMatches {
+			if sharesAuthor(b, authorNames) {
+				candidateIDs = append(candidateIDs, b.ID)
+			}
+		}
+	}
+
+	for _, id := range candidateIDs {
+		candidate, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &id})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		candidateAudio, candidateEbook := mainFileKinds(candidate)
+		if candidateAudio != candidateEbook && candidateAudio != audio {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
+
+// mainFileKinds reports whether book has audiobook (M4B) and ebook (any other
+// type) main files.
+func mainFileKinds(book *models.Book) (audio, ebook bool) {
+	for _, f := range book.Files {
+		if f.FileRole != models.FileRoleMain {
+			continue
+		}
+		if f.FileType == models.FileTypeM4B {
+			audio = true
+		} else {
+			ebook = true
+		}
+	}
+	return audio, ebook
+}
+
+func sharesAuthor(book *models.Book, names []string) bool {
+	for _, a := range book.Authors {
+		if a.Person == nil {
+			continue
+		}
+		for _, name := range names {
+			if normalizePairName(a.Person.Name) == normalizePairName(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizePairName lowercases s and collapses runs of whitespace.
+func normalizePairName(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
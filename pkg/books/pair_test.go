@@ -0,0 +1,127 @@
+package books
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+// setupTestAudiobook creates a book whose only file is an M4B.
+func setupTestAudiobook(t *testing.T, db *bun.DB, library *models.Library, title string) (*models.Book, *models.File) {
+	t.Helper()
+	book, file := setupTestBookWithFile(t, db, library, title)
+	file.FileType = models.FileTypeM4B
+	_, err := db.NewUpdate().Model(file).Column("file_type").WherePK().Exec(context.Background())
+	require.NoError(t, err)
+	return book, file
+}
+
+func TestPairBooks(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	library := setupTestLibrary(t, db)
+	svc := NewService(db)
+
+	ebook, _ := setupTestBookWithFile(t, db, library, "Wind and Truth")
+	otherEbook, _ := setupTestBookWithFile(t, db, library, "Wind and Truth (Copy)")
+	audiobook, audioFile := setupTestAudiobook(t, db, library, "Wind and Truth Audio")
+
+	// Two ebooks aren't a pair.
+	_, err := svc.PairBooks(ctx, ebook.ID, otherEbook.ID)
+	var codeErr *errcodes.Error
+	require.ErrorAs(t, err, &codeErr)
+	assert.Equal(t, "validation_error", codeErr.Code)
+
+	result, err := svc.PairBooks(ctx, ebook.ID, audiobook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.FilesMoved)
+	assert.Equal(t, []int{audiobook.ID}, result.DeletedBookIDs)
+
+	paired, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &ebook.ID})
+	require.NoError(t, err)
+	require.Len(t, paired.Files, 2)
+	var fileIDs []int
+	for _, f := range paired.Files {
+		fileIDs = append(fileIDs, f.ID)
+	}
+	assert.Contains(t, fileIDs, audioFile.ID)
+}
+
+func TestFindPairableBook(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	library := setupTestLibrary(t, db)
+	svc := NewService(db)
+
+	ebook, ebookFile := setupTestBookWithFile(t, db, library, "Wind and Truth")
+	_, err := db.NewInsert().Model(&models.FileIdentifier{
+		FileID: ebookFile.ID,
+		Type:   models.IdentifierTypeISBN13,
+		Value:  "9781250319180",
+		Source: models.DataSourceEPUBMetadata,
+	}).Exec(ctx)
+	require.NoError(t, err)
+	person := &models.Person{
+		LibraryID:      library.ID,
+		Name:           "Brandon Sanderson",
+		SortName:       "Sanderson, Brandon",
+		SortNameSource: models.DataSourceFilepath,
+	}
+	_, err = db.NewInsert().Model(person).Exec(ctx)
+	require.NoError(t, err)
+	_, err = db.NewInsert().Model(&models.Author{BookID: ebook.ID, PersonID: person.ID, SortOrder: 1}).Exec(ctx)
+	require.NoError(t, err)
+
+	// The ISBN matches even though the titles differ.
+	isbnAudio, isbnAudioFile := setupTestAudiobook(t, db, library, "Wind & Truth (Unabridged)")
+	_, err = db.NewInsert().Model(&models.FileIdentifier{
+		FileID: isbnAudioFile.ID,
+		Type:   models.IdentifierTypeISBN13,
+		Value:  "9781250319180",
+		Source: models.DataSourceM4BMetadata,
+	}).Exec(ctx)
+	require.NoError(t, err)
+	isbnAudio, err = svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &isbnAudio.ID})
+	require.NoError(t, err)
+	match, err := svc.FindPairableBook(ctx, isbnAudio)
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	assert.Equal(t, ebook.ID, match.ID)
+
+	// Without identifiers, title and author are compared ignoring case and
+	// extra whitespace.
+	titleAudio, _ := setupTestAudiobook(t, db, library, "wind and  TRUTH")
+	_, err = db.NewInsert().Model(&models.Author{BookID: titleAudio.ID, PersonID: person.ID, SortOrder: 1}).Exec(ctx)
+	require.NoError(t, err)
+	titleAudio, err = svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &titleAudio.ID})
+	require.NoError(t, err)
+	match, err = svc.FindPairableBook(ctx, titleAudio)
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	assert.Equal(t, ebook.ID, match.ID)
+
+	// The same title by a different author doesn't match.
+	other := &models.Person{
+		LibraryID:      library.ID,
+		Name:           "Someone Else",
+		SortName:       "Else, Someone",
+		SortNameSource: models.DataSourceFilepath,
+	}
+	_, err = db.NewInsert().Model(other).Exec(ctx)
+	require.NoError(t, err)
+	otherAudio, _ := setupTestAudiobook(t, db, library, "Wind and Truth")
+	_, err = db.NewInsert().Model(&models.Author{BookID: otherAudio.ID, PersonID: other.ID, SortOrder: 1}).Exec(ctx)
+	require.NoError(t, err)
+	otherAudio, err = svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &otherAudio.ID})
+	require.NoError(t, err)
+	match, err = svc.FindPairableBook(ctx, otherAudio)
+	require.NoError(t, err)
+	assert.Nil(t, match)
+}
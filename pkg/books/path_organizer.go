@@ -0,0 +1,230 @@
+package books
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/fileutils"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/plugins"
+	"github.com/shishobooks/shisho/pkg/sidecar"
+)
+
+// PathOrganizer chooses where a library's organized files go in place of the
+// built-in naming. The plugin manager implements it for organizer plugins.
+type PathOrganizer interface {
+	// OrganizedPath returns where file should go, relative to libraryPath,
+	// or "" to leave it to the built-in naming.
+	OrganizedPath(ctx context.Context, book *models.Book, file *models.File, libraryPath string) (string, error)
+}
+
+var _ PathOrganizer = (*plugins.Manager)(nil)
+
+// RenameOrganizedFile renames file after a file-level change, like a new
+// name or narrators, and returns its new path. When an organizer plugin is in
+// charge of the library, it chooses the path: the book's files are organized
+// with file's pending changes, and file's new path and cover are saved and
+// set on it. Otherwise file is renamed in its folder with opts, leaving the
+// book sidecar alone, and saving the new path is up to the caller.
+func (svc *Service) RenameOrganizedFile(ctx context.Context, library *models.Library, book *models.Book, file *models.File, opts fileutils.OrganizedNameOptions) (string, error) {
+	if svc.pathOrganizer != nil && library.OrganizeFiles && library.OrganizeFolders {
+		files, err := svc.ListFiles(ctx, ListFilesOptions{BookID: &book.ID})
+		if err != nil {
+			return file.Filepath, errors.WithStack(err)
+		}
+		// The organizer sees file as it is in memory, so changes that
+		// haven't been saved yet still count.
+		for i, f := range files {
+			if f.ID == file.ID {
+				files[i] = file
+			}
+		}
+		handled, err := svc.organizeWithPathOrganizer(ctx, library, book, files)
+		if err != nil || handled {
+			return file.Filepath, err
+		}
+	}
+	return fileutils.RenameOrganizedFileOnly(file.Filepath, opts)
+}
+
+// organizeWithPathOrganizer moves a book's files to the paths
+// svc.pathOrganizer chooses. It reports false without touching anything when
+// the organizer chose no path for any of the files, so the built-in naming
+// runs instead. Otherwise the organizer is in charge of the book: files it
+// has no path for, fails on, or gives an invalid path for stay where they
+// are.
+func (svc *Service) organizeWithPathOrganizer(ctx context.Context, library *models.Library, book *models.Book, files []*models.File) (bool, error) {
+	log := logger.FromContext(ctx)
+
+	libraryPath := containingLibraryPath(files[0].Filepath, library.LibraryPaths)
+	if libraryPath == "" {
+		return false, nil
+	}
+
+	handled := false
+	targets := make(map[int]string, len(files))
+	for _, file := range files {
+		rel, err := svc.pathOrganizer.OrganizedPath(ctx, book, file, libraryPath)
+		if err != nil {
+			log.Error("organizer failed", logger.Data{
+				"file_id": file.ID,
+				"path":    file.Filepath,
+				"error":   err.Error(),
+			})
+			handled = true
+			continue
+		}
+		if rel == "" {
+			continue
+		}
+		handled = true
+
+		target, err := organizerTargetPath(libraryPath, rel, file.Filepath, svc.sanitizeOptions)
+		if err != nil {
+			log.Warn("ignoring organizer path", logger.Data{
+				"file_id": file.ID,
+				"path":    rel,
+				"error":   err.Error(),
+			})
+			continue
+		}
+		targets[file.ID] = target
+	}
+	if !handled {
+		return false, nil
+	}
+
+	now := time.Now()
+	for _, file := range files {
+		target, ok := targets[file.ID]
+		if !ok || target == file.Filepath {
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			log.Error("failed to create organizer folder", logger.Data{
+				"file_id": file.ID,
+				"path":    target,
+				"error":   err.Error(),
+			})
+			continue
+		}
+		target = fileutils.GenerateUniqueFilepathIfExists(target)
+		if _, err := fileutils.MoveFileWithAssociatedFiles(file.Filepath, target); err != nil {
+			log.Error("failed to move file to organizer path", logger.Data{
+				"file_id": file.ID,
+				"path":    file.Filepath,
+				"error":   err.Error(),
+			})
+			continue
+		}
+		log.Info("moved file to organizer path", logger.Data{
+			"file_id":  file.ID,
+			"old_path": file.Filepath,
+			"new_path": target,
+		})
+
+		q := svc.db.NewUpdate().
+			Model((*models.File)(nil)).
+			Set("filepath = ?, updated_at = ?", target, now).
+			Where("id = ?", file.ID)
+		if file.CoverImageFilename != nil {
+			newCoverPath := fileutils.ComputeNewCoverFilename(*file.CoverImageFilename, target)
+			file.CoverImageFilename = &newCoverPath
+			q = q.Set("cover_image_filename = ?", newCoverPath)
+		}
+		if _, err := q.Exec(ctx); err != nil {
+			return true, errors.WithStack(err)
+		}
+
+		oldDir := filepath.Dir(file.Filepath)
+		file.Filepath = target
+		if oldDir != book.Filepath {
+			_ = fileutils.CleanupEmptyParentDirectories(oldDir, libraryPath)
+		}
+	}
+
+	// The book lives in its first file's folder, like a scanned directory.
+	newBookPath := filepath.Dir(files[0].Filepath)
+	if newBookPath == book.Filepath {
+		return true, nil
+	}
+	oldBookPath := book.Filepath
+	book.Filepath = newBookPath
+	book.UpdatedAt = now
+	_, err := svc.db.NewUpdate().
+		Model(book).
+		Column("filepath", "updated_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return true, errors.WithStack(err)
+	}
+
+	// Drop the old folder's book sidecar and, if nothing else is left, the
+	// folder and any parents it emptied.
+	if !isLibraryRoot(oldBookPath, library.LibraryPaths) {
+		svc.cleanUpStaleRootLevelBookFolder(ctx, oldBookPath)
+		_ = fileutils.CleanupEmptyParentDirectories(filepath.Dir(oldBookPath), libraryPath)
+	}
+	if err := sidecar.WriteBookSidecarFromModel(book); err != nil {
+		log.Warn("failed to write book sidecar after organize", logger.Data{
+			"book_id": book.ID,
+			"path":    book.Filepath,
+			"error":   err.Error(),
+		})
+	}
+	return true, nil
+}
+
+// organizerTargetPath turns a path an organizer chose for the file at
+// currentPath into an absolute path inside libraryPath. The path is
+// sanitized, must put the file in a folder, since books are folders, and
+// gets the file's extension if it doesn't already end with it.
+func organizerTargetPath(libraryPath, rel, currentPath string, opts fileutils.SanitizeOptions) (string, error) {
+	clean, err := fileutils.SanitizeRelativePath(rel, opts)
+	if err != nil {
+		return "", err
+	}
+	if filepath.Dir(clean) == "." {
+		return "", errors.Errorf("path %q must include a folder", rel)
+	}
+	ext := filepath.Ext(currentPath)
+	if !strings.EqualFold(filepath.Ext(clean), ext) {
+		clean += ext
+	}
+
+	target := filepath.Join(libraryPath, clean)
+	// SanitizeRelativePath already refuses "..", so this only guards
+	// against a library path that isn't clean itself.
+	if relToLibrary, err := filepath.Rel(libraryPath, target); err != nil || strings.HasPrefix(relToLibrary, "..") {
+		return "", errors.Errorf("path %q leaves the library", rel)
+	}
+	return target, nil
+}
+
+// containingLibraryPath returns the library path filePath is under, or "" if
+// it isn't under any of them.
+func containingLibraryPath(filePath string, libraryPaths []*models.LibraryPath) string {
+	for _, lp := range libraryPaths {
+		if rel, err := filepath.Rel(lp.Filepath, filePath); err == nil && !strings.HasPrefix(rel, "..") {
+			return lp.Filepath
+		}
+	}
+	return ""
+}
+
+// isLibraryRoot reports whether dir is one of the library's root paths.
+func isLibraryRoot(dir string, libraryPaths []*models.LibraryPath) bool {
+	for _, lp := range libraryPaths {
+		if dir == lp.Filepath {
+			return true
+		}
+	}
+	return false
+}
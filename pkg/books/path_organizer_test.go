@@ -0,0 +1,210 @@
+package books
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/fileutils"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/sidecar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPathOrganizer returns paths keyed by file type.
+type stubPathOrganizer map[string]string
+
+func (s stubPathOrganizer) OrganizedPath(_ context.Context, _ *models.Book, file *models.File, _ string) (string, error) {
+	return s[file.FileType], nil
+}
+
+func setupPathOrganizerBook(t *testing.T, svc *Service) (string, *models.Book, *models.File) {
+	t.Helper()
+	ctx := context.Background()
+
+	libDir := t.TempDir()
+	library := &models.Library{
+		Name:                     "Test Library",
+		CoverAspectRatio:         "book",
+		DownloadFormatPreference: models.DownloadFormatOriginal,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
+	}
+	_, err := svc.db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+	_, err = svc.db.NewInsert().Model(&models.LibraryPath{LibraryID: library.ID, Filepath: libDir}).Exec(ctx)
+	require.NoError(t, err)
+
+	bookDir := filepath.Join(libDir, "[Author] Title")
+	require.NoError(t, os.MkdirAll(bookDir, 0755))
+	require.NoError(t, sidecar.WriteBookSidecar(bookDir, &sidecar.BookSidecar{Title: "Title"}))
+	filePath := filepath.Join(bookDir, "Title.epub")
+	require.NoError(t, os.WriteFile(filePath, []byte("epub"), 0644))
+	require.NoError(t, os.WriteFile(filePath+".cover.jpg", []byte("jpg"), 0644))
+
+	book := &models.Book{
+		LibraryID:       library.ID,
+		Title:           "Title",
+		TitleSource:     models.DataSourceFilepath,
+		SortTitle:       "Title",
+		SortTitleSource: models.DataSourceFilepath,
+		AuthorSource:    models.DataSourceFilepath,
+		Filepath:        bookDir,
+	}
+	_, err = svc.db.NewInsert().Model(book).Exec(ctx)
+	require.NoError(t, err)
+
+	cover := "Title.epub.cover.jpg"
+	file := &models.File{
+		LibraryID:          library.ID,
+		BookID:             book.ID,
+		FileType:           models.FileTypeEPUB,
+		FileRole:           models.FileRoleMain,
+		Filepath:           filePath,
+		FilesizeBytes:      4,
+		CoverImageFilename: &cover,
+	}
+	_, err = svc.db.NewInsert().Model(file).Exec(ctx)
+	require.NoError(t, err)
+
+	loaded, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &book.ID})
+	require.NoError(t, err)
+	return libDir, loaded, file
+}
+
+func TestOrganizeBookFiles_UsesPathOrganizer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc := NewService(setupTestDB(t)).WithPathOrganizer(stubPathOrganizer{
+		models.FileTypeEPUB: "Fantasy/Someone/Custom Name",
+	})
+	libDir, book, file := setupPathOrganizerBook(t, svc)
+	oldBookDir := book.Filepath
+
+	require.NoError(t, svc.OrganizeBookFiles(ctx, book))
+
+	newDir := filepath.Join(libDir, "Fantasy", "Someone")
+	newPath := filepath.Join(newDir, "Custom Name.epub")
+	assert.FileExists(t, newPath)
+	assert.FileExists(t, newPath+".cover.jpg")
+	assert.FileExists(t, sidecar.BookSidecarPath(newDir))
+	_, err := os.Stat(oldBookDir)
+	assert.True(t, os.IsNotExist(err), "old book folder should be removed")
+
+	reloadedFile, err := svc.RetrieveFile(ctx, RetrieveFileOptions{ID: &file.ID})
+	require.NoError(t, err)
+	assert.Equal(t, newPath, reloadedFile.Filepath)
+	require.NotNil(t, reloadedFile.CoverImageFilename)
+	assert.Equal(t, "Custom Name.epub.cover.jpg", *reloadedFile.CoverImageFilename)
+
+	reloadedBook, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &book.ID})
+	require.NoError(t, err)
+	assert.Equal(t, newDir, reloadedBook.Filepath)
+}
+
+func TestOrganizeWithPathOrganizer_NoPathFallsBack(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc := NewService(setupTestDB(t)).WithPathOrganizer(stubPathOrganizer{})
+	_, book, file := setupPathOrganizerBook(t, svc)
+
+	var library models.Library
+	err := svc.db.NewSelect().Model(&library).Relation("LibraryPaths").Where("l.id = ?", book.LibraryID).Scan(ctx)
+	require.NoError(t, err)
+	handled, err := svc.organizeWithPathOrganizer(ctx, &library, book, []*models.File{file})
+	require.NoError(t, err)
+	assert.False(t, handled)
+	assert.FileExists(t, file.Filepath)
+}
+
+func TestRenameOrganizedFile_UsesPathOrganizer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc := NewService(setupTestDB(t)).WithPathOrganizer(stubPathOrganizer{
+		models.FileTypeEPUB: "Fantasy/Someone/Custom Name",
+	})
+	libDir, book, file := setupPathOrganizerBook(t, svc)
+
+	var library models.Library
+	err := svc.db.NewSelect().Model(&library).Relation("LibraryPaths").Where("l.id = ?", book.LibraryID).Scan(ctx)
+	require.NoError(t, err)
+
+	newPath, err := svc.RenameOrganizedFile(ctx, &library, book, file, fileutils.OrganizedNameOptions{
+		Title:    "Built-in Name",
+		FileType: file.FileType,
+	})
+	require.NoError(t, err)
+
+	want := filepath.Join(libDir, "Fantasy", "Someone", "Custom Name.epub")
+	assert.Equal(t, want, newPath)
+	assert.Equal(t, want, file.Filepath)
+	assert.FileExists(t, want)
+	assert.FileExists(t, want+".cover.jpg")
+
+	reloadedFile, err := svc.RetrieveFile(ctx, RetrieveFileOptions{ID: &file.ID})
+	require.NoError(t, err)
+	assert.Equal(t, want, reloadedFile.Filepath)
+	require.NotNil(t, reloadedFile.CoverImageFilename)
+	assert.Equal(t, "Custom Name.epub.cover.jpg", *reloadedFile.CoverImageFilename)
+}
+
+func TestRenameOrganizedFile_NoPathFallsBack(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc := NewService(setupTestDB(t)).WithPathOrganizer(stubPathOrganizer{})
+	_, book, file := setupPathOrganizerBook(t, svc)
+	oldPath := file.Filepath
+
+	var library models.Library
+	err := svc.db.NewSelect().Model(&library).Relation("LibraryPaths").Where("l.id = ?", book.LibraryID).Scan(ctx)
+	require.NoError(t, err)
+
+	newPath, err := svc.RenameOrganizedFile(ctx, &library, book, file, fileutils.OrganizedNameOptions{
+		Title:    "Built-in Name",
+		FileType: file.FileType,
+	})
+	require.NoError(t, err)
+
+	// The built-in naming renames the file in its folder and leaves saving
+	// the new path to the caller.
+	assert.Equal(t, filepath.Join(book.Filepath, "Built-in Name.epub"), newPath)
+	assert.FileExists(t, newPath)
+	assert.Equal(t, oldPath, file.Filepath)
+}
+
+func TestOrganizeBookFiles_PathOrganizerEscapeIsIgnored(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc := NewService(setupTestDB(t)).WithPathOrganizer(stubPathOrganizer{
+		models.FileTypeEPUB: "../outside/Title",
+	})
+	_, book, file := setupPathOrganizerBook(t, svc)
+
+	require.NoError(t, svc.OrganizeBookFiles(ctx, book))
+
+	// The organizer handled the book, so the built-in naming didn't run
+	// either, and the file stays put.
+	assert.FileExists(t, file.Filepath)
+}
+
+func TestOrganizerTargetPath(t *testing.T) {
+	t.Parallel()
+	lib := filepath.Join(string(filepath.Separator), "library")
+	current := filepath.Join(lib, "Old", "old.epub")
+
+	got, err := organizerTargetPath(lib, "A/B/Name", current, fileutils.SanitizeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(lib, "A", "B", "Name.epub"), got)
+
+	got, err = organizerTargetPath(lib, "A/Name.EPUB", current, fileutils.SanitizeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(lib, "A", "Name.EPUB"), got)
+
+	_, err = organizerTargetPath(lib, "Name", current, fileutils.SanitizeOptions{})
+	assert.Error(t, err, "files must be in a folder")
+
+	_, err = organizerTargetPath(lib, "../Name", current, fileutils.SanitizeOptions{})
+	assert.Error(t, err)
+}
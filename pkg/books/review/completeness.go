@@ -62,6 +62,8 @@ func isPresent(book *models.Book, file *models.File, field string) bool {
 		return file.Language != nil && *file.Language != ""
 	case FieldURL:
 		return file.URL != nil && *file.URL != ""
+	case FieldConfirmedTitle:
+		return book.TitleSource != models.DataSourceFilepath || len(file.Identifiers) > 0
 	case FieldNarrators:
 		return len(file.Narrators) > 0
 	case FieldChapters:
@@ -141,3 +141,24 @@ func TestIsComplete_False(t *testing.T) {
 		FileRole: models.FileRoleMain,
 	}, Default()))
 }
+
+func TestMissingFields_ConfirmedTitle(t *testing.T) {
+	t.Parallel()
+	criteria := Criteria{BookFields: []string{FieldConfirmedTitle}}
+	file := &models.File{FileType: models.FileTypeEPUB, FileRole: models.FileRoleMain}
+
+	// A filepath-derived title with nothing to confirm it needs review.
+	book := &models.Book{TitleSource: models.DataSourceFilepath}
+	require.Equal(t, []string{"confirmed_title"}, MissingFields(book, file, criteria))
+
+	// An identifier is enough to trust the file.
+	withID := &models.File{
+		FileType:    models.FileTypeEPUB,
+		FileRole:    models.FileRoleMain,
+		Identifiers: []*models.FileIdentifier{{Type: "isbn_13", Value: "9780316769488"}},
+	}
+	require.Empty(t, MissingFields(book, withID, criteria))
+
+	// So is a title from the file's metadata.
+	require.Empty(t, MissingFields(&models.Book{TitleSource: models.DataSourceEPUBMetadata}, file, criteria))
+}
@@ -23,16 +23,19 @@ const (
 	FieldReleaseDate = "release_date"
 	FieldLanguage    = "language"
 	FieldURL         = "url"
-	FieldNarrators   = "narrators"
-	FieldChapters    = "chapters"
-	FieldAbridged    = "abridged"
+	// FieldConfirmedTitle is satisfied unless the title was only derived
+	// from the filepath and the file has no identifiers to back it up.
+	FieldConfirmedTitle = "confirmed_title"
+	FieldNarrators      = "narrators"
+	FieldChapters       = "chapters"
+	FieldAbridged       = "abridged"
 )
 
 // UniversalCandidates is the set of fields that can be required for all books.
 var UniversalCandidates = []string{
 	FieldAuthors, FieldDescription, FieldCover, FieldGenres, FieldTags,
 	FieldSeries, FieldSubtitle, FieldPublisher, FieldIdentifiers,
-	FieldReleaseDate, FieldLanguage, FieldURL,
+	FieldReleaseDate, FieldLanguage, FieldURL, FieldConfirmedTitle,
 }
 
 // AudioCandidates is the set of fields that can be required only when the book has at least one audio file.
@@ -46,7 +49,7 @@ type Criteria struct {
 // Default returns the seeded review criteria.
 func Default() Criteria {
 	return Criteria{
-		BookFields:  []string{FieldAuthors, FieldDescription, FieldCover, FieldGenres},
+		BookFields:  []string{FieldAuthors, FieldDescription, FieldCover, FieldGenres, FieldConfirmedTitle},
 		AudioFields: []string{FieldNarrators},
 	}
 }
@@ -38,7 +38,7 @@ func newTestDB(t *testing.T) *bun.DB {
 func TestDefault_HasExpectedFields(t *testing.T) {
 	t.Parallel()
 	d := Default()
-	require.ElementsMatch(t, []string{"authors", "description", "cover", "genres"}, d.BookFields)
+	require.ElementsMatch(t, []string{"authors", "description", "cover", "genres", "confirmed_title"}, d.BookFields)
 	require.ElementsMatch(t, []string{"narrators"}, d.AudioFields)
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/shishobooks/shisho/pkg/cbzpages"
 	"github.com/shishobooks/shisho/pkg/config"
 	"github.com/shishobooks/shisho/pkg/downloadcache"
+	"github.com/shishobooks/shisho/pkg/fileutils"
 	"github.com/shishobooks/shisho/pkg/genres"
 	"github.com/shishobooks/shisho/pkg/libraries"
 	"github.com/shishobooks/shisho/pkg/lists"
@@ -18,6 +19,7 @@ import (
 	"github.com/shishobooks/shisho/pkg/search"
 	"github.com/shishobooks/shisho/pkg/settings"
 	"github.com/shishobooks/shisho/pkg/tags"
+	"github.com/shishobooks/shisho/pkg/webhooks"
 	"github.com/uptrace/bun"
 )
 
@@ -26,15 +28,31 @@ import (
 func RegisterLibraryRoutes(g *echo.Group, db *bun.DB, authMiddleware *auth.Middleware) {
 	bookService := NewService(db)
 	settingsService := settings.NewService(db)
-	h := &handler{bookService: bookService, settingsService: settingsService}
+	h := &handler{bookService: bookService, libraryService: libraries.NewService(db), settingsService: settingsService}
 	g.GET("/:id/languages", h.listLibraryLanguages, authMiddleware.RequireLibraryAccess("id"))
+	g.GET("/:id/stats", h.libraryStats, authMiddleware.RequireLibraryAccess("id"))
+	g.GET("/:id/verify", h.verifyLibrary, authMiddleware.RequirePermission(models.ResourceLibraries, models.OperationWrite), authMiddleware.RequireLibraryAccess("id"))
+}
+
+// RegisterFileRoutes registers the cross-book file routes on a files group.
+func RegisterFileRoutes(g *echo.Group, db *bun.DB) {
+	h := &handler{bookService: NewService(db)}
+	g.GET("", h.listFiles)
+	g.GET("/:id/sidecar", h.fileSidecar)
 }
 
 // RegisterRoutesWithGroup registers book routes on a pre-configured group.
-func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, cfg *config.Config, authMiddleware *auth.Middleware, scanner Scanner, pm *plugins.Manager, dlCache *downloadcache.Cache, appSettingsSvc *appsettings.Service) {
-	bookService := NewService(db).WithAppSettings(appSettingsSvc)
+func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, cfg *config.Config, authMiddleware *auth.Middleware, scanner Scanner, pm *plugins.Manager, dlCache *downloadcache.Cache, appSettingsSvc *appsettings.Service, hooks *webhooks.Dispatcher) {
+	bookService := NewService(db).
+		WithAppSettings(appSettingsSvc).
+		WithFilenameSanitizer(fileutils.SanitizeOptions{Mode: cfg.OrganizeFilenameMode}).
+		WithWebhooks(hooks).
+		WithPersonNameLocale(cfg.PersonNameLocale)
+	if pm != nil {
+		bookService.WithPathOrganizer(pm)
+	}
 	libraryService := libraries.NewService(db)
-	personService := people.NewService(db)
+	personService := people.NewService(db).WithNameLocale(cfg.PersonNameLocale)
 	searchService := search.NewService(db)
 	genreService := genres.NewService(db)
 	tagService := tags.NewService(db)
@@ -60,12 +78,15 @@ func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, cfg *config.Config, auth
 		pageCache:          pageCache,
 		pdfPageCache:       pdfPageCache,
 		scanner:            scanner,
+		importer:           NewImporter(libraryService, scanner),
 	}
 	// Only set pluginManager if it's not nil to avoid interface holding nil pointer
 	if pm != nil {
 		h.pluginManager = pm
 	}
 
+	// Import a book from a remote URL - must be before /:id routes
+	g.POST("/import-url", h.importFromURL, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	// Merge books - must be before /:id routes
 	g.POST("/merge", h.mergeBooks, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 
@@ -77,25 +98,39 @@ func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, cfg *config.Config, auth
 	g.GET("", h.list)
 	g.POST("/:id", h.update, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.POST("/:id/resync", h.resyncBook, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+	g.POST("/:id/approve", h.approveBook, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+	g.POST("/:id/pair", h.pairBooks, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	// Move files between books
 	g.POST("/:id/move-files", h.moveFiles, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.GET("/:id/cover", h.bookCover)
+	g.GET("/:id/download.zip", h.downloadBookZip)
+	g.GET("/:id/audio-quality", h.bookAudioQuality)
+	g.GET("/:id/sidecar", h.bookSidecar)
+	g.GET("/:id/snapshots", h.listMetadataSnapshots)
+	g.POST("/:id/snapshots/:snapshotId/revert", h.revertMetadataSnapshot, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.GET("/:id/lists", h.bookLists)
 	g.POST("/:id/lists", h.updateBookLists)
+	// Preview metadata for a file on disk - must be before /files/:id routes
+	g.POST("/files/preview-metadata", h.previewFileMetadata, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.GET("/files/:id/cover", h.fileCover)
 	g.POST("/files/:id", h.updateFile, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.POST("/files/:id/cover", h.uploadFileCover, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+	g.POST("/files/:id/cover/revert", h.revertFileCover, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.PUT("/files/:id/cover-page", h.updateFileCoverPage, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.GET("/files/:id/download", h.downloadFile)
 	g.HEAD("/files/:id/download", h.downloadFile)
 	g.GET("/files/:id/download/original", h.downloadOriginalFile)
 	g.GET("/files/:id/download/kepub", h.downloadKepubFile)
 	g.HEAD("/files/:id/download/kepub", h.downloadKepubFile)
+	g.GET("/files/:id/epub/*", h.epubResource)
 	g.GET("/files/:id/page/:pageNum", h.getPage)
 	g.GET("/files/:id/stream", h.streamFile)
+	g.POST("/files/:id/move", h.moveFile, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.POST("/files/:id/resync", h.resyncFile, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.PATCH("/files/:id/review", h.setFileReview, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.DELETE("/files/:id", h.deleteFile, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.PATCH("/:id/review", h.setBookReview, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.POST("/bulk/review", h.bulkSetReview, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+	g.POST("/bulk/tags", h.bulkUpdateTags, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+	g.POST("/bulk/genres", h.bulkUpdateGenres, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 }
@@ -10,11 +10,15 @@ var (
 	errSeriesNumberNotFinite       = errors.New("series number must be finite")
 	errSeriesNumberEndNotFinite    = errors.New("series number end must be finite")
 	errSeriesNumberEndBeforeStart  = errors.New("series number end must not be less than the start")
+	errReadingOrderNotFinite       = errors.New("series reading order must be finite")
 )
 
 func validateSeriesInputs(inputs []SeriesInput) error {
 	for i := range inputs {
 		input := &inputs[i]
+		if input.ReadingOrder != nil && !isFiniteSeriesNumber(*input.ReadingOrder) {
+			return errReadingOrderNotFinite
+		}
 		if input.Number == nil {
 			if input.NumberEnd != nil {
 				return errSeriesNumberEndWithoutStart
@@ -27,6 +27,8 @@ func TestValidateSeriesInputs(t *testing.T) {
 		{name: "non finite start", input: SeriesInput{Name: "Series", Number: float64Pointer(math.Inf(1))}, wantErr: true},
 		{name: "non finite end", input: SeriesInput{Name: "Series", Number: float64Pointer(1), NumberEnd: float64Pointer(math.NaN())}, wantErr: true},
 		{name: "unit without start clears group", input: SeriesInput{Name: "Series", SeriesNumberUnit: &unit}, wantEndNil: true, wantUnitNil: true},
+		{name: "reading order without number", input: SeriesInput{Name: "Series", ReadingOrder: float64Pointer(0.5)}, wantEndNil: true, wantUnitNil: true},
+		{name: "non finite reading order", input: SeriesInput{Name: "Series", Number: float64Pointer(1), ReadingOrder: float64Pointer(math.NaN())}, wantErr: true},
 	}
 
 	for _, tt := range tests {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/shishobooks/shisho/pkg/sidecar"
 	"github.com/shishobooks/shisho/pkg/sortname"
 	"github.com/shishobooks/shisho/pkg/sortspec"
+	"github.com/shishobooks/shisho/pkg/webhooks"
 	"github.com/uptrace/bun"
 )
 
@@ -35,6 +37,7 @@ type ListBooksOptions struct {
 	LibraryID      *int
 	LibraryIDs     []int // Filter by multiple library IDs (for access control)
 	SeriesID       *int
+	SeriesOrder    string   // models.SeriesOrderPublication (default when "") or models.SeriesOrderReading; only used with SeriesID
 	PersonID       *int     // Filter to books authored by this person (joins through authors)
 	FileTypes      []string // Filter by file types (e.g., ["epub", "cbz"])
 	GenreIDs       []int    // Filter by genre IDs
@@ -43,14 +46,22 @@ type ListBooksOptions struct {
 	IDs            []int    // Filter by specific book IDs
 	Search         *string  // Search query for title/author
 	ReviewedFilter string   // "" (default = all), "needs_review", "reviewed"
+	IncludeHidden  bool     // Include hidden books and files (excluded by default)
 
 	// Sort overrides the default ordering. When nil and SeriesID is set,
-	// single-numbered books precede ranges, then endpoints and sort title order. When nil
+	// single-numbered books precede ranges, then endpoints and sort title order
+	// (or reading order, per SeriesOrder). When nil
 	// and SeriesID is not set, the service falls back to
 	// sortspec.BuiltinDefault (date_added DESC) so all surfaces (REST,
 	// OPDS, eReader, gallery) share the same "newest first" default.
 	Sort []sortspec.SortLevel
 
+	// Keyset switches to cursor pagination: books are ordered by sort title
+	// and id (Sort and series ordering are ignored), and After, when set,
+	// skips to the books that follow it. Offset should be left unset.
+	Keyset bool
+	After  *BookCursor
+
 	includeTotal  bool
 	orderByRecent bool // Order by updated_at DESC instead of created_at ASC
 }
@@ -73,11 +84,41 @@ type ListFilesOptions struct {
 	Offset         *int
 	BookID         *int
 	LibraryID      *int
-	FilepathPrefix *string // Matches files whose filepath equals this value or is a descendant (prefix + "/")
+	FilepathPrefix *string  // Matches files whose filepath equals this value or is a descendant (prefix + "/")
+	LibraryIDs     []int    // Restrict to these libraries (for access control)
+	FileTypes      []string // Filter by file types (e.g., ["epub", "m4b"])
+	FileRole       *string  // Filter by file role (main or supplement)
+	MissingCover   bool     // Only files without a cover image
+	Source         *string  // Only files where any metadata field came from this source
+	Sort           string   // One of the models.FileSort* values; "" = oldest first
 
 	includeTotal bool
 }
 
+var fileSortOrders = map[string]string{
+	models.FileSortCreatedAtAsc:  "f.created_at ASC",
+	models.FileSortCreatedAtDesc: "f.created_at DESC",
+	models.FileSortFilepathAsc:   "f.filepath ASC",
+	models.FileSortFilepathDesc:  "f.filepath DESC",
+	models.FileSortFilesizeAsc:   "f.filesize_bytes ASC",
+	models.FileSortFilesizeDesc:  "f.filesize_bytes DESC",
+}
+
+// fileSourceColumns are the files columns that record where a piece of
+// metadata came from, checked by ListFilesOptions.Source.
+var fileSourceColumns = []string{
+	"cover_source",
+	"name_source",
+	"narrator_source",
+	"identifier_source",
+	"url_source",
+	"release_date_source",
+	"publisher_source",
+	"chapter_source",
+	"language_source",
+	"abridged_source",
+}
+
 type UpdateFileOptions struct {
 	Columns []string
 }
@@ -85,6 +126,10 @@ type UpdateFileOptions struct {
 type Service struct {
 	db                 *bun.DB
 	appSettingsService *appsettings.Service
+	sanitizeOptions    fileutils.SanitizeOptions
+	webhooks           *webhooks.Dispatcher
+	personNameLocale   string
+	pathOrganizer      PathOrganizer
 }
 
 // NewService creates a book service without review-criteria support.
@@ -105,6 +150,34 @@ func (svc *Service) WithAppSettings(s *appsettings.Service) *Service {
 	return svc
 }
 
+// WithFilenameSanitizer sets the rules used to sanitize names when this
+// service organizes files. Without it, the lenient defaults are used.
+func (svc *Service) WithFilenameSanitizer(opts fileutils.SanitizeOptions) *Service {
+	svc.sanitizeOptions = opts
+	return svc
+}
+
+// WithWebhooks sends book created, updated, and deleted events to the given
+// dispatcher.
+func (svc *Service) WithWebhooks(d *webhooks.Dispatcher) *Service {
+	svc.webhooks = d
+	return svc
+}
+
+// WithPersonNameLocale sets the locale used to split the names of people this
+// service creates when reverting a metadata snapshot.
+func (svc *Service) WithPersonNameLocale(locale string) *Service {
+	svc.personNameLocale = locale
+	return svc
+}
+
+// WithPathOrganizer lets o choose where organized files go, falling back to
+// the built-in naming for books it has no paths for.
+func (svc *Service) WithPathOrganizer(o PathOrganizer) *Service {
+	svc.pathOrganizer = o
+	return svc
+}
+
 // RecomputeReviewedForFile loads the active criteria and refreshes
 // files.reviewed for the given file. Errors are logged but do not propagate
 // to the caller — review state is non-critical metadata.
@@ -197,6 +270,8 @@ func (svc *Service) CreateBook(ctx context.Context, book *models.Book) error {
 		return errors.WithStack(err)
 	}
 
+	svc.webhooks.Send(webhooks.EventBookCreated, webhooks.BookData{BookID: book.ID, LibraryID: book.LibraryID, Title: book.Title})
+
 	return nil
 }
 
@@ -219,6 +294,10 @@ func (svc *Service) RetrieveBook(ctx context.Context, opts RetrieveBookOptions)
 		Relation("BookGenres.Genre").
 		Relation("BookTags").
 		Relation("BookTags.Tag").
+		Relation("BookCollections", func(sq *bun.SelectQuery) *bun.SelectQuery {
+			return sq.Order("bc.created_at ASC")
+		}).
+		Relation("BookCollections.Collection").
 		Relation("Files", func(sq *bun.SelectQuery) *bun.SelectQuery {
 			return sq.Order("f.file_type ASC")
 		}).
@@ -276,6 +355,10 @@ func (svc *Service) RetrieveBookByFilePath(ctx context.Context, filepath string,
 		Relation("BookGenres.Genre").
 		Relation("BookTags").
 		Relation("BookTags.Tag").
+		Relation("BookCollections", func(sq *bun.SelectQuery) *bun.SelectQuery {
+			return sq.Order("bc.created_at ASC")
+		}).
+		Relation("BookCollections.Collection").
 		Relation("Files", func(sq *bun.SelectQuery) *bun.SelectQuery {
 			return sq.Order("f.filepath ASC")
 		}).
@@ -336,7 +419,14 @@ func (svc *Service) listBooksWithTotal(ctx context.Context, opts ListBooksOption
 		Relation("BookGenres.Genre").
 		Relation("BookTags").
 		Relation("BookTags.Tag").
+		Relation("BookCollections", func(sq *bun.SelectQuery) *bun.SelectQuery {
+			return sq.Order("bc.created_at ASC")
+		}).
+		Relation("BookCollections.Collection").
 		Relation("Files", func(sq *bun.SelectQuery) *bun.SelectQuery {
+			if !opts.IncludeHidden {
+				sq = sq.Where("f.hidden = FALSE")
+			}
 			return sq.Order("f.file_type ASC")
 		}).
 		Relation("Files.Narrators", func(sq *bun.SelectQuery) *bun.SelectQuery {
@@ -346,6 +436,10 @@ func (svc *Service) listBooksWithTotal(ctx context.Context, opts ListBooksOption
 		Relation("Files.Publisher").
 		Relation("Files.Identifiers")
 
+	if !opts.IncludeHidden {
+		q = q.Where("b.hidden = FALSE")
+	}
+
 	// Apply series filter first (affects ordering)
 	if opts.SeriesID != nil {
 		q = q.Join("INNER JOIN book_series bs_filter ON bs_filter.book_id = b.id").
@@ -359,6 +453,12 @@ func (svc *Service) listBooksWithTotal(ctx context.Context, opts ListBooksOption
 	case opts.orderByRecent:
 		q = q.Order("b.updated_at DESC")
 
+	case opts.Keyset:
+		// Must match the row-value comparison on After below, and is
+		// covered by the (library_id, sort_title, id) and (sort_title, id)
+		// indexes.
+		q = q.Order("b.sort_title ASC").Order("b.id ASC")
+
 	case len(opts.Sort) > 0:
 		for _, clause := range sortspec.OrderClauses(opts.Sort) {
 			q = q.OrderExpr(clause.Expression)
@@ -369,6 +469,13 @@ func (svc *Service) listBooksWithTotal(ctx context.Context, opts ListBooksOption
 		// to shift between pages.
 		q = q.Order("b.id ASC")
 
+	case opts.SeriesID != nil && opts.SeriesOrder == models.SeriesOrderReading:
+		// Books without a reading order fall back to their series number, so
+		// a series only needs reading orders on the books that move.
+		q = q.OrderExpr("COALESCE(bs_filter.reading_order, bs_filter.series_number) ASC").
+			Order("bs_filter.series_number ASC").
+			Order("b.sort_title ASC")
+
 	case opts.SeriesID != nil:
 		// Keep omnibus ranges after single-numbered books, then order each
 		// group by its start, endpoint, and title.
@@ -471,7 +578,18 @@ func (svc *Service) listBooksWithTotal(ctx context.Context, opts ListBooksOption
 		}
 	}
 
-	if opts.includeTotal {
+	if opts.Keyset && opts.After != nil {
+		// Count before seeking past the cursor so the total still covers
+		// every matching book, like it does with offsets.
+		if opts.includeTotal {
+			total, err = q.Count(ctx)
+			if err != nil {
+				return nil, 0, errors.WithStack(err)
+			}
+		}
+		q = q.Where("(b.sort_title, b.id) > (?, ?)", opts.After.SortTitle, opts.After.ID)
+		err = q.Scan(ctx)
+	} else if opts.includeTotal {
 		total, err = q.ScanAndCount(ctx)
 	} else {
 		err = q.Scan(ctx)
@@ -550,6 +668,12 @@ func (svc *Service) UpdateBook(ctx context.Context, book *models.Book, opts Upda
 	// Recompute reviewed state for all files in the book after any successful mutation.
 	if hasDBWork {
 		svc.RecomputeReviewedForBook(ctx, book.ID)
+
+		changes := slices.Clone(opts.Columns)
+		if opts.UpdateAuthors {
+			changes = append(changes, "authors")
+		}
+		svc.webhooks.Send(webhooks.EventBookUpdated, webhooks.BookData{BookID: book.ID, LibraryID: book.LibraryID, Title: book.Title, Changes: changes})
 	}
 
 	return nil
@@ -687,8 +811,14 @@ func (svc *Service) listFilesWithTotal(ctx context.Context, opts ListFilesOption
 		Relation("Narrators", func(sq *bun.SelectQuery) *bun.SelectQuery {
 			return sq.Order("n.sort_order ASC")
 		}).
-		Relation("Narrators.Person").
-		Order("f.created_at ASC")
+		Relation("Narrators.Person")
+
+	order, ok := fileSortOrders[opts.Sort]
+	if !ok {
+		order = fileSortOrders[models.FileSortCreatedAtAsc]
+	}
+	// Tie-break on id so pages are stable when the sort column repeats.
+	q = q.Order(order, "f.id ASC")
 
 	if opts.Limit != nil {
 		q = q.Limit(*opts.Limit)
@@ -702,6 +832,26 @@ func (svc *Service) listFilesWithTotal(ctx context.Context, opts ListFilesOption
 	if opts.LibraryID != nil {
 		q = q.Where("f.library_id = ?", *opts.LibraryID)
 	}
+	if opts.LibraryIDs != nil {
+		q = q.Where("f.library_id IN (?)", bun.List(opts.LibraryIDs))
+	}
+	if len(opts.FileTypes) > 0 {
+		q = q.Where("f.file_type IN (?)", bun.List(opts.FileTypes))
+	}
+	if opts.FileRole != nil {
+		q = q.Where("f.file_role = ?", *opts.FileRole)
+	}
+	if opts.MissingCover {
+		q = q.Where("(f.cover_image_filename IS NULL OR f.cover_image_filename = '')")
+	}
+	if opts.Source != nil {
+		q = q.WhereGroup(" AND ", func(sq *bun.SelectQuery) *bun.SelectQuery {
+			for _, column := range fileSourceColumns {
+				sq = sq.WhereOr("f.? = ?", bun.Ident(column), *opts.Source)
+			}
+			return sq
+		})
+	}
 	if opts.FilepathPrefix != nil {
 		// Match the directory itself (should not happen for files) or any descendant.
 		// Escape LIKE wildcards so paths containing % or _ don't over-match. The
@@ -845,19 +995,22 @@ func (svc *Service) GetFirstBooksFilesForSeries(ctx context.Context, seriesIDs [
 }
 
 // OrganizeBookFiles is the public entry point for triggering file organization.
-// It checks the library's OrganizeFileStructure setting internally and returns
-// early if disabled.
+// It checks the library's OrganizeFiles and OrganizeFolders settings internally
+// and returns early if both are disabled or if the book is still staged.
 func (svc *Service) OrganizeBookFiles(ctx context.Context, book *models.Book) error {
 	return svc.organizeBookFiles(ctx, book)
 }
 
 // organizeBookFiles renames files and folders based on updated book metadata.
+// Folders are only renamed, and root-level files only moved into a folder,
+// when the library has OrganizeFolders enabled. Files are only renamed when
+// it has OrganizeFiles enabled, and otherwise keep their names when moved.
 func (svc *Service) organizeBookFiles(ctx context.Context, book *models.Book) error {
 	log := logger.FromContext(ctx)
 	now := time.Now()
 
-	// Get the library with paths to check if organize_file_structure is enabled
-	// and to determine if files are at root level
+	// Get the library with paths to check which kinds of organizing are
+	// enabled and to determine if files are at root level
 	var library models.Library
 	err := svc.db.NewSelect().
 		Model(&library).
@@ -870,8 +1023,9 @@ func (svc *Service) organizeBookFiles(ctx context.Context, book *models.Book) er
 		return errors.WithStack(err)
 	}
 
-	// Only proceed if organize_file_structure is enabled
-	if !library.OrganizeFileStructure {
+	// Only proceed if the library organizes files or folders. Staged books
+	// stay where they were found until they're approved.
+	if !library.OrganizeEnabled() || book.Staged {
 		return nil
 	}
 
@@ -887,6 +1041,15 @@ func (svc *Service) organizeBookFiles(ctx context.Context, book *models.Book) er
 		return nil
 	}
 
+	// Organizer plugins pick whole paths, so they only run when the library
+	// organizes both files and folders.
+	if svc.pathOrganizer != nil && library.OrganizeFiles && library.OrganizeFolders {
+		handled, err := svc.organizeWithPathOrganizer(ctx, &library, book, files)
+		if err != nil || handled {
+			return err
+		}
+	}
+
 	// Get author names from Authors
 	authorNames := make([]string, 0, len(book.Authors))
 	for _, a := range book.Authors {
@@ -909,6 +1072,8 @@ func (svc *Service) organizeBookFiles(ctx context.Context, book *models.Book) er
 		Title:            book.Title,
 		SeriesNumber:     seriesNumber,
 		SeriesNumberUnit: seriesNumberUnit,
+		Sanitize:         svc.sanitizeOptions,
+		KeepFileName:     !library.OrganizeFiles,
 	}
 
 	// Track path updates for database
@@ -927,9 +1092,12 @@ func (svc *Service) organizeBookFiles(ctx context.Context, book *models.Book) er
 
 	if isDirectoryBased {
 		// For directory-based books, rename the folder and update all file paths
-		newFolderPath, err := fileutils.RenameOrganizedFolder(book.Filepath, organizeOpts)
-		if err != nil {
-			return errors.WithStack(err)
+		newFolderPath := book.Filepath
+		if library.OrganizeFolders {
+			newFolderPath, err = fileutils.RenameOrganizedFolder(book.Filepath, organizeOpts)
+			if err != nil {
+				return errors.WithStack(err)
+			}
 		}
 
 		folderRenamed := newFolderPath != book.Filepath
@@ -1008,7 +1176,7 @@ func (svc *Service) organizeBookFiles(ctx context.Context, book *models.Book) er
 			// matches the rest of the book's files even when the per-file
 			// opts (file.Name override, CBZ + series number, etc.) would
 			// otherwise generate a different folder name.
-			if isFileAtLibraryRoot(file.Filepath, library.LibraryPaths) {
+			if library.OrganizeFolders && isFileAtLibraryRoot(file.Filepath, library.LibraryPaths) {
 				result, organizeErr := fileutils.MoveFileIntoOrganizedFolder(file.Filepath, book.Filepath, organizeOpts)
 				if organizeErr != nil {
 					log.Error("failed to promote root-level file into book folder", logger.Data{
@@ -1034,6 +1202,20 @@ func (svc *Service) organizeBookFiles(ctx context.Context, book *models.Book) er
 				continue
 			}
 
+			// Without OrganizeFiles, files keep their names and only pick
+			// up the folder rename.
+			if !library.OrganizeFiles {
+				if currentPath != file.Filepath {
+					pathUpdates = append(pathUpdates, struct {
+						fileID         int
+						oldPath        string
+						newPath        string
+						coverImagePath *string
+					}{file.ID, file.Filepath, currentPath, file.CoverImageFilename})
+				}
+				continue
+			}
+
 			// Rename the file to the organized name
 			newPath, err := fileutils.RenameOrganizedFile(currentPath, organizeOpts)
 			if err != nil {
@@ -1069,10 +1251,15 @@ func (svc *Service) organizeBookFiles(ctx context.Context, book *models.Book) er
 				}{file.ID, file.Filepath, newPath, file.CoverImageFilename})
 			}
 		}
-	} else if isRootLevelBook {
+	} else if isRootLevelBook && library.OrganizeFolders {
 		// For root-level files that need folder creation, organize each file into a new folder
 		log.Info("organizing root-level files into folder", logger.Data{"file_count": len(files)})
 
+		// All of the book's files go into the same folder, so the first
+		// file's type picks the subfolder, matching the book path the scan
+		// computed for it.
+		organizeOpts.Subfolder = library.OrganizeSubfolder(files[0].FileType)
+
 		var newBookPath string
 		for _, file := range files {
 			// Set file type for proper volume formatting
@@ -1160,8 +1347,9 @@ func (svc *Service) organizeBookFiles(ctx context.Context, book *models.Book) er
 				})
 			}
 		}
-	} else {
-		// For files already in a subfolder, just rename them in place
+	} else if library.OrganizeFiles {
+		// For files already in a subfolder, or root-level files when folders
+		// aren't organized, just rename them in place
 		log.Info("renaming files in place", logger.Data{"file_count": len(files)})
 
 		for _, file := range files {
@@ -1690,6 +1878,100 @@ func (svc *Service) ListFilesForLibrary(ctx context.Context, libraryID int) ([]*
 	return files, errors.WithStack(err)
 }
 
+// ListFilesWithCoversAfter returns up to limit files in a library that have a
+// cover image, with IDs greater than afterID, in ID order. Used to page
+// through a library in batches that can be resumed from the last ID.
+func (svc *Service) ListFilesWithCoversAfter(ctx context.Context, libraryID, afterID, limit int) ([]*models.File, error) {
+	var files []*models.File
+	err := svc.db.NewSelect().
+		Model(&files).
+		Where("library_id = ?", libraryID).
+		Where("id > ?", afterID).
+		Where("cover_image_filename IS NOT NULL").
+		Where("cover_image_filename != ''").
+		Order("id ASC").
+		Limit(limit).
+		Scan(ctx)
+	return files, errors.WithStack(err)
+}
+
+// CountFilesWithCovers returns how many files in a library have a cover
+// image, and how many of those have IDs up to and including throughID.
+func (svc *Service) CountFilesWithCovers(ctx context.Context, libraryID, throughID int) (total, through int, err error) {
+	q := svc.db.NewSelect().
+		Model((*models.File)(nil)).
+		Where("library_id = ?", libraryID).
+		Where("cover_image_filename IS NOT NULL").
+		Where("cover_image_filename != ''")
+	total, err = q.Count(ctx)
+	if err != nil {
+		return 0, 0, errors.WithStack(err)
+	}
+	through, err = q.Where("id <= ?", throughID).Count(ctx)
+	if err != nil {
+		return 0, 0, errors.WithStack(err)
+	}
+	return total, through, nil
+}
+
+// RegenerateSortTitles recomputes the sort title of every book in a library
+// from its title and language, for when the sort rules have changed since the
+// books were added. Sort titles from a manual edit, sidecar, or plugin are
+// kept, since they outrank anything derived here. A book's language is that
+// of its first main file that has one. Only books whose sort title changes
+// are written, and their IDs are returned so the caller can re-index them and
+// rewrite their sidecars.
+func (svc *Service) RegenerateSortTitles(ctx context.Context, libraryID int) ([]int, error) {
+	var rows []struct {
+		ID              int
+		Title           string
+		SortTitle       string
+		SortTitleSource string
+		Language        *string
+	}
+	err := svc.db.NewSelect().
+		TableExpr("books AS b").
+		Column("b.id", "b.title", "b.sort_title", "b.sort_title_source").
+		ColumnExpr(`(
+			SELECT f.language FROM files AS f
+			WHERE f.book_id = b.id AND f.file_role = ? AND f.language IS NOT NULL AND f.language != ''
+			ORDER BY f.id ASC LIMIT 1
+		) AS language`, models.FileRoleMain).
+		Where("b.library_id = ?", libraryID).
+		Order("b.id ASC").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var updated []int
+	now := time.Now()
+	for _, row := range rows {
+		if models.GetDataSourcePriority(row.SortTitleSource) < models.DataSourceFileMetadataPriority {
+			continue
+		}
+		var language string
+		if row.Language != nil {
+			language = *row.Language
+		}
+		sortTitle := sortname.ForTitleInLanguage(row.Title, language)
+		if sortTitle == row.SortTitle {
+			continue
+		}
+		_, err := svc.db.NewUpdate().
+			Model((*models.Book)(nil)).
+			Set("sort_title = ?", sortTitle).
+			Set("updated_at = ?", now).
+			Where("id = ?", row.ID).
+			Exec(ctx)
+		if err != nil {
+			return updated, errors.WithStack(err)
+		}
+		updated = append(updated, row.ID)
+	}
+	return updated, nil
+}
+
 // ListAllFilesForLibrary returns all files (main and supplement) for a library.
 // Used to preload the scan cache so the path-based scan walk can detect
 // supplement files that share scannable extensions (e.g. .pdf) with main files
@@ -1704,14 +1986,19 @@ func (svc *Service) ListAllFilesForLibrary(ctx context.Context, libraryID int) (
 }
 
 // DeleteBook deletes a book and all its associated records.
-// All child records (files, authors, book_series, book_genres, book_tags) cascade via FK.
+// All child records (files, authors, book_series, book_genres, book_tags,
+// book_collections) cascade via FK.
 // File children (narrators, identifiers, chapters) cascade from files via FK.
 func (svc *Service) DeleteBook(ctx context.Context, bookID int) error {
 	_, err := svc.db.NewDelete().
 		Model((*models.Book)(nil)).
 		Where("id = ?", bookID).
 		Exec(ctx)
-	return errors.WithStack(err)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	svc.sendBookDeleted(bookID)
+	return nil
 }
 
 // DeleteBooksByIDs deletes multiple books and all their associated records.
@@ -1725,14 +2012,26 @@ func (svc *Service) DeleteBooksByIDs(ctx context.Context, bookIDs []int) error {
 		Model((*models.Book)(nil)).
 		Where("id IN (?)", bun.List(bookIDs)).
 		Exec(ctx)
-	return errors.WithStack(err)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	svc.sendBookDeleted(bookIDs...)
+	return nil
+}
+
+// sendBookDeleted sends a book.deleted webhook event for each of bookIDs.
+func (svc *Service) sendBookDeleted(bookIDs ...int) {
+	for _, bookID := range bookIDs {
+		svc.webhooks.Send(webhooks.EventBookDeleted, webhooks.BookData{BookID: bookID})
+	}
 }
 
 // DeleteOrphanedBookChildren removes all child rows (files, authors, book_series,
-// book_genres, book_tags, list_books) that reference a book_id whose book row no
-// longer exists. File-scoped children (narrators, identifiers, chapters, fingerprints)
-// cascade from file deletion via FK. This handles the case where a book row was
-// previously deleted without FK enforcement, leaving orphaned children behind.
+// book_genres, book_tags, list_books, book_collections) that reference a book_id
+// whose book row no longer exists. File-scoped children (narrators, identifiers,
+// chapters, fingerprints) cascade from file deletion via FK. This handles the case
+// where a book row was previously deleted without FK enforcement, leaving orphaned
+// children behind.
 func (svc *Service) DeleteOrphanedBookChildren(ctx context.Context, bookID int) error {
 	// Delete files first — their FK children (narrators, identifiers, chapters,
 	// fingerprints) cascade automatically.
@@ -1762,6 +2061,9 @@ func (svc *Service) DeleteOrphanedBookChildren(ctx context.Context, bookID int)
 	if _, err = svc.db.NewDelete().Model((*models.ListBook)(nil)).Where("book_id = ?", bookID).Exec(ctx); err != nil {
 		return errors.WithStack(err)
 	}
+	if _, err = svc.db.NewDelete().Model((*models.BookCollection)(nil)).Where("book_id = ?", bookID).Exec(ctx); err != nil {
+		return errors.WithStack(err)
+	}
 
 	return nil
 }
@@ -1772,7 +2074,7 @@ type DeleteBookAndFilesResult struct {
 }
 
 // DeleteBookAndFiles deletes a book and all its files from both disk and database.
-// If library.OrganizeFileStructure is true, the entire book directory is deleted.
+// If library.OrganizeFolders is true, the entire book directory is deleted.
 // Otherwise, each file is deleted individually with its cover and sidecar.
 func (svc *Service) DeleteBookAndFiles(ctx context.Context, bookID int, library *models.Library) (*DeleteBookAndFilesResult, error) {
 	result := &DeleteBookAndFilesResult{}
@@ -1794,7 +2096,7 @@ func (svc *Service) DeleteBookAndFiles(ctx context.Context, bookID int, library
 	result.FilesDeleted = len(book.Files)
 
 	// Delete files from disk first (before DB transaction)
-	if library.OrganizeFileStructure && book.Filepath != "" {
+	if library.OrganizeFolders && book.Filepath != "" {
 		// Organized structure: delete entire book directory
 		if err := os.RemoveAll(book.Filepath); err != nil && !os.IsNotExist(err) {
 			return nil, errors.Wrap(err, "failed to delete book directory")
@@ -1831,6 +2133,7 @@ func deleteFileFromDisk(file *models.File) error {
 		coverPath := filepath.Join(filepath.Dir(file.Filepath), *file.CoverImageFilename)
 		_ = os.Remove(coverPath)
 	}
+	_ = fileutils.RemoveOriginalCover(filepath.Dir(file.Filepath), file.Filepath)
 
 	// Delete sidecar file if exists (best effort)
 	sidecarPath := file.Filepath + ".metadata.json"
@@ -1988,7 +2291,7 @@ func (svc *Service) DeleteFileAndCleanup(ctx context.Context, fileID int, librar
 	result.BookDeleted = true
 
 	// Clean up book directory if organized structure
-	if library.OrganizeFileStructure && file.Book != nil && file.Book.Filepath != "" {
+	if library.OrganizeFolders && file.Book != nil && file.Book.Filepath != "" {
 		// Combine caller's ignored patterns with shisho special file patterns
 		// so covers (*.cover.*) and sidecars (*.metadata.json) are cleaned up too
 		allIgnoredPatterns := make([]string, 0, len(ignoredPatterns)+len(fileutils.ShishoSpecialFilePatterns))
@@ -181,3 +181,56 @@ func TestListBooks_ReviewedFilter(t *testing.T) {
 	assert.NotContains(t, gotIDs, bookFalse)
 	assert.NotContains(t, gotIDs, bookNull)
 }
+
+func TestListBooks_HiddenExcludedByDefault(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := setupBooksTestDB(t)
+	svc := NewService(db)
+	lib := seedLibrary(t, db, "L")
+
+	visible := seedBook(t, db, lib, "Visible", "Visible", time.Now())
+	hidden := seedBook(t, db, lib, "Hidden", "Hidden", time.Now())
+	hidden.Hidden = true
+	_, err := db.NewUpdate().Model(hidden).Column("hidden").WherePK().Exec(ctx)
+	require.NoError(t, err)
+
+	mkFile := func(path string, isHidden bool) *models.File {
+		f := &models.File{
+			LibraryID:     lib.ID,
+			BookID:        visible.ID,
+			Filepath:      path,
+			FileType:      models.FileTypeEPUB,
+			FileRole:      models.FileRoleMain,
+			FilesizeBytes: 1,
+			Hidden:        isHidden,
+		}
+		_, err := db.NewInsert().Model(f).Exec(ctx)
+		require.NoError(t, err)
+		return f
+	}
+	shownFile := mkFile("/tmp/shown.epub", false)
+	hiddenFile := mkFile("/tmp/hidden.epub", true)
+
+	books, total, err := svc.ListBooksWithTotal(ctx, ListBooksOptions{LibraryID: &lib.ID})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Len(t, books, 1)
+	assert.Equal(t, visible.ID, books[0].ID)
+	require.Len(t, books[0].Files, 1)
+	assert.Equal(t, shownFile.ID, books[0].Files[0].ID)
+
+	books, total, err = svc.ListBooksWithTotal(ctx, ListBooksOptions{LibraryID: &lib.ID, IncludeHidden: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	for _, b := range books {
+		if b.ID == visible.ID {
+			fileIDs := make([]int, 0, len(b.Files))
+			for _, f := range b.Files {
+				fileIDs = append(fileIDs, f.ID)
+			}
+			assert.ElementsMatch(t, []int{shownFile.ID, hiddenFile.ID}, fileIDs)
+		}
+	}
+}
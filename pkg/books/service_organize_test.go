@@ -38,7 +38,8 @@ func TestOrganizeBookFiles_RootLevel_CleansUpStaleBookFolderAfterTitleChange(t *
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -154,7 +155,8 @@ func TestOrganizeBookFiles_RootLevel_PreservesUserFilesInStaleFolder(t *testing.
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -246,7 +248,8 @@ func TestOrganizeBookFiles_DirectoryBased_WritesFreshSidecarAfterFolderRename(t
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -342,7 +345,8 @@ func TestOrganizeBookFiles_MixedLayout_PromotesRootLevelFileIntoBookFolder(t *te
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
 	require.NoError(t, err)
@@ -459,3 +463,95 @@ func TestOrganizeBookFiles_MixedLayout_PromotesRootLevelFileIntoBookFolder(t *te
 	}
 	assert.True(t, foundM4b, "expected to find the m4b file in the DB")
 }
+
+// TestOrganizeBookFiles_DirectoryBased_RespectsSeparateSettings checks that
+// OrganizeFiles and OrganizeFolders apply independently: with only folders,
+// the folder is renamed and the file keeps its name, and with only files,
+// the file is renamed in place.
+func TestOrganizeBookFiles_DirectoryBased_RespectsSeparateSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		organizeFiles   bool
+		organizeFolders bool
+		wantFolder      string
+		wantFile        string
+	}{
+		{"folders only", false, true, "[Test Author] New Title", "old-name.epub"},
+		{"files only", true, false, "My Folder", "New Title.epub"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			db := setupTestDB(t)
+			svc := NewService(db)
+
+			libDir := t.TempDir()
+
+			library := &models.Library{
+				Name:                     "Test Library",
+				CoverAspectRatio:         "book",
+				DownloadFormatPreference: models.DownloadFormatOriginal,
+				OrganizeFiles:            tt.organizeFiles,
+				OrganizeFolders:          tt.organizeFolders,
+			}
+			_, err := db.NewInsert().Model(library).Exec(ctx)
+			require.NoError(t, err)
+
+			bookFolder := filepath.Join(libDir, "My Folder")
+			require.NoError(t, os.MkdirAll(bookFolder, 0755))
+			filePath := filepath.Join(bookFolder, "old-name.epub")
+			require.NoError(t, os.WriteFile(filePath, []byte("epub"), 0644))
+
+			person := &models.Person{
+				LibraryID: library.ID,
+				Name:      "Test Author",
+				SortName:  "Author, Test",
+			}
+			_, err = db.NewInsert().Model(person).Exec(ctx)
+			require.NoError(t, err)
+
+			book := &models.Book{
+				LibraryID:       library.ID,
+				Title:           "New Title",
+				TitleSource:     models.DataSourcePlugin,
+				SortTitle:       "New Title",
+				SortTitleSource: models.DataSourcePlugin,
+				AuthorSource:    models.DataSourceFilepath,
+				Filepath:        bookFolder,
+			}
+			_, err = db.NewInsert().Model(book).Exec(ctx)
+			require.NoError(t, err)
+
+			author := &models.Author{BookID: book.ID, PersonID: person.ID, SortOrder: 1}
+			_, err = db.NewInsert().Model(author).Exec(ctx)
+			require.NoError(t, err)
+
+			file := &models.File{
+				LibraryID:     library.ID,
+				BookID:        book.ID,
+				FileType:      models.FileTypeEPUB,
+				FileRole:      models.FileRoleMain,
+				Filepath:      filePath,
+				FilesizeBytes: 4,
+			}
+			_, err = db.NewInsert().Model(file).Exec(ctx)
+			require.NoError(t, err)
+
+			loadedBook, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &book.ID})
+			require.NoError(t, err)
+
+			require.NoError(t, svc.OrganizeBookFiles(ctx, loadedBook))
+
+			wantFolder := filepath.Join(libDir, tt.wantFolder)
+			assert.FileExists(t, filepath.Join(wantFolder, tt.wantFile))
+
+			reloaded, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &book.ID})
+			require.NoError(t, err)
+			assert.Equal(t, wantFolder, reloaded.Filepath)
+		})
+	}
+}
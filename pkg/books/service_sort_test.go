@@ -159,6 +159,38 @@ func TestListBooks_SortByDateAddedDesc(t *testing.T) {
 	assert.Equal(t, oldest.ID, got[2].ID)
 }
 
+// TestListBooks_SortByDateUpdatedDesc confirms recently edited books come
+// first regardless of when they were added.
+func TestListBooks_SortByDateUpdatedDesc(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := setupBooksTestDB(t)
+	svc := NewService(db)
+	lib := seedLibrary(t, db, "Books")
+
+	now := time.Now()
+	older := seedBook(t, db, lib, "Older", "Older", now.Add(-2*time.Hour))
+	newer := seedBook(t, db, lib, "Newer", "Newer", now.Add(-time.Hour))
+
+	older.UpdatedAt = now
+	_, err := db.NewUpdate().Model(older).Column("updated_at").WherePK().Exec(ctx)
+	require.NoError(t, err)
+	newer.UpdatedAt = now.Add(-time.Hour)
+	_, err = db.NewUpdate().Model(newer).Column("updated_at").WherePK().Exec(ctx)
+	require.NoError(t, err)
+
+	got, total, err := svc.ListBooksWithTotal(ctx, ListBooksOptions{
+		LibraryID: &lib.ID,
+		Sort:      []sortspec.SortLevel{{Field: sortspec.FieldDateUpdated, Direction: sortspec.DirDesc}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, got, 2)
+	assert.Equal(t, older.ID, got[0].ID)
+	assert.Equal(t, newer.ID, got[1].ID)
+}
+
 // TestListBooks_SortByTiesFallsBackToID confirms a stable tiebreaker
 // when the user-specified sort levels all have ties. Without a final
 // `b.id ASC`, SQLite's order for tied rows is unspecified and can
@@ -308,10 +308,11 @@ func TestDeleteBookAndFiles_DeletesBookFilesAndDiskFiles(t *testing.T) {
 	// Create temp directory for files
 	tmpDir := t.TempDir()
 
-	// Create library with OrganizeFileStructure=false (files at root level)
+	// Create library with organizing=false (files at root level)
 	library := &models.Library{
 		Name:                     "Test Library",
-		OrganizeFileStructure:    false,
+		OrganizeFiles:            false,
+		OrganizeFolders:          false,
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
 	}
@@ -402,7 +403,8 @@ func TestDeleteBookAndFiles_RemovesCoverWhenMainFileAlreadyMissing(t *testing.T)
 	tmpDir := t.TempDir()
 	library := &models.Library{
 		Name:                     "Test Library",
-		OrganizeFileStructure:    false,
+		OrganizeFiles:            false,
+		OrganizeFolders:          false,
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
 	}
@@ -460,10 +462,11 @@ func TestDeleteBookAndFiles_OrganizedStructure_DeletesEntireDirectory(t *testing
 	// Create temp directory for library
 	tmpDir := t.TempDir()
 
-	// Create library with OrganizeFileStructure=true (files in book directories)
+	// Create library with organizing=true (files in book directories)
 	library := &models.Library{
 		Name:                     "Test Library",
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 		CoverAspectRatio:         "book",
 		DownloadFormatPreference: models.DownloadFormatOriginal,
 	}
@@ -699,11 +702,12 @@ func TestDeleteFileAndCleanup_CleansUpDirectoryWithIgnoredFiles(t *testing.T) {
 	// Create temp directory for the book
 	bookDir := t.TempDir()
 
-	// Create library with OrganizeFileStructure enabled
+	// Create library with organizing enabled
 	library := &models.Library{
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 		DownloadFormatPreference: models.DownloadFormatOriginal,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
@@ -768,11 +772,12 @@ func TestDeleteFileAndCleanup_CleansUpCoverAndSidecarFiles(t *testing.T) {
 	// Create temp directory for the book
 	bookDir := t.TempDir()
 
-	// Create library with OrganizeFileStructure enabled
+	// Create library with organizing enabled
 	library := &models.Library{
 		Name:                     "Test Library",
 		CoverAspectRatio:         "book",
-		OrganizeFileStructure:    true,
+		OrganizeFiles:            true,
+		OrganizeFolders:          true,
 		DownloadFormatPreference: models.DownloadFormatOriginal,
 	}
 	_, err := db.NewInsert().Model(library).Exec(ctx)
@@ -0,0 +1,507 @@
+package books
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/segmentio/encoding/json"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/genres"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/people"
+	"github.com/shishobooks/shisho/pkg/publishers"
+	"github.com/shishobooks/shisho/pkg/sidecar"
+	"github.com/shishobooks/shisho/pkg/tags"
+	"github.com/shishobooks/shisho/pkg/webhooks"
+	"github.com/uptrace/bun"
+)
+
+// MetadataSnapshotDataFromBook copies the metadata a snapshot keeps from
+// book, which must be loaded with its relations.
+func MetadataSnapshotDataFromBook(book *models.Book) *models.MetadataSnapshotData {
+	data := &models.MetadataSnapshotData{
+		Title:             book.Title,
+		TitleSource:       book.TitleSource,
+		SortTitle:         book.SortTitle,
+		SortTitleSource:   book.SortTitleSource,
+		Subtitle:          book.Subtitle,
+		SubtitleSource:    book.SubtitleSource,
+		Description:       book.Description,
+		DescriptionSource: book.DescriptionSource,
+		Authors:           []models.SnapshotPerson{},
+		AuthorSource:      book.AuthorSource,
+		Series:            []models.SnapshotSeries{},
+		Genres:            []string{},
+		GenreSource:       book.GenreSource,
+		Tags:              []string{},
+		TagSource:         book.TagSource,
+		Files:             []models.MetadataSnapshotFile{},
+	}
+	for _, a := range book.Authors {
+		if a.Person != nil {
+			data.Authors = append(data.Authors, models.SnapshotPerson{Name: a.Person.Name, Role: a.Role})
+		}
+	}
+	for _, bs := range book.BookSeries {
+		if bs.Series != nil {
+			data.Series = append(data.Series, models.SnapshotSeries{
+				Name:             bs.Series.Name,
+				SeriesNumber:     bs.SeriesNumber,
+				SeriesNumberEnd:  bs.SeriesNumberEnd,
+				SeriesNumberUnit: bs.SeriesNumberUnit,
+				ReadingOrder:     bs.ReadingOrder,
+			})
+		}
+	}
+	// Genres and tags aren't ordered, so they're sorted to keep snapshots of
+	// the same metadata identical.
+	for _, bg := range book.BookGenres {
+		if bg.Genre != nil {
+			data.Genres = append(data.Genres, bg.Genre.Name)
+		}
+	}
+	sort.Strings(data.Genres)
+	for _, bt := range book.BookTags {
+		if bt.Tag != nil {
+			data.Tags = append(data.Tags, bt.Tag.Name)
+		}
+	}
+	sort.Strings(data.Tags)
+
+	for _, f := range book.Files {
+		sf := models.MetadataSnapshotFile{
+			FileID:            f.ID,
+			Name:              f.Name,
+			NameSource:        f.NameSource,
+			Narrators:         []models.SnapshotPerson{},
+			NarratorSource:    f.NarratorSource,
+			Identifiers:       []models.SnapshotIdentifier{},
+			IdentifierSource:  f.IdentifierSource,
+			URL:               f.URL,
+			URLSource:         f.URLSource,
+			ReleaseDate:       f.ReleaseDate,
+			ReleaseDateSource: f.ReleaseDateSource,
+			PublisherSource:   f.PublisherSource,
+			Language:          f.Language,
+			LanguageSource:    f.LanguageSource,
+			Abridged:          f.Abridged,
+			AbridgedSource:    f.AbridgedSource,
+		}
+		for _, n := range f.Narrators {
+			if n.Person != nil {
+				sf.Narrators = append(sf.Narrators, models.SnapshotPerson{Name: n.Person.Name, Role: n.Role})
+			}
+		}
+		for _, id := range f.Identifiers {
+			sf.Identifiers = append(sf.Identifiers, models.SnapshotIdentifier{Type: id.Type, Value: id.Value, Source: id.Source})
+		}
+		sort.Slice(sf.Identifiers, func(i, j int) bool {
+			if sf.Identifiers[i].Type != sf.Identifiers[j].Type {
+				return sf.Identifiers[i].Type < sf.Identifiers[j].Type
+			}
+			return sf.Identifiers[i].Value < sf.Identifiers[j].Value
+		})
+		if f.Publisher != nil {
+			name := f.Publisher.Name
+			sf.Publisher = &name
+		}
+		data.Files = append(data.Files, sf)
+	}
+	sort.Slice(data.Files, func(i, j int) bool { return data.Files[i].FileID < data.Files[j].FileID })
+
+	return data
+}
+
+// RecordMetadataSnapshot stores before, the book's metadata taken before a
+// resync, as a snapshot if the book's metadata has changed since. It then
+// deletes all but the newest keep snapshots of the book. fileID is the
+// resynced file, or nil for a book resync. It reports whether a snapshot was
+// stored; nothing is stored when keep isn't positive.
+func (svc *Service) RecordMetadataSnapshot(ctx context.Context, bookID int, fileID *int, before *models.MetadataSnapshotData, keep int) (bool, error) {
+	if keep <= 0 || before == nil {
+		return false, nil
+	}
+
+	book, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &bookID})
+	if err != nil {
+		return false, err
+	}
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	afterJSON, err := json.Marshal(MetadataSnapshotDataFromBook(book))
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	if bytes.Equal(beforeJSON, afterJSON) {
+		return false, nil
+	}
+
+	snapshot := &models.MetadataSnapshot{
+		CreatedAt: time.Now(),
+		BookID:    bookID,
+		FileID:    fileID,
+		Data:      string(beforeJSON),
+	}
+	err = svc.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(snapshot).Exec(ctx); err != nil {
+			return errors.WithStack(err)
+		}
+		keepIDs := tx.NewSelect().
+			Model((*models.MetadataSnapshot)(nil)).
+			Column("ms.id").
+			Where("ms.book_id = ?", bookID).
+			Order("ms.id DESC").
+			Limit(keep)
+		_, err := tx.NewDelete().
+			Model((*models.MetadataSnapshot)(nil)).
+			Where("book_id = ?", bookID).
+			Where("id NOT IN (?)", keepIDs).
+			Exec(ctx)
+		return errors.WithStack(err)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListMetadataSnapshots returns the book's snapshots, newest first.
+func (svc *Service) ListMetadataSnapshots(ctx context.Context, bookID int) ([]*models.MetadataSnapshot, error) {
+	var snapshots []*models.MetadataSnapshot
+	err := svc.db.NewSelect().
+		Model(&snapshots).
+		Where("ms.book_id = ?", bookID).
+		Order("ms.id DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, s := range snapshots {
+		if err := s.UnmarshalData(); err != nil {
+			return nil, err
+		}
+	}
+	return snapshots, nil
+}
+
+// RetrieveMetadataSnapshot returns a snapshot by ID.
+func (svc *Service) RetrieveMetadataSnapshot(ctx context.Context, id int) (*models.MetadataSnapshot, error) {
+	snapshot := &models.MetadataSnapshot{}
+	err := svc.db.NewSelect().
+		Model(snapshot).
+		Where("ms.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcodes.NotFound("Snapshot")
+		}
+		return nil, errors.WithStack(err)
+	}
+	if err := snapshot.UnmarshalData(); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// RevertToSnapshot restores the book metadata saved in a snapshot, along with
+// each of its files that still belongs to the book, and returns the reloaded
+// book. Values get back the sources they had, so a later scan can change them
+// again the same way. The book's files are reorganized if its title or
+// authors change, and its sidecars are rewritten (unless it's staged). Search
+// indexing is left to the caller.
+func (svc *Service) RevertToSnapshot(ctx context.Context, snapshotID int) (*models.Book, error) {
+	snapshot, err := svc.RetrieveMetadataSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	book, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &snapshot.BookID})
+	if err != nil {
+		return nil, err
+	}
+	data := snapshot.DataParsed
+	changes := snapshotChanges(MetadataSnapshotDataFromBook(book), data)
+	if len(changes) == 0 {
+		return book, nil
+	}
+
+	// Resolving names may create people, series, genres, tags, or publishers
+	// outside the transaction. If the transaction then fails they're left
+	// unused and removed by the next orphan cleanup.
+	rows, err := svc.resolveSnapshot(ctx, book, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		book.Title = data.Title
+		book.TitleSource = data.TitleSource
+		book.SortTitle = data.SortTitle
+		book.SortTitleSource = data.SortTitleSource
+		book.Subtitle = data.Subtitle
+		book.SubtitleSource = data.SubtitleSource
+		book.Description = data.Description
+		book.DescriptionSource = data.DescriptionSource
+		book.AuthorSource = data.AuthorSource
+		book.GenreSource = data.GenreSource
+		book.TagSource = data.TagSource
+		book.UpdatedAt = time.Now()
+		_, err := tx.NewUpdate().
+			Model(book).
+			Column("title", "title_source", "sort_title", "sort_title_source", "subtitle", "subtitle_source",
+				"description", "description_source", "author_source", "genre_source", "tag_source", "updated_at").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if err := replaceRows(ctx, tx, (*models.Author)(nil), "book_id", book.ID, &rows.authors); err != nil {
+			return err
+		}
+		if err := replaceRows(ctx, tx, (*models.BookSeries)(nil), "book_id", book.ID, &rows.series); err != nil {
+			return err
+		}
+		if err := replaceRows(ctx, tx, (*models.BookGenre)(nil), "book_id", book.ID, &rows.genres); err != nil {
+			return err
+		}
+		if err := replaceRows(ctx, tx, (*models.BookTag)(nil), "book_id", book.ID, &rows.tags); err != nil {
+			return err
+		}
+
+		for _, fr := range rows.files {
+			fr.file.UpdatedAt = time.Now()
+			_, err := tx.NewUpdate().
+				Model(fr.file).
+				Column("name", "name_source", "narrator_source", "identifier_source", "url", "url_source",
+					"release_date", "release_date_source", "publisher_id", "publisher_source",
+					"language", "language_source", "abridged", "abridged_source", "updated_at").
+				WherePK().
+				Exec(ctx)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if err := replaceRows(ctx, tx, (*models.Narrator)(nil), "file_id", fr.file.ID, &fr.narrators); err != nil {
+				return err
+			}
+			if err := replaceRows(ctx, tx, (*models.FileIdentifier)(nil), "file_id", fr.file.ID, &fr.identifiers); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	book, err = svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &snapshot.BookID})
+	if err != nil {
+		return nil, err
+	}
+	log := logger.FromContext(ctx)
+	if containsColumn(changes, "title") || containsColumn(changes, "authors") {
+		if err := svc.UpdateBook(ctx, book, UpdateBookOptions{OrganizeFiles: true}); err != nil {
+			log.Warn("failed to organize book files", logger.Data{"book_id": book.ID, "error": err.Error()})
+		}
+		book, err = svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &snapshot.BookID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !book.Staged {
+		if err := sidecar.WriteBookSidecarFromModel(book); err != nil {
+			log.Warn("failed to write book sidecar", logger.Data{"book_id": book.ID, "error": err.Error()})
+		}
+		for _, file := range book.Files {
+			if err := sidecar.WriteFileSidecarFromModel(file); err != nil {
+				log.Warn("failed to write file sidecar", logger.Data{"file_id": file.ID, "error": err.Error()})
+			}
+		}
+	}
+	if err := svc.RefreshMetadataHash(ctx, book); err != nil {
+		log.Warn("failed to update book metadata hash", logger.Data{"book_id": book.ID, "error": err.Error()})
+	}
+	svc.RecomputeReviewedForBook(ctx, book.ID)
+	svc.webhooks.Send(webhooks.EventBookUpdated, webhooks.BookData{BookID: book.ID, LibraryID: book.LibraryID, Title: book.Title, Changes: changes})
+
+	return book, nil
+}
+
+// snapshotRows are the rows a revert writes, with names already resolved to
+// IDs.
+type snapshotRows struct {
+	authors []*models.Author
+	series  []*models.BookSeries
+	genres  []*models.BookGenre
+	tags    []*models.BookTag
+	files   []*snapshotFileRows
+}
+
+type snapshotFileRows struct {
+	file        *models.File
+	narrators   []*models.Narrator
+	identifiers []*models.FileIdentifier
+}
+
+func (svc *Service) resolveSnapshot(ctx context.Context, book *models.Book, data *models.MetadataSnapshotData) (*snapshotRows, error) {
+	personService := people.NewService(svc.db).WithNameLocale(svc.personNameLocale)
+	genreService := genres.NewService(svc.db)
+	tagService := tags.NewService(svc.db)
+	publisherService := publishers.NewService(svc.db)
+	rows := &snapshotRows{}
+
+	for i, a := range data.Authors {
+		person, err := personService.FindOrCreatePerson(ctx, a.Name, book.LibraryID)
+		if err != nil {
+			return nil, err
+		}
+		rows.authors = append(rows.authors, &models.Author{BookID: book.ID, PersonID: person.ID, SortOrder: i + 1, Role: a.Role})
+	}
+	for i, s := range data.Series {
+		series, err := svc.FindOrCreateSeries(ctx, s.Name, book.LibraryID, models.DataSourceManual)
+		if err != nil {
+			return nil, err
+		}
+		rows.series = append(rows.series, &models.BookSeries{
+			BookID:           book.ID,
+			SeriesID:         series.ID,
+			SeriesNumber:     s.SeriesNumber,
+			SeriesNumberEnd:  s.SeriesNumberEnd,
+			SeriesNumberUnit: s.SeriesNumberUnit,
+			ReadingOrder:     s.ReadingOrder,
+			SortOrder:        i + 1,
+		})
+	}
+	for _, name := range data.Genres {
+		genre, err := genreService.FindOrCreateGenre(ctx, name, book.LibraryID)
+		if err != nil {
+			return nil, err
+		}
+		rows.genres = append(rows.genres, &models.BookGenre{BookID: book.ID, GenreID: genre.ID})
+	}
+	for _, name := range data.Tags {
+		tag, err := tagService.FindOrCreateTag(ctx, name, book.LibraryID)
+		if err != nil {
+			return nil, err
+		}
+		rows.tags = append(rows.tags, &models.BookTag{BookID: book.ID, TagID: tag.ID})
+	}
+
+	files := make(map[int]*models.File, len(book.Files))
+	for _, f := range book.Files {
+		files[f.ID] = f
+	}
+	now := time.Now()
+	for _, sf := range data.Files {
+		file, ok := files[sf.FileID]
+		if !ok {
+			continue
+		}
+		fr := &snapshotFileRows{file: file}
+		file.Name = sf.Name
+		file.NameSource = sf.NameSource
+		file.NarratorSource = sf.NarratorSource
+		file.IdentifierSource = sf.IdentifierSource
+		file.URL = sf.URL
+		file.URLSource = sf.URLSource
+		file.ReleaseDate = sf.ReleaseDate
+		file.ReleaseDateSource = sf.ReleaseDateSource
+		file.PublisherSource = sf.PublisherSource
+		file.Language = sf.Language
+		file.LanguageSource = sf.LanguageSource
+		file.Abridged = sf.Abridged
+		file.AbridgedSource = sf.AbridgedSource
+		file.PublisherID = nil
+		if sf.Publisher != nil {
+			publisher, err := publisherService.FindOrCreatePublisher(ctx, *sf.Publisher, book.LibraryID)
+			if err != nil {
+				return nil, err
+			}
+			file.PublisherID = &publisher.ID
+		}
+		for i, n := range sf.Narrators {
+			person, err := personService.FindOrCreatePerson(ctx, n.Name, book.LibraryID)
+			if err != nil {
+				return nil, err
+			}
+			fr.narrators = append(fr.narrators, &models.Narrator{FileID: file.ID, PersonID: person.ID, SortOrder: i + 1, Role: n.Role})
+		}
+		for _, id := range sf.Identifiers {
+			fr.identifiers = append(fr.identifiers, &models.FileIdentifier{
+				CreatedAt: now,
+				UpdatedAt: now,
+				FileID:    file.ID,
+				Type:      id.Type,
+				Value:     id.Value,
+				Source:    id.Source,
+			})
+		}
+		rows.files = append(rows.files, fr)
+	}
+	return rows, nil
+}
+
+// replaceRows deletes the rows of model's table whose column is id and
+// inserts rows, a pointer to a slice of models, in their place.
+func replaceRows[T any](ctx context.Context, tx bun.Tx, model any, column string, id int, rows *[]T) error {
+	_, err := tx.NewDelete().
+		Model(model).
+		Where("? = ?", bun.Ident(column), id).
+		Exec(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if len(*rows) == 0 {
+		return nil
+	}
+	_, err = tx.NewInsert().Model(rows).Exec(ctx)
+	return errors.WithStack(err)
+}
+
+// snapshotChanges lists the fields that differ between the current metadata
+// and a snapshot's, using the names sent in book.updated webhooks. Files are
+// only compared when they're in both.
+func snapshotChanges(current, target *models.MetadataSnapshotData) []string {
+	var changes []string
+	add := func(field string, a, b any) {
+		if !reflect.DeepEqual(a, b) {
+			changes = append(changes, field)
+		}
+	}
+	add("title", []any{current.Title, current.TitleSource}, []any{target.Title, target.TitleSource})
+	add("sort_title", []any{current.SortTitle, current.SortTitleSource}, []any{target.SortTitle, target.SortTitleSource})
+	add("subtitle", []any{current.Subtitle, current.SubtitleSource}, []any{target.Subtitle, target.SubtitleSource})
+	add("description", []any{current.Description, current.DescriptionSource}, []any{target.Description, target.DescriptionSource})
+	add("authors", []any{current.Authors, current.AuthorSource}, []any{target.Authors, target.AuthorSource})
+	add("series", current.Series, target.Series)
+	add("genres", []any{current.Genres, current.GenreSource}, []any{target.Genres, target.GenreSource})
+	add("tags", []any{current.Tags, current.TagSource}, []any{target.Tags, target.TagSource})
+
+	files := make(map[int]models.MetadataSnapshotFile, len(current.Files))
+	for _, f := range current.Files {
+		files[f.FileID] = f
+	}
+	for _, f := range target.Files {
+		if cur, ok := files[f.FileID]; ok && !snapshotFilesEqual(cur, f) {
+			changes = append(changes, "files")
+			break
+		}
+	}
+	return changes
+}
+
+// snapshotFilesEqual compares files by their JSON, so release dates that
+// went through a snapshot compare equal to the ones read from the database.
+func snapshotFilesEqual(a, b models.MetadataSnapshotFile) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aJSON, bJSON)
+}
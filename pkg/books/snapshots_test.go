@@ -0,0 +1,113 @@
+package books
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/people"
+	"github.com/shishobooks/shisho/pkg/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_RecordMetadataSnapshot_RevertToSnapshot(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	library, book := setupTestLibraryAndBook(t, db)
+	person, err := people.NewService(db).FindOrCreatePerson(ctx, "Original Author", library.ID)
+	require.NoError(t, err)
+	require.NoError(t, svc.CreateAuthor(ctx, &models.Author{BookID: book.ID, PersonID: person.ID, SortOrder: 1}))
+	tag, err := tags.NewService(db).FindOrCreateTag(ctx, "Original", library.ID)
+	require.NoError(t, err)
+	require.NoError(t, svc.CreateBookTag(ctx, &models.BookTag{BookID: book.ID, TagID: tag.ID}))
+
+	loaded, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &book.ID})
+	require.NoError(t, err)
+	before := MetadataSnapshotDataFromBook(loaded)
+
+	// Nothing has changed yet, so no snapshot is stored.
+	stored, err := svc.RecordMetadataSnapshot(ctx, book.ID, nil, before, 5)
+	require.NoError(t, err)
+	assert.False(t, stored)
+
+	// Simulate a resync that changed the title and tags.
+	_, err = db.NewUpdate().Model((*models.Book)(nil)).
+		Set("title = ?", "Resynced Title").
+		Set("title_source = ?", models.DataSourcePlugin).
+		Where("id = ?", book.ID).
+		Exec(ctx)
+	require.NoError(t, err)
+	_, err = svc.BulkUpdateTags(ctx, []int{book.ID}, []string{"Resynced"}, []string{"Original"})
+	require.NoError(t, err)
+
+	stored, err = svc.RecordMetadataSnapshot(ctx, book.ID, nil, before, 5)
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	snapshots, err := svc.ListMetadataSnapshots(ctx, book.ID)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	require.NotNil(t, snapshots[0].DataParsed)
+	assert.Equal(t, "Test Book", snapshots[0].DataParsed.Title)
+
+	reverted, err := svc.RevertToSnapshot(ctx, snapshots[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Test Book", reverted.Title)
+	assert.Equal(t, models.DataSourceFilepath, reverted.TitleSource)
+	require.Len(t, reverted.Authors, 1)
+	assert.Equal(t, "Original Author", reverted.Authors[0].Person.Name)
+	assert.Equal(t, []string{"Original"}, bookTagNames(t, svc, book.ID))
+}
+
+func TestService_RecordMetadataSnapshot_KeepsNewest(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	_, book := setupTestLibraryAndBook(t, db)
+
+	for i, title := range []string{"First", "Second", "Third"} {
+		loaded, err := svc.RetrieveBook(ctx, RetrieveBookOptions{ID: &book.ID})
+		require.NoError(t, err)
+		before := MetadataSnapshotDataFromBook(loaded)
+
+		_, err = db.NewUpdate().Model((*models.Book)(nil)).
+			Set("title = ?", title).
+			Where("id = ?", book.ID).
+			Exec(ctx)
+		require.NoError(t, err)
+
+		stored, err := svc.RecordMetadataSnapshot(ctx, book.ID, nil, before, 2)
+		require.NoError(t, err, "snapshot %d", i)
+		assert.True(t, stored)
+	}
+
+	snapshots, err := svc.ListMetadataSnapshots(ctx, book.ID)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, "Second", snapshots[0].DataParsed.Title)
+	assert.Equal(t, "First", snapshots[1].DataParsed.Title)
+}
+
+func TestService_RecordMetadataSnapshot_Disabled(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	_, book := setupTestLibraryAndBook(t, db)
+	before := &models.MetadataSnapshotData{Title: "Something Else"}
+
+	stored, err := svc.RecordMetadataSnapshot(ctx, book.ID, nil, before, 0)
+	require.NoError(t, err)
+	assert.False(t, stored)
+
+	snapshots, err := svc.ListMetadataSnapshots(ctx, book.ID)
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
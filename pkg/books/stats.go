@@ -0,0 +1,52 @@
+package books
+
+import (
+	"context"
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// LibraryStats counts how many of a library's books have a cover, a
+// description, a series, identifiers, and a title from metadata rather than
+// the filename, in a single query.
+func (svc *Service) LibraryStats(ctx context.Context, libraryID int) (*LibraryStats, error) {
+	var counts struct {
+		Total           int `bun:"total"`
+		WithCover       int `bun:"with_cover"`
+		WithDescription int `bun:"with_description"`
+		WithSeries      int `bun:"with_series"`
+		WithIdentifiers int `bun:"with_identifiers"`
+		WithTitle       int `bun:"with_title"`
+	}
+	err := svc.db.NewSelect().
+		TableExpr("books AS b").
+		ColumnExpr("COUNT(*) AS total").
+		ColumnExpr("COALESCE(SUM(CASE WHEN EXISTS (SELECT 1 FROM files f WHERE f.book_id = b.id AND f.cover_image_filename IS NOT NULL AND f.cover_image_filename != '') THEN 1 ELSE 0 END), 0) AS with_cover").
+		ColumnExpr("COALESCE(SUM(CASE WHEN b.description IS NOT NULL AND TRIM(b.description) != '' THEN 1 ELSE 0 END), 0) AS with_description").
+		ColumnExpr("COALESCE(SUM(CASE WHEN EXISTS (SELECT 1 FROM book_series bs WHERE bs.book_id = b.id) THEN 1 ELSE 0 END), 0) AS with_series").
+		ColumnExpr("COALESCE(SUM(CASE WHEN EXISTS (SELECT 1 FROM file_identifiers fi JOIN files f ON f.id = fi.file_id WHERE f.book_id = b.id) THEN 1 ELSE 0 END), 0) AS with_identifiers").
+		ColumnExpr("COALESCE(SUM(CASE WHEN b.title_source != ? THEN 1 ELSE 0 END), 0) AS with_title", models.DataSourceFilepath).
+		Where("b.library_id = ?", libraryID).
+		Scan(ctx, &counts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	stat := func(count int) LibraryStat {
+		s := LibraryStat{Count: count}
+		if counts.Total > 0 {
+			s.Percent = math.Round(float64(count)/float64(counts.Total)*1000) / 10
+		}
+		return s
+	}
+	return &LibraryStats{
+		TotalBooks:      counts.Total,
+		WithCover:       stat(counts.WithCover),
+		WithDescription: stat(counts.WithDescription),
+		WithSeries:      stat(counts.WithSeries),
+		WithIdentifiers: stat(counts.WithIdentifiers),
+		WithTitle:       stat(counts.WithTitle),
+	}, nil
+}
@@ -0,0 +1,103 @@
+package books
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibraryStats(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	// The throwaway book has only a filepath title and nothing else.
+	library, _ := setupTestLibraryAndBook(t, db)
+
+	description := "A description"
+	curated := &models.Book{
+		LibraryID:       library.ID,
+		Title:           "Curated",
+		TitleSource:     models.DataSourceEPUBMetadata,
+		SortTitle:       "Curated",
+		SortTitleSource: models.DataSourceEPUBMetadata,
+		AuthorSource:    models.DataSourceEPUBMetadata,
+		Description:     &description,
+		Filepath:        t.TempDir(),
+	}
+	_, err := db.NewInsert().Model(curated).Exec(ctx)
+	require.NoError(t, err)
+
+	coverFilename := "curated.epub.cover.jpg"
+	file := &models.File{
+		LibraryID:          library.ID,
+		BookID:             curated.ID,
+		FileType:           models.FileTypeEPUB,
+		FileRole:           models.FileRoleMain,
+		Filepath:           "/fake/curated.epub",
+		FilesizeBytes:      100,
+		CoverImageFilename: &coverFilename,
+	}
+	_, err = db.NewInsert().Model(file).Exec(ctx)
+	require.NoError(t, err)
+
+	// Two identifiers on the same book still count it once.
+	for idType, value := range map[string]string{
+		models.IdentifierTypeISBN13: "9780000000002",
+		models.IdentifierTypeISBN10: "0000000000",
+	} {
+		_, err = db.NewInsert().Model(&models.FileIdentifier{
+			FileID: file.ID,
+			Type:   idType,
+			Value:  value,
+			Source: models.DataSourceEPUBMetadata,
+		}).Exec(ctx)
+		require.NoError(t, err)
+	}
+
+	series := &models.Series{
+		LibraryID:      library.ID,
+		Name:           "Series",
+		NameSource:     models.DataSourceEPUBMetadata,
+		SortName:       "Series",
+		SortNameSource: models.DataSourceEPUBMetadata,
+	}
+	_, err = db.NewInsert().Model(series).Exec(ctx)
+	require.NoError(t, err)
+	_, err = db.NewInsert().Model(&models.BookSeries{BookID: curated.ID, SeriesID: series.ID, SortOrder: 1}).Exec(ctx)
+	require.NoError(t, err)
+
+	stats, err := NewService(db).LibraryStats(ctx, library.ID)
+	require.NoError(t, err)
+
+	half := LibraryStat{Count: 1, Percent: 50}
+	assert.Equal(t, &LibraryStats{
+		TotalBooks:      2,
+		WithCover:       half,
+		WithDescription: half,
+		WithSeries:      half,
+		WithIdentifiers: half,
+		WithTitle:       half,
+	}, stats)
+}
+
+func TestLibraryStats_EmptyLibrary(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	library := &models.Library{
+		Name:                     "Empty Library",
+		CoverAspectRatio:         "book",
+		DownloadFormatPreference: models.DownloadFormatOriginal,
+	}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+
+	stats, err := NewService(db).LibraryStats(ctx, library.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &LibraryStats{}, stats)
+}
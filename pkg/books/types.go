@@ -1,12 +1,17 @@
 package books
 
-import "github.com/shishobooks/shisho/pkg/models"
+import (
+	"time"
+
+	"github.com/shishobooks/shisho/pkg/models"
+)
 
 type ListBooksQuery struct {
 	Limit          int      `query:"limit" json:"limit,omitempty" default:"24" validate:"min=1,max=50"`
 	Offset         int      `query:"offset" json:"offset,omitempty" validate:"min=0"`
 	LibraryID      *int     `query:"library_id" json:"library_id,omitempty" validate:"omitempty,min=1" tstype:"number"`
 	SeriesID       *int     `query:"series_id" json:"series_id,omitempty" validate:"omitempty,min=1" tstype:"number"`
+	SeriesOrder    string   `query:"series_order" json:"series_order,omitempty" validate:"omitempty,oneof=publication reading" tstype:"SeriesOrder"` // Order of a series listing when no sort is given; "" = publication
 	Search         *string  `query:"search" json:"search,omitempty" validate:"omitempty,max=100" tstype:"string"`
 	FileTypes      []string `query:"file_types" json:"file_types,omitempty"`                                         // Filter by file types (e.g., ["epub", "m4b"])
 	GenreIDs       []int    `query:"genre_ids" json:"genre_ids,omitempty"`                                           // Filter by genre IDs
@@ -15,12 +20,104 @@ type ListBooksQuery struct {
 	IDs            []int    `query:"ids" json:"ids,omitempty"`                                                       // Filter by specific book IDs
 	Sort           string   `query:"sort" json:"sort,omitempty" validate:"omitempty,max=200"`
 	ReviewedFilter string   `query:"reviewed_filter" json:"reviewed_filter,omitempty" validate:"omitempty,oneof=all needs_review reviewed" tstype:"ReviewedFilter"` // "" or "all" = all books, "needs_review", "reviewed"
+	IncludeHidden  bool     `query:"include_hidden" json:"include_hidden,omitempty"`                                                                                // Include hidden books and files
+	Cursor         *string  `query:"cursor" json:"cursor,omitempty" validate:"omitempty,max=2000" tstype:"string"`                                                  // Switches to cursor pagination ordered by sort title; "" for the first page, then each response's next_cursor
+}
+
+// ListFilesQuery is the query for GET /files.
+type ListFilesQuery struct {
+	Limit        int      `query:"limit" json:"limit,omitempty" default:"50" validate:"min=1,max=100"`
+	Offset       int      `query:"offset" json:"offset,omitempty" validate:"min=0"`
+	LibraryID    *int     `query:"library_id" json:"library_id,omitempty" validate:"omitempty,min=1" tstype:"number"`
+	FileTypes    []string `query:"file_types" json:"file_types,omitempty" validate:"max=10,dive,oneof=epub cbz m4b pdf mobi azw3" tstype:"FileType[]"`
+	FileRole     *string  `query:"file_role" json:"file_role,omitempty" validate:"omitempty,oneof=main supplement" tstype:"FileRole"`
+	MissingCover bool     `query:"missing_cover" json:"missing_cover,omitempty"`                                // Only files without a cover image
+	Source       *string  `query:"source" json:"source,omitempty" validate:"omitempty,max=200" tstype:"string"` // Only files with any metadata field from this source (e.g., "sidecar", "plugin:shisho/goodreads")
+	Sort         string   `query:"sort" json:"sort,omitempty" validate:"omitempty,oneof=created_at_asc created_at_desc filepath_asc filepath_desc filesize_asc filesize_desc" tstype:"FileSort"`
+}
+
+// ListFilesResponse is the list-endpoint envelope for files.
+type ListFilesResponse struct {
+	Items []*models.File `json:"items" tstype:"File[]"`
+	Total int            `json:"total"`
+}
+
+// GetPageQuery is the query for GET /books/files/:id/page/:pageNum.
+type GetPageQuery struct {
+	Width int `query:"w" json:"w,omitempty" validate:"min=0,max=4096"` // Scale CBZ pages down to at most this width (0 = original)
+}
+
+// SidecarResponse is the response of GET /books/:id/sidecar and
+// GET /files/:id/sidecar.
+type SidecarResponse struct {
+	Path       string    `json:"path"`
+	ModifiedAt time.Time `json:"modified_at"`
+	// Sidecar is the parsed sidecar, as read during scans. Unset when the
+	// sidecar is invalid.
+	Sidecar any `json:"sidecar,omitempty" tstype:"Record<string, unknown>"`
+	// Error explains why an invalid sidecar was ignored.
+	Error string `json:"error,omitempty"`
+}
+
+// LibraryStats is the response of GET /libraries/:id/stats. It reports how
+// many of a library's books have each kind of metadata, to show where
+// curation is needed.
+type LibraryStats struct {
+	TotalBooks      int         `json:"total_books"`
+	WithCover       LibraryStat `json:"with_cover"`
+	WithDescription LibraryStat `json:"with_description"`
+	WithSeries      LibraryStat `json:"with_series"`
+	WithIdentifiers LibraryStat `json:"with_identifiers"` // At least one file has an identifier
+	WithTitle       LibraryStat `json:"with_title"`       // Title came from metadata rather than the filename
+}
+
+// LibraryStat is the number of books with some kind of metadata, and the
+// percentage of the library's books that is (0 when it has no books).
+type LibraryStat struct {
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+// IntegrityReport is the response of GET /libraries/:id/verify. It lists
+// where a library's files in the database and on disk disagree.
+type IntegrityReport struct {
+	LibraryID      int                    `json:"library_id"`
+	CheckedFiles   int                    `json:"checked_files"`   // Files in the database that were checked
+	MissingFiles   []IntegrityFile        `json:"missing_files"`   // In the database but not on disk
+	UntrackedFiles []string               `json:"untracked_files"` // On disk but not in the database
+	ChangedFiles   []IntegrityChangedFile `json:"changed_files"`   // Size or modification time differs from the last scan
+	MissingCovers  []IntegrityFile        `json:"missing_covers"`  // Cover image is recorded but not on disk
+}
+
+// IntegrityFile is a file from the database listed in an IntegrityReport.
+type IntegrityFile struct {
+	FileID   int    `json:"file_id"`
+	BookID   int    `json:"book_id"`
+	Filepath string `json:"filepath"`
+}
+
+// IntegrityChangedFile is a file whose size or modification time on disk
+// doesn't match what the last scan recorded.
+type IntegrityChangedFile struct {
+	FileID             int        `json:"file_id"`
+	BookID             int        `json:"book_id"`
+	Filepath           string     `json:"filepath"`
+	ExpectedSize       int64      `json:"expected_size"`
+	ActualSize         int64      `json:"actual_size"`
+	ExpectedModifiedAt *time.Time `json:"expected_modified_at"`
+	ActualModifiedAt   time.Time  `json:"actual_modified_at"`
+}
+
+// DownloadBookZipQuery is the query for GET /books/:id/download.zip.
+type DownloadBookZipQuery struct {
+	Supplements bool `query:"supplements" json:"supplements,omitempty"` // Include supplement files alongside the main files
 }
 
 // ListBooksResponse is the list-endpoint envelope for books.
 type ListBooksResponse struct {
-	Items []*models.Book `json:"items" tstype:"Book[]"`
-	Total int            `json:"total"`
+	Items      []*models.Book `json:"items" tstype:"Book[]"`
+	Total      int            `json:"total"`
+	NextCursor string         `json:"next_cursor,omitempty"` // Cursor for the next page in cursor mode; unset on the last page
 }
 
 // SetReviewPayload is the request body for the file and book review-override
@@ -35,6 +132,20 @@ type BulkSetReviewPayload struct {
 	Override *string `json:"override" validate:"omitempty,oneof=reviewed unreviewed" tstype:"ReviewOverride"`
 }
 
+// BulkUpdateNamesPayload is the request body for POST /books/bulk/tags and
+// POST /books/bulk/genres.
+type BulkUpdateNamesPayload struct {
+	BookIDs []int    `json:"book_ids" validate:"required,min=1,max=500"`
+	Add     []string `json:"add,omitempty" validate:"max=50,dive,max=100"`
+	Remove  []string `json:"remove,omitempty" validate:"max=50,dive,max=100"`
+}
+
+// BulkUpdateResponse lists the outcome for each requested book, in request
+// order.
+type BulkUpdateResponse struct {
+	Results []*BulkUpdateResult `json:"results"`
+}
+
 type UpdateBookPayload struct {
 	Title       *string       `json:"title,omitempty" mod:"trim" validate:"omitempty,min=1,max=300"`
 	SortTitle   *string       `json:"sort_title,omitempty" validate:"omitempty,max=300"`
@@ -44,6 +155,7 @@ type UpdateBookPayload struct {
 	Series      []SeriesInput `json:"series,omitempty" validate:"omitempty,dive"`
 	Genres      []string      `json:"genres,omitempty" validate:"omitempty,dive,max=100"` // Genre names
 	Tags        []string      `json:"tags,omitempty" validate:"omitempty,dive,max=100"`   // Tag names
+	Hidden      *bool         `json:"hidden,omitempty"`
 }
 
 // AuthorInput represents an author with an optional role (for CBZ files).
@@ -58,6 +170,7 @@ type SeriesInput struct {
 	Number           *float64 `json:"number,omitempty"`
 	NumberEnd        *float64 `json:"number_end,omitempty"`
 	SeriesNumberUnit *string  `json:"series_number_unit,omitempty" validate:"omitempty,oneof=volume chapter" tstype:"SeriesNumberUnit"`
+	ReadingOrder     *float64 `json:"reading_order,omitempty"`
 }
 
 // IdentifierPayload represents an identifier in update requests.
@@ -78,6 +191,7 @@ type UpdateFilePayload struct {
 	Abridged         *string              `json:"abridged,omitempty" validate:"omitempty,oneof=true false"` // "true", "false", or "" to clear
 	Identifiers      *[]IdentifierPayload `json:"identifiers,omitempty" mod:"dive" validate:"omitempty,dive"`
 	IsPreferredCover *bool                `json:"is_preferred_cover,omitempty"`
+	Hidden           *bool                `json:"hidden,omitempty"`
 }
 
 // ResyncMode is the scan mode for resync operations: "scan" (default),
@@ -123,6 +237,24 @@ type MoveFilesResponse struct {
 	SourceBookDeleted bool         `json:"source_book_deleted"`
 }
 
+// MoveFilePayload is the payload for moving a single file to another book.
+type MoveFilePayload struct {
+	TargetBookID int `json:"target_book_id" validate:"required,min=1"`
+}
+
+// PreviewFileMetadataPayload is the payload for previewing a file's parsed
+// metadata without importing it.
+type PreviewFileMetadataPayload struct {
+	LibraryID int    `json:"library_id" validate:"required,min=1"`
+	Filepath  string `json:"filepath" validate:"required"`
+}
+
+// ImportFromURLPayload is the payload for importing a book from a remote URL.
+type ImportFromURLPayload struct {
+	LibraryID int    `json:"library_id" validate:"required,min=1"`
+	URL       string `json:"url" mod:"trim" validate:"required,url,max=2048"`
+}
+
 // MergeBooksPayload is the payload for merging multiple books.
 type MergeBooksPayload struct {
 	SourceBookIDs []int `json:"source_book_ids" validate:"required,min=1,dive,min=1"`
@@ -136,6 +268,12 @@ type MergeBooksResponse struct {
 	BooksDeleted int          `json:"books_deleted"`
 }
 
+// PairBooksPayload is the payload for pairing a book with the other format
+// of the same title.
+type PairBooksPayload struct {
+	BookID int `json:"book_id" validate:"required,min=1"`
+}
+
 // DeleteBooksPayload is the request body for bulk book deletion.
 type DeleteBooksPayload struct {
 	BookIDs []int `json:"book_ids"`
@@ -176,3 +314,32 @@ type ResyncFileResponse struct {
 type ResyncBookResponse struct {
 	BookDeleted bool `json:"book_deleted"`
 }
+
+// AudioQualityFile holds the audio properties of a single audiobook file.
+type AudioQualityFile struct {
+	FileID     int     `json:"file_id"`
+	Filepath   string  `json:"filepath"`
+	BitrateBps *int    `json:"bitrate_bps"`
+	Codec      *string `json:"codec"`
+	SampleRate *int    `json:"sample_rate"`
+	Channels   *int    `json:"channels"`
+}
+
+// AudioQualitySummary holds the range of audio properties across a book's
+// audiobook files. Ranges are nil when no file reports the property.
+type AudioQualitySummary struct {
+	FileCount     int      `json:"file_count"`
+	Codecs        []string `json:"codecs"`
+	MinBitrateBps *int     `json:"min_bitrate_bps"`
+	MaxBitrateBps *int     `json:"max_bitrate_bps"`
+	MinSampleRate *int     `json:"min_sample_rate"`
+	MaxSampleRate *int     `json:"max_sample_rate"`
+	MinChannels   *int     `json:"min_channels"`
+	MaxChannels   *int     `json:"max_channels"`
+}
+
+// AudioQualityResponse is the response for a book's audio quality summary.
+type AudioQualityResponse struct {
+	Files   []AudioQualityFile  `json:"files"`
+	Summary AudioQualitySummary `json:"summary"`
+}
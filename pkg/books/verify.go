@@ -0,0 +1,164 @@
+package books
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/fileutils"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// VerifyLibraryIntegrity compares a library's files in the database with
+// what's on disk and reports the differences: files that are missing, files
+// that were never imported, files whose size or modification time changed
+// since the last scan, and covers that are referenced but gone. Nothing is
+// modified; a scan is what reconciles the two.
+func (svc *Service) VerifyLibraryIntegrity(ctx context.Context, libraryID int) (*IntegrityReport, error) {
+	var library models.Library
+	err := svc.db.NewSelect().
+		Model(&library).
+		Relation("LibraryPaths").
+		Where("l.id = ?", libraryID).
+		Scan(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var files []*models.File
+	err = svc.db.NewSelect().
+		Model(&files).
+		Column("f.id", "f.book_id", "f.filepath", "f.filesize_bytes", "f.file_modified_at", "f.cover_image_filename").
+		Where("f.library_id = ?", libraryID).
+		Order("f.filepath ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	report := &IntegrityReport{
+		LibraryID:      libraryID,
+		CheckedFiles:   len(files),
+		MissingFiles:   []IntegrityFile{},
+		UntrackedFiles: []string{},
+		ChangedFiles:   []IntegrityChangedFile{},
+		MissingCovers:  []IntegrityFile{},
+	}
+
+	known := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		known[filepath.Clean(file.Filepath)] = struct{}{}
+		checkFileIntegrity(report, file)
+	}
+
+	ignores := fileutils.NewIgnoreMatcher()
+	for _, libraryPath := range library.LibraryPaths {
+		untracked, err := findUntrackedFiles(ctx, &library, libraryPath.Filepath, known, ignores)
+		if err != nil {
+			return nil, err
+		}
+		report.UntrackedFiles = append(report.UntrackedFiles, untracked...)
+	}
+	sort.Strings(report.UntrackedFiles)
+
+	return report, nil
+}
+
+// checkFileIntegrity stats a file from the database and adds it to the
+// report if it's missing, has changed, or has lost its cover. Modification
+// times are compared to the second, like the scan does, and aren't compared
+// at all for files imported before they were recorded.
+func checkFileIntegrity(report *IntegrityReport, file *models.File) {
+	entry := IntegrityFile{FileID: file.ID, BookID: file.BookID, Filepath: file.Filepath}
+
+	info, err := os.Stat(file.Filepath)
+	if err != nil {
+		report.MissingFiles = append(report.MissingFiles, entry)
+		return
+	}
+
+	sizeChanged := info.Size() != file.FilesizeBytes
+	modTimeChanged := file.FileModifiedAt != nil &&
+		!info.ModTime().Truncate(time.Second).Equal(file.FileModifiedAt.Truncate(time.Second))
+	if sizeChanged || modTimeChanged {
+		report.ChangedFiles = append(report.ChangedFiles, IntegrityChangedFile{
+			FileID:             file.ID,
+			BookID:             file.BookID,
+			Filepath:           file.Filepath,
+			ExpectedSize:       file.FilesizeBytes,
+			ActualSize:         info.Size(),
+			ExpectedModifiedAt: file.FileModifiedAt,
+			ActualModifiedAt:   info.ModTime(),
+		})
+	}
+
+	if file.CoverImageFilename != nil && *file.CoverImageFilename != "" {
+		coverPath := filepath.Join(filepath.Dir(file.Filepath), *file.CoverImageFilename)
+		if _, err := os.Stat(coverPath); err != nil {
+			report.MissingCovers = append(report.MissingCovers, entry)
+		}
+	}
+}
+
+// findUntrackedFiles walks a library path for files of a type the library
+// imports that aren't in known. Paths excluded by .shishoignore are skipped,
+// as they are by the scan.
+func findUntrackedFiles(ctx context.Context, library *models.Library, root string, known map[string]struct{}, ignores *fileutils.IgnoreMatcher) ([]string, error) {
+	var untracked []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			// A library path that's gone already shows up as missing
+			// files, and other unreadable entries are skipped rather than
+			// failing the whole report.
+			if path == root {
+				return filepath.SkipAll
+			}
+			if entry != nil && entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ignores.Match(root, path, entry.IsDir()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		fileType := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+		if !isBuiltInFileType(fileType) || !library.AllowsFileType(fileType) {
+			return nil
+		}
+		if _, ok := known[filepath.Clean(path)]; !ok {
+			untracked = append(untracked, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return untracked, nil
+}
+
+// isBuiltInFileType reports whether fileType is one of the types Shisho
+// imports without a plugin.
+func isBuiltInFileType(fileType string) bool {
+	switch fileType {
+	case models.FileTypeCBZ, models.FileTypeEPUB, models.FileTypeM4B, models.FileTypePDF, models.FileTypeMOBI, models.FileTypeAZW3:
+		return true
+	}
+	return false
+}
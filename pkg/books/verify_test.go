@@ -0,0 +1,121 @@
+package books
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyLibraryIntegrity(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	library, book := setupTestLibraryAndBook(t, db)
+	libraryDir := t.TempDir()
+	_, err := db.NewInsert().Model(&models.LibraryPath{LibraryID: library.ID, Filepath: libraryDir}).Exec(ctx)
+	require.NoError(t, err)
+
+	write := func(rel, content string) string {
+		path := filepath.Join(libraryDir, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+	insertFile := func(path string, size int64, modifiedAt *time.Time, coverFilename *string) *models.File {
+		file := &models.File{
+			LibraryID:          library.ID,
+			BookID:             book.ID,
+			FileType:           models.FileTypeEPUB,
+			FileRole:           models.FileRoleMain,
+			Filepath:           path,
+			FilesizeBytes:      size,
+			FileModifiedAt:     modifiedAt,
+			CoverImageFilename: coverFilename,
+		}
+		_, err := db.NewInsert().Model(file).Exec(ctx)
+		require.NoError(t, err)
+		return file
+	}
+	modTime := func(path string) *time.Time {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		mt := info.ModTime()
+		return &mt
+	}
+
+	// Matches the database, including its cover.
+	okPath := write("Author/OK/ok.epub", "ok")
+	write("Author/OK/ok.epub.cover.jpg", "jpg")
+	cover := "ok.epub.cover.jpg"
+	insertFile(okPath, 2, modTime(okPath), &cover)
+
+	// Was never scanned with a modification time, so only size counts.
+	legacyPath := write("Author/Legacy/legacy.epub", "legacy")
+	insertFile(legacyPath, 6, nil, nil)
+
+	resizedPath := write("Author/Resized/resized.epub", "bigger now")
+	resized := insertFile(resizedPath, 3, modTime(resizedPath), nil)
+
+	touchedPath := write("Author/Touched/touched.epub", "touched")
+	earlier := modTime(touchedPath).Add(-time.Hour)
+	touched := insertFile(touchedPath, 7, &earlier, nil)
+
+	missing := insertFile(filepath.Join(libraryDir, "Author", "Gone", "gone.epub"), 4, nil, nil)
+
+	coverlessPath := write("Author/Coverless/coverless.epub", "coverless")
+	lostCover := "coverless.epub.cover.jpg"
+	coverless := insertFile(coverlessPath, 9, modTime(coverlessPath), &lostCover)
+
+	untracked := write("Author/New/new.epub", "new")
+	write("Author/New/notes.txt", "not a book")
+	write(".shishoignore", "drafts/\n")
+	write("drafts/draft.epub", "ignored")
+
+	report, err := NewService(db).VerifyLibraryIntegrity(ctx, library.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, library.ID, report.LibraryID)
+	assert.Equal(t, 6, report.CheckedFiles)
+	assert.Equal(t, []IntegrityFile{{FileID: missing.ID, BookID: book.ID, Filepath: missing.Filepath}}, report.MissingFiles)
+	assert.Equal(t, []string{untracked}, report.UntrackedFiles)
+	assert.Equal(t, []IntegrityFile{{FileID: coverless.ID, BookID: book.ID, Filepath: coverlessPath}}, report.MissingCovers)
+
+	require.Len(t, report.ChangedFiles, 2)
+	assert.Equal(t, resized.ID, report.ChangedFiles[0].FileID)
+	assert.Equal(t, int64(3), report.ChangedFiles[0].ExpectedSize)
+	assert.Equal(t, int64(10), report.ChangedFiles[0].ActualSize)
+	assert.Equal(t, touched.ID, report.ChangedFiles[1].FileID)
+	assert.Equal(t, report.ChangedFiles[1].ExpectedSize, report.ChangedFiles[1].ActualSize)
+	assert.True(t, report.ChangedFiles[1].ActualModifiedAt.After(*report.ChangedFiles[1].ExpectedModifiedAt))
+}
+
+func TestVerifyLibraryIntegrity_SkipsDisallowedTypes(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	library, _ := setupTestLibraryAndBook(t, db)
+	library.AllowedFileTypes = []string{models.FileTypeM4B}
+	_, err := db.NewUpdate().Model(library).Column("allowed_file_types").WherePK().Exec(ctx)
+	require.NoError(t, err)
+
+	libraryDir := t.TempDir()
+	_, err = db.NewInsert().Model(&models.LibraryPath{LibraryID: library.ID, Filepath: libraryDir}).Exec(ctx)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(libraryDir, "book.epub"), []byte("epub"), 0600))
+	// Extensions are matched regardless of case, like the scanner does.
+	audiobook := filepath.Join(libraryDir, "book.M4B")
+	require.NoError(t, os.WriteFile(audiobook, []byte("m4b"), 0600))
+
+	report, err := NewService(db).VerifyLibraryIntegrity(ctx, library.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{audiobook}, report.UntrackedFiles)
+	assert.Empty(t, report.MissingFiles)
+}
@@ -16,17 +16,19 @@ type Provider interface {
 
 // Handler exposes HTTP endpoints for cache management.
 type Handler struct {
-	downloads Provider
-	cbzPages  Provider
-	pdfPages  Provider
+	downloads   Provider
+	cbzPages    Provider
+	pdfPages    Provider
+	openLibrary Provider
 }
 
 // NewHandler returns a new cache management handler.
-func NewHandler(downloads, cbzPages, pdfPages Provider) *Handler {
+func NewHandler(downloads, cbzPages, pdfPages, openLibrary Provider) *Handler {
 	return &Handler{
-		downloads: downloads,
-		cbzPages:  cbzPages,
-		pdfPages:  pdfPages,
+		downloads:   downloads,
+		cbzPages:    cbzPages,
+		pdfPages:    pdfPages,
+		openLibrary: openLibrary,
 	}
 }
 
@@ -78,6 +80,12 @@ func (h *Handler) entries() []cacheEntry {
 			description: "JPEGs rendered from PDF pages for the in-app reader.",
 			provider:    h.pdfPages,
 		},
+		{
+			id:          "open_library",
+			name:        "Open Library",
+			description: "ISBN lookups and covers fetched from Open Library for metadata enrichment.",
+			provider:    h.openLibrary,
+		},
 	}
 }
 
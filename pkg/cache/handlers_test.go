@@ -39,15 +39,15 @@ func newTestFakes() (*fakeCache, *fakeCache, *fakeCache) {
 
 func newTestHandler() (*Handler, *fakeCache, *fakeCache, *fakeCache) {
 	dl, cbz, pdf := newTestFakes()
-	return NewHandler(dl, cbz, pdf), dl, cbz, pdf
+	return NewHandler(dl, cbz, pdf, &fakeCache{}), dl, cbz, pdf
 }
 
 func newTestHandlerOnly() *Handler {
 	dl, cbz, pdf := newTestFakes()
-	return NewHandler(dl, cbz, pdf)
+	return NewHandler(dl, cbz, pdf, &fakeCache{bytes: 10, count: 3})
 }
 
-func TestList_ReturnsAllThreeCaches(t *testing.T) {
+func TestList_ReturnsAllCaches(t *testing.T) {
 	t.Parallel()
 	h := newTestHandlerOnly()
 
@@ -61,12 +61,16 @@ func TestList_ReturnsAllThreeCaches(t *testing.T) {
 
 	var resp ListResponse
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Len(t, resp.Caches, 3)
+	require.Len(t, resp.Caches, 4)
 
-	ids := []string{resp.Caches[0].ID, resp.Caches[1].ID, resp.Caches[2].ID}
+	ids := make([]string, 0, len(resp.Caches))
+	for _, ci := range resp.Caches {
+		ids = append(ids, ci.ID)
+	}
 	assert.Contains(t, ids, "downloads")
 	assert.Contains(t, ids, "cbz_pages")
 	assert.Contains(t, ids, "pdf_pages")
+	assert.Contains(t, ids, "open_library")
 
 	for _, ci := range resp.Caches {
 		switch ci.ID {
@@ -79,6 +83,9 @@ func TestList_ReturnsAllThreeCaches(t *testing.T) {
 		case "pdf_pages":
 			assert.Equal(t, int64(25), ci.SizeBytes)
 			assert.Equal(t, 1, ci.FileCount)
+		case "open_library":
+			assert.Equal(t, int64(10), ci.SizeBytes)
+			assert.Equal(t, 3, ci.FileCount)
 		}
 	}
 }
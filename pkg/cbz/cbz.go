@@ -47,6 +47,7 @@ type ComicInfo struct {
 	Teams           string   `xml:"Teams"`
 	Locations       string   `xml:"Locations"`
 	StoryArc        string   `xml:"StoryArc"`
+	StoryArcNumber  string   `xml:"StoryArcNumber"`
 	AgeRating       string   `xml:"AgeRating"`
 	CommunityRating string   `xml:"CommunityRating"`
 	PageCount       string   `xml:"PageCount"`
@@ -167,6 +168,26 @@ func Parse(path string) (*mediafile.ParsedMetadata, error) {
 		addCreators(comicInfo.Translator, models.AuthorRoleTranslator)
 	}
 
+	// Story arcs become additional series after the main one. StoryArc and
+	// StoryArcNumber are parallel comma-separated lists.
+	var additionalSeries []mediafile.ParsedSeries
+	if comicInfo != nil && comicInfo.StoryArc != "" {
+		arcNumbers := strings.Split(comicInfo.StoryArcNumber, ",")
+		for i, arc := range strings.Split(comicInfo.StoryArc, ",") {
+			arc = strings.TrimSpace(arc)
+			if arc == "" || arc == series {
+				continue
+			}
+			parsed := mediafile.ParsedSeries{Name: arc}
+			if i < len(arcNumbers) {
+				if num, err := strconv.ParseFloat(strings.TrimSpace(arcNumbers[i]), 64); err == nil {
+					parsed.Number = &num
+				}
+			}
+			additionalSeries = append(additionalSeries, parsed)
+		}
+	}
+
 	// Extract genres and tags from ComicInfo
 	var genres []string
 	var tags []string
@@ -237,6 +258,11 @@ func Parse(path string) (*mediafile.ParsedMetadata, error) {
 		language = mediafile.NormalizeLanguage(comicInfo.LanguageISO)
 	}
 
+	var readingDirection string
+	if comicInfo != nil {
+		readingDirection = readingDirectionFromManga(comicInfo.Manga)
+	}
+
 	// Parse GTIN as identifier
 	var identifiersList []mediafile.ParsedIdentifier
 	if comicInfo != nil && comicInfo.GTIN != "" {
@@ -253,27 +279,42 @@ func Parse(path string) (*mediafile.ParsedMetadata, error) {
 	}
 
 	return &mediafile.ParsedMetadata{
-		Title:         title,
-		Authors:       authors,
-		Series:        series,
-		SeriesNumber:  seriesNumber,
-		Genres:        genres,
-		Tags:          tags,
-		Description:   description,
-		Publisher:     publisher,
-		URL:           url,
-		ReleaseDate:   releaseDate,
-		Language:      language,
-		CoverMimeType: coverMimeType,
-		CoverData:     coverData,
-		CoverPage:     coverPage,
-		PageCount:     pageCount,
-		DataSource:    models.DataSourceCBZMetadata,
-		Identifiers:   identifiersList,
-		Chapters:      chapters,
+		Title:            title,
+		Authors:          authors,
+		Series:           series,
+		SeriesNumber:     seriesNumber,
+		AdditionalSeries: additionalSeries,
+		Genres:           genres,
+		Tags:             tags,
+		Description:      description,
+		Publisher:        publisher,
+		URL:              url,
+		ReleaseDate:      releaseDate,
+		Language:         language,
+		CoverMimeType:    coverMimeType,
+		CoverData:        coverData,
+		CoverPage:        coverPage,
+		PageCount:        pageCount,
+		ReadingDirection: readingDirection,
+		DataSource:       models.DataSourceCBZMetadata,
+		Identifiers:      identifiersList,
+		Chapters:         chapters,
 	}, nil
 }
 
+// readingDirectionFromManga maps ComicInfo's <Manga> value to a reading
+// direction. "YesAndRightToLeft" is the only value that means right-to-left;
+// "Yes" marks manga read left-to-right. "Unknown" and empty values return "".
+func readingDirectionFromManga(manga string) string {
+	switch strings.ToLower(strings.TrimSpace(manga)) {
+	case "yesandrighttoleft":
+		return models.ReadingDirectionRTL
+	case "yes", "no":
+		return models.ReadingDirectionLTR
+	}
+	return ""
+}
+
 func ParseComicInfo(r io.ReadCloser) (*ComicInfo, error) {
 	defer r.Close()
 
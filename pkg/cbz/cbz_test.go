@@ -226,6 +226,103 @@ func TestParseCBZ_PublisherPrefersImprint(t *testing.T) {
 	}
 }
 
+func TestParseCBZ_ReadingDirection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		manga     string
+		wantValue string
+	}{
+		{name: "right to left", manga: "YesAndRightToLeft", wantValue: "rtl"},
+		{name: "manga read left to right", manga: "Yes", wantValue: "ltr"},
+		{name: "not manga", manga: "No", wantValue: "ltr"},
+		{name: "unknown", manga: "Unknown", wantValue: ""},
+		{name: "absent", manga: "", wantValue: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tmpDir := t.TempDir()
+			cbzPath := filepath.Join(tmpDir, "test.cbz")
+
+			f, err := os.Create(cbzPath)
+			require.NoError(t, err)
+
+			zw := zip.NewWriter(f)
+
+			imgWriter, err := zw.Create("page001.jpg")
+			require.NoError(t, err)
+			_, err = imgWriter.Write([]byte{0xFF, 0xD8, 0xFF, 0xE0}) // JPEG header
+			require.NoError(t, err)
+
+			comicInfo := "<?xml version=\"1.0\"?>\n<ComicInfo>\n  <Title>Test Comic</Title>\n"
+			if tt.manga != "" {
+				comicInfo += "  <Manga>" + tt.manga + "</Manga>\n"
+			}
+			comicInfo += "</ComicInfo>"
+
+			comicInfoWriter, err := zw.Create("ComicInfo.xml")
+			require.NoError(t, err)
+			_, err = comicInfoWriter.Write([]byte(comicInfo))
+			require.NoError(t, err)
+
+			require.NoError(t, zw.Close())
+			require.NoError(t, f.Close())
+
+			metadata, err := Parse(cbzPath)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantValue, metadata.ReadingDirection)
+		})
+	}
+}
+
+func TestParseCBZ_StoryArcsAsAdditionalSeries(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	cbzPath := filepath.Join(tmpDir, "test.cbz")
+
+	f, err := os.Create(cbzPath)
+	require.NoError(t, err)
+
+	zw := zip.NewWriter(f)
+
+	imgWriter, err := zw.Create("page001.jpg")
+	require.NoError(t, err)
+	_, err = imgWriter.Write([]byte{0xFF, 0xD8, 0xFF, 0xE0})
+	require.NoError(t, err)
+
+	comicInfoWriter, err := zw.Create("ComicInfo.xml")
+	require.NoError(t, err)
+	_, err = comicInfoWriter.Write([]byte(`<?xml version="1.0"?>
+<ComicInfo>
+  <Title>Test Comic</Title>
+  <Series>Saga</Series>
+  <Number>12</Number>
+  <StoryArc>The War for Phang, Saga, Homeworld</StoryArc>
+  <StoryArcNumber>2, 12</StoryArcNumber>
+</ComicInfo>`))
+	require.NoError(t, err)
+
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+
+	metadata, err := Parse(cbzPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Saga", metadata.Series)
+	// The arc matching the main series is skipped, and arcs without a
+	// matching number keep a nil number.
+	require.Len(t, metadata.AdditionalSeries, 2)
+	assert.Equal(t, "The War for Phang", metadata.AdditionalSeries[0].Name)
+	require.NotNil(t, metadata.AdditionalSeries[0].Number)
+	assert.InDelta(t, 2.0, *metadata.AdditionalSeries[0].Number, 0)
+	assert.Equal(t, "Homeworld", metadata.AdditionalSeries[1].Name)
+	assert.Nil(t, metadata.AdditionalSeries[1].Number)
+}
+
 func TestExtractSeriesNumberFromFilename(t *testing.T) {
 	t.Parallel()
 	floatPtr := func(f float64) *float64 { return &f }
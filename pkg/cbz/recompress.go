@@ -0,0 +1,245 @@
+package cbz
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultRecompressQuality is the JPEG quality pages are re-encoded at
+	// when none is given.
+	DefaultRecompressQuality = 85
+	// DefaultRecompressMinPageBytes is how big a PNG page has to be before
+	// it's re-encoded when no threshold is given.
+	DefaultRecompressMinPageBytes = 1 << 20
+)
+
+// RecompressOptions controls which pages RecompressPages re-encodes and how.
+type RecompressOptions struct {
+	// Quality is the JPEG quality, from 1 to 100. Defaults to
+	// DefaultRecompressQuality.
+	Quality int
+	// MinPageBytes is the uncompressed size a PNG page has to reach to be
+	// re-encoded. Defaults to DefaultRecompressMinPageBytes.
+	MinPageBytes int64
+}
+
+// RecompressResult describes what RecompressPages did.
+type RecompressResult struct {
+	// Recompressed is how many PNG pages were replaced with JPEGs.
+	Recompressed int
+	// OriginalSizeBytes and NewSizeBytes are the sizes of the archive
+	// before and after.
+	OriginalSizeBytes int64
+	NewSizeBytes      int64
+}
+
+// RecompressPages writes a copy of the CBZ at src to dst with its oversized
+// PNG pages re-encoded as JPEG. Every other entry, ComicInfo.xml included, is
+// copied byte for byte and entries keep their order. A page is only replaced
+// if the JPEG is smaller and its new name sorts the same way the old one did,
+// so page order and the page indexes in ComicInfo.xml stay valid.
+//
+// dst is written through a temporary file and renamed into place, so it may
+// be the same as src to recompress in place. An in-place run that has no
+// pages to replace leaves src untouched.
+func RecompressPages(src, dst string, opts RecompressOptions) (*RecompressResult, error) {
+	if opts.Quality <= 0 {
+		opts.Quality = DefaultRecompressQuality
+	}
+	if opts.Quality > 100 {
+		opts.Quality = 100
+	}
+	if opts.MinPageBytes <= 0 {
+		opts.MinPageBytes = DefaultRecompressMinPageBytes
+	}
+
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	r, err := zip.OpenReader(src)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer r.Close()
+
+	renames := planPageRenames(r.File, opts.MinPageBytes)
+	inPlace := filepath.Clean(src) == filepath.Clean(dst)
+	if inPlace && len(renames) == 0 {
+		return &RecompressResult{OriginalSizeBytes: srcInfo.Size(), NewSizeBytes: srcInfo.Size()}, nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".recompress-*.cbz")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	result := &RecompressResult{OriginalSizeBytes: srcInfo.Size()}
+	w := zip.NewWriter(tmp)
+	for _, f := range r.File {
+		newName, ok := renames[f.Name]
+		if ok {
+			replaced, err := writeRecompressedPage(w, f, newName, opts.Quality)
+			if err != nil {
+				tmp.Close()
+				return nil, err
+			}
+			if replaced {
+				result.Recompressed++
+				continue
+			}
+		}
+		if err := w.Copy(f); err != nil {
+			tmp.Close()
+			return nil, errors.Wrapf(err, "copy %s", f.Name)
+		}
+	}
+	if err := w.SetComment(r.Comment); err != nil {
+		tmp.Close()
+		return nil, errors.WithStack(err)
+	}
+	if err := w.Close(); err != nil {
+		tmp.Close()
+		return nil, errors.WithStack(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if inPlace && result.Recompressed == 0 {
+		result.NewSizeBytes = result.OriginalSizeBytes
+		return result, nil
+	}
+	info, err := os.Stat(tmpPath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	result.NewSizeBytes = info.Size()
+
+	// Close the source before replacing it, for in-place recompression on
+	// platforms that won't rename over an open file.
+	r.Close()
+	if err := os.Chmod(tmpPath, srcInfo.Mode().Perm()); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := os.Rename(tmpPath, dst); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return result, nil
+}
+
+// planPageRenames picks the PNG pages worth re-encoding and the names their
+// JPEGs get. Pages whose new name would collide with another entry or sort
+// differently against the other pages are left out.
+func planPageRenames(files []*zip.File, minPageBytes int64) map[string]string {
+	candidates := make(map[string]string)
+	taken := make(map[string]bool, len(files))
+	for _, f := range files {
+		taken[strings.ToLower(f.Name)] = true
+		if strings.ToLower(filepath.Ext(f.Name)) != ".png" || f.FileInfo().IsDir() {
+			continue
+		}
+		if int64(f.UncompressedSize64) < minPageBytes { //nolint:gosec // zip sizes fit in int64
+			continue
+		}
+		candidates[f.Name] = strings.TrimSuffix(f.Name, filepath.Ext(f.Name)) + ".jpg"
+	}
+
+	// Every name a page might end up with, to check the order against.
+	var names []string
+	for _, f := range getSortedImageFiles(&zip.Reader{File: files}) {
+		names = append(names, f.Name)
+	}
+	for _, newName := range candidates {
+		names = append(names, newName)
+	}
+
+	renames := make(map[string]string, len(candidates))
+	for oldName, newName := range candidates {
+		if taken[strings.ToLower(newName)] {
+			continue
+		}
+		low, high := newName, oldName
+		if low > high {
+			low, high = high, low
+		}
+		reorders := false
+		for _, name := range names {
+			if name != oldName && name != newName && name > low && name < high {
+				reorders = true
+				break
+			}
+		}
+		if !reorders {
+			renames[oldName] = newName
+		}
+	}
+	return renames
+}
+
+// writeRecompressedPage re-encodes the PNG page f as a JPEG named newName. It
+// reports false without writing anything if the page can't be decoded or the
+// JPEG wouldn't be smaller, so the caller copies the original instead.
+func writeRecompressedPage(w *zip.Writer, f *zip.File, newName string, quality int) (bool, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return false, errors.Wrapf(err, "open %s", f.Name)
+	}
+	original, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		return false, errors.Wrapf(err, "read %s", f.Name)
+	}
+
+	img, err := png.Decode(bytes.NewReader(original))
+	if err != nil {
+		return false, nil
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flattenAlpha(img), &jpeg.Options{Quality: quality}); err != nil {
+		return false, errors.Wrapf(err, "encode %s", f.Name)
+	}
+	if buf.Len() >= len(original) {
+		return false, nil
+	}
+
+	// JPEG data doesn't deflate, so it's stored as is.
+	entry, err := w.CreateHeader(&zip.FileHeader{
+		Name:     newName,
+		Method:   zip.Store,
+		Modified: f.Modified,
+	})
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	if _, err := entry.Write(buf.Bytes()); err != nil {
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+// flattenAlpha draws a page with transparency onto white, since JPEG has no
+// alpha channel and transparent areas would otherwise turn black.
+func flattenAlpha(img image.Image) image.Image {
+	if o, ok := img.(interface{ Opaque() bool }); ok && o.Opaque() {
+		return img
+	}
+	flat := image.NewRGBA(img.Bounds())
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, img.Bounds().Min, draw.Over)
+	return flat
+}
@@ -0,0 +1,138 @@
+package cbz
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noisyPNG encodes a size x size image of random pixels, which PNG can't
+// compress but JPEG can.
+func noisyPNG(t *testing.T, size int) []byte {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // deterministic test data
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(rng.Intn(256)), G: uint8(rng.Intn(256)), B: uint8(rng.Intn(256)), A: 255}) //nolint:gosec // 0-255
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func writeTestCBZ(t *testing.T, path string, entries []struct{ name, data string }) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	for _, e := range entries {
+		entry, err := w.Create(e.name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(e.data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+}
+
+func readTestCBZ(t *testing.T, path string) ([]string, map[string][]byte) {
+	t.Helper()
+	r, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer r.Close()
+	var names []string
+	contents := make(map[string][]byte)
+	for _, f := range r.File {
+		names = append(names, f.Name)
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		contents[f.Name] = data
+	}
+	return names, contents
+}
+
+func TestRecompressPages(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	src := filepath.Join(dir, "comic.cbz")
+	dst := filepath.Join(dir, "comic-small.cbz")
+
+	big := string(noisyPNG(t, 256))
+	small := string(noisyPNG(t, 8))
+	comicInfo := `<?xml version="1.0"?><ComicInfo><Title>T</Title><Pages><Page Image="0" Type="FrontCover"/></Pages></ComicInfo>`
+	writeTestCBZ(t, src, []struct{ name, data string }{
+		{"ComicInfo.xml", comicInfo},
+		{"001.png", big},
+		{"002.jpg", "already a jpeg"},
+		{"003.png", small},
+		{"004.PNG", big},
+	})
+
+	result, err := RecompressPages(src, dst, RecompressOptions{Quality: 80, MinPageBytes: 1024})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Recompressed)
+	assert.Less(t, result.NewSizeBytes, result.OriginalSizeBytes)
+
+	names, contents := readTestCBZ(t, dst)
+	assert.Equal(t, []string{"ComicInfo.xml", "001.jpg", "002.jpg", "003.png", "004.jpg"}, names)
+	assert.Equal(t, comicInfo, string(contents["ComicInfo.xml"]))
+	assert.Equal(t, small, string(contents["003.png"]), "pages under the threshold are kept")
+	_, format, err := image.DecodeConfig(bytes.NewReader(contents["001.jpg"]))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+
+	// The original is left alone.
+	srcNames, _ := readTestCBZ(t, src)
+	assert.Equal(t, []string{"ComicInfo.xml", "001.png", "002.jpg", "003.png", "004.PNG"}, srcNames)
+}
+
+func TestRecompressPages_InPlace(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "comic.cbz")
+	writeTestCBZ(t, path, []struct{ name, data string }{
+		{"001.png", string(noisyPNG(t, 128))},
+	})
+
+	result, err := RecompressPages(path, path, RecompressOptions{MinPageBytes: 1024})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Recompressed)
+
+	names, _ := readTestCBZ(t, path)
+	assert.Equal(t, []string{"001.jpg"}, names)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, result.NewSizeBytes, info.Size())
+	leftovers, err := filepath.Glob(filepath.Join(filepath.Dir(path), ".recompress-*"))
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+}
+
+func TestPlanPageRenames_KeepsOrderAndAvoidsCollisions(t *testing.T) {
+	t.Parallel()
+	file := func(name string) *zip.File {
+		return &zip.File{FileHeader: zip.FileHeader{Name: name, UncompressedSize64: 10}}
+	}
+	renames := planPageRenames([]*zip.File{
+		file("a.png"),
+		file("a.jpg"), // a.png would collide with it
+		file("b.png"),
+		file("b.lo.jpg"), // b.png sorts after it, b.jpg would sort before
+		file("c.png"),
+	}, 1)
+	assert.Equal(t, map[string]string{"c.png": "c.jpg"}, renames)
+}
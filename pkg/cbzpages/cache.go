@@ -3,6 +3,10 @@ package cbzpages
 import (
 	"archive/zip"
 	"fmt"
+	"image"
+	_ "image/gif" // Register GIF decoder for resizing.
+	"image/jpeg"
+	_ "image/png" // Register PNG decoder for resizing.
 	"io"
 	"os"
 	"path/filepath"
@@ -11,8 +15,17 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // Register WebP decoder for resizing.
 )
 
+// MaxResizeWidth is the largest width GetResizedPage will scale a page to.
+// Bounding it keeps arbitrary widths from filling the cache with variants.
+const MaxResizeWidth = 4096
+
+// resizedJPEGQuality is the JPEG quality used for resized pages.
+const resizedJPEGQuality = 85
+
 // maxImageSize is the maximum size for a single page image (100 MB).
 // This prevents decompression bombs from consuming excessive memory.
 const maxImageSize = 100 * 1024 * 1024
@@ -42,6 +55,71 @@ func (c *Cache) GetPage(cbzPath string, fileID int, pageNum int) (cachedPath str
 	return c.extractPage(cbzPath, fileID, pageNum)
 }
 
+// GetResizedPage returns the path to a cached copy of a page scaled down to at
+// most width pixels wide, preserving the aspect ratio. Pages that are already
+// narrower than width are returned as-is. pageNum is 0-indexed.
+func (c *Cache) GetResizedPage(cbzPath string, fileID int, pageNum int, width int) (cachedPath string, mimeType string, err error) {
+	if width <= 0 || width > MaxResizeWidth {
+		return "", "", errors.Errorf("width %d out of range (1-%d)", width, MaxResizeWidth)
+	}
+
+	cachedPath = filepath.Join(c.pageDir(fileID), fmt.Sprintf("page_%d_w%d.jpg", pageNum, width))
+	if _, err := os.Stat(cachedPath); err == nil {
+		return cachedPath, "image/jpeg", nil
+	}
+
+	srcPath, srcMimeType, err := c.GetPage(cbzPath, fileID, pageNum)
+	if err != nil {
+		return "", "", err
+	}
+
+	srcFile, err := os.Open(srcPath)
+	if err != nil {
+		return "", "", errors.WithStack(err)
+	}
+	defer srcFile.Close()
+
+	srcImg, _, err := image.Decode(srcFile)
+	if err != nil {
+		return "", "", errors.WithStack(err)
+	}
+
+	srcBounds := srcImg.Bounds()
+	if srcBounds.Dx() <= width {
+		return srcPath, srcMimeType, nil
+	}
+
+	height := srcBounds.Dy() * width / srcBounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+	dstImg := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dstImg, dstImg.Bounds(), srcImg, srcBounds, draw.Over, nil)
+
+	// Write to a temp file and rename so concurrent readers never see a
+	// partially written image.
+	tmpPath := cachedPath + ".tmp"
+	outFile, err := os.Create(tmpPath)
+	if err != nil {
+		return "", "", errors.WithStack(err)
+	}
+	if err := jpeg.Encode(outFile, dstImg, &jpeg.Options{Quality: resizedJPEGQuality}); err != nil {
+		outFile.Close()
+		os.Remove(tmpPath)
+		return "", "", errors.WithStack(err)
+	}
+	if err := outFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return "", "", errors.WithStack(err)
+	}
+	if err := os.Rename(tmpPath, cachedPath); err != nil {
+		os.Remove(tmpPath)
+		return "", "", errors.WithStack(err)
+	}
+
+	return cachedPath, "image/jpeg", nil
+}
+
 // extractPage extracts a single page from a CBZ file and caches it.
 func (c *Cache) extractPage(cbzPath string, fileID int, pageNum int) (cachedPath string, mimeType string, err error) {
 	f, err := os.Open(cbzPath)
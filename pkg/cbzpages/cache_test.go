@@ -1,6 +1,10 @@
 package cbzpages
 
 import (
+	"archive/zip"
+	"image"
+	"image/color"
+	"image/png"
 	"os"
 	"path/filepath"
 	"testing"
@@ -77,3 +81,70 @@ func TestCache_Clear_IdempotentWhenMissing(t *testing.T) {
 
 	require.NoError(t, c.Clear())
 }
+
+func writeTestCBZ(t *testing.T, width, height int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "comic.cbz")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: 200, A: 255})
+		}
+	}
+
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("001.png")
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(w, img))
+	require.NoError(t, zw.Close())
+	return path
+}
+
+func TestCache_GetResizedPage(t *testing.T) {
+	t.Parallel()
+	c := NewCache(t.TempDir())
+	cbzPath := writeTestCBZ(t, 200, 100)
+
+	cachedPath, mimeType, err := c.GetResizedPage(cbzPath, 1, 0, 50)
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", mimeType)
+	assert.Equal(t, "page_0_w50.jpg", filepath.Base(cachedPath))
+
+	f, err := os.Open(cachedPath)
+	require.NoError(t, err)
+	defer f.Close()
+	cfg, _, err := image.DecodeConfig(f)
+	require.NoError(t, err)
+	assert.Equal(t, 50, cfg.Width)
+	assert.Equal(t, 25, cfg.Height)
+
+	// The original extraction is still served for unsized requests.
+	originalPath, mimeType, err := c.GetPage(cbzPath, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", mimeType)
+	assert.Equal(t, "page_0.png", filepath.Base(originalPath))
+}
+
+func TestCache_GetResizedPage_NarrowerThanWidth(t *testing.T) {
+	t.Parallel()
+	c := NewCache(t.TempDir())
+	cbzPath := writeTestCBZ(t, 40, 60)
+
+	cachedPath, mimeType, err := c.GetResizedPage(cbzPath, 1, 0, 400)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", mimeType)
+	assert.Equal(t, "page_0.png", filepath.Base(cachedPath))
+}
+
+func TestCache_GetResizedPage_InvalidWidth(t *testing.T) {
+	t.Parallel()
+	c := NewCache(t.TempDir())
+	cbzPath := writeTestCBZ(t, 40, 60)
+
+	_, _, err := c.GetResizedPage(cbzPath, 1, 0, MaxResizeWidth+1)
+	require.Error(t, err)
+}
@@ -0,0 +1,358 @@
+package chapters
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/uptrace/bun"
+)
+
+// UpdateChapterOptions holds the chapter fields to change. Nil fields are
+// left as they are.
+type UpdateChapterOptions struct {
+	Title            *string
+	StartPage        *int
+	StartTimestampMs *int64
+	Href             *string
+	CoverPageIndex   *int
+}
+
+// The granular edits below each run in a transaction over the file's full
+// chapter list, validate the result, and mark the file's chapters as manual so
+// later scans don't replace them.
+
+// UpdateChapter renames, moves, or sets the cover page of a single chapter.
+func (svc *Service) UpdateChapter(ctx context.Context, file *models.File, chapterID int, opts UpdateChapterOptions) error {
+	return svc.editChapters(ctx, file, func(ctx context.Context, tx bun.Tx, chapters []*models.Chapter) error {
+		ch := findChapter(chapters, chapterID)
+		if ch == nil {
+			return errcodes.NotFound("Chapter")
+		}
+		if opts.Title != nil {
+			if *opts.Title == "" {
+				return errcodes.ValidationError("title is required")
+			}
+			ch.Title = *opts.Title
+		}
+		if opts.StartPage != nil {
+			ch.StartPage = opts.StartPage
+		}
+		if opts.StartTimestampMs != nil {
+			ch.StartTimestampMs = opts.StartTimestampMs
+		}
+		if opts.Href != nil {
+			ch.Href = opts.Href
+		}
+		if opts.CoverPageIndex != nil {
+			if err := validateCoverPageIndex(file, *opts.CoverPageIndex); err != nil {
+				return err
+			}
+			ch.CoverPageIndex = opts.CoverPageIndex
+		}
+		if err := validateChapterTree(file, chapters); err != nil {
+			return err
+		}
+		ch.UpdatedAt = time.Now()
+		_, err := tx.NewUpdate().
+			Model(ch).
+			Column("title", "start_page", "start_timestamp_ms", "href", "cover_page_index", "updated_at").
+			WherePK().
+			Exec(ctx)
+		return errors.WithStack(err)
+	})
+}
+
+// SplitChapter splits an audiobook chapter in two at atMs. The new chapter
+// starts at atMs, right after the original, and is titled title (or the
+// original's title when empty).
+func (svc *Service) SplitChapter(ctx context.Context, file *models.File, chapterID int, atMs int64, title string) error {
+	return svc.editChapters(ctx, file, func(ctx context.Context, tx bun.Tx, chapters []*models.Chapter) error {
+		ch := findChapter(chapters, chapterID)
+		if ch == nil {
+			return errcodes.NotFound("Chapter")
+		}
+		if ch.StartTimestampMs == nil {
+			return errcodes.ValidationError("Only timestamped chapters can be split")
+		}
+		if atMs <= *ch.StartTimestampMs {
+			return errcodes.ValidationError("at_ms must be after the chapter's start")
+		}
+		if title == "" {
+			title = ch.Title
+		}
+
+		now := time.Now()
+		siblings := siblingsOf(chapters, ch.ParentID)
+		for _, s := range siblings {
+			if s.SortOrder > ch.SortOrder {
+				s.SortOrder++
+			}
+		}
+		split := &models.Chapter{
+			CreatedAt:        now,
+			UpdatedAt:        now,
+			FileID:           file.ID,
+			ParentID:         ch.ParentID,
+			SortOrder:        ch.SortOrder + 1,
+			Title:            title,
+			StartTimestampMs: &atMs,
+		}
+		if err := validateChapterTree(file, append(chapters, split)); err != nil {
+			return err
+		}
+
+		if err := saveSortOrders(ctx, tx, siblings); err != nil {
+			return err
+		}
+		_, err := tx.NewInsert().Model(split).Exec(ctx)
+		return errors.WithStack(err)
+	})
+}
+
+// MergeChapters merges adjacent sibling chapters into the first of them. The
+// first keeps its title and position, the others are deleted, and their
+// children move to the merged chapter.
+func (svc *Service) MergeChapters(ctx context.Context, file *models.File, chapterIDs []int) error {
+	return svc.editChapters(ctx, file, func(ctx context.Context, tx bun.Tx, chapters []*models.Chapter) error {
+		if len(chapterIDs) < 2 {
+			return errcodes.ValidationError("At least two chapters are required to merge")
+		}
+		merging := make([]*models.Chapter, 0, len(chapterIDs))
+		for _, id := range chapterIDs {
+			ch := findChapter(chapters, id)
+			if ch == nil {
+				return errcodes.NotFound("Chapter")
+			}
+			merging = append(merging, ch)
+		}
+		sort.Slice(merging, func(i, j int) bool { return merging[i].SortOrder < merging[j].SortOrder })
+
+		siblings := siblingsOf(chapters, merging[0].ParentID)
+		start := -1
+		for i, s := range siblings {
+			if s == merging[0] {
+				start = i
+			}
+		}
+		for i, ch := range merging {
+			if start+i >= len(siblings) || siblings[start+i] != ch {
+				return errcodes.ValidationError("Only adjacent chapters with the same parent can be merged")
+			}
+		}
+
+		// Children of the merged chapters move under the first one, after its
+		// own children, keeping their order.
+		target := merging[0]
+		nextOrder := len(siblingsOf(chapters, &target.ID))
+		removed := make(map[int]bool, len(merging)-1)
+		removedIDs := make([]int, 0, len(merging)-1)
+		for _, ch := range merging[1:] {
+			removed[ch.ID] = true
+			removedIDs = append(removedIDs, ch.ID)
+			for _, child := range siblingsOf(chapters, &ch.ID) {
+				child.ParentID = &target.ID
+				child.SortOrder = nextOrder
+				nextOrder++
+				_, err := tx.NewUpdate().
+					Model(child).
+					Column("parent_id", "sort_order").
+					WherePK().
+					Exec(ctx)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+			}
+		}
+
+		_, err := tx.NewDelete().
+			Model((*models.Chapter)(nil)).
+			Where("id IN (?)", bun.In(removedIDs)).
+			Exec(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		remaining := make([]*models.Chapter, 0, len(siblings))
+		for _, s := range siblings {
+			if !removed[s.ID] {
+				s.SortOrder = len(remaining)
+				remaining = append(remaining, s)
+			}
+		}
+		return saveSortOrders(ctx, tx, remaining)
+	})
+}
+
+// ReorderChapters sets the order of a group of sibling chapters. chapterIDs
+// must list every chapter under the same parent exactly once.
+func (svc *Service) ReorderChapters(ctx context.Context, file *models.File, chapterIDs []int) error {
+	return svc.editChapters(ctx, file, func(ctx context.Context, tx bun.Tx, chapters []*models.Chapter) error {
+		if len(chapterIDs) == 0 {
+			return errcodes.ValidationError("chapter_ids is required")
+		}
+		first := findChapter(chapters, chapterIDs[0])
+		if first == nil {
+			return errcodes.NotFound("Chapter")
+		}
+		siblings := siblingsOf(chapters, first.ParentID)
+		if len(siblings) != len(chapterIDs) {
+			return errcodes.ValidationError("chapter_ids must list every chapter with the same parent")
+		}
+		seen := make(map[int]bool, len(chapterIDs))
+		for i, id := range chapterIDs {
+			ch := findChapter(siblings, id)
+			if ch == nil || seen[id] {
+				return errcodes.ValidationError("chapter_ids must list every chapter with the same parent")
+			}
+			seen[id] = true
+			ch.SortOrder = i
+		}
+		if err := validateChapterTree(file, chapters); err != nil {
+			return err
+		}
+		return saveSortOrders(ctx, tx, siblings)
+	})
+}
+
+// SetChapterOffset sets how far file's chapters are shifted when served. The
+// stored timestamps aren't touched, and scans never change the offset.
+func (svc *Service) SetChapterOffset(ctx context.Context, file *models.File, offsetMs int) error {
+	_, err := svc.db.NewUpdate().
+		Model((*models.File)(nil)).
+		Set("chapter_offset_ms = ?", offsetMs).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", file.ID).
+		Exec(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	file.ChapterOffsetMs = offsetMs
+	return nil
+}
+
+// editChapters loads file's chapters in a transaction, runs edit on them, and
+// records the file's chapter source as manual.
+func (svc *Service) editChapters(ctx context.Context, file *models.File, edit func(ctx context.Context, tx bun.Tx, chapters []*models.Chapter) error) error {
+	return svc.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var chapters []*models.Chapter
+		err := tx.NewSelect().
+			Model(&chapters).
+			Where("file_id = ?", file.ID).
+			Order("sort_order ASC").
+			Scan(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if err := edit(ctx, tx, chapters); err != nil {
+			return err
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*models.File)(nil)).
+			Set("chapter_source = ?", models.DataSourceManual).
+			Set("updated_at = ?", time.Now()).
+			Where("id = ?", file.ID).
+			Exec(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		source := models.DataSourceManual
+		file.ChapterSource = &source
+		return nil
+	})
+}
+
+// validateChapterTree checks that, within each group of siblings, timestamps
+// increase in chapter order and don't run past the end of the audiobook.
+func validateChapterTree(file *models.File, chapters []*models.Chapter) error {
+	var maxMs *int64
+	if file.AudiobookDurationSeconds != nil {
+		ms := int64(*file.AudiobookDurationSeconds * 1000)
+		maxMs = &ms
+	}
+
+	groups := make(map[int][]*models.Chapter)
+	for _, ch := range chapters {
+		parent := 0
+		if ch.ParentID != nil {
+			parent = *ch.ParentID
+		}
+		groups[parent] = append(groups[parent], ch)
+	}
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool { return group[i].SortOrder < group[j].SortOrder })
+		var prev *int64
+		for _, ch := range group {
+			if ch.StartTimestampMs == nil {
+				continue
+			}
+			if *ch.StartTimestampMs < 0 {
+				return errcodes.ValidationError("start_timestamp_ms must not be negative")
+			}
+			if maxMs != nil && *ch.StartTimestampMs > *maxMs {
+				return errcodes.ValidationError("start_timestamp_ms exceeds file duration")
+			}
+			if prev != nil && *ch.StartTimestampMs <= *prev {
+				return errcodes.ValidationError("Chapter timestamps must increase in chapter order")
+			}
+			prev = ch.StartTimestampMs
+		}
+	}
+	return nil
+}
+
+// validateCoverPageIndex checks that the 0-indexed page can be used as a
+// chapter's cover page. Only CBZ chapters have cover pages.
+func validateCoverPageIndex(file *models.File, page int) error {
+	if file.FileType != models.FileTypeCBZ {
+		return errcodes.ValidationError("Only CBZ chapters have a cover page")
+	}
+	if page < 0 {
+		return errcodes.ValidationError("cover_page_index must be non-negative")
+	}
+	if file.PageCount != nil && page >= *file.PageCount {
+		return errcodes.ValidationError("cover_page_index must be less than page_count")
+	}
+	return nil
+}
+
+func findChapter(chapters []*models.Chapter, id int) *models.Chapter {
+	for _, ch := range chapters {
+		if ch.ID == id {
+			return ch
+		}
+	}
+	return nil
+}
+
+// siblingsOf returns the chapters under parentID (nil for top level), ordered
+// by sort order.
+func siblingsOf(chapters []*models.Chapter, parentID *int) []*models.Chapter {
+	var siblings []*models.Chapter
+	for _, ch := range chapters {
+		if (ch.ParentID == nil && parentID == nil) || (ch.ParentID != nil && parentID != nil && *ch.ParentID == *parentID) {
+			siblings = append(siblings, ch)
+		}
+	}
+	sort.SliceStable(siblings, func(i, j int) bool { return siblings[i].SortOrder < siblings[j].SortOrder })
+	return siblings
+}
+
+func saveSortOrders(ctx context.Context, tx bun.Tx, chapters []*models.Chapter) error {
+	for _, ch := range chapters {
+		_, err := tx.NewUpdate().
+			Model(ch).
+			Column("sort_order").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package chapters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/mediafile"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+// setupAudiobookChapters creates a 100-second M4B file with chapters starting
+// at 0s, 30s and 60s.
+func setupAudiobookChapters(t *testing.T, db *bun.DB) (*Service, *models.File) {
+	t.Helper()
+	ctx := context.Background()
+
+	library := &models.Library{
+		Name:                     "Test Library",
+		CoverAspectRatio:         "book",
+		DownloadFormatPreference: models.DownloadFormatOriginal,
+	}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+
+	book := &models.Book{
+		LibraryID:       library.ID,
+		Title:           "Test Audiobook",
+		Filepath:        t.TempDir(),
+		TitleSource:     models.DataSourceFilepath,
+		SortTitle:       "Test Audiobook",
+		SortTitleSource: models.DataSourceFilepath,
+		AuthorSource:    models.DataSourceFilepath,
+	}
+	_, err = db.NewInsert().Model(book).Exec(ctx)
+	require.NoError(t, err)
+
+	duration := 100.0
+	file := &models.File{
+		LibraryID:                library.ID,
+		BookID:                   book.ID,
+		FileType:                 models.FileTypeM4B,
+		FileRole:                 models.FileRoleMain,
+		Filepath:                 "/tmp/test.m4b",
+		FilesizeBytes:            1,
+		AudiobookDurationSeconds: &duration,
+	}
+	_, err = db.NewInsert().Model(file).Exec(ctx)
+	require.NoError(t, err)
+
+	svc := NewService(db)
+	starts := []int64{0, 30_000, 60_000}
+	parsed := make([]mediafile.ParsedChapter, len(starts))
+	for i := range starts {
+		parsed[i] = mediafile.ParsedChapter{Title: []string{"One", "Two", "Three"}[i], StartTimestampMs: &starts[i]}
+	}
+	require.NoError(t, svc.ReplaceChapters(ctx, file.ID, parsed))
+	return svc, file
+}
+
+func assertValidationError(t *testing.T, err error) {
+	t.Helper()
+	var codeErr *errcodes.Error
+	require.ErrorAs(t, err, &codeErr)
+	assert.Equal(t, "validation_error", codeErr.Code)
+}
+
+func chapterTitles(chapters []*models.Chapter) []string {
+	titles := make([]string, len(chapters))
+	for i, ch := range chapters {
+		titles[i] = ch.Title
+	}
+	return titles
+}
+
+func TestSplitChapter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := newTestDB(t)
+	svc, file := setupAudiobookChapters(t, db)
+
+	chapters, err := svc.ListChapters(ctx, file.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.SplitChapter(ctx, file, chapters[0].ID, 15_000, "One, Part 2"))
+
+	chapters, err = svc.ListChapters(ctx, file.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"One", "One, Part 2", "Two", "Three"}, chapterTitles(chapters))
+	assert.Equal(t, int64(15_000), *chapters[1].StartTimestampMs)
+
+	var after models.File
+	require.NoError(t, db.NewSelect().Model(&after).Where("f.id = ?", file.ID).Scan(ctx))
+	require.NotNil(t, after.ChapterSource)
+	assert.Equal(t, models.DataSourceManual, *after.ChapterSource)
+
+	// Splitting past the next chapter's start would break ordering.
+	err = svc.SplitChapter(ctx, file, chapters[2].ID, 65_000, "")
+	assertValidationError(t, err)
+}
+
+func TestMergeChapters(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := newTestDB(t)
+	svc, file := setupAudiobookChapters(t, db)
+
+	chapters, err := svc.ListChapters(ctx, file.ID)
+	require.NoError(t, err)
+
+	// Chapters that aren't adjacent can't be merged.
+	err = svc.MergeChapters(ctx, file, []int{chapters[0].ID, chapters[2].ID})
+	assertValidationError(t, err)
+
+	require.NoError(t, svc.MergeChapters(ctx, file, []int{chapters[2].ID, chapters[1].ID}))
+
+	chapters, err = svc.ListChapters(ctx, file.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"One", "Two"}, chapterTitles(chapters))
+	assert.Equal(t, 1, chapters[1].SortOrder)
+}
+
+func TestUpdateAndReorderChapters(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := newTestDB(t)
+	svc, file := setupAudiobookChapters(t, db)
+
+	chapters, err := svc.ListChapters(ctx, file.ID)
+	require.NoError(t, err)
+
+	title := "Prologue"
+	require.NoError(t, svc.UpdateChapter(ctx, file, chapters[0].ID, UpdateChapterOptions{Title: &title}))
+
+	tooLate := int64(200_000)
+	err = svc.UpdateChapter(ctx, file, chapters[2].ID, UpdateChapterOptions{StartTimestampMs: &tooLate})
+	assertValidationError(t, err)
+
+	// Reordering timestamped chapters out of time order is rejected.
+	err = svc.ReorderChapters(ctx, file, []int{chapters[1].ID, chapters[0].ID, chapters[2].ID})
+	assertValidationError(t, err)
+
+	chapters, err = svc.ListChapters(ctx, file.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Prologue", "Two", "Three"}, chapterTitles(chapters))
+	assert.Equal(t, int64(60_000), *chapters[2].StartTimestampMs)
+}
+
+func TestUpdateChapter_CoverPageIndex(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := newTestDB(t)
+	svc, audiobook := setupAudiobookChapters(t, db)
+
+	pageCount := 20
+	file := &models.File{
+		LibraryID:     audiobook.LibraryID,
+		BookID:        audiobook.BookID,
+		FileType:      models.FileTypeCBZ,
+		FileRole:      models.FileRoleMain,
+		Filepath:      "/tmp/test.cbz",
+		FilesizeBytes: 1,
+		PageCount:     &pageCount,
+	}
+	_, err := db.NewInsert().Model(file).Exec(ctx)
+	require.NoError(t, err)
+	starts := []int{0, 10}
+	require.NoError(t, svc.ReplaceChapters(ctx, file.ID, []mediafile.ParsedChapter{
+		{Title: "Arc One", StartPage: &starts[0]},
+		{Title: "Arc Two", StartPage: &starts[1]},
+	}))
+
+	chapters, err := svc.ListChapters(ctx, file.ID)
+	require.NoError(t, err)
+	coverPage := 12
+	require.NoError(t, svc.UpdateChapter(ctx, file, chapters[1].ID, UpdateChapterOptions{CoverPageIndex: &coverPage}))
+
+	chapter, err := svc.RetrieveChapter(ctx, file.ID, chapters[1].ID)
+	require.NoError(t, err)
+	require.NotNil(t, chapter.CoverPageIndex)
+	assert.Equal(t, 12, *chapter.CoverPageIndex)
+	assert.Equal(t, 12, *chapter.ThumbnailPageIndex())
+	require.NotNil(t, file.ChapterSource)
+	assert.Equal(t, models.DataSourceManual, *file.ChapterSource)
+
+	// Like start pages, cover pages are 0-indexed, so page_count is past
+	// the end.
+	pastEnd := 20
+	err = svc.UpdateChapter(ctx, file, chapters[1].ID, UpdateChapterOptions{CoverPageIndex: &pastEnd})
+	assertValidationError(t, err)
+
+	// Only CBZ chapters have cover pages.
+	audioChapters, err := svc.ListChapters(ctx, audiobook.ID)
+	require.NoError(t, err)
+	err = svc.UpdateChapter(ctx, audiobook, audioChapters[0].ID, UpdateChapterOptions{CoverPageIndex: &coverPage})
+	assertValidationError(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 	"github.com/shishobooks/shisho/pkg/books"
+	"github.com/shishobooks/shisho/pkg/cbzpages"
 	"github.com/shishobooks/shisho/pkg/errcodes"
 	"github.com/shishobooks/shisho/pkg/mediafile"
 	"github.com/shishobooks/shisho/pkg/models"
@@ -16,6 +17,7 @@ import (
 type handler struct {
 	chapterService *Service
 	bookService    *books.Service
+	pageCache      *cbzpages.Cache
 }
 
 func (h *handler) list(c echo.Context) error {
@@ -38,7 +40,7 @@ func (h *handler) list(c echo.Context) error {
 		}
 	}
 
-	chapters, err := h.chapterService.ListChapters(ctx, fileID)
+	chapters, err := h.chapterService.GetChapterTree(ctx, fileID)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -46,6 +48,63 @@ func (h *handler) list(c echo.Context) error {
 	return errors.WithStack(c.JSON(http.StatusOK, ChaptersResponse{Chapters: chapters}))
 }
 
+// thumbnail serves a CBZ chapter's cover page, or its start page when no
+// cover page is set, scaled down to the requested width. Pages are extracted
+// through the same cache the reader streams pages from.
+func (h *handler) thumbnail(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	fileID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("File")
+	}
+	chapterID, err := strconv.Atoi(c.Param("chapterId"))
+	if err != nil {
+		return errcodes.NotFound("Chapter")
+	}
+
+	params := ChapterThumbnailQuery{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	file, err := h.bookService.RetrieveFile(ctx, books.RetrieveFileOptions{ID: &fileID})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(file.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	if file.FileType != models.FileTypeCBZ {
+		return errcodes.ValidationError("Only CBZ chapters have thumbnails")
+	}
+
+	chapter, err := h.chapterService.RetrieveChapter(ctx, fileID, chapterID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	page := chapter.ThumbnailPageIndex()
+	if page == nil || *page < 0 || (file.PageCount != nil && *page >= *file.PageCount) {
+		return errcodes.NotFound("Page")
+	}
+
+	cachedPath, mimeType, err := h.pageCache.GetResizedPage(file.Filepath, file.ID, *page, params.Width)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// The page picked can change, so unlike pages themselves, thumbnails
+	// are revalidated.
+	c.Response().Header().Set("Cache-Control", "private, no-cache")
+	c.Response().Header().Set("Content-Type", mimeType)
+
+	return c.File(cachedPath)
+}
+
 func (h *handler) replace(c echo.Context) error {
 	ctx := c.Request().Context()
 
@@ -77,8 +136,9 @@ func (h *handler) replace(c echo.Context) error {
 		return err
 	}
 
-	// Convert input to ParsedChapter
-	chapters := convertInputToChapters(payload.Chapters)
+	// Convert input to ParsedChapter, taking the offset back out so the
+	// stored timestamps stay on the file's own timeline
+	chapters := convertInputToChapters(payload.Chapters, file.ChapterOffsetMs)
 
 	// Replace chapters
 	if err := h.chapterService.ReplaceChapters(ctx, fileID, chapters); err != nil {
@@ -101,7 +161,91 @@ func (h *handler) replace(c echo.Context) error {
 		_ = sidecar.WriteFileSidecarWithChapters(fileWithRelations, updatedChapters)
 	}
 
-	return errors.WithStack(c.JSON(http.StatusOK, ChaptersResponse{Chapters: updatedChapters}))
+	return errors.WithStack(c.JSON(http.StatusOK, ChaptersResponse{Chapters: models.OffsetChapters(updatedChapters, file.ChapterOffsetMs)}))
+}
+
+// patch applies a single granular chapter edit and marks the file's chapters
+// as manually edited.
+func (h *handler) patch(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	fileID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("File")
+	}
+
+	var payload PatchChaptersPayload
+	if err := c.Bind(&payload); err != nil {
+		return errors.WithStack(err)
+	}
+
+	file, err := h.bookService.RetrieveFile(ctx, books.RetrieveFileOptions{ID: &fileID})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(file.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	switch payload.Action {
+	case ChapterActionUpdate:
+		if payload.ChapterID == nil {
+			return errcodes.ValidationError("chapter_id is required")
+		}
+		var startMs *int64
+		if payload.StartTimestampMs != nil {
+			ms := removeOffset(*payload.StartTimestampMs, file.ChapterOffsetMs)
+			startMs = &ms
+		}
+		err = h.chapterService.UpdateChapter(ctx, file, *payload.ChapterID, UpdateChapterOptions{
+			Title:            payload.Title,
+			StartPage:        payload.StartPage,
+			StartTimestampMs: startMs,
+			Href:             payload.Href,
+			CoverPageIndex:   payload.CoverPageIndex,
+		})
+	case ChapterActionSplit:
+		if payload.ChapterID == nil || payload.AtMs == nil {
+			return errcodes.ValidationError("chapter_id and at_ms are required")
+		}
+		title := ""
+		if payload.Title != nil {
+			title = *payload.Title
+		}
+		err = h.chapterService.SplitChapter(ctx, file, *payload.ChapterID, removeOffset(*payload.AtMs, file.ChapterOffsetMs), title)
+	case ChapterActionMerge:
+		err = h.chapterService.MergeChapters(ctx, file, payload.ChapterIDs)
+	case ChapterActionReorder:
+		err = h.chapterService.ReorderChapters(ctx, file, payload.ChapterIDs)
+	case ChapterActionOffset:
+		if payload.OffsetMs == nil {
+			return errcodes.ValidationError("offset_ms is required")
+		}
+		err = h.chapterService.SetChapterOffset(ctx, file, *payload.OffsetMs)
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Recompute reviewed state — chapters are a configurable audio_field
+	h.bookService.RecomputeReviewedForFile(ctx, fileID)
+
+	updatedChapters, err := h.chapterService.ListChapters(ctx, fileID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Write sidecar file with updated chapters
+	fileWithRelations, err := h.bookService.RetrieveFileWithRelations(ctx, fileID)
+	if err == nil {
+		// Best effort - don't fail the request if sidecar write fails
+		_ = sidecar.WriteFileSidecarWithChapters(fileWithRelations, updatedChapters)
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, ChaptersResponse{Chapters: models.OffsetChapters(updatedChapters, file.ChapterOffsetMs)}))
 }
 
 // validateChapters validates chapter data against file constraints.
@@ -112,6 +256,11 @@ func validateChapters(file *models.File, chapters []ChapterInput) error {
 				return errcodes.ValidationError("start_page must be less than page_count")
 			}
 		}
+		if ch.CoverPageIndex != nil {
+			if err := validateCoverPageIndex(file, *ch.CoverPageIndex); err != nil {
+				return err
+			}
+		}
 		if ch.StartTimestampMs != nil && file.AudiobookDurationSeconds != nil {
 			maxMs := int64(*file.AudiobookDurationSeconds * 1000)
 			if *ch.StartTimestampMs > maxMs {
@@ -129,20 +278,31 @@ func validateChapters(file *models.File, chapters []ChapterInput) error {
 	return nil
 }
 
-// convertInputToChapters converts ChapterInput slice to ParsedChapter slice.
-func convertInputToChapters(inputs []ChapterInput) []mediafile.ParsedChapter {
+// convertInputToChapters converts ChapterInput slice to ParsedChapter slice,
+// removing offsetMs from the timestamps.
+func convertInputToChapters(inputs []ChapterInput, offsetMs int) []mediafile.ParsedChapter {
 	chapters := make([]mediafile.ParsedChapter, 0, len(inputs))
 	for _, in := range inputs {
 		ch := mediafile.ParsedChapter{
-			Title:            in.Title,
-			StartPage:        in.StartPage,
-			StartTimestampMs: in.StartTimestampMs,
-			Href:             in.Href,
+			Title:          in.Title,
+			StartPage:      in.StartPage,
+			Href:           in.Href,
+			CoverPageIndex: in.CoverPageIndex,
+		}
+		if in.StartTimestampMs != nil {
+			ms := removeOffset(*in.StartTimestampMs, offsetMs)
+			ch.StartTimestampMs = &ms
 		}
 		if len(in.Children) > 0 {
-			ch.Children = convertInputToChapters(in.Children)
+			ch.Children = convertInputToChapters(in.Children, offsetMs)
 		}
 		chapters = append(chapters, ch)
 	}
 	return chapters
 }
+
+// removeOffset converts a timestamp on the file's corrected timeline back to
+// the stored one, clamping at zero.
+func removeOffset(ms int64, offsetMs int) int64 {
+	return max(ms-int64(offsetMs), 0)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/shishobooks/shisho/pkg/binder"
 	"github.com/shishobooks/shisho/pkg/books"
 	"github.com/shishobooks/shisho/pkg/books/review"
+	"github.com/shishobooks/shisho/pkg/mediafile"
 	"github.com/shishobooks/shisho/pkg/migrations"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -148,3 +149,98 @@ func TestReplaceChapters_TriggersReviewRecompute(t *testing.T) {
 	require.NotNil(t, after.Reviewed, "reviewed should not be nil after recompute")
 	assert.True(t, *after.Reviewed, "file should be reviewed=true after chapters added with chapters-only criteria")
 }
+
+func TestChapterOffset(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	library := &models.Library{
+		Name:                     "Test Library",
+		CoverAspectRatio:         "book",
+		DownloadFormatPreference: models.DownloadFormatOriginal,
+	}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+
+	book := &models.Book{
+		LibraryID:       library.ID,
+		Title:           "Test Audiobook",
+		Filepath:        t.TempDir(),
+		TitleSource:     models.DataSourceFilepath,
+		SortTitle:       "Test Audiobook",
+		SortTitleSource: models.DataSourceFilepath,
+		AuthorSource:    models.DataSourceFilepath,
+	}
+	_, err = db.NewInsert().Model(book).Exec(ctx)
+	require.NoError(t, err)
+
+	file := &models.File{
+		LibraryID:     library.ID,
+		BookID:        book.ID,
+		FileType:      models.FileTypeM4B,
+		FileRole:      models.FileRoleMain,
+		Filepath:      "/tmp/test.m4b",
+		FilesizeBytes: 1,
+	}
+	_, err = db.NewInsert().Model(file).Exec(ctx)
+	require.NoError(t, err)
+
+	svc := NewService(db)
+	start := int64(60000)
+	require.NoError(t, svc.ReplaceChapters(ctx, file.ID, []mediafile.ParsedChapter{
+		{Title: "Chapter 1", StartTimestampMs: &start},
+	}))
+
+	h := &handler{
+		chapterService: svc,
+		bookService:    books.NewService(db),
+	}
+	e := newTestEcho(t)
+	do := func(method string, payload any, fn echo.HandlerFunc) ChaptersResponse {
+		var body []byte
+		if payload != nil {
+			body, err = json.Marshal(payload)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, "/", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(strconv.Itoa(file.ID))
+		require.NoError(t, fn(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp ChaptersResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp
+	}
+	storedStart := func() int64 {
+		chapters, err := svc.ListChapters(ctx, file.ID)
+		require.NoError(t, err)
+		require.Len(t, chapters, 1)
+		return *chapters[0].StartTimestampMs
+	}
+
+	offset := 300
+	resp := do(http.MethodPatch, PatchChaptersPayload{Action: ChapterActionOffset, OffsetMs: &offset}, h.patch)
+	require.Len(t, resp.Chapters, 1)
+	assert.Equal(t, int64(60300), *resp.Chapters[0].StartTimestampMs)
+	assert.Equal(t, int64(60000), storedStart(), "stored timestamps aren't changed")
+
+	resp = do(http.MethodGet, nil, h.list)
+	assert.Equal(t, int64(60300), *resp.Chapters[0].StartTimestampMs)
+
+	// Edits are made on the corrected timeline.
+	edited := int64(90300)
+	resp = do(http.MethodPut, ReplaceChaptersPayload{Chapters: []ChapterInput{
+		{Title: "Chapter 1", StartTimestampMs: &edited},
+	}}, h.replace)
+	assert.Equal(t, int64(90300), *resp.Chapters[0].StartTimestampMs)
+	assert.Equal(t, int64(90000), storedStart())
+
+	var after models.File
+	require.NoError(t, db.NewSelect().Model(&after).Where("f.id = ?", file.ID).Scan(ctx))
+	assert.Equal(t, 300, after.ChapterOffsetMs)
+}
@@ -5,17 +5,22 @@ import (
 	"github.com/shishobooks/shisho/pkg/appsettings"
 	"github.com/shishobooks/shisho/pkg/auth"
 	"github.com/shishobooks/shisho/pkg/books"
+	"github.com/shishobooks/shisho/pkg/cbzpages"
+	"github.com/shishobooks/shisho/pkg/config"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/uptrace/bun"
 )
 
-func RegisterRoutes(g *echo.Group, db *bun.DB, authMiddleware *auth.Middleware) {
+func RegisterRoutes(g *echo.Group, db *bun.DB, cfg *config.Config, authMiddleware *auth.Middleware) {
 	appSettingsSvc := appsettings.NewService(db)
 	h := &handler{
 		chapterService: NewService(db),
 		bookService:    books.NewService(db).WithAppSettings(appSettingsSvc),
+		pageCache:      cbzpages.NewCache(cfg.CacheDir),
 	}
 
 	g.GET("/files/:id/chapters", h.list)
 	g.PUT("/files/:id/chapters", h.replace, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+	g.PATCH("/files/:id/chapters", h.patch, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+	g.GET("/files/:id/chapters/:chapterId/thumbnail", h.thumbnail)
 }
@@ -2,9 +2,13 @@ package chapters
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
 	"github.com/shishobooks/shisho/pkg/mediafile"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/uptrace/bun"
@@ -17,12 +21,19 @@ import (
 // embedded file metadata wins — matching the convention used by
 // shouldApplySidecarScalar / shouldApplySidecarRelationship in pkg/worker.
 // All non-sidecar sources still apply unconditionally under forceRefresh.
+// Generated chapters are the exception: they only ever replace other generated
+// chapters (or none), so they can't clobber real chapters even on a refresh.
 func ShouldUpdateChapters(newChapters []mediafile.ParsedChapter, newSource string, existingSource *string, forceRefresh bool) bool {
 	// Never update with empty chapters
 	if len(newChapters) == 0 {
 		return false
 	}
 
+	if newSource == models.DataSourceGenerated {
+		return existingSource == nil || *existingSource == "" ||
+			*existingSource == models.DataSourceGenerated || *existingSource == models.DataSourceFilepath
+	}
+
 	if forceRefresh {
 		// Skip sidecar so embedded file metadata wins; everything else applies.
 		return newSource != models.DataSourceSidecar
@@ -44,6 +55,25 @@ func ShouldUpdateChapters(newChapters []mediafile.ParsedChapter, newSource strin
 	return newPriority <= existingPriority
 }
 
+// GenerateChapters returns evenly spaced chapters every interval for an
+// audiobook of the given duration, titled "Chapter 1", "Chapter 2", and so on.
+// It returns nil when either value isn't positive or the audiobook is no
+// longer than a single interval.
+func GenerateChapters(duration, interval time.Duration) []mediafile.ParsedChapter {
+	if duration <= 0 || interval <= 0 || duration <= interval {
+		return nil
+	}
+	var chapters []mediafile.ParsedChapter
+	for start := time.Duration(0); start < duration; start += interval {
+		startMs := start.Milliseconds()
+		chapters = append(chapters, mediafile.ParsedChapter{
+			Title:            fmt.Sprintf("Chapter %d", len(chapters)+1),
+			StartTimestampMs: &startMs,
+		})
+	}
+	return chapters
+}
+
 type Service struct {
 	db *bun.DB
 }
@@ -67,6 +97,82 @@ func (svc *Service) ListChapters(ctx context.Context, fileID int) ([]*models.Cha
 	return buildChapterTree(chapters), nil
 }
 
+// RetrieveChapter returns one of a file's chapters.
+func (svc *Service) RetrieveChapter(ctx context.Context, fileID, chapterID int) (*models.Chapter, error) {
+	chapter := &models.Chapter{}
+	err := svc.db.NewSelect().
+		Model(chapter).
+		Where("ch.id = ?", chapterID).
+		Where("ch.file_id = ?", fileID).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcodes.NotFound("Chapter")
+		}
+		return nil, errors.WithStack(err)
+	}
+	return chapter, nil
+}
+
+// GetChapterTree returns a file's chapters as a nested tree, with the file's
+// chapter offset applied and StartMs and EndMs resolved for timestamped
+// chapters. A chapter ends where its next sibling starts. The last chapter at
+// each level ends where its parent ends, and the last top-level chapter ends
+// at the file's duration (EndMs is left nil when the duration is unknown).
+func (svc *Service) GetChapterTree(ctx context.Context, fileID int) ([]*models.Chapter, error) {
+	file := &models.File{}
+	err := svc.db.NewSelect().
+		Model(file).
+		Column("f.id", "f.chapter_offset_ms", "f.audiobook_duration_seconds").
+		Where("f.id = ?", fileID).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcodes.NotFound("File")
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	chapters, err := svc.ListChapters(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	chapters = models.OffsetChapters(chapters, file.ChapterOffsetMs)
+
+	var durationMs *int64
+	if file.AudiobookDurationSeconds != nil {
+		ms := int64(math.Round(*file.AudiobookDurationSeconds * 1000))
+		durationMs = &ms
+	}
+	resolveChapterTimes(chapters, durationMs)
+	return chapters, nil
+}
+
+// resolveChapterTimes sets StartMs and EndMs on timestamped chapters and
+// their children. endMs is where the last of chapters ends.
+func resolveChapterTimes(chapters []*models.Chapter, endMs *int64) {
+	for i, ch := range chapters {
+		if ch.StartTimestampMs == nil {
+			continue
+		}
+		start := *ch.StartTimestampMs
+		ch.StartMs = &start
+
+		end := endMs
+		for _, next := range chapters[i+1:] {
+			if next.StartTimestampMs != nil {
+				end = next.StartTimestampMs
+				break
+			}
+		}
+		if end != nil {
+			e := max(*end, start)
+			ch.EndMs = &e
+		}
+		resolveChapterTimes(ch.Children, ch.EndMs)
+	}
+}
+
 // ReplaceChapters deletes all existing chapters for a file and inserts new ones.
 func (svc *Service) ReplaceChapters(ctx context.Context, fileID int, chapters []mediafile.ParsedChapter) error {
 	return svc.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
@@ -107,6 +213,7 @@ func insertChapters(ctx context.Context, tx bun.Tx, fileID int, parentID *int, c
 			StartPage:        ch.StartPage,
 			StartTimestampMs: ch.StartTimestampMs,
 			Href:             ch.Href,
+			CoverPageIndex:   ch.CoverPageIndex,
 		}
 
 		_, err := tx.NewInsert().Model(model).Exec(ctx)
@@ -1,7 +1,9 @@
 package chapters
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/shishobooks/shisho/pkg/mediafile"
 	"github.com/shishobooks/shisho/pkg/models"
@@ -19,6 +21,7 @@ func TestShouldUpdateChapters(t *testing.T) {
 	pluginSource := models.DataSourcePluginPrefix + "test"
 	m4bSource := models.DataSourceM4BMetadata
 	filepathSource := models.DataSourceFilepath
+	generatedSource := models.DataSourceGenerated
 
 	tests := []struct {
 		name           string
@@ -100,6 +103,30 @@ func TestShouldUpdateChapters(t *testing.T) {
 			forceRefresh:   false,
 			want:           true,
 		},
+		{
+			name:           "file metadata replaces generated chapters",
+			chapters:       chapters,
+			newSource:      m4bSource,
+			existingSource: &generatedSource,
+			forceRefresh:   false,
+			want:           true,
+		},
+		{
+			name:           "generated chapters replace generated chapters",
+			chapters:       chapters,
+			newSource:      generatedSource,
+			existingSource: &generatedSource,
+			forceRefresh:   false,
+			want:           true,
+		},
+		{
+			name:           "force refresh + generated chapters never replace real chapters",
+			chapters:       chapters,
+			newSource:      generatedSource,
+			existingSource: &m4bSource,
+			forceRefresh:   true,
+			want:           false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -110,3 +137,72 @@ func TestShouldUpdateChapters(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateChapters(t *testing.T) {
+	t.Parallel()
+
+	chapters := GenerateChapters(65*time.Minute, 30*time.Minute)
+	if assert.Len(t, chapters, 3) {
+		for i, ch := range chapters {
+			assert.Equal(t, fmt.Sprintf("Chapter %d", i+1), ch.Title)
+			if assert.NotNil(t, ch.StartTimestampMs) {
+				assert.Equal(t, int64(i)*30*60*1000, *ch.StartTimestampMs)
+			}
+		}
+	}
+
+	assert.Nil(t, GenerateChapters(20*time.Minute, 30*time.Minute))
+	assert.Nil(t, GenerateChapters(0, 30*time.Minute))
+	assert.Nil(t, GenerateChapters(time.Hour, 0))
+}
+
+func TestResolveChapterTimes(t *testing.T) {
+	t.Parallel()
+
+	ms := func(v int64) *int64 { return &v }
+
+	t.Run("nested chapters end at the next sibling or parent end", func(t *testing.T) {
+		t.Parallel()
+
+		chapters := []*models.Chapter{
+			{Title: "Part 1", StartTimestampMs: ms(0), Children: []*models.Chapter{
+				{Title: "Chapter 1", StartTimestampMs: ms(0)},
+				{Title: "Chapter 2", StartTimestampMs: ms(30_000)},
+			}},
+			{Title: "Part 2", StartTimestampMs: ms(60_000)},
+		}
+		resolveChapterTimes(chapters, ms(90_000))
+
+		assert.Equal(t, int64(0), *chapters[0].StartMs)
+		assert.Equal(t, int64(60_000), *chapters[0].EndMs)
+		assert.Equal(t, int64(30_000), *chapters[0].Children[0].EndMs)
+		assert.Equal(t, int64(30_000), *chapters[0].Children[1].StartMs)
+		assert.Equal(t, int64(60_000), *chapters[0].Children[1].EndMs)
+		assert.Equal(t, int64(60_000), *chapters[1].StartMs)
+		assert.Equal(t, int64(90_000), *chapters[1].EndMs)
+	})
+
+	t.Run("last chapter has no end without a duration", func(t *testing.T) {
+		t.Parallel()
+
+		chapters := []*models.Chapter{
+			{Title: "One", StartTimestampMs: ms(0)},
+			{Title: "Two", StartTimestampMs: ms(10_000)},
+		}
+		resolveChapterTimes(chapters, nil)
+
+		assert.Equal(t, int64(10_000), *chapters[0].EndMs)
+		assert.Nil(t, chapters[1].EndMs)
+	})
+
+	t.Run("chapters without timestamps are left alone", func(t *testing.T) {
+		t.Parallel()
+
+		page := 3
+		chapters := []*models.Chapter{{Title: "Page", StartPage: &page}}
+		resolveChapterTimes(chapters, ms(1000))
+
+		assert.Nil(t, chapters[0].StartMs)
+		assert.Nil(t, chapters[0].EndMs)
+	})
+}
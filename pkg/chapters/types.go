@@ -17,10 +17,48 @@ type ChapterInput struct {
 	StartPage        *int           `json:"start_page"`
 	StartTimestampMs *int64         `json:"start_timestamp_ms"`
 	Href             *string        `json:"href"`
+	CoverPageIndex   *int           `json:"cover_page_index"`
 	Children         []ChapterInput `json:"children"`
 }
 
-// ReplaceChaptersPayload is the request body for replacing chapters.
+// ReplaceChaptersPayload is the request body for replacing chapters. Like
+// the list response, timestamps include the file's chapter offset.
 type ReplaceChaptersPayload struct {
 	Chapters []ChapterInput `json:"chapters"`
 }
+
+// Chapter edit actions accepted by PatchChaptersPayload.
+const (
+	ChapterActionUpdate  = "update"
+	ChapterActionSplit   = "split"
+	ChapterActionMerge   = "merge"
+	ChapterActionReorder = "reorder"
+	ChapterActionOffset  = "offset"
+)
+
+// PatchChaptersPayload is the request body for a single chapter edit.
+//   - update: changes ChapterID's title, position, and/or cover page.
+//   - split: splits ChapterID at AtMs; Title names the new chapter.
+//   - merge: merges the adjacent chapters in ChapterIDs into the first.
+//   - reorder: sets the order of the sibling chapters in ChapterIDs.
+//   - offset: sets the file's chapter offset to OffsetMs.
+//
+// Timestamps are on the file's corrected timeline, i.e. with its chapter
+// offset already applied, matching what the list endpoint returns.
+type PatchChaptersPayload struct {
+	Action           string  `json:"action" validate:"required,oneof=update split merge reorder offset"`
+	ChapterID        *int    `json:"chapter_id,omitempty"`
+	Title            *string `json:"title,omitempty" validate:"omitempty,max=500"`
+	StartPage        *int    `json:"start_page,omitempty" validate:"omitempty,min=0"`
+	StartTimestampMs *int64  `json:"start_timestamp_ms,omitempty" validate:"omitempty,min=0"`
+	Href             *string `json:"href,omitempty"`
+	CoverPageIndex   *int    `json:"cover_page_index,omitempty" validate:"omitempty,min=0"`
+	AtMs             *int64  `json:"at_ms,omitempty" validate:"omitempty,min=0"`
+	ChapterIDs       []int   `json:"chapter_ids,omitempty" validate:"max=1000"`
+	OffsetMs         *int    `json:"offset_ms,omitempty" validate:"omitempty,min=-3600000,max=3600000"`
+}
+
+// ChapterThumbnailQuery is the query for a chapter's thumbnail.
+type ChapterThumbnailQuery struct {
+	Width int `query:"w" json:"w,omitempty" default:"300" validate:"min=1,max=4096"` // Scale the page down to at most this width
+}
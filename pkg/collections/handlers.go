@@ -0,0 +1,268 @@
+package collections
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/search"
+)
+
+type handler struct {
+	collectionService *Service
+	searchService     *search.Service
+}
+
+func (h *handler) retrieve(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	collection, err := h.retrieveAccessible(c)
+	if err != nil {
+		return err
+	}
+
+	bookCount, err := h.collectionService.GetBookCount(ctx, collection.ID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	response := CollectionResponse{Collection: *collection, BookCount: bookCount}
+
+	return errors.WithStack(c.JSON(http.StatusOK, response))
+}
+
+func (h *handler) list(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params := ListCollectionsQuery{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	opts := ListCollectionsOptions{
+		Limit:     &params.Limit,
+		Offset:    &params.Offset,
+		LibraryID: params.LibraryID,
+		Search:    params.Search,
+	}
+
+	if user, ok := c.Get("user").(*models.User); ok {
+		libraryIDs := user.GetAccessibleLibraryIDs()
+		if libraryIDs != nil {
+			opts.LibraryIDs = libraryIDs
+		}
+	}
+
+	collections, total, err := h.collectionService.ListCollectionsWithTotal(ctx, opts)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	result := make([]CollectionResponse, len(collections))
+	for i, col := range collections {
+		result[i] = CollectionResponse{Collection: *col, BookCount: col.BookCount}
+	}
+
+	response := ListCollectionsResponse{Items: result, Total: total}
+
+	return errors.WithStack(c.JSON(http.StatusOK, response))
+}
+
+func (h *handler) create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params := CreateCollectionPayload{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(params.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return errcodes.ValidationError("Collection name cannot be empty")
+	}
+
+	collection := &models.Collection{
+		LibraryID:   params.LibraryID,
+		Name:        name,
+		Description: params.Description,
+	}
+	if err := h.collectionService.CreateCollection(ctx, collection); err != nil {
+		return errors.WithStack(err)
+	}
+
+	log := logger.FromContext(ctx)
+	if err := h.searchService.IndexCollection(ctx, collection); err != nil {
+		log.Warn("failed to update search index for collection", logger.Data{"collection_id": collection.ID, "error": err.Error()})
+	}
+
+	response := CollectionResponse{Collection: *collection}
+
+	return errors.WithStack(c.JSON(http.StatusCreated, response))
+}
+
+func (h *handler) update(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params := UpdateCollectionPayload{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	collection, err := h.retrieveAccessible(c)
+	if err != nil {
+		return err
+	}
+
+	opts := UpdateCollectionOptions{}
+	if params.Name != nil && *params.Name != collection.Name {
+		newName := strings.TrimSpace(*params.Name)
+		if newName == "" {
+			return errcodes.ValidationError("Collection name cannot be empty")
+		}
+		collection.Name = newName
+		opts.Columns = append(opts.Columns, "name")
+	}
+	if params.Description != nil {
+		description := strings.TrimSpace(*params.Description)
+		if description == "" {
+			collection.Description = nil
+		} else {
+			collection.Description = &description
+		}
+		opts.Columns = append(opts.Columns, "description")
+	}
+
+	if err := h.collectionService.UpdateCollection(ctx, collection, opts); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if len(opts.Columns) > 0 {
+		log := logger.FromContext(ctx)
+		if err := h.searchService.IndexCollection(ctx, collection); err != nil {
+			log.Warn("failed to update search index for collection", logger.Data{"collection_id": collection.ID, "error": err.Error()})
+		}
+	}
+
+	bookCount, _ := h.collectionService.GetBookCount(ctx, collection.ID)
+	response := CollectionResponse{Collection: *collection, BookCount: bookCount}
+
+	return errors.WithStack(c.JSON(http.StatusOK, response))
+}
+
+func (h *handler) deleteCollection(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	collection, err := h.retrieveAccessible(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.collectionService.DeleteCollection(ctx, collection.ID); err != nil {
+		return errors.WithStack(err)
+	}
+
+	log := logger.FromContext(ctx)
+	if err := h.searchService.DeleteFromCollectionIndex(ctx, collection.ID); err != nil {
+		log.Warn("failed to remove collection from search index", logger.Data{"collection_id": collection.ID, "error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *handler) books(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params := SubResourceQuery{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	collection, err := h.retrieveAccessible(c)
+	if err != nil {
+		return err
+	}
+
+	books, total, err := h.collectionService.GetBooksPaginated(ctx, collection.ID, params.Limit, params.Offset, params.IncludeHidden)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	response := ListCollectionBooksResponse{Items: books, Total: total}
+
+	return errors.WithStack(c.JSON(http.StatusOK, response))
+}
+
+func (h *handler) addBooks(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params := AddBooksPayload{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	collection, err := h.retrieveAccessible(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.collectionService.AddBooks(ctx, collection, params.BookIDs); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *handler) removeBooks(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params := RemoveBooksPayload{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	collection, err := h.retrieveAccessible(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.collectionService.RemoveBooks(ctx, collection.ID, params.BookIDs); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// retrieveAccessible loads the collection from the :id path param and checks
+// that the current user can access its library.
+func (h *handler) retrieveAccessible(c echo.Context) (*models.Collection, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return nil, errcodes.NotFound("Collection")
+	}
+
+	collection, err := h.collectionService.RetrieveCollection(c.Request().Context(), RetrieveCollectionOptions{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(collection.LibraryID) {
+			return nil, errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	return collection, nil
+}
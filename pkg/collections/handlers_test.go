@@ -0,0 +1,133 @@
+package collections
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shishobooks/shisho/pkg/binder"
+	"github.com/shishobooks/shisho/pkg/migrations"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	"github.com/uptrace/bun/driver/sqliteshim"
+)
+
+// setupHandlerTestDB creates an in-memory SQLite database using a named memory
+// URI so that Bun's ScanAndCount (which opens a second connection for the COUNT
+// query) sees the same database.
+func setupHandlerTestDB(t *testing.T) *bun.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	sqldb, err := sql.Open(sqliteshim.ShimName, dsn)
+	require.NoError(t, err)
+
+	db := bun.NewDB(sqldb, sqlitedialect.New())
+
+	_, err = db.Exec("PRAGMA foreign_keys = ON")
+	require.NoError(t, err)
+
+	_, err = migrations.BringUpToDate(context.Background(), db)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	return db
+}
+
+func newTestEcho(t *testing.T) *echo.Echo {
+	t.Helper()
+	e := echo.New()
+	b, err := binder.New()
+	require.NoError(t, err)
+	e.Binder = b
+	return e
+}
+
+func newTestHandler(db *bun.DB) *handler {
+	return &handler{
+		collectionService: NewService(db),
+		searchService:     search.NewService(db),
+	}
+}
+
+func TestCreateAndList_SearchesByName(t *testing.T) {
+	t.Parallel()
+	db := setupHandlerTestDB(t)
+	lib := createTestLibrary(t, db)
+	h := newTestHandler(db)
+	e := newTestEcho(t)
+
+	for _, name := range []string{"Wheel of Time Box Set", "Favorites"} {
+		body := fmt.Sprintf(`{"library_id":%d,"name":%q}`, lib.ID, name)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		require.NoError(t, h.create(e.NewContext(req, rec)))
+		require.Equal(t, http.StatusCreated, rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/?search=whe", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.list(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp ListCollectionsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Total)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, "Wheel of Time Box Set", resp.Items[0].Name)
+}
+
+func TestAddBooksAndBooks_ReturnsCollectionBooks(t *testing.T) {
+	t.Parallel()
+	db := setupHandlerTestDB(t)
+	ctx := context.Background()
+	lib := createTestLibrary(t, db)
+	h := newTestHandler(db)
+	e := newTestEcho(t)
+
+	collection := &models.Collection{LibraryID: lib.ID, Name: "Box Set"}
+	require.NoError(t, h.collectionService.CreateCollection(ctx, collection))
+	book1 := createTestBook(t, db, lib, "Book One")
+	book2 := createTestBook(t, db, lib, "Book Two")
+	createTestBook(t, db, lib, "Not In Collection")
+
+	body := fmt.Sprintf(`{"book_ids":[%d,%d]}`, book1.ID, book2.ID)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(collection.ID))
+	require.NoError(t, h.addBooks(c))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(collection.ID))
+	require.NoError(t, h.books(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp ListCollectionBooksResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Total)
+	require.Len(t, resp.Items, 2)
+	assert.Equal(t, "Book One", resp.Items[0].Title)
+	assert.Equal(t, "Book Two", resp.Items[1].Title)
+}
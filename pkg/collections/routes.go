@@ -0,0 +1,29 @@
+package collections
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/shishobooks/shisho/pkg/auth"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/search"
+	"github.com/uptrace/bun"
+)
+
+// RegisterRoutesWithGroup registers collection routes on a pre-configured group.
+func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, authMiddleware *auth.Middleware) {
+	collectionService := NewService(db)
+	searchService := search.NewService(db)
+
+	h := &handler{
+		collectionService: collectionService,
+		searchService:     searchService,
+	}
+
+	g.GET("", h.list)
+	g.GET("/:id", h.retrieve)
+	g.GET("/:id/books", h.books)
+	g.POST("", h.create, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+	g.PATCH("/:id", h.update, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+	g.DELETE("/:id", h.deleteCollection, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+	g.POST("/:id/books", h.addBooks, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+	g.DELETE("/:id/books", h.removeBooks, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+}
@@ -0,0 +1,308 @@
+package collections
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/uptrace/bun"
+)
+
+type RetrieveCollectionOptions struct {
+	ID        *int
+	Name      *string
+	LibraryID *int
+}
+
+type ListCollectionsOptions struct {
+	Limit      *int
+	Offset     *int
+	LibraryID  *int
+	LibraryIDs []int // Filter by multiple library IDs (for access control)
+	Search     *string
+
+	includeTotal bool
+}
+
+type UpdateCollectionOptions struct {
+	Columns []string
+}
+
+type Service struct {
+	db *bun.DB
+}
+
+func NewService(db *bun.DB) *Service {
+	return &Service{db}
+}
+
+func (svc *Service) CreateCollection(ctx context.Context, collection *models.Collection) error {
+	now := time.Now()
+	if collection.CreatedAt.IsZero() {
+		collection.CreatedAt = now
+	}
+	collection.UpdatedAt = collection.CreatedAt
+
+	_, err := svc.db.
+		NewInsert().
+		Model(collection).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint") {
+			return errcodes.Conflict("A collection with this name already exists in this library.")
+		}
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (svc *Service) RetrieveCollection(ctx context.Context, opts RetrieveCollectionOptions) (*models.Collection, error) {
+	collection := &models.Collection{}
+
+	q := svc.db.
+		NewSelect().
+		Model(collection)
+
+	if opts.ID != nil {
+		q = q.Where("c.id = ?", *opts.ID)
+	}
+	if opts.Name != nil && opts.LibraryID != nil {
+		// Case-insensitive match
+		q = q.Where("LOWER(c.name) = LOWER(?) AND c.library_id = ?", *opts.Name, *opts.LibraryID)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcodes.NotFound("Collection")
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return collection, nil
+}
+
+func (svc *Service) ListCollections(ctx context.Context, opts ListCollectionsOptions) ([]*models.Collection, error) {
+	c, _, err := svc.listCollectionsWithTotal(ctx, opts)
+	return c, errors.WithStack(err)
+}
+
+func (svc *Service) ListCollectionsWithTotal(ctx context.Context, opts ListCollectionsOptions) ([]*models.Collection, int, error) {
+	opts.includeTotal = true
+	return svc.listCollectionsWithTotal(ctx, opts)
+}
+
+func (svc *Service) listCollectionsWithTotal(ctx context.Context, opts ListCollectionsOptions) ([]*models.Collection, int, error) {
+	var collections []*models.Collection
+	var total int
+	var err error
+
+	q := svc.db.
+		NewSelect().
+		Model(&collections).
+		ColumnExpr("c.*").
+		ColumnExpr("(SELECT COUNT(*) FROM book_collections bc WHERE bc.collection_id = c.id) AS book_count").
+		Order("c.name ASC")
+
+	if opts.LibraryID != nil {
+		q = q.Where("c.library_id = ?", *opts.LibraryID)
+	}
+	if len(opts.LibraryIDs) > 0 {
+		q = q.Where("c.library_id IN (?)", bun.List(opts.LibraryIDs))
+	}
+	// Search using FTS5
+	if opts.Search != nil && *opts.Search != "" {
+		ftsQuery := buildFTSPrefixQuery(*opts.Search)
+		if ftsQuery != "" {
+			q = q.Where("c.id IN (SELECT collection_id FROM collections_fts WHERE collections_fts MATCH ?)", ftsQuery)
+		}
+	}
+	if opts.Limit != nil {
+		q = q.Limit(*opts.Limit)
+	}
+	if opts.Offset != nil {
+		q = q.Offset(*opts.Offset)
+	}
+
+	if opts.includeTotal {
+		total, err = q.ScanAndCount(ctx)
+	} else {
+		err = q.Scan(ctx)
+	}
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	return collections, total, nil
+}
+
+func (svc *Service) UpdateCollection(ctx context.Context, collection *models.Collection, opts UpdateCollectionOptions) error {
+	if len(opts.Columns) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	collection.UpdatedAt = now
+	columns := append(opts.Columns, "updated_at")
+
+	_, err := svc.db.
+		NewUpdate().
+		Model(collection).
+		Column(columns...).
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errcodes.NotFound("Collection")
+		}
+		if strings.Contains(err.Error(), "UNIQUE constraint") {
+			return errcodes.Conflict("A collection with this name already exists in this library.")
+		}
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteCollection deletes a collection and all book associations. The books
+// themselves are untouched.
+func (svc *Service) DeleteCollection(ctx context.Context, collectionID int) error {
+	return svc.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		// Delete book_collections associations (cascade should handle this, but be explicit)
+		_, err := tx.NewDelete().
+			Model((*models.BookCollection)(nil)).
+			Where("collection_id = ?", collectionID).
+			Exec(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		_, err = tx.NewDelete().
+			Model((*models.Collection)(nil)).
+			Where("id = ?", collectionID).
+			Exec(ctx)
+		return errors.WithStack(err)
+	})
+}
+
+// AddBooks adds books to a collection. Books already in the collection are
+// skipped. All books must belong to the collection's library.
+func (svc *Service) AddBooks(ctx context.Context, collection *models.Collection, bookIDs []int) error {
+	bookIDs = uniqueIDs(bookIDs)
+	if len(bookIDs) == 0 {
+		return nil
+	}
+
+	return svc.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		count, err := tx.NewSelect().
+			Model((*models.Book)(nil)).
+			Where("b.id IN (?)", bun.List(bookIDs)).
+			Where("b.library_id = ?", collection.LibraryID).
+			Count(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if count != len(bookIDs) {
+			return errcodes.ValidationError("All books must exist and belong to the collection's library.")
+		}
+
+		now := time.Now()
+		bookCollections := make([]*models.BookCollection, len(bookIDs))
+		for i, bookID := range bookIDs {
+			bookCollections[i] = &models.BookCollection{
+				CreatedAt:    now,
+				BookID:       bookID,
+				CollectionID: collection.ID,
+			}
+		}
+
+		_, err = tx.NewInsert().
+			Model(&bookCollections).
+			On("CONFLICT (book_id, collection_id) DO NOTHING").
+			Exec(ctx)
+		return errors.WithStack(err)
+	})
+}
+
+// RemoveBooks removes books from a collection. Books that aren't in the
+// collection are ignored.
+func (svc *Service) RemoveBooks(ctx context.Context, collectionID int, bookIDs []int) error {
+	if len(bookIDs) == 0 {
+		return nil
+	}
+
+	_, err := svc.db.NewDelete().
+		Model((*models.BookCollection)(nil)).
+		Where("collection_id = ?", collectionID).
+		Where("book_id IN (?)", bun.List(bookIDs)).
+		Exec(ctx)
+	return errors.WithStack(err)
+}
+
+// GetBookCount returns the count of books in this collection, not counting
+// hidden books.
+func (svc *Service) GetBookCount(ctx context.Context, collectionID int) (int, error) {
+	count, err := svc.db.NewSelect().
+		Model((*models.BookCollection)(nil)).
+		Join("INNER JOIN books b ON b.id = bc.book_id").
+		Where("bc.collection_id = ?", collectionID).
+		Where("b.hidden = FALSE").
+		Count(ctx)
+	return count, errors.WithStack(err)
+}
+
+// GetBooksPaginated returns a paginated list of books in this collection.
+// Hidden books are left out unless includeHidden is set.
+func (svc *Service) GetBooksPaginated(ctx context.Context, collectionID, limit, offset int, includeHidden bool) ([]*models.Book, int, error) {
+	var books []*models.Book
+
+	q := svc.db.NewSelect().
+		Model(&books).
+		Join("INNER JOIN book_collections bc ON bc.book_id = b.id").
+		Where("bc.collection_id = ?", collectionID)
+	if !includeHidden {
+		q = q.Where("b.hidden = FALSE")
+	}
+	total, err := q.
+		Order("b.sort_title ASC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	return books, total, nil
+}
+
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// buildFTSPrefixQuery builds an FTS5 query for prefix/typeahead search.
+func buildFTSPrefixQuery(input string) string {
+	const maxQueryLength = 100
+
+	input = strings.TrimSpace(input)
+	if len(input) > maxQueryLength {
+		input = input[:maxQueryLength]
+	}
+	if input == "" {
+		return ""
+	}
+
+	input = strings.ReplaceAll(input, `"`, `""`)
+	return `"` + input + `"*`
+}
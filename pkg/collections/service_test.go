@@ -0,0 +1,183 @@
+package collections
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/migrations"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	"github.com/uptrace/bun/driver/sqliteshim"
+)
+
+func setupTestDB(t *testing.T) *bun.DB {
+	t.Helper()
+
+	sqldb, err := sql.Open(sqliteshim.ShimName, ":memory:")
+	require.NoError(t, err)
+
+	db := bun.NewDB(sqldb, sqlitedialect.New())
+
+	_, err = db.Exec("PRAGMA foreign_keys = ON")
+	require.NoError(t, err)
+
+	_, err = migrations.BringUpToDate(context.Background(), db)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	return db
+}
+
+func createTestLibrary(t *testing.T, db *bun.DB) *models.Library {
+	t.Helper()
+	lib := &models.Library{
+		Name:                     "Test Library",
+		CoverAspectRatio:         "book",
+		DownloadFormatPreference: models.DownloadFormatOriginal,
+	}
+	_, err := db.NewInsert().Model(lib).Exec(context.Background())
+	require.NoError(t, err)
+	return lib
+}
+
+func createTestBook(t *testing.T, db *bun.DB, lib *models.Library, title string) *models.Book {
+	t.Helper()
+	book := &models.Book{
+		LibraryID:       lib.ID,
+		Title:           title,
+		TitleSource:     models.DataSourceFilepath,
+		SortTitle:       title,
+		SortTitleSource: models.DataSourceFilepath,
+		AuthorSource:    models.DataSourceFilepath,
+		Filepath:        t.TempDir(),
+	}
+	_, err := db.NewInsert().Model(book).Exec(context.Background())
+	require.NoError(t, err)
+	return book
+}
+
+func TestCreateCollection_DuplicateNameConflicts(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+	lib := createTestLibrary(t, db)
+
+	require.NoError(t, svc.CreateCollection(ctx, &models.Collection{LibraryID: lib.ID, Name: "Discworld Box Set"}))
+
+	err := svc.CreateCollection(ctx, &models.Collection{LibraryID: lib.ID, Name: "discworld box set"})
+	var codeErr *errcodes.Error
+	require.ErrorAs(t, err, &codeErr)
+	assert.Equal(t, "conflict", codeErr.Code)
+}
+
+func TestAddBooks_SkipsExistingAndRemoveBooks(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+	lib := createTestLibrary(t, db)
+	book1 := createTestBook(t, db, lib, "Book One")
+	book2 := createTestBook(t, db, lib, "Book Two")
+
+	collection := &models.Collection{LibraryID: lib.ID, Name: "Box Set"}
+	require.NoError(t, svc.CreateCollection(ctx, collection))
+
+	require.NoError(t, svc.AddBooks(ctx, collection, []int{book1.ID}))
+	require.NoError(t, svc.AddBooks(ctx, collection, []int{book1.ID, book2.ID, book2.ID}))
+
+	count, err := svc.GetBookCount(ctx, collection.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	require.NoError(t, svc.RemoveBooks(ctx, collection.ID, []int{book1.ID}))
+
+	count, err = svc.GetBookCount(ctx, collection.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestGetBooksPaginated_ExcludesHiddenBooks(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+	lib := createTestLibrary(t, db)
+	visible := createTestBook(t, db, lib, "Book One")
+	hidden := createTestBook(t, db, lib, "Book Two")
+	hidden.Hidden = true
+	_, err := db.NewUpdate().Model(hidden).Column("hidden").WherePK().Exec(ctx)
+	require.NoError(t, err)
+
+	collection := &models.Collection{LibraryID: lib.ID, Name: "Box Set"}
+	require.NoError(t, svc.CreateCollection(ctx, collection))
+	require.NoError(t, svc.AddBooks(ctx, collection, []int{visible.ID, hidden.ID}))
+
+	books, total, err := svc.GetBooksPaginated(ctx, collection.ID, 10, 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, books, 1)
+	assert.Equal(t, visible.ID, books[0].ID)
+
+	count, err := svc.GetBookCount(ctx, collection.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	books, total, err = svc.GetBooksPaginated(ctx, collection.ID, 10, 0, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, books, 2)
+}
+
+func TestAddBooks_RejectsBooksFromOtherLibraries(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+	lib := createTestLibrary(t, db)
+	otherLib := createTestLibrary(t, db)
+	book := createTestBook(t, db, lib, "Book One")
+	otherBook := createTestBook(t, db, otherLib, "Other Book")
+
+	collection := &models.Collection{LibraryID: lib.ID, Name: "Box Set"}
+	require.NoError(t, svc.CreateCollection(ctx, collection))
+
+	err := svc.AddBooks(ctx, collection, []int{book.ID, otherBook.ID})
+	var codeErr *errcodes.Error
+	require.ErrorAs(t, err, &codeErr)
+	assert.Equal(t, "validation_error", codeErr.Code)
+
+	count, err := svc.GetBookCount(ctx, collection.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestDeleteCollection_KeepsBooks(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+	lib := createTestLibrary(t, db)
+	book := createTestBook(t, db, lib, "Book One")
+
+	collection := &models.Collection{LibraryID: lib.ID, Name: "Box Set"}
+	require.NoError(t, svc.CreateCollection(ctx, collection))
+	require.NoError(t, svc.AddBooks(ctx, collection, []int{book.ID}))
+
+	require.NoError(t, svc.DeleteCollection(ctx, collection.ID))
+
+	_, err := svc.RetrieveCollection(ctx, RetrieveCollectionOptions{ID: &collection.ID})
+	require.Error(t, err)
+
+	exists, err := db.NewSelect().Model((*models.Book)(nil)).Where("id = ?", book.ID).Exists(ctx)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
@@ -0,0 +1,55 @@
+package collections
+
+import "github.com/shishobooks/shisho/pkg/models"
+
+// CollectionResponse is the single-collection API response. It embeds the
+// Collection model by value (so tygo emits `extends Collection`) and adds the
+// number of books in the collection.
+type CollectionResponse struct {
+	models.Collection `tstype:",extends"`
+	BookCount         int `json:"book_count"`
+}
+
+// ListCollectionsResponse is the list-endpoint envelope.
+type ListCollectionsResponse struct {
+	Items []CollectionResponse `json:"items"`
+	Total int                  `json:"total"`
+}
+
+// ListCollectionBooksResponse is the envelope for the collection books sub-resource.
+type ListCollectionBooksResponse struct {
+	Items []*models.Book `json:"items" tstype:"Book[]"`
+	Total int            `json:"total"`
+}
+
+type ListCollectionsQuery struct {
+	Limit     int     `query:"limit" json:"limit,omitempty" default:"24" validate:"min=1,max=50"`
+	Offset    int     `query:"offset" json:"offset,omitempty" validate:"min=0"`
+	LibraryID *int    `query:"library_id" json:"library_id,omitempty" validate:"omitempty,min=1" tstype:"number"`
+	Search    *string `query:"search" json:"search,omitempty" validate:"omitempty,max=100" tstype:"string"`
+}
+
+type SubResourceQuery struct {
+	Limit         int  `query:"limit" json:"limit,omitempty" default:"24" validate:"min=1,max=50"`
+	Offset        int  `query:"offset" json:"offset,omitempty" validate:"min=0"`
+	IncludeHidden bool `query:"include_hidden" json:"include_hidden,omitempty"` // Include hidden books
+}
+
+type CreateCollectionPayload struct {
+	LibraryID   int     `json:"library_id" validate:"required,min=1"`
+	Name        string  `json:"name" validate:"required,min=1,max=300"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=5000"`
+}
+
+type UpdateCollectionPayload struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=1,max=300"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=5000"`
+}
+
+type AddBooksPayload struct {
+	BookIDs []int `json:"book_ids" validate:"required,min=1,max=500,dive,min=1"`
+}
+
+type RemoveBooksPayload struct {
+	BookIDs []int `json:"book_ids" validate:"required,min=1,max=500,dive,min=1"`
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/v2"
 	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/models"
 )
 
 // Config holds all application configuration.
@@ -51,6 +52,12 @@ type Config struct {
 	// Plugin settings
 	PluginDir     string `koanf:"plugin_dir" json:"plugin_dir"`
 	PluginDataDir string `koanf:"plugin_data_dir" json:"plugin_data_dir"`
+	// PluginExecTimeout bounds a single file parser or metadata enricher
+	// call; a plugin that runs longer is skipped for that file.
+	PluginExecTimeout time.Duration `koanf:"plugin_exec_timeout" json:"plugin_exec_timeout" validate:"min=1s"`
+	// PluginMaxOutputMB caps the size of the metadata a file parser or
+	// enricher call can return, cover images included.
+	PluginMaxOutputMB int `koanf:"plugin_max_output_mb" json:"plugin_max_output_mb" validate:"min=1"`
 
 	// Enrichment settings
 	EnrichmentConfidenceThreshold float64 `koanf:"enrichment_confidence_threshold" json:"enrichment_confidence_threshold"`
@@ -62,6 +69,91 @@ type Config struct {
 	// Supplement discovery settings
 	SupplementExcludePatterns []string `koanf:"supplement_exclude_patterns" json:"supplement_exclude_patterns"`
 	PDFSupplementFilenames    []string `koanf:"pdf_supplement_filenames" json:"pdf_supplement_filenames"`
+	// SupplementKinds maps a file extension (e.g. "mp3", "jpg") to the kind
+	// given to supplements of that type: "document", "audio" or "image".
+	// Unlisted extensions are documents.
+	SupplementKinds map[string]string `koanf:"supplement_kinds" json:"supplement_kinds" validate:"dive,oneof=document audio image"`
+
+	// Scan settings
+	// MinFileSizeBytes maps a file type (e.g. "epub", "cbz") to the smallest
+	// size a new file of that type must have to be imported. Missing or zero
+	// entries disable the check for that type.
+	MinFileSizeBytes map[string]int64 `koanf:"min_file_size_bytes" json:"min_file_size_bytes"`
+	// NarratorAtomFallback lists the M4B atoms ("composer", "writer") checked,
+	// in order, for narrators when the dedicated narrator atom is empty. Empty
+	// by default, so narrators only come from the narrator atom.
+	NarratorAtomFallback []string `koanf:"narrator_atom_fallback" json:"narrator_atom_fallback" validate:"dive,oneof=composer writer"`
+	// AuthorMergeStrategy controls what happens to [Author] names from the
+	// filepath when the file's metadata has its own authors: "replace" drops
+	// them, while "append" adds them after the metadata authors.
+	AuthorMergeStrategy string `koanf:"author_merge_strategy" json:"author_merge_strategy" validate:"omitempty,oneof=replace append"`
+	// AutoChapterIntervalMin is the spacing, in minutes, of the chapters
+	// generated for audiobooks that have none. 0 disables generation.
+	AutoChapterIntervalMin int `koanf:"auto_chapter_interval_min" json:"auto_chapter_interval_min" validate:"min=0"`
+	// MissingFileGraceScans is how many scans a file can be missing from disk
+	// before its record is deleted, so a briefly unmounted share doesn't wipe
+	// the library. 0 deletes missing files on the first scan.
+	MissingFileGraceScans int `koanf:"missing_file_grace_scans" json:"missing_file_grace_scans" validate:"min=0"`
+	// DedupeIdenticalFiles links a new file to the existing book of an
+	// identical (same sha256) main file in the same library instead of
+	// creating a second book. Books are never shared across libraries.
+	DedupeIdenticalFiles bool `koanf:"dedupe_identical_files" json:"dedupe_identical_files"`
+	// PersonNameLocale is the BCP 47 locale names are assumed to be written
+	// in when generating people's sort names. Languages that put the family
+	// name first (e.g. "ja") sort on the first word instead of the last.
+	PersonNameLocale string `koanf:"person_name_locale" json:"person_name_locale"`
+	// RepairExtensions renames new files whose content doesn't match their
+	// extension (e.g. a CBZ named .epub) to the extension of their real type
+	// and scans them as that type. Only applies to libraries that organize
+	// their file structure, since the file is renamed on disk.
+	RepairExtensions bool `koanf:"repair_extensions" json:"repair_extensions"`
+	// LockedFields lists metadata fields that scans never change, whatever
+	// the priority of the incoming source, even on a forced refresh or reset.
+	// Manual edits to a locked field still apply.
+	LockedFields []string `koanf:"locked_fields" json:"locked_fields" validate:"dive,oneof=title subtitle description authors series genres tags name url release_date language abridged publisher narrators identifiers"`
+	// ScanDedupWindow is how long the result of a single book or file scan
+	// (e.g. a resync) is reused for identical requests after it finishes.
+	// Identical requests made while it's still running always wait for it
+	// instead of starting a second scan. 0 only coalesces those.
+	ScanDedupWindow time.Duration `koanf:"scan_dedup_window" json:"scan_dedup_window" validate:"min=0s"`
+	// KeepOriginalCover keeps a file's embedded cover as
+	// <file>.cover.original.<ext> when a manual or plugin cover replaces it,
+	// so the embedded cover can be restored later.
+	KeepOriginalCover bool `koanf:"keep_original_cover" json:"keep_original_cover"`
+	// UpgradeEmbeddedCovers replaces a file's stored cover during a resync
+	// when the file's embedded cover is strictly larger. Manual covers are
+	// never replaced.
+	UpgradeEmbeddedCovers bool `koanf:"upgrade_embedded_covers" json:"upgrade_embedded_covers"`
+	// MetadataSnapshotLimit is how many snapshots of a book's metadata are
+	// kept from before book and file resyncs, so a resync can be reverted.
+	// Older snapshots are deleted. 0 disables snapshots.
+	MetadataSnapshotLimit int `koanf:"metadata_snapshot_limit" json:"metadata_snapshot_limit" validate:"min=0"`
+
+	// Organize settings
+	// OrganizeFilenameMode picks the rules used to sanitize organized file and
+	// folder names: "lenient" only strips characters filesystems reject, while
+	// "strict" also drops emoji and avoids reserved Windows names.
+	OrganizeFilenameMode string `koanf:"organize_filename_mode" json:"organize_filename_mode" validate:"omitempty,oneof=lenient strict"`
+
+	// Search settings
+	// SearchHighlightStart and SearchHighlightEnd wrap the matched terms in
+	// the snippets returned with book search results. They're inserted after
+	// the snippet text is HTML-escaped.
+	SearchHighlightStart string `koanf:"search_highlight_start" json:"search_highlight_start"`
+	SearchHighlightEnd   string `koanf:"search_highlight_end" json:"search_highlight_end"`
+	// IndexFullText indexes the body text of EPUB files during scans so books
+	// can be found by a quote. Off by default since the index is large.
+	IndexFullText bool `koanf:"index_full_text" json:"index_full_text"`
+
+	// Webhook settings
+	// Webhooks are the endpoints notified when books are created, updated, or
+	// deleted and when scans finish.
+	Webhooks []Webhook `koanf:"webhooks" json:"webhooks" validate:"dive"`
+
+	// Metrics settings
+	// MetricsEnabled serves scan and job metrics in the Prometheus text
+	// format at GET /metrics, which doesn't require authentication.
+	MetricsEnabled bool `koanf:"metrics_enabled" json:"metrics_enabled"`
 
 	// Authentication settings
 	JWTSecret           string `koanf:"jwt_secret" json:"-" validate:"required"` // Never expose in JSON
@@ -80,6 +172,15 @@ type Config struct {
 	DevLibraryPath string `koanf:"-" json:"dev_library_path,omitempty"`
 }
 
+// Webhook is an outbound webhook endpoint.
+type Webhook struct {
+	URL string `koanf:"url" json:"url" validate:"url"`
+	// Events limits the webhook to these events; empty sends every event.
+	Events []string `koanf:"events" json:"events" validate:"dive,oneof=book.created book.updated book.deleted scan.completed"`
+	// Secret signs each delivery with an HMAC-SHA256 of its body.
+	Secret string `koanf:"secret" json:"-"` // Never expose in JSON
+}
+
 // IsTestMode returns true if the server is running in test mode.
 func (c *Config) IsTestMode() bool {
 	return c.Environment == "test"
@@ -102,6 +203,8 @@ func defaults() *Config {
 		CacheDir:                      "/config/cache",
 		PluginDir:                     "/config/plugins/installed",
 		PluginDataDir:                 "/config/plugins/data",
+		PluginExecTimeout:             time.Minute,
+		PluginMaxOutputMB:             50,
 		EnrichmentConfidenceThreshold: 0.85,
 		DownloadCacheMaxSizeGB:        5,
 		PDFRenderDPI:                  200,
@@ -109,6 +212,13 @@ func defaults() *Config {
 		LibraryMonitorEnabled:         true,
 		LibraryMonitorDelaySeconds:    60,
 		SupplementExcludePatterns:     []string{".*", ".DS_Store", "Thumbs.db", "desktop.ini"},
+		NarratorAtomFallback:          []string{},
+		AuthorMergeStrategy:           "replace",
+		ScanDedupWindow:               0,
+		MetadataSnapshotLimit:         5,
+		OrganizeFilenameMode:          "lenient",
+		SearchHighlightStart:          "<mark>",
+		SearchHighlightEnd:            "</mark>",
 		PDFSupplementFilenames: []string{
 			"supplement", "supplemental", "bonus", "bonus material", "bonus content",
 			"companion", "notes", "liner notes", "errata", "booklet", "digital booklet",
@@ -204,11 +314,31 @@ func (c *Config) SessionDuration() time.Duration {
 	return time.Duration(c.SessionDurationDays) * 24 * time.Hour
 }
 
+// MinFileSize returns the minimum size in bytes for new files of the given
+// type, or 0 if no minimum is configured.
+func (c *Config) MinFileSize(fileType string) int64 {
+	return c.MinFileSizeBytes[strings.ToLower(fileType)]
+}
+
+// SupplementKind returns the kind given to supplements with the given
+// extension, defaulting to models.SupplementKindDocument.
+func (c *Config) SupplementKind(ext string) string {
+	if kind, ok := c.SupplementKinds[strings.ToLower(strings.TrimPrefix(ext, "."))]; ok {
+		return kind
+	}
+	return models.SupplementKindDocument
+}
+
 // DownloadCacheMaxSizeBytes returns the maximum cache size in bytes.
 func (c *Config) DownloadCacheMaxSizeBytes() int64 {
 	return int64(c.DownloadCacheMaxSizeGB) * 1024 * 1024 * 1024
 }
 
+// PluginMaxOutputBytes returns the maximum plugin output size in bytes.
+func (c *Config) PluginMaxOutputBytes() int64 {
+	return int64(c.PluginMaxOutputMB) * 1024 * 1024
+}
+
 // validateConfig validates the config and returns user-friendly error messages.
 func validateConfig(cfg *Config) error {
 	validate := validator.New()
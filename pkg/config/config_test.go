@@ -96,6 +96,8 @@ func TestNew_Defaults(t *testing.T) {
 	assert.Equal(t, 2, cfg.WorkerProcesses)
 	assert.True(t, cfg.LibraryMonitorEnabled)
 	assert.Equal(t, 60, cfg.LibraryMonitorDelaySeconds)
+	assert.False(t, cfg.MetricsEnabled)
+	assert.Equal(t, 5, cfg.MetadataSnapshotLimit)
 	assert.Equal(t, 200, cfg.PDFRenderDPI)
 	assert.Equal(t, 85, cfg.PDFRenderQuality)
 }
@@ -206,3 +208,195 @@ func TestToSnakeCase(t *testing.T) {
 	assert.Equal(t, "sync_interval_minutes", toSnakeCase("SyncIntervalMinutes"))
 	assert.Equal(t, "jwt_secret", toSnakeCase("JWTSecret"))
 }
+
+func TestNew_MinFileSizeBytesFromConfigFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+database_file_path: /data/shisho.db
+jwt_secret: test-secret-from-file
+min_file_size_bytes:
+  epub: 4096
+  cbz: 10240
+`
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	t.Setenv("CONFIG_FILE", configPath)
+
+	cfg, err := New()
+	require.NoError(t, err)
+	assert.Equal(t, int64(4096), cfg.MinFileSize("epub"))
+	assert.Equal(t, int64(4096), cfg.MinFileSize("EPUB"))
+	assert.Equal(t, int64(10240), cfg.MinFileSize("cbz"))
+	assert.Equal(t, int64(0), cfg.MinFileSize("m4b"))
+}
+
+func TestMinFileSize_DefaultsToDisabled(t *testing.T) {
+	cfg := NewForTest()
+	assert.Equal(t, int64(0), cfg.MinFileSize("epub"))
+}
+
+func TestSupplementKind(t *testing.T) {
+	cfg := NewForTest()
+	cfg.SupplementKinds = map[string]string{"mp3": "audio", "png": "image"}
+	assert.Equal(t, "audio", cfg.SupplementKind("mp3"))
+	assert.Equal(t, "image", cfg.SupplementKind(".PNG"))
+	assert.Equal(t, "document", cfg.SupplementKind("pdf"))
+}
+
+func TestNew_NarratorAtomFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "defaults to no fallback", yaml: "", want: []string{}},
+		{name: "composer then writer", yaml: "narrator_atom_fallback: [composer, writer]\n", want: []string{"composer", "writer"}},
+		{name: "custom order", yaml: "narrator_atom_fallback: [writer]\n", want: []string{"writer"}},
+		{name: "unknown atom is rejected", yaml: "narrator_atom_fallback: [album]\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			content := "database_file_path: /data/shisho.db\njwt_secret: test-secret\n" + tt.yaml
+			require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+			t.Setenv("CONFIG_FILE", configPath)
+
+			cfg, err := New()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "NarratorAtomFallback")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.NarratorAtomFallback)
+		})
+	}
+}
+
+func TestNew_LockedFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "defaults to nothing locked", yaml: "", want: nil},
+		{name: "known fields", yaml: "locked_fields: [description, genres]\n", want: []string{"description", "genres"}},
+		{name: "unknown field is rejected", yaml: "locked_fields: [cover]\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			content := "database_file_path: /data/shisho.db\njwt_secret: test-secret\n" + tt.yaml
+			require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+			t.Setenv("CONFIG_FILE", configPath)
+
+			cfg, err := New()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "LockedFields")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.LockedFields)
+		})
+	}
+}
+
+func TestNew_OrganizeFilenameMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    string
+		wantErr bool
+	}{
+		{name: "defaults to lenient", yaml: "", want: "lenient"},
+		{name: "strict", yaml: "organize_filename_mode: strict\n", want: "strict"},
+		{name: "unknown mode is rejected", yaml: "organize_filename_mode: paranoid\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			content := "database_file_path: /data/shisho.db\njwt_secret: test-secret\n" + tt.yaml
+			require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+			t.Setenv("CONFIG_FILE", configPath)
+
+			cfg, err := New()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "OrganizeFilenameMode")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.OrganizeFilenameMode)
+		})
+	}
+}
+
+func TestNew_AuthorMergeStrategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    string
+		wantErr bool
+	}{
+		{name: "defaults to replace", yaml: "", want: "replace"},
+		{name: "append", yaml: "author_merge_strategy: append\n", want: "append"},
+		{name: "unknown strategy is rejected", yaml: "author_merge_strategy: merge\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			content := "database_file_path: /data/shisho.db\njwt_secret: test-secret\n" + tt.yaml
+			require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+			t.Setenv("CONFIG_FILE", configPath)
+
+			cfg, err := New()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "AuthorMergeStrategy")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.AuthorMergeStrategy)
+		})
+	}
+}
+
+func TestNew_Webhooks(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    []Webhook
+		wantErr bool
+	}{
+		{name: "defaults to none", yaml: "", want: nil},
+		{
+			name: "url, events, and secret",
+			yaml: "webhooks:\n  - url: https://example.com/hook\n    events: [book.created, scan.completed]\n    secret: s3cret\n",
+			want: []Webhook{{URL: "https://example.com/hook", Events: []string{"book.created", "scan.completed"}, Secret: "s3cret"}},
+		},
+		{name: "missing url is rejected", yaml: "webhooks:\n  - secret: s3cret\n", wantErr: true},
+		{name: "unknown event is rejected", yaml: "webhooks:\n  - url: https://example.com/hook\n    events: [book.read]\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			content := "database_file_path: /data/shisho.db\njwt_secret: test-secret\n" + tt.yaml
+			require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+			t.Setenv("CONFIG_FILE", configPath)
+
+			cfg, err := New()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.Webhooks)
+		})
+	}
+}
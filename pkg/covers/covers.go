@@ -3,10 +3,15 @@
 package covers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -36,7 +41,7 @@ func SelectFile(files []*models.File, coverAspectRatio string) *models.File {
 			continue
 		}
 		switch f.FileType {
-		case models.FileTypeEPUB, models.FileTypeCBZ, models.FileTypePDF:
+		case models.FileTypeEPUB, models.FileTypeCBZ, models.FileTypePDF, models.FileTypeMOBI, models.FileTypeAZW3:
 			bookFiles = append(bookFiles, f)
 		case models.FileTypeM4B:
 			audiobookFiles = append(audiobookFiles, f)
@@ -97,14 +102,15 @@ func CacheKey(files []*models.File, coverAspectRatio string) string {
 // CacheControlImmutable (the frontend uses ?v=cover_cache_key to bust cache);
 // external callers (OPDS, eReader, Kobo) should pass CacheControlNoCache.
 //
-// Conditional GET uses an ETag of `"<file_id>-<mtime_unix>"` and intentionally
-// omits Last-Modified. SelectFile's choice depends on the library's
-// CoverAspectRatio and which files belong to the book, so the served file's
-// identity can change without any change to the new cover's mtime — flipping
-// CoverAspectRatio on a hybrid book (EPUB + M4B) swaps which file's cover is
-// served, and the new cover may have an older mtime than the previously-served
-// one. Mtime-only revalidation would return stale 304s in that case; baking
-// the file ID into the validator ensures it bumps whenever selection changes.
+// Conditional GET uses an ETag of `"<file_id>-<content_hash>"` (see ETag) and
+// intentionally omits Last-Modified. SelectFile's choice depends on the
+// library's CoverAspectRatio and which files belong to the book, so the served
+// file's identity can change without any change to the new cover's mtime —
+// flipping CoverAspectRatio on a hybrid book (EPUB + M4B) swaps which file's
+// cover is served, and the new cover may have an older mtime than the
+// previously-served one. Mtime-only revalidation would return stale 304s in
+// that case; baking the file ID into the validator ensures it bumps whenever
+// selection changes.
 func ServeBookCover(c echo.Context, files []*models.File, coverAspectRatio string, cacheControl string) error {
 	coverFile := SelectFile(files, coverAspectRatio)
 	if coverFile == nil || coverFile.CoverImageFilename == nil || *coverFile.CoverImageFilename == "" {
@@ -122,18 +128,26 @@ func ServeBookCover(c echo.Context, files []*models.File, coverAspectRatio strin
 		}
 		return errors.WithStack(err)
 	}
-	modTime := stat.ModTime().UTC().Truncate(time.Second)
 
-	etag := fmt.Sprintf(`"%d-%d"`, coverFile.ID, modTime.Unix())
+	// A zero lastModified keeps Last-Modified and IMS handling off; see above.
+	return ServeFile(c, coverFile.ID, coverPath, stat, cacheControl, time.Time{})
+}
+
+// ServeFile serves the cover image at coverPath, which belongs to the file
+// with fileID and was just stat'ed as info. It sets Cache-Control and the ETag
+// and answers If-None-Match with a 304. When lastModified isn't zero it's
+// sent as Last-Modified and If-Modified-Since is honored too; only callers
+// that always serve the same file's cover should pass it, for the reasons
+// given on ServeBookCover.
+func ServeFile(c echo.Context, fileID int, coverPath string, info os.FileInfo, cacheControl string, lastModified time.Time) error {
+	etag, err := ETag(fileID, coverPath, info)
+	if err != nil {
+		return err
+	}
 
 	c.Response().Header().Set("Cache-Control", cacheControl)
 	c.Response().Header().Set("ETag", etag)
 
-	if inm := c.Request().Header.Get("If-None-Match"); inm != "" && inm == etag {
-		c.Response().WriteHeader(http.StatusNotModified)
-		return nil
-	}
-
 	fh, err := os.Open(coverPath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -143,7 +157,87 @@ func ServeBookCover(c echo.Context, files []*models.File, coverAspectRatio strin
 	}
 	defer fh.Close()
 
-	// Zero modtime suppresses Last-Modified and IMS handling inside ServeContent.
-	http.ServeContent(c.Response(), c.Request(), filepath.Base(coverPath), time.Time{}, fh)
+	// ServeContent evaluates If-None-Match against the ETag header set above
+	// and, when it's present, ignores If-Modified-Since.
+	http.ServeContent(c.Response(), c.Request(), filepath.Base(coverPath), lastModified, fh)
 	return nil
 }
+
+// NotModified reports whether r's conditional headers show the client already
+// has the representation with etag, last modified at lastModified. As with
+// http.ServeContent, If-None-Match takes precedence and If-Modified-Since is
+// only checked when it's absent. A zero lastModified ignores
+// If-Modified-Since. Handlers that build the response themselves, like
+// resized covers, use it to answer 304 before doing the work.
+func NotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ims); err == nil && !lastModified.Truncate(time.Second).After(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// ETag returns the validator for the cover image at coverPath, which belongs
+// to the file with fileID: `"<file_id>-<content_hash>"`. Hashing the content
+// means a cover that's rewritten with the same bytes, like one re-extracted
+// by a rescan, still revalidates, and a changed cover is caught even if its
+// mtime went backwards. The file ID keeps the validator tied to the selected
+// file, so switching between files with identical covers is still noticed.
+func ETag(fileID int, coverPath string, info os.FileInfo) (string, error) {
+	hash, err := contentHash(fileID, coverPath, info)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`"%d-%s"`, fileID, hash), nil
+}
+
+// hashEntry is a cover content hash along with the path, size, and
+// modification time the cover had when it was hashed.
+type hashEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+	hash    string
+}
+
+// hashCache remembers cover content hashes by file ID so revalidating a grid
+// of unchanged covers doesn't re-read each one. Keying by file ID keeps it to
+// one entry per file, however often its cover moves or is replaced. An entry
+// is only reused while the cover's path, size, and modification time still
+// match.
+var hashCache sync.Map
+
+// contentHash returns a hex SHA-256 prefix of the cover at path, which belongs
+// to the file with fileID and was stat'ed as info.
+func contentHash(fileID int, path string, info os.FileInfo) (string, error) {
+	if cached, ok := hashCache.Load(fileID); ok {
+		entry := cached.(hashEntry)
+		if entry.path == path && entry.size == info.Size() && entry.modTime.Equal(info.ModTime()) {
+			return entry.hash, nil
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", errors.WithStack(err)
+	}
+	hash := hex.EncodeToString(h.Sum(nil)[:16])
+	hashCache.Store(fileID, hashEntry{path: path, size: info.Size(), modTime: info.ModTime(), hash: hash})
+	return hash, nil
+}
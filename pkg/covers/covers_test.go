@@ -249,7 +249,7 @@ func TestServeBookCover_ServesCoverFile(t *testing.T) {
 	assert.Equal(t, coverBytes, rec.Body.Bytes())
 }
 
-func TestServeBookCover_ETagIncludesFileIDAndMtime(t *testing.T) {
+func TestServeBookCover_ETagIncludesFileIDAndContentHash(t *testing.T) {
 	t.Parallel()
 
 	dir := t.TempDir()
@@ -259,21 +259,31 @@ func TestServeBookCover_ETagIncludesFileIDAndMtime(t *testing.T) {
 	coverPath := filepath.Join(dir, coverName)
 	require.NoError(t, os.WriteFile(coverPath, []byte("jpeg"), 0o644))
 
-	// Pin the mtime so the assertion is stable.
-	pinned := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
-	require.NoError(t, os.Chtimes(coverPath, pinned, pinned))
-
 	e := echo.New()
-	req := httptest.NewRequest(http.MethodGet, "/", nil)
-	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-
 	files := []*models.File{
 		{ID: 42, FileType: models.FileTypeEPUB, Filepath: bookPath, CoverImageFilename: &coverName},
 	}
+	serve := func() string {
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+		require.NoError(t, ServeBookCover(c, files, "book", CacheControlNoCache))
+		return rec.Header().Get("ETag")
+	}
 
-	require.NoError(t, ServeBookCover(c, files, "book", CacheControlNoCache))
-	assert.Equal(t, fmt.Sprintf(`"%d-%d"`, 42, pinned.Unix()), rec.Header().Get("ETag"))
+	// sha256("jpeg"), truncated to 16 bytes.
+	etag := serve()
+	assert.Equal(t, `"42-41e5787e9f28562d07b891b1816b4923"`, etag)
+
+	// The same bytes with a new mtime keep the ETag.
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(coverPath, later, later))
+	assert.Equal(t, etag, serve())
+
+	// New content changes it, even with an older mtime.
+	earlier := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, os.WriteFile(coverPath, []byte("other jpeg"), 0o644))
+	require.NoError(t, os.Chtimes(coverPath, earlier, earlier))
+	assert.NotEqual(t, etag, serve())
 }
 
 func TestServeBookCover_Returns304WhenIfNoneMatchMatches(t *testing.T) {
@@ -474,3 +484,79 @@ func TestServeBookCover_NotFoundWhenCoverFileMissingOnDisk(t *testing.T) {
 	require.ErrorAs(t, err, &ecErr)
 	assert.Equal(t, http.StatusNotFound, ecErr.HTTPCode)
 }
+
+func TestServeFile_HonorsIfModifiedSinceWithLastModified(t *testing.T) {
+	t.Parallel()
+
+	coverPath := filepath.Join(t.TempDir(), "book.epub.cover.jpg")
+	require.NoError(t, os.WriteFile(coverPath, []byte("\xff\xd8\xff\xe0jpeg-bytes"), 0o644))
+	pinned := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(coverPath, pinned, pinned))
+	info, err := os.Stat(coverPath)
+	require.NoError(t, err)
+
+	e := echo.New()
+	rec1 := httptest.NewRecorder()
+	c1 := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec1)
+	require.NoError(t, ServeFile(c1, 7, coverPath, info, CacheControlImmutable, info.ModTime()))
+	assert.Equal(t, http.StatusOK, rec1.Code)
+	assert.Equal(t, pinned.Format(http.TimeFormat), rec1.Header().Get("Last-Modified"))
+	assert.True(t, strings.HasPrefix(rec1.Header().Get("ETag"), `"7-`))
+
+	req2 := httptest.NewRequest(http.MethodGet, "/", nil)
+	req2.Header.Set("If-Modified-Since", pinned.Format(http.TimeFormat))
+	rec2 := httptest.NewRecorder()
+	c2 := e.NewContext(req2, rec2)
+	require.NoError(t, ServeFile(c2, 7, coverPath, info, CacheControlImmutable, info.ModTime()))
+	assert.Equal(t, http.StatusNotModified, rec2.Code)
+	assert.Empty(t, rec2.Body.Bytes())
+
+	// If-None-Match takes precedence, so a stale ETag gets the cover even
+	// though it hasn't been modified since.
+	req3 := httptest.NewRequest(http.MethodGet, "/", nil)
+	req3.Header.Set("If-None-Match", `"7-stale"`)
+	req3.Header.Set("If-Modified-Since", pinned.Format(http.TimeFormat))
+	rec3 := httptest.NewRecorder()
+	c3 := e.NewContext(req3, rec3)
+	require.NoError(t, ServeFile(c3, 7, coverPath, info, CacheControlImmutable, info.ModTime()))
+	assert.Equal(t, http.StatusOK, rec3.Code)
+}
+
+func TestNotModified(t *testing.T) {
+	t.Parallel()
+
+	pinned := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		ifNoneMatch  string
+		ifModSince   string
+		lastModified time.Time
+		want         bool
+	}{
+		{name: "no conditional headers", lastModified: pinned, want: false},
+		{name: "matching etag", ifNoneMatch: `"7-abc"`, want: true},
+		{name: "matching weak etag in list", ifNoneMatch: `"7-old", W/"7-abc"`, want: true},
+		{name: "wildcard", ifNoneMatch: "*", want: true},
+		{name: "stale etag", ifNoneMatch: `"7-old"`, want: false},
+		{name: "stale etag ignores if-modified-since", ifNoneMatch: `"7-old"`, ifModSince: pinned.Format(http.TimeFormat), lastModified: pinned, want: false},
+		{name: "not modified since", ifModSince: pinned.Format(http.TimeFormat), lastModified: pinned.Add(500 * time.Millisecond), want: true},
+		{name: "modified since", ifModSince: pinned.Format(http.TimeFormat), lastModified: pinned.Add(time.Second), want: false},
+		{name: "zero last modified ignores if-modified-since", ifModSince: pinned.Format(http.TimeFormat), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			if tt.ifModSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModSince)
+			}
+			assert.Equal(t, tt.want, NotModified(req, `"7-abc"`, tt.lastModified))
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/shishobooks/shisho/pkg/errcodes"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -172,8 +173,9 @@ func TestCache_GetOrGenerate(t *testing.T) {
 		}
 
 		_, _, err := cache.GetOrGenerate(context.Background(), book, file)
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "unsupported")
+		var unsupportedErr *errcodes.UnsupportedFileTypeError
+		require.ErrorAs(t, err, &unsupportedErr)
+		assert.Equal(t, "unknown", unsupportedErr.FileType)
 	})
 }
 
@@ -48,7 +48,7 @@ type Fingerprint struct {
 	Publisher         *string                 `json:"publisher,omitempty"`
 	ReleaseDate       *time.Time              `json:"release_date,omitempty"`
 	Cover             *FingerprintCover       `json:"cover,omitempty"`
-	CoverPage         *int                    `json:"cover_page,omitempty"` // For page-based files (CBZ, PDF): page index of cover
+	CoverPage         *int                    `json:"cover_page,omitempty"` // For page-based files (CBZ, PDF): 1-indexed cover page
 	Chapters          []FingerprintChapter    `json:"chapters,omitempty"`
 	Format            string                  `json:"format,omitempty"`             // Download format: original, kepub, or plugin:<id>
 	Name              *string                 `json:"name,omitempty"`               // File name (edition name)
@@ -335,7 +335,7 @@ func ComputeFingerprint(book *models.Book, file *models.File) (*Fingerprint, err
 		fileType = file.FileType
 	}
 	if file != nil && len(file.Chapters) > 0 {
-		fp.Chapters = convertChaptersToFingerprint(file.Chapters, fileType)
+		fp.Chapters = convertChaptersToFingerprint(models.OffsetChapters(file.Chapters, file.ChapterOffsetMs), fileType)
 	} else {
 		fp.Chapters = []FingerprintChapter{}
 	}
@@ -0,0 +1,176 @@
+package epub
+
+import (
+	"archive/zip"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// findCover picks the cover image declared by the package. The canonical
+// declarations come first: the EPUB 3 manifest item with
+// properties="cover-image" and the EPUB 2 <meta name="cover"> pointing at a
+// manifest item, each preferred by the version that defines it. Next is a
+// <guide> reference of type "cover" that points straight at an image. Last is
+// an image whose manifest id looks like a cover.
+//
+// When the guide reference points at an XHTML page instead, coverPage is that
+// page's path in the archive. The image on it is only known once the page is
+// read (see coverImageFromPage), and it should be used over the id heuristic.
+func findCover(pkg *Package, basePath string) (coverFilepath, coverMimeType, coverPage string) {
+	image := func(href, mediaType string) (string, string, bool) {
+		if !strings.HasPrefix(mediaType, "image/") {
+			return "", "", false
+		}
+		return resolveHref(basePath, href), mediaType, true
+	}
+
+	fromMeta := func() (string, string, bool) {
+		var content string
+		for _, m := range pkg.Metadata.Meta {
+			if m.Name == "cover" && m.Content != "" {
+				content = m.Content
+			}
+		}
+		if content == "" {
+			return "", "", false
+		}
+		for _, item := range pkg.Manifest.Item {
+			if item.ID == content {
+				return image(item.Href, item.MediaType)
+			}
+		}
+		// Some generators put the image's href in content instead of its id.
+		for _, item := range pkg.Manifest.Item {
+			if item.Href == content {
+				return image(item.Href, item.MediaType)
+			}
+		}
+		return "", "", false
+	}
+
+	fromProperties := func() (string, string, bool) {
+		for _, item := range pkg.Manifest.Item {
+			// properties is a whitespace-separated token list per the spec,
+			// so match tokens rather than running a substring check.
+			for _, prop := range strings.Fields(item.Properties) {
+				if prop == "cover-image" {
+					if p, m, ok := image(item.Href, item.MediaType); ok {
+						return p, m, true
+					}
+				}
+			}
+		}
+		return "", "", false
+	}
+
+	canonical := []func() (string, string, bool){fromMeta, fromProperties}
+	if strings.HasPrefix(pkg.Version, "3") {
+		canonical = []func() (string, string, bool){fromProperties, fromMeta}
+	}
+	for _, find := range canonical {
+		if p, m, ok := find(); ok {
+			return p, m, ""
+		}
+	}
+
+	for _, ref := range pkg.Guide.Reference {
+		if !strings.EqualFold(ref.Type, "cover") || ref.Href == "" {
+			continue
+		}
+		href, _, _ := strings.Cut(ref.Href, "#")
+		target := resolveHref(basePath, href)
+		for _, item := range pkg.Manifest.Item {
+			if resolveHref(basePath, item.Href) != target {
+				continue
+			}
+			if p, m, ok := image(item.Href, item.MediaType); ok {
+				return p, m, ""
+			}
+		}
+		coverPage = target
+		break
+	}
+
+	// Fallback: image manifest item whose id matches a common cover convention
+	// (e.g. id="cover-image" or id="cover" with no explicit <meta>/properties
+	// hint). Some older EPUBs (notably legacy HarperCollins exports) ship only
+	// this.
+	for _, item := range pkg.Manifest.Item {
+		id := strings.ToLower(item.ID)
+		if id == "cover-image" || id == "cover" || id == "coverimage" {
+			if p, m, ok := image(item.Href, item.MediaType); ok {
+				return p, m, coverPage
+			}
+		}
+	}
+	return "", "", coverPage
+}
+
+// coverImageFromPage returns the first image on the cover page named by the
+// guide, as long as it's an image in the manifest. Both <img src> and the
+// SVG <image xlink:href> that many cover pages use are recognized.
+func coverImageFromPage(files []*zip.File, pkg *Package, basePath, page string) (string, string) {
+	var src string
+	for _, file := range files {
+		if file.Name != page {
+			continue
+		}
+		r, err := file.Open()
+		if err != nil {
+			return "", ""
+		}
+		src = firstImageSource(r)
+		r.Close()
+		break
+	}
+	if src == "" {
+		return "", ""
+	}
+
+	if unescaped, err := url.PathUnescape(src); err == nil {
+		src = unescaped
+	}
+	target := path.Clean(path.Join(path.Dir(page), src))
+	for _, item := range pkg.Manifest.Item {
+		if resolveHref(basePath, item.Href) == target && strings.HasPrefix(item.MediaType, "image/") {
+			return target, item.MediaType
+		}
+	}
+	return "", ""
+}
+
+// firstImageSource returns the source of the first <img> or SVG <image> in an
+// (X)HTML document.
+func firstImageSource(r io.Reader) string {
+	z := html.NewTokenizer(r)
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			tag := string(name)
+			if tag != "img" && tag != "image" {
+				continue
+			}
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				switch string(key) {
+				case "src":
+					if tag == "img" {
+						return string(val)
+					}
+				case "xlink:href", "href":
+					if tag == "image" {
+						return string(val)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package epub
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseCoverOPF(t *testing.T, opfXML string) *OPF {
+	t.Helper()
+	result, err := ParseOPF("OEBPS/content.opf", io.NopCloser(strings.NewReader(opfXML)))
+	require.NoError(t, err)
+	return result.OPF
+}
+
+// EPUB 2 declares the cover with <meta name="cover"> pointing at a manifest
+// id. It wins over an image whose id merely looks like a cover.
+func TestParseOPF_Cover_EPUB2Meta(t *testing.T) {
+	t.Parallel()
+	opf := parseCoverOPF(t, `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opf="http://www.idpf.org/2007/opf">
+    <dc:title>Test Book</dc:title>
+    <meta name="cover" content="front"/>
+  </metadata>
+  <manifest>
+    <item href="Images/logo.png" id="cover" media-type="image/png"/>
+    <item href="Images/front.jpg" id="front" media-type="image/jpeg"/>
+  </manifest>
+</package>`)
+
+	assert.Equal(t, "OEBPS/Images/front.jpg", opf.CoverFilepath)
+	assert.Equal(t, "image/jpeg", opf.CoverMimeType)
+}
+
+// A <meta name="cover"> that points at the cover page rather than its image
+// is ignored instead of being read as an image.
+func TestParseOPF_Cover_EPUB2MetaIgnoresNonImage(t *testing.T) {
+	t.Parallel()
+	opf := parseCoverOPF(t, `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opf="http://www.idpf.org/2007/opf">
+    <dc:title>Test Book</dc:title>
+    <meta name="cover" content="titlepage"/>
+  </metadata>
+  <manifest>
+    <item href="titlepage.xhtml" id="titlepage" media-type="application/xhtml+xml"/>
+    <item href="Images/cover.jpg" id="cover-image" media-type="image/jpeg"/>
+  </manifest>
+</package>`)
+
+	assert.Equal(t, "OEBPS/Images/cover.jpg", opf.CoverFilepath)
+}
+
+// EPUB 3 declares the cover with properties="cover-image", which wins over a
+// leftover EPUB 2 <meta name="cover"> in an EPUB 3 package.
+func TestParseOPF_Cover_EPUB3Properties(t *testing.T) {
+	t.Parallel()
+	opf := parseCoverOPF(t, `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Test Book</dc:title>
+    <meta name="cover" content="old"/>
+  </metadata>
+  <manifest>
+    <item href="old-cover.jpg" id="old" media-type="image/jpeg"/>
+    <item href="images/cover%20art.png" id="img1" media-type="image/png" properties="svg cover-image"/>
+  </manifest>
+</package>`)
+
+	assert.Equal(t, "OEBPS/images/cover art.png", opf.CoverFilepath)
+	assert.Equal(t, "image/png", opf.CoverMimeType)
+}
+
+func TestParseOPF_Cover_GuideImage(t *testing.T) {
+	t.Parallel()
+	opf := parseCoverOPF(t, `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Test Book</dc:title>
+  </metadata>
+  <manifest>
+    <item href="img/front.jpg" id="img1" media-type="image/jpeg"/>
+    <item href="img/cover.jpg" id="cover" media-type="image/jpeg"/>
+  </manifest>
+  <guide>
+    <reference type="cover" title="Cover" href="img/front.jpg"/>
+  </guide>
+</package>`)
+
+	assert.Equal(t, "OEBPS/img/front.jpg", opf.CoverFilepath)
+	assert.Empty(t, opf.CoverPage)
+}
+
+// A guide reference to a cover page is followed to the image on that page,
+// which wins over the id heuristic.
+func TestParse_Cover_GuidePage(t *testing.T) {
+	t.Parallel()
+	path := writeWordCountEPUB(t, `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Test Book</dc:title>
+  </metadata>
+  <manifest>
+    <item href="text/cover.xhtml" id="coverpage" media-type="application/xhtml+xml"/>
+    <item href="images/front.png" id="img1" media-type="image/png"/>
+    <item href="images/logo.png" id="cover" media-type="image/png"/>
+  </manifest>
+  <spine>
+    <itemref idref="coverpage"/>
+  </spine>
+  <guide>
+    <reference type="cover" title="Cover" href="text/cover.xhtml#start"/>
+  </guide>
+</package>`, map[string]string{
+		"OEBPS/text/cover.xhtml": `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:xlink="http://www.w3.org/1999/xlink"><body>
+<svg><image width="600" height="800" xlink:href="../images/front.png"/></svg>
+</body></html>`,
+		"OEBPS/images/front.png": "front",
+		"OEBPS/images/logo.png":  "logo",
+	})
+
+	metadata, err := Parse(path)
+	require.NoError(t, err)
+	assert.Equal(t, "front", string(metadata.CoverData))
+	assert.Equal(t, "image/png", metadata.CoverMimeType)
+}
+
+func TestFirstImageSource(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "cover.jpg", firstImageSource(strings.NewReader(`<html><body><p>x</p><img alt="Cover" src="cover.jpg"/></body></html>`)))
+	assert.Equal(t, "../images/c.png", firstImageSource(strings.NewReader(`<svg><image xlink:href="../images/c.png"/></svg>`)))
+	assert.Empty(t, firstImageSource(strings.NewReader(`<html><body>No images</body></html>`)))
+}
@@ -0,0 +1,73 @@
+package epub
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// Files in META-INF that only exist in DRM-protected EPUBs.
+var drmMarkerFiles = map[string]struct{}{
+	"meta-inf/rights.xml":   {}, // Adobe ADEPT
+	"meta-inf/sinf.xml":     {}, // Apple FairPlay
+	"meta-inf/license.lcpl": {}, // Readium LCP
+}
+
+// fontObfuscationAlgorithms are the encryption.xml algorithms used to
+// obfuscate embedded fonts. They're allowed in DRM-free EPUBs, so an
+// encryption.xml that only references these doesn't indicate DRM.
+var fontObfuscationAlgorithms = map[string]struct{}{
+	"http://www.idpf.org/2008/embedding": {},
+	"http://ns.adobe.com/pdf/enc#RC":     {},
+}
+
+type encryptionXML struct {
+	EncryptedData []struct {
+		EncryptionMethod struct {
+			Algorithm string `xml:"Algorithm,attr"`
+		} `xml:"EncryptionMethod"`
+	} `xml:"EncryptedData"`
+}
+
+// hasDRM reports whether the EPUB archive is DRM-protected. It looks for the
+// marker files left by common DRM schemes and for content in
+// META-INF/encryption.xml that is encrypted with anything other than font
+// obfuscation. Nothing is decrypted. An unreadable encryption.xml is ignored
+// so it doesn't block otherwise-parseable books.
+func hasDRM(files []*zip.File) bool {
+	for _, file := range files {
+		name := strings.ToLower(file.Name)
+		if _, ok := drmMarkerFiles[name]; ok {
+			return true
+		}
+		if name != "meta-inf/encryption.xml" {
+			continue
+		}
+		r, err := file.Open()
+		if err != nil {
+			continue
+		}
+		encrypted := hasNonFontEncryption(r)
+		r.Close()
+		if encrypted {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNonFontEncryption parses an encryption.xml document and reports whether
+// any resource uses an algorithm other than font obfuscation.
+func hasNonFontEncryption(r io.Reader) bool {
+	var enc encryptionXML
+	if err := xml.NewDecoder(r).Decode(&enc); err != nil {
+		return false
+	}
+	for _, data := range enc.EncryptedData {
+		if _, ok := fontObfuscationAlgorithms[data.EncryptionMethod.Algorithm]; !ok {
+			return true
+		}
+	}
+	return false
+}
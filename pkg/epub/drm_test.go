@@ -0,0 +1,88 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const drmTestOPF = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Test Book</dc:title>
+  </metadata>
+  <manifest></manifest>
+  <spine></spine>
+</package>`
+
+func encryptionXMLWith(algorithms ...string) string {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0"?><encryption xmlns="urn:oasis:names:tc:opendocument:xmlns:container" xmlns:enc="http://www.w3.org/2001/04/xmlenc#">`)
+	for _, alg := range algorithms {
+		buf.WriteString(`<enc:EncryptedData><enc:EncryptionMethod Algorithm="` + alg + `"/><enc:CipherData><enc:CipherReference URI="OEBPS/x"/></enc:CipherData></enc:EncryptedData>`)
+	}
+	buf.WriteString(`</encryption>`)
+	return buf.String()
+}
+
+func writeTestEPUB(t *testing.T, extra map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "book.epub")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	files := map[string]string{"OEBPS/content.opf": drmTestOPF}
+	for name, content := range extra {
+		files[name] = content
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return path
+}
+
+func TestParse_DRMDetection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		extra   map[string]string
+		wantDRM bool
+	}{
+		{"no META-INF extras", nil, false},
+		{"adobe rights.xml", map[string]string{"META-INF/rights.xml": "<rights/>"}, true},
+		{"apple sinf.xml", map[string]string{"META-INF/sinf.xml": "<fairplay/>"}, true},
+		{"readium lcp license", map[string]string{"META-INF/license.lcpl": "{}"}, true},
+		{"idpf font obfuscation only", map[string]string{"META-INF/encryption.xml": encryptionXMLWith("http://www.idpf.org/2008/embedding")}, false},
+		{"adobe font obfuscation only", map[string]string{"META-INF/encryption.xml": encryptionXMLWith("http://ns.adobe.com/pdf/enc#RC")}, false},
+		{"aes encrypted content", map[string]string{"META-INF/encryption.xml": encryptionXMLWith("http://www.idpf.org/2008/embedding", "http://www.w3.org/2001/04/xmlenc#aes128-cbc")}, true},
+		{"malformed encryption.xml is ignored", map[string]string{"META-INF/encryption.xml": "<not xml"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := writeTestEPUB(t, tt.extra)
+
+			metadata, err := Parse(path)
+			if tt.wantDRM {
+				require.ErrorIs(t, err, errcodes.DRMProtected())
+				assert.Nil(t, metadata)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Test Book", metadata.Title)
+		})
+	}
+}
@@ -4,13 +4,16 @@ import (
 	"archive/zip"
 	"encoding/xml"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
 	"github.com/shishobooks/shisho/pkg/htmlutil"
 	"github.com/shishobooks/shisho/pkg/identifiers"
 	"github.com/shishobooks/shisho/pkg/mediafile"
@@ -29,12 +32,15 @@ type OPF struct {
 	Publisher     string
 	URL           string
 	ReleaseDate   *time.Time
+	ModifiedDate  *time.Time
 	CoverFilepath string
 	CoverMimeType string
+	CoverPage     string // Cover page named by the <guide>, when its image still has to be found (see findCover)
 	CoverData     []byte
 	Identifiers   []mediafile.ParsedIdentifier
 	Chapters      []mediafile.ParsedChapter
 	Language      *string
+	Accessibility map[string][]string
 }
 
 type Package struct {
@@ -44,16 +50,13 @@ type Package struct {
 	Version          string   `xml:"version,attr"`
 	UniqueIdentifier string   `xml:"unique-identifier,attr"`
 	Metadata         struct {
-		Text    string `xml:",chardata"`
-		Opf     string `xml:"opf,attr"`
-		Dc      string `xml:"dc,attr"`
-		Dcterms string `xml:"dcterms,attr"`
-		Xsi     string `xml:"xsi,attr"`
-		Calibre string `xml:"calibre,attr"`
-		Title   []struct {
-			Text string `xml:",chardata"`
-			ID   string `xml:"id,attr"`
-		} `xml:"title"`
+		Text    string     `xml:",chardata"`
+		Opf     string     `xml:"opf,attr"`
+		Dc      string     `xml:"dc,attr"`
+		Dcterms string     `xml:"dcterms,attr"`
+		Xsi     string     `xml:"xsi,attr"`
+		Calibre string     `xml:"calibre,attr"`
+		Title   []opfTitle `xml:"title"`
 		Creator []struct {
 			Text   string `xml:",chardata"`
 			ID     string `xml:"id,attr"`
@@ -72,11 +75,11 @@ type Package struct {
 			ID     string `xml:"id,attr"`
 			Scheme string `xml:"scheme,attr"`
 		} `xml:"identifier"`
-		Date     string   `xml:"date"`
-		Relation []string `xml:"relation"`
-		Source   []string `xml:"source"`
-		Rights   string   `xml:"rights"`
-		Language string   `xml:"language"`
+		Date     []opfDate `xml:"date"`
+		Relation []string  `xml:"relation"`
+		Source   []string  `xml:"source"`
+		Rights   string    `xml:"rights"`
+		Language string    `xml:"language"`
 		Meta     []struct {
 			Text     string `xml:",chardata"`
 			Name     string `xml:"name,attr"`
@@ -133,29 +136,26 @@ func Parse(path string) (*mediafile.ParsedMetadata, error) {
 		return nil, errors.WithStack(err)
 	}
 
-	// Go through all files in the existing archive save the page information.
-	var result *ParseOPFResult
-	for _, file := range zipReader.File {
-		ext := filepath.Ext(file.Name)
-		if ext == ".opf" {
-			r, err := file.Open()
-			if err != nil {
-				return nil, errors.WithStack(err)
-			}
-			result, err = ParseOPF(file.Name, r)
-			if err != nil {
-				return nil, errors.WithStack(err)
-			}
-			break
-		}
+	// DRM-protected EPUBs can't be read, so classify them up front instead of
+	// failing later with a generic parse error.
+	if hasDRM(zipReader.File) {
+		return nil, errors.WithStack(errcodes.DRMProtected())
 	}
 
-	if result == nil {
-		return nil, errors.New("no opf file found")
+	result, err := findPackage(zipReader.File)
+	if err != nil {
+		return nil, err
 	}
 
 	opf := result.OPF
 
+	if opf.CoverPage != "" {
+		if coverPath, mimeType := coverImageFromPage(zipReader.File, result.Package, result.BasePath, opf.CoverPage); coverPath != "" {
+			opf.CoverFilepath = coverPath
+			opf.CoverMimeType = mimeType
+		}
+	}
+
 	if opf.CoverFilepath != "" {
 		for _, file := range zipReader.File {
 			if file.Name == opf.CoverFilepath {
@@ -198,6 +198,8 @@ func Parse(path string) (*mediafile.ParsedMetadata, error) {
 		}
 	}
 
+	wordCount := countWords(zipReader.File, result.Package, result.BasePath)
+
 	return &mediafile.ParsedMetadata{
 		Title:         opf.Title,
 		Subtitle:      opf.Subtitle,
@@ -210,15 +212,35 @@ func Parse(path string) (*mediafile.ParsedMetadata, error) {
 		Publisher:     opf.Publisher,
 		URL:           opf.URL,
 		ReleaseDate:   opf.ReleaseDate,
+		ModifiedDate:  opf.ModifiedDate,
 		CoverMimeType: opf.CoverMimeType,
 		CoverData:     opf.CoverData,
 		DataSource:    models.DataSourceEPUBMetadata,
 		Identifiers:   opf.Identifiers,
 		Chapters:      opf.Chapters,
 		Language:      opf.Language,
+		WordCount:     wordCount,
+		Accessibility: opf.Accessibility,
 	}, nil
 }
 
+// ParseModifiedDate returns the modified date declared by the EPUB at path
+// (see parseDates), or nil when it doesn't declare one. Only the package
+// document is read, so it's much cheaper than Parse.
+func ParseModifiedDate(path string) (*time.Time, error) {
+	zipReader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer zipReader.Close()
+
+	result, err := findPackage(zipReader.File)
+	if err != nil {
+		return nil, err
+	}
+	return result.OPF.ModifiedDate, nil
+}
+
 // ParseOPFResult contains the parsed OPF data along with the raw package and base path
 // needed for resolving relative paths to other files in the EPUB.
 type ParseOPFResult struct {
@@ -227,6 +249,75 @@ type ParseOPFResult struct {
 	BasePath string
 }
 
+// container is META-INF/container.xml, which names the package document.
+type container struct {
+	Rootfiles []struct {
+		FullPath  string `xml:"full-path,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// findPackage parses the OPF package document named by the archive's
+// META-INF/container.xml. Archives without a usable container.xml fall back
+// to the first .opf entry.
+func findPackage(files []*zip.File) (*ParseOPFResult, error) {
+	opfFile := containerRootfile(files)
+	if opfFile == nil {
+		for _, file := range files {
+			if filepath.Ext(file.Name) == ".opf" {
+				opfFile = file
+				break
+			}
+		}
+	}
+	if opfFile == nil {
+		return nil, errors.New("no opf file found")
+	}
+
+	r, err := opfFile.Open()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer r.Close()
+	result, err := ParseOPF(opfFile.Name, r)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return result, nil
+}
+
+// containerRootfile returns the package document entry that
+// META-INF/container.xml points to, or nil if there isn't one.
+func containerRootfile(files []*zip.File) *zip.File {
+	var c container
+	for _, file := range files {
+		if file.Name != "META-INF/container.xml" {
+			continue
+		}
+		r, err := file.Open()
+		if err != nil {
+			return nil
+		}
+		err = xml.NewDecoder(r).Decode(&c)
+		r.Close()
+		if err != nil {
+			return nil
+		}
+		break
+	}
+	for _, rootfile := range c.Rootfiles {
+		if rootfile.MediaType != "" && rootfile.MediaType != "application/oebps-package+xml" {
+			continue
+		}
+		for _, file := range files {
+			if file.Name == rootfile.FullPath {
+				return file
+			}
+		}
+	}
+	return nil
+}
+
 func ParseOPF(filename string, r io.ReadCloser) (*ParseOPFResult, error) {
 	b, err := io.ReadAll(r)
 	if err != nil {
@@ -265,30 +356,7 @@ func ParseOPF(filename string, r io.ReadCloser) (*ParseOPFResult, error) {
 	}
 
 	// Parse out the main title and subtitle of the book.
-	title := ""
-	subtitle := ""
-	if len(pkg.Metadata.Title) == 1 {
-		title = pkg.Metadata.Title[0].Text
-	} else if len(pkg.Metadata.Title) > 1 {
-		for _, t := range pkg.Metadata.Title {
-			titleType := ""
-			if t.ID != "" && metaProperties[t.ID] != nil {
-				titleType = metaProperties[t.ID]["title-type"]
-			}
-			// Check for main title
-			if titleType == "main" || t.ID == "title-main" {
-				title = t.Text
-			}
-			// Check for subtitle - either by title-type property or by id
-			if titleType == "subtitle" || t.ID == "subtitle" {
-				subtitle = t.Text
-			}
-		}
-		// If we didn't find a main title, use the first one
-		if title == "" && len(pkg.Metadata.Title) > 0 {
-			title = pkg.Metadata.Title[0].Text
-		}
-	}
+	title, subtitle := parseTitles(pkg.Metadata.Title, metaProperties)
 
 	authors := []mediafile.ParsedAuthor{}
 	for _, creator := range pkg.Metadata.Creator {
@@ -302,53 +370,7 @@ func ParseOPF(filename string, r io.ReadCloser) (*ParseOPFResult, error) {
 		}
 	}
 
-	coverFilepath := ""
-	coverMimeType := ""
-	// 1. Preferred: <meta name="cover" content="ID"/> pointing at a manifest item.
-	if metaContent["cover"] != "" {
-		for _, item := range pkg.Manifest.Item {
-			if item.ID == metaContent["cover"] {
-				coverFilepath = basePath + item.Href
-				coverMimeType = item.MediaType
-			}
-		}
-	}
-	// 2. EPUB 3: item with properties="cover-image". The properties
-	// attribute is a whitespace-separated token list per the spec, so
-	// match tokens rather than running a substring check.
-	if coverFilepath == "" {
-		for _, item := range pkg.Manifest.Item {
-			if !strings.HasPrefix(item.MediaType, "image/") {
-				continue
-			}
-			for _, prop := range strings.Fields(item.Properties) {
-				if prop == "cover-image" {
-					coverFilepath = basePath + item.Href
-					coverMimeType = item.MediaType
-					break
-				}
-			}
-			if coverFilepath != "" {
-				break
-			}
-		}
-	}
-	// 3. Fallback: image manifest item whose id matches a common cover convention
-	// (e.g. id="cover-image" or id="cover" with no explicit <meta>/properties hint).
-	// Some older EPUBs (notably legacy HarperCollins exports) ship only this.
-	if coverFilepath == "" {
-		for _, item := range pkg.Manifest.Item {
-			if !strings.HasPrefix(item.MediaType, "image/") {
-				continue
-			}
-			id := strings.ToLower(item.ID)
-			if id == "cover-image" || id == "cover" || id == "coverimage" {
-				coverFilepath = basePath + item.Href
-				coverMimeType = item.MediaType
-				break
-			}
-		}
-	}
+	coverFilepath, coverMimeType, coverPage := findCover(pkg, basePath)
 
 	// Parse series information from calibre meta tags
 	series := metaContent["calibre:series"]
@@ -400,23 +422,7 @@ func ParseOPF(filename string, r io.ReadCloser) (*ParseOPFResult, error) {
 		}
 	}
 
-	// Extract release date from dc:date
-	var releaseDate *time.Time
-	if pkg.Metadata.Date != "" {
-		// Try various date formats
-		formats := []string{
-			"2006-01-02",
-			"2006-01-02T15:04:05Z",
-			"2006-01-02T15:04:05-07:00",
-			"2006",
-		}
-		for _, format := range formats {
-			if t, err := time.Parse(format, pkg.Metadata.Date); err == nil {
-				releaseDate = &t
-				break
-			}
-		}
-	}
+	releaseDate, modifiedDate := parseDates(pkg)
 
 	// Extract URL from dc:relation or dc:source
 	var url string
@@ -459,6 +465,29 @@ func ParseOPF(filename string, r io.ReadCloser) (*ParseOPFResult, error) {
 		})
 	}
 
+	// Collect schema.org accessibility metadata. EPUB 3 writes it as
+	// <meta property="schema:accessMode">textual</meta>, and EPUB 2 as
+	// <meta name="schema:accessMode" content="textual"/>.
+	var accessibility map[string][]string
+	for _, m := range pkg.Metadata.Meta {
+		if m.Refines != "" {
+			continue
+		}
+		property, value := m.Property, m.Text
+		if property == "" {
+			property, value = m.Name, m.Content
+		}
+		key, ok := strings.CutPrefix(property, "schema:")
+		value = strings.TrimSpace(value)
+		if !ok || !mediafile.IsAccessibilityKey(key) || value == "" {
+			continue
+		}
+		if accessibility == nil {
+			accessibility = map[string][]string{}
+		}
+		accessibility[key] = append(accessibility[key], value)
+	}
+
 	return &ParseOPFResult{
 		OPF: &OPF{
 			Title:         title,
@@ -472,12 +501,156 @@ func ParseOPF(filename string, r io.ReadCloser) (*ParseOPFResult, error) {
 			Publisher:     publisher,
 			URL:           url,
 			ReleaseDate:   releaseDate,
+			ModifiedDate:  modifiedDate,
 			CoverFilepath: coverFilepath,
 			CoverMimeType: coverMimeType,
+			CoverPage:     coverPage,
 			Identifiers:   identifiersList,
 			Language:      language,
+			Accessibility: accessibility,
 		},
 		Package:  pkg,
 		BasePath: basePath,
 	}, nil
 }
+
+// opfDate is a <dc:date> element. EPUB 2 packages may have several, told
+// apart by opf:event ("publication", "modification", "creation").
+type opfDate struct {
+	Text  string `xml:",chardata"`
+	Event string `xml:"event,attr"`
+}
+
+// parseDates returns the publication date and the date the package was last
+// modified. The publication date is the <dc:date> marked as the publication
+// event, or else the first one without an event. The modified date is the
+// EPUB 3 dcterms:modified meta, or else an EPUB 2 <dc:date> marked as the
+// modification event.
+func parseDates(pkg *Package) (*time.Time, *time.Time) {
+	var published, modified *time.Time
+	for _, d := range pkg.Metadata.Date {
+		switch strings.ToLower(d.Event) {
+		case "publication":
+			if t := parseOPFDate(d.Text); t != nil {
+				published = t
+			}
+		case "":
+			if published == nil {
+				published = parseOPFDate(d.Text)
+			}
+		case "modification":
+			if modified == nil {
+				modified = parseOPFDate(d.Text)
+			}
+		}
+	}
+	for _, m := range pkg.Metadata.Meta {
+		if m.Property == "dcterms:modified" && m.Refines == "" {
+			if t := parseOPFDate(m.Text); t != nil {
+				modified = t
+			}
+			break
+		}
+	}
+	return published, modified
+}
+
+// parseOPFDate parses a date in any of the forms EPUBs use, from a bare year
+// to a full timestamp. Returns nil when none of them match.
+func parseOPFDate(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	formats := []string{
+		"2006-01-02",
+		"2006-01-02T15:04:05Z",
+		"2006-01-02T15:04:05-07:00",
+		"2006",
+	}
+	for _, format := range formats {
+		if t, err := time.Parse(format, s); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// opfTitle is a <dc:title> element.
+type opfTitle struct {
+	Text string `xml:",chardata"`
+	ID   string `xml:"id,attr"`
+}
+
+// parseTitles picks the main title and subtitle out of a package's
+// <dc:title> elements. EPUB 3 types each title with a refining
+// <meta property="title-type"> and orders them with "display-seq"; titles are
+// considered in display-seq order, with unsequenced ones after in document
+// order. The main title is the first one typed "main" (or with
+// id="title-main"), falling back to the first untyped title and then the
+// first title. The subtitle is the first
+// other title typed "subtitle" (or with id="subtitle", as Shisho writes it).
+func parseTitles(titles []opfTitle, metaProperties map[string]map[string]string) (string, string) {
+	type candidate struct {
+		text      string
+		titleType string
+		isMainID  bool
+		isSubID   bool
+		seq       int
+	}
+	candidates := make([]candidate, 0, len(titles))
+	for _, t := range titles {
+		text := strings.TrimSpace(t.Text)
+		if text == "" {
+			continue
+		}
+		c := candidate{text: text, isMainID: t.ID == "title-main", isSubID: t.ID == "subtitle", seq: math.MaxInt}
+		if props := metaProperties[t.ID]; t.ID != "" && props != nil {
+			c.titleType = strings.TrimSpace(props["title-type"])
+			if seq, err := strconv.Atoi(strings.TrimSpace(props["display-seq"])); err == nil {
+				c.seq = seq
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	if len(candidates) == 0 {
+		return "", ""
+	}
+	if len(candidates) == 1 {
+		return candidates[0].text, ""
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].seq < candidates[j].seq
+	})
+
+	isSubtitle := func(c candidate) bool {
+		return c.titleType == "subtitle" || (c.titleType == "" && c.isSubID)
+	}
+	mainIdx := -1
+	for i, c := range candidates {
+		if c.titleType == "main" || (c.titleType == "" && c.isMainID) {
+			mainIdx = i
+			break
+		}
+	}
+	if mainIdx < 0 {
+		for i, c := range candidates {
+			if c.titleType == "" && !isSubtitle(c) {
+				mainIdx = i
+				break
+			}
+		}
+	}
+	if mainIdx < 0 {
+		mainIdx = 0
+	}
+
+	subtitle := ""
+	for i, c := range candidates {
+		if i != mainIdx && isSubtitle(c) {
+			subtitle = c.text
+			break
+		}
+	}
+	return candidates[mainIdx].text, subtitle
+}
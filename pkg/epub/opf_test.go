@@ -4,6 +4,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -103,6 +104,34 @@ func TestParseOPF_Subtitle_ByID(t *testing.T) {
 	assert.Equal(t, "Mistborn Book One", result.OPF.Subtitle)
 }
 
+func TestParseOPF_Subtitle_DisplaySeq(t *testing.T) {
+	t.Parallel()
+	// The subtitle comes first in the document, and titles are ordered by
+	// display-seq rather than document order.
+	opfXML := `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title id="t3">A Collection Name</dc:title>
+    <dc:title id="t2">An Alternate Subtitle</dc:title>
+    <dc:title id="t1">The Primary Subtitle</dc:title>
+    <dc:title id="t0"> The Real Title </dc:title>
+    <meta refines="#t3" property="title-type">collection</meta>
+    <meta refines="#t3" property="display-seq">4</meta>
+    <meta refines="#t2" property="title-type">subtitle</meta>
+    <meta refines="#t2" property="display-seq">3</meta>
+    <meta refines="#t1" property="title-type">subtitle</meta>
+    <meta refines="#t1" property="display-seq">2</meta>
+    <meta refines="#t0" property="display-seq">1</meta>
+  </metadata>
+</package>`
+
+	result, err := ParseOPF("test.opf", io.NopCloser(strings.NewReader(opfXML)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "The Real Title", result.OPF.Title, "the untyped title is the main one")
+	assert.Equal(t, "The Primary Subtitle", result.OPF.Subtitle)
+}
+
 func TestParseOPF_Subtitle_SingleTitle(t *testing.T) {
 	t.Parallel()
 	// Single title: no subtitle
@@ -289,3 +318,118 @@ func TestParseOPF_Language_Missing(t *testing.T) {
 
 	assert.Nil(t, result.OPF.Language)
 }
+
+func TestParseOPF_Accessibility(t *testing.T) {
+	t.Parallel()
+
+	opfXML := `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Test Book</dc:title>
+    <meta property="schema:accessMode">textual</meta>
+    <meta property="schema:accessMode">visual</meta>
+    <meta property="schema:accessModeSufficient">textual</meta>
+    <meta property="schema:accessibilityFeature">alternativeText</meta>
+    <meta property="schema:accessibilityFeature">tableOfContents</meta>
+    <meta name="schema:accessibilityHazard" content="none"/>
+    <meta property="schema:accessibilitySummary"> Fully accessible. </meta>
+    <meta property="schema:unrelated">ignored</meta>
+  </metadata>
+  <manifest>
+    <item href="chapter1.xhtml" id="ch1" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine>
+    <itemref idref="ch1"/>
+  </spine>
+</package>`
+
+	result, err := ParseOPF("content.opf", io.NopCloser(strings.NewReader(opfXML)))
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"accessMode":           {"textual", "visual"},
+		"accessModeSufficient": {"textual"},
+		"accessibilityFeature": {"alternativeText", "tableOfContents"},
+		"accessibilityHazard":  {"none"},
+		"accessibilitySummary": {"Fully accessible."},
+	}, result.OPF.Accessibility)
+}
+
+func TestParseOPF_Accessibility_Missing(t *testing.T) {
+	t.Parallel()
+
+	opfXML := `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Test Book</dc:title>
+  </metadata>
+</package>`
+
+	result, err := ParseOPF("content.opf", io.NopCloser(strings.NewReader(opfXML)))
+	require.NoError(t, err)
+	assert.Nil(t, result.OPF.Accessibility)
+}
+
+func TestParseOPF_Dates(t *testing.T) {
+	t.Parallel()
+	date := func(s string) *time.Time {
+		parsed, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return &parsed
+	}
+	tests := []struct {
+		name         string
+		metadata     string
+		wantRelease  *time.Time
+		wantModified *time.Time
+	}{
+		{
+			name: "EPUB 3 dc:date and dcterms:modified",
+			metadata: `<dc:date>2019-05-14</dc:date>
+    <meta property="dcterms:modified">2023-02-01T10:30:00Z</meta>`,
+			wantRelease:  date("2019-05-14T00:00:00Z"),
+			wantModified: date("2023-02-01T10:30:00Z"),
+		},
+		{
+			name: "EPUB 2 dates by event",
+			metadata: `<dc:date opf:event="modification">2021-03-03</dc:date>
+    <dc:date opf:event="creation">2017-01-01</dc:date>
+    <dc:date opf:event="publication">2018-06-30</dc:date>`,
+			wantRelease:  date("2018-06-30T00:00:00Z"),
+			wantModified: date("2021-03-03T00:00:00Z"),
+		},
+		{
+			name:         "modification date is never the release date",
+			metadata:     `<dc:date opf:event="modification">2021-03-03</dc:date>`,
+			wantModified: date("2021-03-03T00:00:00Z"),
+		},
+		{
+			name: "dcterms:modified wins over a modification event",
+			metadata: `<dc:date opf:event="modification">2021-03-03</dc:date>
+    <meta property="dcterms:modified">2022-04-04T00:00:00Z</meta>`,
+			wantModified: date("2022-04-04T00:00:00Z"),
+		},
+		{
+			name:        "year only",
+			metadata:    `<dc:date>1999</dc:date>`,
+			wantRelease: date("1999-01-01T00:00:00Z"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opfXML := `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opf="http://www.idpf.org/2007/opf">
+    <dc:title>Test Book</dc:title>
+    ` + tt.metadata + `
+  </metadata>
+</package>`
+
+			result, err := ParseOPF("test.opf", io.NopCloser(strings.NewReader(opfXML)))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantRelease, result.OPF.ReleaseDate)
+			assert.Equal(t, tt.wantModified, result.OPF.ModifiedDate)
+		})
+	}
+}
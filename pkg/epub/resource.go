@@ -0,0 +1,115 @@
+package epub
+
+import (
+	"archive/zip"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+)
+
+// Resource is a single entry streamed out of an EPUB archive. Closing it
+// closes both the entry and the underlying archive.
+type Resource struct {
+	io.Reader
+	MediaType string
+	Size      int64
+
+	entry   io.ReadCloser
+	archive *zip.ReadCloser
+}
+
+func (r *Resource) Close() error {
+	entryErr := r.entry.Close()
+	archiveErr := r.archive.Close()
+	if entryErr != nil {
+		return errors.WithStack(entryErr)
+	}
+	return errors.WithStack(archiveErr)
+}
+
+// OpenResource opens the entry at resourcePath inside the EPUB at path.
+// resourcePath is relative to the root of the archive (e.g.
+// "OEBPS/chapter1.xhtml"). Only entries listed in the OPF manifest can be
+// opened, which keeps arbitrary archive members (and anything that tries to
+// climb out of the archive root) from being served.
+func OpenResource(path, resourcePath string) (*Resource, error) {
+	name, ok := cleanResourcePath(resourcePath)
+	if !ok {
+		return nil, errcodes.NotFound("Resource")
+	}
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res, err := openResource(archive, name)
+	if err != nil {
+		archive.Close()
+		return nil, err
+	}
+	return res, nil
+}
+
+func openResource(archive *zip.ReadCloser, name string) (*Resource, error) {
+	if hasDRM(archive.File) {
+		return nil, errors.WithStack(errcodes.DRMProtected())
+	}
+
+	result, err := findPackage(archive.File)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType := ""
+	found := false
+	for _, item := range result.Package.Manifest.Item {
+		if resolveHref(result.BasePath, item.Href) == name {
+			mediaType = item.MediaType
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errcodes.NotFound("Resource")
+	}
+
+	for _, file := range archive.File {
+		if file.Name != name {
+			continue
+		}
+		entry, err := file.Open()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if mediaType == "" {
+			mediaType = "application/octet-stream"
+		}
+		return &Resource{
+			Reader:    entry,
+			MediaType: mediaType,
+			Size:      int64(file.UncompressedSize64),
+			entry:     entry,
+			archive:   archive,
+		}, nil
+	}
+
+	// Listed in the manifest but missing from the archive.
+	return nil, errcodes.NotFound("Resource")
+}
+
+// cleanResourcePath normalizes a requested resource path into a zip entry
+// name. It rejects absolute paths and anything that escapes the archive root.
+func cleanResourcePath(resourcePath string) (string, bool) {
+	if resourcePath == "" || strings.HasPrefix(resourcePath, "/") || strings.Contains(resourcePath, "\\") {
+		return "", false
+	}
+	name := path.Clean(resourcePath)
+	if name == "." || name == ".." || strings.HasPrefix(name, "../") {
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,90 @@
+package epub
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenResource(t *testing.T) {
+	t.Parallel()
+
+	path := writeWordCountEPUB(t, wordCountTestOPF, map[string]string{
+		"OEBPS/text/chapter 1.xhtml": `<html><body><p>Chapter one</p></body></html>`,
+		"OEBPS/style.css":            `p { margin: 0; }`,
+		"OEBPS/secret.txt":           `not in the manifest`,
+	})
+
+	t.Run("manifest entry", func(t *testing.T) {
+		t.Parallel()
+		res, err := OpenResource(path, "OEBPS/style.css")
+		require.NoError(t, err)
+		defer res.Close()
+
+		b, err := io.ReadAll(res)
+		require.NoError(t, err)
+		assert.Equal(t, "p { margin: 0; }", string(b))
+		assert.Equal(t, "text/css", res.MediaType)
+		assert.EqualValues(t, len(b), res.Size)
+	})
+
+	t.Run("percent-encoded href", func(t *testing.T) {
+		t.Parallel()
+		res, err := OpenResource(path, "OEBPS/text/chapter 1.xhtml")
+		require.NoError(t, err)
+		defer res.Close()
+		assert.Equal(t, "application/xhtml+xml", res.MediaType)
+	})
+
+	notFound := []struct {
+		name         string
+		resourcePath string
+	}{
+		{"not in manifest", "OEBPS/secret.txt"},
+		{"in manifest but missing from archive", "OEBPS/text/chapter2.xhtml"},
+		{"traversal", "OEBPS/../../etc/passwd"},
+		{"absolute", "/OEBPS/style.css"},
+		{"empty", ""},
+	}
+	for _, tt := range notFound {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := OpenResource(path, tt.resourcePath)
+			require.Error(t, err)
+			var codeErr *errcodes.Error
+			require.True(t, errors.As(err, &codeErr))
+			assert.Equal(t, http.StatusNotFound, codeErr.HTTPCode)
+		})
+	}
+}
+
+func TestOpenResource_UsesContainerRootfile(t *testing.T) {
+	t.Parallel()
+
+	// The first .opf in the archive is a leftover; container.xml names the
+	// real package document.
+	decoy := `<?xml version="1.0"?><package xmlns="http://www.idpf.org/2007/opf"><manifest/></package>`
+	path := writeWordCountEPUB(t, decoy, map[string]string{
+		"META-INF/container.xml": `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OPS/package.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>`,
+		"OPS/package.opf": `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf">
+  <manifest><item id="css" href="style.css" media-type="text/css"/></manifest>
+</package>`,
+		"OPS/style.css": `p { margin: 0; }`,
+	})
+
+	res, err := OpenResource(path, "OPS/style.css")
+	require.NoError(t, err)
+	defer res.Close()
+	assert.Equal(t, "text/css", res.MediaType)
+}
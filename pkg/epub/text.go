@@ -0,0 +1,103 @@
+package epub
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxTextBytes bounds how much spine content ExtractText reads, so a huge
+// book can't balloon the full-text index. Text past the budget is dropped.
+const maxTextBytes = 16 << 20
+
+// ExtractText returns the body text of the EPUB at path, read from the XHTML
+// documents in its spine in reading order. Runs of whitespace are collapsed
+// to single spaces, and documents are separated by newlines.
+func ExtractText(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	defer f.Close()
+
+	stats, err := f.Stat()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	zipReader, err := zip.NewReader(f, stats.Size())
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if hasDRM(zipReader.File) {
+		return "", errors.WithStack(errcodes.DRMProtected())
+	}
+
+	result, err := findPackage(zipReader.File)
+	if err != nil {
+		return "", err
+	}
+	docs, _ := spineDocuments(zipReader.File, result.Package, result.BasePath)
+
+	var sb strings.Builder
+	var readBytes int64
+	for _, doc := range docs {
+		remaining := maxTextBytes - readBytes
+		if remaining <= 0 {
+			break
+		}
+		r, err := doc.Open()
+		if err != nil {
+			continue
+		}
+		cr := &countingReader{r: io.LimitReader(r, remaining)}
+		if sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+		writeHTMLText(&sb, cr)
+		readBytes += cr.n
+		r.Close()
+	}
+	return sb.String(), nil
+}
+
+// writeHTMLText writes the body text of an (X)HTML document to sb, skipping
+// anything inside head, script, and style elements. Whitespace is collapsed
+// to single spaces, and block elements count as word breaks so adjacent
+// paragraphs don't run together.
+func writeHTMLText(sb *strings.Builder, r io.Reader) {
+	z := html.NewTokenizer(r)
+	var raw strings.Builder
+	skipDepth := 0
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			sb.WriteString(strings.Join(strings.Fields(raw.String()), " "))
+			return
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Head, atom.Script, atom.Style:
+				if tt == html.StartTagToken {
+					skipDepth++
+				} else if tt == html.EndTagToken && skipDepth > 0 {
+					skipDepth--
+				}
+			case atom.P, atom.Div, atom.Br, atom.Hr, atom.Li, atom.Tr, atom.Td, atom.Th,
+				atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6,
+				atom.Blockquote, atom.Section:
+				raw.WriteByte(' ')
+			}
+		case html.TextToken:
+			if skipDepth == 0 {
+				raw.Write(z.Text())
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package epub
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteHTMLText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"collapses whitespace", "<body><p>One\n   two\tthree.</p></body>", "One two three."},
+		{"skips head, script and style", `<html><head><title>No</title><style>p {}</style></head><body><script>x()</script><p>Yes</p></body></html>`, "Yes"},
+		{"inline tags don't split words", `<body><p>Hello <em>big</em> wor<b>ld</b></p></body>`, "Hello big world"},
+		{"blocks split words", `<body><p>end.</p><p>Start</p><div>a<br/>b</div></body>`, "end. Start a b"},
+		{"empty", ``, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var sb strings.Builder
+			writeHTMLText(&sb, strings.NewReader(tt.html))
+			assert.Equal(t, tt.want, sb.String())
+		})
+	}
+}
+
+func TestExtractText(t *testing.T) {
+	t.Parallel()
+
+	path := writeWordCountEPUB(t, wordCountTestOPF, map[string]string{
+		"OEBPS/text/chapter 1.xhtml": `<html><body><p>It was a dark and stormy night.</p></body></html>`,
+		"OEBPS/text/chapter2.xhtml":  `<html><body><p>The end.</p></body></html>`,
+		"OEBPS/text/extra.xhtml":     `<html><body><p>Not in the spine.</p></body></html>`,
+		"OEBPS/style.css":            `p { color: red; }`,
+	})
+
+	text, err := ExtractText(path)
+	require.NoError(t, err)
+	assert.Equal(t, "It was a dark and stormy night.\nThe end.", text)
+}
@@ -0,0 +1,139 @@
+package epub
+
+import (
+	"archive/zip"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxWordCountBytes bounds how much spine content is read when estimating the
+// word count, so huge books don't balloon scan time. Books larger than this
+// get an estimate that's extrapolated from the portion that was read.
+const maxWordCountBytes = 16 << 20
+
+// countWords estimates the number of words in the book's text by reading the
+// XHTML documents in the spine and counting whitespace-separated words in
+// their body text. It returns nil if there's no readable spine content.
+func countWords(files []*zip.File, pkg *Package, basePath string) *int {
+	docs, totalBytes := spineDocuments(files, pkg, basePath)
+	if len(docs) == 0 {
+		return nil
+	}
+
+	words := 0
+	var readBytes int64
+	for _, f := range docs {
+		remaining := maxWordCountBytes - readBytes
+		if remaining <= 0 {
+			break
+		}
+		r, err := f.Open()
+		if err != nil {
+			continue
+		}
+		cr := &countingReader{r: io.LimitReader(r, remaining)}
+		words += countHTMLWords(cr)
+		readBytes += cr.n
+		r.Close()
+	}
+
+	// Scale up when the budget cut reading short.
+	if readBytes > 0 && uint64(readBytes) < totalBytes {
+		words = int(float64(words) * float64(totalBytes) / float64(readBytes))
+	}
+
+	return &words
+}
+
+// spineDocuments returns the zip entries of the (X)HTML documents in the
+// book's spine, in reading order, along with their total uncompressed size.
+func spineDocuments(files []*zip.File, pkg *Package, basePath string) ([]*zip.File, uint64) {
+	byName := make(map[string]*zip.File, len(files))
+	for _, f := range files {
+		byName[f.Name] = f
+	}
+
+	hrefs := make(map[string]string, len(pkg.Manifest.Item))
+	for _, item := range pkg.Manifest.Item {
+		switch item.MediaType {
+		case "application/xhtml+xml", "text/html":
+			hrefs[item.ID] = item.Href
+		}
+	}
+
+	var docs []*zip.File
+	var totalBytes uint64
+	for _, ref := range pkg.Spine.Itemref {
+		href, ok := hrefs[ref.Idref]
+		if !ok {
+			continue
+		}
+		f, ok := byName[resolveHref(basePath, href)]
+		if !ok {
+			continue
+		}
+		docs = append(docs, f)
+		totalBytes += f.UncompressedSize64
+	}
+	return docs, totalBytes
+}
+
+// resolveHref turns a manifest href into a zip entry name. Hrefs are relative
+// to the OPF file and may be percent-encoded.
+func resolveHref(basePath, href string) string {
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+	return path.Clean(basePath + href)
+}
+
+// countHTMLWords counts the words in the body text of an (X)HTML document,
+// skipping anything inside head, script, and style elements.
+func countHTMLWords(r io.Reader) int {
+	z := html.NewTokenizer(r)
+	words := 0
+	skipDepth := 0
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return words
+		case html.StartTagToken:
+			if isNonTextElement(z) {
+				skipDepth++
+			}
+		case html.EndTagToken:
+			if isNonTextElement(z) && skipDepth > 0 {
+				skipDepth--
+			}
+		case html.TextToken:
+			if skipDepth == 0 {
+				words += len(strings.Fields(string(z.Text())))
+			}
+		}
+	}
+}
+
+func isNonTextElement(z *html.Tokenizer) bool {
+	name, _ := z.TagName()
+	switch atom.Lookup(name) {
+	case atom.Head, atom.Script, atom.Style:
+		return true
+	}
+	return false
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
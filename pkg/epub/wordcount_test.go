@@ -0,0 +1,101 @@
+package epub
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const wordCountTestOPF = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Test Book</dc:title>
+  </metadata>
+  <manifest>
+    <item id="ch1" href="text/chapter%201.xhtml" media-type="application/xhtml+xml"/>
+    <item id="ch2" href="text/chapter2.xhtml" media-type="application/xhtml+xml"/>
+    <item id="extra" href="text/extra.xhtml" media-type="application/xhtml+xml"/>
+    <item id="css" href="style.css" media-type="text/css"/>
+  </manifest>
+  <spine>
+    <itemref idref="ch1"/>
+    <itemref idref="css"/>
+    <itemref idref="ch2"/>
+  </spine>
+</package>`
+
+func writeWordCountEPUB(t *testing.T, opf string, files map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "book.epub")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("OEBPS/content.opf")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(opf))
+	require.NoError(t, err)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return path
+}
+
+func TestCountHTMLWords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		html string
+		want int
+	}{
+		{"plain body", `<html><body><p>One two three.</p></body></html>`, 3},
+		{"skips head", `<html><head><title>Not Counted</title></head><body><p>Counted</p></body></html>`, 1},
+		{"skips script and style", `<body><style>p { color: red; }</style><script>var a = 1;</script><p>Just these four words</p></body>`, 4},
+		{"words split across tags", `<body><p>Hello <em>big</em> world</p><p>again</p></body>`, 4},
+		{"entities", `<body><p>Fish&nbsp;&amp;&nbsp;chips</p></body>`, 3},
+		{"empty", ``, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, countHTMLWords(strings.NewReader(tt.html)))
+		})
+	}
+}
+
+func TestParse_WordCount(t *testing.T) {
+	t.Parallel()
+
+	path := writeWordCountEPUB(t, wordCountTestOPF, map[string]string{
+		"OEBPS/text/chapter 1.xhtml": `<html><head><title>Chapter One</title></head><body><h1>Chapter One</h1><p>It was a dark night.</p></body></html>`,
+		"OEBPS/text/chapter2.xhtml":  `<html><body><p>The end.</p></body></html>`,
+		"OEBPS/text/extra.xhtml":     `<html><body><p>Not in the spine so not counted.</p></body></html>`,
+		"OEBPS/style.css":            `body { margin: 0; }`,
+	})
+
+	metadata, err := Parse(path)
+	require.NoError(t, err)
+	require.NotNil(t, metadata.WordCount)
+	assert.Equal(t, 9, *metadata.WordCount)
+}
+
+func TestParse_WordCount_NoSpine(t *testing.T) {
+	t.Parallel()
+
+	path := writeWordCountEPUB(t, drmTestOPF, nil)
+
+	metadata, err := Parse(path)
+	require.NoError(t, err)
+	assert.Nil(t, metadata.WordCount)
+}
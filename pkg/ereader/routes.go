@@ -4,6 +4,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/shishobooks/shisho/pkg/apikeys"
 	"github.com/shishobooks/shisho/pkg/books"
+	"github.com/shishobooks/shisho/pkg/config"
 	"github.com/shishobooks/shisho/pkg/downloadcache"
 	"github.com/shishobooks/shisho/pkg/libraries"
 	"github.com/shishobooks/shisho/pkg/people"
@@ -13,12 +14,12 @@ import (
 )
 
 // RegisterRoutes registers all eReader routes.
-func RegisterRoutes(e *echo.Echo, db *bun.DB, downloadCache *downloadcache.Cache) {
+func RegisterRoutes(e *echo.Echo, db *bun.DB, cfg *config.Config, downloadCache *downloadcache.Cache) {
 	apiKeyService := apikeys.NewService(db)
 	libraryService := libraries.NewService(db)
 	bookService := books.NewService(db)
 	seriesService := series.NewService(db)
-	peopleService := people.NewService(db)
+	peopleService := people.NewService(db).WithNameLocale(cfg.PersonNameLocale)
 
 	mw := NewMiddleware(apiKeyService)
 	h := newHandler(db, libraryService, bookService, seriesService, peopleService, downloadCache, settings.NewService(db))
@@ -98,6 +98,42 @@ func PluginLoadFailure(msg string) error {
 	}
 }
 
+// DRMProtected returns a 422 error for a book file that is encrypted with DRM.
+// These files can't be read, so they're classified instead of being reported
+// as corrupt.
+func DRMProtected() error {
+	return &Error{
+		http.StatusUnprocessableEntity,
+		"This file is protected by DRM and can't be imported.",
+		"drm_protected",
+	}
+}
+
+// UnsupportedFileTypeError is returned for a file whose type has no built-in
+// or plugin parser. It unwraps to a 422 *Error, so the handler maps it like
+// any other errcodes value, while callers can still read the FileType.
+type UnsupportedFileTypeError struct {
+	FileType string
+}
+
+func (err *UnsupportedFileTypeError) Error() string {
+	return fmt.Sprintf("Unsupported file type %q.", err.FileType)
+}
+
+func (err *UnsupportedFileTypeError) Unwrap() error {
+	return &Error{
+		http.StatusUnprocessableEntity,
+		err.Error(),
+		"unsupported_file_type",
+	}
+}
+
+// UnsupportedFileType returns a 422 error for a file type that can't be
+// parsed or generated.
+func UnsupportedFileType(fileType string) error {
+	return &UnsupportedFileTypeError{FileType: fileType}
+}
+
 func MalformedPayload() error {
 	return &Error{
 		http.StatusBadRequest,
@@ -75,11 +75,18 @@ func (h *Handler) generateIndividualPayload(_ echo.Context, err error) (int, map
 		msg = "Internal Server Error"
 	}
 
+	payload := map[string]interface{}{
+		"code":        code,
+		"message":     msg,
+		"status_code": httpCode,
+	}
+
+	var uft *UnsupportedFileTypeError
+	if ok := errors.As(err, &uft); ok {
+		payload["file_type"] = uft.FileType
+	}
+
 	return httpCode, map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":        code,
-			"message":     msg,
-			"status_code": httpCode,
-		},
+		"error": payload,
 	}
 }
@@ -451,7 +451,7 @@ func modifyCBZComicInfo(existing *cbzComicInfo, book *models.Book, file *models.
 
 	// Update cover page in Pages section
 	if file.CoverPage != nil {
-		updateCoverPage(&comicInfo, *file.CoverPage)
+		updateCoverPage(&comicInfo, *file.CoverPageIndex())
 	}
 
 	// Write GTIN from file identifiers (priority: ISBN-13 > ISBN-10 > Other > ASIN)
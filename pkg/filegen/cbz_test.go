@@ -130,7 +130,7 @@ func TestCBZGenerator_Generate(t *testing.T) {
 
 		destPath := filepath.Join(tmpDir, "dest.cbz")
 
-		coverPage := 2 // Third page is the cover
+		coverPage := 3 // Third page is the cover (1-indexed)
 		book := &models.Book{
 			Title: "Comic With Cover",
 		}
@@ -157,7 +157,7 @@ func TestCBZGenerator_Generate(t *testing.T) {
 		}
 
 		require.NotNil(t, frontCoverPage, "should have a FrontCover page")
-		assert.Equal(t, "2", frontCoverPage.Image, "FrontCover should be on page 2")
+		assert.Equal(t, "2", frontCoverPage.Image, "FrontCover should be on image index 2")
 	})
 
 	t.Run("preserves original ComicInfo.xml fields", func(t *testing.T) {
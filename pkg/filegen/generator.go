@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
 	"github.com/shishobooks/shisho/pkg/models"
 )
 
@@ -58,13 +59,15 @@ func GetGenerator(fileType string) (Generator, error) {
 		return &CBZGenerator{}, nil
 	case models.FileTypePDF:
 		return &PDFGenerator{}, nil
+	case models.FileTypeMOBI, models.FileTypeAZW3:
+		return &MOBIGenerator{fileType: fileType}, nil
 	default:
-		return nil, errors.Errorf("unsupported file type: %s", fileType)
+		return nil, errors.WithStack(errcodes.UnsupportedFileType(fileType))
 	}
 }
 
 // GetKepubGenerator returns the appropriate KePub generator for a file type.
-// Returns ErrKepubNotSupported for file types that don't support KePub conversion (M4B).
+// Returns ErrKepubNotSupported for file types that don't support KePub conversion (M4B, PDF, MOBI, AZW3).
 func GetKepubGenerator(fileType string) (Generator, error) {
 	switch fileType {
 	case models.FileTypeEPUB:
@@ -73,10 +76,10 @@ func GetKepubGenerator(fileType string) (Generator, error) {
 		return NewKepubCBZGenerator(), nil
 	case models.FileTypeM4B:
 		return nil, ErrKepubNotSupported
-	case models.FileTypePDF:
+	case models.FileTypePDF, models.FileTypeMOBI, models.FileTypeAZW3:
 		return nil, ErrKepubNotSupported
 	default:
-		return nil, errors.Errorf("unsupported file type: %s", fileType)
+		return nil, errors.WithStack(errcodes.UnsupportedFileType(fileType))
 	}
 }
 
@@ -103,9 +103,9 @@ func buildCBZMetadata(book *models.Book, file *models.File) *kepub.CBZMetadata {
 		metadata.Chapters = convertModelChaptersToCBZ(file.Chapters)
 	}
 
-	// Set cover page if available
+	// Set cover page if available (kepub expects a 0-indexed page)
 	if file != nil && file.CoverPage != nil {
-		metadata.CoverPage = file.CoverPage
+		metadata.CoverPage = file.CoverPageIndex()
 	}
 
 	return metadata
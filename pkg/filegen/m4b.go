@@ -85,6 +85,7 @@ func (g *M4BGenerator) buildMetadata(book *models.Book, file *models.File, src *
 		Publisher:   src.Publisher,
 		URL:         src.URL,
 		ReleaseDate: src.ReleaseDate,
+		ReleaseYear: src.ReleaseYear,
 
 		// Always tag as audiobook. Some source files have no stik atom,
 		// which causes audiobook players to treat them as generic music.
@@ -100,7 +101,7 @@ func (g *M4BGenerator) buildMetadata(book *models.Book, file *models.File, src *
 
 	// Use database chapters if available, otherwise preserve source chapters
 	if file != nil && len(file.Chapters) > 0 {
-		meta.Chapters = convertModelChaptersToMP4(file.Chapters, src.Duration)
+		meta.Chapters = convertModelChaptersToMP4(models.OffsetChapters(file.Chapters, file.ChapterOffsetMs), src.Duration)
 	}
 
 	// Set description from book if available. Mirror into ©cmt (Comment) too
@@ -202,11 +203,21 @@ func (g *M4BGenerator) buildMetadata(book *models.Book, file *models.File, src *
 		sort.Slice(narrators, func(i, j int) bool {
 			return narrators[i].SortOrder < narrators[j].SortOrder
 		})
+		hasRoles := false
 		for _, n := range narrators {
 			if n.Person != nil {
 				meta.Narrators = append(meta.Narrators, n.Person.Name)
+				role := ""
+				if n.Role != nil {
+					role = *n.Role
+					hasRoles = true
+				}
+				meta.NarratorRoles = append(meta.NarratorRoles, mediafile.ParsedNarrator{Name: n.Person.Name, Role: role})
 			}
 		}
+		if !hasRoles {
+			meta.NarratorRoles = nil
+		}
 	}
 
 	// Series from book (first by SortOrder)
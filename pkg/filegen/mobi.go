@@ -0,0 +1,24 @@
+package filegen
+
+import (
+	"context"
+
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// MOBIGenerator handles MOBI and AZW3 files. Writing metadata back into the
+// EXTH header isn't supported yet, so Generate always reports
+// ErrNotImplemented and callers fall back to the original file.
+type MOBIGenerator struct {
+	fileType string
+}
+
+// SupportedType returns the file type this generator handles.
+func (g *MOBIGenerator) SupportedType() string {
+	return g.fileType
+}
+
+// Generate returns ErrNotImplemented.
+func (g *MOBIGenerator) Generate(_ context.Context, _, _ string, _ *models.Book, _ *models.File) error {
+	return NewGenerationError(g.fileType, ErrNotImplemented, "metadata can't be written to "+g.fileType+" files yet")
+}
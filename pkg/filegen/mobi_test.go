@@ -0,0 +1,27 @@
+package filegen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMOBIGenerator_NotImplemented(t *testing.T) {
+	t.Parallel()
+	for _, fileType := range []string{models.FileTypeMOBI, models.FileTypeAZW3} {
+		gen, err := GetGenerator(fileType)
+		require.NoError(t, err)
+		assert.Equal(t, fileType, gen.SupportedType())
+
+		err = gen.Generate(context.Background(), "src", "dest", &models.Book{}, &models.File{})
+		var genErr *GenerationError
+		require.ErrorAs(t, err, &genErr)
+		assert.True(t, errors.Is(err, ErrNotImplemented))
+
+		assert.False(t, SupportsKepub(fileType))
+	}
+}
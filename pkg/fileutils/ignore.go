@@ -0,0 +1,237 @@
+package fileutils
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ShishoIgnoreFilename is the name of the per-directory ignore file. Its
+// patterns use gitignore syntax and are evaluated relative to the directory
+// the file lives in.
+const ShishoIgnoreFilename = ".shishoignore"
+
+// ignoreRule is a single compiled pattern from a .shishoignore file.
+type ignoreRule struct {
+	re      *regexp.Regexp
+	negate  bool // pattern started with "!" and re-includes matching paths
+	dirOnly bool // pattern ended with "/" and only matches directories
+	// anchored patterns contain a slash and are matched against the full path
+	// relative to the ignore file's directory. Unanchored patterns match the
+	// base name at any depth.
+	anchored bool
+}
+
+// matches reports whether rel (slash-separated, relative to the directory of
+// the ignore file that defined the rule) is matched by the rule.
+func (r ignoreRule) matches(rel string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if r.anchored {
+		return r.re.MatchString(rel)
+	}
+	return r.re.MatchString(rel[strings.LastIndex(rel, "/")+1:])
+}
+
+// IgnoreMatcher evaluates .shishoignore files found in a library tree. Rules
+// are loaded lazily and cached per directory (including the "no ignore file"
+// result), so a scan reads each .shishoignore at most once. Safe for
+// concurrent use by the parallel scan workers.
+type IgnoreMatcher struct {
+	mu    sync.Mutex
+	rules map[string][]ignoreRule // dir → compiled rules (nil when no file)
+}
+
+// NewIgnoreMatcher returns a matcher with an empty rule cache.
+func NewIgnoreMatcher() *IgnoreMatcher {
+	return &IgnoreMatcher{rules: make(map[string][]ignoreRule)}
+}
+
+// rulesForDir returns the compiled rules for dir's .shishoignore, loading and
+// caching them on first access. Unreadable ignore files are treated as empty.
+func (m *IgnoreMatcher) rulesForDir(dir string) []ignoreRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if rules, ok := m.rules[dir]; ok {
+		return rules
+	}
+	rules := loadIgnoreRules(filepath.Join(dir, ShishoIgnoreFilename))
+	m.rules[dir] = rules
+	return rules
+}
+
+// Match reports whether path is excluded by the .shishoignore files between
+// root and path's parent directory. Ancestor directories are not checked, so
+// this is intended for tree walks that already skip ignored directories. Use
+// IsIgnored for standalone paths.
+func (m *IgnoreMatcher) Match(root, path string, isDir bool) bool {
+	parts, ok := RelativeParts(root, path)
+	if !ok {
+		return false
+	}
+	return m.matchParts(root, parts, isDir)
+}
+
+// IsIgnored reports whether path is excluded, either directly or because one
+// of its ancestor directories (below root) is excluded. As with gitignore, a
+// file inside an ignored directory can't be re-included by a negated pattern.
+func (m *IgnoreMatcher) IsIgnored(root, path string, isDir bool) bool {
+	parts, ok := RelativeParts(root, path)
+	if !ok {
+		return false
+	}
+	for i := 1; i < len(parts); i++ {
+		if m.matchParts(root, parts[:i], true) {
+			return true
+		}
+	}
+	return m.matchParts(root, parts, isDir)
+}
+
+// matchParts evaluates every ignore file from root down to the entry's parent
+// directory. Later (deeper) rules override earlier ones, and within a file the
+// last matching rule wins.
+func (m *IgnoreMatcher) matchParts(root string, parts []string, isDir bool) bool {
+	ignored := false
+	dir := root
+	for i := range parts {
+		if i > 0 {
+			dir = filepath.Join(dir, parts[i-1])
+		}
+		rules := m.rulesForDir(dir)
+		if len(rules) == 0 {
+			continue
+		}
+		rel := strings.Join(parts[i:], "/")
+		for _, rule := range rules {
+			if rule.matches(rel, isDir) {
+				ignored = !rule.negate
+			}
+		}
+	}
+	return ignored
+}
+
+// RelativeParts splits path into its components relative to root. Returns
+// false when path is root itself or lies outside of it.
+func RelativeParts(root, path string) ([]string, bool) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, false
+	}
+	return strings.Split(filepath.ToSlash(rel), "/"), true
+}
+
+// loadIgnoreRules reads and compiles the ignore file at path. Returns nil if
+// the file doesn't exist or can't be read.
+func loadIgnoreRules(path string) []ignoreRule {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreLine(scanner.Text()); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// parseIgnoreLine compiles a single gitignore-style line. Returns false for
+// blank lines, comments, and patterns that fail to compile.
+func parseIgnoreLine(line string) (ignoreRule, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	// Trailing spaces are ignored unless escaped with a backslash.
+	if !strings.HasSuffix(line, "\\ ") {
+		line = strings.TrimRight(line, " ")
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+	switch {
+	case strings.HasPrefix(line, "!"):
+		rule.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, "\\!"), strings.HasPrefix(line, "\\#"):
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+	// A slash at the beginning or in the middle anchors the pattern to the
+	// ignore file's directory. A leading "**/" is equivalent to no anchor.
+	if strings.Contains(line, "/") {
+		rule.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+
+	re, err := regexp.Compile("^" + ignoreGlobToRegexp(line) + "$")
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.re = re
+	return rule, true
+}
+
+// ignoreGlobToRegexp translates a gitignore glob into a regular expression
+// fragment. "*" and "?" never match a slash, "**" spans directories, and
+// character classes are passed through (with "[!...]" negation).
+func ignoreGlobToRegexp(pattern string) string {
+	var sb strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				// "**/" matches zero or more leading directories.
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					sb.WriteString("(?:.*/)?")
+					continue
+				}
+				sb.WriteString(".*")
+				continue
+			}
+			sb.WriteString("[^/]*")
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + strings.ReplaceAll(class, "\\", "\\\\") + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+				continue
+			}
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,129 @@
+package fileutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeIgnoreFile(t *testing.T, dir, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ShishoIgnoreFilename), []byte(content), 0600))
+}
+
+func TestIgnoreMatcher_Patterns(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	writeIgnoreFile(t, root, `# working files
+*.tmp
+\#literal.epub
+duplicates/
+/Top Level.epub
+drafts/**/*.epub
+!drafts/keep/*.epub
+*.bak
+!important.bak
+`)
+
+	m := NewIgnoreMatcher()
+	tests := []struct {
+		name  string
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"unanchored glob matches at root", "notes.tmp", false, true},
+		{"unanchored glob matches at any depth", "Author/Book/notes.tmp", false, true},
+		{"comment lines are ignored", "# working files", false, false},
+		{"escaped hash is literal", "#literal.epub", false, true},
+		{"dir-only pattern matches directory", "Author/duplicates", true, true},
+		{"dir-only pattern does not match file", "Author/duplicates", false, false},
+		{"leading slash anchors to ignore file dir", "Top Level.epub", false, true},
+		{"anchored pattern doesn't match deeper", "Author/Top Level.epub", false, false},
+		{"double star spans directories", "drafts/a/b/book.epub", false, true},
+		{"double star matches zero directories", "drafts/book.epub", false, true},
+		{"negation re-includes", "drafts/keep/book.epub", false, false},
+		{"later rule wins", "important.bak", false, false},
+		{"other bak still ignored", "old.bak", false, true},
+		{"unmatched file", "Author/Book/book.epub", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := m.Match(root, filepath.Join(root, filepath.FromSlash(tt.path)), tt.isDir)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIgnoreMatcher_NestedFilesAreRelative(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	writeIgnoreFile(t, root, "*.cbz\n")
+	writeIgnoreFile(t, filepath.Join(root, "Comics"), "!*.cbz\n/scans\n")
+
+	m := NewIgnoreMatcher()
+	assert.True(t, m.Match(root, filepath.Join(root, "Other", "a.cbz"), false))
+	// A deeper ignore file overrides its parent.
+	assert.False(t, m.Match(root, filepath.Join(root, "Comics", "a.cbz"), false))
+	// Anchored patterns are relative to the nested ignore file's directory.
+	assert.True(t, m.Match(root, filepath.Join(root, "Comics", "scans"), true))
+	assert.False(t, m.Match(root, filepath.Join(root, "scans"), true))
+}
+
+func TestIgnoreMatcher_IsIgnoredChecksAncestors(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	writeIgnoreFile(t, root, "duplicates/\n!*.epub\n")
+
+	m := NewIgnoreMatcher()
+	path := filepath.Join(root, "duplicates", "Book", "book.epub")
+	// Match only looks at the entry itself; the walk handles ancestors via SkipDir.
+	assert.False(t, m.Match(root, path, false))
+	// Files inside an ignored directory can't be re-included.
+	assert.True(t, m.IsIgnored(root, path, false))
+	assert.False(t, m.IsIgnored(root, filepath.Join(root, "Book", "book.epub"), false))
+}
+
+func TestIgnoreMatcher_ReincludeInsideIgnoredDirectory(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	writeIgnoreFile(t, root, "drafts/**\n!drafts/keep/\n!drafts/keep/*.epub\n")
+
+	m := NewIgnoreMatcher()
+	// The re-included directory is walked, so the scan reaches its files.
+	assert.False(t, m.IsIgnored(root, filepath.Join(root, "drafts", "keep"), true))
+	assert.False(t, m.IsIgnored(root, filepath.Join(root, "drafts", "keep", "book.epub"), false))
+	assert.True(t, m.IsIgnored(root, filepath.Join(root, "drafts", "keep", "book.pdf"), false))
+	assert.True(t, m.IsIgnored(root, filepath.Join(root, "drafts", "other", "book.epub"), false))
+	assert.True(t, m.IsIgnored(root, filepath.Join(root, "drafts", "book.epub"), false))
+}
+
+func TestIgnoreMatcher_PathOutsideRoot(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	writeIgnoreFile(t, root, "*\n")
+
+	m := NewIgnoreMatcher()
+	assert.False(t, m.IsIgnored(root, root, true))
+	assert.False(t, m.IsIgnored(root, filepath.Join(filepath.Dir(root), "elsewhere.epub"), false))
+}
+
+func TestIgnoreMatcher_CachesRulesPerDirectory(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	writeIgnoreFile(t, root, "*.tmp\n")
+
+	m := NewIgnoreMatcher()
+	path := filepath.Join(root, "notes.tmp")
+	require.True(t, m.Match(root, path, false))
+
+	// Changes on disk aren't picked up mid-scan; rules are compiled once.
+	writeIgnoreFile(t, root, "")
+	assert.True(t, m.Match(root, path, false))
+	assert.False(t, NewIgnoreMatcher().Match(root, path, false))
+}
@@ -18,22 +18,36 @@ type OrganizedNameOptions struct {
 	SeriesNumber     *float64
 	SeriesNumberUnit *string // for CBZ: models.SeriesNumberUnitVolume or models.SeriesNumberUnitChapter; nil treated as volume
 	FileType         string  // for determining number formatting
+	Subfolder        string  // Folder under the library root that new organized folders go into (e.g. "Audiobooks"); empty keeps them at the root
+	KeepFileName     bool    // Keep the file's current name when moving it into an organized folder
+	Sanitize         SanitizeOptions
 }
 
 // GenerateOrganizedFolderName creates a standardized folder name: [Author] Title <number>.
 // For CBZ files, the number is formatted as "v{N}" for volumes or "c{N}" for chapters.
+// When opts.Subfolder is set, the name is prefixed with it (Audiobooks/[Author] Title).
 func GenerateOrganizedFolderName(opts OrganizedNameOptions) string {
+	name := generateOrganizedName(opts)
+	if opts.Subfolder != "" {
+		return filepath.Join(SanitizeFilename(opts.Subfolder, opts.Sanitize), name)
+	}
+	return name
+}
+
+// generateOrganizedName builds the [Author] Title <number> name shared by
+// organized folders and files.
+func generateOrganizedName(opts OrganizedNameOptions) string {
 	var parts []string
 
 	// Add author in brackets if available
 	if len(opts.AuthorNames) > 0 && opts.AuthorNames[0] != "" {
-		author := sanitizeForFilename(opts.AuthorNames[0])
+		author := SanitizeFilename(opts.AuthorNames[0], opts.Sanitize)
 		parts = append(parts, fmt.Sprintf("[%s]", author))
 	}
 
 	// Add title
 	if opts.Title != "" {
-		title := sanitizeForFilename(opts.Title)
+		title := SanitizeFilename(opts.Title, opts.Sanitize)
 		parts = append(parts, title)
 	}
 
@@ -52,6 +66,10 @@ func GenerateOrganizedFolderName(opts OrganizedNameOptions) string {
 
 	name := strings.Join(parts, " ")
 
+	// Each part is already capped, but together they can still exceed what
+	// a filesystem allows for a single entry.
+	name = strings.TrimRight(truncateUTF8(name, maxNameBytes), " .")
+
 	// Ensure we have at least something
 	if name == "" {
 		name = "Unknown"
@@ -63,7 +81,11 @@ func GenerateOrganizedFolderName(opts OrganizedNameOptions) string {
 // GenerateOrganizedFileName creates a standardized filename: Title.ext.
 // For M4B files, includes narrator in braces: Title {Narrator}.m4b.
 // Author names are NOT included since files are already inside author-prefixed folders.
+// With opts.KeepFileName set, the file keeps its current name.
 func GenerateOrganizedFileName(opts OrganizedNameOptions, originalFilepath string) string {
+	if opts.KeepFileName {
+		return filepath.Base(originalFilepath)
+	}
 	ext := filepath.Ext(originalFilepath)
 
 	// For organized files in folders, we don't include series numbers or author names
@@ -74,14 +96,18 @@ func GenerateOrganizedFileName(opts OrganizedNameOptions, originalFilepath strin
 	optsForFilename := opts
 	optsForFilename.SeriesNumber = nil
 	optsForFilename.AuthorNames = nil
-	baseName := GenerateOrganizedFolderName(optsForFilename)
+	baseName := generateOrganizedName(optsForFilename)
 
 	// Add narrator in braces for M4B files
 	if opts.FileType == models.FileTypeM4B && len(opts.NarratorNames) > 0 && opts.NarratorNames[0] != "" {
-		narrator := sanitizeForFilename(opts.NarratorNames[0])
+		narrator := SanitizeFilename(opts.NarratorNames[0], opts.Sanitize)
 		baseName = fmt.Sprintf("%s {%s}", baseName, narrator)
 	}
 
+	if len(baseName)+len(ext) > maxNameBytes {
+		baseName = strings.TrimRight(truncateUTF8(baseName, maxNameBytes-len(ext)), " .")
+	}
+
 	return baseName + ext
 }
 
@@ -106,33 +132,6 @@ func formatSeriesNumber(number float64, unit string, fileType string) string {
 	return fmt.Sprintf("#%.1f", number)
 }
 
-// sanitizeForFilename removes or replaces characters that are not safe for filenames.
-func sanitizeForFilename(name string) string {
-	// Remove/replace problematic characters
-	// Replace various quotes and smart quotes with regular quotes
-	name = regexp.MustCompile(`[""]`).ReplaceAllString(name, `"`)
-	name = regexp.MustCompile(`['']`).ReplaceAllString(name, `'`)
-
-	// Remove or replace characters that are invalid in filenames
-	// Different operating systems have different restrictions, so we'll be conservative
-	invalidChars := regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]`)
-	name = invalidChars.ReplaceAllString(name, "")
-
-	// Replace multiple spaces with single space
-	name = regexp.MustCompile(`\s+`).ReplaceAllString(name, " ")
-
-	// Trim spaces and dots from the ends (Windows doesn't like trailing dots)
-	name = strings.Trim(name, " .")
-
-	// Limit length to reasonable filesystem limits (255 is common, but we'll be conservative)
-	if len(name) > 200 {
-		name = name[:200]
-		name = strings.Trim(name, " .")
-	}
-
-	return name
-}
-
 // IsOrganizedName checks if a filename/foldername follows the organized naming pattern.
 func IsOrganizedName(name string) bool {
 	// Remove extension for analysis
@@ -225,31 +224,75 @@ func extractSeriesNumberFromTitle(title string) (*float64, string) {
 	return &number, unit
 }
 
-// SplitNames splits a string of names by common delimiters (comma and semicolon),
-// trims whitespace from each name, and returns non-empty names.
+// DefaultNameSeparators are what SplitNames splits on when it isn't given
+// any separators.
+var DefaultNameSeparators = []string{",", ";"}
+
+// SplitNames splits a string of names on any of separators, trims whitespace
+// from each name, and returns non-empty names. Separators are matched without
+// regard to case, so " feat. " also splits "A Feat. B". With no separators it
+// uses DefaultNameSeparators (comma and semicolon).
 // This is used for parsing author and narrator lists from metadata.
-func SplitNames(s string) []string {
+func SplitNames(s string, separators ...string) []string {
 	if s == "" {
 		return nil
 	}
+	if len(separators) == 0 {
+		separators = DefaultNameSeparators
+	}
+
+	segments := []string{s}
+	for _, sep := range separators {
+		if sep == "" {
+			continue
+		}
+		var next []string
+		for _, segment := range segments {
+			next = append(next, splitFold(segment, sep)...)
+		}
+		segments = next
+	}
 
-	// Split by both comma and semicolon
 	var parts []string
-	for _, segment := range strings.Split(s, ";") {
-		for _, part := range strings.Split(segment, ",") {
-			if trimmed := strings.TrimSpace(part); trimmed != "" {
-				parts = append(parts, trimmed)
-			}
+	for _, segment := range segments {
+		if trimmed := strings.TrimSpace(segment); trimmed != "" {
+			parts = append(parts, trimmed)
 		}
 	}
 	return parts
 }
 
+// splitFold is strings.Split with sep matched case-insensitively.
+func splitFold(s, sep string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i+len(sep) <= len(s); {
+		if strings.EqualFold(s[i:i+len(sep)], sep) {
+			parts = append(parts, s[start:i])
+			i += len(sep)
+			start = i
+			continue
+		}
+		i++
+	}
+	return append(parts, s[start:])
+}
+
 // ExtractSeriesFromTitle extracts series name and number from a normalized CBZ title.
 // Returns the base title (series name), number, unit (models.SeriesNumberUnitVolume or models.SeriesNumberUnitChapter), and
-// whether extraction succeeded. Only applies to CBZ files with normalized "v{N}"
-// or "c{N}" suffixes.
-func ExtractSeriesFromTitle(title string, fileType string) (seriesName string, number *float64, unit string, ok bool) {
+// whether extraction succeeded. Custom patterns with a series group are tried
+// first, for any file type; their number group is optional and they never set
+// a unit. Otherwise only CBZ titles with normalized "v{N}" or "c{N}" suffixes
+// are recognized.
+func ExtractSeriesFromTitle(title string, fileType string, patterns FilenamePatterns) (seriesName string, number *float64, unit string, ok bool) {
+	if captured := patterns.Match(title, PatternGroupSeries); captured != nil {
+		if raw, hasNumber := captured[PatternGroupNumber]; hasNumber {
+			if parsed, err := strconv.ParseFloat(raw, 64); err == nil {
+				number = &parsed
+			}
+		}
+		return captured[PatternGroupSeries], number, "", true
+	}
 	if fileType != models.FileTypeCBZ {
 		return "", nil, "", false
 	}
@@ -272,3 +315,27 @@ func ExtractSeriesFromTitle(title string, fileType string) (seriesName string, n
 	}
 	return seriesName, &parsed, parsedUnit, true
 }
+
+// seriesPrefixPattern matches titles that lead with their series and number,
+// like "Mistborn #2: The Well of Ascension" or "Mistborn, Book 2 - The Well
+// of Ascension". "Book" and "Volume" need the comma, so "The Jungle Book 2:
+// Mowgli" isn't split, and the number must be followed by a colon or a
+// spaced dash, so "Warriors #1" alone isn't either.
+var seriesPrefixPattern = regexp.MustCompile(`^(.+?)(?:\s+#\s*|,\s+(?i:book|volume|vol\.?)\s+)(\d+(?:\.\d+)?)\s*(?::|\s[-–—])\s*(.+)$`)
+
+// SplitSeriesPrefix splits a title that leads with its series (see
+// seriesPrefixPattern) into the series name, series number, and the rest of
+// the title. ok is false when the title doesn't start with a series.
+func SplitSeriesPrefix(title string) (seriesName string, number *float64, rest string, ok bool) {
+	matches := seriesPrefixPattern.FindStringSubmatch(strings.TrimSpace(title))
+	if matches == nil {
+		return "", nil, "", false
+	}
+	seriesName = strings.TrimSpace(matches[1])
+	rest = strings.TrimSpace(matches[3])
+	parsed, err := strconv.ParseFloat(matches[2], 64)
+	if seriesName == "" || rest == "" || err != nil {
+		return "", nil, "", false
+	}
+	return seriesName, &parsed, rest, true
+}
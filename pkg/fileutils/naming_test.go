@@ -1,9 +1,11 @@
 package fileutils
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNormalizeSeriesNumberInTitle(t *testing.T) {
@@ -112,6 +114,29 @@ func TestSplitNames(t *testing.T) {
 	}
 }
 
+func TestSplitNames_CustomSeparators(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		input      string
+		separators []string
+		expected   []string
+	}{
+		{"slash", "John Doe / Jane Smith", []string{" / "}, []string{"John Doe", "Jane Smith"}},
+		{"several separators", "John Doe / Jane Smith feat. Bob Roe", []string{" / ", " feat. "}, []string{"John Doe", "Jane Smith", "Bob Roe"}},
+		{"case-insensitive", "John Doe Feat. Jane Smith", []string{" feat. "}, []string{"John Doe", "Jane Smith"}},
+		{"replaces the defaults", "Doe, John / Smith, Jane", []string{" / "}, []string{"Doe, John", "Smith, Jane"}},
+		{"drops empties", " / John Doe /  / ", []string{"/"}, []string{"John Doe"}},
+		{"empty separators are ignored", "John Doe, Jane Smith", []string{"", ","}, []string{"John Doe", "Jane Smith"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, SplitNames(tt.input, tt.separators...))
+		})
+	}
+}
+
 func TestFormatSeriesNumber(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -184,7 +209,7 @@ func TestExtractSeriesFromTitle(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			gotSeries, gotNum, gotUnit, gotOK := ExtractSeriesFromTitle(tt.title, tt.fileType)
+			gotSeries, gotNum, gotUnit, gotOK := ExtractSeriesFromTitle(tt.title, tt.fileType, nil)
 			assert.Equal(t, tt.wantSeries, gotSeries)
 			assert.Equal(t, tt.wantUnit, gotUnit)
 			assert.Equal(t, tt.wantOK, gotOK)
@@ -246,3 +271,88 @@ func TestGenerateOrganizedFolderName_TitleAlreadyHasChapter(t *testing.T) {
 	// Title already encodes the number — don't double-stamp.
 	assert.Equal(t, "[Eiichiro Oda] One Piece c042", got)
 }
+
+func TestGenerateOrganizedFolderName_Subfolder(t *testing.T) {
+	t.Parallel()
+	opts := OrganizedNameOptions{
+		AuthorNames:   []string{"Brandon Sanderson"},
+		NarratorNames: []string{"Michael Kramer"},
+		Title:         "Wind and Truth",
+		FileType:      "m4b",
+		Subfolder:     "Audiobooks",
+	}
+	assert.Equal(t, filepath.Join("Audiobooks", "[Brandon Sanderson] Wind and Truth"), GenerateOrganizedFolderName(opts))
+	// The subfolder only applies to the folder, not the file inside it.
+	assert.Equal(t, "Wind and Truth {Michael Kramer}.m4b", GenerateOrganizedFileName(opts, "/lib/wind.m4b"))
+}
+
+func TestGenerateOrganizedFileName_KeepFileName(t *testing.T) {
+	t.Parallel()
+	opts := OrganizedNameOptions{
+		AuthorNames:   []string{"Brandon Sanderson"},
+		NarratorNames: []string{"Michael Kramer"},
+		Title:         "Wind and Truth",
+		FileType:      "m4b",
+		KeepFileName:  true,
+	}
+	assert.Equal(t, "[Brandon Sanderson] Wind and Truth", GenerateOrganizedFolderName(opts))
+	assert.Equal(t, "wind.m4b", GenerateOrganizedFileName(opts, "/lib/wind.m4b"))
+}
+
+func TestExtractSeriesFromTitle_CustomPatterns(t *testing.T) {
+	t.Parallel()
+	patterns, err := CompileFilenamePatterns([]string{`^(?P<series>.+?) #(?P<number>\d+)`})
+	require.NoError(t, err)
+
+	series, num, unit, ok := ExtractSeriesFromTitle("Discworld #7 - Pyramids", "epub", patterns)
+	assert.True(t, ok)
+	assert.Equal(t, "Discworld", series)
+	require.NotNil(t, num)
+	assert.InEpsilon(t, 7.0, *num, 0.0001)
+	assert.Empty(t, unit)
+
+	// Titles the pattern doesn't match fall back to the built-in CBZ rules
+	series, num, unit, ok = ExtractSeriesFromTitle("Naruto v003", "cbz", patterns)
+	assert.True(t, ok)
+	assert.Equal(t, "Naruto", series)
+	require.NotNil(t, num)
+	assert.InEpsilon(t, 3.0, *num, 0.0001)
+	assert.Equal(t, "volume", unit)
+}
+
+func TestSplitSeriesPrefix(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		title      string
+		wantSeries string
+		wantNum    *float64
+		wantRest   string
+		wantOK     bool
+	}{
+		{"hash and colon", "Mistborn #2: The Well of Ascension", "Mistborn", floatPtr(2), "The Well of Ascension", true},
+		{"hash and dash", "The Expanse #3 - Abaddon's Gate", "The Expanse", floatPtr(3), "Abaddon's Gate", true},
+		{"decimal number", "Discworld #1.5: A Tale", "Discworld", floatPtr(1.5), "A Tale", true},
+		{"comma and book", "Mistborn, Book 2: The Well of Ascension", "Mistborn", floatPtr(2), "The Well of Ascension", true},
+		{"comma and volume", "Saga, Vol. 4 – Part Two", "Saga", floatPtr(4), "Part Two", true},
+		{"book without a comma", "The Jungle Book 2: Mowgli", "", nil, "", false},
+		{"no title after the number", "Warriors #1", "", nil, "", false},
+		{"unspaced dash", "Catch #22-Yossarian", "", nil, "", false},
+		{"plain title", "The Well of Ascension", "", nil, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gotSeries, gotNum, gotRest, gotOK := SplitSeriesPrefix(tt.title)
+			assert.Equal(t, tt.wantOK, gotOK)
+			assert.Equal(t, tt.wantSeries, gotSeries)
+			assert.Equal(t, tt.wantRest, gotRest)
+			if tt.wantNum == nil {
+				assert.Nil(t, gotNum)
+			} else {
+				require.NotNil(t, gotNum)
+				assert.InEpsilon(t, *tt.wantNum, *gotNum, 0.0001)
+			}
+		})
+	}
+}
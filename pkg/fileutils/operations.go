@@ -178,24 +178,24 @@ func renameAssociatedFiles(originalPath, newPath string, skipBookSidecar bool) (
 	originalFilename := filepath.Base(originalPath)
 	newFilename := filepath.Base(newPath)
 
-	// Common image extensions for covers
-	imageExtensions := []string{".jpg", ".jpeg", ".png", ".webp", ".gif", ".bmp"}
-
 	renamed := 0
 
-	// Rename individual covers: {filename}.cover.{ext}
-	for _, ext := range imageExtensions {
-		originalCoverName := originalFilename + ".cover" + ext
-		originalCoverPath := filepath.Join(dir, originalCoverName)
+	// Rename individual covers: {filename}.cover.{ext} and
+	// {filename}.cover.original.{ext}
+	for _, suffix := range coverSuffixes {
+		for _, ext := range CoverImageExtensions {
+			originalCoverName := originalFilename + suffix + ext
+			originalCoverPath := filepath.Join(dir, originalCoverName)
 
-		if _, err := os.Stat(originalCoverPath); err == nil {
-			newCoverName := newFilename + ".cover" + ext
-			newCoverPath := filepath.Join(dir, newCoverName)
+			if _, err := os.Stat(originalCoverPath); err == nil {
+				newCoverName := newFilename + suffix + ext
+				newCoverPath := filepath.Join(dir, newCoverName)
 
-			if err := os.Rename(originalCoverPath, newCoverPath); err != nil {
-				return renamed, errors.WithStack(err)
+				if err := os.Rename(originalCoverPath, newCoverPath); err != nil {
+					return renamed, errors.WithStack(err)
+				}
+				renamed++
 			}
-			renamed++
 		}
 	}
 
@@ -227,12 +227,14 @@ func renameAssociatedFiles(originalPath, newPath string, skipBookSidecar bool) (
 	return renamed, nil
 }
 
-// RenameOrganizedFolder renames a folder containing organized files.
+// RenameOrganizedFolder renames a folder containing organized files. The
+// folder stays in its current parent directory, so opts.Subfolder is ignored.
 func RenameOrganizedFolder(currentFolderPath string, opts OrganizedNameOptions) (string, error) {
 	// Get the parent directory
 	parentDir := filepath.Dir(currentFolderPath)
 
 	// Generate new folder name
+	opts.Subfolder = ""
 	newFolderName := GenerateOrganizedFolderName(opts)
 	newFolderPath := filepath.Join(parentDir, newFolderName)
 
@@ -367,29 +369,28 @@ func moveAssociatedCovers(originalFilePath, newFilePath string) (int, error) {
 	originalFilename := filepath.Base(originalFilePath)
 	newFilename := filepath.Base(newFilePath)
 
-	// Common image extensions for covers
-	imageExtensions := []string{".jpg", ".jpeg", ".png", ".webp", ".gif", ".bmp"}
-
 	coversMoved := 0
 
 	// Look for individual covers: {filename}.cover.{ext}
 	// e.g., mybook.epub.cover.jpg for mybook.epub
-	for _, ext := range imageExtensions {
-		originalCoverName := originalFilename + ".cover" + ext
-		originalCoverPath := filepath.Join(originalDir, originalCoverName)
-
-		// Check if this cover exists
-		if _, err := os.Stat(originalCoverPath); err == nil {
-			// Generate the new cover name
-			newCoverName := newFilename + ".cover" + ext
-			newCoverPath := filepath.Join(newDir, newCoverName)
-
-			// Move the cover
-			err := moveFile(originalCoverPath, newCoverPath)
-			if err != nil {
-				return coversMoved, errors.WithStack(err)
+	for _, suffix := range coverSuffixes {
+		for _, ext := range CoverImageExtensions {
+			originalCoverName := originalFilename + suffix + ext
+			originalCoverPath := filepath.Join(originalDir, originalCoverName)
+
+			// Check if this cover exists
+			if _, err := os.Stat(originalCoverPath); err == nil {
+				// Generate the new cover name
+				newCoverName := newFilename + suffix + ext
+				newCoverPath := filepath.Join(newDir, newCoverName)
+
+				// Move the cover
+				err := moveFile(originalCoverPath, newCoverPath)
+				if err != nil {
+					return coversMoved, errors.WithStack(err)
+				}
+				coversMoved++
 			}
-			coversMoved++
 		}
 	}
 
@@ -427,7 +428,7 @@ func getBaseNameWithoutExt(path string) string {
 // ResolveCoverDirForWrite resolves the directory where a cover image should
 // be written. Handles the case where bookFilepath may be a synthetic
 // organized-folder path that does not yet exist on disk — common when
-// scanning root-level files in libraries with OrganizeFileStructure enabled,
+// scanning root-level files in libraries with OrganizeFolders enabled,
 // where the organized directory is created later in the batch. In that case
 // the cover must land alongside the file at filepath.Dir(fileFilepath), which
 // is where extractAndSaveCover wrote the file-embedded cover.
@@ -465,8 +466,46 @@ func ComputeNewCoverFilename(oldCoverFilename, newFilePath string) string {
 	return newFilename + ".cover" + coverExt
 }
 
+// originalCoverSuffix marks the copy of a file's embedded cover that's kept
+// when another cover replaces it (see config.KeepOriginalCover), e.g.
+// book.epub.cover.original.jpg.
+const originalCoverSuffix = ".cover.original"
+
+// coverSuffixes are the suffixes of the cover images that travel with a file
+// when it's renamed or moved.
+var coverSuffixes = []string{".cover", originalCoverSuffix}
+
+// OriginalCoverBaseName returns the base name, without extension, of the
+// original cover kept for filePath (e.g. "mybook.epub.cover.original").
+func OriginalCoverBaseName(filePath string) string {
+	return filepath.Base(filePath) + originalCoverSuffix
+}
+
+// KeepOriginalCover moves the cover at coverPath aside as filePath's original
+// cover, replacing any previous one. It's used before an embedded cover is
+// overwritten by a manual or plugin cover so it can be restored later.
+func KeepOriginalCover(coverPath, filePath string) error {
+	dir := filepath.Dir(coverPath)
+	if err := RemoveOriginalCover(dir, filePath); err != nil {
+		return err
+	}
+	originalPath := filepath.Join(dir, OriginalCoverBaseName(filePath)+filepath.Ext(coverPath))
+	return errors.WithStack(os.Rename(coverPath, originalPath))
+}
+
+// RemoveOriginalCover deletes any original cover kept for filePath in dir.
+func RemoveOriginalCover(dir, filePath string) error {
+	for _, ext := range CoverImageExtensions {
+		path := filepath.Join(dir, OriginalCoverBaseName(filePath)+ext)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
 // CoverImageExtensions contains all supported image extensions for cover files.
-var CoverImageExtensions = []string{".jpg", ".jpeg", ".png", ".webp", ".gif", ".bmp"}
+var CoverImageExtensions = []string{".jpg", ".jpeg", ".png", ".webp", ".avif", ".gif", ".bmp"}
 
 // MimeTypeFromExtension returns the MIME type for a given file extension.
 // Returns empty string if the extension is not recognized.
@@ -478,6 +517,8 @@ func MimeTypeFromExtension(ext string) string {
 		return "image/png"
 	case ".webp":
 		return "image/webp"
+	case ".avif":
+		return "image/avif"
 	case ".gif":
 		return "image/gif"
 	case ".bmp":
@@ -506,6 +547,36 @@ func CoverExistsWithBaseName(dir, baseName string) string {
 	return ""
 }
 
+// ExternalCoverNames are the file names, without extension, recognized as a
+// book's cover image when placed in its folder, in order of preference.
+var ExternalCoverNames = []string{"cover", "folder", "poster", "front"}
+
+// FindExternalCover returns the path of a cover image sitting loose in dir,
+// such as cover.jpg or Folder.png, or "" if there isn't one. Names are matched
+// ignoring case, in ExternalCoverNames order, then by CoverImageExtensions
+// order.
+func FindExternalCover(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	found := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		found[strings.ToLower(entry.Name())] = entry.Name()
+	}
+	for _, name := range ExternalCoverNames {
+		for _, ext := range CoverImageExtensions {
+			if actual, ok := found[name+ext]; ok {
+				return filepath.Join(dir, actual)
+			}
+		}
+	}
+	return ""
+}
+
 // CleanupEmptyDirectory removes a directory if it's empty or only contains ignored files.
 // ignoredPatterns can include glob patterns like ".*" (dotfiles), ".DS_Store", "Thumbs.db", etc.
 // Returns true if the directory was removed, false if it wasn't empty or didn't exist.
@@ -592,7 +663,10 @@ func CleanupEmptyParentDirectories(startPath, stopAt string, ignoredPatterns ...
 // NormalizeImage decodes and re-encodes an image to strip problematic metadata
 // (like gAMA chunks without sRGB in PNG) that cause color rendering issues in browsers.
 // Returns the normalized image data and the new MIME type.
-// If the input is a JPEG, it stays as JPEG to preserve quality. Otherwise, it becomes PNG.
+// If the input is a JPEG, it stays as JPEG to preserve quality. Otherwise (PNG,
+// GIF, WebP, and any other format with a registered decoder), it becomes PNG.
+// If no decoder is registered for the input (e.g. AVIF, which browsers can
+// display directly), the original data and MIME type are returned unchanged.
 func NormalizeImage(data []byte, mimeType string) ([]byte, string, error) {
 	// Decode the image
 	img, _, err := image.Decode(bytes.NewReader(data))
@@ -693,22 +767,24 @@ func moveFileAssociatedFiles(originalFilePath, newFilePath string) (int, error)
 
 	// Look for individual covers: {filename}.cover.{ext}
 	// e.g., mybook.m4b.cover.jpg for mybook.m4b
-	for _, ext := range CoverImageExtensions {
-		originalCoverName := originalFilename + ".cover" + ext
-		originalCoverPath := filepath.Join(originalDir, originalCoverName)
-
-		// Check if this cover exists
-		if _, err := os.Stat(originalCoverPath); err == nil {
-			// Generate the new cover name
-			newCoverName := newFilename + ".cover" + ext
-			newCoverPath := filepath.Join(newDir, newCoverName)
-
-			// Move the cover
-			err := moveFile(originalCoverPath, newCoverPath)
-			if err != nil {
-				return moved, errors.WithStack(err)
+	for _, suffix := range coverSuffixes {
+		for _, ext := range CoverImageExtensions {
+			originalCoverName := originalFilename + suffix + ext
+			originalCoverPath := filepath.Join(originalDir, originalCoverName)
+
+			// Check if this cover exists
+			if _, err := os.Stat(originalCoverPath); err == nil {
+				// Generate the new cover name
+				newCoverName := newFilename + suffix + ext
+				newCoverPath := filepath.Join(newDir, newCoverName)
+
+				// Move the cover
+				err := moveFile(originalCoverPath, newCoverPath)
+				if err != nil {
+					return moved, errors.WithStack(err)
+				}
+				moved++
 			}
-			moved++
 		}
 	}
 
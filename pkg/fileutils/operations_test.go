@@ -1,11 +1,15 @@
 package fileutils
 
 import (
+	"bytes"
+	"image"
+	"image/gif"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCoverExistsWithBaseName(t *testing.T) {
@@ -459,6 +463,40 @@ func TestRenameOrganizedFile_SupplementDoesNotRenameBookSidecar(t *testing.T) {
 	assert.True(t, os.IsNotExist(err), "book sidecar should NOT have been renamed to supplement's basename")
 }
 
+func TestFindExternalCover(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		files    []string
+		expected string
+	}{
+		{"no cover", []string{"book.cbz", "notes.txt"}, ""},
+		{"cover.jpg", []string{"book.cbz", "cover.jpg"}, "cover.jpg"},
+		{"case-insensitive", []string{"book.cbz", "Folder.PNG"}, "Folder.PNG"},
+		{"cover preferred over folder", []string{"folder.jpg", "cover.webp"}, "cover.webp"},
+		{"generated covers ignored", []string{"book.cbz", "book.cbz.cover.jpg"}, ""},
+		{"non-image ignored", []string{"cover.txt"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			for _, f := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, f), []byte("x"), 0644))
+			}
+			got := FindExternalCover(dir)
+			if tt.expected == "" {
+				assert.Empty(t, got)
+			} else {
+				assert.Equal(t, filepath.Join(dir, tt.expected), got)
+			}
+		})
+	}
+
+	assert.Empty(t, FindExternalCover(filepath.Join(t.TempDir(), "missing")))
+}
+
 func TestCleanupEmptyDirectory(t *testing.T) {
 	t.Parallel()
 	tempDir, err := os.MkdirTemp("", "cleanup-empty-dir-test-*")
@@ -764,6 +802,23 @@ func TestMoveFileWithAssociatedFiles(t *testing.T) {
 				"Book.m4b.metadata.json",
 			},
 		},
+		{
+			name:         "moves file with its original cover",
+			originalFile: "Novel.epub",
+			associatedFiles: []string{
+				"Novel.epub.cover.png",
+				"Novel.epub.cover.original.jpg",
+			},
+			expectMoved: []string{
+				"Novel.epub",
+				"Novel.epub.cover.png",
+				"Novel.epub.cover.original.jpg",
+			},
+			expectGone: []string{
+				"Novel.epub.cover.png",
+				"Novel.epub.cover.original.jpg",
+			},
+		},
 		{
 			name:         "moves file with multiple cover formats",
 			originalFile: "Comic.cbz",
@@ -918,8 +973,71 @@ func TestResolveCoverDirForWrite(t *testing.T) {
 			t.Fatalf("failed to write test file: %v", err)
 		}
 		// Synthetic bookPath (never created on disk), e.g. for a root-level
-		// new file in a library with OrganizeFileStructure enabled.
+		// new file in a library with organizing enabled.
 		syntheticBookPath := filepath.Join(libraryDir, "Author", "Title")
 		assert.Equal(t, libraryDir, ResolveCoverDirForWrite(syntheticBookPath, filePath))
 	})
 }
+
+func TestMimeTypeFromExtension(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "image/jpeg", MimeTypeFromExtension(".JPG"))
+	assert.Equal(t, "image/webp", MimeTypeFromExtension(".webp"))
+	assert.Equal(t, "image/avif", MimeTypeFromExtension(".avif"))
+	assert.Empty(t, MimeTypeFromExtension(".heic"))
+
+	// Every cover extension must map to a MIME type so existing covers on
+	// disk are served and adopted correctly.
+	for _, ext := range CoverImageExtensions {
+		assert.NotEmpty(t, MimeTypeFromExtension(ext), ext)
+	}
+}
+
+func TestNormalizeImage(t *testing.T) {
+	t.Parallel()
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	var gifBuf bytes.Buffer
+	require.NoError(t, gif.Encode(&gifBuf, img, nil))
+
+	t.Run("decodable non-jpeg is re-encoded as png", func(t *testing.T) {
+		t.Parallel()
+		data, mimeType, err := NormalizeImage(gifBuf.Bytes(), "image/gif")
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", mimeType)
+		_, format, err := image.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, "png", format)
+	})
+
+	t.Run("undecodable avif keeps original bytes", func(t *testing.T) {
+		t.Parallel()
+		// ftyp box header for an AVIF file; no decoder is registered for it.
+		avif := []byte("\x00\x00\x00\x1cftypavif\x00\x00\x00\x00avifmif1miaf")
+		data, mimeType, err := NormalizeImage(avif, "image/avif")
+		require.NoError(t, err)
+		assert.Equal(t, "image/avif", mimeType)
+		assert.Equal(t, avif, data)
+	})
+}
+
+func TestKeepOriginalCover(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "book.epub")
+	coverPath := filepath.Join(dir, "book.epub.cover.jpg")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "book.epub.cover.original.png"), []byte("older"), 0644))
+	require.NoError(t, os.WriteFile(coverPath, []byte("embedded"), 0644))
+
+	require.NoError(t, KeepOriginalCover(coverPath, filePath))
+
+	assert.NoFileExists(t, coverPath)
+	assert.NoFileExists(t, filepath.Join(dir, "book.epub.cover.original.png"), "a previous original is replaced")
+	data, err := os.ReadFile(filepath.Join(dir, "book.epub.cover.original.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, "embedded", string(data))
+	assert.Empty(t, CoverExistsWithBaseName(dir, "book.epub.cover"))
+
+	require.NoError(t, RemoveOriginalCover(dir, filePath))
+	assert.NoFileExists(t, filepath.Join(dir, "book.epub.cover.original.jpg"))
+}
@@ -0,0 +1,91 @@
+package fileutils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Named capture groups a filename pattern can use.
+const (
+	PatternGroupAuthor   = "author"
+	PatternGroupNarrator = "narrator"
+	PatternGroupSeries   = "series"
+	PatternGroupNumber   = "number"
+	PatternGroupTitle    = "title"
+)
+
+var patternGroups = map[string]struct{}{
+	PatternGroupAuthor:   {},
+	PatternGroupNarrator: {},
+	PatternGroupSeries:   {},
+	PatternGroupNumber:   {},
+	PatternGroupTitle:    {},
+}
+
+// FilenamePatterns are a library's custom regexes for pulling metadata out of
+// file and folder names (without extensions). They're consulted in order,
+// ahead of the built-in [Author] and {Narrator} conventions.
+type FilenamePatterns []*regexp.Regexp
+
+// CompileFilenamePatterns compiles a library's filename patterns. Every
+// pattern must be a valid regex with at least one named capture group, and
+// only the author, narrator, series, number, and title groups are allowed.
+func CompileFilenamePatterns(patterns []string) (FilenamePatterns, error) {
+	compiled := make(FilenamePatterns, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Errorf("invalid filename pattern %q: %s", pattern, strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+		}
+		named := false
+		for _, name := range re.SubexpNames() {
+			if name == "" {
+				continue
+			}
+			if _, ok := patternGroups[name]; !ok {
+				return nil, errors.Errorf("invalid filename pattern %q: unknown group %q (use author, narrator, series, number, or title)", pattern, name)
+			}
+			named = true
+		}
+		if !named {
+			return nil, errors.Errorf("invalid filename pattern %q: needs at least one named group like (?P<author>...)", pattern)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// Match returns the named groups captured from name by the first pattern
+// that matches it and captures group, or nil if none does. Empty captures
+// are left out.
+func (p FilenamePatterns) Match(name, group string) map[string]string {
+	for _, re := range p {
+		idx := re.SubexpIndex(group)
+		if idx < 0 {
+			continue
+		}
+		matches := re.FindStringSubmatch(name)
+		if matches == nil || strings.TrimSpace(matches[idx]) == "" {
+			continue
+		}
+		captured := make(map[string]string)
+		for i, groupName := range re.SubexpNames() {
+			if groupName == "" {
+				continue
+			}
+			if value := strings.TrimSpace(matches[i]); value != "" {
+				captured[groupName] = value
+			}
+		}
+		return captured
+	}
+	return nil
+}
+
+// Find returns the value group captured from name by the first pattern that
+// matches it and captures group, or "" if none does.
+func (p FilenamePatterns) Find(name, group string) string {
+	return p.Match(name, group)[group]
+}
@@ -0,0 +1,46 @@
+package fileutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileFilenamePatterns_Invalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		pattern string
+		wantErr string
+	}{
+		{"bad syntax", `(?P<author>[^)]+`, "missing closing )"},
+		{"no named group", `^\((.+)\) - (.+)$`, "needs at least one named group"},
+		{"unknown group", `^(?P<writer>.+) - (?P<title>.+)$`, `unknown group "writer"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := CompileFilenamePatterns([]string{tt.pattern})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFilenamePatterns_Find(t *testing.T) {
+	t.Parallel()
+	patterns, err := CompileFilenamePatterns([]string{
+		`^\((?P<author>[^)]+)\) - (?P<title>.+)$`,
+		`^(?P<title>.+) read by (?P<narrator>.+)$`,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Ursula K. Le Guin", patterns.Find("(Ursula K. Le Guin) - A Wizard of Earthsea", PatternGroupAuthor))
+	assert.Equal(t, "A Wizard of Earthsea", patterns.Find("(Ursula K. Le Guin) - A Wizard of Earthsea", PatternGroupTitle))
+	// The first pattern that captures the group wins
+	assert.Equal(t, "Dune", patterns.Find("Dune read by Scott Brick", PatternGroupTitle))
+	assert.Equal(t, "Scott Brick", patterns.Find("Dune read by Scott Brick", PatternGroupNarrator))
+	assert.Empty(t, patterns.Find("Dune read by Scott Brick", PatternGroupAuthor))
+	assert.Empty(t, FilenamePatterns(nil).Find("anything", PatternGroupTitle))
+}
@@ -0,0 +1,173 @@
+package fileutils
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Filename sanitization modes.
+const (
+	// SanitizeModeLenient strips characters that are invalid on common
+	// filesystems and otherwise keeps the name as-is. This is the default.
+	SanitizeModeLenient = "lenient"
+	// SanitizeModeStrict additionally drops emoji and other control/format
+	// characters, normalizes to NFC, and avoids reserved Windows device names,
+	// for libraries stored on SMB shares, FAT/exFAT drives and the like.
+	SanitizeModeStrict = "strict"
+)
+
+// DefaultFilenameMaxBytes is the byte limit applied to each sanitized name
+// component when SanitizeOptions.MaxBytes is unset. It leaves headroom under
+// the usual 255-byte filesystem limit for extensions and the extra pieces an
+// organized name is built from.
+const DefaultFilenameMaxBytes = 200
+
+// maxNameBytes is the common per-entry filesystem limit that a full organized
+// file or folder name must fit in.
+const maxNameBytes = 255
+
+var (
+	invalidFilenameChar = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]`)
+	whitespaceRun       = regexp.MustCompile(`\s+`)
+	windowsDrivePrefix  = regexp.MustCompile(`^[A-Za-z]:`)
+)
+
+// windowsReservedNames are device names Windows won't allow as a file or
+// folder name, with or without an extension.
+var windowsReservedNames = map[string]struct{}{
+	"CON": {}, "PRN": {}, "AUX": {}, "NUL": {},
+	"COM1": {}, "COM2": {}, "COM3": {}, "COM4": {}, "COM5": {}, "COM6": {}, "COM7": {}, "COM8": {}, "COM9": {},
+	"LPT1": {}, "LPT2": {}, "LPT3": {}, "LPT4": {}, "LPT5": {}, "LPT6": {}, "LPT7": {}, "LPT8": {}, "LPT9": {},
+}
+
+// SanitizeOptions controls SanitizeFilename.
+type SanitizeOptions struct {
+	Mode     string // SanitizeModeLenient (default when empty) or SanitizeModeStrict
+	MaxBytes int    // 0 uses DefaultFilenameMaxBytes
+}
+
+func (o SanitizeOptions) maxBytes() int {
+	if o.MaxBytes > 0 {
+		return o.MaxBytes
+	}
+	return DefaultFilenameMaxBytes
+}
+
+// SanitizeFilename makes name safe to use as a single file or folder name
+// component. In every mode it removes path separators, characters Windows
+// rejects (<>:"|?*) and ASCII control characters, collapses whitespace,
+// trims leading/trailing spaces and dots, and truncates to the byte limit
+// without splitting a UTF-8 character. Strict mode also applies the extra
+// rules described on SanitizeModeStrict.
+func SanitizeFilename(name string, opts SanitizeOptions) string {
+	strict := opts.Mode == SanitizeModeStrict
+
+	if strict {
+		name = norm.NFC.String(name)
+	}
+
+	// Remove characters that are invalid in filenames. Different operating
+	// systems have different restrictions, so we'll be conservative.
+	name = invalidFilenameChar.ReplaceAllString(name, "")
+
+	if strict {
+		name = strings.Map(func(r rune) rune {
+			if isStrictRemovedRune(r) {
+				return -1
+			}
+			return r
+		}, name)
+	}
+
+	name = whitespaceRun.ReplaceAllString(name, " ")
+
+	// Trim spaces and dots from the ends (Windows doesn't like trailing dots)
+	name = strings.Trim(name, " .")
+
+	name = truncateUTF8(name, opts.maxBytes())
+	name = strings.Trim(name, " .")
+
+	if strict && isWindowsReservedName(name) {
+		name += "_"
+	}
+
+	return name
+}
+
+// SanitizeRelativePath makes rel, a path from outside Shisho such as one a
+// plugin organizer chose, safe to join to a library path. Both / and \ are
+// treated as separators and each component is sanitized with
+// SanitizeFilename. Absolute paths and ".." components are rejected rather
+// than cleaned up, since they can only mean the path was meant to leave the
+// library.
+func SanitizeRelativePath(rel string, opts SanitizeOptions) (string, error) {
+	if strings.HasPrefix(rel, "/") || strings.HasPrefix(rel, `\`) || windowsDrivePrefix.MatchString(rel) {
+		return "", errors.Errorf("path %q is absolute", rel)
+	}
+
+	parts := strings.FieldsFunc(rel, func(r rune) bool { return r == '/' || r == '\\' })
+	cleaned := make([]string, 0, len(parts))
+	for _, part := range parts {
+		switch part {
+		case ".":
+			continue
+		case "..":
+			return "", errors.Errorf("path %q leaves the library", rel)
+		}
+		name := SanitizeFilename(part, opts)
+		if name == "" {
+			return "", errors.Errorf("path %q has a component that's empty once sanitized", rel)
+		}
+		cleaned = append(cleaned, name)
+	}
+	if len(cleaned) == 0 {
+		return "", errors.Errorf("path %q is empty", rel)
+	}
+	return filepath.Join(cleaned...), nil
+}
+
+// isStrictRemovedRune reports whether strict mode drops r: emoji and other
+// pictographic symbols (plus the joiners, variation selectors and skin-tone
+// modifiers that build emoji sequences), and any remaining control or format
+// characters.
+func isStrictRemovedRune(r rune) bool {
+	switch {
+	case unicode.Is(unicode.So, r):
+		return true
+	case r == 0x200d, r >= 0xfe00 && r <= 0xfe0f, r >= 0x1f3fb && r <= 0x1f3ff:
+		return true
+	case unicode.IsControl(r), unicode.Is(unicode.Cf, r):
+		return true
+	}
+	return false
+}
+
+// isWindowsReservedName reports whether name (ignoring any extension) is a
+// reserved Windows device name such as CON or LPT1.
+func isWindowsReservedName(name string) bool {
+	base := name
+	if i := strings.IndexByte(base, '.'); i >= 0 {
+		base = base[:i]
+	}
+	_, ok := windowsReservedNames[strings.ToUpper(strings.TrimSpace(base))]
+	return ok
+}
+
+// truncateUTF8 shortens s to at most maxBytes bytes, backing off to the
+// previous character boundary so a multi-byte character is never split.
+func truncateUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
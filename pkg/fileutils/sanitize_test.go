@@ -0,0 +1,113 @@
+package fileutils
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeFilename(t *testing.T) {
+	t.Parallel()
+	lenient := SanitizeOptions{}
+	strict := SanitizeOptions{Mode: SanitizeModeStrict}
+	tests := []struct {
+		name string
+		in   string
+		opts SanitizeOptions
+		want string
+	}{
+		// Lenient keeps the historical behavior
+		{"strips invalid characters", `Title: A/B\C <Part> "1"?*|`, lenient, "Title ABC Part 1"},
+		{"strips ascii control characters", "Title\x00\x07\x1f", lenient, "Title"},
+		{"collapses whitespace", "  Title \t  Two  ", lenient, "Title Two"},
+		{"trims trailing dots and spaces", "Title. . ", lenient, "Title"},
+		{"keeps emoji in lenient", "Title 📚", lenient, "Title 📚"},
+		{"keeps reserved names in lenient", "CON", lenient, "CON"},
+		{"keeps unicode quotes", "It’s “Quoted”", lenient, "It’s “Quoted”"},
+		// Strict extras
+		{"drops emoji in strict", "Title 📚 ✨", strict, "Title"},
+		{"drops emoji sequences in strict", "Family 👨‍👩‍👧 👍🏽", strict, "Family"},
+		{"drops format characters in strict", "Ti\u200btle\u00ad", strict, "Title"},
+		{"normalizes to NFC in strict", "Cafe\u0301", strict, "Caf\u00e9"},
+		{"suffixes reserved name", "CON", strict, "CON_"},
+		{"suffixes reserved name case-insensitively", "lpt1", strict, "lpt1_"},
+		{"suffixes reserved name with extension", "nul.txt", strict, "nul.txt_"},
+		{"leaves names that only start with a reserved word", "Console", strict, "Console"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, SanitizeFilename(tt.in, tt.opts))
+		})
+	}
+}
+
+func TestSanitizeFilename_TruncatesWithoutSplittingRunes(t *testing.T) {
+	t.Parallel()
+
+	// "é" is two bytes, so a 5-byte limit falls in the middle of the third one.
+	got := SanitizeFilename("ééééé", SanitizeOptions{MaxBytes: 5})
+	assert.Equal(t, "éé", got)
+	assert.True(t, utf8.ValidString(got))
+
+	got = SanitizeFilename(strings.Repeat("日本", 100), SanitizeOptions{})
+	assert.LessOrEqual(t, len(got), DefaultFilenameMaxBytes)
+	assert.True(t, utf8.ValidString(got))
+}
+
+func TestSanitizeFilename_TrimsAfterTruncation(t *testing.T) {
+	t.Parallel()
+	got := SanitizeFilename("abcd. efgh", SanitizeOptions{MaxBytes: 6})
+	assert.Equal(t, "abcd", got)
+}
+
+func TestGenerateOrganizedFileName_FitsFilesystemLimit(t *testing.T) {
+	t.Parallel()
+	// Title and narrator are each within the per-component limit, but not
+	// together.
+	got := GenerateOrganizedFileName(OrganizedNameOptions{
+		NarratorNames: []string{strings.Repeat("Narrator ", 40)},
+		Title:         strings.Repeat("Long Title ", 40),
+		FileType:      "m4b",
+	}, "/library/book.m4b")
+	assert.LessOrEqual(t, len(got), 255)
+	assert.True(t, strings.HasSuffix(got, ".m4b"))
+	assert.True(t, utf8.ValidString(got))
+}
+
+func TestSanitizeRelativePath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "nested path", in: "Fantasy/Brandon Sanderson/Mistborn.epub", want: filepath.Join("Fantasy", "Brandon Sanderson", "Mistborn.epub")},
+		{name: "backslashes", in: `Fantasy\Mistborn.epub`, want: filepath.Join("Fantasy", "Mistborn.epub")},
+		{name: "sanitizes components", in: "Who? What:/Title*.epub", want: filepath.Join("Who What", "Title.epub")},
+		{name: "drops dot and empty components", in: "./Fantasy//Mistborn.epub", want: filepath.Join("Fantasy", "Mistborn.epub")},
+		{name: "absolute", in: "/etc/passwd", wantErr: true},
+		{name: "windows absolute", in: `C:\books\a.epub`, wantErr: true},
+		{name: "parent escape", in: "Fantasy/../../a.epub", wantErr: true},
+		{name: "component empty after sanitizing", in: "Fantasy/???/a.epub", wantErr: true},
+		{name: "empty", in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := SanitizeRelativePath(tt.in, SanitizeOptions{})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
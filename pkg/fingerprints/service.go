@@ -64,6 +64,31 @@ func (svc *Service) FindFilesByHash(ctx context.Context, libraryID int, algorith
 	return files, nil
 }
 
+// FindMainFileByHash returns the earliest-created main file in the library
+// whose fingerprint for the given algorithm matches value, or nil if there is
+// none. Used for identical-file dedupe.
+func (svc *Service) FindMainFileByHash(ctx context.Context, libraryID int, algorithm, value string) (*models.File, error) {
+	file := new(models.File)
+	err := svc.db.
+		NewSelect().
+		Model(file).
+		Join("JOIN file_fingerprints AS ffp ON ffp.file_id = f.id").
+		Where("ffp.algorithm = ?", algorithm).
+		Where("ffp.value = ?", value).
+		Where("f.library_id = ?", libraryID).
+		Where("f.file_role = ?", models.FileRoleMain).
+		Order("f.id ASC").
+		Limit(1).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return file, nil
+}
+
 // DeleteForFile removes all fingerprints for a file. Called when a file's
 // content changes (size/mtime mismatch during rescan) so the next hash
 // generation job recomputes a fresh fingerprint.
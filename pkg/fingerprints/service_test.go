@@ -197,6 +197,32 @@ func TestFindFilesByHash_DifferentLibrary(t *testing.T) {
 	assert.Empty(t, found, "files from a different library should not be returned")
 }
 
+// TestFindMainFileByHash verifies that only matching main files in the given
+// library are returned.
+func TestFindMainFileByHash(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	ctx := context.Background()
+	svc := fingerprints.NewService(db)
+
+	lib1 := insertTestLibrary(t, db, "Library 1")
+	file1 := insertTestFile(t, db, insertTestBook(t, db, lib1))
+	lib2 := insertTestLibrary(t, db, "Library 2")
+
+	hash := "deadbeef1234"
+	require.NoError(t, svc.Insert(ctx, file1.ID, models.FingerprintAlgorithmSHA256, hash))
+
+	found, err := svc.FindMainFileByHash(ctx, lib1.ID, models.FingerprintAlgorithmSHA256, hash)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, file1.ID, found.ID)
+
+	// Files in other libraries are never returned.
+	found, err = svc.FindMainFileByHash(ctx, lib2.ID, models.FingerprintAlgorithmSHA256, hash)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
 // TestFindFilesByHash_UnknownHash verifies that an empty slice is returned when
 // no file matches the hash.
 func TestFindFilesByHash_UnknownHash(t *testing.T) {
@@ -275,6 +275,49 @@ func (h *handler) merge(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+func (h *handler) mergeVariants(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params := MergeGenreVariantsPayload{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Check library access
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(params.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	merged, err := h.genreService.MergeVariants(ctx, params.LibraryID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Remove the merged genres from the FTS index and re-index the ones they
+	// were merged into, which picked up their aliases
+	log := logger.FromContext(ctx)
+	targets := map[int]struct{}{}
+	for sourceID, targetID := range merged {
+		if err := h.searchService.DeleteFromGenreIndex(ctx, sourceID); err != nil {
+			log.Warn("failed to remove merged genre from search index", logger.Data{"genre_id": sourceID, "error": err.Error()})
+		}
+		targets[targetID] = struct{}{}
+	}
+	for targetID := range targets {
+		genre, err := h.genreService.RetrieveGenre(ctx, RetrieveGenreOptions{ID: &targetID})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := h.searchService.IndexGenre(ctx, genre); err != nil {
+			log.Warn("failed to re-index target genre after merge", logger.Data{"genre_id": genre.ID, "error": err.Error()})
+		}
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, MergeGenreVariantsResponse{Merged: len(merged)}))
+}
+
 func (h *handler) deleteGenre(c echo.Context) error {
 	ctx := c.Request().Context()
 	id, err := strconv.Atoi(c.Param("id"))
@@ -82,14 +82,26 @@ func (svc *Service) RetrieveGenre(ctx context.Context, opts RetrieveGenreOptions
 	return genre, nil
 }
 
-// FindOrCreateGenre finds an existing genre or creates a new one (case-insensitive match).
-func (svc *Service) FindOrCreateGenre(ctx context.Context, name string, libraryID int) (*models.Genre, error) {
-	// Normalize the name by trimming whitespace
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return nil, errors.New("genre name cannot be empty")
+// Normalize resolves name to the name of the genre it refers to in the
+// library, so that spelling variants collapse to one canonical genre. Names
+// that don't match any existing genre are returned with their spacing cleaned
+// up.
+func (svc *Service) Normalize(ctx context.Context, name string, libraryID int) (string, error) {
+	name = aliases.NormalizeName(name)
+	genre, err := svc.findCanonicalGenre(ctx, name, libraryID)
+	if err != nil {
+		if errors.Is(err, errcodes.NotFound("Genre")) {
+			return name, nil
+		}
+		return "", err
 	}
+	return genre.Name, nil
+}
 
+// findCanonicalGenre finds the genre that name refers to, trying in order: a
+// genre with the same name (case-insensitive), a genre with a matching alias,
+// and a genre whose name only differs in case, spaces, hyphens or underscores.
+func (svc *Service) findCanonicalGenre(ctx context.Context, name string, libraryID int) (*models.Genre, error) {
 	genre, err := svc.RetrieveGenre(ctx, RetrieveGenreOptions{
 		Name:      &name,
 		LibraryID: &libraryID,
@@ -106,6 +118,33 @@ func (svc *Service) FindOrCreateGenre(ctx context.Context, name string, libraryI
 		return svc.RetrieveGenre(ctx, RetrieveGenreOptions{ID: &resourceID})
 	}
 
+	// Check spelling variants
+	resourceID, err := aliases.FindResourceIDByMatchKey(ctx, svc.db, aliases.GenreConfig, name, libraryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcodes.NotFound("Genre")
+		}
+		return nil, errors.WithStack(err)
+	}
+	return svc.RetrieveGenre(ctx, RetrieveGenreOptions{ID: &resourceID})
+}
+
+// FindOrCreateGenre finds the canonical genre for name (see Normalize) or
+// creates a new one.
+func (svc *Service) FindOrCreateGenre(ctx context.Context, name string, libraryID int) (*models.Genre, error) {
+	name = aliases.NormalizeName(name)
+	if name == "" {
+		return nil, errors.New("genre name cannot be empty")
+	}
+
+	genre, err := svc.findCanonicalGenre(ctx, name, libraryID)
+	if err == nil {
+		return genre, nil
+	}
+	if !errors.Is(err, errcodes.NotFound("Genre")) {
+		return nil, err
+	}
+
 	// Create new genre
 	genre = &models.Genre{
 		LibraryID: libraryID,
@@ -305,6 +344,39 @@ func (svc *Service) MergeGenres(ctx context.Context, targetID, sourceID int) err
 	})
 }
 
+// MergeVariants merges the genres in a library whose names are spelling
+// variants of each other (see aliases.MatchKey) into the oldest one. It
+// returns the ID each merged genre was merged into, keyed by the merged genre's
+// ID, so callers can update the search index.
+func (svc *Service) MergeVariants(ctx context.Context, libraryID int) (map[int]int, error) {
+	var genres []*models.Genre
+	err := svc.db.NewSelect().
+		Model(&genres).
+		Column("id", "name").
+		Where("library_id = ?", libraryID).
+		Order("id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	merged := map[int]int{}
+	keepers := map[string]int{}
+	for _, genre := range genres {
+		key := aliases.MatchKey(genre.Name)
+		keeperID, ok := keepers[key]
+		if !ok {
+			keepers[key] = genre.ID
+			continue
+		}
+		if err := svc.MergeGenres(ctx, keeperID, genre.ID); err != nil {
+			return merged, err
+		}
+		merged[genre.ID] = keeperID
+	}
+	return merged, nil
+}
+
 // CleanupOrphanedGenres deletes genres with no book associations and returns
 // the IDs of deleted genres. Callers must pass the returned IDs to
 // searchService.DeleteFromGenreIndex to keep genres_fts in sync — genres_fts
@@ -189,3 +189,76 @@ func TestFindOrCreateGenre_AliasMatch_LibraryScoped(t *testing.T) {
 	assert.Equal(t, "Sci-Fi", found.Name)
 	assert.Equal(t, lib2.ID, found.LibraryID)
 }
+
+func TestFindOrCreateGenre_SpellingVariantMatch(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	lib := createTestLibrary(t, db)
+
+	genre := &models.Genre{LibraryID: lib.ID, Name: "Sci-Fi"}
+	err := svc.CreateGenre(ctx, genre)
+	require.NoError(t, err)
+
+	for _, variant := range []string{"SciFi", "sci fi", "sci_fi", "  SCI-FI "} {
+		found, err := svc.FindOrCreateGenre(ctx, variant, lib.ID)
+		require.NoError(t, err, variant)
+		assert.Equal(t, genre.ID, found.ID, variant)
+	}
+
+	name, err := svc.Normalize(ctx, "scifi", lib.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Sci-Fi", name)
+
+	// Variants are only matched within the same library.
+	other := createTestLibrary(t, db)
+	found, err := svc.FindOrCreateGenre(ctx, "SciFi", other.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, genre.ID, found.ID)
+}
+
+func TestNormalizeGenre_NoMatch(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	svc := NewService(db)
+
+	lib := createTestLibrary(t, db)
+
+	name, err := svc.Normalize(context.Background(), "  Space   Opera ", lib.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Space Opera", name)
+}
+
+func TestMergeGenreVariants_ScopedToLibrary(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	lib := createTestLibrary(t, db)
+	other := createTestLibrary(t, db)
+
+	create := func(libraryID int, name string) *models.Genre {
+		genre := &models.Genre{LibraryID: libraryID, Name: name}
+		require.NoError(t, svc.CreateGenre(ctx, genre))
+		return genre
+	}
+	keeper := create(lib.ID, "Sci-Fi")
+	variant := create(lib.ID, "scifi")
+	unrelated := create(lib.ID, "Fantasy")
+	otherKeeper := create(other.ID, "Sci-Fi")
+	otherVariant := create(other.ID, "Sci Fi")
+
+	merged, err := svc.MergeVariants(ctx, lib.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{variant.ID: keeper.ID}, merged)
+
+	_, err = svc.RetrieveGenre(ctx, RetrieveGenreOptions{ID: &variant.ID})
+	require.Error(t, err)
+	for _, id := range []int{keeper.ID, unrelated.ID, otherKeeper.ID, otherVariant.ID} {
+		_, err := svc.RetrieveGenre(ctx, RetrieveGenreOptions{ID: &id})
+		require.NoError(t, err)
+	}
+}
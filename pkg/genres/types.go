@@ -44,3 +44,12 @@ type UpdateGenrePayload struct {
 type MergeGenresPayload struct {
 	SourceID int `json:"source_id" validate:"required,min=1"`
 }
+
+type MergeGenreVariantsPayload struct {
+	LibraryID int `json:"library_id" validate:"required,min=1"`
+}
+
+// MergeGenreVariantsResponse reports how many genres were merged away.
+type MergeGenreVariantsResponse struct {
+	Merged int `json:"merged"`
+}
@@ -0,0 +1,125 @@
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// Component statuses reported by /readyz.
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// checkTimeout bounds how long /readyz waits on the database checks, so a
+// wedged database fails the probe instead of hanging it.
+const checkTimeout = 5 * time.Second
+
+// WorkerState is the part of the worker that readiness reports on.
+type WorkerState interface {
+	Running() bool
+	InFlightJobs() int
+}
+
+// JobCounter counts jobs by status.
+type JobCounter interface {
+	CountJobsByStatus(ctx context.Context, status string) (int, error)
+}
+
+// Handler serves the liveness and readiness probes.
+type Handler struct {
+	pingDB   func(ctx context.Context) error
+	checkFTS func() error
+	worker   WorkerState
+	jobs     JobCounter
+}
+
+// NewHandler returns a probe handler. pingDB and checkFTS check the
+// database and its FTS5 support; worker and jobs report on job processing.
+func NewHandler(pingDB func(ctx context.Context) error, checkFTS func() error, worker WorkerState, jobs JobCounter) *Handler {
+	return &Handler{
+		pingDB:   pingDB,
+		checkFTS: checkFTS,
+		worker:   worker,
+		jobs:     jobs,
+	}
+}
+
+// ComponentStatus is the result of a single readiness check.
+type ComponentStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// WorkerStatus is the worker's readiness check, along with its job counts.
+type WorkerStatus struct {
+	ComponentStatus
+	Running      bool `json:"running"`
+	QueuedJobs   int  `json:"queued_jobs"`
+	InFlightJobs int  `json:"in_flight_jobs"`
+}
+
+// ReadyResponse is returned from GET /readyz.
+type ReadyResponse struct {
+	Status   string          `json:"status"`
+	Database ComponentStatus `json:"database"`
+	FTS5     ComponentStatus `json:"fts5"`
+	Worker   WorkerStatus    `json:"worker"`
+}
+
+func (h *Handler) healthz(c echo.Context) error {
+	return errors.WithStack(c.JSON(http.StatusOK, map[string]string{"status": StatusOK}))
+}
+
+func (h *Handler) readyz(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), checkTimeout)
+	defer cancel()
+
+	resp := ReadyResponse{
+		Status:   StatusOK,
+		Database: componentStatus(h.pingDB(ctx)),
+	}
+
+	// The FTS5 check and job count need a working database, so they're
+	// reported as failed rather than run when the ping fails.
+	if resp.Database.Status == StatusOK {
+		resp.FTS5 = componentStatus(h.checkFTS())
+	} else {
+		resp.FTS5 = ComponentStatus{Status: StatusError, Error: "database unavailable"}
+	}
+
+	resp.Worker = WorkerStatus{
+		ComponentStatus: ComponentStatus{Status: StatusOK},
+		Running:         h.worker.Running(),
+		InFlightJobs:    h.worker.InFlightJobs(),
+	}
+	if !resp.Worker.Running {
+		resp.Worker.ComponentStatus = ComponentStatus{Status: StatusError, Error: "worker is not running"}
+	}
+	if resp.Database.Status == StatusOK {
+		queued, err := h.jobs.CountJobsByStatus(ctx, models.JobStatusPending)
+		if err != nil && resp.Worker.Status == StatusOK {
+			resp.Worker.ComponentStatus = componentStatus(err)
+		}
+		resp.Worker.QueuedJobs = queued
+	}
+
+	code := http.StatusOK
+	if resp.Database.Status != StatusOK || resp.FTS5.Status != StatusOK || resp.Worker.Status != StatusOK {
+		resp.Status = StatusError
+		code = http.StatusServiceUnavailable
+	}
+	return errors.WithStack(c.JSON(code, resp))
+}
+
+func componentStatus(err error) ComponentStatus {
+	if err != nil {
+		return ComponentStatus{Status: StatusError, Error: err.Error()}
+	}
+	return ComponentStatus{Status: StatusOK}
+}
@@ -0,0 +1,123 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWorker struct {
+	running  bool
+	inFlight int
+}
+
+func (f *fakeWorker) Running() bool     { return f.running }
+func (f *fakeWorker) InFlightJobs() int { return f.inFlight }
+
+type fakeJobs struct {
+	queued int
+	err    error
+}
+
+func (f *fakeJobs) CountJobsByStatus(_ context.Context, _ string) (int, error) {
+	return f.queued, f.err
+}
+
+func ok(context.Context) error { return nil }
+
+func runReadyz(t *testing.T, h *Handler) (int, ReadyResponse) {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.readyz(e.NewContext(req, rec)))
+
+	var resp ReadyResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec.Code, resp
+}
+
+func TestHealthz(t *testing.T) {
+	t.Parallel()
+	h := NewHandler(nil, nil, nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.healthz(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestReadyz_AllChecksPass(t *testing.T) {
+	t.Parallel()
+	h := NewHandler(ok, func() error { return nil }, &fakeWorker{running: true, inFlight: 1}, &fakeJobs{queued: 3})
+
+	code, resp := runReadyz(t, h)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, StatusOK, resp.Status)
+	assert.Equal(t, StatusOK, resp.Database.Status)
+	assert.Equal(t, StatusOK, resp.FTS5.Status)
+	assert.Equal(t, StatusOK, resp.Worker.Status)
+	assert.True(t, resp.Worker.Running)
+	assert.Equal(t, 3, resp.Worker.QueuedJobs)
+	assert.Equal(t, 1, resp.Worker.InFlightJobs)
+}
+
+func TestReadyz_DatabaseDown(t *testing.T) {
+	t.Parallel()
+	ftsCalled := false
+	h := NewHandler(
+		func(context.Context) error { return errors.New("database is locked") },
+		func() error { ftsCalled = true; return nil },
+		&fakeWorker{running: true},
+		&fakeJobs{},
+	)
+
+	code, resp := runReadyz(t, h)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusError, resp.Status)
+	assert.Equal(t, StatusError, resp.Database.Status)
+	assert.Equal(t, "database is locked", resp.Database.Error)
+	assert.Equal(t, StatusError, resp.FTS5.Status)
+	assert.False(t, ftsCalled)
+}
+
+func TestReadyz_FTS5Unavailable(t *testing.T) {
+	t.Parallel()
+	h := NewHandler(ok, func() error { return errors.New("no fts5") }, &fakeWorker{running: true}, &fakeJobs{})
+
+	code, resp := runReadyz(t, h)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusOK, resp.Database.Status)
+	assert.Equal(t, StatusError, resp.FTS5.Status)
+	assert.Equal(t, "no fts5", resp.FTS5.Error)
+}
+
+func TestReadyz_WorkerNotRunning(t *testing.T) {
+	t.Parallel()
+	h := NewHandler(ok, func() error { return nil }, &fakeWorker{running: false}, &fakeJobs{queued: 2})
+
+	code, resp := runReadyz(t, h)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusError, resp.Worker.Status)
+	assert.False(t, resp.Worker.Running)
+	assert.Equal(t, 2, resp.Worker.QueuedJobs)
+}
+
+func TestReadyz_JobCountFails(t *testing.T) {
+	t.Parallel()
+	h := NewHandler(ok, func() error { return nil }, &fakeWorker{running: true}, &fakeJobs{err: errors.New("count failed")})
+
+	code, resp := runReadyz(t, h)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusError, resp.Worker.Status)
+	assert.Equal(t, "count failed", resp.Worker.Error)
+}
@@ -0,0 +1,12 @@
+package healthcheck
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes registers the unauthenticated probe routes: GET /healthz
+// (liveness) and GET /readyz (readiness).
+func RegisterRoutes(e *echo.Echo, h *Handler) {
+	e.GET("/healthz", h.healthz)
+	e.GET("/readyz", h.readyz)
+}
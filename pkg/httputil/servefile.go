@@ -0,0 +1,30 @@
+package httputil
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// ServeFile writes the file at path to w with byte-range support, so media
+// players can seek without downloading the whole file. Range, If-Range and
+// conditional requests are handled by http.ServeContent using the file's
+// size and modification time; a Content-Type already set on w is kept.
+func ServeFile(w http.ResponseWriter, r *http.Request, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	w.Header().Set("Accept-Ranges", "bytes")
+	http.ServeContent(w, r, filepath.Base(path), info.ModTime(), f)
+	return nil
+}
@@ -0,0 +1,54 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "book.m4b")
+	require.NoError(t, os.WriteFile(path, []byte("0123456789abcdefghij"), 0600))
+
+	t.Run("full file", func(t *testing.T) {
+		t.Parallel()
+		rr := httptest.NewRecorder()
+		require.NoError(t, ServeFile(rr, httptest.NewRequest(http.MethodGet, "/", nil), path))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "bytes", rr.Header().Get("Accept-Ranges"))
+		assert.Equal(t, "0123456789abcdefghij", rr.Body.String())
+	})
+
+	t.Run("mid-file range", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Range", "bytes=5-9")
+		rr := httptest.NewRecorder()
+		require.NoError(t, ServeFile(rr, req, path))
+		assert.Equal(t, http.StatusPartialContent, rr.Code)
+		assert.Equal(t, "bytes 5-9/20", rr.Header().Get("Content-Range"))
+		assert.Equal(t, "56789", rr.Body.String())
+	})
+
+	t.Run("keeps preset content type", func(t *testing.T) {
+		t.Parallel()
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Type", "audio/mp4")
+		require.NoError(t, ServeFile(rr, httptest.NewRequest(http.MethodGet, "/", nil), path))
+		assert.Equal(t, "audio/mp4", rr.Header().Get("Content-Type"))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+		rr := httptest.NewRecorder()
+		err := ServeFile(rr, httptest.NewRequest(http.MethodGet, "/", nil), filepath.Join(t.TempDir(), "nope"))
+		require.Error(t, err)
+	})
+}
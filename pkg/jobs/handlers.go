@@ -4,16 +4,20 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 	"github.com/segmentio/encoding/json"
+	"github.com/shishobooks/shisho/pkg/cbz"
 	"github.com/shishobooks/shisho/pkg/downloadcache"
 	"github.com/shishobooks/shisho/pkg/errcodes"
 	"github.com/shishobooks/shisho/pkg/events"
 	"github.com/shishobooks/shisho/pkg/httputil"
 	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/thumbnails"
 	"github.com/uptrace/bun"
 )
 
@@ -42,6 +46,22 @@ func (h *handler) create(c echo.Context) error {
 		if hasActive {
 			return errcodes.Conflict("A scan job is already running or pending.")
 		}
+
+		dataBytes, err := json.Marshal(params.Data)
+		if err != nil {
+			return errcodes.BadRequest("Invalid scan data")
+		}
+		var scanData models.JobScanData
+		if err := json.Unmarshal(dataBytes, &scanData); err != nil {
+			return errcodes.BadRequest("Invalid scan data")
+		}
+		if scanData.DirPath != "" {
+			if err := h.validateScanDirPath(c, params.LibraryID, scanData.DirPath); err != nil {
+				return err
+			}
+			scanData.DirPath = filepath.Clean(scanData.DirPath)
+		}
+		params.Data = &scanData
 	}
 
 	// Validate bulk download jobs: require books:read permission and non-empty file_ids.
@@ -68,6 +88,125 @@ func (h *handler) create(c echo.Context) error {
 		}
 	}
 
+	// Validate thumbnail backfill jobs and allow only one per library at a time.
+	if params.Type == models.JobTypeThumbnailBackfill {
+		dataBytes, err := json.Marshal(params.Data)
+		if err != nil {
+			return errcodes.BadRequest("Invalid thumbnail backfill data")
+		}
+		var backfillData models.JobThumbnailBackfillData
+		if err := json.Unmarshal(dataBytes, &backfillData); err != nil {
+			return errcodes.BadRequest("Invalid thumbnail backfill data")
+		}
+		if backfillData.LibraryID <= 0 {
+			return errcodes.BadRequest("A library ID is required for thumbnail backfill")
+		}
+		if backfillData.MaxDim < 0 || backfillData.MaxDim > thumbnails.MaxDim {
+			return errcodes.BadRequest(fmt.Sprintf("max_dim must be between 1 and %d", thumbnails.MaxDim))
+		}
+		// Checkpoints are written by the worker, so don't trust them from the request.
+		backfillData.LastFileID = 0
+		backfillData.Generated = 0
+		params.Data = &backfillData
+		params.LibraryID = &backfillData.LibraryID
+
+		hasActive, err := h.jobService.HasActiveJob(ctx, models.JobTypeThumbnailBackfill, params.LibraryID)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if hasActive {
+			return errcodes.Conflict("A thumbnail backfill job is already running or pending for this library.")
+		}
+	}
+
+	// Validate sort title jobs and allow only one per library at a time.
+	if params.Type == models.JobTypeRegenerateSortTitles {
+		dataBytes, err := json.Marshal(params.Data)
+		if err != nil {
+			return errcodes.BadRequest("Invalid sort title data")
+		}
+		var sortData models.JobRegenerateSortTitlesData
+		if err := json.Unmarshal(dataBytes, &sortData); err != nil {
+			return errcodes.BadRequest("Invalid sort title data")
+		}
+		if sortData.LibraryID <= 0 {
+			return errcodes.BadRequest("A library ID is required to regenerate sort titles")
+		}
+		// The result is written by the worker, so don't trust it from the request.
+		sortData.Updated = 0
+		params.Data = &sortData
+		params.LibraryID = &sortData.LibraryID
+
+		hasActive, err := h.jobService.HasActiveJob(ctx, models.JobTypeRegenerateSortTitles, params.LibraryID)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if hasActive {
+			return errcodes.Conflict("A sort title job is already running or pending for this library.")
+		}
+	}
+
+	// Validate comic page recompression jobs. They rewrite or copy library
+	// files, so they need books:write and an explicit confirmation.
+	if params.Type == models.JobTypeRecompressComicPages {
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			return errcodes.Unauthorized("User not found in context")
+		}
+		if !user.HasPermission(models.ResourceBooks, models.OperationWrite) {
+			return errcodes.Forbidden("Recompressing comic pages without books:write permission")
+		}
+
+		dataBytes, err := json.Marshal(params.Data)
+		if err != nil {
+			return errcodes.BadRequest("Invalid recompress data")
+		}
+		var recompressData models.JobRecompressComicPagesData
+		if err := json.Unmarshal(dataBytes, &recompressData); err != nil {
+			return errcodes.BadRequest("Invalid recompress data")
+		}
+		if len(recompressData.FileIDs) == 0 {
+			return errcodes.BadRequest("No file IDs provided for recompression")
+		}
+		// Verify the user has library access for every file. Query the DB
+		// directly to avoid an import cycle (jobs cannot import books).
+		var files []*models.File
+		err = h.db.NewSelect().
+			Model(&files).
+			Column("id", "library_id").
+			Where("id IN (?)", bun.In(recompressData.FileIDs)).
+			Scan(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, file := range files {
+			if !user.HasLibraryAccess(file.LibraryID) {
+				return errcodes.Forbidden("Recompressing files in libraries without permission")
+			}
+		}
+		switch recompressData.Format {
+		case "", "jpeg":
+			recompressData.Format = "jpeg"
+		case "webp":
+			return errcodes.BadRequest("WebP output isn't supported yet, use jpeg")
+		default:
+			return errcodes.BadRequest("format must be jpeg")
+		}
+		if recompressData.Quality < 0 || recompressData.Quality > 100 {
+			return errcodes.BadRequest(fmt.Sprintf("quality must be between 1 and 100, or 0 for the default of %d", cbz.DefaultRecompressQuality))
+		}
+		if recompressData.MinPageBytes < 0 {
+			return errcodes.BadRequest("min_page_bytes can't be negative")
+		}
+		if !recompressData.Confirm {
+			return errcodes.BadRequest("Recompressing comic pages rewrites files; set confirm to true to continue")
+		}
+		// Results are written by the worker, so don't trust them from the request.
+		recompressData.Results = nil
+		recompressData.BytesSaved = 0
+		params.Data = &recompressData
+	}
+
 	job := &models.Job{
 		Type:       params.Type,
 		Status:     models.JobStatusPending,
@@ -111,6 +250,28 @@ func (h *handler) retrieve(c echo.Context) error {
 	return errors.WithStack(c.JSON(http.StatusOK, job))
 }
 
+func (h *handler) report(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Job")
+	}
+
+	// Unknown jobs 404 as a job, not as a missing report.
+	if _, err := h.jobService.RetrieveJob(ctx, RetrieveJobOptions{
+		ID: &id,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	report, err := h.jobService.RetrieveScanReport(ctx, id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, report))
+}
+
 func (h *handler) list(c echo.Context) error {
 	ctx := c.Request().Context()
 
@@ -201,3 +362,39 @@ func (h *handler) download(c echo.Context) error {
 
 	return c.File(zipPath)
 }
+
+// validateScanDirPath checks that a directory scan targets a directory inside
+// one of the paths of a library the user can access.
+func (h *handler) validateScanDirPath(c echo.Context, libraryID *int, dirPath string) error {
+	if libraryID == nil {
+		return errcodes.BadRequest("A library ID is required to scan a directory")
+	}
+	user, ok := c.Get("user").(*models.User)
+	if !ok {
+		return errcodes.Unauthorized("User not found in context")
+	}
+	if !user.HasLibraryAccess(*libraryID) {
+		return errcodes.Forbidden("Scanning a library without permission")
+	}
+	if !filepath.IsAbs(dirPath) {
+		return errcodes.BadRequest("dir_path must be an absolute path")
+	}
+
+	// Query the DB directly to avoid an import cycle (jobs cannot import libraries).
+	var libraryPaths []*models.LibraryPath
+	err := h.db.NewSelect().
+		Model(&libraryPaths).
+		Where("library_id = ?", *libraryID).
+		Scan(c.Request().Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	dir := filepath.Clean(dirPath)
+	for _, lp := range libraryPaths {
+		rel, err := filepath.Rel(filepath.Clean(lp.Filepath), dir)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil
+		}
+	}
+	return errcodes.BadRequest("dir_path must be inside one of the library's paths")
+}
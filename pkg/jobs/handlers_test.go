@@ -3,11 +3,14 @@ package jobs
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/labstack/echo/v4"
+	"github.com/shishobooks/shisho/pkg/errcodes"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -64,3 +67,117 @@ func TestList_ResponseUsesItemsTotalEnvelope(t *testing.T) {
 	assert.GreaterOrEqual(t, len(resp.Items), 2)
 	assert.GreaterOrEqual(t, resp.Total, 2)
 }
+
+// TestCreate_RecompressComicPagesRequiresLibraryAccess asserts that a
+// recompression job can only be created for files in libraries the user can
+// access.
+func TestCreate_RecompressComicPagesRequiresLibraryAccess(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	allowed := insertTestLibrary(t, db, "Allowed")
+	denied := insertTestLibrary(t, db, "Denied")
+
+	fileIDs := make(map[int]int)
+	for _, lib := range []*models.Library{allowed, denied} {
+		book := &models.Book{
+			LibraryID:       lib.ID,
+			Title:           lib.Name,
+			TitleSource:     models.DataSourceFilepath,
+			SortTitle:       lib.Name,
+			SortTitleSource: models.DataSourceFilepath,
+			AuthorSource:    models.DataSourceFilepath,
+			Filepath:        "/tmp/" + lib.Name,
+		}
+		_, err := db.NewInsert().Model(book).Exec(ctx)
+		require.NoError(t, err)
+		file := &models.File{
+			LibraryID:     lib.ID,
+			BookID:        book.ID,
+			FileType:      models.FileTypeCBZ,
+			FileRole:      models.FileRoleMain,
+			Filepath:      "/tmp/" + lib.Name + "/comic.cbz",
+			FilesizeBytes: 100,
+		}
+		_, err = db.NewInsert().Model(file).Exec(ctx)
+		require.NoError(t, err)
+		fileIDs[lib.ID] = file.ID
+	}
+
+	user := &models.User{
+		ID: 1,
+		Role: &models.Role{
+			Permissions: []*models.Permission{
+				{Resource: models.ResourceBooks, Operation: models.OperationWrite},
+			},
+		},
+		LibraryAccess: []*models.UserLibraryAccess{{UserID: 1, LibraryID: &allowed.ID}},
+	}
+
+	h := &handler{jobService: NewService(db), db: db}
+	e := echo.New()
+	create := func(fileIDs ...int) error {
+		ids, err := json.Marshal(fileIDs)
+		require.NoError(t, err)
+		body := fmt.Sprintf(`{"type":"recompress_comic_pages","data":{"file_ids":%s,"confirm":true}}`, ids)
+		req := httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.Set("user", user)
+		return h.create(c)
+	}
+
+	err := create(fileIDs[allowed.ID], fileIDs[denied.ID])
+	var codeErr *errcodes.Error
+	require.ErrorAs(t, err, &codeErr)
+	assert.Equal(t, http.StatusForbidden, codeErr.HTTPCode)
+
+	require.NoError(t, create(fileIDs[allowed.ID]))
+}
+
+// TestCreate_ScanDirPathMustBeInsideLibrary asserts that a directory scan
+// job is only created for a directory inside one of its library's paths.
+func TestCreate_ScanDirPathMustBeInsideLibrary(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	lib := insertTestLibrary(t, db, "Books")
+	_, err := db.NewInsert().Model(&models.LibraryPath{LibraryID: lib.ID, Filepath: "/media/books"}).Exec(ctx)
+	require.NoError(t, err)
+
+	user := &models.User{
+		ID:            1,
+		LibraryAccess: []*models.UserLibraryAccess{{UserID: 1, LibraryID: &lib.ID}},
+	}
+
+	h := &handler{jobService: NewService(db), db: db}
+	e := echo.New()
+	create := func(dirPath string) error {
+		body := fmt.Sprintf(`{"type":"scan","library_id":%d,"data":{"dir_path":%q}}`, lib.ID, dirPath)
+		req := httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.Set("user", user)
+		return h.create(c)
+	}
+
+	for _, dirPath := range []string{"/media/other", "/media/books/../other", "relative/dir"} {
+		err := create(dirPath)
+		var codeErr *errcodes.Error
+		require.ErrorAs(t, err, &codeErr, dirPath)
+		assert.Equal(t, http.StatusBadRequest, codeErr.HTTPCode, dirPath)
+	}
+
+	require.NoError(t, create("/media/books/Author/"))
+
+	var job models.Job
+	require.NoError(t, db.NewSelect().Model(&job).Where("type = ?", models.JobTypeScan).Scan(ctx))
+	require.NoError(t, job.UnmarshalData())
+	data, ok := job.DataParsed.(*models.JobScanData)
+	require.True(t, ok)
+	assert.Equal(t, "/media/books/Author", data.DirPath)
+}
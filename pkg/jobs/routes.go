@@ -23,5 +23,6 @@ func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, authMiddleware *auth.Mid
 	g.GET("", h.list)
 	g.GET("/:id", h.retrieve)
 	g.GET("/:id/download", h.download)
+	g.GET("/:id/report", h.report)
 	g.POST("", h.create, authMiddleware.RequirePermission(models.ResourceJobs, models.OperationWrite))
 }
@@ -195,6 +195,18 @@ func (svc *Service) HasActiveJob(ctx context.Context, jobType string, libraryID
 	return count > 0, nil
 }
 
+// CountJobsByStatus returns the number of jobs with the given status.
+func (svc *Service) CountJobsByStatus(ctx context.Context, status string) (int, error) {
+	count, err := svc.db.NewSelect().
+		Model((*models.Job)(nil)).
+		Where("status = ?", status).
+		Count(ctx)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return count, nil
+}
+
 func (svc *Service) UpdateJob(ctx context.Context, job *models.Job, opts UpdateJobOptions) error {
 	if len(opts.Columns) == 0 {
 		return nil
@@ -221,6 +233,69 @@ func (svc *Service) UpdateJob(ctx context.Context, job *models.Job, opts UpdateJ
 	return nil
 }
 
+// CreateScanReport saves the summary of a finished scan job.
+func (svc *Service) CreateScanReport(ctx context.Context, report *models.ScanReport) error {
+	if report.CreatedAt.IsZero() {
+		report.CreatedAt = time.Now()
+	}
+
+	if report.Errors == "" && len(report.ErrorsParsed) > 0 {
+		data, err := json.Marshal(report.ErrorsParsed)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		report.Errors = string(data)
+	}
+	if report.SkipReasons == "" && len(report.SkipReasonsParsed) > 0 {
+		data, err := json.Marshal(report.SkipReasonsParsed)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		report.SkipReasons = string(data)
+	}
+	if report.Warnings == "" && len(report.WarningsParsed) > 0 {
+		data, err := json.Marshal(report.WarningsParsed)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		report.Warnings = string(data)
+	}
+
+	_, err := svc.db.
+		NewInsert().
+		Model(report).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// RetrieveScanReport returns the scan report recorded for a job.
+func (svc *Service) RetrieveScanReport(ctx context.Context, jobID int) (*models.ScanReport, error) {
+	report := &models.ScanReport{}
+
+	err := svc.db.
+		NewSelect().
+		Model(report).
+		Where("sr.job_id = ?", jobID).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcodes.NotFound("Scan report")
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	if err := report.UnmarshalErrors(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return report, nil
+}
+
 // CleanupOldJobs deletes completed and failed jobs older than the retention period.
 // Associated job_logs are deleted automatically via ON DELETE CASCADE.
 func (svc *Service) CleanupOldJobs(ctx context.Context, retentionDays int) (int64, error) {
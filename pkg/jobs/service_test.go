@@ -371,3 +371,72 @@ func TestListJobs_FilterByLibraryIDOrGlobal(t *testing.T) {
 	assert.True(t, foundLib1, "should include library 1 job")
 	assert.False(t, foundLib2, "should not include library 2 job")
 }
+
+func TestScanReport_RoundTrip(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	svc := NewService(db)
+	ctx := context.Background()
+
+	job := &models.Job{Type: models.JobTypeScan, Status: models.JobStatusCompleted, DataParsed: &models.JobScanData{}}
+	require.NoError(t, svc.CreateJob(ctx, job))
+
+	err := svc.CreateScanReport(ctx, &models.ScanReport{
+		JobID:             job.ID,
+		BooksCreated:      2,
+		FilesCreated:      3,
+		FilesErrored:      1,
+		ErrorsParsed:      []models.ScanReportError{{Path: "/books/broken.epub", Error: "zip: not a valid zip file"}},
+		SkipReasonsParsed: map[string]int{models.ScanSkipReasonIgnored: 4},
+		WarningsParsed:    []models.ScanReportWarning{{Path: "/books/a.metadata.json", Warning: "sidecar ignored"}},
+	})
+	require.NoError(t, err)
+
+	report, err := svc.RetrieveScanReport(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.BooksCreated)
+	assert.Equal(t, 3, report.FilesCreated)
+	assert.Equal(t, 1, report.FilesErrored)
+	require.Len(t, report.ErrorsParsed, 1)
+	assert.Equal(t, "/books/broken.epub", report.ErrorsParsed[0].Path)
+	assert.Equal(t, map[string]int{models.ScanSkipReasonIgnored: 4}, report.SkipReasonsParsed)
+	assert.Equal(t, []models.ScanReportWarning{{Path: "/books/a.metadata.json", Warning: "sidecar ignored"}}, report.WarningsParsed)
+}
+
+func TestRetrieveScanReport_NotFound(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	svc := NewService(db)
+
+	_, err := svc.RetrieveScanReport(context.Background(), 999)
+	require.Error(t, err)
+}
+
+func TestCountJobsByStatus(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	svc := NewService(db)
+	ctx := context.Background()
+
+	// Migrations seed some pending jobs, so only count the ones created here.
+	basePending, err := svc.CountJobsByStatus(ctx, models.JobStatusPending)
+	require.NoError(t, err)
+	baseFailed, err := svc.CountJobsByStatus(ctx, models.JobStatusFailed)
+	require.NoError(t, err)
+
+	for _, status := range []string{models.JobStatusPending, models.JobStatusPending, models.JobStatusInProgress, models.JobStatusCompleted} {
+		require.NoError(t, svc.CreateJob(ctx, &models.Job{
+			Type:       models.JobTypeScan,
+			Status:     status,
+			DataParsed: &models.JobScanData{},
+		}))
+	}
+
+	pending, err := svc.CountJobsByStatus(ctx, models.JobStatusPending)
+	require.NoError(t, err)
+	assert.Equal(t, 2, pending-basePending)
+
+	failed, err := svc.CountJobsByStatus(ctx, models.JobStatusFailed)
+	require.NoError(t, err)
+	assert.Equal(t, 0, failed-baseFailed)
+}
@@ -3,8 +3,8 @@ package jobs
 import "github.com/shishobooks/shisho/pkg/models"
 
 type CreateJobPayload struct {
-	Type      string      `json:"type" validate:"required,oneof=export scan bulk_download recompute_review" tstype:"JobType"`
-	Data      interface{} `json:"data" validate:"required" tstype:"JobExportData | JobScanData | JobBulkDownloadData | JobRecomputeReviewData"`
+	Type      string      `json:"type" validate:"required,oneof=export scan bulk_download recompute_review thumbnail_backfill regenerate_sort_titles recompress_comic_pages" tstype:"JobType"`
+	Data      interface{} `json:"data" validate:"required" tstype:"JobExportData | JobScanData | JobBulkDownloadData | JobRecomputeReviewData | JobThumbnailBackfillData | JobRegenerateSortTitlesData | JobRecompressComicPagesData"`
 	LibraryID *int        `json:"library_id,omitempty"`
 }
 
@@ -12,7 +12,7 @@ type ListJobsQuery struct {
 	Limit             int      `query:"limit" json:"limit,omitempty" default:"10" validate:"min=1,max=100"`
 	Offset            int      `query:"offset" json:"offset,omitempty" validate:"min=0"`
 	Status            []string `query:"status" json:"status,omitempty" validate:"dive,oneof=pending in_progress completed failed" tstype:"JobStatus[]"`
-	Type              *string  `query:"type" json:"type,omitempty" validate:"omitempty,oneof=export scan bulk_download recompute_review" tstype:"JobType"`
+	Type              *string  `query:"type" json:"type,omitempty" validate:"omitempty,oneof=export scan bulk_download recompute_review thumbnail_backfill regenerate_sort_titles recompress_comic_pages" tstype:"JobType"`
 	LibraryIDOrGlobal *int     `query:"library_id_or_global" json:"library_id_or_global,omitempty"`
 }
 
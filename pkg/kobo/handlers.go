@@ -20,6 +20,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/robinjoseph08/golib/logger"
 	"github.com/shishobooks/shisho/pkg/books"
+	"github.com/shishobooks/shisho/pkg/covers"
 	"github.com/shishobooks/shisho/pkg/downloadcache"
 	"github.com/shishobooks/shisho/pkg/errcodes"
 	"github.com/shishobooks/shisho/pkg/filegen"
@@ -307,36 +308,37 @@ func (h *handler) handleCover(c echo.Context) error {
 	// Resolve via the file's parent dir — book.Filepath may be a synthetic
 	// organized-folder path that doesn't exist on disk for root-level files.
 	coverPath := filepath.Join(filepath.Dir(file.Filepath), *file.CoverImageFilename)
-
-	// Stat source cover for Last-Modified + conditional GET short-circuit.
-	// This runs before the resize so revalidated requests skip the expensive
-	// decode/resize/encode work. In the no-dimensions branch below, c.File
-	// also sets Last-Modified from the same file mtime — the values match
-	// (second precision), so the overlap is harmless.
 	coverStat, err := os.Stat(coverPath)
 	if err != nil {
 		return errcodes.NotFound("Cover")
 	}
-	modTime := coverStat.ModTime().UTC().Truncate(time.Second)
-	c.Response().Header().Set("Cache-Control", "private, no-cache")
-	c.Response().Header().Set("Last-Modified", modTime.Format(http.TimeFormat))
-	if ims := c.Request().Header.Get("If-Modified-Since"); ims != "" {
-		if t, parseErr := http.ParseTime(ims); parseErr == nil && !modTime.After(t) {
-			c.Response().WriteHeader(http.StatusNotModified)
-			return nil
-		}
-	}
 
 	// Parse requested dimensions
-	widthStr := c.Param("w")
-	heightStr := c.Param("h")
-	width, _ := strconv.Atoi(widthStr)
-	height, _ := strconv.Atoi(heightStr)
+	width, _ := strconv.Atoi(c.Param("w"))
+	height, _ := strconv.Atoi(c.Param("h"))
 
 	if width == 0 || height == 0 {
-		// Serve original if dimensions not specified. c.File handles
-		// Last-Modified/If-Modified-Since automatically for this branch.
-		return c.File(coverPath)
+		// Serve original if dimensions not specified. The URL always names
+		// this file's cover, so Last-Modified is safe to send alongside the
+		// ETag.
+		return covers.ServeFile(c, file.ID, coverPath, coverStat, covers.CacheControlNoCache, coverStat.ModTime())
+	}
+
+	// A resized cover gets the source cover's validators with the requested
+	// dimensions added to the ETag, and conditional requests are answered
+	// before the expensive decode/resize/encode work.
+	etag, err := covers.ETag(file.ID, coverPath, coverStat)
+	if err != nil {
+		return err
+	}
+	etag = fmt.Sprintf(`%s-%dx%d"`, strings.TrimSuffix(etag, `"`), width, height)
+	modTime := coverStat.ModTime().UTC().Truncate(time.Second)
+	c.Response().Header().Set("Cache-Control", covers.CacheControlNoCache)
+	c.Response().Header().Set("ETag", etag)
+	c.Response().Header().Set("Last-Modified", modTime.Format(http.TimeFormat))
+	if covers.NotModified(c.Request(), etag, modTime) {
+		c.Response().WriteHeader(http.StatusNotModified)
+		return nil
 	}
 
 	// Open and resize the image
@@ -279,3 +279,112 @@ func TestHandleCover_304SkipsResizeWork(t *testing.T) {
 	// confirming that the 304 above genuinely skipped image decoding.
 	require.Error(t, err3, "expected decode error on garbage cover to confirm 304 skipped resize")
 }
+
+func TestHandleCover_Returns304WhenIfNoneMatchMatches(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestDB(t)
+	ctx := context.Background()
+	e := echo.New()
+	bookService := books.NewService(db)
+
+	library := &models.Library{
+		Name:                     "Test Library ETag",
+		CoverAspectRatio:         "book",
+		DownloadFormatPreference: models.DownloadFormatOriginal,
+	}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+
+	bookDir := filepath.Join(t.TempDir(), "Test Book ETag")
+	require.NoError(t, os.MkdirAll(bookDir, 0o755))
+
+	book := &models.Book{
+		LibraryID:       library.ID,
+		Title:           "Test Book ETag",
+		TitleSource:     models.DataSourceFilepath,
+		SortTitle:       "Test Book ETag",
+		SortTitleSource: models.DataSourceFilepath,
+		AuthorSource:    models.DataSourceFilepath,
+		Filepath:        bookDir,
+	}
+	_, err = db.NewInsert().Model(book).Exec(ctx)
+	require.NoError(t, err)
+
+	filePath := filepath.Join(bookDir, "test.epub")
+	require.NoError(t, os.WriteFile(filePath, []byte("fake epub"), 0o644))
+
+	coverFilename := "test.epub.cover.jpg"
+	coverPath := filepath.Join(bookDir, coverFilename)
+	coverFile, err := os.Create(coverPath)
+	require.NoError(t, err)
+	require.NoError(t, jpeg.Encode(coverFile, image.NewRGBA(image.Rect(0, 0, 100, 150)), nil))
+	require.NoError(t, coverFile.Close())
+
+	mimeType := "image/jpeg"
+	file := &models.File{
+		LibraryID:          library.ID,
+		BookID:             book.ID,
+		FileType:           models.FileTypeEPUB,
+		FileRole:           models.FileRoleMain,
+		Filepath:           filePath,
+		FilesizeBytes:      1000,
+		CoverImageFilename: &coverFilename,
+		CoverMimeType:      &mimeType,
+	}
+	_, err = db.NewInsert().Model(file).Exec(ctx)
+	require.NoError(t, err)
+
+	h := &handler{bookService: bookService}
+	imageID := fmt.Sprintf("shisho-%d", file.ID)
+
+	get := func(w, h2, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("imageId", "w", "h")
+		c.SetParamValues(imageID, w, h2)
+		require.NoError(t, h.handleCover(c))
+		return rec
+	}
+
+	// The original and each resized variant get distinct ETags.
+	original := get("", "", "")
+	require.Equal(t, http.StatusOK, original.Code)
+	originalETag := original.Header().Get("ETag")
+	require.NotEmpty(t, originalETag)
+
+	resized := get("100", "150", "")
+	require.Equal(t, http.StatusOK, resized.Code)
+	resizedETag := resized.Header().Get("ETag")
+	require.NotEmpty(t, resizedETag)
+	assert.NotEqual(t, originalETag, resizedETag)
+	assert.NotEqual(t, resizedETag, get("50", "75", "").Header().Get("ETag"))
+
+	// Revalidating with the matching ETag gets a 304 for both.
+	rec := get("", "", originalETag)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.Bytes())
+
+	rec = get("100", "150", resizedETag)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.Bytes())
+
+	// A different size's ETag doesn't match.
+	rec = get("50", "75", resizedETag)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, rec.Body.Bytes())
+
+	// Once the cover's content changes, the old ETag no longer matches.
+	coverFile, err = os.Create(coverPath)
+	require.NoError(t, err)
+	require.NoError(t, jpeg.Encode(coverFile, image.NewRGBA(image.Rect(0, 0, 120, 180)), nil))
+	require.NoError(t, coverFile.Close())
+
+	rec = get("100", "150", resizedETag)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEqual(t, resizedETag, rec.Header().Get("ETag"))
+}
@@ -230,7 +230,8 @@ func (svc *Service) DetectChanges(ctx context.Context, apiKeyID, lastSyncPointID
 
 // GetScopedFiles queries all Kobo-compatible main files (EPUB, CBZ) in scope,
 // filtered by library access. Supplement files and non-Kobo formats (M4B, PDF)
-// are excluded. A book with multiple compatible files (e.g. two EPUBs) will
+// are excluded, as are hidden files and the files of hidden books. A book with
+// multiple compatible files (e.g. two EPUBs) will
 // have all of them returned — each gets its own content ID on the device.
 func (svc *Service) GetScopedFiles(ctx context.Context, userID int, scope *SyncScope) ([]ScopedFile, error) {
 	// Load user with library access.
@@ -245,7 +246,8 @@ func (svc *Service) GetScopedFiles(ctx context.Context, userID int, scope *SyncS
 	}
 
 	// Query all Kobo-compatible main files (EPUB, CBZ) — supplements are
-	// excluded so only real editions sync to the device.
+	// excluded so only real editions sync to the device, and hidden files
+	// and books are left off it.
 	var files []models.File
 	q := svc.db.NewSelect().
 		Model(&files).
@@ -257,7 +259,9 @@ func (svc *Service) GetScopedFiles(ctx context.Context, userID int, scope *SyncS
 		Relation("Book.BookSeries.Series").
 		Relation("Publisher").
 		Where("f.file_type IN (?)", bun.List([]string{models.FileTypeEPUB, models.FileTypeCBZ})).
-		Where("f.file_role = ?", models.FileRoleMain)
+		Where("f.file_role = ?", models.FileRoleMain).
+		Where("f.hidden = FALSE").
+		Where("f.book_id IN (SELECT id FROM books WHERE hidden = FALSE)")
 
 	// Apply scope.
 	switch scope.Type {
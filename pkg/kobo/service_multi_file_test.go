@@ -183,3 +183,26 @@ func TestGetScopedFiles_CBZFilesSyncToo(t *testing.T) {
 
 	assert.Equal(t, []int{epub.ID, cbz.ID}, scopedFileIDs(files))
 }
+
+func TestGetScopedFiles_HiddenFilesAndBooksExcluded(t *testing.T) {
+	t.Parallel()
+	ctx, bookSvc, koboSvc, library, user := setupScopedFilesTest(t)
+	db := bookSvc.DB()
+
+	book := createBook(ctx, t, bookSvc, library.ID, "Partly Hidden")
+	visible := createFile(ctx, t, bookSvc, library.ID, book.ID, "/tmp/test/partly-hidden/book.epub", models.FileTypeEPUB, models.FileRoleMain, 1000)
+	hiddenFile := createFile(ctx, t, bookSvc, library.ID, book.ID, "/tmp/test/partly-hidden/book.cbz", models.FileTypeCBZ, models.FileRoleMain, 2000)
+	_, err := db.NewUpdate().Model((*models.File)(nil)).Set("hidden = TRUE").Where("id = ?", hiddenFile.ID).Exec(ctx)
+	require.NoError(t, err)
+
+	hiddenBook := createBook(ctx, t, bookSvc, library.ID, "Hidden")
+	_ = createFile(ctx, t, bookSvc, library.ID, hiddenBook.ID, "/tmp/test/hidden/book.epub", models.FileTypeEPUB, models.FileRoleMain, 1000)
+	_, err = db.NewUpdate().Model((*models.Book)(nil)).Set("hidden = TRUE").Where("id = ?", hiddenBook.ID).Exec(ctx)
+	require.NoError(t, err)
+
+	scope := &SyncScope{Type: "all"}
+	files, err := koboSvc.GetScopedFiles(ctx, user.ID, scope)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{visible.ID}, scopedFileIDs(files))
+}
@@ -3,10 +3,12 @@ package libraries
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/fileutils"
 	"github.com/shishobooks/shisho/pkg/jobs"
 	"github.com/shishobooks/shisho/pkg/models"
 )
@@ -15,6 +17,7 @@ type handler struct {
 	libraryService   *Service
 	jobService       *jobs.Service
 	onLibraryChanged func() // optional callback when libraries are created/updated
+	scanErrorRetrier ScanErrorRetrier
 }
 
 func (h *handler) create(c echo.Context) error {
@@ -26,9 +29,33 @@ func (h *handler) create(c echo.Context) error {
 		return errors.WithStack(err)
 	}
 
-	organizeFileStructure := true
-	if params.OrganizeFileStructure != nil {
-		organizeFileStructure = *params.OrganizeFileStructure
+	if _, err := fileutils.CompileFilenamePatterns(params.FilenamePatterns); err != nil {
+		return errcodes.ValidationError(err.Error())
+	}
+	organizeSubfolders, err := normalizeOrganizeSubfolders(params.OrganizeSubfolders)
+	if err != nil {
+		return errcodes.ValidationError(err.Error())
+	}
+	scanSchedule := ""
+	if params.ScanSchedule != nil && strings.TrimSpace(*params.ScanSchedule) != "" {
+		if _, err := ParseScanSchedule(*params.ScanSchedule); err != nil {
+			return errcodes.ValidationError(err.Error())
+		}
+		scanSchedule = strings.TrimSpace(*params.ScanSchedule)
+	}
+
+	organizeFiles := true
+	if params.OrganizeFiles != nil {
+		organizeFiles = *params.OrganizeFiles
+	}
+	organizeFolders := true
+	if params.OrganizeFolders != nil {
+		organizeFolders = *params.OrganizeFolders
+	}
+
+	cbzCoverPageDefault := 1
+	if params.CBZCoverPageDefault != nil {
+		cbzCoverPageDefault = *params.CBZCoverPageDefault
 	}
 
 	downloadFormatPreference := models.DownloadFormatOriginal
@@ -38,9 +65,22 @@ func (h *handler) create(c echo.Context) error {
 
 	library := &models.Library{
 		Name:                     params.Name,
-		OrganizeFileStructure:    organizeFileStructure,
+		OrganizeFiles:            organizeFiles,
+		OrganizeFolders:          organizeFolders,
+		Staging:                  params.Staging != nil && *params.Staging,
+		InferSeriesFromParentDir: params.InferSeriesFromParentDir != nil && *params.InferSeriesFromParentDir,
+		EnrichFromOpenLibrary:    params.EnrichFromOpenLibrary != nil && *params.EnrichFromOpenLibrary,
+		AutoPairFormats:          params.AutoPairFormats != nil && *params.AutoPairFormats,
+		StripSeriesFromTitle:     params.StripSeriesFromTitle != nil && *params.StripSeriesFromTitle,
+		CBZCoverPageDefault:      cbzCoverPageDefault,
+		PreferExternalCover:      params.PreferExternalCover != nil && *params.PreferExternalCover,
 		CoverAspectRatio:         params.CoverAspectRatio,
 		DownloadFormatPreference: downloadFormatPreference,
+		AllowedFileTypes:         normalizeFileTypes(params.AllowedFileTypes),
+		FilenamePatterns:         params.FilenamePatterns,
+		NameSeparators:           normalizeNameSeparators(params.NameSeparators),
+		ScanSchedule:             scanSchedule,
+		OrganizeSubfolders:       organizeSubfolders,
 		LibraryPaths:             make([]*models.LibraryPath, 0, len(params.LibraryPaths)),
 	}
 	for _, path := range params.LibraryPaths {
@@ -49,7 +89,7 @@ func (h *handler) create(c echo.Context) error {
 		})
 	}
 
-	err := h.libraryService.CreateLibrary(ctx, library)
+	err = h.libraryService.CreateLibrary(ctx, library)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -135,6 +175,99 @@ func (h *handler) list(c echo.Context) error {
 	return errors.WithStack(c.JSON(http.StatusOK, resp))
 }
 
+// listSkips returns the files the library's latest full scan didn't import
+// and why.
+func (h *handler) listSkips(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Library")
+	}
+
+	params := ListScanSkipsQuery{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if _, err := h.libraryService.RetrieveLibrary(ctx, RetrieveLibraryOptions{ID: &id}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	skips, total, err := h.libraryService.ListScanSkipsWithTotal(ctx, ListScanSkipsOptions{
+		LibraryID: id,
+		Reason:    params.Reason,
+		Path:      params.Path,
+		Limit:     &params.Limit,
+		Offset:    &params.Offset,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, ListScanSkipsResponse{Items: skips, Total: total}))
+}
+
+// listErrors returns the library's files that failed to scan and haven't
+// scanned successfully since.
+func (h *handler) listErrors(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Library")
+	}
+
+	params := ListScanErrorsQuery{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if _, err := h.libraryService.RetrieveLibrary(ctx, RetrieveLibraryOptions{ID: &id}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	scanErrors, total, err := h.libraryService.ListScanErrorsWithTotal(ctx, ListScanErrorsOptions{
+		LibraryID: id,
+		Code:      params.Code,
+		Limit:     &params.Limit,
+		Offset:    &params.Offset,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, ListScanErrorsResponse{Items: scanErrors, Total: total}))
+}
+
+// retryError scans a failed file again. The error is cleared if the scan
+// succeeds and updated if it doesn't.
+func (h *handler) retryError(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Scan error")
+	}
+
+	scanError, err := h.libraryService.RetrieveScanError(ctx, id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	user, ok := c.Get("user").(*models.User)
+	if !ok {
+		return errcodes.Unauthorized("User not found in context")
+	}
+	if !user.HasLibraryAccess(scanError.LibraryID) {
+		return errcodes.Forbidden("You don't have access to this library")
+	}
+
+	updated, err := h.scanErrorRetrier.RetryScanError(ctx, scanError)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, RetryScanErrorResponse{Resolved: updated == nil, Error: updated}))
+}
+
 func (h *handler) update(c echo.Context) error {
 	ctx := c.Request().Context()
 	id, err := strconv.Atoi(c.Param("id"))
@@ -163,9 +296,41 @@ func (h *handler) update(c echo.Context) error {
 		library.Name = *params.Name
 		opts.Columns = append(opts.Columns, "name")
 	}
-	if params.OrganizeFileStructure != nil && *params.OrganizeFileStructure != library.OrganizeFileStructure {
-		library.OrganizeFileStructure = *params.OrganizeFileStructure
-		opts.Columns = append(opts.Columns, "organize_file_structure")
+	if params.OrganizeFiles != nil && *params.OrganizeFiles != library.OrganizeFiles {
+		library.OrganizeFiles = *params.OrganizeFiles
+		opts.Columns = append(opts.Columns, "organize_files")
+	}
+	if params.OrganizeFolders != nil && *params.OrganizeFolders != library.OrganizeFolders {
+		library.OrganizeFolders = *params.OrganizeFolders
+		opts.Columns = append(opts.Columns, "organize_folders")
+	}
+	if params.Staging != nil && *params.Staging != library.Staging {
+		library.Staging = *params.Staging
+		opts.Columns = append(opts.Columns, "staging")
+	}
+	if params.InferSeriesFromParentDir != nil && *params.InferSeriesFromParentDir != library.InferSeriesFromParentDir {
+		library.InferSeriesFromParentDir = *params.InferSeriesFromParentDir
+		opts.Columns = append(opts.Columns, "infer_series_from_parent_dir")
+	}
+	if params.EnrichFromOpenLibrary != nil && *params.EnrichFromOpenLibrary != library.EnrichFromOpenLibrary {
+		library.EnrichFromOpenLibrary = *params.EnrichFromOpenLibrary
+		opts.Columns = append(opts.Columns, "enrich_from_open_library")
+	}
+	if params.AutoPairFormats != nil && *params.AutoPairFormats != library.AutoPairFormats {
+		library.AutoPairFormats = *params.AutoPairFormats
+		opts.Columns = append(opts.Columns, "auto_pair_formats")
+	}
+	if params.StripSeriesFromTitle != nil && *params.StripSeriesFromTitle != library.StripSeriesFromTitle {
+		library.StripSeriesFromTitle = *params.StripSeriesFromTitle
+		opts.Columns = append(opts.Columns, "strip_series_from_title")
+	}
+	if params.CBZCoverPageDefault != nil && *params.CBZCoverPageDefault != library.CBZCoverPageDefault {
+		library.CBZCoverPageDefault = *params.CBZCoverPageDefault
+		opts.Columns = append(opts.Columns, "cbz_cover_page_default")
+	}
+	if params.PreferExternalCover != nil && *params.PreferExternalCover != library.PreferExternalCover {
+		library.PreferExternalCover = *params.PreferExternalCover
+		opts.Columns = append(opts.Columns, "prefer_external_cover")
 	}
 	if params.CoverAspectRatio != nil && *params.CoverAspectRatio != library.CoverAspectRatio {
 		library.CoverAspectRatio = *params.CoverAspectRatio
@@ -175,6 +340,39 @@ func (h *handler) update(c echo.Context) error {
 		library.DownloadFormatPreference = *params.DownloadFormatPreference
 		opts.Columns = append(opts.Columns, "download_format_preference")
 	}
+	if params.AllowedFileTypes != nil {
+		library.AllowedFileTypes = normalizeFileTypes(params.AllowedFileTypes)
+		opts.Columns = append(opts.Columns, "allowed_file_types")
+	}
+	if params.FilenamePatterns != nil {
+		if _, err := fileutils.CompileFilenamePatterns(params.FilenamePatterns); err != nil {
+			return errcodes.ValidationError(err.Error())
+		}
+		library.FilenamePatterns = params.FilenamePatterns
+		opts.Columns = append(opts.Columns, "filename_patterns")
+	}
+	if params.NameSeparators != nil {
+		library.NameSeparators = normalizeNameSeparators(params.NameSeparators)
+		opts.Columns = append(opts.Columns, "name_separators")
+	}
+	if params.ScanSchedule != nil && strings.TrimSpace(*params.ScanSchedule) != library.ScanSchedule {
+		scanSchedule := strings.TrimSpace(*params.ScanSchedule)
+		if scanSchedule != "" {
+			if _, err := ParseScanSchedule(scanSchedule); err != nil {
+				return errcodes.ValidationError(err.Error())
+			}
+		}
+		library.ScanSchedule = scanSchedule
+		opts.Columns = append(opts.Columns, "scan_schedule")
+	}
+	if params.OrganizeSubfolders != nil {
+		organizeSubfolders, err := normalizeOrganizeSubfolders(params.OrganizeSubfolders)
+		if err != nil {
+			return errcodes.ValidationError(err.Error())
+		}
+		library.OrganizeSubfolders = organizeSubfolders
+		opts.Columns = append(opts.Columns, "organize_subfolders")
+	}
 	if params.LibraryPaths != nil {
 		library.LibraryPaths = make([]*models.LibraryPath, 0, len(params.LibraryPaths))
 		for _, path := range params.LibraryPaths {
@@ -223,3 +421,116 @@ func (h *handler) delete(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+func (h *handler) relocatePath(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Library")
+	}
+	pathID, err := strconv.Atoi(c.Param("pathId"))
+	if err != nil {
+		return errcodes.NotFound("Library path")
+	}
+
+	params := RelocateLibraryPathPayload{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	library, err := h.libraryService.RetrieveLibrary(ctx, RetrieveLibraryOptions{
+		ID: &id,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	found := false
+	for _, path := range library.LibraryPaths {
+		if path.ID == pathID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errcodes.NotFound("Library path")
+	}
+
+	if _, err := h.libraryService.RelocatePath(ctx, pathID, params.Filepath); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Reload the model.
+	library, err = h.libraryService.RetrieveLibrary(ctx, RetrieveLibraryOptions{
+		ID: &id,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if h.onLibraryChanged != nil {
+		h.onLibraryChanged()
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, LibraryResponse{Library: *library}))
+}
+
+// normalizeFileTypes lowercases file types, strips a leading dot (".epub" and
+// "epub" are both accepted), and drops duplicates.
+func normalizeFileTypes(fileTypes []string) []string {
+	normalized := make([]string, 0, len(fileTypes))
+	seen := make(map[string]struct{}, len(fileTypes))
+	for _, fileType := range fileTypes {
+		fileType = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(fileType), "."))
+		if fileType == "" {
+			continue
+		}
+		if _, ok := seen[fileType]; ok {
+			continue
+		}
+		seen[fileType] = struct{}{}
+		normalized = append(normalized, fileType)
+	}
+	return normalized
+}
+
+// normalizeNameSeparators drops blank and duplicate name separators. The rest
+// are kept as given, surrounding spaces included, so " / " only splits on a
+// slash with spaces around it.
+func normalizeNameSeparators(separators []string) []string {
+	normalized := make([]string, 0, len(separators))
+	seen := make(map[string]struct{}, len(separators))
+	for _, sep := range separators {
+		if strings.TrimSpace(sep) == "" {
+			continue
+		}
+		if _, ok := seen[sep]; ok {
+			continue
+		}
+		seen[sep] = struct{}{}
+		normalized = append(normalized, sep)
+	}
+	return normalized
+}
+
+// normalizeOrganizeSubfolders lowercases the file types and trims the folder
+// names in an organize_subfolders map, dropping entries with an empty folder.
+// Each folder must be a single directory name, so organized books can't end
+// up outside the library.
+func normalizeOrganizeSubfolders(subfolders map[string]string) (map[string]string, error) {
+	normalized := make(map[string]string, len(subfolders))
+	for fileType, folder := range subfolders {
+		fileType = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(fileType), "."))
+		folder = strings.TrimSpace(folder)
+		if fileType == "" || folder == "" {
+			continue
+		}
+		if folder == "." || folder == ".." || strings.ContainsAny(folder, `/\`) {
+			return nil, errors.Errorf("organize subfolder %q for %s must be a single folder name", folder, fileType)
+		}
+		if len(folder) > 100 {
+			return nil, errors.Errorf("organize subfolder for %s must be at most 100 characters", fileType)
+		}
+		normalized[fileType] = folder
+	}
+	return normalized, nil
+}
@@ -212,3 +212,20 @@ func TestDeleteLibraryHandler_RequiresLibraryAccess(t *testing.T) {
 
 	assert.Equal(t, http.StatusForbidden, rr.Code)
 }
+
+func TestNormalizeOrganizeSubfolders(t *testing.T) {
+	t.Parallel()
+
+	got, err := normalizeOrganizeSubfolders(map[string]string{
+		".M4B": " Audiobooks ",
+		"cbz":  "Comics",
+		"epub": "",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"m4b": "Audiobooks", "cbz": "Comics"}, got)
+
+	for _, folder := range []string{"..", ".", "Audio/books", `Audio\books`} {
+		_, err := normalizeOrganizeSubfolders(map[string]string{"m4b": folder})
+		assert.Error(t, err, folder)
+	}
+}
@@ -0,0 +1,179 @@
+package libraries
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// Kinds of library path problems found by CheckPaths.
+const (
+	PathProblemNotAbsolute   = "not_absolute"
+	PathProblemNotNormalized = "not_normalized"
+	PathProblemMissing       = "missing"
+	PathProblemNotDirectory  = "not_directory"
+	PathProblemUnreadable    = "unreadable"
+	PathProblemSymlink       = "symlink"
+	PathProblemOverlap       = "overlap"
+)
+
+// PathProblem is something wrong with a configured library path.
+type PathProblem struct {
+	LibraryID     int
+	LibraryPathID int
+	Path          string
+	Kind          string
+	Detail        string
+}
+
+func (p PathProblem) String() string {
+	return fmt.Sprintf("library %d, path %d (%s): %s: %s", p.LibraryID, p.LibraryPathID, p.Path, p.Kind, p.Detail)
+}
+
+// CheckPaths checks every library path for problems that make scans behave
+// oddly: paths that aren't absolute or clean, paths that are missing or
+// can't be read, symlinks, and paths that are the same as or nested inside
+// another one, which get scanned twice. Nothing is changed.
+func (svc *Service) CheckPaths(ctx context.Context) ([]PathProblem, error) {
+	var paths []*models.LibraryPath
+	err := svc.db.NewSelect().Model(&paths).Order("lp.library_id ASC", "lp.id ASC").Scan(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return checkPaths(paths), nil
+}
+
+// NormalizePaths rewrites library paths stored with trailing slashes or
+// other redundant elements to their clean form, and returns the paths it
+// changed. Books and files are stored with clean paths already, so only the
+// library paths need rewriting. A path whose clean form is already another
+// path of the same library is left alone, since removing the duplicate is
+// the library owner's call.
+func (svc *Service) NormalizePaths(ctx context.Context) ([]*models.LibraryPath, error) {
+	var paths []*models.LibraryPath
+	err := svc.db.NewSelect().Model(&paths).Order("lp.library_id ASC", "lp.id ASC").Scan(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	existing := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		existing[fmt.Sprintf("%d:%s", p.LibraryID, p.Filepath)] = struct{}{}
+	}
+
+	var normalized []*models.LibraryPath
+	now := time.Now()
+	for _, p := range paths {
+		clean := filepath.Clean(p.Filepath)
+		if clean == p.Filepath || !filepath.IsAbs(clean) {
+			continue
+		}
+		key := fmt.Sprintf("%d:%s", p.LibraryID, clean)
+		if _, ok := existing[key]; ok {
+			continue
+		}
+		p.Filepath = clean
+		p.UpdatedAt = now
+		_, err := svc.db.NewUpdate().Model(p).Column("filepath", "updated_at").WherePK().Exec(ctx)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		existing[key] = struct{}{}
+		normalized = append(normalized, p)
+	}
+	return normalized, nil
+}
+
+// checkPaths reports the problems with paths, ordered by library and path.
+func checkPaths(paths []*models.LibraryPath) []PathProblem {
+	var problems []PathProblem
+	report := func(p *models.LibraryPath, kind, detail string) {
+		problems = append(problems, PathProblem{
+			LibraryID:     p.LibraryID,
+			LibraryPathID: p.ID,
+			Path:          p.Filepath,
+			Kind:          kind,
+			Detail:        detail,
+		})
+	}
+
+	// Overlaps are checked on the resolved paths, so a symlink to another
+	// library path counts as the same folder.
+	resolved := make([]string, len(paths))
+	for i, p := range paths {
+		clean := filepath.Clean(p.Filepath)
+		resolved[i] = clean
+
+		if !filepath.IsAbs(p.Filepath) {
+			report(p, PathProblemNotAbsolute, "library paths must be absolute")
+			continue
+		}
+		if clean != p.Filepath {
+			report(p, PathProblemNotNormalized, "should be stored as "+clean)
+		}
+
+		info, err := os.Stat(clean)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				report(p, PathProblemMissing, "doesn't exist")
+			} else {
+				report(p, PathProblemUnreadable, err.Error())
+			}
+			continue
+		}
+		if !info.IsDir() {
+			report(p, PathProblemNotDirectory, "isn't a directory")
+			continue
+		}
+		if err := checkReadableDir(clean); err != nil {
+			report(p, PathProblemUnreadable, err.Error())
+		}
+
+		if target, err := filepath.EvalSymlinks(clean); err == nil && target != clean {
+			resolved[i] = target
+			report(p, PathProblemSymlink, "resolves to "+target)
+		}
+	}
+
+	for i := range paths {
+		for j := range paths {
+			if i == j || !filepath.IsAbs(paths[i].Filepath) || !filepath.IsAbs(paths[j].Filepath) {
+				continue
+			}
+			switch {
+			case resolved[i] == resolved[j] && i < j:
+				report(paths[j], PathProblemOverlap, fmt.Sprintf("is the same folder as library %d, path %d (%s)", paths[i].LibraryID, paths[i].ID, paths[i].Filepath))
+			case resolved[i] != resolved[j] && isSameOrWithin(resolved[j], resolved[i]):
+				report(paths[j], PathProblemOverlap, fmt.Sprintf("is inside library %d, path %d (%s), so its files are scanned twice", paths[i].LibraryID, paths[i].ID, paths[i].Filepath))
+			}
+		}
+	}
+
+	sort.SliceStable(problems, func(a, b int) bool {
+		if problems[a].LibraryID != problems[b].LibraryID {
+			return problems[a].LibraryID < problems[b].LibraryID
+		}
+		return problems[a].LibraryPathID < problems[b].LibraryPathID
+	})
+	return problems
+}
+
+// checkReadableDir reports whether dir's entries can be listed.
+func checkReadableDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+	if _, err := f.Readdirnames(1); err != nil && !errors.Is(err, io.EOF) {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package libraries
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPaths(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	books := filepath.Join(root, "books")
+	comics := filepath.Join(books, "comics")
+	audio := filepath.Join(root, "audio")
+	require.NoError(t, os.MkdirAll(comics, 0755))
+	require.NoError(t, os.MkdirAll(audio, 0755))
+	link := filepath.Join(root, "audio-link")
+	require.NoError(t, os.Symlink(audio, link))
+	notDir := filepath.Join(root, "file.txt")
+	require.NoError(t, os.WriteFile(notDir, []byte("x"), 0600))
+
+	problems := checkPaths([]*models.LibraryPath{
+		{ID: 1, LibraryID: 1, Filepath: books + "/"},
+		{ID: 2, LibraryID: 2, Filepath: comics},
+		{ID: 3, LibraryID: 3, Filepath: audio},
+		{ID: 4, LibraryID: 3, Filepath: link},
+		{ID: 5, LibraryID: 4, Filepath: filepath.Join(root, "missing")},
+		{ID: 6, LibraryID: 4, Filepath: notDir},
+		{ID: 7, LibraryID: 4, Filepath: "relative/path"},
+	})
+
+	kinds := make(map[int][]string)
+	for _, p := range problems {
+		kinds[p.LibraryPathID] = append(kinds[p.LibraryPathID], p.Kind)
+	}
+	assert.Equal(t, map[int][]string{
+		1: {PathProblemNotNormalized},
+		2: {PathProblemOverlap},
+		4: {PathProblemSymlink, PathProblemOverlap},
+		5: {PathProblemMissing},
+		6: {PathProblemNotDirectory},
+		7: {PathProblemNotAbsolute},
+	}, kinds)
+}
+
+func TestCheckPaths_NoProblems(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "books"), 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(root, "books-extra"), 0755))
+
+	// A shared prefix isn't nesting.
+	problems := checkPaths([]*models.LibraryPath{
+		{ID: 1, LibraryID: 1, Filepath: filepath.Join(root, "books")},
+		{ID: 2, LibraryID: 2, Filepath: filepath.Join(root, "books-extra")},
+	})
+	assert.Empty(t, problems)
+}
+
+func TestNormalizePaths(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	svc := NewService(db)
+	ctx := context.Background()
+
+	library := &models.Library{
+		Name:             "Books",
+		CoverAspectRatio: models.CoverAspectRatioBook,
+		LibraryPaths: []*models.LibraryPath{
+			{Filepath: "/books/fiction/"},
+			{Filepath: "/books/./poetry"},
+			{Filepath: "/books/comics"},
+			{Filepath: "/books/comics/"},
+		},
+	}
+	require.NoError(t, svc.CreateLibrary(ctx, library))
+
+	normalized, err := svc.NormalizePaths(ctx)
+	require.NoError(t, err)
+	require.Len(t, normalized, 2)
+	assert.Equal(t, "/books/fiction", normalized[0].Filepath)
+	assert.Equal(t, "/books/poetry", normalized[1].Filepath)
+
+	retrieved, err := svc.RetrieveLibrary(ctx, RetrieveLibraryOptions{ID: &library.ID})
+	require.NoError(t, err)
+	var paths []string
+	for _, lp := range retrieved.LibraryPaths {
+		paths = append(paths, lp.Filepath)
+	}
+	// The trailing-slash duplicate of /books/comics is left for the owner to remove.
+	assert.ElementsMatch(t, []string{"/books/fiction", "/books/poetry", "/books/comics", "/books/comics/"}, paths)
+}
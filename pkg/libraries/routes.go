@@ -1,6 +1,8 @@
 package libraries
 
 import (
+	"context"
+
 	"github.com/labstack/echo/v4"
 	"github.com/shishobooks/shisho/pkg/auth"
 	"github.com/shishobooks/shisho/pkg/jobs"
@@ -15,6 +17,13 @@ type RegisterRoutesOptions struct {
 	OnLibraryChanged func()
 }
 
+// ScanErrorRetrier scans the path of a scan error again. It's implemented
+// by the worker, which clears the error when the scan succeeds and returns
+// the updated error when it doesn't.
+type ScanErrorRetrier interface {
+	RetryScanError(ctx context.Context, scanError *models.ScanError) (*models.ScanError, error)
+}
+
 // RegisterRoutesWithGroup registers library routes on a pre-configured group.
 func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, authMiddleware *auth.Middleware, opts ...RegisterRoutesOptions) {
 	libraryService := NewService(db)
@@ -30,9 +39,25 @@ func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, authMiddleware *auth.Mid
 
 	g.GET("", h.list)
 	g.GET("/:id", h.retrieve, authMiddleware.RequireLibraryAccess("id"))
+	g.GET("/:id/skips", h.listSkips, authMiddleware.RequireLibraryAccess("id"))
+	g.GET("/:id/errors", h.listErrors, authMiddleware.RequireLibraryAccess("id"))
 	g.POST("", h.create, authMiddleware.RequirePermission(models.ResourceLibraries, models.OperationWrite))
 	g.POST("/:id", h.update, authMiddleware.RequirePermission(models.ResourceLibraries, models.OperationWrite), authMiddleware.RequireLibraryAccess("id"))
+	g.POST("/:id/paths/:pathId/relocate", h.relocatePath,
+		authMiddleware.RequirePermission(models.ResourceLibraries, models.OperationWrite),
+		authMiddleware.RequireLibraryAccess("id"))
 	g.DELETE("/:id", h.delete,
 		authMiddleware.RequirePermission(models.ResourceLibraries, models.OperationWrite),
 		authMiddleware.RequireLibraryAccess("id"))
 }
+
+// RegisterScanErrorRoutes registers the routes for acting on individual scan
+// errors on a pre-configured group.
+func RegisterScanErrorRoutes(g *echo.Group, db *bun.DB, authMiddleware *auth.Middleware, retrier ScanErrorRetrier) {
+	h := &handler{
+		libraryService:   NewService(db),
+		scanErrorRetrier: retrier,
+	}
+
+	g.POST("/:id/retry", h.retryError, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+}
@@ -0,0 +1,111 @@
+package libraries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/uptrace/bun"
+)
+
+type ListScanErrorsOptions struct {
+	LibraryID int
+	Code      *string
+	Limit     *int
+	Offset    *int
+}
+
+// ReplaceScanErrors swaps a library's recorded scan errors for the ones from
+// its latest full scan. Errors seen again keep their ID and created_at and
+// get a new last_seen, and errors for paths that scanned fine are cleared.
+func (svc *Service) ReplaceScanErrors(ctx context.Context, libraryID int, scanErrors []*models.ScanError) error {
+	now := time.Now()
+	err := svc.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		for _, scanError := range scanErrors {
+			scanError.LibraryID = libraryID
+			if err := upsertScanError(ctx, tx, scanError, now); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.NewDelete().
+			Model((*models.ScanError)(nil)).
+			Where("library_id = ?", libraryID).
+			Where("last_seen < ?", now).
+			Exec(ctx)
+		return errors.WithStack(err)
+	})
+	return errors.WithStack(err)
+}
+
+// RecordScanError saves a failed scan of scanError.Path, replacing any
+// earlier error for the same path in the library.
+func (svc *Service) RecordScanError(ctx context.Context, scanError *models.ScanError) error {
+	return upsertScanError(ctx, svc.db, scanError, time.Now())
+}
+
+func upsertScanError(ctx context.Context, db bun.IDB, scanError *models.ScanError, now time.Time) error {
+	scanError.CreatedAt = now
+	scanError.LastSeen = now
+	_, err := db.NewInsert().
+		Model(scanError).
+		On("CONFLICT (library_id, path) DO UPDATE").
+		Set("file_id = EXCLUDED.file_id").
+		Set("code = EXCLUDED.code").
+		Set("message = EXCLUDED.message").
+		Set("last_seen = EXCLUDED.last_seen").
+		Returning("*").
+		Exec(ctx)
+	return errors.WithStack(err)
+}
+
+// RetrieveScanError returns the scan error with the given ID. Returns
+// errcodes.NotFound if it doesn't exist, including once it's been cleared.
+func (svc *Service) RetrieveScanError(ctx context.Context, id int) (*models.ScanError, error) {
+	scanError := &models.ScanError{}
+	err := svc.db.NewSelect().Model(scanError).Where("se.id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcodes.NotFound("Scan error")
+		}
+		return nil, errors.WithStack(err)
+	}
+	return scanError, nil
+}
+
+// DeleteScanError clears a scan error.
+func (svc *Service) DeleteScanError(ctx context.Context, id int) error {
+	_, err := svc.db.NewDelete().
+		Model((*models.ScanError)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	return errors.WithStack(err)
+}
+
+// ListScanErrorsWithTotal returns a library's outstanding scan errors
+// ordered by path.
+func (svc *Service) ListScanErrorsWithTotal(ctx context.Context, opts ListScanErrorsOptions) ([]*models.ScanError, int, error) {
+	scanErrors := []*models.ScanError{}
+	q := svc.db.
+		NewSelect().
+		Model(&scanErrors).
+		Where("se.library_id = ?", opts.LibraryID).
+		Order("se.path ASC")
+	if opts.Code != nil {
+		q = q.Where("se.code = ?", *opts.Code)
+	}
+	if opts.Limit != nil {
+		q = q.Limit(*opts.Limit)
+	}
+	if opts.Offset != nil {
+		q = q.Offset(*opts.Offset)
+	}
+	total, err := q.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+	return scanErrors, total, nil
+}
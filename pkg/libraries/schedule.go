@@ -0,0 +1,28 @@
+package libraries
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+)
+
+// minScanScheduleInterval is the shortest @every interval a scan schedule may
+// use. The worker checks schedules once a minute, so anything shorter would
+// just run every minute anyway.
+const minScanScheduleInterval = time.Minute
+
+// ParseScanSchedule parses a library's scan schedule: a standard five-field
+// cron expression ("*/15 * * * *"), a descriptor like "@daily", or an
+// interval like "@every 6h". Times are in the server's local time zone.
+func ParseScanSchedule(spec string) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(strings.TrimSpace(spec))
+	if err != nil {
+		return nil, errors.Errorf("invalid scan schedule %q: %s", spec, err)
+	}
+	if every, ok := schedule.(cron.ConstantDelaySchedule); ok && every.Delay < minScanScheduleInterval {
+		return nil, errors.Errorf("invalid scan schedule %q: interval must be at least %s", spec, minScanScheduleInterval)
+	}
+	return schedule, nil
+}
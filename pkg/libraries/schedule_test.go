@@ -0,0 +1,34 @@
+package libraries
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScanSchedule(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 3, 4, 10, 7, 0, 0, time.UTC)
+	tests := []struct {
+		spec string
+		next time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 3, 4, 10, 15, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2026, 3, 5, 3, 0, 0, 0, time.UTC)},
+		{" @daily ", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"@every 6h", from.Add(6 * time.Hour)},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseScanSchedule(tt.spec)
+		require.NoError(t, err, tt.spec)
+		assert.Equal(t, tt.next, schedule.Next(from), tt.spec)
+	}
+
+	for _, spec := range []string{"", "every day", "* * * *", "61 * * * *", "@every 30s"} {
+		_, err := ParseScanSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
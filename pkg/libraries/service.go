@@ -3,7 +3,12 @@ package libraries
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 	"github.com/shishobooks/shisho/pkg/errcodes"
@@ -264,3 +269,145 @@ func (svc *Service) DeleteLibrary(ctx context.Context, id int) error {
 		return errors.WithStack(err)
 	}))
 }
+
+// RelocatePath points a library path at a new root after its directory has
+// been moved on disk, rewriting the library path and every book and file path
+// under it (including the search index) by prefix replacement. Cover
+// filenames are stored relative to their book, so they move along with it.
+//
+// The rewrite runs in a single transaction and is refused up front, with
+// nothing changed, when:
+//
+//   - newRoot isn't an existing directory,
+//   - newRoot is, contains, or sits inside another library path,
+//   - a rewritten path would collide with a book or file already in the
+//     library, or
+//   - any file under the old root isn't at its new location, which usually
+//     means the move hasn't finished.
+//
+// Returns errcodes.NotFound if the library path does not exist.
+func (svc *Service) RelocatePath(ctx context.Context, libraryPathID int, newRoot string) (*models.LibraryPath, error) {
+	newRoot = filepath.Clean(newRoot)
+	if !filepath.IsAbs(newRoot) {
+		return nil, errcodes.ValidationError("New path must be absolute")
+	}
+	info, err := os.Stat(newRoot)
+	if err != nil || !info.IsDir() {
+		return nil, errcodes.ValidationError("New path must be an existing directory")
+	}
+
+	libraryPath := &models.LibraryPath{}
+	err = svc.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().Model(libraryPath).Where("lp.id = ?", libraryPathID).Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errcodes.NotFound("Library path")
+			}
+			return errors.WithStack(err)
+		}
+		oldRoot := libraryPath.Filepath
+		if oldRoot == newRoot {
+			return nil
+		}
+
+		// Library paths can't overlap, or a file would belong to two of them.
+		var otherPaths []string
+		err = tx.NewSelect().
+			Model((*models.LibraryPath)(nil)).
+			Column("filepath").
+			Where("id != ?", libraryPath.ID).
+			Scan(ctx, &otherPaths)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, other := range otherPaths {
+			if isSameOrWithin(newRoot, other) || isSameOrWithin(other, newRoot) {
+				return errcodes.ValidationError("New path overlaps the library path " + other)
+			}
+		}
+
+		// SQLite's substr counts characters, so the prefix lengths must too.
+		prefix := oldRoot + string(filepath.Separator)
+		prefixLen := utf8.RuneCountInString(prefix)
+		rest := utf8.RuneCountInString(oldRoot) + 1
+		underOldRoot := "library_id = ? AND (filepath = ? OR substr(filepath, 1, ?) = ?)"
+		underOldRootArgs := []any{libraryPath.LibraryID, oldRoot, prefixLen, prefix}
+
+		var files []*models.File
+		err = tx.NewSelect().Model(&files).Column("id", "filepath").Where(underOldRoot, underOldRootArgs...).Scan(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		var missing []string
+		for _, f := range files {
+			newPath := newRoot + f.Filepath[len(oldRoot):]
+			if _, err := os.Stat(newPath); err != nil {
+				missing = append(missing, newPath)
+			}
+		}
+		if len(missing) > 0 {
+			return errcodes.ValidationError(fmt.Sprintf("%d file(s) were not found under the new path, e.g. %s", len(missing), missing[0]))
+		}
+
+		for _, table := range []string{"files", "books"} {
+			var collisions int
+			err := tx.NewSelect().
+				TableExpr(table+" AS moved").
+				ColumnExpr("COUNT(*)").
+				Join("JOIN "+table+" AS existing ON existing.library_id = moved.library_id AND existing.filepath = ? || substr(moved.filepath, ?)", newRoot, rest).
+				Where("moved.library_id = ? AND (moved.filepath = ? OR substr(moved.filepath, 1, ?) = ?)", underOldRootArgs...).
+				Scan(ctx, &collisions)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if collisions > 0 {
+				return errcodes.ValidationError(fmt.Sprintf("%d %s already exist under the new path", collisions, table))
+			}
+		}
+
+		now := time.Now()
+		for _, model := range []any{(*models.File)(nil), (*models.Book)(nil)} {
+			_, err := tx.NewUpdate().
+				Model(model).
+				Set("filepath = ? || substr(filepath, ?)", newRoot, rest).
+				Set("updated_at = ?", now).
+				Where(underOldRoot, underOldRootArgs...).
+				Exec(ctx)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		// books_fts stores the book path and its space-separated file paths.
+		_, err = tx.ExecContext(ctx, `
+			UPDATE books_fts
+			SET filepath = CASE
+					WHEN filepath = ? OR substr(filepath, 1, ?) = ? THEN ? || substr(filepath, ?)
+					ELSE filepath
+				END,
+				filenames = replace(filenames, ?, ?)
+			WHERE library_id = ?`,
+			oldRoot, prefixLen, prefix, newRoot, rest,
+			prefix, newRoot+string(filepath.Separator),
+			libraryPath.LibraryID)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		libraryPath.Filepath = newRoot
+		libraryPath.UpdatedAt = now
+		_, err = tx.NewUpdate().Model(libraryPath).Column("filepath", "updated_at").WherePK().Exec(ctx)
+		return errors.WithStack(err)
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return libraryPath, nil
+}
+
+// isSameOrWithin reports whether path is dir or a descendant of it.
+func isSameOrWithin(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
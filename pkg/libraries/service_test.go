@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -322,3 +324,108 @@ func TestDeleteLibrary_Atomicity(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 1, ftsCount, "FTS entry should survive a failed transaction")
 }
+
+// seedRelocatableLibrary creates a library whose single path holds one book
+// with one file, mirrored on disk under a new root as if it had been moved.
+func seedRelocatableLibrary(ctx context.Context, t *testing.T, db *bun.DB) (libraryPathID int, oldRoot, newRoot string, seeded seededIDs) {
+	t.Helper()
+
+	oldRoot = filepath.Join(t.TempDir(), "old")
+	newRoot = filepath.Join(t.TempDir(), "new")
+	seeded = seedLibraryWithContent(ctx, t, db, "Relocate Library")
+
+	libraryPath := &models.LibraryPath{LibraryID: seeded.LibraryID, Filepath: oldRoot}
+	_, err := db.NewInsert().Model(libraryPath).Returning("*").Exec(ctx)
+	require.NoError(t, err)
+
+	bookPath := filepath.Join(oldRoot, "Author", "Book")
+	_, err = db.NewUpdate().Model((*models.Book)(nil)).Set("filepath = ?", bookPath).Where("id = ?", seeded.BookID).Exec(ctx)
+	require.NoError(t, err)
+	_, err = db.NewUpdate().Model((*models.File)(nil)).Set("filepath = ?", filepath.Join(bookPath, "book.epub")).Where("id = ?", seeded.FileID).Exec(ctx)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "UPDATE books_fts SET filepath = ?, filenames = ? WHERE book_id = ?",
+		bookPath, filepath.Join(bookPath, "book.epub"), seeded.BookID)
+	require.NoError(t, err)
+
+	newBookPath := filepath.Join(newRoot, "Author", "Book")
+	require.NoError(t, os.MkdirAll(newBookPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(newBookPath, "book.epub"), []byte("epub"), 0600))
+
+	return libraryPath.ID, oldRoot, newRoot, seeded
+}
+
+func TestRelocatePath_RewritesPaths(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	libraryPathID, _, newRoot, seeded := seedRelocatableLibrary(ctx, t, db)
+
+	libraryPath, err := svc.RelocatePath(ctx, libraryPathID, newRoot)
+	require.NoError(t, err)
+	assert.Equal(t, newRoot, libraryPath.Filepath)
+
+	newBookPath := filepath.Join(newRoot, "Author", "Book")
+	book := &models.Book{}
+	require.NoError(t, db.NewSelect().Model(book).Where("b.id = ?", seeded.BookID).Scan(ctx))
+	assert.Equal(t, newBookPath, book.Filepath)
+
+	file := &models.File{}
+	require.NoError(t, db.NewSelect().Model(file).Where("f.id = ?", seeded.FileID).Scan(ctx))
+	assert.Equal(t, filepath.Join(newBookPath, "book.epub"), file.Filepath)
+
+	var ftsPath, ftsFilenames string
+	err = db.NewSelect().TableExpr("books_fts").Column("filepath", "filenames").Where("book_id = ?", seeded.BookID).Scan(ctx, &ftsPath, &ftsFilenames)
+	require.NoError(t, err)
+	assert.Equal(t, newBookPath, ftsPath)
+	assert.Equal(t, filepath.Join(newBookPath, "book.epub"), ftsFilenames)
+}
+
+func TestRelocatePath_RejectsPartialMove(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	libraryPathID, oldRoot, newRoot, seeded := seedRelocatableLibrary(ctx, t, db)
+	require.NoError(t, os.Remove(filepath.Join(newRoot, "Author", "Book", "book.epub")))
+
+	_, err := svc.RelocatePath(ctx, libraryPathID, newRoot)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found under the new path")
+
+	// Nothing was rewritten.
+	file := &models.File{}
+	require.NoError(t, db.NewSelect().Model(file).Where("f.id = ?", seeded.FileID).Scan(ctx))
+	assert.Equal(t, filepath.Join(oldRoot, "Author", "Book", "book.epub"), file.Filepath)
+}
+
+func TestRelocatePath_RejectsInvalidRoots(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	libraryPathID, _, newRoot, seeded := seedRelocatableLibrary(ctx, t, db)
+
+	_, err := svc.RelocatePath(ctx, libraryPathID, filepath.Join(newRoot, "does-not-exist"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "existing directory")
+
+	// Another library path already covers the new root.
+	other := &models.LibraryPath{LibraryID: seeded.LibraryID, Filepath: filepath.Dir(newRoot)}
+	_, err = db.NewInsert().Model(other).Returning("*").Exec(ctx)
+	require.NoError(t, err)
+	_, err = svc.RelocatePath(ctx, libraryPathID, newRoot)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "overlaps")
+
+	_, err = svc.RelocatePath(ctx, 99999, newRoot)
+	var codeErr *errcodes.Error
+	require.ErrorAs(t, err, &codeErr)
+	assert.Equal(t, "not_found", codeErr.Code)
+}
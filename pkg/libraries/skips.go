@@ -0,0 +1,127 @@
+package libraries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/uptrace/bun"
+)
+
+// scanSkipBatchSize bounds how many skips are inserted per statement so
+// large libraries stay under SQLite's variable limit.
+const scanSkipBatchSize = 500
+
+type ListScanSkipsOptions struct {
+	LibraryID int
+	Reason    *string
+	Path      *string
+	Limit     *int
+	Offset    *int
+}
+
+// ReplaceScanSkips swaps a library's recorded skips for the ones from its
+// latest full scan and returns how many were kept per reason. Paths that are
+// files in the library by now, like supplements picked up later in the same
+// scan, aren't kept.
+func (svc *Service) ReplaceScanSkips(ctx context.Context, libraryID int, jobID *int, skips []*models.ScanSkip) (map[string]int, error) {
+	counts := map[string]int{}
+	err := svc.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().
+			Model((*models.ScanSkip)(nil)).
+			Where("library_id = ?", libraryID).
+			Exec(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		// A path can be recorded more than once, e.g. by a scan worker
+		// after the walk let it through. The last reason wins.
+		now := time.Now()
+		byPath := make(map[string]int, len(skips))
+		rows := make([]*models.ScanSkip, 0, len(skips))
+		for _, skip := range skips {
+			row := &models.ScanSkip{
+				CreatedAt: now,
+				LibraryID: libraryID,
+				JobID:     jobID,
+				Path:      skip.Path,
+				Reason:    skip.Reason,
+				Detail:    skip.Detail,
+			}
+			if i, ok := byPath[skip.Path]; ok {
+				rows[i] = row
+				continue
+			}
+			byPath[skip.Path] = len(rows)
+			rows = append(rows, row)
+		}
+		for start := 0; start < len(rows); start += scanSkipBatchSize {
+			end := min(start+scanSkipBatchSize, len(rows))
+			batch := rows[start:end]
+			if _, err := tx.NewInsert().Model(&batch).Exec(ctx); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		_, err = tx.NewDelete().
+			Model((*models.ScanSkip)(nil)).
+			Where("library_id = ?", libraryID).
+			Where("path IN (SELECT filepath FROM files WHERE library_id = ?)", libraryID).
+			Exec(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		var tallies []struct {
+			Reason string `bun:"reason"`
+			Count  int    `bun:"count"`
+		}
+		err = tx.NewSelect().
+			Model((*models.ScanSkip)(nil)).
+			ColumnExpr("reason, COUNT(*) AS count").
+			Where("library_id = ?", libraryID).
+			Group("reason").
+			Scan(ctx, &tallies)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, t := range tallies {
+			counts[t.Reason] = t.Count
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// ListScanSkipsWithTotal returns a library's recorded skips ordered by path.
+func (svc *Service) ListScanSkipsWithTotal(ctx context.Context, opts ListScanSkipsOptions) ([]*models.ScanSkip, int, error) {
+	skips := []*models.ScanSkip{}
+	q := svc.db.
+		NewSelect().
+		Model(&skips).
+		Where("ss.library_id = ?", opts.LibraryID).
+		Order("ss.path ASC")
+	if opts.Reason != nil {
+		q = q.Where("ss.reason = ?", *opts.Reason)
+	}
+	if opts.Path != nil {
+		q = q.Where("ss.path = ?", *opts.Path)
+	}
+	if opts.Limit != nil {
+		q = q.Limit(*opts.Limit)
+	}
+	if opts.Offset != nil {
+		q = q.Offset(*opts.Offset)
+	}
+	total, err := q.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+	return skips, total, nil
+}
@@ -16,11 +16,30 @@ type ListLibrariesResponse struct {
 }
 
 type CreateLibraryPayload struct {
-	Name                     string   `json:"name" validate:"required,max=100"`
-	OrganizeFileStructure    *bool    `json:"organize_file_structure,omitempty"`
-	CoverAspectRatio         string   `json:"cover_aspect_ratio" validate:"required,oneof=book audiobook book_fallback_audiobook audiobook_fallback_book" tstype:"CoverAspectRatio"`
-	DownloadFormatPreference *string  `json:"download_format_preference,omitempty" validate:"omitempty,oneof=original kepub ask" tstype:"DownloadFormat"`
-	LibraryPaths             []string `json:"library_paths" validate:"required,min=1,max=50,dive"`
+	Name                     string            `json:"name" validate:"required,max=100"`
+	OrganizeFiles            *bool             `json:"organize_files,omitempty"`
+	OrganizeFolders          *bool             `json:"organize_folders,omitempty"`
+	Staging                  *bool             `json:"staging,omitempty"`
+	InferSeriesFromParentDir *bool             `json:"infer_series_from_parent_dir,omitempty"`
+	EnrichFromOpenLibrary    *bool             `json:"enrich_from_open_library,omitempty"`
+	AutoPairFormats          *bool             `json:"auto_pair_formats,omitempty"`
+	StripSeriesFromTitle     *bool             `json:"strip_series_from_title,omitempty"`
+	CBZCoverPageDefault      *int              `json:"cbz_cover_page_default,omitempty" validate:"omitempty,min=1,max=1000"`
+	PreferExternalCover      *bool             `json:"prefer_external_cover,omitempty"`
+	CoverAspectRatio         string            `json:"cover_aspect_ratio" validate:"required,oneof=book audiobook book_fallback_audiobook audiobook_fallback_book" tstype:"CoverAspectRatio"`
+	DownloadFormatPreference *string           `json:"download_format_preference,omitempty" validate:"omitempty,oneof=original kepub ask" tstype:"DownloadFormat"`
+	AllowedFileTypes         []string          `json:"allowed_file_types,omitempty" validate:"omitempty,max=20,dive,min=1,max=20"`
+	FilenamePatterns         []string          `json:"filename_patterns,omitempty" validate:"omitempty,max=20,dive,min=1,max=500"`
+	NameSeparators           []string          `json:"name_separators,omitempty" validate:"omitempty,max=20,dive,min=1,max=20"`
+	ScanSchedule             *string           `json:"scan_schedule,omitempty" validate:"omitempty,max=100" tstype:"string"`
+	OrganizeSubfolders       map[string]string `json:"organize_subfolders,omitempty" validate:"omitempty,max=20"`
+	LibraryPaths             []string          `json:"library_paths" validate:"required,min=1,max=50,dive"`
+}
+
+// RelocateLibraryPathPayload moves a library path to the directory its
+// contents were moved to on disk.
+type RelocateLibraryPathPayload struct {
+	Filepath string `json:"filepath" validate:"required"`
 }
 
 type ListLibrariesQuery struct {
@@ -29,9 +48,57 @@ type ListLibrariesQuery struct {
 }
 
 type UpdateLibraryPayload struct {
-	Name                     *string  `json:"name,omitempty" validate:"omitempty,max=100"`
-	OrganizeFileStructure    *bool    `json:"organize_file_structure,omitempty"`
-	CoverAspectRatio         *string  `json:"cover_aspect_ratio,omitempty" validate:"omitempty,oneof=book audiobook book_fallback_audiobook audiobook_fallback_book" tstype:"CoverAspectRatio"`
-	DownloadFormatPreference *string  `json:"download_format_preference,omitempty" validate:"omitempty,oneof=original kepub ask" tstype:"DownloadFormat"`
-	LibraryPaths             []string `json:"library_paths,omitempty" validate:"omitempty,min=1,max=50,dive"`
+	Name                     *string           `json:"name,omitempty" validate:"omitempty,max=100"`
+	OrganizeFiles            *bool             `json:"organize_files,omitempty"`
+	OrganizeFolders          *bool             `json:"organize_folders,omitempty"`
+	Staging                  *bool             `json:"staging,omitempty"`
+	InferSeriesFromParentDir *bool             `json:"infer_series_from_parent_dir,omitempty"`
+	EnrichFromOpenLibrary    *bool             `json:"enrich_from_open_library,omitempty"`
+	AutoPairFormats          *bool             `json:"auto_pair_formats,omitempty"`
+	StripSeriesFromTitle     *bool             `json:"strip_series_from_title,omitempty"`
+	CBZCoverPageDefault      *int              `json:"cbz_cover_page_default,omitempty" validate:"omitempty,min=1,max=1000"`
+	PreferExternalCover      *bool             `json:"prefer_external_cover,omitempty"`
+	CoverAspectRatio         *string           `json:"cover_aspect_ratio,omitempty" validate:"omitempty,oneof=book audiobook book_fallback_audiobook audiobook_fallback_book" tstype:"CoverAspectRatio"`
+	DownloadFormatPreference *string           `json:"download_format_preference,omitempty" validate:"omitempty,oneof=original kepub ask" tstype:"DownloadFormat"`
+	AllowedFileTypes         []string          `json:"allowed_file_types,omitempty" validate:"omitempty,max=20,dive,min=1,max=20"` // An empty list allows all types again
+	FilenamePatterns         []string          `json:"filename_patterns,omitempty" validate:"omitempty,max=20,dive,min=1,max=500"` // An empty list goes back to the built-in conventions only
+	NameSeparators           []string          `json:"name_separators,omitempty" validate:"omitempty,max=20,dive,min=1,max=20"`    // An empty list goes back to the default separators
+	ScanSchedule             *string           `json:"scan_schedule,omitempty" validate:"omitempty,max=100" tstype:"string"`       // An empty string removes the schedule
+	OrganizeSubfolders       map[string]string `json:"organize_subfolders,omitempty" validate:"omitempty,max=20"`                  // An empty map organizes every type at the root again
+	LibraryPaths             []string          `json:"library_paths,omitempty" validate:"omitempty,min=1,max=50,dive"`
+}
+
+// ListScanSkipsQuery filters a library's recorded scan skips. Path matches
+// exactly, to answer why a particular file wasn't imported.
+type ListScanSkipsQuery struct {
+	Limit  int     `query:"limit" json:"limit,omitempty" default:"50" validate:"min=1,max=500"`
+	Offset int     `query:"offset" json:"offset,omitempty" validate:"min=0"`
+	Reason *string `query:"reason" json:"reason,omitempty" validate:"omitempty,oneof=ignored disallowed_type unsupported_file_type too_small unreadable mime_mismatch drm_protected" tstype:"ScanSkipReason"`
+	Path   *string `query:"path" json:"path,omitempty"`
+}
+
+// ListScanSkipsResponse is the scan skips list-endpoint envelope.
+type ListScanSkipsResponse struct {
+	Items []*models.ScanSkip `json:"items" tstype:"ScanSkip[]"`
+	Total int                `json:"total"`
+}
+
+// ListScanErrorsQuery filters a library's outstanding scan errors.
+type ListScanErrorsQuery struct {
+	Limit  int     `query:"limit" json:"limit,omitempty" default:"50" validate:"min=1,max=500"`
+	Offset int     `query:"offset" json:"offset,omitempty" validate:"min=0"`
+	Code   *string `query:"code" json:"code,omitempty" validate:"omitempty,max=100"`
+}
+
+// ListScanErrorsResponse is the scan errors list-endpoint envelope.
+type ListScanErrorsResponse struct {
+	Items []*models.ScanError `json:"items" tstype:"ScanError[]"`
+	Total int                 `json:"total"`
+}
+
+// RetryScanErrorResponse is the result of retrying a scan error. Error is
+// the updated error when the file still fails to scan.
+type RetryScanErrorResponse struct {
+	Resolved bool              `json:"resolved"`
+	Error    *models.ScanError `json:"error,omitempty" tstype:"ScanError"`
 }
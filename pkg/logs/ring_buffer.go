@@ -159,9 +159,11 @@ var knownFields = map[string]bool{
 // fetching /logs generates a log entry that triggers an SSE event that
 // causes another fetch).
 var skipRoutes = map[string]bool{
-	"/logs":   true,
-	"/events": true,
-	"/health": true,
+	"/logs":    true,
+	"/events":  true,
+	"/health":  true,
+	"/healthz": true,
+	"/readyz":  true,
 }
 
 func parseLogLine(line []byte) (LogEntry, bool) {
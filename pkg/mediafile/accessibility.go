@@ -0,0 +1,40 @@
+package mediafile
+
+import "strings"
+
+// Accessibility metadata keys. These are the schema.org property names used by
+// EPUB 3 accessibility metadata, without the "schema:" prefix.
+const (
+	AccessibilityAccessMode           = "accessMode"
+	AccessibilityAccessModeSufficient = "accessModeSufficient"
+	AccessibilityFeature              = "accessibilityFeature"
+	AccessibilityHazard               = "accessibilityHazard"
+	AccessibilitySummary              = "accessibilitySummary"
+)
+
+// IsAccessibilityKey reports whether key is one of the Accessibility* keys.
+func IsAccessibilityKey(key string) bool {
+	switch key {
+	case AccessibilityAccessMode, AccessibilityAccessModeSufficient, AccessibilityFeature,
+		AccessibilityHazard, AccessibilitySummary:
+		return true
+	}
+	return false
+}
+
+// SupportsTextToSpeech reports whether accessibility metadata says a book can
+// be read aloud: text alone is a sufficient access mode, or the book declares
+// text-to-speech markup.
+func SupportsTextToSpeech(accessibility map[string][]string) bool {
+	for _, mode := range accessibility[AccessibilityAccessModeSufficient] {
+		if strings.EqualFold(strings.TrimSpace(mode), "textual") {
+			return true
+		}
+	}
+	for _, feature := range accessibility[AccessibilityFeature] {
+		if strings.EqualFold(feature, "ttsMarkup") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package mediafile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupportsTextToSpeech(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		accessibility map[string][]string
+		want          bool
+	}{
+		{"no metadata", nil, false},
+		{"textual is sufficient", map[string][]string{AccessibilityAccessModeSufficient: {"textual"}}, true},
+		{"textual needs visual too", map[string][]string{AccessibilityAccessModeSufficient: {"textual,visual"}}, false},
+		{"tts markup", map[string][]string{AccessibilityFeature: {"alternativeText", "ttsMarkup"}}, true},
+		{"textual access mode alone", map[string][]string{AccessibilityAccessMode: {"textual"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, SupportsTextToSpeech(tt.accessibility))
+		})
+	}
+}
@@ -14,6 +14,13 @@ type ParsedAuthor struct {
 	Role string `json:"role"` // empty for generic author, or one of: writer, penciller, inker, colorist, letterer, cover_artist, editor, translator
 }
 
+// ParsedNarrator represents a narrator with the part they read, for
+// full-cast audiobooks that credit narrators by character.
+type ParsedNarrator struct {
+	Name string `json:"name"`
+	Role string `json:"role,omitempty"` // e.g. "Kaladin" or "Narrator"; empty when the file doesn't say
+}
+
 // ParsedIdentifier represents an identifier parsed from file metadata.
 type ParsedIdentifier struct {
 	Type  string `json:"type"`  // One of the IdentifierType constants (isbn_10, isbn_13, asin, uuid, goodreads, google, other)
@@ -27,30 +34,54 @@ type ParsedChapter struct {
 	StartPage        *int            `json:"start_page,omitempty"`         // CBZ: 0-indexed page number
 	StartTimestampMs *int64          `json:"start_timestamp_ms,omitempty"` // M4B: milliseconds from start
 	Href             *string         `json:"href,omitempty"`               // EPUB: content document href
+	CoverPageIndex   *int            `json:"cover_page_index,omitempty"`   // CBZ: 0-indexed page for the chapter's thumbnail
 	Children         []ParsedChapter `json:"children,omitempty"`           // EPUB nesting only; CBZ/M4B always empty
 }
 
+// ParsedSeries represents a series membership beyond the primary one, such as
+// a ComicInfo story arc. Number, NumberEnd, and Unit follow the same rules as
+// ParsedMetadata's SeriesNumber group. ReadingOrder is independent of that
+// group, like ParsedMetadata's SeriesReadingOrder.
+type ParsedSeries struct {
+	Name         string   `json:"name"`
+	Number       *float64 `json:"number,omitempty"`
+	NumberEnd    *float64 `json:"number_end,omitempty"`
+	Unit         *string  `json:"unit,omitempty" tstype:"SeriesNumberUnit"`
+	ReadingOrder *float64 `json:"reading_order,omitempty"`
+}
+
 type ParsedMetadata struct {
-	Title           string         `json:"title"`
-	Subtitle        string         `json:"subtitle"` // from M4B freeform SUBTITLE atom
-	Authors         []ParsedAuthor `json:"authors"`
-	Narrators       []string       `json:"narrators"`
-	Series          string         `json:"series"`
-	SeriesNumber    *float64       `json:"series_number,omitempty"`
-	SeriesNumberEnd *float64       `json:"series_number_end,omitempty"`
+	Title     string         `json:"title"`
+	Subtitle  string         `json:"subtitle"` // from M4B freeform SUBTITLE atom
+	Authors   []ParsedAuthor `json:"authors"`
+	Narrators []string       `json:"narrators"`
+	// NarratorRoles optionally repeats Narrators with the role each one
+	// read. It's only used while it lists the same names as Narrators, in
+	// the same order; see NarratorsWithRoles.
+	NarratorRoles   []ParsedNarrator `json:"narrator_roles,omitempty"`
+	Series          string           `json:"series"`
+	SeriesNumber    *float64         `json:"series_number,omitempty"`
+	SeriesNumberEnd *float64         `json:"series_number_end,omitempty"`
 	// SeriesNumberUnit indicates whether SeriesNumber refers to a volume or a
 	// chapter. CBZ-only — null for other formats. Valid values: "volume", "chapter".
-	SeriesNumberUnit *string    `json:"series_number_unit,omitempty" tstype:"SeriesNumberUnit"`
-	Genres           []string   `json:"genres"` // Genre names from file metadata
-	Tags             []string   `json:"tags"`   // Tag names from file metadata
-	Description      string     `json:"description"`
-	Publisher        string     `json:"publisher"`
-	URL              string     `json:"url"`
-	ReleaseDate      *time.Time `json:"release_date,omitempty"`
-	CoverMimeType    string     `json:"cover_mime_type"`
-	CoverURL         string     `json:"cover_url"`
-	CoverData        []byte     `json:"-"`
-	CoverPage        *int       `json:"cover_page,omitempty"` // 0-indexed page number for CBZ cover, nil for other file types
+	SeriesNumberUnit *string `json:"series_number_unit,omitempty" tstype:"SeriesNumberUnit"`
+	// SeriesReadingOrder is the position in the series' reading order when it
+	// differs from publication order (SeriesNumber). Nil means the same.
+	SeriesReadingOrder *float64 `json:"series_reading_order,omitempty"`
+	// AdditionalSeries lists series memberships after the primary one above,
+	// in sort order. They're only applied alongside a primary Series.
+	AdditionalSeries []ParsedSeries `json:"additional_series,omitempty"`
+	Genres           []string       `json:"genres"` // Genre names from file metadata
+	Tags             []string       `json:"tags"`   // Tag names from file metadata
+	Description      string         `json:"description"`
+	Publisher        string         `json:"publisher"`
+	URL              string         `json:"url"`
+	ReleaseDate      *time.Time     `json:"release_date,omitempty"`
+	ModifiedDate     *time.Time     `json:"modified_date,omitempty"` // When the file says its content was last revised (EPUB dcterms:modified)
+	CoverMimeType    string         `json:"cover_mime_type"`
+	CoverURL         string         `json:"cover_url"`
+	CoverData        []byte         `json:"-"`
+	CoverPage        *int           `json:"cover_page,omitempty"` // 0-indexed page number for CBZ cover, nil for other file types
 	// DataSource should be a value of books.DataSource
 	DataSource string `json:"-"`
 	// FieldDataSources maps individual field names to the data source that provided them.
@@ -59,20 +90,36 @@ type ParsedMetadata struct {
 	// "genres", "tags", "description", "publisher", "url", "releaseDate",
 	// "cover", "identifiers", "language", "abridged".
 	FieldDataSources map[string]string `json:"-"`
-	PluginScope      string            `json:"-"`
-	PluginID         string            `json:"-"`
+	// CoverPreferred is set during scan enrichment when the plugin that
+	// supplied CoverData is configured to prefer its cover over covers read
+	// from file metadata.
+	CoverPreferred bool   `json:"-"`
+	PluginScope    string `json:"-"`
+	PluginID       string `json:"-"`
 	// Duration is the length of the audiobook (M4B files only)
 	Duration time.Duration `json:"duration"`
 	// BitrateBps is the audio bitrate in bits per second (M4B files only)
 	BitrateBps int `json:"bitrate_bps"`
 	// Codec is the audio codec with profile (M4B files only), e.g. "AAC-LC", "xHE-AAC"
 	Codec string `json:"-"`
+	// SampleRateHz is the audio sample rate in Hz (M4B files only)
+	SampleRateHz int `json:"-"`
+	// Channels is the number of audio channels (M4B files only)
+	Channels int `json:"-"`
 	// Language is a BCP 47 language tag (e.g., "en", "en-US", "zh-Hans")
 	Language *string `json:"language,omitempty"`
 	// Abridged indicates whether this is an abridged edition
 	Abridged *bool `json:"abridged,omitempty"`
 	// PageCount is the number of pages (CBZ and PDF files)
 	PageCount *int `json:"page_count,omitempty"`
+	// WordCount is an estimate of the number of words in the text (EPUB files only)
+	WordCount *int `json:"word_count,omitempty"`
+	// ReadingDirection is the page turn direction, models.ReadingDirectionLTR
+	// or models.ReadingDirectionRTL, when the file declares one (CBZ files only)
+	ReadingDirection string `json:"reading_direction,omitempty"`
+	// Accessibility holds schema.org accessibility metadata keyed by property
+	// name (see the Accessibility* constants), in file order (EPUB files only)
+	Accessibility map[string][]string `json:"-"`
 	// Identifiers contains file identifiers (ISBN, ASIN, etc.) parsed from metadata
 	Identifiers []ParsedIdentifier `json:"identifiers"`
 	// Chapters contains chapter information parsed from file metadata
@@ -83,6 +130,27 @@ type ParsedMetadata struct {
 	Confidence *float64 `json:"confidence,omitempty"`
 }
 
+// NarratorsWithRoles returns Narrators along with their roles. Roles are
+// dropped when NarratorRoles no longer lines up with Narrators, like after
+// an enricher replaced the narrator names.
+func (m *ParsedMetadata) NarratorsWithRoles() []ParsedNarrator {
+	if len(m.Narrators) == 0 {
+		return nil
+	}
+	aligned := len(m.NarratorRoles) == len(m.Narrators)
+	for i := 0; aligned && i < len(m.Narrators); i++ {
+		aligned = m.NarratorRoles[i].Name == m.Narrators[i]
+	}
+	if aligned {
+		return m.NarratorRoles
+	}
+	narrators := make([]ParsedNarrator, len(m.Narrators))
+	for i, name := range m.Narrators {
+		narrators[i] = ParsedNarrator{Name: name}
+	}
+	return narrators
+}
+
 func (m *ParsedMetadata) String() string {
 	authorNames := make([]string, len(m.Authors))
 	for i, a := range m.Authors {
@@ -95,6 +163,28 @@ func (m *ParsedMetadata) String() string {
 	return fmt.Sprintf("Title:           %s\nAuthor(s):       %v\nNarrator(s):     %v\nHas Cover Data:  %v\nCover Mime Type: %s\nData Source:     %s", m.Title, strings.Join(authorNames, ", "), m.Narrators, len(m.CoverData) > 0, m.CoverMimeType, m.DataSource)
 }
 
+// AllSeries returns the primary series followed by AdditionalSeries, skipping
+// entries without a name. It returns nil when there's no primary series.
+func (m *ParsedMetadata) AllSeries() []ParsedSeries {
+	if m.Series == "" {
+		return nil
+	}
+	all := make([]ParsedSeries, 0, 1+len(m.AdditionalSeries))
+	all = append(all, ParsedSeries{
+		Name:         m.Series,
+		Number:       m.SeriesNumber,
+		NumberEnd:    m.SeriesNumberEnd,
+		Unit:         m.SeriesNumberUnit,
+		ReadingOrder: m.SeriesReadingOrder,
+	})
+	for _, s := range m.AdditionalSeries {
+		if s.Name != "" {
+			all = append(all, s)
+		}
+	}
+	return all
+}
+
 // SourceForField returns the data source for a specific field.
 // If a per-field source is set, it returns that; otherwise falls back to DataSource.
 func (m *ParsedMetadata) SourceForField(field string) string {
@@ -113,6 +203,12 @@ func (m *ParsedMetadata) CoverExtension() string {
 		ext = ".jpg"
 	case "image/png":
 		ext = ".png"
+	case "image/webp":
+		ext = ".webp"
+	case "image/avif":
+		ext = ".avif"
+	case "image/gif":
+		ext = ".gif"
 	}
 	return ext
 }
@@ -28,3 +28,63 @@ func TestParsedMetadataIdentifiers(t *testing.T) {
 	assert.Len(t, m.Identifiers, 2)
 	assert.Equal(t, "isbn_13", m.Identifiers[0].Type)
 }
+
+func TestParsedMetadataCoverExtension(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		mimeType string
+		want     string
+	}{
+		{"image/jpeg", ".jpg"},
+		{"image/png", ".png"},
+		{"image/webp", ".webp"},
+		{"image/avif", ".avif"},
+		{"image/gif", ".gif"},
+		{"application/octet-stream", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mimeType, func(t *testing.T) {
+			t.Parallel()
+			m := ParsedMetadata{CoverMimeType: tt.mimeType}
+			assert.Equal(t, tt.want, m.CoverExtension())
+		})
+	}
+}
+
+func TestParsedMetadataAllSeries(t *testing.T) {
+	t.Parallel()
+	one, two := 1.0, 2.0
+
+	m := ParsedMetadata{
+		Series:       "Saga",
+		SeriesNumber: &one,
+		AdditionalSeries: []ParsedSeries{
+			{Name: "The War for Phang", Number: &two},
+			{Name: ""},
+		},
+	}
+	assert.Equal(t, []ParsedSeries{
+		{Name: "Saga", Number: &one},
+		{Name: "The War for Phang", Number: &two},
+	}, m.AllSeries())
+
+	// Additional series are only used alongside a primary series.
+	assert.Nil(t, (&ParsedMetadata{AdditionalSeries: []ParsedSeries{{Name: "Arc"}}}).AllSeries())
+}
+
+func TestParsedMetadataNarratorsWithRoles(t *testing.T) {
+	t.Parallel()
+
+	m := ParsedMetadata{
+		Narrators:     []string{"Michael Kramer", "Kate Reading"},
+		NarratorRoles: []ParsedNarrator{{Name: "Michael Kramer", Role: "Kaladin"}, {Name: "Kate Reading", Role: "Shallan"}},
+	}
+	assert.Equal(t, m.NarratorRoles, m.NarratorsWithRoles())
+
+	// An enricher replaced the names, so the roles no longer apply.
+	m.Narrators = []string{"Someone Else"}
+	assert.Equal(t, []ParsedNarrator{{Name: "Someone Else"}}, m.NarratorsWithRoles())
+
+	assert.Nil(t, (&ParsedMetadata{}).NarratorsWithRoles())
+}
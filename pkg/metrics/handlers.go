@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/robinjoseph08/golib/echo/v4/middleware/logger"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// countTimeout bounds the job count query, so a wedged database doesn't hang
+// the scrape.
+const countTimeout = 5 * time.Second
+
+// WorkerState is the part of the worker that the queue gauges report on.
+type WorkerState interface {
+	InFlightJobs() int
+}
+
+// JobCounter counts jobs by status.
+type JobCounter interface {
+	CountJobsByStatus(ctx context.Context, status string) (int, error)
+}
+
+// Handler serves the metrics in a registry along with the job queue gauges.
+type Handler struct {
+	registry prometheus.Gatherer
+	worker   WorkerState
+	jobs     JobCounter
+}
+
+// NewHandler returns a handler for registry. worker and jobs supply the job
+// queue gauges, which are read at scrape time.
+func NewHandler(registry prometheus.Gatherer, worker WorkerState, jobs JobCounter) *Handler {
+	return &Handler{
+		registry: registry,
+		worker:   worker,
+		jobs:     jobs,
+	}
+}
+
+func (h *Handler) metrics(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), countTimeout)
+	defer cancel()
+
+	// The queue gauges are gathered from a registry of their own, built per
+	// scrape, so a failed count can be left out of the response instead of
+	// reported as an empty queue.
+	queue := prometheus.NewRegistry()
+	pending, err := h.jobs.CountJobsByStatus(ctx, models.JobStatusPending)
+	if err != nil {
+		logger.FromEchoContext(c).Err(err).Warn("failed to count pending jobs for metrics")
+	} else {
+		queue.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "shisho_worker_queue_depth",
+			Help: "Jobs waiting to be run.",
+		}, func() float64 { return float64(pending) }))
+	}
+	queue.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "shisho_worker_jobs_in_flight",
+		Help: "Jobs this process is running.",
+	}, func() float64 { return float64(h.worker.InFlightJobs()) }))
+
+	promhttp.HandlerFor(prometheus.Gatherers{h.registry, queue}, promhttp.HandlerOpts{}).
+		ServeHTTP(c.Response(), c.Request())
+	return nil
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWorker struct {
+	inFlight int
+}
+
+func (f *fakeWorker) InFlightJobs() int { return f.inFlight }
+
+type fakeJobs struct {
+	pending int
+	err     error
+}
+
+func (f *fakeJobs) CountJobsByStatus(_ context.Context, _ string) (int, error) {
+	return f.pending, f.err
+}
+
+func runMetrics(t *testing.T, h *Handler) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.metrics(e.NewContext(req, rec)))
+	return rec
+}
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	r := prometheus.NewRegistry()
+	files := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_files_total", Help: "Files seen."}, []string{"result"})
+	r.MustRegister(files)
+	files.WithLabelValues("created").Inc()
+	rec := runMetrics(t, NewHandler(r, &fakeWorker{inFlight: 1}, &fakeJobs{pending: 4}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/plain")
+	body := rec.Body.String()
+	assert.Contains(t, body, "test_files_total{result=\"created\"} 1\n")
+	assert.Contains(t, body, "# TYPE shisho_worker_queue_depth gauge\nshisho_worker_queue_depth 4\n")
+	assert.Contains(t, body, "# TYPE shisho_worker_jobs_in_flight gauge\nshisho_worker_jobs_in_flight 1\n")
+}
+
+func TestMetrics_OmitsQueueDepthWhenCountFails(t *testing.T) {
+	t.Parallel()
+
+	rec := runMetrics(t, NewHandler(prometheus.NewRegistry(), &fakeWorker{}, &fakeJobs{err: errors.New("database is locked")}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "shisho_worker_queue_depth")
+	assert.Contains(t, rec.Body.String(), "shisho_worker_jobs_in_flight 0\n")
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+)
+
+// Default is the registry the metrics below are recorded in. They're
+// recorded whether or not the /metrics endpoint is enabled.
+var Default = prometheus.NewRegistry()
+
+// DefaultBuckets are the histogram bucket upper bounds, in seconds, for
+// per-file timings. They cover a few milliseconds to a few minutes.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+
+var (
+	// ScanFiles counts the files processed by scan jobs, by result: created,
+	// updated, unchanged, deleted, skipped, or errored.
+	ScanFiles = promauto.With(Default).NewCounterVec(prometheus.CounterOpts{
+		Name: "shisho_scan_files_total",
+		Help: "Files processed by scan jobs, by result.",
+	}, []string{"result"})
+	// ScanErrors counts the files that failed to scan, by error code.
+	ScanErrors = promauto.With(Default).NewCounterVec(prometheus.CounterOpts{
+		Name: "shisho_scan_errors_total",
+		Help: "Files that failed to scan, by error code.",
+	}, []string{"code"})
+	// ScanFileDuration is how long applying a file's metadata to its book
+	// took, by file type.
+	ScanFileDuration = promauto.With(Default).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "shisho_scan_file_duration_seconds",
+		Help:    "Time spent applying a scanned file's metadata, by file type.",
+		Buckets: DefaultBuckets,
+	}, []string{"file_type"})
+	// ParseDuration is how long parsing a file's metadata took, by file type.
+	ParseDuration = promauto.With(Default).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "shisho_file_parse_duration_seconds",
+		Help:    "Time spent parsing file metadata, by file type.",
+		Buckets: DefaultBuckets,
+	}, []string{"file_type"})
+	// JobDuration is how long jobs ran, by type and final status.
+	JobDuration = promauto.With(Default).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "shisho_job_duration_seconds",
+		Help:    "Time spent running jobs, by type and final status.",
+		Buckets: []float64{.1, .5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600, 7200},
+	}, []string{"type", "status"})
+)
+
+// ErrorCode returns the label used for err in ScanErrors: the code of an
+// errcodes error, "canceled" or "timeout" for context errors, and "unknown"
+// for anything else.
+func ErrorCode(err error) string {
+	var e *errcodes.Error
+	switch {
+	case errors.As(err, &e):
+		return e.Code
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	}
+	return "unknown"
+}
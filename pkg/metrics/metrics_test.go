@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault_Gathers(t *testing.T) {
+	t.Parallel()
+
+	ScanFiles.WithLabelValues("created").Inc()
+
+	families, err := Default.Gather()
+	require.NoError(t, err)
+	names := make([]string, 0, len(families))
+	for _, f := range families {
+		names = append(names, f.GetName())
+	}
+	assert.Contains(t, names, "shisho_scan_files_total")
+}
+
+func TestErrorCode(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "drm_protected", ErrorCode(errors.Wrap(errcodes.DRMProtected(), "failed to parse file")))
+	assert.Equal(t, "unsupported_file_type", ErrorCode(errcodes.UnsupportedFileType("xyz")))
+	assert.Equal(t, "canceled", ErrorCode(errors.WithStack(context.Canceled)))
+	assert.Equal(t, "timeout", ErrorCode(context.DeadlineExceeded))
+	assert.Equal(t, "unknown", ErrorCode(errors.New("bad zip")))
+}
@@ -0,0 +1,10 @@
+package metrics
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes registers the unauthenticated GET /metrics route.
+func RegisterRoutes(e *echo.Echo, h *Handler) {
+	e.GET("/metrics", h.metrics)
+}
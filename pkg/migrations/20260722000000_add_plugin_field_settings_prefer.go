@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`ALTER TABLE plugin_field_settings ADD COLUMN prefer BOOLEAN NOT NULL DEFAULT false`)
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`ALTER TABLE plugin_field_settings DROP COLUMN prefer`)
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
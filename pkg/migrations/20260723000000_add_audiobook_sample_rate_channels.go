@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE files ADD COLUMN audiobook_sample_rate INTEGER")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE files ADD COLUMN audiobook_channels INTEGER")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE files DROP COLUMN audiobook_channels")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE files DROP COLUMN audiobook_sample_rate")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE libraries ADD COLUMN staging BOOLEAN NOT NULL DEFAULT false")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE books ADD COLUMN staged BOOLEAN NOT NULL DEFAULT false")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE books DROP COLUMN staged")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE libraries DROP COLUMN staging")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE collections (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				library_id INTEGER REFERENCES libraries (id) ON DELETE CASCADE NOT NULL,
+				name TEXT NOT NULL,
+				description TEXT
+			)
+		`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`CREATE UNIQUE INDEX ux_collections_name_library_id ON collections (name COLLATE NOCASE, library_id)`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`CREATE INDEX ix_collections_library_id ON collections (library_id)`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		_, err = db.Exec(`
+			CREATE TABLE book_collections (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				book_id INTEGER REFERENCES books (id) ON DELETE CASCADE NOT NULL,
+				collection_id INTEGER REFERENCES collections (id) ON DELETE CASCADE NOT NULL
+			)
+		`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`CREATE INDEX ix_book_collections_book_id ON book_collections (book_id)`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`CREATE INDEX ix_book_collections_collection_id ON book_collections (collection_id)`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`CREATE UNIQUE INDEX ux_book_collections_book_collection ON book_collections (book_id, collection_id)`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		_, err = db.Exec(`
+			CREATE VIRTUAL TABLE collections_fts USING fts5(
+				collection_id UNINDEXED,
+				library_id UNINDEXED,
+				name,
+				description,
+				tokenize='unicode61',
+				prefix='2,3'
+			)
+		`)
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("DROP TABLE IF EXISTS collections_fts")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("DROP TABLE IF EXISTS book_collections")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("DROP TABLE IF EXISTS collections")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+// Cover pages were stored 0-indexed; they're now 1-indexed to match what
+// readers display and what people type into sidecars.
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("UPDATE files SET cover_page = cover_page + 1 WHERE cover_page IS NOT NULL")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("UPDATE files SET cover_page = cover_page - 1 WHERE cover_page IS NOT NULL")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE books ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT false")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE files ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT false")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE files DROP COLUMN hidden")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE books DROP COLUMN hidden")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`CREATE INDEX ix_books_library_id_created_at ON books (library_id, created_at)`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`CREATE INDEX ix_books_library_id_updated_at ON books (library_id, updated_at)`)
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`DROP INDEX IF EXISTS ix_books_library_id_updated_at`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`DROP INDEX IF EXISTS ix_books_library_id_created_at`)
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
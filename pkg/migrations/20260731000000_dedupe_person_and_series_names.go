@@ -0,0 +1,148 @@
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	type joinTable struct {
+		name string
+		// scope lists the columns that, together with the resource FK, make a
+		// row unique. A row that would collide after repointing is dropped.
+		scope []string
+	}
+	resources := []struct {
+		table      string
+		fk         string
+		aliasTable string
+		ftsTable   string
+		joins      []joinTable
+	}{
+		{
+			table:      "persons",
+			fk:         "person_id",
+			aliasTable: "person_aliases",
+			ftsTable:   "persons_fts",
+			joins: []joinTable{
+				{name: "authors", scope: []string{"book_id", "role"}},
+				{name: "narrators", scope: []string{"file_id"}},
+			},
+		},
+		{
+			table:      "series",
+			fk:         "series_id",
+			aliasTable: "series_aliases",
+			ftsTable:   "series_fts",
+			joins: []joinTable{
+				{name: "book_series", scope: []string{"book_id"}},
+			},
+		},
+	}
+
+	up := func(ctx context.Context, db *bun.DB) error {
+		// FindOrCreatePerson/FindOrCreateSeries now collapse whitespace in
+		// names (aliases.NormalizeName) so that e.g. "Brandon  Sanderson" and
+		// "Brandon Sanderson" resolve to one row. Older scans stored the raw
+		// names, so merge rows that only differed in spacing into the oldest
+		// one and rewrite the survivors to the normalized form. The existing
+		// (name COLLATE NOCASE, library_id) unique indexes then enforce it.
+		for _, r := range resources {
+			rows, err := db.QueryContext(ctx, `SELECT id, library_id, name FROM `+r.table+` ORDER BY id`)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			type row struct {
+				id         int
+				normalized string
+				rename     bool
+			}
+			type groupKey struct {
+				libraryID int
+				name      string
+			}
+			keepers := map[groupKey]int{}
+			var survivors []row
+			duplicates := map[int]int{} // duplicate ID -> keeper ID
+			for rows.Next() {
+				var (
+					id        int
+					libraryID int
+					name      string
+				)
+				if err := rows.Scan(&id, &libraryID, &name); err != nil {
+					_ = rows.Close()
+					return errors.WithStack(err)
+				}
+				// Same as aliases.NormalizeName, inlined so this migration
+				// doesn't depend on (or import-cycle with) the aliases package.
+				normalized := strings.Join(strings.Fields(name), " ")
+				key := groupKey{libraryID: libraryID, name: strings.ToLower(normalized)}
+				if keeperID, ok := keepers[key]; ok {
+					duplicates[id] = keeperID
+					continue
+				}
+				keepers[key] = id
+				survivors = append(survivors, row{id: id, normalized: normalized, rename: normalized != name})
+			}
+			if err := rows.Err(); err != nil {
+				_ = rows.Close()
+				return errors.WithStack(err)
+			}
+			if err := rows.Close(); err != nil {
+				return errors.WithStack(err)
+			}
+
+			for dupID, keeperID := range duplicates {
+				for _, j := range r.joins {
+					conds := make([]string, 0, len(j.scope))
+					for _, col := range j.scope {
+						conds = append(conds, "k."+col+" IS "+j.name+"."+col)
+					}
+					dropCollisions := `DELETE FROM ` + j.name + ` WHERE ` + r.fk + ` = ? AND EXISTS (
+						SELECT 1 FROM ` + j.name + ` k WHERE k.` + r.fk + ` = ? AND ` + strings.Join(conds, " AND ") + `
+					)`
+					if _, err := db.ExecContext(ctx, dropCollisions, dupID, keeperID); err != nil {
+						return errors.WithStack(err)
+					}
+					if _, err := db.ExecContext(ctx, `UPDATE `+j.name+` SET `+r.fk+` = ? WHERE `+r.fk+` = ?`, keeperID, dupID); err != nil {
+						return errors.WithStack(err)
+					}
+				}
+				if _, err := db.ExecContext(ctx, `UPDATE `+r.aliasTable+` SET `+r.fk+` = ? WHERE `+r.fk+` = ?`, keeperID, dupID); err != nil {
+					return errors.WithStack(err)
+				}
+				if _, err := db.ExecContext(ctx, `DELETE FROM `+r.ftsTable+` WHERE `+r.fk+` = ?`, dupID); err != nil {
+					return errors.WithStack(err)
+				}
+				if _, err := db.ExecContext(ctx, `DELETE FROM `+r.table+` WHERE id = ?`, dupID); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+
+			for _, s := range survivors {
+				if !s.rename {
+					continue
+				}
+				if _, err := db.ExecContext(ctx, `UPDATE `+r.table+` SET name = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, s.normalized, s.id); err != nil {
+					return errors.WithStack(err)
+				}
+				if _, err := db.ExecContext(ctx, `UPDATE `+r.ftsTable+` SET name = ? WHERE `+r.fk+` = ?`, s.normalized, s.id); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+		}
+		return nil
+	}
+
+	down := func(_ context.Context, _ *bun.DB) error {
+		// Merged rows and their original spacing can't be restored. No-op
+		// rollback.
+		return nil
+	}
+
+	Migrations.MustRegister(up, down)
+}
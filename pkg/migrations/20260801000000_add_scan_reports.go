@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE scan_reports (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+				books_created INTEGER NOT NULL DEFAULT 0,
+				books_updated INTEGER NOT NULL DEFAULT 0,
+				books_deleted INTEGER NOT NULL DEFAULT 0,
+				files_created INTEGER NOT NULL DEFAULT 0,
+				files_updated INTEGER NOT NULL DEFAULT 0,
+				files_deleted INTEGER NOT NULL DEFAULT 0,
+				files_skipped INTEGER NOT NULL DEFAULT 0,
+				files_errored INTEGER NOT NULL DEFAULT 0,
+				errors TEXT
+			)
+		`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		// One report per job.
+		_, err = db.Exec(`CREATE UNIQUE INDEX ux_scan_reports_job_id ON scan_reports(job_id)`)
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`DROP INDEX IF EXISTS ux_scan_reports_job_id`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`DROP TABLE IF EXISTS scan_reports`)
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+// matchKeySQL is a copy of aliases.MatchKeySQL, inlined so this migration
+// doesn't import the aliases package (whose tests import migrations).
+const matchKeySQL = "REPLACE(REPLACE(REPLACE(LOWER(name), ' ', ''), '-', ''), '_', '')"
+
+func init() {
+	tables := []string{"genres", "tags"}
+
+	up := func(ctx context.Context, db *bun.DB) error {
+		// FindOrCreateGenre/FindOrCreateTag now look up spelling variants
+		// by match key. Index it so those lookups don't scan the table.
+		// Existing variants are left alone; they're merged per library
+		// through the merge-variants endpoints.
+		for _, table := range tables {
+			idx := `CREATE INDEX ix_` + table + `_library_id_match_key ON ` + table + ` (library_id, ` + matchKeySQL + `)`
+			if _, err := db.ExecContext(ctx, idx); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		return nil
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		for _, table := range tables {
+			if _, err := db.Exec(`DROP INDEX IF EXISTS ix_` + table + `_library_id_match_key`); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		return nil
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE libraries ADD COLUMN allowed_file_types TEXT")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE libraries DROP COLUMN allowed_file_types")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
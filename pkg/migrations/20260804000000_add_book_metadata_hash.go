@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		// Existing books are left NULL; the worker fills them in at the end of
+		// the next library scan.
+		_, err := db.Exec("ALTER TABLE books ADD COLUMN metadata_hash TEXT")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE books DROP COLUMN metadata_hash")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
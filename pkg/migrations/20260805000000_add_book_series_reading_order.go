@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`ALTER TABLE book_series ADD COLUMN reading_order REAL`)
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`ALTER TABLE book_series DROP COLUMN reading_order`)
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
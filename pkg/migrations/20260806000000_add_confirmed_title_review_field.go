@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/encoding/json"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		var value string
+		err := db.QueryRowContext(ctx, `SELECT value FROM app_settings WHERE key = 'review_criteria'`).Scan(&value)
+		if err != nil {
+			// No row means there are no criteria to update.
+			return nil
+		}
+
+		var criteria map[string][]string
+		if err := json.Unmarshal([]byte(value), &criteria); err != nil {
+			// Malformed JSON — skip silently, don't fail the migration.
+			return nil
+		}
+
+		// Only criteria still matching the seeded defaults get the new field,
+		// so criteria an admin has customized are left alone.
+		if !slices.Equal(criteria["book_fields"], []string{"authors", "description", "cover", "genres"}) {
+			return nil
+		}
+		criteria["book_fields"] = append(criteria["book_fields"], "confirmed_title")
+
+		encoded, err := json.Marshal(criteria)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if _, err := db.ExecContext(ctx, `UPDATE app_settings SET value = ? WHERE key = 'review_criteria'`, string(encoded)); err != nil {
+			return errors.WithStack(err)
+		}
+
+		// Recompute so files whose title only came from the filepath rejoin
+		// the needs-review queue.
+		jobData, err := json.Marshal(map[string]interface{}{"clear_overrides": false})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.ExecContext(ctx, `
+			INSERT INTO jobs (type, status, data, progress, created_at, updated_at)
+			VALUES ('recompute_review', 'pending', ?, 0, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, string(jobData))
+		return errors.WithStack(err)
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		var value string
+		err := db.QueryRowContext(ctx, `SELECT value FROM app_settings WHERE key = 'review_criteria'`).Scan(&value)
+		if err != nil {
+			return nil
+		}
+
+		var criteria map[string][]string
+		if err := json.Unmarshal([]byte(value), &criteria); err != nil {
+			return nil
+		}
+		criteria["book_fields"] = slices.DeleteFunc(criteria["book_fields"], func(f string) bool {
+			return f == "confirmed_title"
+		})
+
+		encoded, err := json.Marshal(criteria)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.ExecContext(ctx, `UPDATE app_settings SET value = ? WHERE key = 'review_criteria'`, string(encoded))
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE libraries ADD COLUMN cbz_cover_page_default INTEGER NOT NULL DEFAULT 1")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE libraries DROP COLUMN cbz_cover_page_default")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
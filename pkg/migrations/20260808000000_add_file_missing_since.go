@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE files ADD COLUMN missing_since TIMESTAMPTZ")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE files ADD COLUMN missing_scans INTEGER NOT NULL DEFAULT 0")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE files DROP COLUMN missing_scans")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE files DROP COLUMN missing_since")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`ALTER TABLE files ADD COLUMN has_text_to_speech BOOLEAN NOT NULL DEFAULT false`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`ALTER TABLE files ADD COLUMN access_modes TEXT`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`ALTER TABLE files ADD COLUMN accessibility_features TEXT`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		return nil
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`ALTER TABLE files DROP COLUMN has_text_to_speech`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`ALTER TABLE files DROP COLUMN access_modes`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`ALTER TABLE files DROP COLUMN accessibility_features`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		return nil
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE libraries ADD COLUMN auto_pair_formats BOOLEAN NOT NULL DEFAULT false")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE libraries DROP COLUMN auto_pair_formats")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE files ADD COLUMN supplement_kind TEXT")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		// Every existing supplement was a generic document until now.
+		_, err = db.Exec("UPDATE files SET supplement_kind = 'document' WHERE file_role = 'supplement'")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE files DROP COLUMN supplement_kind")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
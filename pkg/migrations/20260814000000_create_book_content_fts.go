@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		// The rowid is the file ID, so a file's row can be looked up and
+		// replaced without scanning the index. content_hash is the sha256 of
+		// the file the text was extracted from.
+		_, err := db.Exec(`
+			CREATE VIRTUAL TABLE book_content_fts USING fts5(
+				content_hash UNINDEXED,
+				content,
+				tokenize='unicode61'
+			)
+		`)
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("DROP TABLE IF EXISTS book_content_fts")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		// Cover cursor pagination, which seeks on (sort_title, id) within a
+		// library or across all of them.
+		_, err := db.Exec(`CREATE INDEX ix_books_library_id_sort_title_id ON books (library_id, sort_title, id)`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`CREATE INDEX ix_books_sort_title_id ON books (sort_title, id)`)
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`DROP INDEX IF EXISTS ix_books_sort_title_id`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`DROP INDEX IF EXISTS ix_books_library_id_sort_title_id`)
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
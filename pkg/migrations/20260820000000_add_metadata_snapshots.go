@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE metadata_snapshots (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				book_id INTEGER NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+				file_id INTEGER REFERENCES files(id) ON DELETE SET NULL,
+				data TEXT NOT NULL
+			)
+		`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		// Snapshots are listed and pruned per book, newest first.
+		_, err = db.Exec(`CREATE INDEX ix_metadata_snapshots_book_id ON metadata_snapshots(book_id, id)`)
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`DROP INDEX IF EXISTS ix_metadata_snapshots_book_id`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`DROP TABLE IF EXISTS metadata_snapshots`)
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
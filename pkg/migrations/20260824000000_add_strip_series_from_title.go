@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`ALTER TABLE libraries ADD COLUMN strip_series_from_title BOOLEAN NOT NULL DEFAULT false`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`ALTER TABLE books ADD COLUMN raw_title TEXT`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		return nil
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`ALTER TABLE books DROP COLUMN raw_title`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`ALTER TABLE libraries DROP COLUMN strip_series_from_title`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		return nil
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE scan_skips (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				library_id INTEGER NOT NULL REFERENCES libraries(id) ON DELETE CASCADE,
+				job_id INTEGER REFERENCES jobs(id) ON DELETE SET NULL,
+				path TEXT NOT NULL,
+				reason TEXT NOT NULL,
+				detail TEXT
+			)
+		`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		// A path is skipped for one reason at a time.
+		_, err = db.Exec(`CREATE UNIQUE INDEX ux_scan_skips_library_id_path ON scan_skips(library_id, path)`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		// The scan report counts skips per reason.
+		_, err = db.Exec(`ALTER TABLE scan_reports ADD COLUMN skip_reasons TEXT`)
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`ALTER TABLE scan_reports DROP COLUMN skip_reasons`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`DROP INDEX IF EXISTS ux_scan_skips_library_id_path`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`DROP TABLE IF EXISTS scan_skips`)
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE scan_errors (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				library_id INTEGER NOT NULL REFERENCES libraries(id) ON DELETE CASCADE,
+				file_id INTEGER REFERENCES files(id) ON DELETE SET NULL,
+				path TEXT NOT NULL,
+				code TEXT NOT NULL,
+				message TEXT NOT NULL,
+				last_seen TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+			)
+		`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		// A path has at most one outstanding error.
+		_, err = db.Exec(`CREATE UNIQUE INDEX ux_scan_errors_library_id_path ON scan_errors(library_id, path)`)
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec(`DROP INDEX IF EXISTS ux_scan_errors_library_id_path`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec(`DROP TABLE IF EXISTS scan_errors`)
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE chapters ADD COLUMN cover_page_index INTEGER")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE chapters DROP COLUMN cover_page_index")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE libraries ADD COLUMN organize_files BOOLEAN NOT NULL DEFAULT TRUE")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE libraries ADD COLUMN organize_folders BOOLEAN NOT NULL DEFAULT TRUE")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("UPDATE libraries SET organize_files = organize_file_structure, organize_folders = organize_file_structure")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE libraries DROP COLUMN organize_file_structure")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		_, err := db.Exec("ALTER TABLE libraries ADD COLUMN organize_file_structure BOOLEAN NOT NULL DEFAULT TRUE")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("UPDATE libraries SET organize_file_structure = (organize_files OR organize_folders)")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE libraries DROP COLUMN organize_files")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE libraries DROP COLUMN organize_folders")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
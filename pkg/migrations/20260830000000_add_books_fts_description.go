@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+// createBooksFTS returns the statement that creates a books_fts table named
+// table, with or without the description column.
+func createBooksFTS(table string, withDescription bool) string {
+	description := ""
+	if withDescription {
+		description = "description,"
+	}
+	return `
+		CREATE VIRTUAL TABLE ` + table + ` USING fts5(
+			book_id UNINDEXED,
+			library_id UNINDEXED,
+			title,
+			filepath,
+			subtitle,
+			authors,
+			filenames,
+			narrators,
+			series_names,
+			` + description + `
+			tokenize='unicode61',
+			prefix='2,3'
+		)
+	`
+}
+
+const booksFTSColumns = "book_id, library_id, title, filepath, subtitle, authors, filenames, narrators, series_names"
+
+func init() {
+	up := func(_ context.Context, db *bun.DB) error {
+		// FTS5 tables can't gain columns, so build a new one with the
+		// description and copy the existing rows over.
+		if _, err := db.Exec(createBooksFTS("books_fts_new", true)); err != nil {
+			return errors.WithStack(err)
+		}
+		_, err := db.Exec(`
+			INSERT INTO books_fts_new (` + booksFTSColumns + `, description)
+			SELECT bf.book_id, bf.library_id, bf.title, bf.filepath, bf.subtitle, bf.authors, bf.filenames, bf.narrators, bf.series_names, COALESCE(b.description, '')
+			FROM books_fts bf
+			JOIN books b ON b.id = bf.book_id
+		`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if _, err := db.Exec("DROP TABLE books_fts"); err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE books_fts_new RENAME TO books_fts")
+		return errors.WithStack(err)
+	}
+
+	down := func(_ context.Context, db *bun.DB) error {
+		if _, err := db.Exec(createBooksFTS("books_fts_old", false)); err != nil {
+			return errors.WithStack(err)
+		}
+		_, err := db.Exec(`INSERT INTO books_fts_old (` + booksFTSColumns + `) SELECT ` + booksFTSColumns + ` FROM books_fts`)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if _, err := db.Exec("DROP TABLE books_fts"); err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = db.Exec("ALTER TABLE books_fts_old RENAME TO books_fts")
+		return errors.WithStack(err)
+	}
+
+	Migrations.MustRegister(up, down)
+}
@@ -0,0 +1,301 @@
+package mobi
+
+import (
+	"encoding/binary"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/htmlutil"
+	"github.com/shishobooks/shisho/pkg/identifiers"
+	"github.com/shishobooks/shisho/pkg/mediafile"
+	"github.com/shishobooks/shisho/pkg/models"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	palmDBHeaderLen   = 78
+	palmDBTypeOffset  = 60
+	palmDBCountOffset = 76
+	recordEntryLen    = 8
+
+	// Offsets within record 0 (PalmDOC header followed by the MOBI header).
+	encryptionOffset     = 12
+	mobiHeaderOffset     = 16
+	mobiHeaderLenOffset  = 20
+	textEncodingOffset   = 28
+	fullNameOffset       = 84
+	fullNameLenOffset    = 88
+	firstImageOffset     = 108
+	exthFlagsOffset      = 128
+	exthPresentFlag      = 0x40
+	textEncodingUTF8     = 65001
+	noImageIndex         = 0xffffffff
+	minRecord0Len        = exthFlagsOffset + 4
+	maxRecordCount       = 0xffff
+	maxEXTHRecordCount   = 1 << 16
+	exthRecordHeaderSize = 8
+)
+
+// EXTH record types read by Parse.
+const (
+	exthAuthor       = 100
+	exthPublisher    = 101
+	exthDescription  = 103
+	exthISBN         = 104
+	exthSubject      = 105
+	exthPublishDate  = 106
+	exthASIN         = 113
+	exthCoverOffset  = 201
+	exthUpdatedTitle = 503
+	exthLanguage     = 524
+)
+
+// Parse reads metadata from a MOBI or AZW3 file and returns it in the
+// mediafile.ParsedMetadata format for compatibility with the existing scanner.
+// Files with a non-zero encryption type in the PalmDOC header are
+// DRM-protected and return errcodes.DRMProtected.
+func Parse(path string) (*mediafile.ParsedMetadata, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	size := info.Size()
+
+	offsets, err := readRecordOffsets(f)
+	if err != nil {
+		return nil, err
+	}
+
+	record0, err := readRecord(f, offsets, size, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(record0) < minRecord0Len || string(record0[mobiHeaderOffset:mobiHeaderOffset+4]) != "MOBI" {
+		return nil, errors.New("missing MOBI header")
+	}
+	if binary.BigEndian.Uint16(record0[encryptionOffset:]) != 0 {
+		return nil, errors.WithStack(errcodes.DRMProtected())
+	}
+
+	decode := decoderFor(binary.BigEndian.Uint32(record0[textEncodingOffset:]))
+
+	var exth map[uint32][][]byte
+	if binary.BigEndian.Uint32(record0[exthFlagsOffset:])&exthPresentFlag != 0 {
+		headerLen := binary.BigEndian.Uint32(record0[mobiHeaderLenOffset:])
+		exth = parseEXTH(record0, mobiHeaderOffset+int(headerLen))
+	}
+	first := func(recordType uint32) string {
+		if values := exth[recordType]; len(values) > 0 {
+			return strings.TrimSpace(decode(values[0]))
+		}
+		return ""
+	}
+
+	// The updated title in EXTH is preferred over the header's full name,
+	// which Kindle tools sometimes truncate.
+	title := first(exthUpdatedTitle)
+	if title == "" {
+		title = strings.TrimSpace(decode(fullName(record0)))
+	}
+
+	var authors []mediafile.ParsedAuthor
+	for _, value := range exth[exthAuthor] {
+		// One record per author is the norm, but some tools join several
+		// authors into one record with "&".
+		for _, name := range strings.Split(decode(value), "&") {
+			name = strings.TrimSpace(name)
+			if name != "" {
+				authors = append(authors, mediafile.ParsedAuthor{Name: name})
+			}
+		}
+	}
+
+	var tags []string
+	for _, value := range exth[exthSubject] {
+		if tag := strings.TrimSpace(decode(value)); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	var identifierList []mediafile.ParsedIdentifier
+	if isbn := first(exthISBN); isbn != "" {
+		if idType := identifiers.DetectType(isbn, "ISBN"); idType != identifiers.TypeUnknown {
+			identifierList = append(identifierList, mediafile.ParsedIdentifier{Type: string(idType), Value: isbn})
+		}
+	}
+	if asin := first(exthASIN); asin != "" {
+		identifierList = append(identifierList, mediafile.ParsedIdentifier{Type: string(identifiers.TypeASIN), Value: asin})
+	}
+
+	var releaseDate *time.Time
+	if t, ok := parseDate(first(exthPublishDate)); ok {
+		releaseDate = &t
+	}
+
+	var language *string
+	if lang := first(exthLanguage); lang != "" {
+		language = mediafile.NormalizeLanguage(lang)
+	}
+
+	// Cover extraction is best-effort: a bad cover offset shouldn't fail the
+	// whole parse.
+	var coverData []byte
+	var coverMime string
+	if values := exth[exthCoverOffset]; len(values) > 0 && len(values[0]) >= 4 {
+		firstImage := binary.BigEndian.Uint32(record0[firstImageOffset:])
+		coverOffset := binary.BigEndian.Uint32(values[0])
+		if firstImage != noImageIndex && coverOffset != noImageIndex {
+			index := uint64(firstImage) + uint64(coverOffset)
+			if index < uint64(len(offsets)) {
+				if data, err := readRecord(f, offsets, size, int(index)); err == nil {
+					if mime := http.DetectContentType(data); strings.HasPrefix(mime, "image/") {
+						coverData = data
+						coverMime = mime
+					}
+				}
+			}
+		}
+	}
+
+	return &mediafile.ParsedMetadata{
+		Title:         title,
+		Authors:       authors,
+		Publisher:     first(exthPublisher),
+		Description:   htmlutil.StripTags(first(exthDescription)),
+		Tags:          tags,
+		ReleaseDate:   releaseDate,
+		Language:      language,
+		Identifiers:   identifierList,
+		CoverData:     coverData,
+		CoverMimeType: coverMime,
+		DataSource:    models.DataSourceMOBIMetadata,
+	}, nil
+}
+
+// readRecordOffsets reads the PalmDB header and returns the start offset of
+// every record. Only BOOKMOBI databases (MOBI and AZW3) are accepted.
+func readRecordOffsets(r io.ReaderAt) ([]uint32, error) {
+	header := make([]byte, palmDBHeaderLen)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, errors.Wrap(err, "failed to read PalmDB header")
+	}
+	if string(header[palmDBTypeOffset:palmDBTypeOffset+8]) != "BOOKMOBI" {
+		return nil, errors.New("not a MOBI file")
+	}
+
+	count := int(binary.BigEndian.Uint16(header[palmDBCountOffset:]))
+	if count == 0 || count > maxRecordCount {
+		return nil, errors.New("invalid PalmDB record count")
+	}
+	list := make([]byte, count*recordEntryLen)
+	if _, err := r.ReadAt(list, palmDBHeaderLen); err != nil {
+		return nil, errors.Wrap(err, "failed to read PalmDB record list")
+	}
+
+	offsets := make([]uint32, count)
+	for i := range offsets {
+		offsets[i] = binary.BigEndian.Uint32(list[i*recordEntryLen:])
+	}
+	return offsets, nil
+}
+
+// readRecord reads record i, which runs up to the next record's offset (or
+// the end of the file for the last record).
+func readRecord(r io.ReaderAt, offsets []uint32, size int64, i int) ([]byte, error) {
+	start := int64(offsets[i])
+	end := size
+	if i+1 < len(offsets) {
+		end = int64(offsets[i+1])
+	}
+	if start > end || end > size {
+		return nil, errors.Errorf("invalid offset for record %d", i)
+	}
+	data := make([]byte, end-start)
+	if _, err := r.ReadAt(data, start); err != nil {
+		return nil, errors.Wrapf(err, "failed to read record %d", i)
+	}
+	return data, nil
+}
+
+// parseEXTH returns the EXTH records starting at offset in record 0, keyed by
+// record type. Types like author can repeat, so each holds every value in
+// file order. Malformed trailing records are ignored.
+func parseEXTH(record0 []byte, offset int) map[uint32][][]byte {
+	records := map[uint32][][]byte{}
+	if offset < 0 || offset+12 > len(record0) || string(record0[offset:offset+4]) != "EXTH" {
+		return records
+	}
+	count := binary.BigEndian.Uint32(record0[offset+8:])
+	pos := offset + 12
+	for i := uint32(0); i < count && i < maxEXTHRecordCount; i++ {
+		if pos+exthRecordHeaderSize > len(record0) {
+			break
+		}
+		recordType := binary.BigEndian.Uint32(record0[pos:])
+		length := int(binary.BigEndian.Uint32(record0[pos+4:]))
+		if length < exthRecordHeaderSize || pos+length > len(record0) {
+			break
+		}
+		records[recordType] = append(records[recordType], record0[pos+exthRecordHeaderSize:pos+length])
+		pos += length
+	}
+	return records
+}
+
+// fullName returns the title stored in record 0's MOBI header.
+func fullName(record0 []byte) []byte {
+	offset := int(binary.BigEndian.Uint32(record0[fullNameOffset:]))
+	length := int(binary.BigEndian.Uint32(record0[fullNameLenOffset:]))
+	if offset <= 0 || length <= 0 || offset+length > len(record0) {
+		return nil
+	}
+	return record0[offset : offset+length]
+}
+
+// decoderFor returns a function that converts header strings to UTF-8. MOBI
+// files declare either UTF-8 (65001) or CP1252 (1252).
+func decoderFor(encoding uint32) func([]byte) string {
+	if encoding == textEncodingUTF8 {
+		return func(b []byte) string { return string(b) }
+	}
+	decoder := charmap.Windows1252.NewDecoder()
+	return func(b []byte) string {
+		s, err := decoder.Bytes(b)
+		if err != nil {
+			return string(b)
+		}
+		return string(s)
+	}
+}
+
+// parseDate parses the EXTH publishing date, which tools write in a handful
+// of ISO 8601 variants.
+func parseDate(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	formats := []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02",
+		"2006-01",
+		"2006",
+	}
+	for _, format := range formats {
+		if t, err := time.Parse(format, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
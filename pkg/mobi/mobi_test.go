@@ -0,0 +1,204 @@
+package mobi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type exthRecord struct {
+	Type uint32
+	Data []byte
+}
+
+type testBook struct {
+	FullName   string
+	Encryption uint16
+	Encoding   uint32
+	EXTH       []exthRecord
+	Images     [][]byte
+}
+
+// pngHeader is enough of a PNG for content sniffing.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func uint32Bytes(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return b
+}
+
+// writeTestBook writes a minimal BOOKMOBI database: record 0 holds the
+// PalmDOC/MOBI/EXTH headers and full name, followed by one empty text record
+// and the image records.
+func writeTestBook(t *testing.T, book testBook) string {
+	t.Helper()
+
+	const mobiHeaderLen = 232
+	var exth bytes.Buffer
+	if len(book.EXTH) > 0 {
+		var records bytes.Buffer
+		for _, r := range book.EXTH {
+			records.Write(uint32Bytes(r.Type))
+			records.Write(uint32Bytes(uint32(len(r.Data) + 8)))
+			records.Write(r.Data)
+		}
+		exth.WriteString("EXTH")
+		exth.Write(uint32Bytes(uint32(records.Len() + 12)))
+		exth.Write(uint32Bytes(uint32(len(book.EXTH))))
+		exth.Write(records.Bytes())
+	}
+
+	encoding := book.Encoding
+	if encoding == 0 {
+		encoding = textEncodingUTF8
+	}
+
+	record0 := make([]byte, mobiHeaderOffset+mobiHeaderLen)
+	binary.BigEndian.PutUint16(record0[0:], 1) // no compression
+	binary.BigEndian.PutUint16(record0[encryptionOffset:], book.Encryption)
+	copy(record0[mobiHeaderOffset:], "MOBI")
+	binary.BigEndian.PutUint32(record0[mobiHeaderLenOffset:], mobiHeaderLen)
+	binary.BigEndian.PutUint32(record0[textEncodingOffset:], encoding)
+	binary.BigEndian.PutUint32(record0[firstImageOffset:], 2)
+	if len(book.EXTH) > 0 {
+		binary.BigEndian.PutUint32(record0[exthFlagsOffset:], exthPresentFlag)
+	}
+	record0 = append(record0, exth.Bytes()...)
+	binary.BigEndian.PutUint32(record0[fullNameOffset:], uint32(len(record0)))
+	binary.BigEndian.PutUint32(record0[fullNameLenOffset:], uint32(len(book.FullName)))
+	record0 = append(record0, book.FullName...)
+
+	records := append([][]byte{record0, {}}, book.Images...)
+
+	header := make([]byte, palmDBHeaderLen)
+	copy(header, "test")
+	copy(header[palmDBTypeOffset:], "BOOKMOBI")
+	binary.BigEndian.PutUint16(header[palmDBCountOffset:], uint16(len(records)))
+
+	var out bytes.Buffer
+	out.Write(header)
+	offset := palmDBHeaderLen + len(records)*recordEntryLen
+	for i, r := range records {
+		out.Write(uint32Bytes(uint32(offset)))
+		out.Write(uint32Bytes(uint32(i)))
+		offset += len(r)
+	}
+	for _, r := range records {
+		out.Write(r)
+	}
+
+	path := filepath.Join(t.TempDir(), "book.mobi")
+	require.NoError(t, os.WriteFile(path, out.Bytes(), 0600))
+	return path
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	cover := append(append([]byte{}, pngHeader...), 0, 1, 2, 3)
+	path := writeTestBook(t, testBook{
+		FullName: "Short Title",
+		EXTH: []exthRecord{
+			{exthAuthor, []byte("Jane Doe")},
+			{exthAuthor, []byte("John Roe & Ann Poe")},
+			{exthPublisher, []byte("Example Press")},
+			{exthDescription, []byte("<p>A <b>great</b> book.</p>")},
+			{exthISBN, []byte("978-0-316-76948-8")},
+			{exthSubject, []byte("Fantasy")},
+			{exthPublishDate, []byte("2019-03-05T00:00:00+00:00")},
+			{exthASIN, []byte("B000000001")},
+			{exthCoverOffset, uint32Bytes(1)},
+			{exthUpdatedTitle, []byte("The Full Title")},
+			{exthLanguage, []byte("en")},
+		},
+		Images: [][]byte{[]byte("not an image"), cover},
+	})
+
+	metadata, err := Parse(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "The Full Title", metadata.Title)
+	require.Len(t, metadata.Authors, 3)
+	assert.Equal(t, "Jane Doe", metadata.Authors[0].Name)
+	assert.Equal(t, "John Roe", metadata.Authors[1].Name)
+	assert.Equal(t, "Ann Poe", metadata.Authors[2].Name)
+	assert.Equal(t, "Example Press", metadata.Publisher)
+	assert.Equal(t, "A great book.", metadata.Description)
+	assert.Equal(t, []string{"Fantasy"}, metadata.Tags)
+	require.NotNil(t, metadata.ReleaseDate)
+	assert.Equal(t, time.Date(2019, 3, 5, 0, 0, 0, 0, time.UTC), metadata.ReleaseDate.UTC())
+	require.NotNil(t, metadata.Language)
+	assert.Equal(t, "en", *metadata.Language)
+	require.Len(t, metadata.Identifiers, 2)
+	assert.Equal(t, "isbn_13", metadata.Identifiers[0].Type)
+	assert.Equal(t, "asin", metadata.Identifiers[1].Type)
+	assert.Equal(t, cover, metadata.CoverData)
+	assert.Equal(t, "image/png", metadata.CoverMimeType)
+	assert.Nil(t, metadata.CoverPage)
+	assert.Equal(t, models.DataSourceMOBIMetadata, metadata.DataSource)
+}
+
+func TestParse_FallsBackToFullName(t *testing.T) {
+	t.Parallel()
+	path := writeTestBook(t, testBook{FullName: "Header Title"})
+
+	metadata, err := Parse(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Header Title", metadata.Title)
+	assert.Empty(t, metadata.Authors)
+	assert.Nil(t, metadata.CoverData)
+}
+
+func TestParse_DecodesCP1252(t *testing.T) {
+	t.Parallel()
+	path := writeTestBook(t, testBook{
+		Encoding: 1252,
+		FullName: "Caf\xe9",
+		EXTH:     []exthRecord{{exthAuthor, []byte("Ren\xe9e")}},
+	})
+
+	metadata, err := Parse(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Café", metadata.Title)
+	require.Len(t, metadata.Authors, 1)
+	assert.Equal(t, "Renée", metadata.Authors[0].Name)
+}
+
+func TestParse_DRMProtected(t *testing.T) {
+	t.Parallel()
+	path := writeTestBook(t, testBook{FullName: "Locked", Encryption: 2})
+
+	_, err := Parse(path)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errcodes.DRMProtected()))
+}
+
+func TestParse_RejectsNonMOBI(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "book.mobi")
+	require.NoError(t, os.WriteFile(path, bytes.Repeat([]byte{0}, 200), 0600))
+
+	_, err := Parse(path)
+	require.Error(t, err)
+}
+
+func TestParse_IgnoresOutOfRangeCover(t *testing.T) {
+	t.Parallel()
+	path := writeTestBook(t, testBook{
+		FullName: "Title",
+		EXTH:     []exthRecord{{exthCoverOffset, uint32Bytes(50)}},
+	})
+
+	metadata, err := Parse(path)
+	require.NoError(t, err)
+	assert.Nil(t, metadata.CoverData)
+}
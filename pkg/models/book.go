@@ -9,27 +9,32 @@ import (
 type Book struct {
 	bun.BaseModel `bun:"table:books,alias:b" tstype:"-"`
 
-	ID                int           `bun:",pk,nullzero" json:"id"`
-	CreatedAt         time.Time     `json:"created_at"`
-	UpdatedAt         time.Time     `json:"updated_at"`
-	LibraryID         int           `bun:",nullzero" json:"library_id"`
-	Library           *Library      `bun:"rel:belongs-to" json:"library" tstype:"Library"`
-	Filepath          string        `bun:",nullzero" json:"filepath"`
-	Title             string        `bun:",nullzero" json:"title"`
-	TitleSource       string        `bun:",nullzero" json:"title_source" tstype:"DataSource"`
-	SortTitle         string        `bun:",notnull" json:"sort_title"`
-	SortTitleSource   string        `bun:",notnull" json:"sort_title_source" tstype:"DataSource"`
-	Subtitle          *string       `json:"subtitle"`
-	SubtitleSource    *string       `json:"subtitle_source" tstype:"DataSource"`
-	Description       *string       `json:"description"`
-	DescriptionSource *string       `json:"description_source" tstype:"DataSource"`
-	Authors           []*Author     `bun:"rel:has-many,join:id=book_id" json:"authors,omitempty" tstype:"Author[]"`
-	AuthorSource      string        `bun:",nullzero" json:"author_source" tstype:"DataSource"`
-	BookSeries        []*BookSeries `bun:"rel:has-many,join:id=book_id" json:"book_series,omitempty" tstype:"BookSeries[]"`
-	BookGenres        []*BookGenre  `bun:"rel:has-many,join:id=book_id" json:"book_genres,omitempty" tstype:"BookGenre[]"`
-	GenreSource       *string       `json:"genre_source" tstype:"DataSource"`
-	BookTags          []*BookTag    `bun:"rel:has-many,join:id=book_id" json:"book_tags,omitempty" tstype:"BookTag[]"`
-	TagSource         *string       `json:"tag_source" tstype:"DataSource"`
-	Files             []*File       `bun:"rel:has-many" json:"files" tstype:"File[]"`
-	CoverCacheKey     string        `bun:"-" json:"cover_cache_key"`
+	ID                int               `bun:",pk,nullzero" json:"id"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+	LibraryID         int               `bun:",nullzero" json:"library_id"`
+	Library           *Library          `bun:"rel:belongs-to" json:"library" tstype:"Library"`
+	Filepath          string            `bun:",nullzero" json:"filepath"`
+	Title             string            `bun:",nullzero" json:"title"`
+	TitleSource       string            `bun:",nullzero" json:"title_source" tstype:"DataSource"`
+	RawTitle          *string           `json:"raw_title,omitempty"` // Title before a scan stripped its series prefix (see Library.StripSeriesFromTitle)
+	SortTitle         string            `bun:",notnull" json:"sort_title"`
+	SortTitleSource   string            `bun:",notnull" json:"sort_title_source" tstype:"DataSource"`
+	Subtitle          *string           `json:"subtitle"`
+	SubtitleSource    *string           `json:"subtitle_source" tstype:"DataSource"`
+	Description       *string           `json:"description"`
+	DescriptionSource *string           `json:"description_source" tstype:"DataSource"`
+	Authors           []*Author         `bun:"rel:has-many,join:id=book_id" json:"authors,omitempty" tstype:"Author[]"`
+	AuthorSource      string            `bun:",nullzero" json:"author_source" tstype:"DataSource"`
+	BookSeries        []*BookSeries     `bun:"rel:has-many,join:id=book_id" json:"book_series,omitempty" tstype:"BookSeries[]"`
+	BookGenres        []*BookGenre      `bun:"rel:has-many,join:id=book_id" json:"book_genres,omitempty" tstype:"BookGenre[]"`
+	GenreSource       *string           `json:"genre_source" tstype:"DataSource"`
+	BookTags          []*BookTag        `bun:"rel:has-many,join:id=book_id" json:"book_tags,omitempty" tstype:"BookTag[]"`
+	TagSource         *string           `json:"tag_source" tstype:"DataSource"`
+	BookCollections   []*BookCollection `bun:"rel:has-many,join:id=book_id" json:"book_collections,omitempty" tstype:"BookCollection[]"`
+	Files             []*File           `bun:"rel:has-many" json:"files" tstype:"File[]"`
+	Staged            bool              `json:"staged"` // Scans don't organize files or write sidecars until approved
+	Hidden            bool              `json:"hidden"` // Excluded from book lists and search unless explicitly requested
+	MetadataHash      string            `bun:",nullzero" json:"metadata_hash"`
+	CoverCacheKey     string            `bun:"-" json:"cover_cache_key"`
 }
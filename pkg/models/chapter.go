@@ -22,8 +22,49 @@ type Chapter struct {
 	StartTimestampMs *int64  `json:"start_timestamp_ms"` // M4B: milliseconds from start
 	Href             *string `json:"href"`               // EPUB: content document href
 
+	// CoverPageIndex is the page shown as the chapter's thumbnail (CBZ only).
+	// Like StartPage it's 0-indexed. When nil, the chapter's start page is
+	// used.
+	CoverPageIndex *int `json:"cover_page_index"`
+
+	// Resolved playback range for timestamped chapters, with the file's
+	// chapter offset applied. Only set by chapters.Service.GetChapterTree.
+	StartMs *int64 `bun:"-" json:"start_ms,omitempty"`
+	EndMs   *int64 `bun:"-" json:"end_ms,omitempty"` // Next sibling's start, or the parent's end (the file's duration for top-level chapters)
+
 	// Relations
 	File     *File      `bun:"rel:belongs-to,join:file_id=id" json:"-"`
 	Parent   *Chapter   `bun:"rel:belongs-to,join:parent_id=id" json:"-"`
 	Children []*Chapter `bun:"rel:has-many,join:id=parent_id" json:"children,omitempty"`
 }
+
+// ThumbnailPageIndex returns the 0-indexed page to show as the chapter's
+// thumbnail: its cover page if one is set, otherwise its start page. Returns
+// nil for chapters without either.
+func (ch *Chapter) ThumbnailPageIndex() *int {
+	if ch.CoverPageIndex != nil {
+		return ch.CoverPageIndex
+	}
+	return ch.StartPage
+}
+
+// OffsetChapters returns chapters with offsetMs added to every timestamp,
+// children included, clamping at zero. The chapters themselves aren't
+// modified; shifted ones are copies. Chapters without a timestamp are
+// returned as they are.
+func OffsetChapters(chapters []*Chapter, offsetMs int) []*Chapter {
+	if offsetMs == 0 || len(chapters) == 0 {
+		return chapters
+	}
+	shifted := make([]*Chapter, len(chapters))
+	for i, ch := range chapters {
+		c := *ch
+		if ch.StartTimestampMs != nil {
+			ms := max(*ch.StartTimestampMs+int64(offsetMs), 0)
+			c.StartTimestampMs = &ms
+		}
+		c.Children = OffsetChapters(ch.Children, offsetMs)
+		shifted[i] = &c
+	}
+	return shifted
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func TestOffsetChapters(t *testing.T) {
+	t.Parallel()
+
+	chapters := []*Chapter{
+		{Title: "Intro", StartTimestampMs: int64Ptr(0)},
+		{Title: "One", StartTimestampMs: int64Ptr(60000), Children: []*Chapter{
+			{Title: "One A", StartTimestampMs: int64Ptr(90000)},
+		}},
+		{Title: "Untimed"},
+	}
+
+	shifted := OffsetChapters(chapters, 300)
+	assert.Equal(t, int64(300), *shifted[0].StartTimestampMs)
+	assert.Equal(t, int64(60300), *shifted[1].StartTimestampMs)
+	assert.Equal(t, int64(90300), *shifted[1].Children[0].StartTimestampMs)
+	assert.Nil(t, shifted[2].StartTimestampMs)
+	assert.Equal(t, int64(60000), *chapters[1].StartTimestampMs, "stored chapters are left alone")
+	assert.Equal(t, int64(90000), *chapters[1].Children[0].StartTimestampMs)
+
+	shifted = OffsetChapters(chapters, -500)
+	assert.Equal(t, int64(0), *shifted[0].StartTimestampMs, "timestamps clamp at zero")
+	assert.Equal(t, int64(59500), *shifted[1].StartTimestampMs)
+
+	assert.Equal(t, chapters, OffsetChapters(chapters, 0))
+}
+
+func TestFileMarshalJSON_AppliesChapterOffset(t *testing.T) {
+	t.Parallel()
+
+	file := &File{
+		ID:              1,
+		ChapterOffsetMs: 250,
+		Chapters:        []*Chapter{{Title: "One", StartTimestampMs: int64Ptr(1000)}},
+	}
+	b, err := json.Marshal(file)
+	require.NoError(t, err)
+
+	var out struct {
+		ID              int `json:"id"`
+		ChapterOffsetMs int `json:"chapter_offset_ms"`
+		Chapters        []struct {
+			StartTimestampMs int64 `json:"start_timestamp_ms"`
+		} `json:"chapters"`
+	}
+	require.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, 1, out.ID)
+	assert.Equal(t, 250, out.ChapterOffsetMs)
+	require.Len(t, out.Chapters, 1)
+	assert.Equal(t, int64(1250), out.Chapters[0].StartTimestampMs)
+	assert.Equal(t, int64(1000), *file.Chapters[0].StartTimestampMs)
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Collection is a user-curated grouping of books within a library (e.g. a
+// box set or a reading list that spans series). Unlike genres and tags,
+// collections never come from file metadata, so scans leave them alone.
+type Collection struct {
+	bun.BaseModel `bun:"table:collections,alias:c" tstype:"-"`
+
+	ID          int       `bun:",pk,nullzero" json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	LibraryID   int       `bun:",nullzero" json:"library_id"`
+	Name        string    `bun:",nullzero" json:"name"`
+	Description *string   `json:"description,omitempty"`
+	BookCount   int       `bun:",scanonly" json:"book_count"`
+}
+
+type BookCollection struct {
+	bun.BaseModel `bun:"table:book_collections,alias:bc" tstype:"-"`
+
+	ID           int         `bun:",pk,nullzero" json:"id"`
+	CreatedAt    time.Time   `json:"created_at"`
+	BookID       int         `bun:",nullzero" json:"book_id"`
+	CollectionID int         `bun:",nullzero" json:"collection_id"`
+	Collection   *Collection `bun:"rel:belongs-to,join:collection_id=id" json:"collection,omitempty" tstype:"Collection"`
+}
@@ -3,7 +3,7 @@ package models
 import "strings"
 
 const (
-	//tygo:emit export type DataSource = typeof DataSourceManual | typeof DataSourceSidecar | typeof DataSourcePlugin | typeof DataSourceFileMetadata | typeof DataSourceExistingCover | typeof DataSourceEPUBMetadata | typeof DataSourceCBZMetadata | typeof DataSourceM4BMetadata | typeof DataSourcePDFMetadata | typeof DataSourceFilepath | `plugin:${string}`;
+	//tygo:emit export type DataSource = typeof DataSourceManual | typeof DataSourceSidecar | typeof DataSourcePlugin | typeof DataSourceFileMetadata | typeof DataSourceExistingCover | typeof DataSourceEPUBMetadata | typeof DataSourceCBZMetadata | typeof DataSourceM4BMetadata | typeof DataSourcePDFMetadata | typeof DataSourceMOBIMetadata | typeof DataSourceNFO | typeof DataSourceGenerated | typeof DataSourceFilepath | `plugin:${string}`;
 	DataSourceManual        = "manual"
 	DataSourceSidecar       = "sidecar"
 	DataSourcePlugin        = "plugin"
@@ -13,6 +13,9 @@ const (
 	DataSourceCBZMetadata   = "cbz_metadata"
 	DataSourceM4BMetadata   = "m4b_metadata"
 	DataSourcePDFMetadata   = "pdf_metadata"
+	DataSourceMOBIMetadata  = "mobi_metadata"
+	DataSourceNFO           = "nfo"
+	DataSourceGenerated     = "generated"
 	DataSourceFilepath      = "filepath"
 
 	// DataSourcePluginPrefix is the prefix for plugin-specific data sources.
@@ -26,7 +29,8 @@ const (
 	DataSourceSidecarPriority      = 1 // Sidecar has higher priority than file metadata
 	DataSourcePluginPriority       = 2 // Plugin enricher/parser results
 	DataSourceFileMetadataPriority = 3 // All file-derived sources share this
-	DataSourceFilepathPriority     = 4
+	DataSourceNFOPriority          = 4 // Labeled lines parsed from a freeform .nfo file
+	DataSourceFilepathPriority     = 5
 )
 
 var dataSourcePriority = map[string]int{
@@ -39,6 +43,9 @@ var dataSourcePriority = map[string]int{
 	DataSourceCBZMetadata:   DataSourceFileMetadataPriority,
 	DataSourceM4BMetadata:   DataSourceFileMetadataPriority,
 	DataSourcePDFMetadata:   DataSourceFileMetadataPriority,
+	DataSourceMOBIMetadata:  DataSourceFileMetadataPriority,
+	DataSourceNFO:           DataSourceNFOPriority,
+	DataSourceGenerated:     DataSourceFilepathPriority,
 	DataSourceFilepath:      DataSourceFilepathPriority,
 }
 
@@ -47,6 +54,15 @@ func PluginDataSource(scope, id string) string {
 	return DataSourcePluginPrefix + scope + "/" + id
 }
 
+// IsValidDataSource reports whether source is a known data source, including
+// plugin-specific "plugin:scope/id" sources.
+func IsValidDataSource(source string) bool {
+	if _, ok := dataSourcePriority[source]; ok {
+		return true
+	}
+	return strings.HasPrefix(source, DataSourcePluginPrefix) && len(source) > len(DataSourcePluginPrefix)
+}
+
 // GetDataSourcePriority returns the priority for a given data source string.
 // Handles "plugin:scope/id" format by matching the prefix.
 func GetDataSourcePriority(source string) int {
@@ -58,3 +74,41 @@ func GetDataSourcePriority(source string) int {
 	}
 	return DataSourceFilepathPriority
 }
+
+// embeddedDataSources are the sources of metadata read from inside the file
+// itself.
+var embeddedDataSources = map[string]struct{}{
+	DataSourceFileMetadata: {},
+	DataSourceEPUBMetadata: {},
+	DataSourceCBZMetadata:  {},
+	DataSourceM4BMetadata:  {},
+	DataSourcePDFMetadata:  {},
+	DataSourceMOBIMetadata: {},
+}
+
+// IsEmbeddedDataSource reports whether source is metadata embedded in the
+// file itself, as opposed to a sidecar, plugin, manual edit, or a cover image
+// found next to the file.
+func IsEmbeddedDataSource(source string) bool {
+	_, ok := embeddedDataSources[source]
+	return ok
+}
+
+// EmbeddedDataSource returns the data source for metadata embedded in a file
+// of fileType.
+func EmbeddedDataSource(fileType string) string {
+	switch fileType {
+	case FileTypeEPUB:
+		return DataSourceEPUBMetadata
+	case FileTypeCBZ:
+		return DataSourceCBZMetadata
+	case FileTypeM4B:
+		return DataSourceM4BMetadata
+	case FileTypePDF:
+		return DataSourcePDFMetadata
+	case FileTypeMOBI, FileTypeAZW3:
+		return DataSourceMOBIMetadata
+	default:
+		return DataSourceFileMetadata
+	}
+}
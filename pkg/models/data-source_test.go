@@ -0,0 +1,22 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsEmbeddedDataSource(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsEmbeddedDataSource(DataSourceEPUBMetadata))
+	assert.True(t, IsEmbeddedDataSource(DataSourceFileMetadata))
+	assert.False(t, IsEmbeddedDataSource(DataSourceExistingCover))
+	assert.False(t, IsEmbeddedDataSource(DataSourceManual))
+	assert.False(t, IsEmbeddedDataSource(PluginDataSource("test", "enricher")))
+
+	for _, fileType := range []string{FileTypeEPUB, FileTypeCBZ, FileTypeM4B, FileTypePDF, FileTypeMOBI, FileTypeAZW3, "plugin-type"} {
+		assert.True(t, IsEmbeddedDataSource(EmbeddedDataSource(fileType)), fileType)
+	}
+	assert.Equal(t, DataSourceMOBIMetadata, EmbeddedDataSource(FileTypeAZW3))
+}
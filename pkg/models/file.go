@@ -1,17 +1,21 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/uptrace/bun"
 )
 
 const (
-	//tygo:emit export type FileType = typeof FileTypeCBZ | typeof FileTypeEPUB | typeof FileTypeM4B | typeof FileTypePDF;
+	//tygo:emit export type FileType = typeof FileTypeCBZ | typeof FileTypeEPUB | typeof FileTypeM4B | typeof FileTypePDF | typeof FileTypeMOBI | typeof FileTypeAZW3;
 	FileTypeCBZ  = "cbz"
 	FileTypeEPUB = "epub"
 	FileTypeM4B  = "m4b"
 	FileTypePDF  = "pdf"
+	FileTypeMOBI = "mobi"
+	FileTypeAZW3 = "azw3"
 )
 
 const (
@@ -20,6 +24,23 @@ const (
 	FileRoleSupplement = "supplement"
 )
 
+// Supplement kinds stored in File.SupplementKind, so clients can group a
+// book's extras. Which kind a supplement gets is decided by its extension
+// (see config.SupplementKinds).
+const (
+	//tygo:emit export type SupplementKind = typeof SupplementKindDocument | typeof SupplementKindAudio | typeof SupplementKindImage;
+	SupplementKindDocument = "document"
+	SupplementKindAudio    = "audio"
+	SupplementKindImage    = "image"
+)
+
+// Page turn directions stored in File.ReadingDirection.
+const (
+	//tygo:emit export type ReadingDirection = typeof ReadingDirectionLTR | typeof ReadingDirectionRTL;
+	ReadingDirectionLTR = "ltr"
+	ReadingDirectionRTL = "rtl"
+)
+
 const (
 	//tygo:emit export type ReviewOverride = typeof ReviewOverrideReviewed | typeof ReviewOverrideUnreviewed;
 	ReviewOverrideReviewed   = "reviewed"
@@ -36,6 +57,18 @@ const (
 	ReviewedFilterReviewed    = "reviewed"
 )
 
+// FileSort values for the files list endpoint's sort query param. "" means
+// oldest first.
+const (
+	//tygo:emit export type FileSort = typeof FileSortCreatedAtAsc | typeof FileSortCreatedAtDesc | typeof FileSortFilepathAsc | typeof FileSortFilepathDesc | typeof FileSortFilesizeAsc | typeof FileSortFilesizeDesc;
+	FileSortCreatedAtAsc  = "created_at_asc"
+	FileSortCreatedAtDesc = "created_at_desc"
+	FileSortFilepathAsc   = "filepath_asc"
+	FileSortFilepathDesc  = "filepath_desc"
+	FileSortFilesizeAsc   = "filesize_asc"
+	FileSortFilesizeDesc  = "filesize_desc"
+)
+
 type File struct {
 	bun.BaseModel `bun:"table:files,alias:f" tstype:"-"`
 
@@ -48,18 +81,27 @@ type File struct {
 	Filepath                 string            `bun:",nullzero" json:"filepath"`
 	FileType                 string            `bun:",nullzero" json:"file_type" tstype:"FileType"`
 	FileRole                 string            `bun:",nullzero,default:'main'" json:"file_role" tstype:"FileRole"`
+	SupplementKind           *string           `json:"supplement_kind" tstype:"SupplementKind"` // Set for supplements only
 	FilesizeBytes            int64             `bun:",nullzero" json:"filesize_bytes"`
 	FileModifiedAt           *time.Time        `json:"file_modified_at"`
+	ModifiedDate             *time.Time        `json:"modified_date"` // When the file says its content was last revised (EPUB dcterms:modified), unlike FileModifiedAt
 	CoverImageFilename       *string           `json:"cover_image_filename"`
 	CoverMimeType            *string           `json:"cover_mime_type"`
 	CoverSource              *string           `json:"cover_source" tstype:"DataSource"`
-	CoverPage                *int              `json:"cover_page"` // 0-indexed page number for CBZ/PDF cover, NULL for EPUB/M4B
+	CoverPage                *int              `json:"cover_page"` // 1-indexed page number for CBZ/PDF cover, NULL for EPUB/M4B
 	Name                     *string           `json:"name"`
 	NameSource               *string           `json:"name_source" tstype:"DataSource"`
-	PageCount                *int              `json:"page_count"` // Number of pages for CBZ/PDF files, NULL for EPUB/M4B
+	PageCount                *int              `json:"page_count"`                                       // Number of pages for CBZ/PDF files, NULL for EPUB/M4B
+	WordCount                *int              `json:"word_count"`                                       // Estimated for EPUB files, NULL for others
+	HasTextToSpeech          bool              `json:"has_text_to_speech"`                               // EPUB accessibility metadata says the text can be read aloud
+	AccessModes              []string          `bun:",nullzero" json:"access_modes,omitempty"`           // schema.org accessMode values from EPUB metadata
+	AccessibilityFeatures    []string          `bun:",nullzero" json:"accessibility_features,omitempty"` // schema.org accessibilityFeature values from EPUB metadata
+	ReadingDirection         *string           `json:"reading_direction" tstype:"ReadingDirection"`      // CBZ page order from ComicInfo <Manga>, NULL when the file doesn't say
 	AudiobookDurationSeconds *float64          `json:"audiobook_duration_seconds"`
 	AudiobookBitrateBps      *int              `json:"audiobook_bitrate_bps"`
 	AudiobookCodec           *string           `json:"audiobook_codec"`
+	AudiobookSampleRate      *int              `json:"audiobook_sample_rate"` // Hz
+	AudiobookChannels        *int              `json:"audiobook_channels"`
 	Narrators                []*Narrator       `bun:"rel:has-many,join:id=file_id" json:"narrators,omitempty" tstype:"Narrator[]"`
 	NarratorSource           *string           `json:"narrator_source" tstype:"DataSource"`
 	Identifiers              []*FileIdentifier `bun:"rel:has-many,join:id=file_id" json:"identifiers,omitempty" tstype:"FileIdentifier[]"`
@@ -73,6 +115,7 @@ type File struct {
 	PublisherSource          *string           `json:"publisher_source" tstype:"DataSource"`
 	Publisher                *Publisher        `bun:"rel:belongs-to,join:publisher_id=id" json:"publisher,omitempty" tstype:"Publisher"`
 	ChapterSource            *string           `json:"chapter_source" tstype:"DataSource"`
+	ChapterOffsetMs          int               `bun:",notnull" json:"chapter_offset_ms"` // Added to chapter timestamps when they're served; stored chapters are left as scanned
 	Language                 *string           `json:"language"`
 	LanguageSource           *string           `json:"language_source" tstype:"DataSource"`
 	Abridged                 *bool             `json:"abridged"`
@@ -81,6 +124,9 @@ type File struct {
 	ReviewOverriddenAt       *time.Time        `json:"review_overridden_at"`
 	Reviewed                 *bool             `json:"reviewed"`
 	IsPreferredCover         bool              `bun:",default:false" json:"is_preferred_cover"`
+	Hidden                   bool              `json:"hidden"`        // Excluded from book lists unless explicitly requested
+	MissingSince             *time.Time        `json:"missing_since"` // First scan that found the file gone from disk, while within the grace period
+	MissingScans             int               `json:"missing_scans"` // Consecutive scans that found the file gone from disk
 }
 
 func (f *File) CoverExtension() string {
@@ -97,6 +143,38 @@ func (f *File) CoverExtension() string {
 	return ext
 }
 
+// CoverPageIndex returns the file's cover page as a 0-indexed page number for
+// page extraction, or nil if no cover page is set. CoverPage itself is stored
+// 1-indexed so that it matches what people see in readers and sidecars.
+func (f *File) CoverPageIndex() *int {
+	if f.CoverPage == nil {
+		return nil
+	}
+	idx := *f.CoverPage - 1
+	return &idx
+}
+
+// MarshalJSON serializes the file with its chapter offset applied to the
+// chapters, so API responses carry corrected timestamps while the stored ones
+// stay as scanned.
+func (f File) MarshalJSON() ([]byte, error) {
+	type file File // drops the method so this doesn't recurse
+	out := file(f)
+	out.Chapters = OffsetChapters(f.Chapters, f.ChapterOffsetMs)
+	b, err := json.Marshal(out)
+	return b, errors.WithStack(err)
+}
+
+// CoverPageFromIndex converts a 0-indexed page number (as produced by file
+// parsers and plugins) to the 1-indexed value stored in File.CoverPage.
+func CoverPageFromIndex(idx *int) *int {
+	if idx == nil {
+		return nil
+	}
+	page := *idx + 1
+	return &page
+}
+
 // IsPageBasedFileType returns true for file types that derive covers from page
 // content (CBZ, PDF). These formats should never have their covers replaced by
 // external sources (plugins, uploads).
@@ -17,12 +17,15 @@ const (
 )
 
 const (
-	//tygo:emit export type JobType = typeof JobTypeExport | typeof JobTypeScan | typeof JobTypeBulkDownload | typeof JobTypeHashGeneration | typeof JobTypeRecomputeReview;
-	JobTypeExport          = "export"
-	JobTypeScan            = "scan"
-	JobTypeBulkDownload    = "bulk_download"
-	JobTypeHashGeneration  = "hash_generation"
-	JobTypeRecomputeReview = "recompute_review"
+	//tygo:emit export type JobType = typeof JobTypeExport | typeof JobTypeScan | typeof JobTypeBulkDownload | typeof JobTypeHashGeneration | typeof JobTypeRecomputeReview | typeof JobTypeThumbnailBackfill | typeof JobTypeRegenerateSortTitles | typeof JobTypeRecompressComicPages;
+	JobTypeExport               = "export"
+	JobTypeScan                 = "scan"
+	JobTypeBulkDownload         = "bulk_download"
+	JobTypeHashGeneration       = "hash_generation"
+	JobTypeRecomputeReview      = "recompute_review"
+	JobTypeThumbnailBackfill    = "thumbnail_backfill"
+	JobTypeRegenerateSortTitles = "regenerate_sort_titles"
+	JobTypeRecompressComicPages = "recompress_comic_pages"
 )
 
 type Job struct {
@@ -34,7 +37,7 @@ type Job struct {
 	Type       string      `bun:",nullzero" json:"type" tstype:"JobType"`
 	Status     string      `bun:",nullzero" json:"status" tstype:"JobStatus"`
 	Data       string      `bun:",nullzero" json:"-"`
-	DataParsed interface{} `bun:"-" json:"data" tstype:"JobExportData | JobScanData | JobBulkDownloadData | JobHashGenerationData | JobRecomputeReviewData"`
+	DataParsed interface{} `bun:"-" json:"data" tstype:"JobExportData | JobScanData | JobBulkDownloadData | JobHashGenerationData | JobRecomputeReviewData | JobThumbnailBackfillData | JobRegenerateSortTitlesData | JobRecompressComicPagesData"`
 	Progress   int         `json:"progress"`
 	ProcessID  *string     `json:"process_id,omitempty"`
 	LibraryID  *int        `json:"library_id,omitempty"`
@@ -52,6 +55,12 @@ func (job *Job) UnmarshalData() error {
 		job.DataParsed = &JobHashGenerationData{}
 	case JobTypeRecomputeReview:
 		job.DataParsed = &JobRecomputeReviewData{}
+	case JobTypeThumbnailBackfill:
+		job.DataParsed = &JobThumbnailBackfillData{}
+	case JobTypeRegenerateSortTitles:
+		job.DataParsed = &JobRegenerateSortTitlesData{}
+	case JobTypeRecompressComicPages:
+		job.DataParsed = &JobRecompressComicPagesData{}
 	}
 
 	err := json.Unmarshal([]byte(job.Data), job.DataParsed)
@@ -64,7 +73,11 @@ func (job *Job) UnmarshalData() error {
 
 type JobExportData struct{}
 
-type JobScanData struct{}
+type JobScanData struct {
+	// DirPath limits the scan to one directory inside the job's library.
+	// Requires the job's library ID. Empty scans the whole library.
+	DirPath string `json:"dir_path,omitempty"`
+}
 
 // JobHashGenerationData is the payload for a hash generation job.
 // The job processes all files in the given library that do not yet have
@@ -79,6 +92,73 @@ type JobRecomputeReviewData struct {
 	ClearOverrides bool `json:"clear_overrides"`
 }
 
+// JobThumbnailBackfillData is the payload for a thumbnail backfill job. The
+// job generates cover thumbnails for every file with a cover in the library,
+// in file ID order, and records how far it got in LastFileID.
+type JobThumbnailBackfillData struct {
+	LibraryID int `json:"library_id"`
+	// MaxDim is the bounding box thumbnails are scaled to fit, in pixels.
+	// Defaults to thumbnails.DefaultMaxDim.
+	MaxDim int `json:"max_dim,omitempty"`
+	// Concurrency is how many thumbnails are generated at once. Defaults to 1
+	// so a backfill doesn't compete with the server for CPU.
+	Concurrency int `json:"concurrency,omitempty"`
+
+	// LastFileID is the checkpoint: every file up to and including this ID
+	// has been handled. A resumed job starts after it.
+	LastFileID int `json:"last_file_id,omitempty"`
+	// Generated counts thumbnails written so far, across resumes.
+	Generated int `json:"generated,omitempty"`
+}
+
+// JobRegenerateSortTitlesData is the payload for a job that recomputes the
+// sort titles of every book in a library.
+type JobRegenerateSortTitlesData struct {
+	LibraryID int `json:"library_id"`
+
+	// Updated is how many sort titles changed, set when the job finishes.
+	Updated int `json:"updated,omitempty"`
+}
+
+// JobRecompressComicPagesData is the payload for a job that re-encodes the
+// oversized PNG pages of CBZ files as JPEG to save space.
+type JobRecompressComicPagesData struct {
+	FileIDs []int `json:"file_ids"`
+	// Format is the format pages are re-encoded to. Only "jpeg" is
+	// supported.
+	Format string `json:"format,omitempty"`
+	// Quality is the JPEG quality, from 1 to 100. Defaults to
+	// cbz.DefaultRecompressQuality.
+	Quality int `json:"quality,omitempty"`
+	// MinPageBytes is how big a PNG page has to be to be re-encoded.
+	// Defaults to cbz.DefaultRecompressMinPageBytes.
+	MinPageBytes int64 `json:"min_page_bytes,omitempty"`
+	// InPlace replaces the library's files instead of writing copies to the
+	// cache directory.
+	InPlace bool `json:"in_place,omitempty"`
+	// Confirm must be true for the job to be created, since in-place runs
+	// rewrite library files and copies can be large.
+	Confirm bool `json:"confirm"`
+
+	// Results has an entry per file, set when the job finishes.
+	Results []JobRecompressComicPagesResult `json:"results,omitempty"`
+	// BytesSaved is the total space saved across all files.
+	BytesSaved int64 `json:"bytes_saved,omitempty"`
+}
+
+// JobRecompressComicPagesResult is the outcome for one file of a recompress
+// comic pages job.
+type JobRecompressComicPagesResult struct {
+	FileID int `json:"file_id"`
+	// OutputPath is where the recompressed copy was written, or the file
+	// itself for in-place runs.
+	OutputPath        string `json:"output_path,omitempty"`
+	PagesRecompressed int    `json:"pages_recompressed"`
+	OriginalSizeBytes int64  `json:"original_size_bytes"`
+	NewSizeBytes      int64  `json:"new_size_bytes"`
+	Error             string `json:"error,omitempty"`
+}
+
 type JobBulkDownloadData struct {
 	// Input (set on creation)
 	FileIDs            []int `json:"file_ids"`
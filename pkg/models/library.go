@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -27,12 +28,51 @@ const (
 type Library struct {
 	bun.BaseModel `bun:"table:libraries,alias:l" tstype:"-"`
 
-	ID                       int            `bun:",pk,nullzero" json:"id"`
-	CreatedAt                time.Time      `json:"created_at"`
-	UpdatedAt                time.Time      `json:"updated_at"`
-	Name                     string         `bun:",nullzero" json:"name"`
-	OrganizeFileStructure    bool           `json:"organize_file_structure"`
-	CoverAspectRatio         string         `bun:",nullzero" json:"cover_aspect_ratio" tstype:"CoverAspectRatio"`
-	DownloadFormatPreference string         `bun:",nullzero,default:'original'" json:"download_format_preference" tstype:"DownloadFormat"`
-	LibraryPaths             []*LibraryPath `bun:"rel:has-many" json:"library_paths,omitempty" tstype:"LibraryPath[]"`
+	ID                       int               `bun:",pk,nullzero" json:"id"`
+	CreatedAt                time.Time         `json:"created_at"`
+	UpdatedAt                time.Time         `json:"updated_at"`
+	Name                     string            `bun:",nullzero" json:"name"`
+	OrganizeFiles            bool              `json:"organize_files"`               // Rename files to match their book's metadata
+	OrganizeFolders          bool              `json:"organize_folders"`             // Rename book folders and move root-level files into them
+	Staging                  bool              `json:"staging"`                      // New books are imported as staged (see Book.Staged)
+	InferSeriesFromParentDir bool              `json:"infer_series_from_parent_dir"` // Infer series from "Series Name/01 - Title/" layouts
+	EnrichFromOpenLibrary    bool              `json:"enrich_from_open_library"`     // Fill empty fields from Open Library by ISBN during scans
+	AutoPairFormats          bool              `json:"auto_pair_formats"`            // Attach new audiobooks/ebooks to an existing book in the other format
+	StripSeriesFromTitle     bool              `json:"strip_series_from_title"`      // Scans turn "Series #2: Title" into the title "Title" in series "Series"
+	CoverAspectRatio         string            `bun:",nullzero" json:"cover_aspect_ratio" tstype:"CoverAspectRatio"`
+	CBZCoverPageDefault      int               `bun:",nullzero,default:1" json:"cbz_cover_page_default"` // 1-indexed page new CBZ scans use as the cover
+	PreferExternalCover      bool              `json:"prefer_external_cover"`                            // CBZ scans use a cover.jpg (or similar) in the book folder over the embedded cover
+	DownloadFormatPreference string            `bun:",nullzero,default:'original'" json:"download_format_preference" tstype:"DownloadFormat"`
+	AllowedFileTypes         []string          `bun:",nullzero" json:"allowed_file_types,omitempty"`  // File types (extensions) scans import; empty allows all
+	FilenamePatterns         []string          `bun:",nullzero" json:"filename_patterns,omitempty"`   // Regexes with named groups tried before the built-in filename conventions
+	NameSeparators           []string          `bun:",nullzero" json:"name_separators,omitempty"`     // What author and narrator lists are split on; empty uses fileutils.DefaultNameSeparators
+	ScanSchedule             string            `bun:",nullzero" json:"scan_schedule,omitempty"`       // Cron expression for scanning this library on its own; empty leaves it to the global sync interval
+	OrganizeSubfolders       map[string]string `bun:",nullzero" json:"organize_subfolders,omitempty"` // File type (extension) to the folder organized root-level files of that type go into
+	LibraryPaths             []*LibraryPath    `bun:"rel:has-many" json:"library_paths,omitempty" tstype:"LibraryPath[]"`
+}
+
+// OrganizeEnabled reports whether the library organizes books on disk at all,
+// renaming either their files or their folders.
+func (l *Library) OrganizeEnabled() bool {
+	return l.OrganizeFiles || l.OrganizeFolders
+}
+
+// AllowsFileType reports whether scans may import files of fileType (an
+// extension without the dot) into the library.
+func (l *Library) AllowsFileType(fileType string) bool {
+	if len(l.AllowedFileTypes) == 0 {
+		return true
+	}
+	for _, allowed := range l.AllowedFileTypes {
+		if strings.EqualFold(allowed, fileType) {
+			return true
+		}
+	}
+	return false
+}
+
+// OrganizeSubfolder returns the folder under the library root that organized
+// root-level files of fileType go into, or "" to organize them at the root.
+func (l *Library) OrganizeSubfolder(fileType string) string {
+	return l.OrganizeSubfolders[strings.ToLower(fileType)]
 }
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLibraryAllowsFileType(t *testing.T) {
+	t.Parallel()
+
+	all := &Library{}
+	assert.True(t, all.AllowsFileType(FileTypeEPUB))
+	assert.True(t, all.AllowsFileType("mobi"))
+
+	audiobooks := &Library{AllowedFileTypes: []string{FileTypeM4B}}
+	assert.True(t, audiobooks.AllowsFileType(FileTypeM4B))
+	assert.True(t, audiobooks.AllowsFileType("M4B"))
+	assert.False(t, audiobooks.AllowsFileType(FileTypeEPUB))
+}
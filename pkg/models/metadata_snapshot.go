@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/encoding/json"
+	"github.com/uptrace/bun"
+)
+
+// MetadataSnapshot is a copy of a book's metadata taken before a book or file
+// resync changed it, so the change can be reverted.
+type MetadataSnapshot struct {
+	bun.BaseModel `bun:"table:metadata_snapshots,alias:ms" tstype:"-"`
+
+	ID         int                   `bun:",pk,nullzero" json:"id"`
+	CreatedAt  time.Time             `json:"created_at"`
+	BookID     int                   `bun:",nullzero" json:"book_id"`
+	FileID     *int                  `json:"file_id"` // The file that was resynced; nil for a book resync
+	Data       string                `bun:",nullzero" json:"-"`
+	DataParsed *MetadataSnapshotData `bun:"-" json:"data"`
+}
+
+// MetadataSnapshotData is the metadata kept in a snapshot. People, series,
+// genres, tags, and publishers are stored by name, so reverting still works
+// after a scan left the originals unused and they were cleaned up. Covers and
+// chapters aren't included.
+type MetadataSnapshotData struct {
+	Title             string                 `json:"title"`
+	TitleSource       string                 `json:"title_source" tstype:"DataSource"`
+	SortTitle         string                 `json:"sort_title"`
+	SortTitleSource   string                 `json:"sort_title_source" tstype:"DataSource"`
+	Subtitle          *string                `json:"subtitle"`
+	SubtitleSource    *string                `json:"subtitle_source" tstype:"DataSource"`
+	Description       *string                `json:"description"`
+	DescriptionSource *string                `json:"description_source" tstype:"DataSource"`
+	Authors           []SnapshotPerson       `json:"authors"`
+	AuthorSource      string                 `json:"author_source" tstype:"DataSource"`
+	Series            []SnapshotSeries       `json:"series"`
+	Genres            []string               `json:"genres"`
+	GenreSource       *string                `json:"genre_source" tstype:"DataSource"`
+	Tags              []string               `json:"tags"`
+	TagSource         *string                `json:"tag_source" tstype:"DataSource"`
+	Files             []MetadataSnapshotFile `json:"files"`
+}
+
+// MetadataSnapshotFile is the metadata kept in a snapshot for one of the
+// book's files.
+type MetadataSnapshotFile struct {
+	FileID            int                  `json:"file_id"`
+	Name              *string              `json:"name"`
+	NameSource        *string              `json:"name_source" tstype:"DataSource"`
+	Narrators         []SnapshotPerson     `json:"narrators"`
+	NarratorSource    *string              `json:"narrator_source" tstype:"DataSource"`
+	Identifiers       []SnapshotIdentifier `json:"identifiers"`
+	IdentifierSource  *string              `json:"identifier_source" tstype:"DataSource"`
+	URL               *string              `json:"url"`
+	URLSource         *string              `json:"url_source" tstype:"DataSource"`
+	ReleaseDate       *time.Time           `json:"release_date"`
+	ReleaseDateSource *string              `json:"release_date_source" tstype:"DataSource"`
+	Publisher         *string              `json:"publisher"`
+	PublisherSource   *string              `json:"publisher_source" tstype:"DataSource"`
+	Language          *string              `json:"language"`
+	LanguageSource    *string              `json:"language_source" tstype:"DataSource"`
+	Abridged          *bool                `json:"abridged"`
+	AbridgedSource    *string              `json:"abridged_source" tstype:"DataSource"`
+}
+
+// SnapshotPerson is an author or narrator in a snapshot.
+type SnapshotPerson struct {
+	Name string  `json:"name"`
+	Role *string `json:"role,omitempty"`
+}
+
+// SnapshotSeries is a series the book belonged to in a snapshot.
+type SnapshotSeries struct {
+	Name             string   `json:"name"`
+	SeriesNumber     *float64 `json:"series_number,omitempty"`
+	SeriesNumberEnd  *float64 `json:"series_number_end,omitempty"`
+	SeriesNumberUnit *string  `json:"series_number_unit,omitempty" tstype:"SeriesNumberUnit"`
+	ReadingOrder     *float64 `json:"reading_order,omitempty"`
+}
+
+// SnapshotIdentifier is a file identifier in a snapshot.
+type SnapshotIdentifier struct {
+	Type   string `json:"type" tstype:"IdentifierType"`
+	Value  string `json:"value"`
+	Source string `json:"source" tstype:"DataSource"`
+}
+
+func (snapshot *MetadataSnapshot) UnmarshalData() error {
+	snapshot.DataParsed = &MetadataSnapshotData{}
+	if err := json.Unmarshal([]byte(snapshot.Data), snapshot.DataParsed); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
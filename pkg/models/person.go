@@ -19,6 +19,15 @@ type Person struct {
 	Aliases        []*PersonAlias `bun:"rel:has-many,join:id=person_id" json:"aliases" tstype:"-"`
 }
 
+// PersonRole values for filtering people by how they're credited: "author"
+// matches anyone with an authors row (in any AuthorRole), "narrator" anyone
+// with a narrators row.
+const (
+	//tygo:emit export type PersonRole = typeof PersonRoleAuthor | typeof PersonRoleNarrator;
+	PersonRoleAuthor   = "author"
+	PersonRoleNarrator = "narrator"
+)
+
 // Author role constants for CBZ ComicInfo.xml creator types.
 const (
 	//tygo:emit export type AuthorRole = typeof AuthorRoleWriter | typeof AuthorRolePenciller | typeof AuthorRoleInker | typeof AuthorRoleColorist | typeof AuthorRoleLetterer | typeof AuthorRoleCoverArtist | typeof AuthorRoleEditor | typeof AuthorRoleTranslator;
@@ -51,4 +60,5 @@ type Narrator struct {
 	PersonID  int     `bun:",nullzero" json:"person_id"`
 	Person    *Person `bun:"rel:belongs-to,join:person_id=id" json:"person,omitempty" tstype:"Person"`
 	SortOrder int     `bun:",nullzero" json:"sort_order"`
+	Role      *string `json:"role"` // Part read in a full-cast audiobook, e.g. "Kaladin". NULL when not credited by role
 }
@@ -8,11 +8,12 @@ import (
 
 // Plugin hook type constants.
 const (
-	//tygo:emit export type PluginHookType = typeof PluginHookInputConverter | typeof PluginHookFileParser | typeof PluginHookOutputGenerator | typeof PluginHookMetadataEnricher;
+	//tygo:emit export type PluginHookType = typeof PluginHookInputConverter | typeof PluginHookFileParser | typeof PluginHookOutputGenerator | typeof PluginHookMetadataEnricher | typeof PluginHookOrganizer;
 	PluginHookInputConverter   = "inputConverter"
 	PluginHookFileParser       = "fileParser"
 	PluginHookOutputGenerator  = "outputGenerator"
 	PluginHookMetadataEnricher = "metadataEnricher"
+	PluginHookOrganizer        = "organizer"
 )
 
 // PluginStatus represents the lifecycle state of a plugin.
@@ -117,8 +118,8 @@ type LibraryPluginHookConfig struct {
 	Mode      string `bun:",notnull,default:'enabled'" json:"mode" tstype:"PluginMode"`
 }
 
-// PluginFieldSetting stores global field enable/disable settings for a plugin.
-// Absence of a row means the field is enabled (default).
+// PluginFieldSetting stores global field settings for a plugin.
+// Absence of a row means the field is enabled and not preferred (default).
 type PluginFieldSetting struct {
 	bun.BaseModel `bun:"table:plugin_field_settings,alias:pfs" tstype:"-"`
 
@@ -126,6 +127,23 @@ type PluginFieldSetting struct {
 	PluginID string `bun:",pk" json:"plugin_id"`
 	Field    string `bun:",pk" json:"field"`
 	Enabled  bool   `bun:",notnull" json:"enabled"`
+	// Prefer lets the plugin's value for this field override values read from
+	// file metadata during scans. Only supported for PluginPreferableFields.
+	Prefer bool `bun:",notnull" json:"prefer"`
+}
+
+// PluginPreferableFields lists the enricher fields that support the prefer
+// setting. Other fields always follow the first-non-empty merge rules.
+var PluginPreferableFields = []string{"cover"}
+
+// IsPluginPreferableField returns true if field supports the prefer setting.
+func IsPluginPreferableField(field string) bool {
+	for _, f := range PluginPreferableFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
 }
 
 // LibraryPluginFieldSetting stores per-library field overrides.
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// ScanError is a file in a library that failed to scan. Each full scan
+// replaces the library's errors, keeping when each one was first and last
+// seen, and a successful retry clears it.
+type ScanError struct {
+	bun.BaseModel `bun:"table:scan_errors,alias:se" tstype:"-"`
+
+	ID        int       `bun:",pk,nullzero" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	LibraryID int       `bun:",nullzero" json:"library_id"`
+	// FileID is set when the path is a file already in the library, so a
+	// retry resyncs it rather than importing it again.
+	FileID *int   `json:"file_id,omitempty"`
+	Path   string `bun:",nullzero" json:"path"`
+	// Code is the errcodes code of the error, "timeout" or "canceled", or
+	// "unknown" for anything else.
+	Code     string    `bun:",nullzero" json:"code"`
+	Message  string    `bun:",nullzero" json:"message"`
+	LastSeen time.Time `json:"last_seen"`
+}
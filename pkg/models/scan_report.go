@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/encoding/json"
+	"github.com/uptrace/bun"
+)
+
+// ScanReport is the summary of a completed scan job. Unlike the job's logs,
+// it's a compact record of what the scan changed that can be audited later.
+type ScanReport struct {
+	bun.BaseModel `bun:"table:scan_reports,alias:sr" tstype:"-"`
+
+	ID           int               `bun:",pk,nullzero" json:"id"`
+	CreatedAt    time.Time         `json:"created_at"`
+	JobID        int               `bun:",nullzero" json:"job_id"`
+	BooksCreated int               `json:"books_created"`
+	BooksUpdated int               `json:"books_updated"`
+	BooksDeleted int               `json:"books_deleted"`
+	FilesCreated int               `json:"files_created"`
+	FilesUpdated int               `json:"files_updated"`
+	FilesDeleted int               `json:"files_deleted"`
+	FilesSkipped int               `json:"files_skipped"`
+	FilesErrored int               `json:"files_errored"`
+	Errors       string            `bun:",nullzero" json:"-"`
+	ErrorsParsed []ScanReportError `bun:"-" json:"errors"`
+	// SkipReasons counts the library's recorded skips (see ScanSkip) by
+	// reason. Unlike FilesSkipped, it includes files left out before they
+	// were scanned, like ignored or disallowed ones.
+	SkipReasons       string         `bun:",nullzero" json:"-"`
+	SkipReasonsParsed map[string]int `bun:"-" json:"skip_reasons"`
+	// Warnings are non-fatal problems found in files that still scanned,
+	// like a sidecar that failed validation and was ignored.
+	Warnings       string              `bun:",nullzero" json:"-"`
+	WarningsParsed []ScanReportWarning `bun:"-" json:"warnings"`
+}
+
+// ScanReportError is a file that failed to scan.
+type ScanReportError struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// ScanReportWarning is a problem found in a file that still scanned.
+type ScanReportWarning struct {
+	Path    string `json:"path"`
+	Warning string `json:"warning"`
+}
+
+func (report *ScanReport) UnmarshalErrors() error {
+	report.ErrorsParsed = []ScanReportError{}
+	report.SkipReasonsParsed = map[string]int{}
+	report.WarningsParsed = []ScanReportWarning{}
+	if report.Errors != "" {
+		if err := json.Unmarshal([]byte(report.Errors), &report.ErrorsParsed); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if report.SkipReasons != "" {
+		if err := json.Unmarshal([]byte(report.SkipReasons), &report.SkipReasonsParsed); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if report.Warnings != "" {
+		if err := json.Unmarshal([]byte(report.Warnings), &report.WarningsParsed); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	//tygo:emit export type ScanSkipReason = typeof ScanSkipReasonIgnored | typeof ScanSkipReasonDisallowedType | typeof ScanSkipReasonUnsupportedType | typeof ScanSkipReasonTooSmall | typeof ScanSkipReasonUnreadable | typeof ScanSkipReasonMimeMismatch | typeof ScanSkipReasonDRMProtected;
+	ScanSkipReasonIgnored         = "ignored"
+	ScanSkipReasonDisallowedType  = "disallowed_type"
+	ScanSkipReasonUnsupportedType = "unsupported_file_type"
+	ScanSkipReasonTooSmall        = "too_small"
+	ScanSkipReasonUnreadable      = "unreadable"
+	ScanSkipReasonMimeMismatch    = "mime_mismatch"
+	ScanSkipReasonDRMProtected    = "drm_protected"
+)
+
+// ScanSkip is a path in a library that the most recent full scan of it
+// didn't import, with the reason why. Each full scan replaces the library's
+// skips.
+type ScanSkip struct {
+	bun.BaseModel `bun:"table:scan_skips,alias:ss" tstype:"-"`
+
+	ID        int       `bun:",pk,nullzero" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	LibraryID int       `bun:",nullzero" json:"library_id"`
+	JobID     *int      `json:"job_id,omitempty"`
+	Path      string    `bun:",nullzero" json:"path"`
+	Reason    string    `bun:",nullzero" json:"reason" tstype:"ScanSkipReason"`
+	// Detail adds context for some reasons, like the detected MIME type of
+	// a mime_mismatch.
+	Detail *string `json:"detail,omitempty"`
+}
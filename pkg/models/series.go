@@ -12,6 +12,15 @@ const (
 	SeriesNumberUnitChapter = "chapter"
 )
 
+// SeriesOrder values for ordering a series' books: by publication order
+// (series number) or by reading order, which falls back to the series number
+// for books without an explicit reading order.
+const (
+	//tygo:emit export type SeriesOrder = typeof SeriesOrderPublication | typeof SeriesOrderReading;
+	SeriesOrderPublication = "publication"
+	SeriesOrderReading     = "reading"
+)
+
 type Series struct {
 	bun.BaseModel `bun:"table:series,alias:s" tstype:"-"`
 
@@ -42,5 +51,9 @@ type BookSeries struct {
 	SeriesNumber     *float64 `json:"series_number,omitempty"`
 	SeriesNumberEnd  *float64 `json:"series_number_end,omitempty"`
 	SeriesNumberUnit *string  `json:"series_number_unit,omitempty" tstype:"SeriesNumberUnit"`
-	SortOrder        int      `bun:",nullzero" json:"sort_order"`
+	// ReadingOrder is the book's position when the series is read in its
+	// suggested order, which can differ from publication order (SeriesNumber).
+	// Nil means the reading order follows SeriesNumber.
+	ReadingOrder *float64 `json:"reading_order,omitempty"`
+	SortOrder    int      `bun:",nullzero" json:"sort_order"`
 }
@@ -16,6 +16,7 @@ type Metadata struct {
 	Subtitle      string                       // from ----:com.apple.iTunes:SUBTITLE or ----:com.pilabor.tone:SUBTITLE
 	Authors       []mediafile.ParsedAuthor     // from ©ART (artist)
 	Narrators     []string                     // from ©nrt (narrator) or ©cmp (composer)
+	NarratorRoles []mediafile.ParsedNarrator   // from ----:com.shisho:narrator_roles freeform, matched to Narrators
 	Album         string                       // from ©alb
 	Series        string                       // parsed from com.apple.iTunes:SERIES freeform or ©grp
 	SeriesNumber  *float64                     // parsed from com.apple.iTunes:SERIES-PART freeform or ©grp
@@ -25,7 +26,8 @@ type Metadata struct {
 	Description   string                       // from desc
 	Publisher     string                       // from ©pub
 	URL           string                       // from com.shisho:url freeform
-	ReleaseDate   *time.Time                   // parsed from rldt or ©day
+	ReleaseDate   *time.Time                   // parsed from rldt or ©day when it holds a full date
+	ReleaseYear   *int                         // parsed from rldt or ©day when it only holds a year (and maybe a month)
 	Comment       string                       // from ©cmt
 	Year          string                       // from ©day
 	Copyright     string                       // from ©cpy
@@ -35,6 +37,8 @@ type Metadata struct {
 	Duration      time.Duration                // from mvhd
 	Bitrate       int                          // bps from esds
 	Codec         string                       // audio codec with profile (e.g., "AAC-LC", "xHE-AAC")
+	SampleRate    int                          // Hz from the audio sample entry
+	Channels      int                          // channel count from the audio sample entry
 	Chapters      []Chapter                    // chapter list (Phase 3)
 	MediaType     int                          // from stik (2 = audiobook)
 	Freeform      map[string]string            // freeform (----) atoms like com.apple.iTunes:ASIN
@@ -87,15 +91,9 @@ func convertRawMetadata(raw *rawMetadata) *Metadata {
 		meta.Authors[i] = mediafile.ParsedAuthor{Name: name, Role: ""}
 	}
 
-	// Parse narrators (comma-separated)
-	// Prefer ©nrt (dedicated narrator), fall back to ©cmp (composer), then ©wrt (writer)
-	if raw.narrator != "" {
-		meta.Narrators = splitMultiValue(raw.narrator)
-	} else if raw.composer != "" {
-		meta.Narrators = splitMultiValue(raw.composer)
-	} else if raw.writer != "" {
-		meta.Narrators = splitMultiValue(raw.writer)
-	}
+	// Parse narrators (comma-separated) from the dedicated ©nrt atom.
+	// Falling back to ©cmp or ©wrt is opt-in through ParseOptions.
+	meta.Narrators, meta.NarratorRoles = withNarratorRoles(narratorsFromRaw(raw, nil), raw.freeform[NarratorRolesKey])
 
 	// Parse series information.
 	// Priority:
@@ -130,6 +128,10 @@ func convertRawMetadata(raw *rawMetadata) *Metadata {
 	// Copy codec from esds
 	meta.Codec = raw.codec
 
+	// Copy audio format from the sample entry
+	meta.SampleRate = int(raw.sampleRate)
+	meta.Channels = int(raw.channels)
+
 	// Parse genres from genre field (comma-separated)
 	if raw.genre != "" {
 		meta.Genres = splitMultiValue(raw.genre)
@@ -193,27 +195,18 @@ func convertRawMetadata(raw *rawMetadata) *Metadata {
 	// Set publisher
 	meta.Publisher = raw.publisher
 
-	// Parse release date - prefer rldt, fall back to ©day
-	var releaseDate *time.Time
-	dateStr := raw.releaseDate
-	if dateStr == "" {
-		dateStr = raw.year
-	}
-	if dateStr != "" {
-		formats := []string{
-			"2006-01-02",
-			"2006-01-02T15:04:05Z",
-			"2006-01-02T15:04:05-07:00",
-			"2006",
-		}
-		for _, format := range formats {
-			if t, err := time.Parse(format, dateStr); err == nil {
-				releaseDate = &t
-				break
-			}
+	// Parse release date - prefer rldt, fall back to ©day. A full date in
+	// either wins over a year on its own.
+	meta.ReleaseDate, meta.ReleaseYear = parseReleaseDate(raw.releaseDate)
+	if meta.ReleaseDate == nil {
+		date, year := parseReleaseDate(raw.year)
+		switch {
+		case date != nil:
+			meta.ReleaseDate, meta.ReleaseYear = date, nil
+		case meta.ReleaseYear == nil:
+			meta.ReleaseYear = year
 		}
 	}
-	meta.ReleaseDate = releaseDate
 
 	// Copy chapters
 	meta.Chapters = raw.chapters
@@ -224,6 +217,54 @@ func convertRawMetadata(raw *rawMetadata) *Metadata {
 	return meta
 }
 
+// releaseDateLayouts are the full date forms accepted in rldt and ©day,
+// which taggers write as a plain date or an ISO 8601 timestamp.
+var releaseDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// releaseYearLayouts are the partial date forms accepted in rldt and ©day,
+// which only give a year.
+var releaseYearLayouts = []string{
+	"2006-01",
+	"2006",
+}
+
+// parseReleaseDate parses a release date atom. It returns date when s holds
+// a full date, year when it holds a year or a year and month, and neither
+// when it can't be parsed.
+func parseReleaseDate(s string) (date *time.Time, year *int) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	for _, layout := range releaseDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t, nil
+		}
+	}
+	for _, layout := range releaseYearLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			y := t.Year()
+			return nil, &y
+		}
+	}
+	return nil, nil
+}
+
+// parsedReleaseDate returns the release date reported in ParsedMetadata,
+// which has no separate year: a year on its own becomes January 1st of it.
+func (m *Metadata) parsedReleaseDate() *time.Time {
+	if m.ReleaseDate != nil || m.ReleaseYear == nil {
+		return m.ReleaseDate
+	}
+	t := time.Date(*m.ReleaseYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
 // parseSeriesFromGrouping extracts series name and number from a grouping string.
 // Handles patterns like "Dungeon Crawler Carl #7", "Series Name, Book 3",
 // and "Series Name - Volume 2".
@@ -284,3 +325,90 @@ func convertChaptersToParsed(chapters []Chapter) []mediafile.ParsedChapter {
 	}
 	return parsed
 }
+
+// Atom names accepted in a narrator fallback list.
+const (
+	NarratorAtomComposer = "composer" // ©cmp
+	NarratorAtomWriter   = "writer"   // ©wrt
+)
+
+// NarratorRolesKey is the freeform atom that credits narrators by role in
+// full-cast audiobooks. It holds "Name: Role" entries separated by semicolons
+// or new lines, like "Michael Kramer: Kaladin; Kate Reading: Shallan".
+const NarratorRolesKey = "com.shisho:narrator_roles"
+
+// parseNarratorRoles parses the value of the NarratorRolesKey atom. Entries
+// without a colon are narrators with no role.
+func parseNarratorRoles(value string) []mediafile.ParsedNarrator {
+	var roles []mediafile.ParsedNarrator
+	for _, entry := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == '\n' }) {
+		name, role, _ := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		roles = append(roles, mediafile.ParsedNarrator{Name: name, Role: strings.TrimSpace(role)})
+	}
+	return roles
+}
+
+// formatNarratorRoles is the inverse of parseNarratorRoles. It returns ""
+// when no narrator has a role.
+func formatNarratorRoles(narrators []mediafile.ParsedNarrator) string {
+	entries := make([]string, 0, len(narrators))
+	for _, n := range narrators {
+		if n.Role != "" {
+			entries = append(entries, n.Name+": "+n.Role)
+		}
+	}
+	return strings.Join(entries, "; ")
+}
+
+// withNarratorRoles matches narrators to the roles in rolesValue, the value
+// of the NarratorRolesKey atom, by name. When narrators is empty, the names
+// in rolesValue are the narrators. The returned roles are nil when no
+// narrator has one.
+func withNarratorRoles(narrators []string, rolesValue string) ([]string, []mediafile.ParsedNarrator) {
+	parsed := parseNarratorRoles(rolesValue)
+	if len(narrators) == 0 {
+		for _, n := range parsed {
+			narrators = append(narrators, n.Name)
+		}
+	}
+	roleByName := make(map[string]string, len(parsed))
+	for _, n := range parsed {
+		if n.Role != "" {
+			roleByName[strings.ToLower(n.Name)] = n.Role
+		}
+	}
+	if len(roleByName) == 0 {
+		return narrators, nil
+	}
+	roles := make([]mediafile.ParsedNarrator, len(narrators))
+	for i, name := range narrators {
+		roles[i] = mediafile.ParsedNarrator{Name: name, Role: roleByName[strings.ToLower(name)]}
+	}
+	return narrators, roles
+}
+
+// narratorsFromRaw returns the narrators from ©nrt, or from the first
+// non-empty atom in fallback when ©nrt is empty. Unknown atom names are
+// ignored.
+func narratorsFromRaw(raw *rawMetadata, fallback []string) []string {
+	if raw.narrator != "" {
+		return splitMultiValue(raw.narrator)
+	}
+	for _, atom := range fallback {
+		var value string
+		switch atom {
+		case NarratorAtomComposer:
+			value = raw.composer
+		case NarratorAtomWriter:
+			value = raw.writer
+		}
+		if value != "" {
+			return splitMultiValue(value)
+		}
+	}
+	return nil
+}
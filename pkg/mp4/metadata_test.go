@@ -3,6 +3,7 @@ package mp4
 import (
 	"testing"
 
+	"github.com/shishobooks/shisho/pkg/mediafile"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -261,3 +262,237 @@ func TestConvertRawMetadata_AlbumIsNotSeriesSource(t *testing.T) {
 	assert.Empty(t, meta.Series)
 	assert.Nil(t, meta.SeriesNumber)
 }
+
+// TestNarratorsFromRaw tests the ©nrt preference and the configurable
+// composer/writer fallback chain.
+func TestNarratorsFromRaw(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		raw      rawMetadata
+		fallback []string
+		want     []string
+	}{
+		{
+			name:     "dedicated narrator atom wins",
+			raw:      rawMetadata{narrator: "Stephen Fry", composer: "Composer", writer: "Writer"},
+			fallback: []string{NarratorAtomComposer, NarratorAtomWriter},
+			want:     []string{"Stephen Fry"},
+		},
+		{
+			name:     "falls back to composer",
+			raw:      rawMetadata{composer: "Jim Dale, Stephen Fry", writer: "Writer"},
+			fallback: []string{NarratorAtomComposer, NarratorAtomWriter},
+			want:     []string{"Jim Dale", "Stephen Fry"},
+		},
+		{
+			name:     "falls back to writer",
+			raw:      rawMetadata{writer: "Writer"},
+			fallback: []string{NarratorAtomComposer, NarratorAtomWriter},
+			want:     []string{"Writer"},
+		},
+		{
+			name:     "custom order",
+			raw:      rawMetadata{composer: "Composer", writer: "Writer"},
+			fallback: []string{NarratorAtomWriter, NarratorAtomComposer},
+			want:     []string{"Writer"},
+		},
+		{
+			name:     "composer only skips writer",
+			raw:      rawMetadata{writer: "Writer"},
+			fallback: []string{NarratorAtomComposer},
+			want:     nil,
+		},
+		{
+			name:     "empty fallback disables",
+			raw:      rawMetadata{composer: "Composer"},
+			fallback: []string{},
+			want:     nil,
+		},
+		{
+			name:     "unknown atoms are ignored",
+			raw:      rawMetadata{composer: "Composer"},
+			fallback: []string{"album", NarratorAtomComposer},
+			want:     []string{"Composer"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, narratorsFromRaw(&tc.raw, tc.fallback))
+		})
+	}
+}
+
+// TestConvertRawMetadata_NoNarratorFallback tests that narrators are only
+// read from ©nrt unless a fallback is asked for.
+func TestConvertRawMetadata_NoNarratorFallback(t *testing.T) {
+	t.Parallel()
+
+	meta := convertRawMetadata(&rawMetadata{composer: "Composer", writer: "Writer"})
+	assert.Empty(t, meta.Narrators)
+
+	meta = convertRawMetadata(&rawMetadata{narrator: "Stephen Fry", composer: "Composer"})
+	assert.Equal(t, []string{"Stephen Fry"}, meta.Narrators)
+}
+
+// TestWithNarratorRoles tests matching narrators to the roles in the
+// com.shisho:narrator_roles freeform atom.
+func TestWithNarratorRoles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		narrators     []string
+		roles         string
+		wantNarrators []string
+		wantRoles     []mediafile.ParsedNarrator
+	}{
+		{
+			name:          "no roles atom",
+			narrators:     []string{"Stephen Fry"},
+			wantNarrators: []string{"Stephen Fry"},
+		},
+		{
+			name:          "roles matched by name",
+			narrators:     []string{"Michael Kramer", "Kate Reading", "Full Cast"},
+			roles:         "kate reading: Shallan, Jasnah\nMichael Kramer: Kaladin",
+			wantNarrators: []string{"Michael Kramer", "Kate Reading", "Full Cast"},
+			wantRoles: []mediafile.ParsedNarrator{
+				{Name: "Michael Kramer", Role: "Kaladin"},
+				{Name: "Kate Reading", Role: "Shallan, Jasnah"},
+				{Name: "Full Cast"},
+			},
+		},
+		{
+			name:          "roles atom supplies the narrators",
+			roles:         "Michael Kramer: Kaladin; Kate Reading",
+			wantNarrators: []string{"Michael Kramer", "Kate Reading"},
+			wantRoles: []mediafile.ParsedNarrator{
+				{Name: "Michael Kramer", Role: "Kaladin"},
+				{Name: "Kate Reading"},
+			},
+		},
+		{
+			name:          "names without roles",
+			narrators:     []string{"Stephen Fry"},
+			roles:         "Stephen Fry",
+			wantNarrators: []string{"Stephen Fry"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			narrators, roles := withNarratorRoles(tc.narrators, tc.roles)
+			assert.Equal(t, tc.wantNarrators, narrators)
+			assert.Equal(t, tc.wantRoles, roles)
+		})
+	}
+}
+
+func TestFormatNarratorRoles(t *testing.T) {
+	t.Parallel()
+
+	narrators := []mediafile.ParsedNarrator{
+		{Name: "Michael Kramer", Role: "Kaladin"},
+		{Name: "Full Cast"},
+		{Name: "Kate Reading", Role: "Shallan, Jasnah"},
+	}
+	formatted := formatNarratorRoles(narrators)
+	assert.Equal(t, "Michael Kramer: Kaladin; Kate Reading: Shallan, Jasnah", formatted)
+
+	_, roles := withNarratorRoles([]string{"Michael Kramer", "Full Cast", "Kate Reading"}, formatted)
+	assert.Equal(t, narrators, roles)
+	assert.Empty(t, formatNarratorRoles([]mediafile.ParsedNarrator{{Name: "Full Cast"}}))
+}
+
+func TestParseReleaseDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		date  string // RFC 3339, empty for no date
+		year  int    // 0 for no year
+	}{
+		{name: "year", input: "2021", year: 2021},
+		{name: "year and month", input: "2021-06", year: 2021},
+		{name: "full date", input: "2021-06-15", date: "2021-06-15T00:00:00Z"},
+		{name: "UTC timestamp", input: "2021-06-15T10:30:00Z", date: "2021-06-15T10:30:00Z"},
+		{name: "timestamp with offset", input: "2021-06-15T10:30:00-07:00", date: "2021-06-15T10:30:00-07:00"},
+		{name: "timestamp with fractional seconds", input: "2021-06-15T10:30:00.123Z", date: "2021-06-15T10:30:00.123Z"},
+		{name: "timestamp without zone", input: "2021-06-15T10:30:00", date: "2021-06-15T10:30:00Z"},
+		{name: "space separated timestamp", input: "2021-06-15 10:30:00", date: "2021-06-15T10:30:00Z"},
+		{name: "surrounding whitespace", input: " 2021-06-15\n", date: "2021-06-15T00:00:00Z"},
+		{name: "empty", input: ""},
+		{name: "unparseable", input: "June 2021"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			date, year := parseReleaseDate(tt.input)
+			if tt.date == "" {
+				assert.Nil(t, date)
+			} else {
+				require.NotNil(t, date)
+				assert.Equal(t, tt.date, date.Format("2006-01-02T15:04:05.999Z07:00"))
+			}
+			if tt.year == 0 {
+				assert.Nil(t, year)
+			} else {
+				require.NotNil(t, year)
+				assert.Equal(t, tt.year, *year)
+			}
+		})
+	}
+}
+
+func TestConvertRawMetadata_ReleaseDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		releaseDate string // rldt
+		year        string // ©day
+		wantDate    string // YYYY-MM-DD, empty for no date
+		wantYear    int    // 0 for no year
+		wantParsed  string // YYYY-MM-DD in ParsedMetadata, empty for none
+	}{
+		{name: "full date in ©day", year: "2021-06-15", wantDate: "2021-06-15", wantParsed: "2021-06-15"},
+		{name: "year in ©day", year: "2021", wantYear: 2021, wantParsed: "2021-01-01"},
+		{name: "year and month in ©day", year: "2021-06", wantYear: 2021, wantParsed: "2021-01-01"},
+		{name: "rldt preferred", releaseDate: "2020-03-01", year: "2021-06-15", wantDate: "2020-03-01", wantParsed: "2020-03-01"},
+		{name: "full ©day beats rldt year", releaseDate: "2020", year: "2021-06-15", wantDate: "2021-06-15", wantParsed: "2021-06-15"},
+		{name: "rldt year beats ©day year", releaseDate: "2020", year: "2021", wantYear: 2020, wantParsed: "2020-01-01"},
+		{name: "unparseable", year: "sometime"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			meta := convertRawMetadata(&rawMetadata{releaseDate: tt.releaseDate, year: tt.year})
+			if tt.wantDate == "" {
+				assert.Nil(t, meta.ReleaseDate)
+			} else {
+				require.NotNil(t, meta.ReleaseDate)
+				assert.Equal(t, tt.wantDate, meta.ReleaseDate.Format("2006-01-02"))
+			}
+			if tt.wantYear == 0 {
+				assert.Nil(t, meta.ReleaseYear)
+			} else {
+				require.NotNil(t, meta.ReleaseYear)
+				assert.Equal(t, tt.wantYear, *meta.ReleaseYear)
+			}
+			if parsed := meta.parsedReleaseDate(); tt.wantParsed == "" {
+				assert.Nil(t, parsed)
+			} else {
+				require.NotNil(t, parsed)
+				assert.Equal(t, tt.wantParsed, parsed.Format("2006-01-02"))
+			}
+		})
+	}
+}
@@ -6,9 +6,24 @@ import (
 	"github.com/shishobooks/shisho/pkg/models"
 )
 
+// ParseOptions controls optional behavior of ParseWithOptions.
+type ParseOptions struct {
+	// NarratorFallbackAtoms lists the atoms ("composer", "writer") consulted,
+	// in order, when the ©nrt narrator atom is empty. FFmpeg and several
+	// other tools only write narrators to the composer atom. Nil or empty
+	// disables the fallback.
+	NarratorFallbackAtoms []string
+}
+
 // Parse reads metadata from an M4B/MP4 file and returns it in the
 // mediafile.ParsedMetadata format for compatibility with the existing scanner.
 func Parse(path string) (*mediafile.ParsedMetadata, error) {
+	return ParseWithOptions(path, ParseOptions{})
+}
+
+// ParseWithOptions is like Parse but allows customizing how metadata is
+// extracted.
+func ParseWithOptions(path string, opts ParseOptions) (*mediafile.ParsedMetadata, error) {
 	// Read raw metadata using go-mp4
 	raw, err := readMetadata(path)
 	if err != nil {
@@ -17,6 +32,9 @@ func Parse(path string) (*mediafile.ParsedMetadata, error) {
 
 	// Convert to the full Metadata struct (which does series parsing, etc.)
 	meta := convertRawMetadata(raw)
+	if len(opts.NarratorFallbackAtoms) > 0 {
+		meta.Narrators, meta.NarratorRoles = withNarratorRoles(narratorsFromRaw(raw, opts.NarratorFallbackAtoms), raw.freeform[NarratorRolesKey])
+	}
 
 	// Convert to the mediafile.ParsedMetadata format
 	return &mediafile.ParsedMetadata{
@@ -24,6 +42,7 @@ func Parse(path string) (*mediafile.ParsedMetadata, error) {
 		Subtitle:      meta.Subtitle,
 		Authors:       meta.Authors,
 		Narrators:     meta.Narrators,
+		NarratorRoles: meta.NarratorRoles,
 		Series:        meta.Series,
 		SeriesNumber:  meta.SeriesNumber,
 		Genres:        meta.Genres,
@@ -31,13 +50,15 @@ func Parse(path string) (*mediafile.ParsedMetadata, error) {
 		Description:   meta.Description,
 		Publisher:     meta.Publisher,
 		URL:           meta.URL,
-		ReleaseDate:   meta.ReleaseDate,
+		ReleaseDate:   meta.parsedReleaseDate(),
 		CoverMimeType: meta.CoverMimeType,
 		CoverData:     meta.CoverData,
 		DataSource:    models.DataSourceM4BMetadata,
 		Duration:      meta.Duration,
 		BitrateBps:    meta.Bitrate, // from esds, already in bps
 		Codec:         meta.Codec,   // from esds AudioSpecificConfig
+		SampleRateHz:  meta.SampleRate,
+		Channels:      meta.Channels,
 		Identifiers:   meta.Identifiers,
 		Chapters:      convertChaptersToParsed(meta.Chapters),
 		Language:      meta.Language,
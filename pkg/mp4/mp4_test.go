@@ -56,7 +56,12 @@ func TestParse_BasicMetadata(t *testing.T) {
 	assert.Equal(t, "My Test Book", metadata.Title)
 	require.Len(t, metadata.Authors, 1)
 	assert.Equal(t, "Author Name", metadata.Authors[0].Name)
-	assert.Contains(t, metadata.Narrators, "Narrator Name")
+	// The composer atom is only read as the narrator when asked for.
+	assert.Empty(t, metadata.Narrators)
+
+	metadata, err = mp4.ParseWithOptions(path, mp4.ParseOptions{NarratorFallbackAtoms: []string{mp4.NarratorAtomComposer}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Narrator Name"}, metadata.Narrators)
 }
 
 // TestParse_WithCover tests that cover images are extracted correctly.
@@ -254,6 +259,43 @@ func TestWrite_NarratorAtoms(t *testing.T) {
 	assert.Equal(t, "Jane Doe", modified.Narrators[1])
 }
 
+// TestWrite_NarratorRoles tests that narrator roles round-trip through the
+// com.shisho:narrator_roles freeform atom.
+func TestWrite_NarratorRoles(t *testing.T) {
+	t.Parallel()
+	testgen.SkipIfNoFFmpeg(t)
+	dir := testgen.TempDir(t, "mp4-narrator-roles-*")
+
+	path := testgen.GenerateM4B(t, dir, "test.m4b", testgen.M4BOptions{
+		Title:    "Full Cast Test",
+		Duration: 1.0,
+	})
+
+	meta, err := mp4.ParseFull(path)
+	require.NoError(t, err)
+	meta.Narrators = []string{"Michael Kramer", "Kate Reading"}
+	meta.NarratorRoles = []mediafile.ParsedNarrator{
+		{Name: "Michael Kramer", Role: "Kaladin"},
+		{Name: "Kate Reading", Role: "Shallan"},
+	}
+	require.NoError(t, mp4.Write(path, meta, mp4.WriteOptions{}))
+
+	parsed, err := mp4.Parse(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Michael Kramer", "Kate Reading"}, parsed.Narrators)
+	assert.Equal(t, meta.NarratorRoles, parsed.NarratorRoles)
+
+	// Clearing the roles removes the atom instead of carrying it over.
+	modified, err := mp4.ParseFull(path)
+	require.NoError(t, err)
+	modified.NarratorRoles = nil
+	require.NoError(t, mp4.Write(path, modified, mp4.WriteOptions{}))
+
+	parsed, err = mp4.Parse(path)
+	require.NoError(t, err)
+	assert.Nil(t, parsed.NarratorRoles)
+}
+
 // TestWrite_SeriesAtoms tests that series is written to ©grp and to the
 // Audible-style SERIES / SERIES-PART freeform atoms, and that ©alb is the
 // book title (not the series).
@@ -428,7 +470,6 @@ func TestWrite_PreservesMetadata(t *testing.T) {
 	path := testgen.GenerateM4B(t, dir, "test.m4b", testgen.M4BOptions{
 		Title:    "Original Title",
 		Artist:   "Original Author",
-		Composer: "Original Narrator",
 		Genre:    "Fantasy",
 		Duration: 1.0,
 	})
@@ -447,7 +488,6 @@ func TestWrite_PreservesMetadata(t *testing.T) {
 	assert.Equal(t, "Modified Title", modified.Title)
 	require.Len(t, modified.Authors, 1)
 	assert.Equal(t, "Original Author", modified.Authors[0].Name)
-	assert.Contains(t, modified.Narrators, "Original Narrator")
 	assert.Equal(t, "Fantasy", modified.Genre)
 }
 
@@ -2,6 +2,10 @@ package mp4
 
 import (
 	"bytes"
+	"encoding/binary"
+	"image"
+	_ "image/jpeg" // Register JPEG decoder for comparing cover sizes.
+	_ "image/png"  // Register PNG decoder for comparing cover sizes.
 	"io"
 	"os"
 
@@ -35,6 +39,8 @@ type rawMetadata struct {
 	duration     uint64            // from mvhd - in timescale units
 	avgBitrate   uint32            // from esds - average bitrate in bps
 	codec        string            // from esds - audio codec name with profile (e.g., "AAC-LC", "xHE-AAC")
+	sampleRate   uint32            // from the audio sample entry (mp4a, ec-3, etc.) - in Hz
+	channels     uint16            // from the audio sample entry (mp4a, ec-3, etc.)
 	freeform     map[string]string // freeform (----) atoms like com.apple.iTunes:ASIN
 	chapters     []Chapter         // chapter list
 	unknownAtoms []RawAtom         // unrecognized atoms to preserve
@@ -99,7 +105,10 @@ func readMetadataFromReader(r io.ReadSeeker) (*rawMetadata, error) {
 			return h.Expand()
 
 		case BoxTypeMp4a:
-			// Descend into mp4a (MPEG-4 audio)
+			// Read sample rate and channels, then descend into mp4a (MPEG-4 audio)
+			if err := processAudioFormat(h, meta); err != nil {
+				return nil, err
+			}
 			return h.Expand()
 
 		case BoxTypeEsds:
@@ -119,7 +128,7 @@ func readMetadataFromReader(r io.ReadSeeker) (*rawMetadata, error) {
 		case BoxTypeAlac:
 			// Apple Lossless audio
 			meta.codec = "ALAC"
-			return nil, nil
+			return nil, processAudioFormat(h, meta)
 
 		case BoxTypeUdta:
 			// Descend into udta
@@ -347,10 +356,35 @@ func processAudioSampleEntry(h *gomp4.ReadHandle, meta *rawMetadata) (interface{
 	data := buf.Bytes()
 
 	meta.avgBitrate = parseBitrateFromBtrt(data)
+	meta.sampleRate, meta.channels = parseAudioSampleEntry(data)
 
 	return nil, nil
 }
 
+// processAudioFormat reads the sample rate and channel count from an audio
+// sample entry (mp4a, alac) without consuming its children.
+func processAudioFormat(h *gomp4.ReadHandle, meta *rawMetadata) error {
+	var buf bytes.Buffer
+	if _, err := h.ReadData(&buf); err != nil {
+		return errors.WithStack(err)
+	}
+	meta.sampleRate, meta.channels = parseAudioSampleEntry(buf.Bytes())
+	return nil
+}
+
+// parseAudioSampleEntry extracts the sample rate and channel count from the
+// payload of an audio sample entry (ISO 14496-12 AudioSampleEntry):
+// [6 bytes reserved][2 bytes data_reference_index][8 bytes reserved]
+// [2 bytes channelcount][2 bytes samplesize][4 bytes reserved][4 bytes samplerate as 16.16].
+func parseAudioSampleEntry(data []byte) (uint32, uint16) {
+	if len(data) < 28 {
+		return 0, 0
+	}
+	channels := uint16(data[16])<<8 | uint16(data[17])
+	sampleRate := (uint32(data[24])<<24 | uint32(data[25])<<16 | uint32(data[26])<<8 | uint32(data[27])) >> 16
+	return sampleRate, channels
+}
+
 // parseBitrateFromBtrt extracts the average bitrate from raw audio sample entry data
 // by searching for the embedded btrt (bitrate) box.
 // btrt structure: [4 bytes size][4 bytes "btrt"][4 bytes bufferSizeDB][4 bytes maxBitrate][4 bytes avgBitrate].
@@ -466,6 +500,15 @@ func processMetadataBox(h *gomp4.ReadHandle, meta *rawMetadata) (interface{}, er
 		return nil, nil
 	}
 
+	// A file can carry several covers, either as repeated covr atoms or as
+	// several data boxes inside one. Offer each of them so the largest wins.
+	if atomTypeEquals(boxType, AtomCover) {
+		for _, dataContent := range extractDataBoxes(data) {
+			processMetadataAtom(ilstChild{atomType: boxType, data: dataContent}, meta)
+		}
+		return nil, nil
+	}
+
 	// Check if this is a known metadata atom
 	if isMetadataAtom(boxType) {
 		// The data should contain a "data" box
@@ -557,6 +600,40 @@ func extractDataBoxContent(content []byte) []byte {
 	return nil
 }
 
+// extractDataBoxes extracts the content of every "data" box in an atom's
+// content. A box whose size doesn't fit is taken to run to the end of the
+// content, matching extractDataBoxContent.
+func extractDataBoxes(content []byte) [][]byte {
+	var boxes [][]byte
+	for offset := 0; offset+16 <= len(content); {
+		size := int(binary.BigEndian.Uint32(content[offset:]))
+		end := offset + size
+		if size < 8 || end > len(content) {
+			end = len(content)
+		}
+		if string(content[offset+4:offset+8]) == "data" {
+			boxes = append(boxes, content[offset+8:end])
+		}
+		offset = end
+	}
+	return boxes
+}
+
+// isLargerCover reports whether candidate is a bigger image than current.
+// Images are compared by pixel area when both can be decoded, and by byte
+// size otherwise. Ties keep current, so the first cover wins.
+func isLargerCover(candidate, current []byte) bool {
+	if current == nil {
+		return true
+	}
+	candidateConfig, _, candidateErr := image.DecodeConfig(bytes.NewReader(candidate))
+	currentConfig, _, currentErr := image.DecodeConfig(bytes.NewReader(current))
+	if candidateErr == nil && currentErr == nil {
+		return candidateConfig.Width*candidateConfig.Height > currentConfig.Width*currentConfig.Height
+	}
+	return len(candidate) > len(current)
+}
+
 // processMetadataAtom processes a single metadata atom and updates rawMetadata.
 func processMetadataAtom(child ilstChild, meta *rawMetadata) {
 	if len(child.data) == 0 {
@@ -594,7 +671,7 @@ func processMetadataAtom(child ilstChild, meta *rawMetadata) {
 		}
 
 	case atomTypeEquals(boxType, AtomCover):
-		if data, mime, ok := parseImageData(child.data); ok {
+		if data, mime, ok := parseImageData(child.data); ok && isLargerCover(data, meta.coverData) {
 			meta.coverData = data
 			meta.coverMime = mime
 		}
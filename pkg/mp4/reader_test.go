@@ -1,9 +1,14 @@
 package mp4
 
 import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/png"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestAudioObjectTypeToCodec tests the mapping from ISO 14496-3 audioObjectType to codec string.
@@ -432,3 +437,91 @@ func TestParseAACProfile(t *testing.T) {
 		})
 	}
 }
+
+// TestParseAudioSampleEntry tests sample rate and channel extraction from an
+// AudioSampleEntry payload.
+func TestParseAudioSampleEntry(t *testing.T) {
+	t.Parallel()
+
+	entry := func(channels uint16, sampleRate uint32) []byte {
+		data := make([]byte, 28)
+		data[7] = 1 // data_reference_index
+		data[16] = byte(channels >> 8)
+		data[17] = byte(channels)
+		data[19] = 16 // samplesize
+		fixed := sampleRate << 16
+		data[24] = byte(fixed >> 24)
+		data[25] = byte(fixed >> 16)
+		return data
+	}
+
+	testCases := []struct {
+		name           string
+		data           []byte
+		wantSampleRate uint32
+		wantChannels   uint16
+	}{
+		{"stereo 44.1kHz", entry(2, 44100), 44100, 2},
+		{"mono 22.05kHz", entry(1, 22050), 22050, 1},
+		{"with trailing child boxes", append(entry(2, 48000), 0, 0, 0, 8, 'e', 's', 'd', 's'), 48000, 2},
+		{"too short", make([]byte, 20), 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sampleRate, channels := parseAudioSampleEntry(tc.data)
+			assert.Equal(t, tc.wantSampleRate, sampleRate)
+			assert.Equal(t, tc.wantChannels, channels)
+		})
+	}
+}
+
+func TestProcessMetadataBox_CoverPrefersLargest(t *testing.T) {
+	t.Parallel()
+
+	encodePNG := func(w, h int) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))))
+		return buf.Bytes()
+	}
+	dataBox := func(img []byte) []byte {
+		// [size][data][version+type (PNG = 14)][locale][image]
+		box := make([]byte, 16, 16+len(img))
+		binary.BigEndian.PutUint32(box, uint32(16+len(img)))
+		copy(box[4:8], "data")
+		box[11] = DataTypePNG
+		return append(box, img...)
+	}
+
+	small := encodePNG(2, 2)
+	large := encodePNG(20, 20)
+
+	t.Run("multiple data boxes in one atom", func(t *testing.T) {
+		t.Parallel()
+		meta := &rawMetadata{}
+		content := append(dataBox(small), dataBox(large)...)
+		for _, data := range extractDataBoxes(content) {
+			processMetadataAtom(ilstChild{atomType: AtomCover, data: data}, meta)
+		}
+		assert.Equal(t, large, meta.coverData)
+		assert.Equal(t, "image/png", meta.coverMime)
+	})
+
+	t.Run("repeated atoms", func(t *testing.T) {
+		t.Parallel()
+		meta := &rawMetadata{}
+		for _, img := range [][]byte{large, small} {
+			for _, data := range extractDataBoxes(dataBox(img)) {
+				processMetadataAtom(ilstChild{atomType: AtomCover, data: data}, meta)
+			}
+		}
+		assert.Equal(t, large, meta.coverData)
+	})
+
+	t.Run("undecodable images fall back to byte size", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, isLargerCover([]byte("BM-longer"), []byte("BM-short")))
+		assert.False(t, isLargerCover([]byte("BM-short"), []byte("BM-longer")))
+	})
+}
@@ -552,6 +552,11 @@ func buildIlst(metadata *Metadata) []byte {
 		content.Write(buildFreeformAtom("com.apple.iTunes", "SUBTITLE", metadata.Subtitle))
 	}
 
+	// Narrator roles as freeform atom, for full-cast audiobooks
+	if roles := formatNarratorRoles(metadata.NarratorRoles); roles != "" {
+		content.Write(buildFreeformAtom("com.shisho", "narrator_roles", roles))
+	}
+
 	// Tags as freeform atom (comma-separated)
 	if len(metadata.Tags) > 0 {
 		content.Write(buildFreeformAtom("com.shisho", "tags", joinStrings(metadata.Tags)))
@@ -580,6 +585,7 @@ func buildIlst(metadata *Metadata) []byte {
 		"com.apple.iTunes:SUBTITLE":     true,
 		"com.pilabor.tone:SUBTITLE":     true,
 		"com.shisho:tags":               true,
+		NarratorRolesKey:                true,
 		"com.apple.iTunes:ASIN":         true,
 		"com.pilabor.tone:AUDIBLE_ASIN": true,
 	}
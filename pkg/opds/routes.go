@@ -15,7 +15,7 @@ import (
 
 // RegisterRoutes registers all OPDS routes.
 func RegisterRoutes(e *echo.Echo, db *bun.DB, cfg *config.Config, authMiddleware *auth.Middleware) {
-	opdsService := NewService(db)
+	opdsService := NewService(db).WithPersonNameLocale(cfg.PersonNameLocale)
 	bookService := books.NewService(db)
 	cache := downloadcache.NewCache(filepath.Join(cfg.CacheDir, "downloads"), cfg.DownloadCacheMaxSizeBytes())
 
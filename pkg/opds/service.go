@@ -40,6 +40,13 @@ func NewService(db *bun.DB) *Service {
 	}
 }
 
+// WithPersonNameLocale sets the locale used to derive sort names for people
+// looked up or created through this service.
+func (svc *Service) WithPersonNameLocale(locale string) *Service {
+	svc.peopleService.WithNameLocale(locale)
+	return svc
+}
+
 // parseFileTypes converts "epub+cbz" to []string{"epub", "cbz"}.
 func parseFileTypes(types string) []string {
 	if types == "" {
@@ -0,0 +1,340 @@
+package openlibrary
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/identifiers"
+)
+
+var (
+	// ErrInvalidISBN is returned when the value isn't a valid ISBN-10 or ISBN-13.
+	ErrInvalidISBN = errors.New("invalid ISBN")
+	// ErrNotFound is returned when Open Library has no record for the ISBN or
+	// cover.
+	ErrNotFound = errors.New("not found on Open Library")
+	// ErrUnavailable is returned when Open Library can't be reached or is
+	// rate limiting us. Callers should treat it as "no data" rather than a
+	// failure.
+	ErrUnavailable = errors.New("Open Library is unavailable")
+)
+
+const (
+	defaultBaseURL       = "https://openlibrary.org"
+	defaultCoversBaseURL = "https://covers.openlibrary.org"
+
+	// offlineBackoff is how long the service stops making requests after a
+	// network failure, so a large scan without network access doesn't wait
+	// on a timeout for every file.
+	offlineBackoff = 5 * time.Minute
+
+	maxResponseBytes = 1 << 20
+	maxCoverBytes    = 10 << 20
+)
+
+// ServiceConfig holds options for constructing a Service.
+type ServiceConfig struct {
+	// HTTPClient is optional; if nil, a client with a 10-second timeout is used.
+	HTTPClient *http.Client
+	// BaseURL and CoversBaseURL override the Open Library endpoints for tests.
+	BaseURL       string
+	CoversBaseURL string
+	// UserAgent sent on every upstream request. Defaults to "Shisho/unknown".
+	UserAgent string
+	// CacheDir is the root cache directory. Responses and covers are stored
+	// under CacheDir/openlibrary. Caching is disabled when empty.
+	CacheDir string
+	// CacheTTL for cached ISBN lookups, including misses. Defaults to 30 days
+	// if zero. Covers never expire.
+	CacheTTL time.Duration
+	// MinInterval is the minimum time between upstream requests. Defaults to
+	// one second if zero, which keeps us within Open Library's rate limits.
+	MinInterval time.Duration
+}
+
+// Service looks up editions and covers by ISBN on Open Library, caching
+// responses on disk.
+type Service struct {
+	http          *http.Client
+	baseURL       string
+	coversBaseURL string
+	userAgent     string
+	cacheDir      string
+	cacheTTL      time.Duration
+	minInterval   time.Duration
+
+	// rateMu serializes upstream requests so they're spaced by minInterval.
+	rateMu       sync.Mutex
+	lastRequest  time.Time
+	offlineUntil time.Time
+}
+
+// NewService constructs a Service with the given config.
+func NewService(cfg ServiceConfig) *Service {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	base := cfg.BaseURL
+	if base == "" {
+		base = defaultBaseURL
+	}
+	coversBase := cfg.CoversBaseURL
+	if coversBase == "" {
+		coversBase = defaultCoversBaseURL
+	}
+	ua := cfg.UserAgent
+	if ua == "" {
+		ua = "Shisho/unknown"
+	}
+	ttl := cfg.CacheTTL
+	if ttl == 0 {
+		ttl = 30 * 24 * time.Hour
+	}
+	interval := cfg.MinInterval
+	if interval == 0 {
+		interval = time.Second
+	}
+	var cacheDir string
+	if cfg.CacheDir != "" {
+		cacheDir = filepath.Join(cfg.CacheDir, "openlibrary")
+	}
+	return &Service{
+		http:          client,
+		baseURL:       strings.TrimRight(base, "/"),
+		coversBaseURL: strings.TrimRight(coversBase, "/"),
+		userAgent:     ua,
+		cacheDir:      cacheDir,
+		cacheTTL:      ttl,
+		minInterval:   interval,
+	}
+}
+
+// LookupISBN returns the Open Library edition for an ISBN-10 or ISBN-13.
+// Misses are cached like hits so a rescan doesn't query the same unknown ISBN
+// again until the cache entry expires.
+func (s *Service) LookupISBN(ctx context.Context, isbn string) (*Edition, error) {
+	normalized := identifiers.NormalizeISBN(isbn)
+	if !identifiers.ValidateISBN10(normalized) && !identifiers.ValidateISBN13(normalized) {
+		return nil, ErrInvalidISBN
+	}
+
+	cachePath := s.cachePath("isbn", normalized+".json")
+	body, ok := s.readCache(cachePath, s.cacheTTL)
+	if !ok {
+		params := url.Values{}
+		params.Set("bibkeys", "ISBN:"+normalized)
+		params.Set("format", "json")
+		params.Set("jscmd", "details")
+		resp, err := s.get(ctx, s.baseURL+"/api/books?"+params.Encode(), maxResponseBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		var upstream map[string]booksUpstream
+		if err := json.Unmarshal(resp, &upstream); err != nil {
+			return nil, errors.Wrap(ErrUnavailable, "invalid JSON from Open Library")
+		}
+		// Store only the edition details, or "null" for a miss.
+		body, err = json.Marshal(upstream["ISBN:"+normalized].Details)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		s.writeCache(cachePath, body)
+	}
+
+	var details *editionUpstream
+	if err := json.Unmarshal(body, &details); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if details == nil {
+		return nil, ErrNotFound
+	}
+	return details.toEdition(), nil
+}
+
+// Cover returns the large cover image for an Open Library cover ID along with
+// its MIME type.
+func (s *Service) Cover(ctx context.Context, coverID int) ([]byte, string, error) {
+	if coverID <= 0 {
+		return nil, "", ErrNotFound
+	}
+
+	cachePath := s.cachePath("covers", strconv.Itoa(coverID)+"-L")
+	data, ok := s.readCache(cachePath, 0)
+	if !ok {
+		// default=false makes the covers API return 404 instead of a blank
+		// placeholder image.
+		var err error
+		data, err = s.get(ctx, s.coversBaseURL+"/b/id/"+strconv.Itoa(coverID)+"-L.jpg?default=false", maxCoverBytes)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	mimeType := http.DetectContentType(data)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return nil, "", ErrNotFound
+	}
+	if !ok {
+		s.writeCache(cachePath, data)
+	}
+	return data, mimeType, nil
+}
+
+// get performs a rate-limited GET and returns the response body.
+func (s *Service) get(ctx context.Context, rawURL string, maxBytes int64) ([]byte, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		s.goOffline()
+		return nil, errors.Wrap(ErrUnavailable, err.Error())
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+		s.goOffline()
+		return nil, errors.Wrap(ErrUnavailable, "upstream returned "+resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return nil, errors.Wrap(ErrUnavailable, "upstream returned "+resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes))
+	if err != nil {
+		return nil, errors.Wrap(ErrUnavailable, err.Error())
+	}
+	return body, nil
+}
+
+// wait blocks until the next upstream request is allowed, or returns
+// ErrUnavailable straight away while backing off after a failure.
+func (s *Service) wait(ctx context.Context) error {
+	s.rateMu.Lock()
+	defer s.rateMu.Unlock()
+
+	if time.Now().Before(s.offlineUntil) {
+		return ErrUnavailable
+	}
+	if d := time.Until(s.lastRequest.Add(s.minInterval)); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		case <-timer.C:
+		}
+	}
+	s.lastRequest = time.Now()
+	return nil
+}
+
+func (s *Service) goOffline() {
+	s.rateMu.Lock()
+	defer s.rateMu.Unlock()
+	s.offlineUntil = time.Now().Add(offlineBackoff)
+}
+
+func (s *Service) cachePath(kind, name string) string {
+	if s.cacheDir == "" {
+		return ""
+	}
+	return filepath.Join(s.cacheDir, kind, name)
+}
+
+// readCache returns the cached file at path if it exists and is younger than
+// ttl (a zero ttl never expires).
+func (s *Service) readCache(path string, ttl time.Duration) ([]byte, bool) {
+	if path == "" {
+		return nil, false
+	}
+	info, err := os.Stat(path)
+	if err != nil || (ttl > 0 && time.Since(info.ModTime()) > ttl) {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// writeCache stores data at path. Failures only cost a repeat request later,
+// so they're ignored.
+func (s *Service) writeCache(path string, data []byte) {
+	if path == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+	}
+}
+
+// SizeBytes returns the total size and number of files in the cache.
+func (s *Service) SizeBytes() (int64, int, error) {
+	if s.cacheDir == "" {
+		return 0, 0, nil
+	}
+	var totalBytes int64
+	var totalCount int
+	err := filepath.Walk(s.cacheDir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		totalBytes += info.Size()
+		totalCount++
+		return nil
+	})
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to walk cache")
+	}
+	return totalBytes, totalCount, nil
+}
+
+// Clear removes every cached response and cover.
+func (s *Service) Clear() error {
+	if s.cacheDir == "" {
+		return nil
+	}
+	if err := os.RemoveAll(s.cacheDir); err != nil {
+		return errors.Wrap(err, "failed to clear cache")
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package openlibrary
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// stubService builds a Service whose HTTP client routes every request through
+// rt instead of the network.
+func stubService(t *testing.T, rt roundTripFunc) *Service {
+	t.Helper()
+	return NewService(ServiceConfig{
+		HTTPClient:  &http.Client{Transport: rt},
+		CacheDir:    t.TempDir(),
+		MinInterval: time.Nanosecond,
+	})
+}
+
+func respond(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+	}
+}
+
+const editionJSON = `{"ISBN:9780316769488": {"bib_key": "ISBN:9780316769488", "details": {
+	"title": "The Catcher in the Rye",
+	"authors": [{"key": "/authors/OL1A", "name": "J. D. Salinger"}],
+	"publishers": ["Little, Brown"],
+	"publish_date": "May 1991",
+	"description": {"type": "/type/text", "value": "A classic."},
+	"covers": [-1, 12345]
+}}}`
+
+func TestLookupISBN(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	svc := stubService(t, func(r *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		assert.Equal(t, "ISBN:9780316769488", r.URL.Query().Get("bibkeys"))
+		assert.Equal(t, "details", r.URL.Query().Get("jscmd"))
+		return respond(http.StatusOK, editionJSON), nil
+	})
+
+	edition, err := svc.LookupISBN(context.Background(), "978-0-316-76948-8")
+	require.NoError(t, err)
+	assert.Equal(t, "The Catcher in the Rye", edition.Title)
+	assert.Equal(t, []string{"J. D. Salinger"}, edition.Authors)
+	assert.Equal(t, []string{"Little, Brown"}, edition.Publishers)
+	assert.Equal(t, "May 1991", edition.PublishDate)
+	assert.Equal(t, "A classic.", edition.Description)
+	assert.Equal(t, 12345, edition.CoverID)
+
+	// The second lookup is served from the disk cache.
+	_, err = svc.LookupISBN(context.Background(), "9780316769488")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestLookupISBN_NotFoundIsCached(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	svc := stubService(t, func(_ *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return respond(http.StatusOK, `{}`), nil
+	})
+
+	for range 2 {
+		_, err := svc.LookupISBN(context.Background(), "9780316769488")
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestLookupISBN_InvalidISBN(t *testing.T) {
+	t.Parallel()
+	svc := stubService(t, func(_ *http.Request) (*http.Response, error) {
+		t.Fatal("unexpected request")
+		return nil, nil
+	})
+
+	_, err := svc.LookupISBN(context.Background(), "9780316769480")
+	require.ErrorIs(t, err, ErrInvalidISBN)
+}
+
+func TestLookupISBN_OfflineBacksOff(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	svc := stubService(t, func(_ *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return nil, errors.New("dial tcp: no route to host")
+	})
+
+	_, err := svc.LookupISBN(context.Background(), "9780316769488")
+	require.ErrorIs(t, err, ErrUnavailable)
+
+	// Later lookups fail fast without touching the network.
+	_, err = svc.LookupISBN(context.Background(), "0316769487")
+	require.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCover(t *testing.T) {
+	t.Parallel()
+	png := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+	var calls atomic.Int32
+	svc := stubService(t, func(r *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		assert.Equal(t, "/b/id/12345-L.jpg", r.URL.Path)
+		assert.Equal(t, "false", r.URL.Query().Get("default"))
+		return respond(http.StatusOK, png), nil
+	})
+
+	data, mimeType, err := svc.Cover(context.Background(), 12345)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(png), data)
+	assert.Equal(t, "image/png", mimeType)
+
+	_, _, err = svc.Cover(context.Background(), 12345)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+
+	size, count, err := svc.SizeBytes()
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(png)), size)
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, svc.Clear())
+	_, count, err = svc.SizeBytes()
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,81 @@
+package openlibrary
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Edition is the subset of an Open Library edition record used for metadata
+// enrichment.
+type Edition struct {
+	Title       string
+	Subtitle    string
+	Authors     []string
+	Publishers  []string
+	PublishDate string // Free-form, e.g. "March 5, 2019" or "2019"
+	Description string
+	CoverID     int // 0 when the edition has no cover
+}
+
+// booksUpstream is one entry of the /api/books response with jscmd=details.
+type booksUpstream struct {
+	Details *editionUpstream `json:"details"`
+}
+
+type editionUpstream struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle"`
+	Authors  []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	Publishers  []string        `json:"publishers"`
+	PublishDate string          `json:"publish_date"`
+	Description json.RawMessage `json:"description"`
+	Covers      []int           `json:"covers"`
+}
+
+func (u *editionUpstream) toEdition() *Edition {
+	e := &Edition{
+		Title:       strings.TrimSpace(u.Title),
+		Subtitle:    strings.TrimSpace(u.Subtitle),
+		PublishDate: strings.TrimSpace(u.PublishDate),
+		Description: strings.TrimSpace(parseDescription(u.Description)),
+	}
+	for _, a := range u.Authors {
+		if name := strings.TrimSpace(a.Name); name != "" {
+			e.Authors = append(e.Authors, name)
+		}
+	}
+	for _, p := range u.Publishers {
+		if p = strings.TrimSpace(p); p != "" {
+			e.Publishers = append(e.Publishers, p)
+		}
+	}
+	// Open Library uses -1 as a placeholder for a removed cover.
+	for _, id := range u.Covers {
+		if id > 0 {
+			e.CoverID = id
+			break
+		}
+	}
+	return e
+}
+
+// parseDescription handles both shapes Open Library uses for descriptions:
+// a plain string or a {"type": "/type/text", "value": "..."} object.
+func parseDescription(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var text struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text.Value
+	}
+	return ""
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/shishobooks/shisho/pkg/errcodes"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/shishobooks/shisho/pkg/search"
-	"github.com/shishobooks/shisho/pkg/sortname"
 )
 
 // FileOrganizer defines the interface for organizing files when person metadata changes.
@@ -27,15 +26,23 @@ type FileOrganizer interface {
 	// Returns the new path, or the original path if no rename was needed.
 	RenameNarratedFile(ctx context.Context, fileID int) (string, error)
 
-	// GetLibraryOrganizeSetting checks if a library has OrganizeFileStructure enabled.
+	// GetLibraryOrganizeSetting checks if a library organizes files or folders.
 	GetLibraryOrganizeSetting(ctx context.Context, libraryID int) (bool, error)
 }
 
+// MetadataHasher refreshes the metadata hashes of books after a change to
+// the people they reference. This is used to break the import cycle between
+// people and books packages.
+type MetadataHasher interface {
+	RefreshMetadataHashes(ctx context.Context, bookIDs []int) error
+}
+
 type handler struct {
-	personService *Service
-	aliasService  *aliases.Service
-	searchService *search.Service
-	fileOrganizer FileOrganizer // optional, can be nil if not configured
+	personService  *Service
+	aliasService   *aliases.Service
+	searchService  *search.Service
+	fileOrganizer  FileOrganizer  // optional, can be nil if not configured
+	metadataHasher MetadataHasher // optional, can be nil if not configured
 }
 
 func (h *handler) retrieve(c echo.Context) error {
@@ -95,6 +102,7 @@ func (h *handler) list(c echo.Context) error {
 		Offset:    &params.Offset,
 		LibraryID: params.LibraryID,
 		Search:    params.Search,
+		Role:      params.Role,
 	}
 
 	// Filter by user's library access if user is in context
@@ -165,7 +173,7 @@ func (h *handler) update(c echo.Context) error {
 		person.Name = *params.Name
 		// Regenerate sort name when name changes (unless sort_name_source is manual)
 		if person.SortNameSource != models.DataSourceManual {
-			person.SortName = sortname.ForPerson(*params.Name)
+			person.SortName = h.personService.SortNameFor(*params.Name)
 			person.SortNameSource = models.DataSourceFilepath
 			opts.Columns = append(opts.Columns, "name", "sort_name", "sort_name_source")
 		} else {
@@ -176,7 +184,7 @@ func (h *handler) update(c echo.Context) error {
 	if params.SortName != nil && *params.SortName != person.SortName {
 		if *params.SortName == "" {
 			// Empty string means regenerate from name
-			person.SortName = sortname.ForPerson(person.Name)
+			person.SortName = h.personService.SortNameFor(person.Name)
 			person.SortNameSource = models.DataSourceFilepath
 		} else {
 			person.SortName = *params.SortName
@@ -242,9 +250,25 @@ func (h *handler) update(c echo.Context) error {
 		}
 	}
 
+	// Author names are part of each authored book's metadata hash
+	if nameChanged && h.metadataHasher != nil {
+		authoredBooks, err := h.personService.GetAuthoredBooks(ctx, id)
+		if err != nil {
+			log.Warn("failed to get authored books for metadata hash refresh", logger.Data{"person_id": id, "error": err.Error()})
+		} else {
+			bookIDs := make([]int, 0, len(authoredBooks))
+			for _, book := range authoredBooks {
+				bookIDs = append(bookIDs, book.ID)
+			}
+			if err := h.metadataHasher.RefreshMetadataHashes(ctx, bookIDs); err != nil {
+				log.Warn("failed to update book metadata hashes after person rename", logger.Data{"person_id": id, "error": err.Error()})
+			}
+		}
+	}
+
 	// If name changed and file organizer is configured, reorganize associated files
 	if nameChanged && h.fileOrganizer != nil {
-		// Check if library has OrganizeFileStructure enabled
+		// Check if library organizes files or folders
 		organizeEnabled, err := h.fileOrganizer.GetLibraryOrganizeSetting(ctx, person.LibraryID)
 		if err != nil {
 			log.Warn("failed to check library organize setting", logger.Data{
@@ -409,16 +433,45 @@ func (h *handler) merge(c echo.Context) error {
 		}
 	}
 
-	// Merge source person into target (this) person
-	err = h.personService.MergePeople(ctx, id, params.SourceID)
+	sourceIDs := params.SourceIDs
+	if params.SourceID != 0 {
+		sourceIDs = append(sourceIDs, params.SourceID)
+	}
+	if len(sourceIDs) == 0 {
+		return errcodes.ValidationError("source_id or source_ids is required.")
+	}
+
+	// Merge source people into target (this) person
+	affectedBookIDs, err := h.personService.MergePersons(ctx, id, sourceIDs)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	// Remove the merged (source) person from FTS index
 	log := logger.FromContext(ctx)
-	if err := h.searchService.DeleteFromPersonIndex(ctx, params.SourceID); err != nil {
-		log.Warn("failed to remove merged person from search index", logger.Data{"person_id": params.SourceID, "error": err.Error()})
+
+	// Remove the merged (source) people from FTS index
+	for _, sourceID := range sourceIDs {
+		if err := h.searchService.DeleteFromPersonIndex(ctx, sourceID); err != nil {
+			log.Warn("failed to remove merged person from search index", logger.Data{"person_id": sourceID, "error": err.Error()})
+		}
+	}
+
+	// Books whose credits moved need their books_fts row refreshed to
+	// reflect the target person's name.
+	for _, bookID := range affectedBookIDs {
+		if err := h.searchService.ReindexBookByID(ctx, bookID); err != nil {
+			log.Warn("failed to update book search index after person merge", logger.Data{"book_id": bookID, "error": err.Error()})
+		}
+	}
+	if h.metadataHasher != nil {
+		if err := h.metadataHasher.RefreshMetadataHashes(ctx, affectedBookIDs); err != nil {
+			log.Warn("failed to update book metadata hashes after person merge", logger.Data{"person_id": id, "error": err.Error()})
+		}
+	}
+
+	// Re-index the target person so the merged names match as aliases
+	if err := h.searchService.IndexPerson(ctx, person); err != nil {
+		log.Warn("failed to update search index for target person", logger.Data{"person_id": id, "error": err.Error()})
 	}
 
 	return c.NoContent(http.StatusNoContent)
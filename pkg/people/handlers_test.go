@@ -467,3 +467,73 @@ func TestList_ResponseAliasesSerializeAsStringArray(t *testing.T) {
 		"aliases must unmarshal into []string, proving it is a JSON array of strings")
 	assert.ElementsMatch(t, []string{"B. Sanderson", "Brandon S."}, aliasStrings)
 }
+
+func TestList_FiltersByRole(t *testing.T) {
+	t.Parallel()
+	db := setupHandlerTestDB(t)
+	lib := createTestLibrary(t, db)
+	h := newTestHandler(db)
+
+	author := seedPersonWithAuthoredBooks(t, db, lib, "Brandon Sanderson", []string{"Book1", "Book2"})
+	narrator := seedPersonWithNarratedFiles(t, db, lib, "Michael Kramer", []string{"File1"})
+	_, err := db.NewInsert().Model(&models.Person{
+		LibraryID:      lib.ID,
+		Name:           "Uncredited",
+		SortName:       "Uncredited",
+		SortNameSource: models.DataSourceFilepath,
+	}).Exec(context.Background())
+	require.NoError(t, err)
+
+	tests := []struct {
+		role      string
+		wantIDs   []int
+		wantTotal int
+	}{
+		{role: models.PersonRoleAuthor, wantIDs: []int{author.ID}, wantTotal: 1},
+		{role: models.PersonRoleNarrator, wantIDs: []int{narrator.ID}, wantTotal: 1},
+		{role: "", wantTotal: 3},
+	}
+	for _, tt := range tests {
+		e := newTestEcho(t)
+		req := httptest.NewRequest(http.MethodGet, "/?role="+tt.role, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, h.list(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp struct {
+			Items []struct {
+				ID                int `json:"id"`
+				AuthoredBookCount int `json:"authored_book_count"`
+				NarratedFileCount int `json:"narrated_file_count"`
+			} `json:"items"`
+			Total int `json:"total"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, tt.wantTotal, resp.Total, "role=%q", tt.role)
+		if tt.wantIDs == nil {
+			continue
+		}
+		require.Len(t, resp.Items, len(tt.wantIDs))
+		assert.Equal(t, tt.wantIDs[0], resp.Items[0].ID)
+		if tt.role == models.PersonRoleAuthor {
+			assert.Equal(t, 2, resp.Items[0].AuthoredBookCount)
+		} else {
+			assert.Equal(t, 1, resp.Items[0].NarratedFileCount)
+		}
+	}
+}
+
+func TestList_RejectsUnknownRole(t *testing.T) {
+	t.Parallel()
+	db := setupHandlerTestDB(t)
+	h := newTestHandler(db)
+
+	e := newTestEcho(t)
+	req := httptest.NewRequest(http.MethodGet, "/?role=illustrator", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	assert.Error(t, h.list(c))
+}
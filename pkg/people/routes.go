@@ -4,6 +4,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/shishobooks/shisho/pkg/aliases"
 	"github.com/shishobooks/shisho/pkg/auth"
+	"github.com/shishobooks/shisho/pkg/config"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/shishobooks/shisho/pkg/search"
 	"github.com/uptrace/bun"
@@ -11,16 +12,18 @@ import (
 
 // RegisterRoutesWithGroup registers people routes on a pre-configured group.
 // fileOrganizer is optional and can be nil if file organization on person name change is not needed.
-func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, authMiddleware *auth.Middleware, fileOrganizer FileOrganizer) {
-	personService := NewService(db)
+// metadataHasher is optional and can be nil if book metadata hashes don't need refreshing.
+func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, cfg *config.Config, authMiddleware *auth.Middleware, fileOrganizer FileOrganizer, metadataHasher MetadataHasher) {
+	personService := NewService(db).WithNameLocale(cfg.PersonNameLocale)
 	aliasService := aliases.NewService(db)
 	searchService := search.NewService(db)
 
 	h := &handler{
-		personService: personService,
-		aliasService:  aliasService,
-		searchService: searchService,
-		fileOrganizer: fileOrganizer,
+		personService:  personService,
+		aliasService:   aliasService,
+		searchService:  searchService,
+		fileOrganizer:  fileOrganizer,
+		metadataHasher: metadataHasher,
 	}
 
 	g.GET("", h.list)
@@ -26,6 +26,7 @@ type ListPeopleOptions struct {
 	LibraryID  *int
 	LibraryIDs []int // Filter by multiple library IDs (for access control)
 	Search     *string
+	Role       string // models.PersonRoleAuthor or models.PersonRoleNarrator; "" = everyone
 
 	includeTotal bool
 }
@@ -35,11 +36,25 @@ type UpdatePersonOptions struct {
 }
 
 type Service struct {
-	db *bun.DB
+	db         *bun.DB
+	nameLocale string
 }
 
 func NewService(db *bun.DB) *Service {
-	return &Service{db}
+	return &Service{db: db}
+}
+
+// WithNameLocale sets the locale names are assumed to be written in when
+// generating sort names (see sortname.ForPerson). Without it, names are
+// treated as given name first.
+func (svc *Service) WithNameLocale(locale string) *Service {
+	svc.nameLocale = locale
+	return svc
+}
+
+// SortNameFor generates the sort name for a person named name.
+func (svc *Service) SortNameFor(name string) string {
+	return sortname.ForPerson(name, svc.nameLocale)
 }
 
 func (svc *Service) CreatePerson(ctx context.Context, person *models.Person) error {
@@ -51,7 +66,7 @@ func (svc *Service) CreatePerson(ctx context.Context, person *models.Person) err
 
 	// Generate sort name if not provided
 	if person.SortName == "" {
-		person.SortName = sortname.ForPerson(person.Name)
+		person.SortName = svc.SortNameFor(person.Name)
 		person.SortNameSource = models.DataSourceFilepath // Auto-generated
 	}
 	// Ensure source is set if not already
@@ -98,8 +113,7 @@ func (svc *Service) RetrievePerson(ctx context.Context, opts RetrievePersonOptio
 
 // FindOrCreatePerson finds an existing person or creates a new one (case-insensitive match).
 func (svc *Service) FindOrCreatePerson(ctx context.Context, name string, libraryID int) (*models.Person, error) {
-	// Normalize the name by trimming whitespace
-	name = strings.TrimSpace(name)
+	name = aliases.NormalizeName(name)
 	if name == "" {
 		return nil, errors.New("person name cannot be empty")
 	}
@@ -120,24 +134,36 @@ func (svc *Service) FindOrCreatePerson(ctx context.Context, name string, library
 		return svc.RetrievePerson(ctx, RetrievePersonOptions{ID: &resourceID})
 	}
 
-	// Create new person
+	// Create new person. Concurrent scans can race to create the same person,
+	// so the insert is a no-op when the name is already taken and the row that
+	// won the race is returned instead.
+	now := time.Now()
 	person = &models.Person{
+		CreatedAt:      now,
+		UpdatedAt:      now,
 		LibraryID:      libraryID,
 		Name:           name,
-		SortName:       sortname.ForPerson(name),
+		SortName:       svc.SortNameFor(name),
 		SortNameSource: models.DataSourceFilepath,
 	}
-	err = svc.CreatePerson(ctx, person)
+	res, err := svc.db.
+		NewInsert().
+		Model(person).
+		On("CONFLICT DO NOTHING").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	n, err := res.RowsAffected()
 	if err != nil {
-		// Handle race condition: if another goroutine created the same person
-		// between our retrieve and create, retry the retrieve
-		if strings.Contains(err.Error(), "UNIQUE constraint") {
-			return svc.RetrievePerson(ctx, RetrievePersonOptions{
-				Name:      &name,
-				LibraryID: &libraryID,
-			})
-		}
-		return nil, err
+		return nil, errors.WithStack(err)
+	}
+	if n == 0 {
+		return svc.RetrievePerson(ctx, RetrievePersonOptions{
+			Name:      &name,
+			LibraryID: &libraryID,
+		})
 	}
 	return person, nil
 }
@@ -168,6 +194,12 @@ func (svc *Service) listPeopleWithTotal(ctx context.Context, opts ListPeopleOpti
 	if len(opts.LibraryIDs) > 0 {
 		q = q.Where("p.library_id IN (?)", bun.List(opts.LibraryIDs))
 	}
+	switch opts.Role {
+	case models.PersonRoleAuthor:
+		q = q.Where("EXISTS (SELECT 1 FROM authors a WHERE a.person_id = p.id)")
+	case models.PersonRoleNarrator:
+		q = q.Where("EXISTS (SELECT 1 FROM narrators n WHERE n.person_id = p.id)")
+	}
 	// Search using FTS5
 	if opts.Search != nil && *opts.Search != "" {
 		ftsQuery := buildFTSPrefixQuery(*opts.Search)
@@ -346,40 +378,128 @@ func (svc *Service) GetNarratedFileCount(ctx context.Context, personID int) (int
 	return count, errors.WithStack(err)
 }
 
-// MergePeople merges sourcePerson into targetPerson (moves all associations, deletes source).
-func (svc *Service) MergePeople(ctx context.Context, targetID, sourceID int) error {
-	return svc.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
-		// Update all authors from source to target
-		_, err := tx.NewUpdate().
-			Model((*models.Author)(nil)).
-			Set("person_id = ?", targetID).
-			Where("person_id = ?", sourceID).
-			Exec(ctx)
+// MergePersons merges every person in mergeIDs into keepID, such as a pen name
+// into the author's real name. Their author and narrator credits move to
+// keepID, their names and aliases become aliases of keepID (so later scans
+// resolve them to keepID), and the merged people are deleted. All people must
+// be in the same library. Returns the IDs of books whose credits moved so the
+// caller can recompute search indexes that bake in author and narrator names.
+func (svc *Service) MergePersons(ctx context.Context, keepID int, mergeIDs []int) ([]int, error) {
+	if len(mergeIDs) == 0 {
+		return nil, errcodes.ValidationError("At least one person to merge is required.")
+	}
+
+	var affectedBookIDs []int
+	err := svc.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		keep := new(models.Person)
+		err := tx.NewSelect().Model(keep).Where("p.id = ?", keepID).Scan(ctx)
 		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errcodes.NotFound("Person")
+			}
 			return errors.WithStack(err)
 		}
 
-		// Update all narrators from source to target
-		_, err = tx.NewUpdate().
-			Model((*models.Narrator)(nil)).
-			Set("person_id = ?", targetID).
-			Where("person_id = ?", sourceID).
-			Exec(ctx)
+		var merged []*models.Person
+		err = tx.NewSelect().Model(&merged).Where("p.id IN (?)", bun.In(mergeIDs)).Scan(ctx)
 		if err != nil {
 			return errors.WithStack(err)
 		}
+		if len(merged) != len(uniqueInts(mergeIDs)) {
+			return errcodes.NotFound("Person")
+		}
+		for _, person := range merged {
+			if person.ID == keepID {
+				return errcodes.ValidationError("A person cannot be merged into itself.")
+			}
+			if person.LibraryID != keep.LibraryID {
+				return errcodes.ValidationError("People can only be merged within the same library.")
+			}
+		}
 
-		if err := aliases.TransferAliasesOnMerge(ctx, tx, aliases.PersonConfig, sourceID, targetID); err != nil {
-			return err
+		err = tx.NewRaw(
+			`SELECT book_id FROM authors WHERE person_id IN (?)
+			 UNION
+			 SELECT f.book_id FROM narrators n JOIN files f ON f.id = n.file_id WHERE n.person_id IN (?)`,
+			bun.In(mergeIDs), bun.In(mergeIDs),
+		).Scan(ctx, &affectedBookIDs)
+		if err != nil {
+			return errors.WithStack(err)
 		}
 
-		// Delete the source person
-		_, err = tx.NewDelete().
-			Model((*models.Person)(nil)).
-			Where("id = ?", sourceID).
-			Exec(ctx)
-		return errors.WithStack(err)
+		for _, person := range merged {
+			if err := mergePersonInto(ctx, tx, keepID, person.ID); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return affectedBookIDs, nil
+}
+
+// mergePersonInto moves sourceID's credits and aliases to targetID and
+// deletes sourceID. Credits the target already has (same book and role, or
+// same file) are dropped rather than duplicated.
+func mergePersonInto(ctx context.Context, tx bun.Tx, targetID, sourceID int) error {
+	_, err := tx.NewDelete().
+		Model((*models.Author)(nil)).
+		Where("person_id = ?", sourceID).
+		Where("EXISTS (SELECT 1 FROM authors t WHERE t.person_id = ? AND t.book_id = a.book_id AND t.role IS a.role)", targetID).
+		Exec(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = tx.NewUpdate().
+		Model((*models.Author)(nil)).
+		Set("person_id = ?", targetID).
+		Where("person_id = ?", sourceID).
+		Exec(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = tx.NewDelete().
+		Model((*models.Narrator)(nil)).
+		Where("person_id = ?", sourceID).
+		Where("EXISTS (SELECT 1 FROM narrators t WHERE t.person_id = ? AND t.file_id = n.file_id)", targetID).
+		Exec(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = tx.NewUpdate().
+		Model((*models.Narrator)(nil)).
+		Set("person_id = ?", targetID).
+		Where("person_id = ?", sourceID).
+		Exec(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := aliases.TransferAliasesOnMerge(ctx, tx, aliases.PersonConfig, sourceID, targetID); err != nil {
+		return err
+	}
+
+	_, err = tx.NewDelete().
+		Model((*models.Person)(nil)).
+		Where("id = ?", sourceID).
+		Exec(ctx)
+	return errors.WithStack(err)
+}
+
+func uniqueInts(values []int) []int {
+	seen := make(map[int]struct{}, len(values))
+	out := make([]int, 0, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
 }
 
 // CleanupOrphanedPeople deletes people with no authors or narrators and
@@ -234,3 +234,114 @@ func TestFindOrCreatePerson_NoMatch_CreatesNew(t *testing.T) {
 	assert.Equal(t, "Terry Pratchett", found.Name)
 	assert.Equal(t, lib.ID, found.LibraryID)
 }
+
+func TestFindOrCreatePerson_NormalizesWhitespace(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	lib := &models.Library{
+		Name:                     "Test Library",
+		CoverAspectRatio:         "book",
+		DownloadFormatPreference: models.DownloadFormatOriginal,
+	}
+	_, err := db.NewInsert().Model(lib).Exec(ctx)
+	require.NoError(t, err)
+
+	first, err := svc.FindOrCreatePerson(ctx, "  Brandon   Sanderson ", lib.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Brandon Sanderson", first.Name)
+
+	second, err := svc.FindOrCreatePerson(ctx, "brandon sanderson", lib.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+
+	count, err := db.NewSelect().Model((*models.Person)(nil)).Where("library_id = ?", lib.ID).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestMergePersons_MovesCreditsAndRecordsAliases(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+	lib := createTestLibrary(t, db)
+
+	keep := seedPersonWithAuthoredBooks(t, db, lib, "J.K. Rowling", []string{"Harry Potter"})
+	penName := seedPersonWithAuthoredBooks(t, db, lib, "Robert Galbraith", []string{"The Cuckoo's Calling"})
+	narrated := seedPersonWithNarratedFiles(t, db, lib, "Rowling, J.K.", []string{"Intro"})
+
+	// Credit the pen name on a book the keeper already wrote so the merge has
+	// to drop the duplicate instead of violating the unique index.
+	var sharedBookID int
+	require.NoError(t, db.NewSelect().Model((*models.Author)(nil)).Column("book_id").Where("person_id = ?", keep.ID).Scan(ctx, &sharedBookID))
+	_, err := db.NewInsert().Model(&models.Author{BookID: sharedBookID, PersonID: penName.ID, SortOrder: 2}).Exec(ctx)
+	require.NoError(t, err)
+
+	bookIDs, err := svc.MergePersons(ctx, keep.ID, []int{penName.ID, narrated.ID})
+	require.NoError(t, err)
+	assert.Len(t, bookIDs, 3)
+
+	authored, err := svc.GetAuthoredBookCount(ctx, keep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, authored)
+	narratedCount, err := svc.GetNarratedFileCount(ctx, keep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, narratedCount)
+
+	_, err = svc.RetrievePerson(ctx, RetrievePersonOptions{ID: &penName.ID})
+	assert.True(t, errors.Is(err, errcodes.NotFound("Person")))
+
+	// Later scans resolve the merged names to the kept person.
+	resolved, err := svc.FindOrCreatePerson(ctx, "Robert Galbraith", lib.ID)
+	require.NoError(t, err)
+	assert.Equal(t, keep.ID, resolved.ID)
+}
+
+func TestMergePersons_RejectsOtherLibrariesAndSelf(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+	lib := createTestLibrary(t, db)
+	otherLib := createTestLibrary(t, db)
+
+	keep := seedPersonWithAuthoredBooks(t, db, lib, "J.K. Rowling", []string{"Harry Potter"})
+	other := seedPersonWithAuthoredBooks(t, db, otherLib, "Robert Galbraith", []string{"The Cuckoo's Calling"})
+
+	_, err := svc.MergePersons(ctx, keep.ID, []int{other.ID})
+	require.Error(t, err)
+	_, err = svc.MergePersons(ctx, keep.ID, []int{keep.ID})
+	require.Error(t, err)
+	_, err = svc.MergePersons(ctx, keep.ID, nil)
+	require.Error(t, err)
+
+	// Nothing moved.
+	count, err := svc.GetAuthoredBookCount(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestFindOrCreatePerson_NameLocale(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	library := &models.Library{
+		Name:                     "Test Library",
+		CoverAspectRatio:         "book",
+		DownloadFormatPreference: models.DownloadFormatOriginal,
+	}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+
+	western, err := NewService(db).FindOrCreatePerson(ctx, "Brandon Sanderson", library.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Sanderson, Brandon", western.SortName)
+
+	familyFirst, err := NewService(db).WithNameLocale("ja").FindOrCreatePerson(ctx, "Murakami Haruki", library.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Murakami, Haruki", familyFirst.SortName)
+}
@@ -37,6 +37,7 @@ type ListPeopleQuery struct {
 	Offset    int     `query:"offset" json:"offset,omitempty" validate:"min=0"`
 	LibraryID *int    `query:"library_id" json:"library_id,omitempty" validate:"omitempty,min=1" tstype:"number"`
 	Search    *string `query:"search" json:"search,omitempty" validate:"omitempty,max=100" tstype:"string"`
+	Role      string  `query:"role" json:"role,omitempty" validate:"omitempty,oneof=author narrator" tstype:"PersonRole"` // Only people credited as authors or narrators
 }
 
 type SubResourceQuery struct {
@@ -50,6 +51,9 @@ type UpdatePersonPayload struct {
 	Aliases  []string `json:"aliases,omitempty" validate:"omitempty,dive,min=1,max=300"`
 }
 
+// MergePeoplePayload names the people to merge into the target person. Set
+// SourceID to merge one person, or SourceIDs to merge several at once.
 type MergePeoplePayload struct {
-	SourceID int `json:"source_id" validate:"required,min=1"`
+	SourceID  int   `json:"source_id,omitempty" validate:"omitempty,min=1"`
+	SourceIDs []int `json:"source_ids,omitempty" validate:"omitempty,max=100,dive,min=1"`
 }
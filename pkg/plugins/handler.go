@@ -21,6 +21,8 @@ type enrichDeps struct {
 	publisherFinder publisherFinder
 	searchIndexer   searchIndexer
 	pageExtractor   pageExtractor
+
+	keepOriginalCover bool
 }
 
 // bookStore provides core book and file CRUD operations.
@@ -31,6 +33,7 @@ type bookStore interface {
 	DeleteNarratorsForFile(ctx context.Context, fileID int) (int, error)
 	CreateNarrator(ctx context.Context, narrator *models.Narrator) error
 	OrganizeBookFiles(ctx context.Context, book *models.Book) error
+	RefreshMetadataHash(ctx context.Context, book *models.Book) error
 }
 
 // relationStore provides book relationship CRUD operations.
@@ -97,7 +97,7 @@ func (h *handler) applyMetadata(c echo.Context) error {
 	// Organize files if title, authors, narrators, or series changed (these affect directory/file names).
 	// Trim title/series first so whitespace-only values don't trigger a no-op organize pass —
 	// persistMetadata already trims before persisting, so untrimmed values would never change the book.
-	// organizeBookFiles checks the library's OrganizeFileStructure setting internally.
+	// organizeBookFiles checks the library's OrganizeFiles and OrganizeFolders settings internally.
 	hasSeriesEntries := overrides != nil && overrides.SeriesEntries != nil && len(*overrides.SeriesEntries) > 0
 	if strings.TrimSpace(md.Title) != "" || len(md.Authors) > 0 || len(md.Narrators) > 0 || strings.TrimSpace(md.Series) != "" || hasSeriesEntries {
 		freshBook, err := h.enrich.bookStore.RetrieveBook(ctx, payload.BookID)
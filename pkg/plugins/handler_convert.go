@@ -39,6 +39,11 @@ func convertFieldsToMetadata(fields map[string]any) *mediafile.ParsedMetadata {
 		md.SeriesNumber = &v
 	}
 
+	// Series reading order
+	if v, ok := fields["series_reading_order"].(float64); ok {
+		md.SeriesReadingOrder = &v
+	}
+
 	// Series number unit
 	if v, ok := fields["series_number_unit"].(string); ok {
 		if v == models.SeriesNumberUnitVolume || v == models.SeriesNumberUnitChapter {
@@ -181,6 +186,9 @@ func extractSeriesEntries(fields map[string]any) *[]SeriesEntry {
 		if num, ok := m["number"].(float64); ok {
 			entry.Number = &num
 		}
+		if order, ok := m["reading_order"].(float64); ok {
+			entry.ReadingOrder = &order
+		}
 		if unit, ok := m["series_number_unit"].(string); ok {
 			if unit == models.SeriesNumberUnitVolume || unit == models.SeriesNumberUnitChapter {
 				entry.SeriesNumberUnit = &unit
@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
 )
 
 func (h *handler) getFieldSettings(c echo.Context) error {
@@ -29,8 +30,14 @@ func (h *handler) getFieldSettings(c echo.Context) error {
 		return errors.WithStack(err)
 	}
 
+	preferred, err := h.service.GetPreferredFields(ctx, scope, id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
 	return c.JSON(http.StatusOK, FieldSettingsResponse{
-		Fields: settings,
+		Fields:    settings,
+		Preferred: preferred,
 	})
 }
 
@@ -70,12 +77,25 @@ func (h *handler) setFieldSettings(c echo.Context) error {
 			return echo.NewHTTPError(http.StatusBadRequest, "unknown field: "+field)
 		}
 	}
+	for field := range payload.Preferred {
+		if !declared[field] {
+			return echo.NewHTTPError(http.StatusBadRequest, "unknown field: "+field)
+		}
+		if !models.IsPluginPreferableField(field) {
+			return errcodes.ValidationError("Field " + field + " can't be preferred.")
+		}
+	}
 
 	for field, enabled := range payload.Fields {
 		if err := h.service.SetFieldSetting(ctx, scope, id, field, enabled); err != nil {
 			return errors.WithStack(err)
 		}
 	}
+	for field, prefer := range payload.Preferred {
+		if err := h.service.SetFieldPreference(ctx, scope, id, field, prefer); err != nil {
+			return errors.WithStack(err)
+		}
+	}
 
 	return c.NoContent(http.StatusNoContent)
 }
@@ -143,6 +163,9 @@ func (h *handler) setLibraryFieldSettings(c echo.Context) error {
 	if payload.Fields == nil {
 		return errcodes.ValidationError("Fields are required.")
 	}
+	if len(payload.Preferred) > 0 {
+		return errcodes.ValidationError("Preferred fields can only be set globally.")
+	}
 
 	// Validate field names are declared by the plugin
 	declared := make(map[string]bool, len(enricherCap.Fields))
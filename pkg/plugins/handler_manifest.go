@@ -10,6 +10,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/models"
 )
 
 // getManifest returns the raw manifest.json for an installed plugin.
@@ -76,6 +77,19 @@ func (h *handler) getConfig(c echo.Context) error {
 		return errors.WithStack(err)
 	}
 
+	preferredFields, err := h.service.GetPreferredFields(ctx, scope, id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Only declared fields that support the prefer setting are offered.
+	preferableFields := []string{}
+	for _, f := range declaredFields {
+		if models.IsPluginPreferableField(f) {
+			preferableFields = append(preferableFields, f)
+		}
+	}
+
 	// Get plugin for confidence threshold
 	plugin, err := h.service.GetPlugin(ctx, scope, id)
 	if err != nil {
@@ -92,6 +106,8 @@ func (h *handler) getConfig(c echo.Context) error {
 		Values:              values,
 		DeclaredFields:      declaredFields,
 		FieldSettings:       fieldSettings,
+		PreferredFields:     preferredFields,
+		PreferableFields:    preferableFields,
 		ConfidenceThreshold: confidenceThreshold,
 	})
 }
@@ -176,22 +176,28 @@ func (h *handler) persistMetadata(ctx context.Context, book *models.Book, target
 				if entry.SeriesNumberUnit != nil {
 					bs.SeriesNumberUnit = entry.SeriesNumberUnit
 				}
+				if entry.ReadingOrder != nil {
+					bs.ReadingOrder = entry.ReadingOrder
+				}
 				if err := h.enrich.relStore.CreateBookSeries(ctx, bs); err != nil {
 					log.Warn("failed to create book series", logger.Data{"error": err.Error()})
 				}
 				newSeries[seriesRecord.ID] = seriesRecord
 			}
 		} else {
-			seriesRecord, sErr := h.enrich.relStore.FindOrCreateSeries(ctx, md.Series, book.LibraryID, pluginSource)
-			if sErr != nil {
-				log.Warn("failed to find/create series", logger.Data{"name": md.Series, "error": sErr.Error()})
-			} else {
+			for i, parsed := range md.AllSeries() {
+				seriesRecord, sErr := h.enrich.relStore.FindOrCreateSeries(ctx, parsed.Name, book.LibraryID, pluginSource)
+				if sErr != nil {
+					log.Warn("failed to find/create series", logger.Data{"name": parsed.Name, "error": sErr.Error()})
+					continue
+				}
 				if err := h.enrich.relStore.CreateBookSeries(ctx, &models.BookSeries{
 					BookID:           book.ID,
 					SeriesID:         seriesRecord.ID,
-					SeriesNumber:     md.SeriesNumber,
-					SeriesNumberUnit: md.SeriesNumberUnit,
-					SortOrder:        1,
+					SeriesNumber:     parsed.Number,
+					SeriesNumberUnit: parsed.Unit,
+					ReadingOrder:     parsed.ReadingOrder,
+					SortOrder:        i + 1,
 				}); err != nil {
 					log.Warn("failed to create book series", logger.Data{"error": err.Error()})
 				}
@@ -443,6 +449,7 @@ func (h *handler) persistMetadata(ctx context.Context, book *models.Book, target
 
 	// Apply cover data. Precedence is strict: page-based files (CBZ, PDF)
 	// only accept coverPage; other formats only accept coverData / coverUrl.
+	// Plugins supply a 0-indexed coverPage while File.CoverPage is 1-indexed.
 	if targetFile != nil {
 		if models.IsPageBasedFileType(targetFile.FileType) {
 			// Page-based: apply coverPage, silently ignore coverData/coverUrl.
@@ -462,7 +469,7 @@ func (h *handler) persistMetadata(ctx context.Context, book *models.Book, target
 					if extractErr != nil {
 						log.Warn("failed to extract plugin-provided cover page", logger.Data{"file_id": targetFile.ID, "cover_page": page, "error": extractErr.Error()})
 					} else {
-						targetFile.CoverPage = &page
+						targetFile.CoverPage = models.CoverPageFromIndex(&page)
 						targetFile.CoverImageFilename = &coverFilename
 						targetFile.CoverMimeType = &mimeType
 						source := models.PluginDataSource(pluginScope, pluginID)
@@ -486,6 +493,15 @@ func (h *handler) persistMetadata(ctx context.Context, book *models.Book, target
 				coverFilename := coverBaseName + coverExt
 				coverFilepath := filepath.Join(coverDir, coverFilename)
 
+				// Set an embedded cover aside so it can be restored later.
+				if h.enrich.keepOriginalCover && targetFile.CoverSource != nil && models.IsEmbeddedDataSource(*targetFile.CoverSource) {
+					if existingPath := fileutils.CoverExistsWithBaseName(coverDir, coverBaseName); existingPath != "" {
+						if err := fileutils.KeepOriginalCover(existingPath, targetFile.Filepath); err != nil {
+							log.Warn("failed to keep original cover", logger.Data{"error": err.Error()})
+						}
+					}
+				}
+
 				if err := os.WriteFile(coverFilepath, normalizedData, 0600); err != nil {
 					log.Warn("failed to write cover file", logger.Data{"error": err.Error()})
 				} else {
@@ -503,16 +519,22 @@ func (h *handler) persistMetadata(ctx context.Context, book *models.Book, target
 		}
 	}
 
-	// Write sidecars to keep them in sync
+	// Write sidecars to keep them in sync. Staged books get theirs written
+	// when they're approved.
 	updatedBook, err := h.enrich.bookStore.RetrieveBook(ctx, book.ID)
 	if err == nil {
-		if sErr := sidecar.WriteBookSidecarFromModel(updatedBook); sErr != nil {
-			log.Warn("failed to write book sidecar", logger.Data{"error": sErr.Error()})
-		}
-		for _, file := range updatedBook.Files {
-			if sErr := sidecar.WriteFileSidecarFromModel(file); sErr != nil {
-				log.Warn("failed to write file sidecar", logger.Data{"file_id": file.ID, "error": sErr.Error()})
+		if !updatedBook.Staged {
+			if sErr := sidecar.WriteBookSidecarFromModel(updatedBook); sErr != nil {
+				log.Warn("failed to write book sidecar", logger.Data{"error": sErr.Error()})
 			}
+			for _, file := range updatedBook.Files {
+				if sErr := sidecar.WriteFileSidecarFromModel(file); sErr != nil {
+					log.Warn("failed to write file sidecar", logger.Data{"file_id": file.ID, "error": sErr.Error()})
+				}
+			}
+		}
+		if hErr := h.enrich.bookStore.RefreshMetadataHash(ctx, updatedBook); hErr != nil {
+			log.Warn("failed to update book metadata hash", logger.Data{"error": hErr.Error()})
 		}
 	}
 
@@ -61,6 +61,10 @@ func (s *stubBookStoreForPersist) OrganizeBookFiles(_ context.Context, _ *models
 	return nil
 }
 
+func (s *stubBookStoreForPersist) RefreshMetadataHash(_ context.Context, _ *models.Book) error {
+	return nil
+}
+
 // TestPersistMetadata_CoverWrite_RootLevelFile_SyntheticBookPath is a
 // regression test for a bug where persistMetadata wrote plugin-provided
 // cover data unconditionally to book.Filepath as the cover directory. For
@@ -79,7 +83,7 @@ func TestPersistMetadata_CoverWrite_RootLevelFile_SyntheticBookPath(t *testing.T
 
 	// Synthetic book.Filepath that does NOT exist on disk, mirroring what
 	// scanFileCreateNew computes for root-level new files in libraries with
-	// OrganizeFileStructure enabled.
+	// organizing enabled.
 	syntheticBookPath := filepath.Join(libraryDir, "Author Name", "Book Title")
 	_, err := os.Stat(syntheticBookPath)
 	require.True(t, os.IsNotExist(err), "synthetic bookPath must not exist on disk")
@@ -195,8 +199,9 @@ func TestPersistMetadata_CoverPage_CBZ_HappyPath(t *testing.T) {
 	assert.Equal(t, 1, extractor.calls[0].FileID)
 	assert.Equal(t, 3, extractor.calls[0].Page)
 
+	// Plugins supply 0-indexed pages; the stored cover page is 1-indexed.
 	require.NotNil(t, file.CoverPage)
-	assert.Equal(t, 3, *file.CoverPage)
+	assert.Equal(t, 4, *file.CoverPage)
 	require.NotNil(t, file.CoverImageFilename)
 	assert.Equal(t, "comic.cbz.cover.jpg", *file.CoverImageFilename)
 	require.NotNil(t, file.CoverMimeType)
@@ -230,7 +235,7 @@ func TestPersistMetadata_CoverPage_PDF_HappyPath(t *testing.T) {
 	require.Len(t, extractor.calls, 1)
 	assert.Equal(t, 7, extractor.calls[0].Page)
 	require.NotNil(t, file.CoverPage)
-	assert.Equal(t, 7, *file.CoverPage)
+	assert.Equal(t, 8, *file.CoverPage)
 }
 
 func TestPersistMetadata_CoverPage_OutOfBounds(t *testing.T) {
@@ -335,7 +340,7 @@ func TestPersistMetadata_CoverPage_CBZ_BeatsCoverData(t *testing.T) {
 	// coverPage path taken
 	require.Len(t, extractor.calls, 1)
 	require.NotNil(t, file.CoverPage)
-	assert.Equal(t, 2, *file.CoverPage)
+	assert.Equal(t, 3, *file.CoverPage)
 
 	// coverData file must NOT have been written alongside the file
 	_, err = os.Stat(filepath.Join(libraryDir, "comic.cbz.cover.png"))
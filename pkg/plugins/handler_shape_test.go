@@ -180,8 +180,9 @@ func TestSearchMetadata_ResponseWireShape(t *testing.T) {
 
 // TestGetConfig_ResponseWireShape pins the exact wire shape of
 // GET /plugins/installed/:scope/:id/config, including the intentionally
-// camelCase declaredFields / fieldSettings keys and the nested config-field
-// keys (manifest passthrough, ADR 0004 exemption).
+// camelCase declaredFields / fieldSettings / preferredFields /
+// preferableFields keys and the nested config-field keys (manifest
+// passthrough, ADR 0004 exemption).
 func TestGetConfig_ResponseWireShape(t *testing.T) {
 	t.Parallel()
 
@@ -204,6 +205,8 @@ func TestGetConfig_ResponseWireShape(t *testing.T) {
 		"confidence_threshold",
 		"declaredFields",
 		"fieldSettings",
+		"preferableFields",
+		"preferredFields",
 		"schema",
 		"values",
 	}, sortedJSONKeys(t, rec.Body.Bytes()))
@@ -17,6 +17,19 @@ import (
 
 var errJSPanic = errors.New("JS runtime panicked")
 
+// ErrOutputTooLarge is returned when a file parser or metadata enricher
+// returns more metadata than the manager's output limit allows.
+var ErrOutputTooLarge = errors.New("plugin output exceeds size limit")
+
+const (
+	// defaultExecTimeout bounds file parser and metadata enricher calls when
+	// SetExecLimits hasn't been called.
+	defaultExecTimeout = 1 * time.Minute
+	// defaultMaxOutputBytes caps file parser and metadata enricher results
+	// when SetExecLimits hasn't been called.
+	defaultMaxOutputBytes = 50 * 1024 * 1024
+)
+
 // safeCallJS invokes a goja function with panic recovery. The goja runtime can
 // panic on certain JS exceptions (e.g., nil pointer in handleThrow). This wrapper
 // ensures plugin errors never crash the server.
@@ -140,7 +153,8 @@ func (m *Manager) RunFileParser(ctx context.Context, rt *Runtime, filePath, file
 		return nil, errors.New("plugin does not have a fileParser hook")
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	timeout, maxOutputBytes := m.execLimits()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	rt.mu.Lock()
@@ -182,6 +196,9 @@ func (m *Manager) RunFileParser(ctx context.Context, rt *Runtime, filePath, file
 	}
 
 	// Parse the result
+	if !outputWithinLimit(result, maxOutputBytes) {
+		return nil, errors.Wrapf(ErrOutputTooLarge, "fileParser.parse returned more than %d bytes", maxOutputBytes)
+	}
 	md, err := parseParsedMetadata(rt.vm, result)
 	if err != nil {
 		return nil, err
@@ -214,7 +231,8 @@ func (m *Manager) RunMetadataSearch(ctx context.Context, rt *Runtime, searchCtx
 		return nil, errors.New("plugin does not have a metadataEnricher hook")
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	timeout, maxOutputBytes := m.execLimits()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	rt.mu.Lock()
@@ -256,9 +274,65 @@ func (m *Manager) RunMetadataSearch(ctx context.Context, rt *Runtime, searchCtx
 	}
 
 	// Parse the result
+	if !outputWithinLimit(result, maxOutputBytes) {
+		return nil, errors.Wrapf(ErrOutputTooLarge, "metadataEnricher.search returned more than %d bytes", maxOutputBytes)
+	}
 	return parseSearchResponse(rt.vm, result, rt.scope, rt.pluginID), nil
 }
 
+// outputWithinLimit reports whether a hook's return value holds at most
+// limit bytes of strings and binary data. It walks the JS value before any of
+// it is copied into Go and stops as soon as the limit is passed, so an
+// oversized result is rejected without being materialized. Array lengths
+// count too (at least a byte per element), which keeps a sparse array with a
+// huge length from sizing an enormous slice during conversion.
+func outputWithinLimit(val goja.Value, limit int64) bool {
+	m := &outputMeter{limit: limit, seen: make(map[*goja.Object]bool)}
+	m.add(val)
+	return m.size <= limit
+}
+
+type outputMeter struct {
+	limit int64
+	size  int64
+	seen  map[*goja.Object]bool // guards against cyclic objects
+}
+
+func (m *outputMeter) add(val goja.Value) {
+	if m.size > m.limit || val == nil || goja.IsUndefined(val) || goja.IsNull(val) {
+		return
+	}
+	if s, ok := val.(goja.String); ok {
+		m.size += int64(s.Length())
+		return
+	}
+	obj, ok := val.(*goja.Object)
+	if !ok || m.seen[obj] {
+		return // numbers and booleans are fixed-size
+	}
+	m.seen[obj] = true
+
+	switch obj.ClassName() {
+	case "ArrayBuffer", "Uint8Array":
+		m.size += int64(len(parseByteData(obj)))
+		return
+	case "Array":
+		m.size += obj.Get("length").ToInteger()
+	case "Object":
+		if length := obj.Get("length"); length != nil && !goja.IsUndefined(length) && !goja.IsNull(length) {
+			m.size += length.ToInteger()
+		}
+	default:
+		return
+	}
+	for _, key := range obj.Keys() {
+		if m.size > m.limit {
+			return
+		}
+		m.add(obj.Get(key))
+	}
+}
+
 // RunOutputGenerator invokes a plugin's outputGenerator.generate() hook.
 func (m *Manager) RunOutputGenerator(ctx context.Context, rt *Runtime, sourcePath, destPath string, bookCtx, fileCtx map[string]interface{}) error {
 	if rt.outputGenerator == nil {
@@ -348,6 +422,68 @@ func (m *Manager) RunFingerprint(rt *Runtime, bookCtx, fileCtx map[string]interf
 	return result.String(), nil
 }
 
+// RunOrganizer invokes a plugin's organizer.organize() hook and returns the
+// path it chose for the file, relative to libraryPath. An empty path means
+// the plugin left the file to the built-in naming. The path is returned as
+// the plugin gave it; callers must sanitize it before using it.
+func (m *Manager) RunOrganizer(ctx context.Context, rt *Runtime, libraryPath string, bookCtx, fileCtx map[string]interface{}) (string, error) {
+	if rt.organizer == nil {
+		return "", errors.New("plugin does not have an organizer hook")
+	}
+
+	timeout, _ := m.execLimits()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	// Set up FSContext. Organizers only pick a path; Shisho does the move.
+	pluginDir := filepath.Join(m.pluginDir, rt.scope, rt.pluginID)
+	fsCtx := NewFSContext(pluginDir, rt.dataDir, nil, rt.manifest.Capabilities.FileAccess)
+	rt.SetFSContext(fsCtx)
+	defer func() {
+		rt.SetFSContext(nil)
+		fsCtx.Cleanup() //nolint:errcheck
+	}()
+
+	// Get the organize method
+	organizerObj := rt.organizer.ToObject(rt.vm)
+	organizeVal := organizerObj.Get("organize")
+	if organizeVal == nil || goja.IsUndefined(organizeVal) {
+		return "", errors.New("organizer.organize is not defined")
+	}
+	organizeFn, ok := goja.AssertFunction(organizeVal)
+	if !ok {
+		return "", errors.New("organizer.organize is not a function")
+	}
+
+	// Build the context argument
+	contextObj := rt.vm.NewObject()
+	contextObj.Set("libraryPath", libraryPath) //nolint:errcheck
+	contextObj.Set("book", bookCtx)            //nolint:errcheck
+	contextObj.Set("file", fileCtx)            //nolint:errcheck
+
+	// Call the hook under a watcher that forwards ctx cancellation into the VM.
+	var result goja.Value
+	var callErr error
+	invokeHook(ctx, rt, func() {
+		result, callErr = safeCallJS(organizeFn, goja.Undefined(), rt.vm.ToValue(contextObj))
+	})
+	if callErr != nil {
+		return "", errors.Wrap(callErr, "organizer.organize failed")
+	}
+
+	if result == nil || goja.IsUndefined(result) || goja.IsNull(result) {
+		return "", nil
+	}
+	pathVal := result.ToObject(rt.vm).Get("path")
+	if pathVal == nil || goja.IsUndefined(pathVal) || goja.IsNull(pathVal) {
+		return "", nil
+	}
+	return pathVal.String(), nil
+}
+
 // parseConvertResult maps a JS result object to ConvertResult.
 func parseConvertResult(vm *goja.Runtime, val goja.Value) (*ConvertResult, error) {
 	if val == nil || goja.IsUndefined(val) || goja.IsNull(val) {
@@ -431,6 +567,13 @@ func parseSearchResponse(vm *goja.Runtime, val goja.Value, pluginScope, pluginID
 			md.SeriesNumber = &f
 		}
 
+		// seriesReadingOrder -> *float64
+		readingOrderVal := itemObj.Get("seriesReadingOrder")
+		if readingOrderVal != nil && !goja.IsUndefined(readingOrderVal) && !goja.IsNull(readingOrderVal) {
+			f := readingOrderVal.ToFloat()
+			md.SeriesReadingOrder = &f
+		}
+
 		// seriesNumberUnit -> *string ("volume" | "chapter"); ignore other values
 		unitVal := itemObj.Get("seriesNumberUnit")
 		if unitVal != nil && !goja.IsUndefined(unitVal) && !goja.IsNull(unitVal) {
@@ -534,6 +677,13 @@ func parseParsedMetadata(vm *goja.Runtime, val goja.Value) (*mediafile.ParsedMet
 		md.SeriesNumber = &f
 	}
 
+	// seriesReadingOrder -> *float64
+	readingOrderVal := obj.Get("seriesReadingOrder")
+	if readingOrderVal != nil && !goja.IsUndefined(readingOrderVal) && !goja.IsNull(readingOrderVal) {
+		f := readingOrderVal.ToFloat()
+		md.SeriesReadingOrder = &f
+	}
+
 	// seriesNumberUnit -> *string ("volume" | "chapter"); ignore other values
 	unitVal := obj.Get("seriesNumberUnit")
 	if unitVal != nil && !goja.IsUndefined(unitVal) && !goja.IsNull(unitVal) {
@@ -543,6 +693,12 @@ func parseParsedMetadata(vm *goja.Runtime, val goja.Value) (*mediafile.ParsedMet
 		}
 	}
 
+	// additionalSeries -> []ParsedSeries
+	additionalSeriesVal := obj.Get("additionalSeries")
+	if additionalSeriesVal != nil && !goja.IsUndefined(additionalSeriesVal) && !goja.IsNull(additionalSeriesVal) {
+		md.AdditionalSeries = parseAdditionalSeries(vm, additionalSeriesVal)
+	}
+
 	// authors -> []ParsedAuthor
 	authorsVal := obj.Get("authors")
 	if authorsVal != nil && !goja.IsUndefined(authorsVal) && !goja.IsNull(authorsVal) {
@@ -681,6 +837,50 @@ func parseAuthors(vm *goja.Runtime, val goja.Value) []mediafile.ParsedAuthor {
 	return authors
 }
 
+// parseAdditionalSeries maps a JS array of {name, number, unit} objects to
+// []mediafile.ParsedSeries. Entries without a name are skipped, and units
+// other than "volume" or "chapter" are ignored.
+func parseAdditionalSeries(vm *goja.Runtime, val goja.Value) []mediafile.ParsedSeries {
+	obj := val.ToObject(vm)
+	lengthVal := obj.Get("length")
+	if lengthVal == nil || goja.IsUndefined(lengthVal) {
+		return nil
+	}
+	length := int(lengthVal.ToInteger())
+
+	series := make([]mediafile.ParsedSeries, 0, length)
+	for i := 0; i < length; i++ {
+		itemVal := obj.Get(intToString(i))
+		if itemVal == nil || goja.IsUndefined(itemVal) || goja.IsNull(itemVal) {
+			continue
+		}
+		itemObj := itemVal.ToObject(vm)
+		entry := mediafile.ParsedSeries{Name: getStringField(itemObj, "name")}
+		if entry.Name == "" {
+			continue
+		}
+		numberVal := itemObj.Get("number")
+		if numberVal != nil && !goja.IsUndefined(numberVal) && !goja.IsNull(numberVal) {
+			f := numberVal.ToFloat()
+			entry.Number = &f
+		}
+		readingOrderVal := itemObj.Get("readingOrder")
+		if readingOrderVal != nil && !goja.IsUndefined(readingOrderVal) && !goja.IsNull(readingOrderVal) {
+			f := readingOrderVal.ToFloat()
+			entry.ReadingOrder = &f
+		}
+		unitVal := itemObj.Get("unit")
+		if unitVal != nil && !goja.IsUndefined(unitVal) && !goja.IsNull(unitVal) {
+			s := unitVal.String()
+			if s == models.SeriesNumberUnitVolume || s == models.SeriesNumberUnitChapter {
+				entry.Unit = &s
+			}
+		}
+		series = append(series, entry)
+	}
+	return series
+}
+
 // parseStringArray maps a JS array of strings to []string.
 func parseStringArray(vm *goja.Runtime, val goja.Value) []string {
 	obj := val.ToObject(vm)
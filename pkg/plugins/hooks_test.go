@@ -401,6 +401,51 @@ func TestRunFingerprint(t *testing.T) {
 	assert.Equal(t, fp, fp2)
 }
 
+func TestRunOrganizer(t *testing.T) {
+	t.Parallel()
+	mgr, rt := setupHooksTestManager(t, "hooks-organizer", "hooks-organizer")
+	ctx := context.Background()
+
+	bookCtx := map[string]interface{}{
+		"title":   "Mistborn",
+		"authors": []map[string]interface{}{{"name": "Brandon Sanderson"}},
+	}
+
+	path, err := mgr.RunOrganizer(ctx, rt, "/library", bookCtx, map[string]interface{}{"fileType": "epub"})
+	require.NoError(t, err)
+	assert.Equal(t, "Ebooks/Brandon Sanderson/Mistborn/Mistborn", path)
+
+	// Returning null leaves the file to the built-in naming.
+	path, err = mgr.RunOrganizer(ctx, rt, "/library", bookCtx, map[string]interface{}{"fileType": "m4b"})
+	require.NoError(t, err)
+	assert.Empty(t, path)
+}
+
+func TestOrganizedPath_UsesEnabledOrganizers(t *testing.T) {
+	t.Parallel()
+	mgr, _ := setupHooksTestManager(t, "hooks-organizer", "hooks-organizer")
+	ctx := context.Background()
+
+	book := &models.Book{
+		Title: "Mistborn",
+		Authors: []*models.Author{
+			{Person: &models.Person{Name: "Brandon Sanderson"}},
+		},
+	}
+	path, err := mgr.OrganizedPath(ctx, book, &models.File{FileType: models.FileTypeEPUB}, "/library")
+	require.NoError(t, err)
+	assert.Equal(t, "Ebooks/Brandon Sanderson/Mistborn/Mistborn", path)
+}
+
+func TestRunOrganizer_NoHook(t *testing.T) {
+	t.Parallel()
+	mgr, rt := setupHooksTestManager(t, "hooks-generator", "hooks-generator")
+
+	_, err := mgr.RunOrganizer(context.Background(), rt, "/library", nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not have an organizer hook")
+}
+
 func TestRunInputConverter_NoHook(t *testing.T) {
 	t.Parallel()
 	// Use the parser plugin which has no converter hook
@@ -1012,3 +1057,62 @@ func TestRunFileParser_InterruptsLongSleep(t *testing.T) {
 	var ie *goja.InterruptedError
 	assert.True(t, errors.As(err, &ie), "expected *goja.InterruptedError in chain, got %T: %v", err, err)
 }
+
+func TestRunFileParser_ExecTimeout(t *testing.T) {
+	t.Parallel()
+
+	mgr, rt := installCancelTestPlugin(t, "slow-parser", "shisho.sleep(3000); return { title: \"done\" };")
+	mgr.SetExecLimits(100*time.Millisecond, 0)
+
+	start := time.Now()
+	err := runWithDeadline(t, func() error {
+		_, e := mgr.RunFileParser(context.Background(), rt, "/some/file.bin", "bin")
+		return e
+	})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 1*time.Second, "the configured timeout should replace the default")
+}
+
+func TestRunFileParser_OutputTooLarge(t *testing.T) {
+	t.Parallel()
+
+	mgr, rt := installCancelTestPlugin(t, "huge-parser", "return { title: \"x\".repeat(4096) };")
+	mgr.SetExecLimits(0, 1024)
+
+	_, err := mgr.RunFileParser(context.Background(), rt, "/some/file.bin", "bin")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrOutputTooLarge)
+
+	// Within the limit the same runtime still works.
+	mgr.SetExecLimits(0, 1<<20)
+	md, err := mgr.RunFileParser(context.Background(), rt, "/some/file.bin", "bin")
+	require.NoError(t, err)
+	assert.Len(t, md.Title, 4096)
+}
+
+func TestRunMetadataSearch_OutputTooLarge(t *testing.T) {
+	t.Parallel()
+
+	mgr, rt := installCancelTestEnricher(t, "huge-enricher",
+		"var r = []; for (var i = 0; i < 8; i++) { r.push({ description: \"x\".repeat(256) }); } return { results: r };")
+	mgr.SetExecLimits(0, 1024)
+
+	_, err := mgr.RunMetadataSearch(context.Background(), rt, map[string]interface{}{"query": "anything"}, "")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrOutputTooLarge)
+}
+
+func TestRunFileParser_OutputTooLargeSparseArray(t *testing.T) {
+	t.Parallel()
+
+	// A sparse array carries almost no data but would size a huge slice if
+	// converted, so its length alone must trip the limit.
+	mgr, rt := installCancelTestPlugin(t, "sparse-parser", "var a = []; a.length = 1e9; return { title: \"t\", authors: a };")
+	mgr.SetExecLimits(0, 1<<20)
+
+	_, err := mgr.RunFileParser(context.Background(), rt, "/some/file.bin", "bin")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrOutputTooLarge)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/robinjoseph08/golib/logger"
@@ -30,6 +31,11 @@ type Manager struct {
 	pluginDir     string
 	pluginDataDir string // Base directory for persistent plugin data
 
+	// execTimeout and maxOutputBytes bound file parser and metadata enricher
+	// calls. Set via SetExecLimits.
+	execTimeout    time.Duration
+	maxOutputBytes int64
+
 	// fetchRepo is the function used to fetch repository manifests.
 	// Defaults to FetchRepository; tests can override this.
 	fetchRepo func(url string) (*RepositoryManifest, error)
@@ -42,12 +48,34 @@ type Manager struct {
 // NewManager creates a new Manager.
 func NewManager(service *Service, pluginDir, pluginDataDir string) *Manager {
 	return &Manager{
-		plugins:       make(map[string]*Runtime),
-		service:       service,
-		pluginDir:     pluginDir,
-		pluginDataDir: pluginDataDir,
-		fetchRepo:     FetchRepository,
+		plugins:        make(map[string]*Runtime),
+		service:        service,
+		pluginDir:      pluginDir,
+		pluginDataDir:  pluginDataDir,
+		fetchRepo:      FetchRepository,
+		execTimeout:    defaultExecTimeout,
+		maxOutputBytes: defaultMaxOutputBytes,
+	}
+}
+
+// SetExecLimits sets how long a file parser or metadata enricher call can run
+// and how large its result can be. Non-positive values keep the defaults.
+func (m *Manager) SetExecLimits(timeout time.Duration, maxOutputBytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if timeout > 0 {
+		m.execTimeout = timeout
 	}
+	if maxOutputBytes > 0 {
+		m.maxOutputBytes = maxOutputBytes
+	}
+}
+
+// execLimits returns the limits set by SetExecLimits.
+func (m *Manager) execLimits() (time.Duration, int64) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.execTimeout, m.maxOutputBytes
 }
 
 // SetEventCallback registers a callback for plugin lifecycle events.
@@ -432,6 +460,34 @@ func (m *Manager) GetOutputGenerator(formatID string) *PluginGenerator {
 	return nil
 }
 
+// OrganizedPath asks the library's enabled organizer plugins, in order, where
+// file should go and returns the first path one of them gives, relative to
+// libraryPath. It returns "" when there are no organizers or none of them
+// chose a path, so the built-in naming applies. It satisfies
+// books.PathOrganizer.
+func (m *Manager) OrganizedPath(ctx context.Context, book *models.Book, file *models.File, libraryPath string) (string, error) {
+	runtimes, err := m.GetOrderedRuntimes(ctx, models.PluginHookOrganizer, book.LibraryID)
+	if err != nil {
+		return "", err
+	}
+	if len(runtimes) == 0 {
+		return "", nil
+	}
+
+	bookCtx := BuildBookContext(book)
+	fileCtx := BuildFileContext(file)
+	for _, rt := range runtimes {
+		path, err := m.RunOrganizer(ctx, rt, libraryPath, bookCtx, fileCtx)
+		if err != nil {
+			return "", errors.Wrapf(err, "organizer %s/%s", rt.scope, rt.pluginID)
+		}
+		if path != "" {
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
 // RegisteredOutputFormats returns all format IDs registered by plugin output generators,
 // along with their source type restrictions.
 func (m *Manager) RegisteredOutputFormats() []OutputFormatInfo {
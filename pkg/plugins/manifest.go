@@ -1,6 +1,7 @@
 package plugins
 
 import (
+	"encoding/hex"
 	"encoding/json"
 
 	"github.com/pkg/errors"
@@ -28,6 +29,7 @@ type Capabilities struct {
 	FileParser       *FileParserCap       `json:"fileParser"`
 	OutputGenerator  *OutputGeneratorCap  `json:"outputGenerator"`
 	MetadataEnricher *MetadataEnricherCap `json:"metadataEnricher"`
+	Organizer        *OrganizerCap        `json:"organizer"`
 	IdentifierTypes  []IdentifierTypeCap  `json:"identifierTypes"`
 	HTTPAccess       *HTTPAccessCap       `json:"httpAccess"`
 	FileAccess       *FileAccessCap       `json:"fileAccess"`
@@ -46,6 +48,25 @@ type FileParserCap struct {
 	Description string   `json:"description"`
 	Types       []string `json:"types"`
 	MIMETypes   []string `json:"mimeTypes"`
+	// MagicBytes are hex-encoded prefixes (e.g. "52617221" for "Rar!") that a
+	// file may start with instead of having one of MIMETypes, for formats
+	// the MIME detector doesn't recognize.
+	MagicBytes []string `json:"magicBytes,omitempty"`
+	// SkipMIMECheck hands every file with one of Types to the parser without
+	// checking its contents first.
+	SkipMIMECheck bool `json:"skipMimeCheck,omitempty"`
+}
+
+// MagicBytePrefixes returns the decoded MagicBytes. ParseManifest has already
+// rejected any that aren't valid hex.
+func (c *FileParserCap) MagicBytePrefixes() [][]byte {
+	prefixes := make([][]byte, 0, len(c.MagicBytes))
+	for _, s := range c.MagicBytes {
+		if b, err := hex.DecodeString(s); err == nil && len(b) > 0 {
+			prefixes = append(prefixes, b)
+		}
+	}
+	return prefixes
 }
 
 type OutputGeneratorCap struct {
@@ -81,6 +102,12 @@ type MetadataEnricherCap struct {
 	Fields      []string `json:"fields"`
 }
 
+// OrganizerCap declares a plugin that chooses where files are moved when a
+// library organizes its file structure.
+type OrganizerCap struct {
+	Description string `json:"description"`
+}
+
 type IdentifierTypeCap struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
@@ -165,5 +192,13 @@ func ParseManifest(data []byte) (*Manifest, error) {
 		return nil, errors.New("manifest: version is required")
 	}
 
+	if fp := m.Capabilities.FileParser; fp != nil {
+		for _, s := range fp.MagicBytes {
+			if b, err := hex.DecodeString(s); err != nil || len(b) == 0 {
+				return nil, errors.Errorf("manifest: fileParser.magicBytes %q must be a non-empty hex string", s)
+			}
+		}
+	}
+
 	return &m, nil
 }
@@ -299,3 +299,54 @@ func TestIsValidMetadataField(t *testing.T) {
 	assert.False(t, IsValidMetadataField(""))
 	assert.False(t, IsValidMetadataField("Language")) // case-sensitive
 }
+
+func TestParseManifest_FileParserMagicBytes(t *testing.T) {
+	t.Parallel()
+	manifest := map[string]interface{}{
+		"manifestVersion": 1,
+		"id":              "parser-cbr",
+		"name":            "CBR Parser",
+		"version":         "1.0.0",
+		"capabilities": map[string]interface{}{
+			"fileParser": map[string]interface{}{
+				"types":      []string{"cbr"},
+				"magicBytes": []string{"526172211a07", "52617221"},
+			},
+		},
+	}
+
+	data, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	m, err := ParseManifest(data)
+	require.NoError(t, err)
+
+	require.NotNil(t, m.Capabilities.FileParser)
+	assert.False(t, m.Capabilities.FileParser.SkipMIMECheck)
+	assert.Equal(t, [][]byte{[]byte("Rar!\x1a\x07"), []byte("Rar!")}, m.Capabilities.FileParser.MagicBytePrefixes())
+}
+
+func TestParseManifest_FileParserInvalidMagicBytes(t *testing.T) {
+	t.Parallel()
+	for _, magic := range []string{"not hex", "", "abc"} {
+		manifest := map[string]interface{}{
+			"manifestVersion": 1,
+			"id":              "parser-cbr",
+			"name":            "CBR Parser",
+			"version":         "1.0.0",
+			"capabilities": map[string]interface{}{
+				"fileParser": map[string]interface{}{
+					"types":      []string{"cbr"},
+					"magicBytes": []string{magic},
+				},
+			},
+		}
+
+		data, err := json.Marshal(manifest)
+		require.NoError(t, err)
+
+		_, err = ParseManifest(data)
+		require.Error(t, err, "magicBytes %q", magic)
+		assert.Contains(t, err.Error(), "magicBytes")
+	}
+}
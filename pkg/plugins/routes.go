@@ -19,6 +19,10 @@ type EnrichDeps struct {
 	PublisherFinder publisherFinder
 	SearchIndexer   searchIndexer
 	PageExtractor   pageExtractor
+
+	// KeepOriginalCover sets an embedded cover aside when an applied plugin
+	// cover replaces it.
+	KeepOriginalCover bool
 }
 
 // RegisterRoutesWithGroup registers plugin management API routes.
@@ -35,6 +39,8 @@ func RegisterRoutesWithGroup(g *echo.Group, service *Service, manager *Manager,
 			publisherFinder: ed.PublisherFinder,
 			searchIndexer:   ed.SearchIndexer,
 			pageExtractor:   ed.PageExtractor,
+
+			keepOriginalCover: ed.KeepOriginalCover,
 		}
 	}
 
@@ -78,6 +84,8 @@ func RegisterIdentifyRoutes(g *echo.Group, service *Service, manager *Manager, e
 			publisherFinder: ed.PublisherFinder,
 			searchIndexer:   ed.SearchIndexer,
 			pageExtractor:   ed.PageExtractor,
+
+			keepOriginalCover: ed.KeepOriginalCover,
 		}
 	}
 
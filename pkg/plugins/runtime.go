@@ -41,6 +41,7 @@ type Runtime struct {
 	fileParser       goja.Value
 	outputGenerator  goja.Value
 	metadataEnricher goja.Value
+	organizer        goja.Value
 	onUninstalling   goja.Callable // Optional lifecycle hook called before uninstall
 
 	// fsCtx is the filesystem context for the current hook invocation.
@@ -123,6 +124,7 @@ func LoadPlugin(dir, scope, pluginID string) (*Runtime, error) {
 	rt.fileParser = extractHook(pluginObj, "fileParser")
 	rt.outputGenerator = extractHook(pluginObj, "outputGenerator")
 	rt.metadataEnricher = extractHook(pluginObj, "metadataEnricher")
+	rt.organizer = extractHook(pluginObj, "organizer")
 
 	// Extract optional lifecycle hook (no manifest capability needed)
 	if val := pluginObj.Get("onUninstalling"); val != nil && !goja.IsUndefined(val) && !goja.IsNull(val) {
@@ -147,6 +149,9 @@ func LoadPlugin(dir, scope, pluginID string) (*Runtime, error) {
 	if rt.metadataEnricher != nil && manifest.Capabilities.MetadataEnricher == nil {
 		return nil, errors.New("plugin exports 'metadataEnricher' but manifest does not declare it in capabilities")
 	}
+	if rt.organizer != nil && manifest.Capabilities.Organizer == nil {
+		return nil, errors.New("plugin exports 'organizer' but manifest does not declare it in capabilities")
+	}
 
 	// 10. Validate metadataEnricher fields (if declared)
 	if manifest.Capabilities.MetadataEnricher != nil {
@@ -215,6 +220,9 @@ func (rt *Runtime) HookTypes() []string {
 	if rt.metadataEnricher != nil {
 		hooks = append(hooks, "metadataEnricher")
 	}
+	if rt.organizer != nil {
+		hooks = append(hooks, "organizer")
+	}
 	sort.Strings(hooks)
 	return hooks
 }
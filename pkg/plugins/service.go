@@ -478,7 +478,7 @@ func (s *Service) ResetAllLibraryOrders(ctx context.Context, libraryID int) erro
 }
 
 // GetFieldSettings returns global field settings for a plugin.
-// Returns a map of field name -> enabled status for fields that are explicitly disabled.
+// Returns a map of field name -> enabled status for fields with a stored setting.
 // Absence from the map means the field is enabled (default).
 func (s *Service) GetFieldSettings(ctx context.Context, scope, pluginID string) (map[string]bool, error) {
 	var settings []*models.PluginFieldSetting
@@ -498,37 +498,77 @@ func (s *Service) GetFieldSettings(ctx context.Context, scope, pluginID string)
 }
 
 // SetFieldSetting upserts a single global field setting.
-// If enabled=true, the row is deleted (enabled is the default).
-// If enabled=false, the row is upserted.
+// If enabled=true and the field isn't preferred, the row is deleted (enabled
+// is the default). Otherwise the row is upserted.
 func (s *Service) SetFieldSetting(ctx context.Context, scope, pluginID, field string, enabled bool) error {
-	if enabled {
-		// Delete the row - enabled is the default
-		_, err := s.db.NewDelete().Model((*models.PluginFieldSetting)(nil)).
-			Where("scope = ?", scope).
-			Where("plugin_id = ?", pluginID).
-			Where("field = ?", field).
-			Exec(ctx)
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		return nil
+	setting := &models.PluginFieldSetting{
+		Scope:    scope,
+		PluginID: pluginID,
+		Field:    field,
+		Enabled:  enabled,
+	}
+	_, err := s.db.NewInsert().Model(setting).
+		On("CONFLICT (scope, plugin_id, field) DO UPDATE").
+		Set("enabled = EXCLUDED.enabled").
+		Exec(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return s.deleteDefaultFieldSetting(ctx, scope, pluginID, field)
+}
+
+// GetPreferredFields returns the fields whose plugin values should override
+// file-embedded metadata during scans. Only fields with prefer=true are
+// included in the map.
+func (s *Service) GetPreferredFields(ctx context.Context, scope, pluginID string) (map[string]bool, error) {
+	var settings []*models.PluginFieldSetting
+	err := s.db.NewSelect().Model(&settings).
+		Where("scope = ?", scope).
+		Where("plugin_id = ?", pluginID).
+		Where("prefer = ?", true).
+		Scan(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
 	}
 
-	// Upsert disabled setting
+	result := make(map[string]bool, len(settings))
+	for _, setting := range settings {
+		result[setting.Field] = true
+	}
+	return result, nil
+}
+
+// SetFieldPreference sets whether a plugin's value for field should override
+// file-embedded metadata during scans. The enabled state is left unchanged.
+func (s *Service) SetFieldPreference(ctx context.Context, scope, pluginID, field string, prefer bool) error {
 	setting := &models.PluginFieldSetting{
 		Scope:    scope,
 		PluginID: pluginID,
 		Field:    field,
-		Enabled:  false,
+		Enabled:  true,
+		Prefer:   prefer,
 	}
 	_, err := s.db.NewInsert().Model(setting).
 		On("CONFLICT (scope, plugin_id, field) DO UPDATE").
-		Set("enabled = EXCLUDED.enabled").
+		Set("prefer = EXCLUDED.prefer").
 		Exec(ctx)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	return nil
+	return s.deleteDefaultFieldSetting(ctx, scope, pluginID, field)
+}
+
+// deleteDefaultFieldSetting removes a global field setting row that only
+// holds default values (enabled, not preferred), keeping the table sparse.
+func (s *Service) deleteDefaultFieldSetting(ctx context.Context, scope, pluginID, field string) error {
+	_, err := s.db.NewDelete().Model((*models.PluginFieldSetting)(nil)).
+		Where("scope = ?", scope).
+		Where("plugin_id = ?", pluginID).
+		Where("field = ?", field).
+		Where("enabled = ?", true).
+		Where("prefer = ?", false).
+		Exec(ctx)
+	return errors.WithStack(err)
 }
 
 // GetLibraryFieldSettings returns per-library field overrides for a plugin.
@@ -586,6 +586,61 @@ func TestService_SetFieldSetting_EnableFieldRemovesRow(t *testing.T) {
 	assert.Empty(t, settings)
 }
 
+func TestService_SetFieldPreference(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	svc := NewService(db)
+	ctx := context.Background()
+
+	insertTestPlugin(t, db, "community", "test-plugin")
+
+	preferred, err := svc.GetPreferredFields(ctx, "community", "test-plugin")
+	require.NoError(t, err)
+	assert.Empty(t, preferred)
+
+	err = svc.SetFieldPreference(ctx, "community", "test-plugin", "cover", true)
+	require.NoError(t, err)
+
+	preferred, err = svc.GetPreferredFields(ctx, "community", "test-plugin")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"cover": true}, preferred)
+
+	// Preferring a field doesn't disable it.
+	settings, err := svc.GetFieldSettings(ctx, "community", "test-plugin")
+	require.NoError(t, err)
+	assert.True(t, settings["cover"])
+
+	err = svc.SetFieldPreference(ctx, "community", "test-plugin", "cover", false)
+	require.NoError(t, err)
+
+	// Back to defaults = no row.
+	settings, err = svc.GetFieldSettings(ctx, "community", "test-plugin")
+	require.NoError(t, err)
+	assert.Empty(t, settings)
+}
+
+func TestService_SetFieldSetting_PreservesPreference(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	svc := NewService(db)
+	ctx := context.Background()
+
+	insertTestPlugin(t, db, "community", "test-plugin")
+
+	require.NoError(t, svc.SetFieldPreference(ctx, "community", "test-plugin", "cover", true))
+	require.NoError(t, svc.SetFieldSetting(ctx, "community", "test-plugin", "cover", false))
+	require.NoError(t, svc.SetFieldSetting(ctx, "community", "test-plugin", "cover", true))
+
+	// Re-enabling keeps the row because the field is still preferred.
+	preferred, err := svc.GetPreferredFields(ctx, "community", "test-plugin")
+	require.NoError(t, err)
+	assert.True(t, preferred["cover"])
+
+	settings, err := svc.GetFieldSettings(ctx, "community", "test-plugin")
+	require.NoError(t, err)
+	assert.True(t, settings["cover"])
+}
+
 func TestService_SetFieldSetting_MultipleFields(t *testing.T) {
 	t.Parallel()
 	db := setupTestDB(t)
@@ -0,0 +1,13 @@
+var plugin = (function() {
+  return {
+    organizer: {
+      organize: function(context) {
+        if (context.file.fileType !== "epub") {
+          return null;
+        }
+        var author = context.book.authors ? context.book.authors[0].name : "Unknown";
+        return { path: "Ebooks/" + author + "/" + context.book.title + "/" + context.book.title };
+      }
+    }
+  };
+})();
@@ -29,6 +29,8 @@ type PluginConfigResponse struct {
 	Values              map[string]interface{} `json:"values"`
 	DeclaredFields      []string               `json:"declaredFields"`
 	FieldSettings       map[string]bool        `json:"fieldSettings"`
+	PreferredFields     map[string]bool        `json:"preferredFields"`
+	PreferableFields    []string               `json:"preferableFields"`
 	ConfidenceThreshold *float64               `json:"confidence_threshold"`
 }
 
@@ -77,7 +79,8 @@ type AvailablePluginResponse struct {
 
 // FieldSettingsResponse is the body of GET /plugins/installed/:scope/:id/fields.
 type FieldSettingsResponse struct {
-	Fields map[string]bool `json:"fields"`
+	Fields    map[string]bool `json:"fields"`
+	Preferred map[string]bool `json:"preferred"`
 }
 
 // LibraryFieldSettingsResponse is the body of
@@ -215,9 +218,11 @@ type SetLibraryOrderPayload struct {
 }
 
 // SetFieldSettingsPayload is the body of PUT /plugins/installed/:scope/:id/fields
-// and PUT /libraries/:id/plugins/:scope/:pluginId/fields.
+// and PUT /libraries/:id/plugins/:scope/:pluginId/fields. Preferred is only
+// accepted by the global endpoint and only for preferable fields (cover).
 type SetFieldSettingsPayload struct {
-	Fields map[string]bool `json:"fields" validate:"required"`
+	Fields    map[string]bool `json:"fields" validate:"required"`
+	Preferred map[string]bool `json:"preferred,omitempty"`
 }
 
 // ---------------------------------------------------------------------------
@@ -235,6 +240,7 @@ type SeriesEntry struct {
 	Name             string
 	Number           *float64
 	SeriesNumberUnit *string
+	ReadingOrder     *float64
 }
 
 // ApplyOverrides carries apply-path-only signals that don't belong on
@@ -32,6 +32,18 @@ func (h *handler) globalSearch(c echo.Context) error {
 		}
 	}
 
+	if params.Scope == SearchScopeContent {
+		books, err := h.searchService.SearchContent(ctx, params.LibraryID, params.Query, globalSearchLimit)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		return errors.WithStack(c.JSON(http.StatusOK, &GlobalSearchResponse{
+			Books:  books,
+			Series: []SeriesSearchResult{},
+			People: []PersonSearchResult{},
+		}))
+	}
+
 	result, err := h.searchService.GlobalSearch(ctx, params.LibraryID, params.Query)
 	if err != nil {
 		return errors.WithStack(err)
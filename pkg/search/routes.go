@@ -2,12 +2,13 @@ package search
 
 import (
 	"github.com/labstack/echo/v4"
+	"github.com/shishobooks/shisho/pkg/config"
 	"github.com/uptrace/bun"
 )
 
 // RegisterRoutesWithGroup registers search routes on a pre-configured group.
-func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB) {
-	searchService := NewService(db)
+func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, cfg *config.Config) {
+	searchService := NewService(db).WithHighlight(cfg.SearchHighlightStart, cfg.SearchHighlightEnd)
 
 	h := &handler{
 		searchService: searchService,
@@ -2,6 +2,7 @@ package search
 
 import (
 	"context"
+	"html"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -12,14 +13,41 @@ import (
 
 const (
 	globalSearchLimit = 5
+
+	// Default markers wrapped around matched terms in book highlights.
+	defaultHighlightStart = "<mark>"
+	defaultHighlightEnd   = "</mark>"
+
+	// snippetMaxTokens is how many tokens a book snippet spans, FTS5's cap.
+	snippetMaxTokens = 12
+
+	// FTS5 marks matched terms with these control characters so the text
+	// can be HTML-escaped before the configured markers (usually tags) are
+	// put in. See renderHighlight.
+	matchStart = "\x02"
+	matchEnd   = "\x03"
 )
 
 type Service struct {
-	db *bun.DB
+	db             *bun.DB
+	highlightStart string
+	highlightEnd   string
 }
 
 func NewService(db *bun.DB) *Service {
-	return &Service{db}
+	return &Service{
+		db:             db,
+		highlightStart: defaultHighlightStart,
+		highlightEnd:   defaultHighlightEnd,
+	}
+}
+
+// WithHighlight sets the markers wrapped around matched terms in the
+// highlighted title and snippet of book search results.
+func (svc *Service) WithHighlight(start, end string) *Service {
+	svc.highlightStart = start
+	svc.highlightEnd = end
+	return svc
 }
 
 // GlobalSearch searches across books, series, and people in a library.
@@ -145,6 +173,10 @@ func (svc *Service) searchBooksInternal(ctx context.Context, ftsQuery string, li
 			TableExpr("books_fts bf").
 			ColumnExpr("bf.book_id AS id, bf.library_id, bf.title, bf.subtitle").
 			ColumnExpr("(SELECT GROUP_CONCAT(DISTINCT p.name) FROM authors a JOIN persons p ON p.id = a.person_id WHERE a.book_id = bf.book_id) AS authors").
+			// Column 2 is title. Passing -1 to snippet() lets FTS5 pick the
+			// column with the best match (author, series, description, ...).
+			ColumnExpr("highlight(books_fts, 2, ?, ?) AS highlighted_title", matchStart, matchEnd).
+			ColumnExpr("snippet(books_fts, -1, ?, ?, '…', ?) AS snippet", matchStart, matchEnd, snippetMaxTokens).
 			Where("books_fts MATCH ?", ftsQuery).
 			Where("bf.library_id = ?", libraryID).
 			Order("bf.rank").
@@ -164,6 +196,8 @@ func (svc *Service) searchBooksInternal(ctx context.Context, ftsQuery string, li
 		// Add FTS results, skipping duplicates from identifier search
 		for _, r := range ftsResults {
 			if !seenIDs[r.ID] && len(results) < limit {
+				r.HighlightedTitle = svc.renderHighlight(r.HighlightedTitle)
+				r.Snippet = svc.renderHighlight(r.Snippet)
 				results = append(results, r)
 				seenIDs[r.ID] = true
 			}
@@ -178,6 +212,20 @@ func (svc *Service) searchBooksInternal(ctx context.Context, ftsQuery string, li
 	return results, nil
 }
 
+// renderHighlight HTML-escapes text returned by FTS5's highlight() or
+// snippet() and swaps the match markers for the configured highlight markers.
+// Escaping first keeps markup in book metadata from reaching clients that
+// render the result as HTML.
+func (svc *Service) renderHighlight(text *string) *string {
+	if text == nil {
+		return nil
+	}
+	rendered := html.EscapeString(*text)
+	rendered = strings.ReplaceAll(rendered, matchStart, svc.highlightStart)
+	rendered = strings.ReplaceAll(rendered, matchEnd, svc.highlightEnd)
+	return &rendered
+}
+
 // populateBookFileTypes fetches and populates file types for a slice of book search results.
 func (svc *Service) populateBookFileTypes(ctx context.Context, results []BookSearchResult) error {
 	if len(results) == 0 {
@@ -333,13 +381,17 @@ func (svc *Service) countPeopleInternal(ctx context.Context, ftsQuery string, li
 	return count, errors.WithStack(err)
 }
 
-// IndexBook adds or updates a book in the FTS index.
+// IndexBook adds or updates a book in the FTS index. Hidden books are removed
+// from the index instead.
 func (svc *Service) IndexBook(ctx context.Context, book *models.Book) error {
 	// First, delete any existing entry
 	err := svc.DeleteFromBookIndex(ctx, book.ID)
 	if err != nil {
 		return errors.WithStack(err)
 	}
+	if book.Hidden {
+		return nil
+	}
 
 	// Collect author names and their aliases (deduplicated)
 	seenAuthors := make(map[string]bool)
@@ -401,10 +453,14 @@ func (svc *Service) IndexBook(ctx context.Context, book *models.Book) error {
 	if book.Subtitle != nil {
 		subtitle = *book.Subtitle
 	}
+	description := ""
+	if book.Description != nil {
+		description = *book.Description
+	}
 
 	_, err = svc.db.ExecContext(ctx,
-		`INSERT INTO books_fts (book_id, library_id, title, filepath, subtitle, authors, filenames, narrators, series_names)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		`INSERT INTO books_fts (book_id, library_id, title, filepath, subtitle, authors, filenames, narrators, series_names, description)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		book.ID,
 		book.LibraryID,
 		book.Title,
@@ -414,6 +470,7 @@ func (svc *Service) IndexBook(ctx context.Context, book *models.Book) error {
 		strings.Join(filenames, " "),
 		strings.Join(narratorNames, " "),
 		strings.Join(seriesNames, " "),
+		description,
 	)
 	return errors.WithStack(err)
 }
@@ -427,6 +484,90 @@ func (svc *Service) DeleteFromBookIndex(ctx context.Context, bookID int) error {
 	return errors.WithStack(err)
 }
 
+// SearchContent searches the extracted body text of indexed files for query,
+// taken as a literal phrase, and returns the matching books with a snippet of
+// the passage that matched. A book with several matching files is returned
+// once, for its best match.
+func (svc *Service) SearchContent(ctx context.Context, libraryID int, query string, limit int) ([]BookSearchResult, error) {
+	results := []BookSearchResult{}
+	ftsQuery := SanitizeFTSQuery(query)
+	if ftsQuery == "" {
+		return results, nil
+	}
+
+	matches := []BookSearchResult{}
+	err := svc.db.NewSelect().
+		TableExpr("book_content_fts bc").
+		Join("JOIN files f ON f.id = bc.rowid").
+		Join("JOIN books b ON b.id = f.book_id").
+		ColumnExpr("b.id, b.library_id, b.title, b.subtitle").
+		ColumnExpr("(SELECT GROUP_CONCAT(DISTINCT p.name) FROM authors a JOIN persons p ON p.id = a.person_id WHERE a.book_id = b.id) AS authors").
+		// Column 1 is content.
+		ColumnExpr("snippet(book_content_fts, 1, ?, ?, '…', ?) AS snippet", matchStart, matchEnd, snippetMaxTokens).
+		Where("book_content_fts MATCH ?", ftsQuery).
+		Where("b.library_id = ?", libraryID).
+		Where("b.hidden = FALSE").
+		Order("bc.rank").
+		Limit(limit*2). // Fetch extra to account for books with several matching files
+		Scan(ctx, &matches)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	seenIDs := make(map[int]bool)
+	for _, r := range matches {
+		if !seenIDs[r.ID] && len(results) < limit {
+			r.Snippet = svc.renderHighlight(r.Snippet)
+			results = append(results, r)
+			seenIDs[r.ID] = true
+		}
+	}
+
+	if err := svc.populateBookFileTypes(ctx, results); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return results, nil
+}
+
+// ContentHash returns the hash of the file that the indexed body text of
+// fileID was extracted from, or "" if the file's text isn't indexed.
+func (svc *Service) ContentHash(ctx context.Context, fileID int) (string, error) {
+	var hashes []string
+	err := svc.db.NewSelect().
+		TableExpr("book_content_fts").
+		ColumnExpr("content_hash").
+		Where("rowid = ?", fileID).
+		Scan(ctx, &hashes)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if len(hashes) == 0 {
+		return "", nil
+	}
+	return hashes[0], nil
+}
+
+// IndexFileContent adds or replaces the indexed body text of a file.
+// contentHash identifies the version of the file the text came from.
+func (svc *Service) IndexFileContent(ctx context.Context, fileID int, contentHash, content string) error {
+	if err := svc.DeleteFromContentIndex(ctx, fileID); err != nil {
+		return err
+	}
+	_, err := svc.db.ExecContext(ctx,
+		"INSERT INTO book_content_fts (rowid, content_hash, content) VALUES (?, ?, ?)",
+		fileID, contentHash, content)
+	return errors.WithStack(err)
+}
+
+// DeleteFromContentIndex removes a file's body text from the content index.
+func (svc *Service) DeleteFromContentIndex(ctx context.Context, fileID int) error {
+	_, err := svc.db.NewDelete().
+		TableExpr("book_content_fts").
+		Where("rowid = ?", fileID).
+		Exec(ctx)
+	return errors.WithStack(err)
+}
+
 // IndexSeries adds or updates a series in the FTS index.
 func (svc *Service) IndexSeries(ctx context.Context, series *models.Series) error {
 	// First, delete any existing entry
@@ -615,6 +756,36 @@ func (svc *Service) DeleteFromPublisherIndex(ctx context.Context, publisherID in
 	return errors.WithStack(err)
 }
 
+// IndexCollection adds or updates a collection in the FTS index.
+func (svc *Service) IndexCollection(ctx context.Context, collection *models.Collection) error {
+	// First, delete any existing entry
+	err := svc.DeleteFromCollectionIndex(ctx, collection.ID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	description := ""
+	if collection.Description != nil {
+		description = *collection.Description
+	}
+
+	_, err = svc.db.ExecContext(ctx,
+		`INSERT INTO collections_fts (collection_id, library_id, name, description)
+		 VALUES (?, ?, ?, ?)`,
+		collection.ID, collection.LibraryID, collection.Name, description,
+	)
+	return errors.WithStack(err)
+}
+
+// DeleteFromCollectionIndex removes a collection from the FTS index.
+func (svc *Service) DeleteFromCollectionIndex(ctx context.Context, collectionID int) error {
+	_, err := svc.db.NewDelete().
+		TableExpr("collections_fts").
+		Where("collection_id = ?", collectionID).
+		Exec(ctx)
+	return errors.WithStack(err)
+}
+
 // ReindexBookByID re-indexes a single book in books_fts using the same SQL
 // pattern as RebuildAllIndexes. Useful when related data changes (e.g., an
 // author's or series' aliases are modified) without a full book model in hand.
@@ -628,7 +799,7 @@ func (svc *Service) ReindexBookByID(ctx context.Context, bookID int) error {
 	}
 
 	_, err = svc.db.ExecContext(ctx, `
-		INSERT INTO books_fts (book_id, library_id, title, filepath, subtitle, authors, filenames, narrators, series_names)
+		INSERT INTO books_fts (book_id, library_id, title, filepath, subtitle, authors, filenames, narrators, series_names, description)
 		SELECT
 			b.id,
 			b.library_id,
@@ -650,7 +821,8 @@ func (svc *Service) ReindexBookByID(ctx context.Context, bookID int) error {
 				SELECT s.name FROM book_series bs JOIN series s ON bs.series_id = s.id WHERE bs.book_id = b.id
 				UNION
 				SELECT sa.name FROM book_series bs JOIN series_aliases sa ON sa.series_id = bs.series_id WHERE bs.book_id = b.id
-			)), '')
+			)), ''),
+			COALESCE(b.description, '')
 		FROM books b
 		WHERE b.id = ?
 	`, bookID)
@@ -681,8 +853,15 @@ func (svc *Service) nameWithAliases(ctx context.Context, table, fkColumn string,
 // RebuildAllIndexes rebuilds all FTS indexes from scratch.
 // This should be called after a scan job completes.
 func (svc *Service) RebuildAllIndexes(ctx context.Context) error {
+	// The content index is expensive to rebuild, so it's kept and only
+	// pruned of files that no longer exist.
+	_, err := svc.db.ExecContext(ctx, "DELETE FROM book_content_fts WHERE rowid NOT IN (SELECT id FROM files)")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
 	// Clear all indexes
-	_, err := svc.db.ExecContext(ctx, "DELETE FROM books_fts")
+	_, err = svc.db.ExecContext(ctx, "DELETE FROM books_fts")
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -706,10 +885,14 @@ func (svc *Service) RebuildAllIndexes(ctx context.Context) error {
 	if err != nil {
 		return errors.WithStack(err)
 	}
+	_, err = svc.db.ExecContext(ctx, "DELETE FROM collections_fts")
+	if err != nil {
+		return errors.WithStack(err)
+	}
 
 	// Rebuild books index (includes person and series aliases in authors/narrators/series_names)
 	_, err = svc.db.ExecContext(ctx, `
-		INSERT INTO books_fts (book_id, library_id, title, filepath, subtitle, authors, filenames, narrators, series_names)
+		INSERT INTO books_fts (book_id, library_id, title, filepath, subtitle, authors, filenames, narrators, series_names, description)
 		SELECT
 			b.id,
 			b.library_id,
@@ -731,8 +914,10 @@ func (svc *Service) RebuildAllIndexes(ctx context.Context) error {
 				SELECT s.name FROM book_series bs JOIN series s ON bs.series_id = s.id WHERE bs.book_id = b.id
 				UNION
 				SELECT sa.name FROM book_series bs JOIN series_aliases sa ON sa.series_id = bs.series_id WHERE bs.book_id = b.id
-			)), '')
+			)), ''),
+			COALESCE(b.description, '')
 		FROM books b
+		WHERE b.hidden = FALSE
 	`)
 	if err != nil {
 		return errors.WithStack(err)
@@ -799,5 +984,15 @@ func (svc *Service) RebuildAllIndexes(ctx context.Context) error {
 		return errors.WithStack(err)
 	}
 
+	// Rebuild collections index
+	_, err = svc.db.ExecContext(ctx, `
+		INSERT INTO collections_fts (collection_id, library_id, name, description)
+		SELECT id, library_id, name, COALESCE(description, '')
+		FROM collections
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
 	return nil
 }
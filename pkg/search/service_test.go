@@ -776,6 +776,45 @@ func TestIndexBook_ExcludesGenreAndTagAliases(t *testing.T) {
 	require.Empty(t, results.Books, "Genre aliases should not be in books_fts")
 }
 
+func TestIndexBook_RemovesHiddenBooks(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	library := &models.Library{Name: "Lib", CoverAspectRatio: "book"}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+
+	book := &models.Book{
+		LibraryID: library.ID, Filepath: "/test/sample", Title: "Sample Chapter",
+		TitleSource: "file", SortTitle: "Sample Chapter", SortTitleSource: "file", AuthorSource: "file",
+	}
+	_, err = db.NewInsert().Model(book).Exec(ctx)
+	require.NoError(t, err)
+
+	svc := NewService(db)
+	require.NoError(t, svc.IndexBook(ctx, book))
+
+	results, err := svc.GlobalSearch(ctx, library.ID, "Sample")
+	require.NoError(t, err)
+	require.Len(t, results.Books, 1)
+
+	book.Hidden = true
+	_, err = db.NewUpdate().Model(book).Column("hidden").WherePK().Exec(ctx)
+	require.NoError(t, err)
+	require.NoError(t, svc.IndexBook(ctx, book))
+
+	results, err = svc.GlobalSearch(ctx, library.ID, "Sample")
+	require.NoError(t, err)
+	require.Empty(t, results.Books, "hidden books should be removed from books_fts")
+
+	// A full rebuild must also leave hidden books out.
+	require.NoError(t, svc.RebuildAllIndexes(ctx))
+	results, err = svc.GlobalSearch(ctx, library.ID, "Sample")
+	require.NoError(t, err)
+	require.Empty(t, results.Books, "hidden books should not be re-added by a rebuild")
+}
+
 func TestIndexPublisher_IncludesAliases(t *testing.T) {
 	t.Parallel()
 	db := setupTestDB(t)
@@ -896,3 +935,155 @@ func TestRebuildAllIndexes_IncludesAliases(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, pubCount, "RebuildAllIndexes should include publisher aliases")
 }
+
+func TestGlobalSearch_ReturnsHighlights(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	library := &models.Library{
+		Name:             "Test Library",
+		CoverAspectRatio: "book",
+	}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+
+	book := &models.Book{
+		LibraryID:       library.ID,
+		Filepath:        "/test/night-circus",
+		Title:           "The Night Circus",
+		TitleSource:     "file",
+		SortTitle:       "Night Circus, The",
+		SortTitleSource: "file",
+		AuthorSource:    "file",
+	}
+	_, err = db.NewInsert().Model(book).Exec(ctx)
+	require.NoError(t, err)
+
+	svc := NewService(db).WithHighlight("[", "]")
+	require.NoError(t, svc.IndexBook(ctx, book))
+
+	results, err := svc.GlobalSearch(ctx, library.ID, "circ")
+	require.NoError(t, err)
+	require.Len(t, results.Books, 1)
+	require.NotNil(t, results.Books[0].HighlightedTitle)
+	require.Equal(t, "The Night [Circus]", *results.Books[0].HighlightedTitle)
+	require.NotNil(t, results.Books[0].Snippet)
+	require.Contains(t, *results.Books[0].Snippet, "[")
+}
+
+func TestSearchContent(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	library := &models.Library{
+		Name:             "Test Library",
+		CoverAspectRatio: "book",
+	}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+
+	book := &models.Book{
+		LibraryID:       library.ID,
+		Filepath:        "/test/moby-dick",
+		Title:           "Moby-Dick",
+		TitleSource:     "file",
+		SortTitle:       "Moby-Dick",
+		SortTitleSource: "file",
+		AuthorSource:    "file",
+	}
+	_, err = db.NewInsert().Model(book).Exec(ctx)
+	require.NoError(t, err)
+
+	file := &models.File{
+		LibraryID:     library.ID,
+		BookID:        book.ID,
+		Filepath:      "/test/moby-dick/moby-dick.epub",
+		FileType:      models.FileTypeEPUB,
+		FileRole:      models.FileRoleMain,
+		FilesizeBytes: 1000,
+	}
+	_, err = db.NewInsert().Model(file).Exec(ctx)
+	require.NoError(t, err)
+
+	svc := NewService(db).WithHighlight("[", "]")
+	require.NoError(t, svc.IndexFileContent(ctx, file.ID, "hash-1", "Call me Ishmael. Some years ago, never mind how long precisely."))
+
+	hash, err := svc.ContentHash(ctx, file.ID)
+	require.NoError(t, err)
+	require.Equal(t, "hash-1", hash)
+
+	results, err := svc.SearchContent(ctx, library.ID, "some years ago", 5)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, book.ID, results[0].ID)
+	require.Equal(t, []string{models.FileTypeEPUB}, results[0].FileTypes)
+	require.NotNil(t, results[0].Snippet)
+	require.Contains(t, *results[0].Snippet, "[Some")
+	require.Contains(t, *results[0].Snippet, "Ishmael")
+
+	// Quotes are matched as a phrase, not as separate words
+	results, err = svc.SearchContent(ctx, library.ID, "ago some", 5)
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	// Re-indexing replaces the previous text
+	require.NoError(t, svc.IndexFileContent(ctx, file.ID, "hash-2", "It was the best of times."))
+	results, err = svc.SearchContent(ctx, library.ID, "Ishmael", 5)
+	require.NoError(t, err)
+	require.Empty(t, results)
+	hash, err = svc.ContentHash(ctx, file.ID)
+	require.NoError(t, err)
+	require.Equal(t, "hash-2", hash)
+
+	// Rebuilding prunes the text of deleted files
+	_, err = db.NewDelete().Model(file).WherePK().Exec(ctx)
+	require.NoError(t, err)
+	require.NoError(t, svc.RebuildAllIndexes(ctx))
+	hash, err = svc.ContentHash(ctx, file.ID)
+	require.NoError(t, err)
+	require.Empty(t, hash)
+}
+
+func TestGlobalSearch_EscapesHighlightsAndMatchesDescription(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	library := &models.Library{
+		Name:             "Test Library",
+		CoverAspectRatio: "book",
+	}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+
+	description := "A duel between two illusionists."
+	book := &models.Book{
+		LibraryID:       library.ID,
+		Filepath:        "/test/night-circus",
+		Title:           "The <b>Night</b> Circus",
+		TitleSource:     "file",
+		SortTitle:       "Night Circus, The",
+		SortTitleSource: "file",
+		AuthorSource:    "file",
+		Description:     &description,
+	}
+	_, err = db.NewInsert().Model(book).Exec(ctx)
+	require.NoError(t, err)
+
+	svc := NewService(db)
+	require.NoError(t, svc.IndexBook(ctx, book))
+
+	results, err := svc.GlobalSearch(ctx, library.ID, "night")
+	require.NoError(t, err)
+	require.Len(t, results.Books, 1)
+	require.NotNil(t, results.Books[0].HighlightedTitle)
+	require.Equal(t, "The &lt;b&gt;<mark>Night</mark>&lt;/b&gt; Circus", *results.Books[0].HighlightedTitle)
+
+	results, err = svc.GlobalSearch(ctx, library.ID, "illusionists")
+	require.NoError(t, err)
+	require.Len(t, results.Books, 1)
+	require.NotNil(t, results.Books[0].Snippet)
+	require.Contains(t, *results.Books[0].Snippet, "<mark>illusionists</mark>")
+}
@@ -4,8 +4,17 @@ package search
 type GlobalSearchQuery struct {
 	Query     string `query:"q" json:"q" validate:"required,min=1,max=100"`
 	LibraryID int    `query:"library_id" json:"library_id" validate:"required,min=1"`
+	// Scope is "metadata" (the default) to search book, series, and person
+	// metadata, or "content" to search the indexed body text of books.
+	Scope string `query:"scope" json:"scope,omitempty" validate:"omitempty,oneof=metadata content" tstype:"SearchScope"`
 }
 
+const (
+	//tygo:emit export type SearchScope = typeof SearchScopeMetadata | typeof SearchScopeContent;
+	SearchScopeMetadata = "metadata"
+	SearchScopeContent  = "content"
+)
+
 // GlobalSearchResponse represents the response from global search.
 // Returns up to 5 results per resource type for popover display.
 type GlobalSearchResponse struct {
@@ -22,6 +31,11 @@ type BookSearchResult struct {
 	Authors   string   `json:"authors"`    // Comma-separated author names
 	FileTypes []string `json:"file_types"` // Unique file types for this book (e.g., ["epub", "m4b"])
 	LibraryID int      `json:"library_id"`
+
+	// Set for full-text matches only (not identifier matches). Matched terms
+	// are wrapped in the configured highlight markers.
+	HighlightedTitle *string `json:"highlighted_title"`
+	Snippet          *string `json:"snippet"` // Short excerpt from the best-matching column
 }
 
 // SeriesSearchResult represents a series in search results.
@@ -0,0 +1,153 @@
+package series
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+// seriesNumberEntry is a book's membership in a series, with the book's title.
+type seriesNumberEntry struct {
+	ID               int      `bun:"id"`
+	BookID           int      `bun:"book_id"`
+	Title            string   `bun:"title"`
+	SeriesNumber     *float64 `bun:"series_number"`
+	SeriesNumberUnit *string  `bun:"series_number_unit"`
+}
+
+func (svc *Service) listSeriesNumberEntries(ctx context.Context, db bun.IDB, seriesID int) ([]seriesNumberEntry, error) {
+	var entries []seriesNumberEntry
+	err := db.NewSelect().
+		TableExpr("book_series AS bs").
+		ColumnExpr("bs.id, bs.book_id, b.title, bs.series_number, bs.series_number_unit").
+		Join("JOIN books AS b ON b.id = bs.book_id").
+		Where("bs.series_id = ?", seriesID).
+		Where("bs.series_number IS NOT NULL").
+		OrderExpr("bs.series_number ASC, b.id ASC").
+		Scan(ctx, &entries)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return entries, nil
+}
+
+// findSeriesNumberConflicts groups entries that share a series number and
+// unit. Entries must be ordered by series number.
+func findSeriesNumberConflicts(entries []seriesNumberEntry) [][]seriesNumberEntry {
+	groups := map[string][]seriesNumberEntry{}
+	var keys []string
+	for _, e := range entries {
+		unit := ""
+		if e.SeriesNumberUnit != nil {
+			unit = *e.SeriesNumberUnit
+		}
+		key := strconv.FormatFloat(*e.SeriesNumber, 'f', -1, 64) + "|" + unit
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], e)
+	}
+
+	var conflicts [][]seriesNumberEntry
+	for _, key := range keys {
+		if len(groups[key]) > 1 {
+			conflicts = append(conflicts, groups[key])
+		}
+	}
+	return conflicts
+}
+
+// ListSeriesNumberConflicts returns the series numbers that more than one
+// book in the series claims, such as two books both marked #2. A volume and
+// a chapter with the same number don't conflict, and ranges are compared by
+// their first number only.
+func (svc *Service) ListSeriesNumberConflicts(ctx context.Context, seriesID int) ([]NumberConflict, error) {
+	entries, err := svc.listSeriesNumberEntries(ctx, svc.db, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []NumberConflict{}
+	for _, group := range findSeriesNumberConflicts(entries) {
+		conflict := NumberConflict{
+			SeriesNumber:     *group[0].SeriesNumber,
+			SeriesNumberUnit: group[0].SeriesNumberUnit,
+		}
+		for _, e := range group {
+			conflict.Books = append(conflict.Books, ConflictBook{BookID: e.BookID, Title: e.Title})
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts, nil
+}
+
+// ResolveSeriesNumberConflicts renumbers books that share a series number by
+// appending a fractional suffix, so two books marked #2 become #2.1 and #2.2
+// (and two marked #2.5 become #2.51 and #2.52). Books are numbered in the
+// order they were added. A group is left as-is when it has more than nine
+// books or a suffixed number is already taken by another book in the series.
+// It returns the IDs of the books that were renumbered.
+func (svc *Service) ResolveSeriesNumberConflicts(ctx context.Context, seriesID int) ([]int, error) {
+	var bookIDs []int
+	err := svc.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		entries, err := svc.listSeriesNumberEntries(ctx, tx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		taken := make(map[float64]bool, len(entries))
+		for _, e := range entries {
+			taken[*e.SeriesNumber] = true
+		}
+
+		for _, group := range findSeriesNumberConflicts(entries) {
+			numbers := fractionalSuffixNumbers(*group[0].SeriesNumber, len(group), taken)
+			if numbers == nil {
+				continue
+			}
+			for i, e := range group {
+				_, err := tx.NewUpdate().
+					Table("book_series").
+					Set("series_number = ?", numbers[i]).
+					Where("id = ?", e.ID).
+					Exec(ctx)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				taken[numbers[i]] = true
+				bookIDs = append(bookIDs, e.BookID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bookIDs, nil
+}
+
+// fractionalSuffixNumbers returns count numbers made by appending the digits
+// 1, 2, ... to number's decimal form, or nil when there aren't enough digits
+// or one of the results is in taken.
+func fractionalSuffixNumbers(number float64, count int, taken map[float64]bool) []float64 {
+	if count > 9 {
+		return nil
+	}
+	prefix := strconv.FormatFloat(number, 'f', -1, 64)
+	if !strings.Contains(prefix, ".") {
+		prefix += "."
+	}
+
+	numbers := make([]float64, 0, count)
+	for digit := 1; digit <= count; digit++ {
+		n, err := strconv.ParseFloat(prefix+strconv.Itoa(digit), 64)
+		if err != nil || taken[n] {
+			return nil
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
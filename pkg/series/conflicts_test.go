@@ -0,0 +1,100 @@
+package series
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFractionalSuffixNumbers(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []float64{2.1, 2.2}, fractionalSuffixNumbers(2, 2, nil))
+	assert.Equal(t, []float64{2.51, 2.52, 2.53}, fractionalSuffixNumbers(2.5, 3, nil))
+	assert.Nil(t, fractionalSuffixNumbers(2, 2, map[float64]bool{2.2: true}), "taken numbers leave the group alone")
+	assert.Nil(t, fractionalSuffixNumbers(2, 10, nil), "more than nine books can't be suffixed")
+}
+
+func TestResolveSeriesNumberConflicts(t *testing.T) {
+	t.Parallel()
+
+	db := setupSeriesTestDB(t)
+	ctx := context.Background()
+
+	library := &models.Library{
+		Name:                     "Test Library",
+		CoverAspectRatio:         "book",
+		DownloadFormatPreference: models.DownloadFormatOriginal,
+	}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+
+	series := &models.Series{
+		LibraryID:      library.ID,
+		Name:           "Mistborn",
+		NameSource:     models.DataSourceManual,
+		SortName:       "Mistborn",
+		SortNameSource: models.DataSourceFilepath,
+	}
+	_, err = db.NewInsert().Model(series).Exec(ctx)
+	require.NoError(t, err)
+
+	chapter := models.SeriesNumberUnitChapter
+	addBook := func(title string, number float64, unit *string) int {
+		book := &models.Book{
+			LibraryID:       library.ID,
+			Title:           title,
+			TitleSource:     models.DataSourceManual,
+			SortTitle:       title,
+			SortTitleSource: models.DataSourceFilepath,
+			AuthorSource:    models.DataSourceFilepath,
+			Filepath:        t.TempDir(),
+		}
+		_, err := db.NewInsert().Model(book).Exec(ctx)
+		require.NoError(t, err)
+		_, err = db.NewInsert().Model(&models.BookSeries{
+			BookID:           book.ID,
+			SeriesID:         series.ID,
+			SeriesNumber:     &number,
+			SeriesNumberUnit: unit,
+			SortOrder:        1,
+		}).Exec(ctx)
+		require.NoError(t, err)
+		return book.ID
+	}
+
+	addBook("The Final Empire", 1, nil)
+	wellID := addBook("The Well of Ascension", 2, nil)
+	heroID := addBook("The Hero of Ages", 2, nil)
+	// A chapter 2 doesn't conflict with volume 2.
+	addBook("Chapter Two", 2, &chapter)
+
+	svc := NewService(db)
+	conflicts, err := svc.ListSeriesNumberConflicts(ctx, series.ID)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	assert.InDelta(t, 2, conflicts[0].SeriesNumber, 0)
+	assert.Equal(t, []ConflictBook{
+		{BookID: wellID, Title: "The Well of Ascension"},
+		{BookID: heroID, Title: "The Hero of Ages"},
+	}, conflicts[0].Books)
+
+	bookIDs, err := svc.ResolveSeriesNumberConflicts(ctx, series.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{wellID, heroID}, bookIDs)
+
+	numberFor := func(bookID int) float64 {
+		bs := &models.BookSeries{}
+		require.NoError(t, db.NewSelect().Model(bs).Where("book_id = ?", bookID).Scan(ctx))
+		return *bs.SeriesNumber
+	}
+	assert.InDelta(t, 2.1, numberFor(wellID), 0)
+	assert.InDelta(t, 2.2, numberFor(heroID), 0)
+
+	conflicts, err = svc.ListSeriesNumberConflicts(ctx, series.ID)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
@@ -1,7 +1,6 @@
 package series
 
 import (
-	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -18,6 +17,7 @@ import (
 	"github.com/shishobooks/shisho/pkg/libraries"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/shishobooks/shisho/pkg/search"
+	"github.com/shishobooks/shisho/pkg/sidecar"
 	"github.com/shishobooks/shisho/pkg/sortname"
 )
 
@@ -152,8 +152,10 @@ func (h *handler) update(c echo.Context) error {
 
 	// Keep track of what's been changed
 	opts := UpdateSeriesOptions{Columns: []string{}}
+	nameChanged := false
 
 	if params.Name != nil && *params.Name != series.Name {
+		nameChanged = true
 		series.Name = *params.Name
 		series.NameSource = models.DataSourceManual
 		opts.Columns = append(opts.Columns, "name", "name_source")
@@ -226,6 +228,16 @@ func (h *handler) update(c echo.Context) error {
 		}
 	}
 
+	// The series name is part of each book's metadata hash
+	if nameChanged {
+		bookIDs, err := h.seriesService.GetSeriesBookIDs(ctx, id)
+		if err != nil {
+			log.Warn("failed to get series book IDs for metadata hash refresh", logger.Data{"series_id": id, "error": err.Error()})
+		} else if err := h.bookService.RefreshMetadataHashes(ctx, bookIDs); err != nil {
+			log.Warn("failed to update book metadata hashes after series rename", logger.Data{"series_id": id, "error": err.Error()})
+		}
+	}
+
 	// Get book count
 	bookCount, _ := h.seriesService.GetSeriesBookCount(ctx, id)
 	aliasList, _ := h.aliasService.ListAliases(ctx, aliases.SeriesConfig, id)
@@ -334,34 +346,14 @@ func (h *handler) seriesCover(c echo.Context) error {
 	if err != nil {
 		return errcodes.NotFound("Series cover")
 	}
-	modTime := coverStat.ModTime().UTC().Truncate(time.Second)
-
-	// ETag bakes in the selected file's identity, not just mtime, so it changes
-	// when the series' first book switches to a different file — even if the new
-	// cover happens to have an older mtime than the previous first book's cover.
-	etag := fmt.Sprintf(`"%d-%d"`, coverFile.ID, modTime.Unix())
 
-	c.Response().Header().Set("Cache-Control", covers.CacheControlImmutable)
-	c.Response().Header().Set("ETag", etag)
-
-	// Conditional GET uses ETag only. If-Modified-Since is intentionally not
-	// honored: file mtime doesn't capture changes in which file is selected,
-	// so IMS-based revalidation would serve stale bytes when the first book
-	// switches to one whose cover file has an older mtime.
-	if inm := c.Request().Header.Get("If-None-Match"); inm != "" && inm == etag {
-		c.Response().WriteHeader(http.StatusNotModified)
-		return nil
-	}
-
-	fh, err := os.Open(coverImagePath)
-	if err != nil {
-		return errcodes.NotFound("Series cover")
-	}
-	defer fh.Close()
-
-	// Zero modtime suppresses Last-Modified and IMS handling inside ServeContent.
-	http.ServeContent(c.Response(), c.Request(), filepath.Base(coverImagePath), time.Time{}, fh)
-	return nil
+	// The ETag bakes in the selected file's identity, not just the cover's
+	// content, so it changes when the series' first book switches to a
+	// different file. If-Modified-Since is intentionally not honored: file
+	// mtime doesn't capture changes in which file is selected, so IMS-based
+	// revalidation would serve stale bytes when the first book switches to
+	// one whose cover file has an older mtime.
+	return covers.ServeFile(c, coverFile.ID, coverImagePath, coverStat, covers.CacheControlImmutable, time.Time{})
 }
 
 func (h *handler) merge(c echo.Context) error {
@@ -411,6 +403,9 @@ func (h *handler) merge(c echo.Context) error {
 			log.Warn("failed to update book search index after series merge", logger.Data{"book_id": bookID, "error": err.Error()})
 		}
 	}
+	if err := h.bookService.RefreshMetadataHashes(ctx, movedBookIDs); err != nil {
+		log.Warn("failed to update book metadata hashes after series merge", logger.Data{"series_id": id, "error": err.Error()})
+	}
 
 	// Re-index the target series since it now has more books
 	series, err = h.seriesService.RetrieveSeries(ctx, RetrieveSeriesOptions{
@@ -456,8 +451,11 @@ func (h *handler) deleteSeries(c echo.Context) error {
 
 	// Removing the join rows can flip the books' Reviewed completeness state
 	// (e.g. when `series` is a required field) and stales their books_fts
-	// rows, which still reference the deleted series name. Recompute review
-	// state and re-index each affected book.
+	// rows and metadata hashes, which still reference the deleted series.
+	// Recompute review state, hash and re-index each affected book.
+	if err := h.bookService.RefreshMetadataHashes(ctx, affectedBookIDs); err != nil {
+		log.Warn("failed to update book metadata hashes after series delete", logger.Data{"series_id": id, "error": err.Error()})
+	}
 	for _, bookID := range affectedBookIDs {
 		h.bookService.RecomputeReviewedForBook(ctx, bookID)
 
@@ -473,3 +471,98 @@ func (h *handler) deleteSeries(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// conflicts lists the series numbers that more than one book in the series
+// claims.
+func (h *handler) conflicts(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Series")
+	}
+
+	series, err := h.seriesService.RetrieveSeries(ctx, RetrieveSeriesOptions{
+		ID: &id,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Check library access
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(series.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	conflicts, err := h.seriesService.ListSeriesNumberConflicts(ctx, id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, ConflictsResponse{Conflicts: conflicts}))
+}
+
+// resolveConflicts gives books that share a series number fractional numbers
+// (2.1, 2.2, ...) and returns the conflicts that couldn't be resolved.
+func (h *handler) resolveConflicts(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return errcodes.NotFound("Series")
+	}
+
+	series, err := h.seriesService.RetrieveSeries(ctx, RetrieveSeriesOptions{
+		ID: &id,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Check library access
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(series.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	bookIDs, err := h.seriesService.ResolveSeriesNumberConflicts(ctx, id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Renumbered books get fresh sidecars so a rescan doesn't bring the old
+	// numbers back.
+	log := logger.FromContext(ctx)
+	for _, bookID := range bookIDs {
+		book, err := h.bookService.RetrieveBook(ctx, books.RetrieveBookOptions{ID: &bookID})
+		if err != nil {
+			log.Warn("failed to load renumbered book", logger.Data{"book_id": bookID, "error": err.Error()})
+			continue
+		}
+		if !book.Staged {
+			if err := sidecar.WriteBookSidecarFromModel(book); err != nil {
+				log.Warn("failed to write book sidecar", logger.Data{"book_id": bookID, "error": err.Error()})
+			}
+		}
+		if err := h.bookService.RefreshMetadataHash(ctx, book); err != nil {
+			log.Warn("failed to update book metadata hash", logger.Data{"book_id": bookID, "error": err.Error()})
+		}
+		if err := h.searchService.ReindexBookByID(ctx, bookID); err != nil {
+			log.Warn("failed to update book search index after renumbering", logger.Data{"book_id": bookID, "error": err.Error()})
+		}
+	}
+
+	conflicts, err := h.seriesService.ListSeriesNumberConflicts(ctx, id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if bookIDs == nil {
+		bookIDs = []int{}
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, ResolveConflictsResponse{
+		RenumberedBookIDs: bookIDs,
+		Conflicts:         conflicts,
+	}))
+}
@@ -35,4 +35,6 @@ func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, authMiddleware *auth.Mid
 	g.PATCH("/:id", h.update, authMiddleware.RequirePermission(models.ResourceSeries, models.OperationWrite))
 	g.DELETE("/:id", h.deleteSeries, authMiddleware.RequirePermission(models.ResourceSeries, models.OperationWrite))
 	g.POST("/:id/merge", h.merge, authMiddleware.RequirePermission(models.ResourceSeries, models.OperationWrite))
+	g.GET("/:id/conflicts", h.conflicts)
+	g.POST("/:id/conflicts/resolve", h.resolveConflicts, authMiddleware.RequirePermission(models.ResourceSeries, models.OperationWrite))
 }
@@ -101,8 +101,7 @@ func (svc *Service) RetrieveSeriesByID(ctx context.Context, id int) (*models.Ser
 
 // FindOrCreateSeries finds an existing series or creates a new one (case-insensitive match).
 func (svc *Service) FindOrCreateSeries(ctx context.Context, name string, libraryID int, nameSource string) (*models.Series, error) {
-	// Normalize the name by trimming whitespace
-	name = strings.TrimSpace(name)
+	name = aliases.NormalizeName(name)
 	if name == "" {
 		return nil, errors.New("series name cannot be empty")
 	}
@@ -143,25 +142,37 @@ func (svc *Service) FindOrCreateSeries(ctx context.Context, name string, library
 		return s, nil
 	}
 
-	// Create new series
+	// Create new series. Concurrent scans can race to create the same
+	// series, so the insert is a no-op when the name is already taken and the
+	// row that won the race is returned instead.
+	now := time.Now()
 	series = &models.Series{
+		CreatedAt:      now,
+		UpdatedAt:      now,
 		LibraryID:      libraryID,
 		Name:           name,
 		NameSource:     nameSource,
 		SortName:       sortname.ForTitle(name),
 		SortNameSource: models.DataSourceFilepath,
 	}
-	err = svc.CreateSeries(ctx, series)
+	res, err := svc.db.
+		NewInsert().
+		Model(series).
+		On("CONFLICT DO NOTHING").
+		Returning("*").
+		Exec(ctx)
 	if err != nil {
-		// Handle race condition: if another goroutine created the same series
-		// between our retrieve and create, retry the retrieve
-		if strings.Contains(err.Error(), "UNIQUE constraint") {
-			return svc.RetrieveSeries(ctx, RetrieveSeriesOptions{
-				Name:      &name,
-				LibraryID: &libraryID,
-			})
-		}
-		return nil, err
+		return nil, errors.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if n == 0 {
+		return svc.RetrieveSeries(ctx, RetrieveSeriesOptions{
+			Name:      &name,
+			LibraryID: &libraryID,
+		})
 	}
 	return series, nil
 }
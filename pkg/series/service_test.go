@@ -557,3 +557,30 @@ func TestFindOrCreateSeries_NoMatch_CreatesNew(t *testing.T) {
 	assert.Equal(t, "The Wheel of Time", found.Name)
 	assert.Equal(t, library.ID, found.LibraryID)
 }
+
+func TestFindOrCreateSeries_NormalizesWhitespace(t *testing.T) {
+	t.Parallel()
+	db := setupSeriesTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	library := &models.Library{
+		Name:                     "Test Library",
+		CoverAspectRatio:         "book",
+		DownloadFormatPreference: models.DownloadFormatOriginal,
+	}
+	_, err := db.NewInsert().Model(library).Exec(ctx)
+	require.NoError(t, err)
+
+	first, err := svc.FindOrCreateSeries(ctx, "The  Stormlight\tArchive", library.ID, models.DataSourceFilepath)
+	require.NoError(t, err)
+	assert.Equal(t, "The Stormlight Archive", first.Name)
+
+	second, err := svc.FindOrCreateSeries(ctx, "the stormlight archive", library.ID, models.DataSourceFilepath)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+
+	count, err := db.NewSelect().Model((*models.Series)(nil)).Where("library_id = ?", library.ID).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
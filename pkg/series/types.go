@@ -55,3 +55,29 @@ type UpdateSeriesPayload struct {
 type MergeSeriesPayload struct {
 	SourceID int `json:"source_id" validate:"required,min=1"`
 }
+
+// NumberConflict is a series number that more than one book in a series
+// claims.
+type NumberConflict struct {
+	SeriesNumber     float64        `json:"series_number"`
+	SeriesNumberUnit *string        `json:"series_number_unit,omitempty" tstype:"SeriesNumberUnit"`
+	Books            []ConflictBook `json:"books"`
+}
+
+// ConflictBook is one of the books claiming a conflicting series number.
+type ConflictBook struct {
+	BookID int    `json:"book_id"`
+	Title  string `json:"title"`
+}
+
+// ConflictsResponse is the response of GET /series/:id/conflicts.
+type ConflictsResponse struct {
+	Conflicts []NumberConflict `json:"conflicts"`
+}
+
+// ResolveConflictsResponse is the response of
+// POST /series/:id/conflicts/resolve.
+type ResolveConflictsResponse struct {
+	RenumberedBookIDs []int            `json:"renumbered_book_ids"`
+	Conflicts         []NumberConflict `json:"conflicts"` // Conflicts that were left as-is
+}
@@ -7,31 +7,41 @@ import (
 	"github.com/pkg/errors"
 	"github.com/robinjoseph08/golib/logger"
 	"github.com/shishobooks/shisho/pkg/books"
+	"github.com/shishobooks/shisho/pkg/config"
 	"github.com/shishobooks/shisho/pkg/fileutils"
 	"github.com/shishobooks/shisho/pkg/libraries"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/shishobooks/shisho/pkg/people"
+	"github.com/shishobooks/shisho/pkg/plugins"
 	"github.com/uptrace/bun"
 )
 
 // fileOrganizer implements people.FileOrganizer interface.
 // It bridges the people package to the books and libraries packages.
 type fileOrganizer struct {
-	db             *bun.DB
-	bookService    *books.Service
-	libraryService *libraries.Service
+	db              *bun.DB
+	bookService     *books.Service
+	libraryService  *libraries.Service
+	sanitizeOptions fileutils.SanitizeOptions
 }
 
-// NewFileOrganizer creates a new FileOrganizer implementation.
-func NewFileOrganizer(db *bun.DB) people.FileOrganizer {
+// NewFileOrganizer creates a new FileOrganizer implementation. pm may be nil,
+// in which case organizer plugins aren't consulted.
+func NewFileOrganizer(db *bun.DB, cfg *config.Config, pm *plugins.Manager) people.FileOrganizer {
+	sanitizeOptions := fileutils.SanitizeOptions{Mode: cfg.OrganizeFilenameMode}
+	bookService := books.NewService(db).WithFilenameSanitizer(sanitizeOptions)
+	if pm != nil {
+		bookService.WithPathOrganizer(pm)
+	}
 	return &fileOrganizer{
-		db:             db,
-		bookService:    books.NewService(db),
-		libraryService: libraries.NewService(db),
+		db:              db,
+		bookService:     bookService,
+		libraryService:  libraries.NewService(db),
+		sanitizeOptions: sanitizeOptions,
 	}
 }
 
-// GetLibraryOrganizeSetting checks if a library has OrganizeFileStructure enabled.
+// GetLibraryOrganizeSetting checks if a library organizes its files or folders.
 func (fo *fileOrganizer) GetLibraryOrganizeSetting(ctx context.Context, libraryID int) (bool, error) {
 	library, err := fo.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{
 		ID: &libraryID,
@@ -39,7 +49,7 @@ func (fo *fileOrganizer) GetLibraryOrganizeSetting(ctx context.Context, libraryI
 	if err != nil {
 		return false, errors.WithStack(err)
 	}
-	return library.OrganizeFileStructure, nil
+	return library.OrganizeEnabled(), nil
 }
 
 // OrganizeBookFiles reorganizes files for a book with the given ID.
@@ -76,7 +86,7 @@ func (fo *fileOrganizer) RenameNarratedFile(ctx context.Context, fileID int) (st
 		return file.Filepath, nil
 	}
 
-	// Get the library to check OrganizeFileStructure
+	// Get the library to check OrganizeFiles
 	library, err := fo.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{
 		ID: &file.LibraryID,
 	})
@@ -84,7 +94,7 @@ func (fo *fileOrganizer) RenameNarratedFile(ctx context.Context, fileID int) (st
 		return file.Filepath, errors.WithStack(err)
 	}
 
-	if !library.OrganizeFileStructure {
+	if !library.OrganizeFiles {
 		return file.Filepath, nil
 	}
 
@@ -124,14 +134,17 @@ func (fo *fileOrganizer) RenameNarratedFile(ctx context.Context, fileID int) (st
 		NarratorNames: narratorNames,
 		Title:         title,
 		FileType:      file.FileType,
+		Sanitize:      fo.sanitizeOptions,
 	}
 
 	// Rename the file
-	// Use RenameOrganizedFileForSupplement to avoid renaming the book sidecar.
-	// Narrator changes are file-level, not book-level.
-	newPath, err := fileutils.RenameOrganizedFileOnly(file.Filepath, organizeOpts)
+	// RenameOrganizedFile avoids renaming the book sidecar, since narrator
+	// changes are file-level, not book-level. When an organizer plugin picks
+	// the path instead, it saves the new path itself.
+	oldPath := file.Filepath
+	newPath, err := fo.bookService.RenameOrganizedFile(ctx, library, book, file, organizeOpts)
 	if err != nil {
-		return file.Filepath, errors.WithStack(err)
+		return oldPath, errors.WithStack(err)
 	}
 
 	if newPath != file.Filepath {
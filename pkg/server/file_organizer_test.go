@@ -69,6 +69,8 @@ func newTestContext(t *testing.T) *testContext {
 	cfg := &config.Config{
 		WorkerProcesses:           1,
 		SupplementExcludePatterns: []string{".*", ".DS_Store", "Thumbs.db", "desktop.ini"},
+		// FFmpeg-generated test M4Bs only carry narrators in ©cmp.
+		NarratorAtomFallback: []string{"composer"},
 	}
 	w := worker.New(cfg, db, nil, nil, nil)
 
@@ -100,7 +102,7 @@ func newTestContext(t *testing.T) *testContext {
 	return tc
 }
 
-// createLibraryWithOrganize creates a test library with OrganizeFileStructure enabled.
+// createLibraryWithOrganize creates a test library with organizing enabled.
 func (tc *testContext) createLibraryWithOrganize(paths []string) *models.Library {
 	tc.t.Helper()
 
@@ -112,10 +114,11 @@ func (tc *testContext) createLibraryWithOrganize(paths []string) *models.Library
 	}
 
 	library := &models.Library{
-		Name:                  "Test Library",
-		OrganizeFileStructure: true,
-		CoverAspectRatio:      "book",
-		LibraryPaths:          libraryPaths,
+		Name:             "Test Library",
+		OrganizeFiles:    true,
+		OrganizeFolders:  true,
+		CoverAspectRatio: "book",
+		LibraryPaths:     libraryPaths,
 	}
 
 	err := tc.libraryService.CreateLibrary(tc.ctx, library)
@@ -234,15 +237,16 @@ func TestFileOrganizer_OrganizeBookFiles_DisabledLibrary(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
-	// Create a temp library directory with OrganizeFileStructure DISABLED
+	// Create a temp library directory with organizing disabled
 	libraryPath := testgen.TempLibraryDir(t)
 
 	libraryPaths := []*models.LibraryPath{{Filepath: libraryPath}}
 	library := &models.Library{
-		Name:                  "Test Library",
-		OrganizeFileStructure: false, // Disabled
-		CoverAspectRatio:      "book",
-		LibraryPaths:          libraryPaths,
+		Name:             "Test Library",
+		OrganizeFiles:    false, // Disabled
+		OrganizeFolders:  false,
+		CoverAspectRatio: "book",
+		LibraryPaths:     libraryPaths,
 	}
 	err := tc.libraryService.CreateLibrary(tc.ctx, library)
 	require.NoError(t, err)
@@ -279,7 +283,7 @@ func TestFileOrganizer_OrganizeBookFiles_DisabledLibrary(t *testing.T) {
 	err = tc.fileOrganizer.OrganizeBookFiles(tc.ctx, book.ID)
 	require.NoError(t, err)
 
-	// Verify files were NOT renamed (because OrganizeFileStructure is disabled)
+	// Verify files were NOT renamed (because organizing is disabled)
 	assert.True(t, testgen.FileExists(originalBookPath), "original book directory should still exist")
 	assert.True(t, testgen.FileExists(originalFilePath), "original file should still exist")
 
@@ -353,7 +357,7 @@ func TestFileOrganizer_GetLibraryOrganizeSetting(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
-	// Create library with OrganizeFileStructure enabled
+	// Create library with organizing enabled
 	libraryPath := testgen.TempLibraryDir(t)
 	library := tc.createLibraryWithOrganize([]string{libraryPath})
 
@@ -361,14 +365,15 @@ func TestFileOrganizer_GetLibraryOrganizeSetting(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, enabled)
 
-	// Create another library with OrganizeFileStructure disabled
+	// Create another library with organizing disabled
 	libraryPath2 := testgen.TempLibraryDir(t)
 	libraryPaths := []*models.LibraryPath{{Filepath: libraryPath2}}
 	library2 := &models.Library{
-		Name:                  "Disabled Library",
-		OrganizeFileStructure: false,
-		CoverAspectRatio:      "book",
-		LibraryPaths:          libraryPaths,
+		Name:             "Disabled Library",
+		OrganizeFiles:    false,
+		OrganizeFolders:  false,
+		CoverAspectRatio: "book",
+		LibraryPaths:     libraryPaths,
 	}
 	err = tc.libraryService.CreateLibrary(tc.ctx, library2)
 	require.NoError(t, err)
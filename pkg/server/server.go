@@ -21,21 +21,27 @@ import (
 	"github.com/shishobooks/shisho/pkg/cache"
 	"github.com/shishobooks/shisho/pkg/cbzpages"
 	"github.com/shishobooks/shisho/pkg/chapters"
+	"github.com/shishobooks/shisho/pkg/collections"
 	"github.com/shishobooks/shisho/pkg/config"
+	"github.com/shishobooks/shisho/pkg/database"
 	"github.com/shishobooks/shisho/pkg/downloadcache"
 	"github.com/shishobooks/shisho/pkg/ereader"
 	"github.com/shishobooks/shisho/pkg/errcodes"
 	"github.com/shishobooks/shisho/pkg/events"
 	"github.com/shishobooks/shisho/pkg/filesystem"
+	"github.com/shishobooks/shisho/pkg/fileutils"
 	"github.com/shishobooks/shisho/pkg/genres"
+	"github.com/shishobooks/shisho/pkg/healthcheck"
 	"github.com/shishobooks/shisho/pkg/joblogs"
 	"github.com/shishobooks/shisho/pkg/jobs"
 	"github.com/shishobooks/shisho/pkg/kobo"
 	"github.com/shishobooks/shisho/pkg/libraries"
 	"github.com/shishobooks/shisho/pkg/lists"
 	"github.com/shishobooks/shisho/pkg/logs"
+	"github.com/shishobooks/shisho/pkg/metrics"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/shishobooks/shisho/pkg/opds"
+	"github.com/shishobooks/shisho/pkg/openlibrary"
 	"github.com/shishobooks/shisho/pkg/pdfpages"
 	"github.com/shishobooks/shisho/pkg/people"
 	"github.com/shishobooks/shisho/pkg/plugins"
@@ -66,6 +72,16 @@ func New(cfg *config.Config, db *bun.DB, w *worker.Worker, pm *plugins.Manager,
 	e.Use(middleware.CORS())
 
 	health.RegisterRoutes(e)
+	healthcheck.RegisterRoutes(e, healthcheck.NewHandler(
+		db.PingContext,
+		func() error { return database.CheckFTS5Support(db) },
+		w,
+		jobs.NewService(db),
+	))
+
+	if cfg.MetricsEnabled {
+		metrics.RegisterRoutes(e, metrics.NewHandler(metrics.Default, w, jobs.NewService(db)))
+	}
 
 	// Register test-only routes when in test mode
 	// These endpoints allow E2E tests to set up and tear down test data
@@ -99,7 +115,7 @@ func New(cfg *config.Config, db *bun.DB, w *worker.Worker, pm *plugins.Manager,
 	opds.RegisterRoutes(e, db, cfg, authMiddleware)
 
 	// Register eReader routes (API key auth for stock browser support)
-	ereader.RegisterRoutes(e, db, dlCache)
+	ereader.RegisterRoutes(e, db, cfg, dlCache)
 
 	// Register Kobo sync routes (API key auth for Kobo device sync)
 	kobo.RegisterRoutes(e, db, dlCache)
@@ -126,7 +142,8 @@ func New(cfg *config.Config, db *bun.DB, w *worker.Worker, pm *plugins.Manager,
 	audnexus.RegisterRoutes(e, audnexusService, authMiddleware)
 
 	// Cache management routes (admin only; requires config:read to list, config:write to clear)
-	cacheHandler := cache.NewHandler(dlCache, cbzCache, pdfCache)
+	openLibraryCache := openlibrary.NewService(openlibrary.ServiceConfig{CacheDir: cfg.CacheDir})
+	cacheHandler := cache.NewHandler(dlCache, cbzCache, pdfCache, openLibraryCache)
 	cache.RegisterRoutes(e, cacheHandler, authMiddleware)
 
 	echo.NotFoundHandler = notFoundHandler
@@ -147,8 +164,14 @@ func registerProtectedRoutes(e *echo.Echo, db *bun.DB, cfg *config.Config, authM
 	booksGroup := e.Group("/books")
 	booksGroup.Use(authMiddleware.Authenticate)
 	booksGroup.Use(authMiddleware.RequirePermission(models.ResourceBooks, models.OperationRead))
-	books.RegisterRoutesWithGroup(booksGroup, db, cfg, authMiddleware, w, pm, dlCache, appsettings.NewService(db))
-	chapters.RegisterRoutes(booksGroup, db, authMiddleware)
+	books.RegisterRoutesWithGroup(booksGroup, db, cfg, authMiddleware, w, pm, dlCache, appsettings.NewService(db), w.Webhooks())
+	chapters.RegisterRoutes(booksGroup, db, cfg, authMiddleware)
+
+	// Files routes
+	filesGroup := e.Group("/files")
+	filesGroup.Use(authMiddleware.Authenticate)
+	filesGroup.Use(authMiddleware.RequirePermission(models.ResourceBooks, models.OperationRead))
+	books.RegisterFileRoutes(filesGroup, db)
 
 	// Libraries routes
 	librariesGroup := e.Group("/libraries")
@@ -160,6 +183,11 @@ func registerProtectedRoutes(e *echo.Echo, db *bun.DB, cfg *config.Config, authM
 	plugins.RegisterLibraryRoutes(librariesGroup, plugins.NewService(db), pm, authMiddleware)
 	books.RegisterLibraryRoutes(librariesGroup, db, authMiddleware)
 
+	// Scan error routes
+	scanErrorsGroup := e.Group("/errors")
+	scanErrorsGroup.Use(authMiddleware.Authenticate)
+	libraries.RegisterScanErrorRoutes(scanErrorsGroup, db, authMiddleware, w)
+
 	// Jobs routes
 	jobsGroup := e.Group("/jobs")
 	jobsGroup.Use(authMiddleware.Authenticate)
@@ -171,8 +199,9 @@ func registerProtectedRoutes(e *echo.Echo, db *bun.DB, cfg *config.Config, authM
 	peopleGroup := e.Group("/people")
 	peopleGroup.Use(authMiddleware.Authenticate)
 	peopleGroup.Use(authMiddleware.RequirePermission(models.ResourcePeople, models.OperationRead))
-	fileOrganizer := NewFileOrganizer(db)
-	people.RegisterRoutesWithGroup(peopleGroup, db, authMiddleware, fileOrganizer)
+	fileOrganizer := NewFileOrganizer(db, cfg, pm)
+	metadataHasher := books.NewService(db)
+	people.RegisterRoutesWithGroup(peopleGroup, db, cfg, authMiddleware, fileOrganizer, metadataHasher)
 
 	// Series routes
 	seriesGroup := e.Group("/series")
@@ -195,7 +224,13 @@ func registerProtectedRoutes(e *echo.Echo, db *bun.DB, cfg *config.Config, authM
 	tagsGroup := e.Group("/tags")
 	tagsGroup.Use(authMiddleware.Authenticate)
 	tagsGroup.Use(authMiddleware.RequirePermission(models.ResourceBooks, models.OperationRead))
-	tags.RegisterRoutesWithGroup(tagsGroup, db, authMiddleware)
+	tags.RegisterRoutesWithGroup(tagsGroup, db, authMiddleware, metadataHasher)
+
+	// Collections routes
+	collectionsGroup := e.Group("/collections")
+	collectionsGroup.Use(authMiddleware.Authenticate)
+	collectionsGroup.Use(authMiddleware.RequirePermission(models.ResourceBooks, models.OperationRead))
+	collections.RegisterRoutesWithGroup(collectionsGroup, db, authMiddleware)
 
 	// Publishers routes
 	publishersGroup := e.Group("/publishers")
@@ -207,24 +242,33 @@ func registerProtectedRoutes(e *echo.Echo, db *bun.DB, cfg *config.Config, authM
 	searchGroup := e.Group("/search")
 	searchGroup.Use(authMiddleware.Authenticate)
 	searchGroup.Use(authMiddleware.RequirePermission(models.ResourceBooks, models.OperationRead))
-	search.RegisterRoutesWithGroup(searchGroup, db)
+	search.RegisterRoutesWithGroup(searchGroup, db, cfg)
 
 	// Plugin identify routes (editors can search/apply metadata)
 	pluginService := plugins.NewService(db)
 	appSettingsSvc := appsettings.NewService(db)
-	bookSvc := books.NewService(db).WithAppSettings(appSettingsSvc)
+	bookSvc := books.NewService(db).
+		WithAppSettings(appSettingsSvc).
+		WithFilenameSanitizer(fileutils.SanitizeOptions{Mode: cfg.OrganizeFilenameMode}).
+		WithWebhooks(w.Webhooks()).
+		WithPersonNameLocale(cfg.PersonNameLocale)
+	if pm != nil {
+		bookSvc.WithPathOrganizer(pm)
+	}
 	bookAdapter := &bookUpdaterAdapter{svc: bookSvc}
 	pageExtractor := books.NewPluginPageExtractor(cbzCache, pdfCache)
 	enrichDeps := &plugins.EnrichDeps{
 		BookStore:       bookAdapter,
 		RelStore:        bookAdapter,
 		IdentStore:      bookAdapter,
-		PersonFinder:    people.NewService(db),
+		PersonFinder:    people.NewService(db).WithNameLocale(cfg.PersonNameLocale),
 		GenreFinder:     genres.NewService(db),
 		TagFinder:       tags.NewService(db),
 		PublisherFinder: publishers.NewService(db),
 		SearchIndexer:   search.NewService(db),
 		PageExtractor:   pageExtractor,
+
+		KeepOriginalCover: cfg.KeepOriginalCover,
 	}
 	pluginIdentifyGroup := e.Group("/plugins")
 	pluginIdentifyGroup.Use(authMiddleware.Authenticate)
@@ -316,3 +360,7 @@ func (a *bookUpdaterAdapter) CreateNarrator(ctx context.Context, narrator *model
 func (a *bookUpdaterAdapter) OrganizeBookFiles(ctx context.Context, book *models.Book) error {
 	return a.svc.OrganizeBookFiles(ctx, book)
 }
+
+func (a *bookUpdaterAdapter) RefreshMetadataHash(ctx context.Context, book *models.Book) error {
+	return a.svc.RefreshMetadataHash(ctx, book)
+}
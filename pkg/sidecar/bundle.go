@@ -0,0 +1,177 @@
+package sidecar
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxBundleEntrySize caps how large a single sidecar in a bundle may be.
+// Real sidecars are a few KB; anything bigger is almost certainly not one.
+const maxBundleEntrySize = 16 << 20
+
+// ImportBundleOptions controls how ImportBundle applies a bundle.
+type ImportBundleOptions struct {
+	// Overwrite replaces sidecars that already exist on disk. When false,
+	// existing sidecars are left alone and counted as skipped.
+	Overwrite bool
+}
+
+// ImportBundleResult summarizes what ImportBundle did.
+type ImportBundleResult struct {
+	Written int
+	// Skipped counts sidecars that already existed (without Overwrite) or
+	// whose book/file isn't present under the target root.
+	Skipped int
+}
+
+// ExportBundle writes every book and file sidecar under root into a gzipped
+// tar stream. Entries are stored by their path relative to root so that the
+// bundle can be applied to a copy of the library mounted somewhere else.
+// It returns the number of sidecars written.
+func ExportBundle(w io.Writer, root string) (int, error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	count := 0
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() || !strings.HasSuffix(d.Name(), SidecarSuffix) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		hdr := &tar.Header{
+			Name:    filepath.ToSlash(rel),
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: info.ModTime(),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return errors.WithStack(err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return errors.WithStack(err)
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if err := gz.Close(); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return count, nil
+}
+
+// ImportBundle unpacks a bundle produced by ExportBundle into root. Each
+// sidecar is validated before it's written, and is only written into
+// directories that already exist under root, so books that aren't in this
+// library are skipped. The next scan of those files then
+// picks the sidecars up as their "sidecar" metadata source.
+func ImportBundle(r io.Reader, root string, opts ImportBundleOptions) (*ImportBundleResult, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	result := &ImportBundleResult{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name, ok := cleanBundlePath(hdr.Name)
+		if !ok {
+			return nil, errors.Errorf("bundle entry %q is not a sidecar path", hdr.Name)
+		}
+		if hdr.Size > maxBundleEntrySize {
+			return nil, errors.Errorf("bundle entry %q is too large", hdr.Name)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxBundleEntrySize))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		target := filepath.Join(root, filepath.FromSlash(name))
+		if _, err := os.Stat(filepath.Dir(target)); err != nil {
+			// The book isn't in this library.
+			result.Skipped++
+			continue
+		}
+		if err := validateBundleSidecar(target, data); err != nil {
+			return nil, errors.Wrapf(err, "bundle entry %q is not a valid sidecar", hdr.Name)
+		}
+		if !opts.Overwrite && pathExists(target) {
+			result.Skipped++
+			continue
+		}
+		// Sidecar files should be readable by users and other applications
+		if err := os.WriteFile(target, data, 0644); err != nil { //nolint:gosec
+			return nil, errors.WithStack(err)
+		}
+		result.Written++
+	}
+	return result, nil
+}
+
+// validateBundleSidecar checks that data parses as the kind of sidecar that
+// belongs at target: a file sidecar when the media file it's named after
+// exists, otherwise a book sidecar.
+func validateBundleSidecar(target string, data []byte) error {
+	if isFileSidecarPath(target) {
+		_, err := decodeFileSidecar(target, data)
+		return err
+	}
+	_, err := decodeBookSidecar(target, data)
+	return err
+}
+
+// cleanBundlePath normalizes a bundle entry name, rejecting absolute paths,
+// anything that climbs out of the library root, and non-sidecar files.
+func cleanBundlePath(name string) (string, bool) {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") {
+		return "", false
+	}
+	cleaned := path.Clean(name)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", false
+	}
+	if !strings.HasSuffix(cleaned, SidecarSuffix) {
+		return "", false
+	}
+	return cleaned, true
+}
@@ -0,0 +1,137 @@
+package sidecar
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupBundleLibrary creates a library with one directory-based book that has
+// a book sidecar and a file sidecar.
+func setupBundleLibrary(t *testing.T) (root, bookDir, filePath string) {
+	t.Helper()
+	root = t.TempDir()
+	bookDir = filepath.Join(root, "[Author] Title")
+	require.NoError(t, os.MkdirAll(bookDir, 0755))
+	filePath = filepath.Join(bookDir, "Title.epub")
+	require.NoError(t, os.WriteFile(filePath, []byte("epub"), 0600))
+	return root, bookDir, filePath
+}
+
+func TestBundle_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	srcRoot, srcBookDir, srcFile := setupBundleLibrary(t)
+	require.NoError(t, WriteBookSidecar(srcBookDir, &BookSidecar{Title: "Curated Title"}))
+	fileName := "Curated File"
+	require.NoError(t, WriteFileSidecar(srcFile, &FileSidecar{Name: &fileName}))
+
+	var buf bytes.Buffer
+	n, err := ExportBundle(&buf, srcRoot)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	// The destination library lives at a different root.
+	dstRoot, dstBookDir, dstFile := setupBundleLibrary(t)
+	result, err := ImportBundle(bytes.NewReader(buf.Bytes()), dstRoot, ImportBundleOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Written)
+	assert.Equal(t, 0, result.Skipped)
+
+	book, err := ReadBookSidecar(dstBookDir)
+	require.NoError(t, err)
+	require.NotNil(t, book)
+	assert.Equal(t, "Curated Title", book.Title)
+
+	file, err := ReadFileSidecar(dstFile)
+	require.NoError(t, err)
+	require.NotNil(t, file)
+	assert.Equal(t, "Curated File", *file.Name)
+}
+
+func TestImportBundle_SkipsExistingUnlessOverwrite(t *testing.T) {
+	t.Parallel()
+
+	srcRoot, srcBookDir, _ := setupBundleLibrary(t)
+	require.NoError(t, WriteBookSidecar(srcBookDir, &BookSidecar{Title: "From Bundle"}))
+	var buf bytes.Buffer
+	_, err := ExportBundle(&buf, srcRoot)
+	require.NoError(t, err)
+
+	dstRoot, dstBookDir, _ := setupBundleLibrary(t)
+	require.NoError(t, WriteBookSidecar(dstBookDir, &BookSidecar{Title: "Already Here"}))
+
+	result, err := ImportBundle(bytes.NewReader(buf.Bytes()), dstRoot, ImportBundleOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Written)
+	assert.Equal(t, 1, result.Skipped)
+	book, err := ReadBookSidecar(dstBookDir)
+	require.NoError(t, err)
+	assert.Equal(t, "Already Here", book.Title)
+
+	result, err = ImportBundle(bytes.NewReader(buf.Bytes()), dstRoot, ImportBundleOptions{Overwrite: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Written)
+	book, err = ReadBookSidecar(dstBookDir)
+	require.NoError(t, err)
+	assert.Equal(t, "From Bundle", book.Title)
+}
+
+func TestImportBundle_SkipsMissingBooks(t *testing.T) {
+	t.Parallel()
+
+	srcRoot, srcBookDir, _ := setupBundleLibrary(t)
+	require.NoError(t, WriteBookSidecar(srcBookDir, &BookSidecar{Title: "Title"}))
+	var buf bytes.Buffer
+	_, err := ExportBundle(&buf, srcRoot)
+	require.NoError(t, err)
+
+	dstRoot := t.TempDir()
+	result, err := ImportBundle(bytes.NewReader(buf.Bytes()), dstRoot, ImportBundleOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Written)
+	assert.Equal(t, 1, result.Skipped)
+}
+
+func TestImportBundle_RejectsUnsafeEntries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		entry string
+		body  string
+	}{
+		{"path traversal", "../escape.metadata.json", `{}`},
+		{"absolute path", "/etc/book.metadata.json", `{}`},
+		{"not a sidecar", "Book/evil.sh", `echo hi`},
+		{"invalid json", "Book/Book.metadata.json", `not json`},
+		{"invalid sources", "Book/Book.metadata.json", `{"sources":{"title":"bogus"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(root, "Book"), 0755))
+
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gz)
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: tt.entry, Mode: 0644, Size: int64(len(tt.body))}))
+			_, err := tw.Write([]byte(tt.body))
+			require.NoError(t, err)
+			require.NoError(t, tw.Close())
+			require.NoError(t, gz.Close())
+
+			_, err = ImportBundle(&buf, root, ImportBundleOptions{})
+			require.Error(t, err)
+			_, statErr := os.Stat(filepath.Join(root, "Book", "Book.metadata.json"))
+			assert.True(t, os.IsNotExist(statErr))
+		})
+	}
+}
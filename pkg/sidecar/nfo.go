@@ -0,0 +1,167 @@
+package sidecar
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/models"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// NFOExtension is the extension of release info files that ReadNFO reads.
+const NFOExtension = ".nfo"
+
+// maxNFOSize caps how much of an .nfo file is read. Real ones are a few KB;
+// anything larger is almost certainly not an info file.
+const maxNFOSize = 64 * 1024
+
+// nfoLabelPattern matches a labeled line such as "Title: Foo",
+// "Title.......: Foo" or "│ Publisher - Foo │". Leading and trailing ASCII
+// art borders are skipped.
+var nfoLabelPattern = regexp.MustCompile(`^[^\p{L}\p{N}]*([\p{L}][\p{L} ]*?)\s*(?:\.{2,}\s*:?|:|\s-\s|\s{2,})\s*(.+?)[\s|│║*#=~]*$`)
+
+// nfoSeriesNumberPattern splits a trailing series number off a series value,
+// e.g. "Foundation #2", "Foundation, Book 2" or "Foundation (Vol. 2)".
+var nfoSeriesNumberPattern = regexp.MustCompile(`(?i)^(.+?)[\s,]*\(?\s*(?:#|no\.?\s*|book\s+|vol(?:ume)?\.?\s*)(\d+(?:\.\d+)?)\s*\)?$`)
+
+// nfoYearPattern finds a plausible publication year in a date value.
+var nfoYearPattern = regexp.MustCompile(`\b(19\d{2}|20\d{2}|2100)\b`)
+
+// nfoFields maps recognized (lowercased) labels to the field they set.
+var nfoFields = map[string]string{
+	"title":        "title",
+	"book title":   "title",
+	"series":       "series",
+	"year":         "release_date",
+	"date":         "release_date",
+	"release date": "release_date",
+	"released":     "release_date",
+	"published":    "release_date",
+	"publisher":    "publisher",
+	"published by": "publisher",
+}
+
+// ReadNFO looks for a single .nfo file in dir and extracts the fields it can
+// recognize confidently: title, series, year and publisher. Every field it
+// sets is pinned to the nfo data source, so file metadata and real sidecars
+// win over it and only filepath-derived values lose to it. Returns nil
+// sidecars when there is no .nfo file, when there are several (it's unclear
+// which book they describe), or when nothing in it is recognizable.
+func ReadNFO(dir string) (*BookSidecar, *FileSidecar, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, errors.WithStack(err)
+	}
+
+	var nfoPath string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), NFOExtension) {
+			continue
+		}
+		if nfoPath != "" {
+			return nil, nil, nil
+		}
+		nfoPath = filepath.Join(dir, entry.Name())
+	}
+	if nfoPath == "" {
+		return nil, nil, nil
+	}
+
+	f, err := os.Open(nfoPath)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxNFOSize))
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	bookSidecar, fileSidecar := ParseNFO(data)
+	return bookSidecar, fileSidecar, nil
+}
+
+// ParseNFO extracts recognizable fields from the contents of an .nfo file.
+// Most .nfo files are freeform text in CP437 with "Label: value" lines
+// somewhere among the ASCII art; lines that don't look like a known label are
+// ignored, and the first occurrence of each label wins.
+func ParseNFO(data []byte) (*BookSidecar, *FileSidecar) {
+	if !utf8.Valid(data) {
+		if decoded, err := charmap.CodePage437.NewDecoder().Bytes(data); err == nil {
+			data = decoded
+		}
+	}
+
+	values := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		m := nfoLabelPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		field, ok := nfoFields[strings.ToLower(strings.Join(strings.Fields(m[1]), " "))]
+		if !ok {
+			continue
+		}
+		if _, seen := values[field]; seen {
+			continue
+		}
+		if value := strings.TrimSpace(m[2]); value != "" {
+			values[field] = value
+		}
+	}
+
+	bookSidecar := &BookSidecar{Version: CurrentVersion}
+	fileSidecar := &FileSidecar{Version: CurrentVersion}
+	pin := func(sources map[string]string, field string) map[string]string {
+		if sources == nil {
+			sources = map[string]string{}
+		}
+		sources[field] = models.DataSourceNFO
+		return sources
+	}
+
+	if title, ok := values["title"]; ok {
+		bookSidecar.Title = title
+		bookSidecar.Sources = pin(bookSidecar.Sources, "title")
+	}
+	if series, ok := values["series"]; ok {
+		s := SeriesMetadata{Name: series}
+		if m := nfoSeriesNumberPattern.FindStringSubmatch(series); m != nil {
+			if number, err := strconv.ParseFloat(m[2], 64); err == nil {
+				s.Name = strings.TrimSpace(m[1])
+				s.Number = &number
+			}
+		}
+		bookSidecar.Series = []SeriesMetadata{s}
+		bookSidecar.Sources = pin(bookSidecar.Sources, "series")
+	}
+	if date, ok := values["release_date"]; ok {
+		if year := nfoYearPattern.FindString(date); year != "" {
+			releaseDate := year + "-01-01"
+			fileSidecar.ReleaseDate = &releaseDate
+			fileSidecar.Sources = pin(fileSidecar.Sources, "release_date")
+		}
+	}
+	if publisher, ok := values["publisher"]; ok {
+		fileSidecar.Publisher = &publisher
+		fileSidecar.Sources = pin(fileSidecar.Sources, "publisher")
+	}
+
+	if bookSidecar.Sources == nil {
+		bookSidecar = nil
+	}
+	if fileSidecar.Sources == nil {
+		fileSidecar = nil
+	}
+	return bookSidecar, fileSidecar
+}
@@ -0,0 +1,84 @@
+package sidecar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNFO(t *testing.T) {
+	t.Parallel()
+	data := []byte(`
+  ╔══════════════════════════════════════╗
+  ║          SOME RELEASE GROUP          ║
+  ╚══════════════════════════════════════╝
+
+  ║ Title.......: The Hobbit             ║
+  ║ Series......: Middle-earth #1        ║
+  ║ Publisher...: George Allen & Unwin   ║
+  ║ Released....: September 21, 1937     ║
+  ║ Title.......: Ignored Second Title   ║
+
+  Greetings to everyone: you know who you are
+`)
+
+	bookSidecar, fileSidecar := ParseNFO(data)
+	require.NotNil(t, bookSidecar)
+	require.NotNil(t, fileSidecar)
+
+	assert.Equal(t, "The Hobbit", bookSidecar.Title)
+	require.Len(t, bookSidecar.Series, 1)
+	assert.Equal(t, "Middle-earth", bookSidecar.Series[0].Name)
+	require.NotNil(t, bookSidecar.Series[0].Number)
+	assert.InDelta(t, 1.0, *bookSidecar.Series[0].Number, 0)
+	assert.Equal(t, models.DataSourceNFO, bookSidecar.SourceFor("title"))
+	assert.Equal(t, models.DataSourceNFO, bookSidecar.SourceFor("series"))
+
+	require.NotNil(t, fileSidecar.Publisher)
+	assert.Equal(t, "George Allen & Unwin", *fileSidecar.Publisher)
+	require.NotNil(t, fileSidecar.ReleaseDate)
+	assert.Equal(t, "1937-01-01", *fileSidecar.ReleaseDate)
+	assert.Equal(t, models.DataSourceNFO, fileSidecar.SourceFor("publisher"))
+}
+
+func TestParseNFO_CP437(t *testing.T) {
+	t.Parallel()
+	// 0xCD is "═" in CP437 and isn't valid UTF-8 on its own.
+	data := []byte("\xcd\xcd\xcd\xcd\r\nTitle: Dune\r\nYear: 1965\r\n\xcd\xcd\xcd\xcd\r\n")
+
+	bookSidecar, fileSidecar := ParseNFO(data)
+	require.NotNil(t, bookSidecar)
+	assert.Equal(t, "Dune", bookSidecar.Title)
+	require.NotNil(t, fileSidecar)
+	assert.Equal(t, "1965-01-01", *fileSidecar.ReleaseDate)
+}
+
+func TestParseNFO_Unrecognized(t *testing.T) {
+	t.Parallel()
+	bookSidecar, fileSidecar := ParseNFO([]byte("just some ascii art\n  /\\_/\\\n ( o.o )\nYear: 12\n"))
+	assert.Nil(t, bookSidecar)
+	assert.Nil(t, fileSidecar)
+}
+
+func TestReadNFO(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "release.NFO"), []byte("Title: Dune\n"), 0600))
+
+	bookSidecar, fileSidecar, err := ReadNFO(dir)
+	require.NoError(t, err)
+	require.NotNil(t, bookSidecar)
+	assert.Equal(t, "Dune", bookSidecar.Title)
+	assert.Nil(t, fileSidecar)
+
+	// With more than one .nfo it's unclear which applies, so none is used.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.nfo"), []byte("Title: Other\n"), 0600))
+	bookSidecar, fileSidecar, err = ReadNFO(dir)
+	require.NoError(t, err)
+	assert.Nil(t, bookSidecar)
+	assert.Nil(t, fileSidecar)
+}
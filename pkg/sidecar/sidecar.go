@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/shishobooks/shisho/pkg/models"
@@ -90,7 +91,8 @@ func FileSidecarExists(filePath string) bool {
 }
 
 // ReadBookSidecar reads and parses a book sidecar file.
-// Returns nil, nil if the sidecar doesn't exist or bookPath is empty.
+// Returns nil, nil if the sidecar doesn't exist or bookPath is empty, and a
+// *ValidationError if it doesn't match the schema.
 func ReadBookSidecar(bookPath string) (*BookSidecar, error) {
 	sidecarPath := BookSidecarPath(bookPath)
 	if sidecarPath == "" {
@@ -105,12 +107,7 @@ func ReadBookSidecar(bookPath string) (*BookSidecar, error) {
 		return nil, errors.WithStack(err)
 	}
 
-	var s BookSidecar
-	if err := json.Unmarshal(data, &s); err != nil {
-		return nil, errors.WithStack(err)
-	}
-
-	return &s, nil
+	return decodeBookSidecar(sidecarPath, data)
 }
 
 // ReadBookSidecarFromModel reads the book sidecar for a Book, using the same
@@ -132,13 +129,21 @@ func ReadBookSidecarFromModel(book *models.Book, fileHint *models.File) (*BookSi
 	return ReadBookSidecar(anchor)
 }
 
+// BookSidecarPathFromModel returns the sidecar path ReadBookSidecarFromModel
+// and WriteBookSidecarFromModel use for a Book, or "" when the book has no
+// paths. book.Files should be loaded so root-level books resolve correctly.
+func BookSidecarPathFromModel(book *models.Book) string {
+	return BookSidecarPath(resolveBookSidecarAnchor(book))
+}
+
 func pathExists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
 }
 
 // ReadFileSidecar reads and parses a file sidecar.
-// Returns nil, nil if the sidecar doesn't exist or filePath is empty.
+// Returns nil, nil if the sidecar doesn't exist or filePath is empty, and a
+// *ValidationError if it doesn't match the schema.
 func ReadFileSidecar(filePath string) (*FileSidecar, error) {
 	sidecarPath := FileSidecarPath(filePath)
 	if sidecarPath == "" {
@@ -153,17 +158,24 @@ func ReadFileSidecar(filePath string) (*FileSidecar, error) {
 		return nil, errors.WithStack(err)
 	}
 
-	var s FileSidecar
-	if err := json.Unmarshal(data, &s); err != nil {
-		return nil, errors.WithStack(err)
+	s, err := decodeFileSidecar(sidecarPath, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Version 1 sidecars stored cover_page 0-indexed. Hand-written sidecars
+	// without a version are taken as current.
+	if s.Version == 1 && s.CoverPage != nil {
+		page := *s.CoverPage + 1
+		s.CoverPage = &page
 	}
 
-	return &s, nil
+	return s, nil
 }
 
 // WriteBookSidecar writes a book sidecar file.
 // Note: The caller is responsible for ensuring the parent directory exists.
-// For root-level files with OrganizeFileStructure enabled, the directory
+// For root-level files with OrganizeFolders enabled, the directory
 // should be created before calling this function.
 // Returns ErrEmptySidecarPath if bookPath is empty.
 func WriteBookSidecar(bookPath string, s *BookSidecar) error {
@@ -213,6 +225,7 @@ func BookSidecarFromModel(book *models.Book) *BookSidecar {
 	s := &BookSidecar{
 		Version:     CurrentVersion,
 		Title:       book.Title,
+		RawTitle:    book.RawTitle,
 		SortTitle:   book.SortTitle,
 		Subtitle:    book.Subtitle,
 		Description: book.Description,
@@ -234,12 +247,13 @@ func BookSidecarFromModel(book *models.Book) *BookSidecar {
 	for _, bs := range book.BookSeries {
 		if bs.Series != nil {
 			s.Series = append(s.Series, SeriesMetadata{
-				Name:      bs.Series.Name,
-				SortName:  bs.Series.SortName,
-				Number:    bs.SeriesNumber,
-				NumberEnd: bs.SeriesNumberEnd,
-				Unit:      bs.SeriesNumberUnit,
-				SortOrder: bs.SortOrder,
+				Name:         bs.Series.Name,
+				SortName:     bs.Series.SortName,
+				Number:       bs.SeriesNumber,
+				NumberEnd:    bs.SeriesNumberEnd,
+				Unit:         bs.SeriesNumberUnit,
+				ReadingOrder: bs.ReadingOrder,
+				SortOrder:    bs.SortOrder,
 			})
 		}
 	}
@@ -262,6 +276,15 @@ func BookSidecarFromModel(book *models.Book) *BookSidecar {
 	}
 	sort.Strings(s.Tags)
 
+	// Keep manual edits pinned so they're restored as manual if the book is
+	// ever rebuilt from its sidecar.
+	s.Sources = pinManualSource(s.Sources, "title", &book.TitleSource)
+	s.Sources = pinManualSource(s.Sources, "subtitle", book.SubtitleSource)
+	s.Sources = pinManualSource(s.Sources, "description", book.DescriptionSource)
+	s.Sources = pinManualSource(s.Sources, "authors", &book.AuthorSource)
+	s.Sources = pinManualSource(s.Sources, "genres", book.GenreSource)
+	s.Sources = pinManualSource(s.Sources, "tags", book.TagSource)
+
 	return s
 }
 
@@ -275,6 +298,7 @@ func FileSidecarFromModel(file *models.File) *FileSidecar {
 		CoverPage: file.CoverPage,
 		Language:  file.Language,
 		Abridged:  file.Abridged,
+		WordCount: file.WordCount,
 	}
 
 	// Set publisher name if available
@@ -282,11 +306,35 @@ func FileSidecarFromModel(file *models.File) *FileSidecar {
 		s.Publisher = &file.Publisher.Name
 	}
 
+	// Record audio properties when any are known
+	if file.AudiobookBitrateBps != nil || file.AudiobookCodec != nil ||
+		file.AudiobookSampleRate != nil || file.AudiobookChannels != nil {
+		s.Audio = &AudioMetadata{
+			BitrateBps: file.AudiobookBitrateBps,
+			Codec:      file.AudiobookCodec,
+			SampleRate: file.AudiobookSampleRate,
+			Channels:   file.AudiobookChannels,
+		}
+	}
+
+	// Record the accessibility summary when the file declares any
+	if file.HasTextToSpeech || len(file.AccessModes) > 0 || len(file.AccessibilityFeatures) > 0 {
+		s.Accessibility = &AccessibilityMetadata{
+			TextToSpeech: file.HasTextToSpeech,
+			AccessModes:  file.AccessModes,
+			Features:     file.AccessibilityFeatures,
+		}
+	}
+
 	// Format release date as ISO 8601 string (YYYY-MM-DD)
 	if file.ReleaseDate != nil {
 		dateStr := file.ReleaseDate.Format("2006-01-02")
 		s.ReleaseDate = &dateStr
 	}
+	if file.ModifiedDate != nil {
+		modified := file.ModifiedDate.UTC().Format(time.RFC3339)
+		s.ModifiedDate = &modified
+	}
 
 	// Convert narrators from Narrators
 	for _, narrator := range file.Narrators {
@@ -295,6 +343,7 @@ func FileSidecarFromModel(file *models.File) *FileSidecar {
 				Name:      narrator.Person.Name,
 				SortName:  narrator.Person.SortName,
 				SortOrder: narrator.SortOrder,
+				Role:      narrator.Role,
 			})
 		}
 	}
@@ -315,12 +364,35 @@ func FileSidecarFromModel(file *models.File) *FileSidecar {
 		s.Chapters = ChaptersFromModels(file.Chapters)
 	}
 
+	// Keep manual edits pinned so they're restored as manual if the file is
+	// ever rebuilt from its sidecar.
+	s.Sources = pinManualSource(s.Sources, "name", file.NameSource)
+	s.Sources = pinManualSource(s.Sources, "url", file.URLSource)
+	s.Sources = pinManualSource(s.Sources, "publisher", file.PublisherSource)
+	s.Sources = pinManualSource(s.Sources, "release_date", file.ReleaseDateSource)
+	s.Sources = pinManualSource(s.Sources, "language", file.LanguageSource)
+	s.Sources = pinManualSource(s.Sources, "abridged", file.AbridgedSource)
+	s.Sources = pinManualSource(s.Sources, "narrators", file.NarratorSource)
+	s.Sources = pinManualSource(s.Sources, "identifiers", file.IdentifierSource)
+	s.Sources = pinManualSource(s.Sources, "chapters", file.ChapterSource)
+	// Generated chapters stay marked as generated so real chapters can still
+	// replace them when the file is rebuilt from its sidecar.
+	if file.ChapterSource != nil && *file.ChapterSource == models.DataSourceGenerated {
+		if s.Sources == nil {
+			s.Sources = map[string]string{}
+		}
+		s.Sources["chapters"] = models.DataSourceGenerated
+	}
+	if file.CoverPage != nil {
+		s.Sources = pinManualSource(s.Sources, "cover_page", file.CoverSource)
+	}
+
 	return s
 }
 
 // WriteBookSidecarFromModel writes a book sidecar from a Book model.
 //
-// For root-level books in libraries with OrganizeFileStructure disabled,
+// For root-level books in libraries with OrganizeFolders disabled,
 // the scanner writes a synthetic organized-folder path into book.Filepath
 // that never exists on disk. Writing a sidecar to that path would fail, so
 // if book.Filepath doesn't resolve to an existing directory we fall back to
@@ -386,6 +458,7 @@ func ChaptersFromModels(chapters []*models.Chapter) []ChapterMetadata {
 			StartPage:        ch.StartPage,
 			StartTimestampMs: ch.StartTimestampMs,
 			Href:             ch.Href,
+			CoverPageIndex:   ch.CoverPageIndex,
 			Children:         ChaptersFromModels(ch.Children),
 		}
 	}
@@ -406,6 +479,7 @@ func ChaptersToModels(chapters []ChapterMetadata) []*models.Chapter {
 			StartPage:        ch.StartPage,
 			StartTimestampMs: ch.StartTimestampMs,
 			Href:             ch.Href,
+			CoverPageIndex:   ch.CoverPageIndex,
 			Children:         ChaptersToModels(ch.Children),
 		}
 	}
@@ -2,9 +2,11 @@ package sidecar
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -35,6 +37,70 @@ func TestFileSidecarFromModel_NilName(t *testing.T) {
 	assert.Nil(t, sidecar.Name)
 }
 
+func TestFileSidecarFromModel_Audio(t *testing.T) {
+	t.Parallel()
+	bitrate := 64000
+	codec := "AAC-LC"
+	sampleRate := 44100
+	channels := 2
+	file := &models.File{
+		AudiobookBitrateBps: &bitrate,
+		AudiobookCodec:      &codec,
+		AudiobookSampleRate: &sampleRate,
+		AudiobookChannels:   &channels,
+	}
+
+	sidecar := FileSidecarFromModel(file)
+
+	require.NotNil(t, sidecar.Audio)
+	assert.Equal(t, 64000, *sidecar.Audio.BitrateBps)
+	assert.Equal(t, "AAC-LC", *sidecar.Audio.Codec)
+	assert.Equal(t, 44100, *sidecar.Audio.SampleRate)
+	assert.Equal(t, 2, *sidecar.Audio.Channels)
+}
+
+func TestFileSidecarFromModel_NoAudio(t *testing.T) {
+	t.Parallel()
+	sidecar := FileSidecarFromModel(&models.File{})
+
+	assert.Nil(t, sidecar.Audio)
+}
+
+func TestFileSidecarFromModel_Dates(t *testing.T) {
+	t.Parallel()
+	released := time.Date(2019, 5, 14, 0, 0, 0, 0, time.UTC)
+	modified := time.Date(2023, 2, 1, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+	file := &models.File{
+		ReleaseDate:  &released,
+		ModifiedDate: &modified,
+	}
+
+	sidecar := FileSidecarFromModel(file)
+
+	require.NotNil(t, sidecar.ReleaseDate)
+	assert.Equal(t, "2019-05-14", *sidecar.ReleaseDate)
+	require.NotNil(t, sidecar.ModifiedDate)
+	assert.Equal(t, "2023-02-01T09:30:00Z", *sidecar.ModifiedDate)
+}
+
+func TestFileSidecarFromModel_Accessibility(t *testing.T) {
+	t.Parallel()
+	file := &models.File{
+		HasTextToSpeech:       true,
+		AccessModes:           []string{"textual", "visual"},
+		AccessibilityFeatures: []string{"alternativeText"},
+	}
+
+	sidecar := FileSidecarFromModel(file)
+
+	require.NotNil(t, sidecar.Accessibility)
+	assert.True(t, sidecar.Accessibility.TextToSpeech)
+	assert.Equal(t, []string{"textual", "visual"}, sidecar.Accessibility.AccessModes)
+	assert.Equal(t, []string{"alternativeText"}, sidecar.Accessibility.Features)
+
+	assert.Nil(t, FileSidecarFromModel(&models.File{}).Accessibility)
+}
+
 func TestFileSidecarFromModel_WithChapters(t *testing.T) {
 	t.Parallel()
 	page1 := 0
@@ -106,9 +172,10 @@ func TestChaptersFromModels_CBZ(t *testing.T) {
 	t.Parallel()
 	page1 := 0
 	page2 := 10
+	cover2 := 12
 	chapters := []*models.Chapter{
 		{Title: "Chapter 1", StartPage: &page1},
-		{Title: "Chapter 2", StartPage: &page2},
+		{Title: "Chapter 2", StartPage: &page2, CoverPageIndex: &cover2},
 	}
 
 	result := ChaptersFromModels(chapters)
@@ -119,8 +186,11 @@ func TestChaptersFromModels_CBZ(t *testing.T) {
 	assert.Nil(t, result[0].StartTimestampMs)
 	assert.Nil(t, result[0].Href)
 
+	assert.Nil(t, result[0].CoverPageIndex)
+
 	assert.Equal(t, "Chapter 2", result[1].Title)
 	assert.Equal(t, 10, *result[1].StartPage)
+	assert.Equal(t, 12, *result[1].CoverPageIndex)
 }
 
 func TestChaptersFromModels_M4B(t *testing.T) {
@@ -200,9 +270,10 @@ func TestChaptersToModels_CBZ(t *testing.T) {
 	t.Parallel()
 	page1 := 0
 	page2 := 10
+	cover2 := 12
 	chapters := []ChapterMetadata{
 		{Title: "Chapter 1", StartPage: &page1},
-		{Title: "Chapter 2", StartPage: &page2},
+		{Title: "Chapter 2", StartPage: &page2, CoverPageIndex: &cover2},
 	}
 
 	result := ChaptersToModels(chapters)
@@ -213,8 +284,11 @@ func TestChaptersToModels_CBZ(t *testing.T) {
 	assert.Nil(t, result[0].StartTimestampMs)
 	assert.Nil(t, result[0].Href)
 
+	assert.Nil(t, result[0].CoverPageIndex)
+
 	assert.Equal(t, "Chapter 2", result[1].Title)
 	assert.Equal(t, 10, *result[1].StartPage)
+	assert.Equal(t, 12, *result[1].CoverPageIndex)
 }
 
 func TestChaptersToModels_M4B(t *testing.T) {
@@ -547,8 +621,8 @@ func TestWriteBookSidecarFromModel_RoundTripGenresAndTags(t *testing.T) {
 }
 
 // Regression: scanFileCreateNew writes a synthetic organized-folder path
-// into book.Filepath for root-level files regardless of OrganizeFileStructure.
-// When OrganizeFileStructure=false, that synthetic directory never exists on
+// into book.Filepath for root-level files regardless of OrganizeFolders.
+// When OrganizeFolders=false, that synthetic directory never exists on
 // disk, so WriteBookSidecarFromModel's write fails and the book sidecar is
 // never persisted. The fix falls back to anchoring the sidecar next to a file
 // in the book.
@@ -609,6 +683,101 @@ func TestWriteFileSidecar_SetsVersion(t *testing.T) {
 	assert.Equal(t, CurrentVersion, readBack.Version)
 }
 
+func TestReadFileSidecar_UpgradesVersion1CoverPage(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{"version 1 is 0-indexed", `{"version":1,"cover_page":0}`, 1},
+		{"version 2 is 1-indexed", `{"version":2,"cover_page":3}`, 3},
+		{"unversioned is taken as current", `{"cover_page":3}`, 3},
+	}
+	for i, tt := range tests {
+		filePath := filepath.Join(tmpDir, fmt.Sprintf("test%d.cbz", i))
+		require.NoError(t, os.WriteFile(FileSidecarPath(filePath), []byte(tt.content), 0600))
+
+		s, err := ReadFileSidecar(filePath)
+		require.NoError(t, err, tt.name)
+		require.NotNil(t, s.CoverPage, tt.name)
+		assert.Equal(t, tt.want, *s.CoverPage, tt.name)
+	}
+}
+
+func TestReadBookSidecar_Sources(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	bookPath := filepath.Join(tmpDir, "book.epub")
+	content := `{"title":"Pinned","sources":{"title":"manual","tags":"plugin:shisho/goodreads"}}`
+	require.NoError(t, os.WriteFile(BookSidecarPath(bookPath), []byte(content), 0600))
+
+	s, err := ReadBookSidecar(bookPath)
+	require.NoError(t, err)
+	assert.Equal(t, models.DataSourceManual, s.SourceFor("title"))
+	assert.Equal(t, "plugin:shisho/goodreads", s.SourceFor("tags"))
+	assert.Equal(t, models.DataSourceSidecar, s.SourceFor("subtitle"))
+}
+
+func TestReadSidecar_InvalidSources(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"unknown source", `{"sources":{"name":"made-up"}}`},
+		{"empty plugin", `{"sources":{"name":"plugin:"}}`},
+		{"unknown field", `{"sources":{"title":"manual"}}`},
+	}
+	for i, tt := range tests {
+		filePath := filepath.Join(tmpDir, fmt.Sprintf("test%d.cbz", i))
+		require.NoError(t, os.WriteFile(FileSidecarPath(filePath), []byte(tt.content), 0600))
+
+		_, err := ReadFileSidecar(filePath)
+		assert.Error(t, err, tt.name)
+	}
+}
+
+func TestSidecarFromModel_PinsManualSources(t *testing.T) {
+	t.Parallel()
+	manual := models.DataSourceManual
+	plugin := "plugin:shisho/goodreads"
+
+	book := &models.Book{
+		Title:             "Title",
+		TitleSource:       models.DataSourceManual,
+		AuthorSource:      models.DataSourceEPUBMetadata,
+		DescriptionSource: &plugin,
+	}
+	bookSidecar := BookSidecarFromModel(book)
+	assert.Equal(t, map[string]string{"title": models.DataSourceManual}, bookSidecar.Sources)
+
+	coverPage := 2
+	file := &models.File{
+		PublisherSource: &manual,
+		CoverSource:     &manual,
+		CoverPage:       &coverPage,
+	}
+	fileSidecar := FileSidecarFromModel(file)
+	assert.Equal(t, map[string]string{
+		"publisher":  models.DataSourceManual,
+		"cover_page": models.DataSourceManual,
+	}, fileSidecar.Sources)
+
+	assert.Nil(t, FileSidecarFromModel(&models.File{}).Sources)
+}
+
+func TestFileSidecarFromModel_PinsGeneratedChapters(t *testing.T) {
+	t.Parallel()
+	generated := models.DataSourceGenerated
+	fileSidecar := FileSidecarFromModel(&models.File{ChapterSource: &generated})
+	assert.Equal(t, map[string]string{"chapters": models.DataSourceGenerated}, fileSidecar.Sources)
+}
+
 func strPtr(s string) *string {
 	return &s
 }
@@ -718,7 +887,7 @@ func TestBookSidecarPath_NonExistentDirectoryPath_WithTrailingSlash(t *testing.T
 func TestWriteBookSidecar_DirectoryCreatedBeforeWrite(t *testing.T) {
 	t.Parallel()
 	tmpDir := t.TempDir()
-	// This simulates the scan scenario with OrganizeFileStructure enabled:
+	// This simulates the scan scenario with organizing enabled:
 	// The caller creates the directory before writing the sidecar
 	bookDir := filepath.Join(tmpDir, "MyBook")
 	require.NoError(t, os.MkdirAll(bookDir, 0755))
@@ -0,0 +1,76 @@
+package sidecar
+
+import (
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// bookSourceFields are the book sidecar fields that can be pinned to a source.
+var bookSourceFields = map[string]struct{}{
+	"title":       {},
+	"subtitle":    {},
+	"description": {},
+	"authors":     {},
+	"series":      {},
+	"genres":      {},
+	"tags":        {},
+}
+
+// fileSourceFields are the file sidecar fields that can be pinned to a source.
+var fileSourceFields = map[string]struct{}{
+	"name":         {},
+	"url":          {},
+	"publisher":    {},
+	"release_date": {},
+	"language":     {},
+	"abridged":     {},
+	"narrators":    {},
+	"identifiers":  {},
+	"chapters":     {},
+	"cover_page":   {},
+}
+
+// SourceFor returns the data source a book sidecar field should be applied
+// with: the pinned source from Sources if there is one, otherwise sidecar.
+func (s *BookSidecar) SourceFor(field string) string {
+	if source, ok := s.Sources[field]; ok {
+		return source
+	}
+	return models.DataSourceSidecar
+}
+
+// SourceFor returns the data source a file sidecar field should be applied
+// with: the pinned source from Sources if there is one, otherwise sidecar.
+func (s *FileSidecar) SourceFor(field string) string {
+	if source, ok := s.Sources[field]; ok {
+		return source
+	}
+	return models.DataSourceSidecar
+}
+
+// validateSources checks that every entry in sources names a known field and
+// a known data source.
+func validateSources(sources map[string]string, fields map[string]struct{}) error {
+	for field, source := range sources {
+		if _, ok := fields[field]; !ok {
+			return errors.Errorf("sources: unknown field %q", field)
+		}
+		if !models.IsValidDataSource(source) {
+			return errors.Errorf("sources: invalid source %q for field %q", source, field)
+		}
+	}
+	return nil
+}
+
+// pinManualSource records field as pinned in sources when its source is
+// manual, so a manual edit survives being rebuilt from the sidecar.
+func pinManualSource(sources map[string]string, field string, source *string) map[string]string {
+	if source == nil || *source != models.DataSourceManual {
+		return sources
+	}
+	if sources == nil {
+		sources = map[string]string{}
+	}
+	sources[field] = models.DataSourceManual
+	return sources
+}
@@ -2,13 +2,18 @@ package sidecar
 
 // CurrentVersion is the current version of the sidecar file format.
 // Increment this when making breaking changes to the schema.
-const CurrentVersion = 1
+//
+// Version history:
+//   - 1: initial format
+//   - 2: file sidecar cover_page switched from 0-indexed to 1-indexed
+const CurrentVersion = 2
 
 // BookSidecar represents the metadata sidecar for a book.
 // This is stored as {bookname}.metadata.json in the book directory.
 type BookSidecar struct {
 	Version     int              `json:"version"`
 	Title       string           `json:"title,omitempty"`
+	RawTitle    *string          `json:"raw_title,omitempty"` // Title before its series prefix was stripped, so the stripping can be undone
 	SortTitle   string           `json:"sort_title,omitempty"`
 	Subtitle    *string          `json:"subtitle,omitempty"`
 	Description *string          `json:"description,omitempty"`
@@ -16,6 +21,9 @@ type BookSidecar struct {
 	Series      []SeriesMetadata `json:"series,omitempty"`
 	Genres      []string         `json:"genres,omitempty"`
 	Tags        []string         `json:"tags,omitempty"`
+	// Sources optionally pins fields to a data source other than "sidecar"
+	// (e.g. {"title": "manual"}), keyed by the field's JSON name.
+	Sources map[string]string `json:"sources,omitempty"`
 }
 
 // FileSidecar represents the metadata sidecar for a media file.
@@ -29,9 +37,34 @@ type FileSidecar struct {
 	Identifiers []IdentifierMetadata `json:"identifiers,omitempty"`
 	Name        *string              `json:"name,omitempty"`
 	Chapters    []ChapterMetadata    `json:"chapters,omitempty"`
-	CoverPage   *int                 `json:"cover_page,omitempty"` // 0-indexed page number for page-based formats (CBZ, PDF)
+	CoverPage   *int                 `json:"cover_page,omitempty"` // 1-indexed page number for page-based formats (CBZ, PDF)
 	Language    *string              `json:"language,omitempty"`
 	Abridged    *bool                `json:"abridged,omitempty"`
+	// Audio, WordCount, ModifiedDate and Accessibility are informational
+	// only. They're always re-read from the media file and never applied
+	// back to the database on scan.
+	Audio         *AudioMetadata         `json:"audio,omitempty"`
+	WordCount     *int                   `json:"word_count,omitempty"`    // Estimated, EPUB only
+	ModifiedDate  *string                `json:"modified_date,omitempty"` // RFC 3339 timestamp from EPUB dcterms:modified
+	Accessibility *AccessibilityMetadata `json:"accessibility,omitempty"`
+	// Sources optionally pins fields to a data source other than "sidecar"
+	// (e.g. {"publisher": "manual"}), keyed by the field's JSON name.
+	Sources map[string]string `json:"sources,omitempty"`
+}
+
+// AudioMetadata describes the technical audio properties of an audiobook file.
+type AudioMetadata struct {
+	BitrateBps *int    `json:"bitrate_bps,omitempty"`
+	Codec      *string `json:"codec,omitempty"`
+	SampleRate *int    `json:"sample_rate,omitempty"` // Hz
+	Channels   *int    `json:"channels,omitempty"`
+}
+
+// AccessibilityMetadata summarizes an EPUB's accessibility metadata.
+type AccessibilityMetadata struct {
+	TextToSpeech bool     `json:"text_to_speech"`
+	AccessModes  []string `json:"access_modes,omitempty"`
+	Features     []string `json:"features,omitempty"`
 }
 
 // AuthorMetadata represents an author in the sidecar file.
@@ -44,9 +77,10 @@ type AuthorMetadata struct {
 
 // NarratorMetadata represents a narrator in the sidecar file.
 type NarratorMetadata struct {
-	Name      string `json:"name"`
-	SortName  string `json:"sort_name,omitempty"`
-	SortOrder int    `json:"sort_order,omitempty"`
+	Name      string  `json:"name"`
+	SortName  string  `json:"sort_name,omitempty"`
+	SortOrder int     `json:"sort_order,omitempty"`
+	Role      *string `json:"role,omitempty"` // Part read in a full-cast audiobook
 }
 
 // IdentifierMetadata represents an identifier in the sidecar file.
@@ -62,7 +96,10 @@ type SeriesMetadata struct {
 	Number    *float64 `json:"number,omitempty"`
 	NumberEnd *float64 `json:"number_end,omitempty"`
 	Unit      *string  `json:"unit,omitempty"` // models.SeriesNumberUnitVolume or models.SeriesNumberUnitChapter; CBZ only
-	SortOrder int      `json:"sort_order,omitempty"`
+	// ReadingOrder is the position in the series' reading order, when it
+	// differs from publication order (Number).
+	ReadingOrder *float64 `json:"reading_order,omitempty"`
+	SortOrder    int      `json:"sort_order,omitempty"`
 }
 
 // ChapterMetadata represents a chapter in the sidecar file.
@@ -75,5 +112,6 @@ type ChapterMetadata struct {
 	StartPage        *int              `json:"start_page,omitempty"`
 	StartTimestampMs *int64            `json:"start_timestamp_ms,omitempty"`
 	Href             *string           `json:"href,omitempty"`
+	CoverPageIndex   *int              `json:"cover_page_index,omitempty"`
 	Children         []ChapterMetadata `json:"children,omitempty"`
 }
@@ -0,0 +1,121 @@
+package sidecar
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// BookSchema is the JSON Schema describing book sidecars. Reads enforce the
+// same rules by decoding strictly into BookSidecar, and a test keeps the two
+// in sync.
+//
+//go:embed schema/book.schema.json
+var BookSchema []byte
+
+// FileSchema is the JSON Schema describing file sidecars.
+//
+//go:embed schema/file.schema.json
+var FileSchema []byte
+
+// ValidationError is returned when a sidecar doesn't match its schema, e.g.
+// it has an unknown (usually misspelled) field or a value of the wrong type.
+type ValidationError struct {
+	Path    string
+	Problem string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid sidecar %s: %s", e.Path, e.Problem)
+}
+
+// decodeBookSidecar strictly decodes and validates a book sidecar read from
+// path.
+func decodeBookSidecar(path string, data []byte) (*BookSidecar, error) {
+	var s BookSidecar
+	if err := decodeStrict(data, &s); err != nil {
+		return nil, &ValidationError{Path: path, Problem: describeDecodeError(err)}
+	}
+	if err := validateSources(s.Sources, bookSourceFields); err != nil {
+		return nil, &ValidationError{Path: path, Problem: err.Error()}
+	}
+	return &s, nil
+}
+
+// decodeFileSidecar strictly decodes and validates a file sidecar read from
+// path.
+func decodeFileSidecar(path string, data []byte) (*FileSidecar, error) {
+	var s FileSidecar
+	if err := decodeStrict(data, &s); err != nil {
+		return nil, &ValidationError{Path: path, Problem: describeDecodeError(err)}
+	}
+	if err := validateSources(s.Sources, fileSourceFields); err != nil {
+		return nil, &ValidationError{Path: path, Problem: err.Error()}
+	}
+	return &s, nil
+}
+
+// decodeStrict unmarshals data into v, rejecting fields v doesn't declare
+// and anything trailing the top-level value.
+func decodeStrict(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("unexpected data after the top-level object")
+	}
+	return nil
+}
+
+// describeDecodeError turns a JSON decoding error into a message that points
+// at the offending field.
+func describeDecodeError(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		if typeErr.Field == "" {
+			return fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value)
+		}
+		return fmt.Sprintf("field %q: expected %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value)
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Sprintf("malformed JSON at byte %d: %s", syntaxErr.Offset, syntaxErr.Error())
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return "malformed JSON: unexpected end of file"
+	}
+	return strings.TrimPrefix(err.Error(), "json: ")
+}
+
+// ValidateSidecar reads the sidecar at path and checks it against the book or
+// file schema. It's treated as a file sidecar when the media file it's named
+// after exists, otherwise as a book sidecar. A schema violation is returned as
+// a *ValidationError.
+func ValidateSidecar(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if isFileSidecarPath(path) {
+		_, err = decodeFileSidecar(path, data)
+	} else {
+		_, err = decodeBookSidecar(path, data)
+	}
+	return err
+}
+
+// isFileSidecarPath reports whether the sidecar at path belongs to a media
+// file, i.e. the file it's named after exists.
+func isFileSidecarPath(path string) bool {
+	mediaPath := strings.TrimSuffix(path, SidecarSuffix)
+	info, err := os.Stat(mediaPath)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,129 @@
+package sidecar
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaNode struct {
+	Properties map[string]*schemaNode `json:"properties"`
+	Items      *schemaNode            `json:"items"`
+	Ref        string                 `json:"$ref"`
+	Defs       map[string]*schemaNode `json:"$defs"`
+}
+
+// assertSchemaMatches checks that the properties declared by node match the
+// JSON fields of typ, recursing into nested objects and arrays of objects.
+// Types in seen have already been checked, which stops recursive types like
+// chapters from looping.
+func assertSchemaMatches(t *testing.T, root, node *schemaNode, typ reflect.Type, path string, seen map[reflect.Type]bool) {
+	t.Helper()
+	for node.Ref != "" {
+		node = root.Defs[strings.TrimPrefix(node.Ref, "#/$defs/")]
+	}
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+		if node.Items != nil {
+			node = node.Items
+			for node.Ref != "" {
+				node = root.Defs[strings.TrimPrefix(node.Ref, "#/$defs/")]
+			}
+		}
+	}
+	if typ.Kind() != reflect.Struct || seen[typ] {
+		return
+	}
+	seen[typ] = true
+
+	var fields []string
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		fields = append(fields, name)
+		prop, ok := node.Properties[name]
+		if assert.True(t, ok, "%s.%s is missing from the schema", path, name) {
+			assertSchemaMatches(t, root, prop, f.Type, path+"."+name, seen)
+		}
+	}
+	var props []string
+	for name := range node.Properties {
+		props = append(props, name)
+	}
+	sort.Strings(fields)
+	sort.Strings(props)
+	assert.Equal(t, fields, props, "schema properties of %s", path)
+}
+
+func TestSchemas_MatchSidecarTypes(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name   string
+		schema []byte
+		typ    reflect.Type
+	}{
+		{"book", BookSchema, reflect.TypeOf(BookSidecar{})},
+		{"file", FileSchema, reflect.TypeOf(FileSidecar{})},
+	} {
+		var root schemaNode
+		require.NoError(t, json.Unmarshal(tt.schema, &root), tt.name)
+		assertSchemaMatches(t, &root, &root, tt.typ, tt.name, map[reflect.Type]bool{})
+	}
+}
+
+func TestReadSidecar_SchemaViolations(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		problem string
+	}{
+		{"misspelled field", `{"titel":"Dune"}`, `unknown field "titel"`},
+		{"misspelled nested field", `{"authors":[{"nmae":"Frank Herbert"}]}`, `unknown field "nmae"`},
+		{"wrong type", `{"title":42}`, `field "title": expected string, got number`},
+		{"wrong nested type", `{"series":[{"name":"Dune","number":"one"}]}`, `field "series.0.number": expected float64, got string`},
+		{"malformed", `{"title":`, `malformed JSON`},
+	}
+	for _, tt := range tests {
+		bookDir := filepath.Join(tmpDir, strings.ReplaceAll(tt.name, " ", "-"))
+		require.NoError(t, os.MkdirAll(bookDir, 0755))
+		require.NoError(t, os.WriteFile(BookSidecarPath(bookDir), []byte(tt.content), 0600))
+
+		s, err := ReadBookSidecar(bookDir)
+		assert.Nil(t, s, tt.name)
+		var validationErr *ValidationError
+		if assert.True(t, errors.As(err, &validationErr), tt.name) {
+			assert.Equal(t, BookSidecarPath(bookDir), validationErr.Path, tt.name)
+			assert.Contains(t, validationErr.Problem, tt.problem, tt.name)
+		}
+	}
+}
+
+func TestValidateSidecar(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	// A file sidecar is recognized by its media file, so a field only file
+	// sidecars have is valid there but not in a book sidecar.
+	mediaPath := filepath.Join(tmpDir, "book.m4b")
+	require.NoError(t, os.WriteFile(mediaPath, []byte("audio"), 0600))
+	require.NoError(t, os.WriteFile(FileSidecarPath(mediaPath), []byte(`{"version":2,"publisher":"Tor"}`), 0600))
+	assert.NoError(t, ValidateSidecar(FileSidecarPath(mediaPath)))
+
+	bookPath := filepath.Join(tmpDir, "Dune.metadata.json")
+	require.NoError(t, os.WriteFile(bookPath, []byte(`{"version":2,"publisher":"Tor"}`), 0600))
+	var validationErr *ValidationError
+	require.True(t, errors.As(ValidateSidecar(bookPath), &validationErr))
+	assert.Equal(t, `unknown field "publisher"`, validationErr.Problem)
+}
@@ -118,6 +118,16 @@ var Particles = []string{
 	"ibn",
 }
 
+// FamilyNameFirstLanguages are the language subtags whose names are written
+// family name first (e.g., "Murakami Haruki"). For these locales the first
+// word is taken as the surname instead of the last.
+var FamilyNameFirstLanguages = []string{
+	"ja",
+	"zh",
+	"ko",
+	"hu",
+}
+
 // ForTitle generates a sort title from a display title.
 // Leading articles are moved to the end.
 // Examples:
@@ -146,6 +156,17 @@ func ForTitle(title string) string {
 	return title
 }
 
+// ForTitleInLanguage is ForTitle for a title written in language, a BCP 47
+// tag. TitleArticles are English, so a title in any other language is
+// returned as is. An empty language is treated as English.
+func ForTitleInLanguage(title, language string) string {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(language)), "-")
+	if primary != "" && primary != "en" && primary != "eng" {
+		return strings.TrimSpace(title)
+	}
+	return ForTitle(title)
+}
+
 // ForPerson generates a sort name from a person's display name.
 // The name is converted to "Last, First Middle" format with proper handling of:
 //   - Prefixes (Dr., Mr., etc.) - stripped
@@ -159,7 +180,12 @@ func ForTitle(title string) string {
 //   - "Jane Doe PhD" -> "Doe, Jane"
 //   - "Dr. Sarah Connor" -> "Connor, Sarah"
 //   - "Ludwig van Beethoven" -> "Beethoven, Ludwig van"
-func ForPerson(name string) string {
+//
+// locale is a BCP 47 tag for the naming convention the name is written in.
+// When its language is in FamilyNameFirstLanguages the first word is the
+// surname and particles aren't moved ("Murakami Haruki" -> "Murakami, Haruki").
+// An empty locale uses the Western given-name-first order.
+func ForPerson(name, locale string) string {
 	name = strings.TrimSpace(name)
 	if name == "" {
 		return ""
@@ -214,6 +240,10 @@ func ForPerson(name string) string {
 		return parts[0]
 	}
 
+	if isFamilyNameFirst(locale) {
+		return joinSortName(parts[0], parts[1:], generationalSuffixes)
+	}
+
 	// Find particles and determine the surname
 	// Particles are words like "van", "von", "de" that precede the surname
 	// In library style, the particle moves to the end with the given name
@@ -236,25 +266,19 @@ func ForPerson(name string) string {
 		}
 	}
 
-	// Build the sort name
+	return joinSortName(surname, append(givenParts, particleParts...), generationalSuffixes)
+}
+
+// joinSortName builds "Surname, Given Names, Suffixes", leaving out empty parts.
+func joinSortName(surname string, givenParts, generationalSuffixes []string) string {
 	var result strings.Builder
 	result.WriteString(surname)
 
-	// Add given name parts
-	if len(givenParts) > 0 || len(particleParts) > 0 {
+	if len(givenParts) > 0 {
 		result.WriteString(", ")
-		if len(givenParts) > 0 {
-			result.WriteString(strings.Join(givenParts, " "))
-		}
-		if len(particleParts) > 0 {
-			if len(givenParts) > 0 {
-				result.WriteString(" ")
-			}
-			result.WriteString(strings.Join(particleParts, " "))
-		}
+		result.WriteString(strings.Join(givenParts, " "))
 	}
 
-	// Add generational suffixes
 	if len(generationalSuffixes) > 0 {
 		result.WriteString(", ")
 		result.WriteString(strings.Join(generationalSuffixes, ", "))
@@ -263,6 +287,20 @@ func ForPerson(name string) string {
 	return result.String()
 }
 
+// isFamilyNameFirst checks if locale's language writes names family name first.
+func isFamilyNameFirst(locale string) bool {
+	if locale == "" {
+		return false
+	}
+	lang, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	for _, l := range FamilyNameFirstLanguages {
+		if strings.EqualFold(lang, l) {
+			return true
+		}
+	}
+	return false
+}
+
 // isPrefix checks if a word is a name prefix (case-insensitive).
 func isPrefix(word string) bool {
 	for _, prefix := range Prefixes {
@@ -107,6 +107,14 @@ func TestForTitle(t *testing.T) {
 	}
 }
 
+func TestForTitleInLanguage(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "Hobbit, The", ForTitleInLanguage("The Hobbit", ""))
+	assert.Equal(t, "Hobbit, The", ForTitleInLanguage("The Hobbit", "en"))
+	assert.Equal(t, "Hobbit, The", ForTitleInLanguage("The Hobbit", "en-GB"))
+	assert.Equal(t, "A Rua", ForTitleInLanguage(" A Rua", "pt"), "English articles aren't moved in other languages")
+}
+
 func TestForPerson(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -327,12 +335,72 @@ func TestForPerson(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := ForPerson(tt.input)
+			result := ForPerson(tt.input, "")
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
 
+func TestForPerson_FamilyNameFirst(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		input    string
+		locale   string
+		expected string
+	}{
+		{
+			name:     "japanese name",
+			input:    "Murakami Haruki",
+			locale:   "ja",
+			expected: "Murakami, Haruki",
+		},
+		{
+			name:     "region subtag",
+			input:    "Mo Yan",
+			locale:   "zh-CN",
+			expected: "Mo, Yan",
+		},
+		{
+			name:     "underscore separator",
+			input:    "Han Kang",
+			locale:   "ko_KR",
+			expected: "Han, Kang",
+		},
+		{
+			name:     "hungarian name",
+			input:    "Kertész Imre",
+			locale:   "hu",
+			expected: "Kertész, Imre",
+		},
+		{
+			name:     "prefix and suffix",
+			input:    "Dr. Tanaka Ichiro Jr.",
+			locale:   "ja",
+			expected: "Tanaka, Ichiro, Jr.",
+		},
+		{
+			name:     "name without spaces",
+			input:    "村上春樹",
+			locale:   "ja",
+			expected: "村上春樹",
+		},
+		{
+			name:     "western locale",
+			input:    "Haruki Murakami",
+			locale:   "en-US",
+			expected: "Murakami, Haruki",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, ForPerson(tt.input, tt.locale))
+		})
+	}
+}
+
 func TestIsPrefix(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -10,7 +10,8 @@ import (
 //
 // Each user-visible sort level produces ONE OrderClause, except the
 // series level which expands to four (name, range discriminator, start,
-// and endpoint).
+// and endpoint) and the reading order level which expands to three (name,
+// reading position, and start).
 //
 // Expressions are built from the whitelisted field branches in
 // OrderClauses and never embed user input, so no parameter args are
@@ -52,25 +53,34 @@ func OrderClauses(levels []SortLevel) []OrderClause {
 			// Pick one primary membership by sort order, then sort by its
 			// series name and position. Omnibus ranges follow singles in the
 			// same series and use the endpoint as a final numeric tie-breaker.
-			nameExpr := `(SELECT s.sort_name
-                          FROM book_series bs
-                          JOIN series s ON s.id = bs.series_id
-                          WHERE bs.book_id = b.id
-                          ORDER BY bs.sort_order ASC, bs.id ASC
-                          LIMIT 1)`
 			rangeExpr := primarySeriesValue(`(bs.series_number_end IS NOT NULL)`)
 			startExpr := primarySeriesValue("bs.series_number")
 			endExpr := primarySeriesValue("COALESCE(bs.series_number_end, bs.series_number)")
 			out = append(out,
-				nullsLast(nameExpr, l.Direction),
+				nullsLast(primarySeriesNameExpr, l.Direction),
 				nullsLast(rangeExpr, DirAsc),
 				nullsLast(startExpr, DirAsc),
 				nullsLast(endExpr, DirAsc),
 			)
 
+		case FieldReadingOrder:
+			// Same primary membership and series name as FieldSeries, but
+			// positioned by reading order. Books without one fall back to
+			// their series number, and ties keep publication order.
+			readingExpr := primarySeriesValue("COALESCE(bs.reading_order, bs.series_number)")
+			startExpr := primarySeriesValue("bs.series_number")
+			out = append(out,
+				nullsLast(primarySeriesNameExpr, l.Direction),
+				nullsLast(readingExpr, DirAsc),
+				nullsLast(startExpr, DirAsc),
+			)
+
 		case FieldDateAdded:
 			out = append(out, nullsLast("b.created_at", l.Direction))
 
+		case FieldDateUpdated:
+			out = append(out, nullsLast("b.updated_at", l.Direction))
+
 		case FieldDateReleased:
 			out = append(out, nullsLast(newestFileCoalesce("release_date"), l.Direction))
 
@@ -84,6 +94,15 @@ func OrderClauses(levels []SortLevel) []OrderClause {
 	return out
 }
 
+// primarySeriesNameExpr is the sort name of the book's primary series
+// membership, picked the same way as primarySeriesValue.
+const primarySeriesNameExpr = `(SELECT s.sort_name
+                               FROM book_series bs
+                               JOIN series s ON s.id = bs.series_id
+                               WHERE bs.book_id = b.id
+                               ORDER BY bs.sort_order ASC, bs.id ASC
+                               LIMIT 1)`
+
 func primarySeriesValue(valueExpr string) string {
 	return fmt.Sprintf(`(SELECT %s
                          FROM book_series bs
@@ -28,6 +28,15 @@ func TestOrderClauses_DescDirection(t *testing.T) {
 	assert.Equal(t, "b.created_at IS NULL, b.created_at DESC", got[0].Expression)
 }
 
+func TestOrderClauses_DateUpdated(t *testing.T) {
+	t.Parallel()
+
+	got := OrderClauses([]SortLevel{
+		{Field: FieldDateUpdated, Direction: DirDesc},
+	})
+	assert.Equal(t, "b.updated_at IS NULL, b.updated_at DESC", got[0].Expression)
+}
+
 func TestOrderClauses_SeriesIncludesOmnibusOrdering(t *testing.T) {
 	t.Parallel()
 
@@ -59,6 +68,27 @@ func TestOrderClauses_SeriesIncludesOmnibusOrdering(t *testing.T) {
 	}
 }
 
+func TestOrderClauses_ReadingOrderFallsBackToSeriesNumber(t *testing.T) {
+	t.Parallel()
+
+	got := OrderClauses([]SortLevel{
+		{Field: FieldReadingOrder, Direction: DirDesc},
+	})
+
+	// Reading order expands to name, reading position, and start. Position
+	// clauses are always ASC regardless of the chosen name direction.
+	assert.Len(t, got, 3)
+	assert.Contains(t, got[0].Expression, "s.sort_name")
+	assert.Contains(t, got[0].Expression, "DESC")
+	assert.Contains(t, got[1].Expression, "COALESCE(bs.reading_order, bs.series_number)")
+	assert.Contains(t, got[1].Expression, "ASC")
+	assert.Contains(t, got[2].Expression, "bs.series_number")
+	assert.Contains(t, got[2].Expression, "ASC")
+	for _, clause := range got {
+		assert.Contains(t, clause.Expression, "ORDER BY bs.sort_order ASC, bs.id ASC")
+	}
+}
+
 func TestOrderClauses_NewestFileFallback(t *testing.T) {
 	t.Parallel()
 
@@ -11,7 +11,9 @@ const (
 	FieldTitle        = "title"
 	FieldAuthor       = "author"
 	FieldSeries       = "series"
+	FieldReadingOrder = "reading_order"
 	FieldDateAdded    = "date_added"
+	FieldDateUpdated  = "date_updated"
 	FieldDateReleased = "date_released"
 	FieldPageCount    = "page_count"
 	FieldDuration     = "duration"
@@ -23,7 +25,9 @@ func AllFields() []string {
 		FieldTitle,
 		FieldAuthor,
 		FieldSeries,
+		FieldReadingOrder,
 		FieldDateAdded,
+		FieldDateUpdated,
 		FieldDateReleased,
 		FieldPageCount,
 		FieldDuration,
@@ -33,8 +37,8 @@ func AllFields() []string {
 // IsValidField returns true if s is a whitelisted sort field token.
 func IsValidField(s string) bool {
 	switch s {
-	case FieldTitle, FieldAuthor, FieldSeries,
-		FieldDateAdded, FieldDateReleased,
+	case FieldTitle, FieldAuthor, FieldSeries, FieldReadingOrder,
+		FieldDateAdded, FieldDateUpdated, FieldDateReleased,
 		FieldPageCount, FieldDuration:
 		return true
 	}
@@ -10,8 +10,8 @@ func TestIsValidField(t *testing.T) {
 	t.Parallel()
 
 	valid := []string{
-		FieldTitle, FieldAuthor, FieldSeries,
-		FieldDateAdded, FieldDateReleased,
+		FieldTitle, FieldAuthor, FieldSeries, FieldReadingOrder,
+		FieldDateAdded, FieldDateUpdated, FieldDateReleased,
 		FieldPageCount, FieldDuration,
 	}
 	for _, f := range valid {
@@ -38,8 +38,8 @@ func TestAllFields_Stable(t *testing.T) {
 	// list changes, the TS whitelist must be updated in lockstep. This
 	// test just pins the expected order so additions are explicit.
 	expected := []string{
-		"title", "author", "series",
-		"date_added", "date_released",
+		"title", "author", "series", "reading_order",
+		"date_added", "date_updated", "date_released",
 		"page_count", "duration",
 	}
 	assert.Equal(t, expected, AllFields())
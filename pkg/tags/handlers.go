@@ -1,6 +1,7 @@
 package tags
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
@@ -14,10 +15,39 @@ import (
 	"github.com/shishobooks/shisho/pkg/search"
 )
 
+// MetadataHasher refreshes the metadata hashes of books after a change to
+// their tags. This is used to break the import cycle between tags and books
+// packages.
+type MetadataHasher interface {
+	RefreshMetadataHashes(ctx context.Context, bookIDs []int) error
+}
+
 type handler struct {
-	tagService    *Service
-	aliasService  *aliases.Service
-	searchService *search.Service
+	tagService     *Service
+	aliasService   *aliases.Service
+	searchService  *search.Service
+	metadataHasher MetadataHasher // optional, can be nil if not configured
+}
+
+// refreshMetadataHashes refreshes the metadata hashes of the given books,
+// or of every book tagged with tagID when bookIDs is nil. Tag names are
+// part of the hash, so this runs after tags are renamed, merged or deleted.
+func (h *handler) refreshMetadataHashes(ctx context.Context, tagID int, bookIDs []int) {
+	if h.metadataHasher == nil {
+		return
+	}
+	log := logger.FromContext(ctx)
+	if bookIDs == nil {
+		var err error
+		bookIDs, err = h.tagService.GetBookIDs(ctx, tagID)
+		if err != nil {
+			log.Warn("failed to get tag book IDs for metadata hash refresh", logger.Data{"tag_id": tagID, "error": err.Error()})
+			return
+		}
+	}
+	if err := h.metadataHasher.RefreshMetadataHashes(ctx, bookIDs); err != nil {
+		log.Warn("failed to update book metadata hashes", logger.Data{"tag_id": tagID, "error": err.Error()})
+	}
 }
 
 func (h *handler) retrieve(c echo.Context) error {
@@ -141,6 +171,7 @@ func (h *handler) update(c echo.Context) error {
 			if err := h.searchService.IndexTag(ctx, existing); err != nil {
 				log.Warn("failed to re-index target tag after merge", logger.Data{"tag_id": existing.ID, "error": err.Error()})
 			}
+			h.refreshMetadataHashes(ctx, existing.ID, nil)
 
 			bookCount, _ := h.tagService.GetBookCount(ctx, existing.ID)
 			aliasList, _ := h.aliasService.ListAliases(ctx, aliases.TagConfig, existing.ID)
@@ -174,6 +205,9 @@ func (h *handler) update(c echo.Context) error {
 			log.Warn("failed to update search index for tag", logger.Data{"tag_id": tag.ID, "error": err.Error()})
 		}
 	}
+	if nameChanged {
+		h.refreshMetadataHashes(ctx, id, nil)
+	}
 
 	bookCount, _ := h.tagService.GetBookCount(ctx, id)
 	aliasList, _ := h.aliasService.ListAliases(ctx, aliases.TagConfig, id)
@@ -254,10 +288,55 @@ func (h *handler) merge(c echo.Context) error {
 	if err := h.searchService.IndexTag(ctx, tag); err != nil {
 		log.Warn("failed to re-index target tag after merge", logger.Data{"tag_id": tag.ID, "error": err.Error()})
 	}
+	h.refreshMetadataHashes(ctx, id, nil)
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+func (h *handler) mergeVariants(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params := MergeTagVariantsPayload{}
+	if err := c.Bind(&params); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Check library access
+	if user, ok := c.Get("user").(*models.User); ok {
+		if !user.HasLibraryAccess(params.LibraryID) {
+			return errcodes.Forbidden("You don't have access to this library")
+		}
+	}
+
+	merged, err := h.tagService.MergeVariants(ctx, params.LibraryID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Remove the merged tags from the FTS index and re-index the ones they
+	// were merged into, which picked up their aliases
+	log := logger.FromContext(ctx)
+	targets := map[int]struct{}{}
+	for sourceID, targetID := range merged {
+		if err := h.searchService.DeleteFromTagIndex(ctx, sourceID); err != nil {
+			log.Warn("failed to remove merged tag from search index", logger.Data{"tag_id": sourceID, "error": err.Error()})
+		}
+		targets[targetID] = struct{}{}
+	}
+	for targetID := range targets {
+		tag, err := h.tagService.RetrieveTag(ctx, RetrieveTagOptions{ID: &targetID})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := h.searchService.IndexTag(ctx, tag); err != nil {
+			log.Warn("failed to re-index target tag after merge", logger.Data{"tag_id": tag.ID, "error": err.Error()})
+		}
+		h.refreshMetadataHashes(ctx, targetID, nil)
+	}
+
+	return errors.WithStack(c.JSON(http.StatusOK, MergeTagVariantsResponse{Merged: len(merged)}))
+}
+
 func (h *handler) deleteTag(c echo.Context) error {
 	ctx := c.Request().Context()
 	id, err := strconv.Atoi(c.Param("id"))
@@ -278,6 +357,11 @@ func (h *handler) deleteTag(c echo.Context) error {
 		}
 	}
 
+	bookIDs, err := h.tagService.GetBookIDs(ctx, id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
 	err = h.tagService.DeleteTag(ctx, id)
 	if err != nil {
 		return errors.WithStack(err)
@@ -287,6 +371,7 @@ func (h *handler) deleteTag(c echo.Context) error {
 	if err := h.searchService.DeleteFromTagIndex(ctx, id); err != nil {
 		log.Warn("failed to remove tag from search index", logger.Data{"tag_id": id, "error": err.Error()})
 	}
+	h.refreshMetadataHashes(ctx, id, bookIDs)
 
 	return c.NoContent(http.StatusNoContent)
 }
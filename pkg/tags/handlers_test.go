@@ -1,6 +1,7 @@
 package tags
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
@@ -289,3 +290,40 @@ func TestList_ResponseAliasesSerializeAsStringArray(t *testing.T) {
 		"aliases must unmarshal into []string, proving it is a JSON array of strings")
 	assert.ElementsMatch(t, []string{"SciFi", "SF"}, aliasStrings)
 }
+
+type recordingMetadataHasher struct {
+	bookIDs []int
+}
+
+func (r *recordingMetadataHasher) RefreshMetadataHashes(_ context.Context, bookIDs []int) error {
+	r.bookIDs = append(r.bookIDs, bookIDs...)
+	return nil
+}
+
+func TestUpdate_RenameRefreshesBookMetadataHashes(t *testing.T) {
+	t.Parallel()
+	db := setupHandlerTestDB(t)
+	lib := createTestLibrary(t, db)
+	hasher := &recordingMetadataHasher{}
+	h := newTestHandler(db)
+	h.metadataHasher = hasher
+
+	tag := seedTagWithBooks(t, db, lib, "Fantasy", []string{"Book A", "Book B"})
+	bookIDs, err := h.tagService.GetBookIDs(context.Background(), tag.ID)
+	require.NoError(t, err)
+	require.Len(t, bookIDs, 2)
+
+	newName := "High Fantasy"
+	body, err := json.Marshal(UpdateTagPayload{Name: &newName})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPatch, "/", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestEcho(t).NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(tag.ID))
+
+	require.NoError(t, h.update(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.ElementsMatch(t, bookIDs, hasher.bookIDs)
+}
@@ -10,15 +10,17 @@ import (
 )
 
 // RegisterRoutesWithGroup registers tag routes on a pre-configured group.
-func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, authMiddleware *auth.Middleware) {
+// metadataHasher is optional and can be nil if book metadata hashes don't need refreshing.
+func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, authMiddleware *auth.Middleware, metadataHasher MetadataHasher) {
 	tagService := NewService(db)
 	aliasService := aliases.NewService(db)
 	searchService := search.NewService(db)
 
 	h := &handler{
-		tagService:    tagService,
-		aliasService:  aliasService,
-		searchService: searchService,
+		tagService:     tagService,
+		aliasService:   aliasService,
+		searchService:  searchService,
+		metadataHasher: metadataHasher,
 	}
 
 	g.GET("", h.list)
@@ -27,4 +29,5 @@ func RegisterRoutesWithGroup(g *echo.Group, db *bun.DB, authMiddleware *auth.Mid
 	g.PATCH("/:id", h.update, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.DELETE("/:id", h.deleteTag, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 	g.POST("/:id/merge", h.merge, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
+	g.POST("/merge-variants", h.mergeVariants, authMiddleware.RequirePermission(models.ResourceBooks, models.OperationWrite))
 }
@@ -82,14 +82,26 @@ func (svc *Service) RetrieveTag(ctx context.Context, opts RetrieveTagOptions) (*
 	return tag, nil
 }
 
-// FindOrCreateTag finds an existing tag or creates a new one (case-insensitive match).
-func (svc *Service) FindOrCreateTag(ctx context.Context, name string, libraryID int) (*models.Tag, error) {
-	// Normalize the name by trimming whitespace
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return nil, errors.New("tag name cannot be empty")
+// Normalize resolves name to the name of the tag it refers to in the
+// library, so that spelling variants collapse to one canonical tag. Names
+// that don't match any existing tag are returned with their spacing cleaned
+// up.
+func (svc *Service) Normalize(ctx context.Context, name string, libraryID int) (string, error) {
+	name = aliases.NormalizeName(name)
+	tag, err := svc.findCanonicalTag(ctx, name, libraryID)
+	if err != nil {
+		if errors.Is(err, errcodes.NotFound("Tag")) {
+			return name, nil
+		}
+		return "", err
 	}
+	return tag.Name, nil
+}
 
+// findCanonicalTag finds the tag that name refers to, trying in order: a
+// tag with the same name (case-insensitive), a tag with a matching alias,
+// and a tag whose name only differs in case, spaces, hyphens or underscores.
+func (svc *Service) findCanonicalTag(ctx context.Context, name string, libraryID int) (*models.Tag, error) {
 	tag, err := svc.RetrieveTag(ctx, RetrieveTagOptions{
 		Name:      &name,
 		LibraryID: &libraryID,
@@ -106,6 +118,33 @@ func (svc *Service) FindOrCreateTag(ctx context.Context, name string, libraryID
 		return svc.RetrieveTag(ctx, RetrieveTagOptions{ID: &resourceID})
 	}
 
+	// Check spelling variants
+	resourceID, err := aliases.FindResourceIDByMatchKey(ctx, svc.db, aliases.TagConfig, name, libraryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcodes.NotFound("Tag")
+		}
+		return nil, errors.WithStack(err)
+	}
+	return svc.RetrieveTag(ctx, RetrieveTagOptions{ID: &resourceID})
+}
+
+// FindOrCreateTag finds the canonical tag for name (see Normalize) or
+// creates a new one.
+func (svc *Service) FindOrCreateTag(ctx context.Context, name string, libraryID int) (*models.Tag, error) {
+	name = aliases.NormalizeName(name)
+	if name == "" {
+		return nil, errors.New("tag name cannot be empty")
+	}
+
+	tag, err := svc.findCanonicalTag(ctx, name, libraryID)
+	if err == nil {
+		return tag, nil
+	}
+	if !errors.Is(err, errcodes.NotFound("Tag")) {
+		return nil, err
+	}
+
 	// Create new tag
 	tag = &models.Tag{
 		LibraryID: libraryID,
@@ -232,6 +271,20 @@ func (svc *Service) GetBookCount(ctx context.Context, tagID int) (int, error) {
 	return count, errors.WithStack(err)
 }
 
+// GetBookIDs returns the IDs of all books with this tag.
+func (svc *Service) GetBookIDs(ctx context.Context, tagID int) ([]int, error) {
+	var bookIDs []int
+	err := svc.db.NewSelect().
+		Model((*models.BookTag)(nil)).
+		Column("book_id").
+		Where("tag_id = ?", tagID).
+		Scan(ctx, &bookIDs)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return bookIDs, nil
+}
+
 // GetBooks returns all books with this tag.
 func (svc *Service) GetBooks(ctx context.Context, tagID int) ([]*models.Book, error) {
 	var books []*models.Book
@@ -305,6 +358,39 @@ func (svc *Service) MergeTags(ctx context.Context, targetID, sourceID int) error
 	})
 }
 
+// MergeVariants merges the tags in a library whose names are spelling
+// variants of each other (see aliases.MatchKey) into the oldest one. It
+// returns the ID each merged tag was merged into, keyed by the merged tag's
+// ID, so callers can update the search index.
+func (svc *Service) MergeVariants(ctx context.Context, libraryID int) (map[int]int, error) {
+	var tags []*models.Tag
+	err := svc.db.NewSelect().
+		Model(&tags).
+		Column("id", "name").
+		Where("library_id = ?", libraryID).
+		Order("id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	merged := map[int]int{}
+	keepers := map[string]int{}
+	for _, tag := range tags {
+		key := aliases.MatchKey(tag.Name)
+		keeperID, ok := keepers[key]
+		if !ok {
+			keepers[key] = tag.ID
+			continue
+		}
+		if err := svc.MergeTags(ctx, keeperID, tag.ID); err != nil {
+			return merged, err
+		}
+		merged[tag.ID] = keeperID
+	}
+	return merged, nil
+}
+
 // CleanupOrphanedTags deletes tags with no book associations and returns the
 // IDs of deleted tags. Callers must pass the returned IDs to
 // searchService.DeleteFromTagIndex to keep tags_fts in sync — tags_fts is a
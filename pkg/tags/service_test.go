@@ -102,3 +102,76 @@ func TestFindOrCreateTag_NoMatch_CreatesNew(t *testing.T) {
 	assert.Equal(t, "Horror", found.Name)
 	assert.Equal(t, lib.ID, found.LibraryID)
 }
+
+func TestFindOrCreateTag_SpellingVariantMatch(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	lib := createTestLibrary(t, db)
+
+	tag := &models.Tag{LibraryID: lib.ID, Name: "Sci-Fi"}
+	err := svc.CreateTag(ctx, tag)
+	require.NoError(t, err)
+
+	for _, variant := range []string{"SciFi", "sci fi", "sci_fi", "  SCI-FI "} {
+		found, err := svc.FindOrCreateTag(ctx, variant, lib.ID)
+		require.NoError(t, err, variant)
+		assert.Equal(t, tag.ID, found.ID, variant)
+	}
+
+	name, err := svc.Normalize(ctx, "scifi", lib.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Sci-Fi", name)
+
+	// Variants are only matched within the same library.
+	other := createTestLibrary(t, db)
+	found, err := svc.FindOrCreateTag(ctx, "SciFi", other.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, tag.ID, found.ID)
+}
+
+func TestNormalizeTag_NoMatch(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	svc := NewService(db)
+
+	lib := createTestLibrary(t, db)
+
+	name, err := svc.Normalize(context.Background(), "  Space   Opera ", lib.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Space Opera", name)
+}
+
+func TestMergeTagVariants_ScopedToLibrary(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	ctx := context.Background()
+	svc := NewService(db)
+
+	lib := createTestLibrary(t, db)
+	other := createTestLibrary(t, db)
+
+	create := func(libraryID int, name string) *models.Tag {
+		tag := &models.Tag{LibraryID: libraryID, Name: name}
+		require.NoError(t, svc.CreateTag(ctx, tag))
+		return tag
+	}
+	keeper := create(lib.ID, "Sci-Fi")
+	variant := create(lib.ID, "scifi")
+	unrelated := create(lib.ID, "Fantasy")
+	otherKeeper := create(other.ID, "Sci-Fi")
+	otherVariant := create(other.ID, "Sci Fi")
+
+	merged, err := svc.MergeVariants(ctx, lib.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{variant.ID: keeper.ID}, merged)
+
+	_, err = svc.RetrieveTag(ctx, RetrieveTagOptions{ID: &variant.ID})
+	require.Error(t, err)
+	for _, id := range []int{keeper.ID, unrelated.ID, otherKeeper.ID, otherVariant.ID} {
+		_, err := svc.RetrieveTag(ctx, RetrieveTagOptions{ID: &id})
+		require.NoError(t, err)
+	}
+}
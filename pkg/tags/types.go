@@ -44,3 +44,12 @@ type UpdateTagPayload struct {
 type MergeTagsPayload struct {
 	SourceID int `json:"source_id" validate:"required,min=1"`
 }
+
+type MergeTagVariantsPayload struct {
+	LibraryID int `json:"library_id" validate:"required,min=1"`
+}
+
+// MergeTagVariantsResponse reports how many tags were merged away.
+type MergeTagVariantsResponse struct {
+	Merged int `json:"merged"`
+}
@@ -0,0 +1,133 @@
+// Package thumbnails generates and caches scaled-down copies of file covers.
+package thumbnails
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif" // Register GIF decoder for covers.
+	"image/jpeg"
+	_ "image/png" // Register PNG decoder for covers.
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // Register WebP decoder for covers.
+)
+
+// DefaultMaxDim is the bounding box used when no size is requested.
+const DefaultMaxDim = 400
+
+// MaxDim is the largest bounding box a thumbnail can be generated for.
+// Bounding it keeps arbitrary sizes from filling the cache with variants.
+const MaxDim = 2048
+
+// jpegQuality is the JPEG quality used for thumbnails.
+const jpegQuality = 85
+
+// GenerateThumbnail scales the image at srcPath down to fit within a
+// maxDim x maxDim box, preserving the aspect ratio, and writes it to dstPath
+// as a JPEG. Images that already fit are re-encoded at their original size.
+// The file is written atomically so concurrent readers never see a partial
+// image.
+func GenerateThumbnail(srcPath, dstPath string, maxDim int) error {
+	if maxDim <= 0 || maxDim > MaxDim {
+		return errors.Errorf("max dimension %d out of range (1-%d)", maxDim, MaxDim)
+	}
+
+	srcFile, err := os.Open(srcPath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer srcFile.Close()
+
+	srcImg, _, err := image.Decode(srcFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode cover")
+	}
+
+	srcBounds := srcImg.Bounds()
+	width, height := fit(srcBounds.Dx(), srcBounds.Dy(), maxDim)
+	dstImg := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dstImg, dstImg.Bounds(), srcImg, srcBounds, draw.Over, nil)
+
+	if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	tmpPath := dstPath + ".tmp"
+	outFile, err := os.Create(tmpPath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := jpeg.Encode(outFile, dstImg, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		outFile.Close()
+		os.Remove(tmpPath)
+		return errors.WithStack(err)
+	}
+	if err := outFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return errors.WithStack(err)
+	}
+	if err := os.Rename(tmpPath, dstPath); err != nil {
+		os.Remove(tmpPath)
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// fit returns the size of a width x height image scaled down to fit within a
+// maxDim x maxDim box. Images that already fit keep their size.
+func fit(width, height, maxDim int) (int, int) {
+	if width <= maxDim && height <= maxDim {
+		return width, height
+	}
+	if width >= height {
+		return maxDim, max(height*maxDim/width, 1)
+	}
+	return max(width*maxDim/height, 1), maxDim
+}
+
+// Cache stores generated thumbnails under its own directory, one folder per
+// file.
+type Cache struct {
+	dir string
+}
+
+// NewCache creates a new Cache rooted in the given cache directory.
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Path returns where the thumbnail of fileID's cover at maxDim is stored.
+func (c *Cache) Path(fileID, maxDim int) string {
+	return filepath.Join(c.rootDir(), strconv.Itoa(fileID), fmt.Sprintf("%d.jpg", maxDim))
+}
+
+// Generate creates the thumbnail of the cover at coverPath for fileID unless
+// an up-to-date one is already cached. It reports whether a new thumbnail was
+// written.
+func (c *Cache) Generate(coverPath string, fileID, maxDim int) (bool, error) {
+	coverInfo, err := os.Stat(coverPath)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	dstPath := c.Path(fileID, maxDim)
+	if thumbInfo, err := os.Stat(dstPath); err == nil && !thumbInfo.ModTime().Before(coverInfo.ModTime()) {
+		return false, nil
+	}
+	if err := GenerateThumbnail(coverPath, dstPath, maxDim); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Invalidate removes all cached thumbnails for a file.
+func (c *Cache) Invalidate(fileID int) error {
+	return errors.WithStack(os.RemoveAll(filepath.Join(c.rootDir(), strconv.Itoa(fileID))))
+}
+
+// rootDir returns the directory this cache owns.
+func (c *Cache) rootDir() string {
+	return filepath.Join(c.dir, "thumbnails")
+}
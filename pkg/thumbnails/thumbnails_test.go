@@ -0,0 +1,91 @@
+package thumbnails
+
+import (
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePNG(t *testing.T, path string, width, height int) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: 200, A: 255})
+		}
+	}
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(f, img))
+	require.NoError(t, f.Close())
+}
+
+func decodedSize(t *testing.T, path string) (int, int) {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	cfg, err := jpeg.DecodeConfig(f)
+	require.NoError(t, err)
+	return cfg.Width, cfg.Height
+}
+
+func TestGenerateThumbnail(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	src := filepath.Join(dir, "cover.png")
+	writePNG(t, src, 300, 600)
+
+	dst := filepath.Join(dir, "out", "thumb.jpg")
+	require.NoError(t, GenerateThumbnail(src, dst, 100))
+	w, h := decodedSize(t, dst)
+	assert.Equal(t, 50, w)
+	assert.Equal(t, 100, h)
+
+	// Covers that already fit keep their size.
+	require.NoError(t, GenerateThumbnail(src, dst, 1000))
+	w, h = decodedSize(t, dst)
+	assert.Equal(t, 300, w)
+	assert.Equal(t, 600, h)
+
+	require.Error(t, GenerateThumbnail(src, dst, MaxDim+1))
+}
+
+func TestCache_Generate(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cover := filepath.Join(dir, "cover.png")
+	writePNG(t, cover, 40, 20)
+	c := NewCache(filepath.Join(dir, "cache"))
+
+	generated, err := c.Generate(cover, 7, 10)
+	require.NoError(t, err)
+	assert.True(t, generated)
+	w, h := decodedSize(t, c.Path(7, 10))
+	assert.Equal(t, 10, w)
+	assert.Equal(t, 5, h)
+
+	// An up-to-date thumbnail isn't regenerated.
+	generated, err = c.Generate(cover, 7, 10)
+	require.NoError(t, err)
+	assert.False(t, generated)
+
+	// A cover changed after the thumbnail was made is.
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(cover, future, future))
+	generated, err = c.Generate(cover, 7, 10)
+	require.NoError(t, err)
+	assert.True(t, generated)
+
+	require.NoError(t, c.Invalidate(7))
+	_, err = os.Stat(c.Path(7, 10))
+	assert.True(t, os.IsNotExist(err))
+}
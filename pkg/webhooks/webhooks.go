@@ -0,0 +1,237 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/config"
+	"github.com/shishobooks/shisho/pkg/version"
+)
+
+// Events a webhook can subscribe to.
+const (
+	EventBookCreated   = "book.created"
+	EventBookUpdated   = "book.updated"
+	EventBookDeleted   = "book.deleted"
+	EventScanCompleted = "scan.completed"
+)
+
+// Headers sent with every delivery.
+const (
+	EventHeader     = "X-Shisho-Event"
+	DeliveryHeader  = "X-Shisho-Delivery"
+	SignatureHeader = "X-Shisho-Signature" // "sha256=" + hex HMAC of the body, when the webhook has a secret
+)
+
+const (
+	// maxAttempts is how many times a delivery is tried before it's dropped.
+	maxAttempts = 5
+	// queueSize bounds the deliveries waiting to be sent. Events that arrive
+	// while it's full are dropped rather than blocking the caller.
+	queueSize = 256
+	// senders is the number of deliveries sent concurrently.
+	senders = 2
+)
+
+// Payload is the JSON body of a delivery.
+type Payload struct {
+	ID        string    `json:"id"` // Unique per event and webhook; stays the same across retries
+	Event     string    `json:"event"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      any       `json:"data"`
+}
+
+// BookData is the payload data of the book events.
+type BookData struct {
+	BookID    int      `json:"book_id"`
+	LibraryID int      `json:"library_id,omitempty"`
+	Title     string   `json:"title,omitempty"`
+	Changes   []string `json:"changes,omitempty"` // Fields that were updated, for book.updated
+}
+
+// ScanData is the payload data of scan.completed.
+type ScanData struct {
+	JobID        int  `json:"job_id"`
+	LibraryID    *int `json:"library_id,omitempty"` // Unset when every library was scanned
+	BooksCreated int  `json:"books_created"`
+	BooksUpdated int  `json:"books_updated"`
+	BooksDeleted int  `json:"books_deleted"`
+	FilesCreated int  `json:"files_created"`
+	FilesUpdated int  `json:"files_updated"`
+	FilesDeleted int  `json:"files_deleted"`
+	FilesSkipped int  `json:"files_skipped"`
+	FilesErrored int  `json:"files_errored"`
+}
+
+type delivery struct {
+	hook    config.Webhook
+	id      string
+	event   string
+	body    []byte
+	attempt int
+}
+
+// Dispatcher sends events to the configured webhooks in the background.
+// Send never blocks: deliveries are queued, sent by a small pool of
+// goroutines, and retried with exponential backoff when the endpoint fails.
+// A nil Dispatcher, or one without webhooks, drops every event.
+type Dispatcher struct {
+	hooks      []config.Webhook
+	client     *http.Client
+	retryDelay time.Duration // Delay before the first retry; doubles with each attempt
+	log        logger.Logger
+
+	queue     chan *delivery
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewDispatcher starts a dispatcher for hooks. It returns nil when there are
+// no hooks, which is safe to use.
+func NewDispatcher(hooks []config.Webhook) *Dispatcher {
+	if len(hooks) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &Dispatcher{
+		hooks:      hooks,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		retryDelay: 2 * time.Second,
+		log:        logger.New().Root(logger.Data{"component": "webhooks"}),
+		queue:      make(chan *delivery, queueSize),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+	for i := 0; i < senders; i++ {
+		d.wg.Add(1)
+		go d.run()
+	}
+	return d
+}
+
+// Send queues event for every webhook subscribed to it.
+func (d *Dispatcher) Send(event string, data any) {
+	if d == nil {
+		return
+	}
+	for _, hook := range d.hooks {
+		if len(hook.Events) > 0 && !slices.Contains(hook.Events, event) {
+			continue
+		}
+		payload := Payload{
+			ID:        uuid.New().String(),
+			Event:     event,
+			Timestamp: time.Now().UTC(),
+			Data:      data,
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			d.log.Err(err).Error("failed to encode webhook payload", logger.Data{"event": event})
+			return
+		}
+		d.enqueue(&delivery{hook: hook, id: payload.ID, event: event, body: body})
+	}
+}
+
+// Close stops sending. Queued deliveries and pending retries are dropped,
+// and requests in flight are aborted.
+func (d *Dispatcher) Close() {
+	if d == nil {
+		return
+	}
+	d.closeOnce.Do(func() {
+		d.cancel()
+		d.wg.Wait()
+	})
+}
+
+func (d *Dispatcher) enqueue(del *delivery) {
+	if d.ctx.Err() != nil {
+		return
+	}
+	select {
+	case d.queue <- del:
+	default:
+		d.log.Warn("webhook queue full, dropping delivery", logger.Data{"event": del.event, "url": del.hook.URL})
+	}
+}
+
+func (d *Dispatcher) run() {
+	defer d.wg.Done()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case del := <-d.queue:
+			d.deliver(del)
+		}
+	}
+}
+
+// deliver sends del once and, if that fails with a retryable error,
+// schedules the next attempt instead of waiting for it, so one slow endpoint
+// doesn't hold up the others.
+func (d *Dispatcher) deliver(del *delivery) {
+	del.attempt++
+	retry, err := d.post(del)
+	if err == nil {
+		return
+	}
+	data := logger.Data{"event": del.event, "url": del.hook.URL, "attempt": del.attempt, "error": err.Error()}
+	if !retry || del.attempt >= maxAttempts {
+		d.log.Warn("webhook delivery failed", data)
+		return
+	}
+	d.log.Debug("webhook delivery failed, retrying", data)
+	time.AfterFunc(d.retryDelay<<(del.attempt-1), func() { d.enqueue(del) })
+}
+
+// post sends del and reports whether a failure is worth retrying: network
+// errors, timeouts, rate limiting, and server errors are, other responses
+// aren't.
+func (d *Dispatcher) post(del *delivery) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, del.hook.URL, bytes.NewReader(del.body))
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Shisho/"+version.Version)
+	req.Header.Set(EventHeader, del.event)
+	req.Header.Set(DeliveryHeader, del.id)
+	if del.hook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(del.hook.Secret, del.body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return d.ctx.Err() == nil, errors.WithStack(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry = resp.StatusCode >= 500 || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests
+	return retry, errors.Errorf("unexpected status %d", resp.StatusCode)
+}
+
+// Sign returns the signature header value for body: "sha256=" followed by
+// the hex HMAC-SHA256 of body keyed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,119 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shishobooks/shisho/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type received struct {
+	headers http.Header
+	body    []byte
+}
+
+// newReceiver starts a server that answers each request with the next of
+// statuses (repeating the last one) and records what it got.
+func newReceiver(t *testing.T, statuses ...int) (*httptest.Server, func() []received) {
+	t.Helper()
+	var mu sync.Mutex
+	var got []received
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		got = append(got, received{headers: r.Header.Clone(), body: body})
+		status := statuses[min(len(got), len(statuses))-1]
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []received {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]received(nil), got...)
+	}
+}
+
+func TestDispatcher_SignsAndFiltersEvents(t *testing.T) {
+	t.Parallel()
+
+	srv, got := newReceiver(t, http.StatusNoContent)
+	d := NewDispatcher([]config.Webhook{
+		{URL: srv.URL, Events: []string{EventBookDeleted}, Secret: "s3cret"},
+	})
+	t.Cleanup(d.Close)
+
+	d.Send(EventBookCreated, BookData{BookID: 1})
+	d.Send(EventBookDeleted, BookData{BookID: 2})
+
+	require.Eventually(t, func() bool { return len(got()) == 1 }, 2*time.Second, 5*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	deliveries := got()
+	require.Len(t, deliveries, 1, "only subscribed events are sent")
+
+	r := deliveries[0]
+	assert.Equal(t, EventBookDeleted, r.headers.Get(EventHeader))
+	assert.Equal(t, "application/json", r.headers.Get("Content-Type"))
+	assert.Equal(t, Sign("s3cret", r.body), r.headers.Get(SignatureHeader))
+
+	var payload struct {
+		ID    string   `json:"id"`
+		Event string   `json:"event"`
+		Data  BookData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(r.body, &payload))
+	assert.Equal(t, EventBookDeleted, payload.Event)
+	assert.Equal(t, r.headers.Get(DeliveryHeader), payload.ID)
+	assert.Equal(t, 2, payload.Data.BookID)
+}
+
+func TestDispatcher_RetriesServerErrors(t *testing.T) {
+	t.Parallel()
+
+	srv, got := newReceiver(t, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK)
+	d := NewDispatcher([]config.Webhook{{URL: srv.URL}})
+	d.retryDelay = time.Millisecond
+	t.Cleanup(d.Close)
+
+	d.Send(EventScanCompleted, ScanData{JobID: 7})
+
+	require.Eventually(t, func() bool { return len(got()) == 3 }, 2*time.Second, 5*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	deliveries := got()
+	require.Len(t, deliveries, 3, "no more attempts after a success")
+	assert.Equal(t, deliveries[0].body, deliveries[2].body, "retries resend the same payload")
+	assert.Empty(t, deliveries[0].headers.Get(SignatureHeader), "unsigned without a secret")
+}
+
+func TestDispatcher_GivesUp(t *testing.T) {
+	t.Parallel()
+
+	failing, gotFailing := newReceiver(t, http.StatusInternalServerError)
+	rejecting, gotRejecting := newReceiver(t, http.StatusBadRequest)
+	d := NewDispatcher([]config.Webhook{{URL: failing.URL}, {URL: rejecting.URL}})
+	d.retryDelay = time.Millisecond
+	t.Cleanup(d.Close)
+
+	d.Send(EventBookUpdated, BookData{BookID: 1, Changes: []string{"title"}})
+
+	require.Eventually(t, func() bool { return len(gotFailing()) == maxAttempts }, 2*time.Second, 5*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, gotFailing(), maxAttempts)
+	assert.Len(t, gotRejecting(), 1, "client errors aren't retried")
+}
+
+func TestDispatcher_NilIsNoop(t *testing.T) {
+	t.Parallel()
+
+	d := NewDispatcher(nil)
+	assert.Nil(t, d)
+	d.Send(EventBookCreated, BookData{BookID: 1})
+	d.Close()
+}
@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/epub"
+	"github.com/shishobooks/shisho/pkg/fingerprint"
+	"github.com/shishobooks/shisho/pkg/joblogs"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// indexFileContent adds the body text of an EPUB main file to the content
+// search index when config.IndexFullText is on. The text is only extracted
+// again when the file's sha256 differs from the one it was last indexed at.
+// Failures are non-fatal and only logged.
+//
+// It runs for unchanged files too, so turning the setting on indexes
+// existing books on the next scan.
+func (w *Worker) indexFileContent(ctx context.Context, file *models.File, jobLog *joblogs.JobLogger) {
+	if !w.config.IndexFullText || w.searchService == nil {
+		return
+	}
+	if file.FileType != models.FileTypeEPUB || file.FileRole == models.FileRoleSupplement {
+		return
+	}
+
+	log := logger.FromContext(ctx)
+	logWarn := func(msg string, data logger.Data) {
+		log.Warn(msg, data)
+		if jobLog != nil {
+			jobLog.Warn(msg, data)
+		}
+	}
+
+	hash, err := w.fileSHA256(ctx, file)
+	if err != nil {
+		logWarn("failed to hash file for content index", logger.Data{"file_id": file.ID, "error": err.Error()})
+		return
+	}
+	indexedHash, err := w.searchService.ContentHash(ctx, file.ID)
+	if err != nil {
+		logWarn("failed to look up content index", logger.Data{"file_id": file.ID, "error": err.Error()})
+		return
+	}
+	if indexedHash == hash {
+		return
+	}
+
+	text, err := epub.ExtractText(file.Filepath)
+	if err != nil {
+		logWarn("failed to extract text for content index", logger.Data{"file_id": file.ID, "error": err.Error()})
+		return
+	}
+	if err := w.searchService.IndexFileContent(ctx, file.ID, hash, text); err != nil {
+		logWarn("failed to update content index", logger.Data{"file_id": file.ID, "error": err.Error()})
+	}
+}
+
+// fileSHA256 returns the file's stored sha256 fingerprint, computing it from
+// disk when there isn't one yet. Fingerprints are dropped whenever a file's
+// size or modification time changes, so a stored one is current.
+func (w *Worker) fileSHA256(ctx context.Context, file *models.File) (string, error) {
+	fps, err := w.fingerprintService.ListForFile(ctx, file.ID, models.FingerprintAlgorithmSHA256)
+	if err != nil {
+		return "", err
+	}
+	if len(fps) > 0 {
+		return fps[0].Value, nil
+	}
+	return fingerprint.ComputeSHA256(file.Filepath)
+}
@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/shishobooks/shisho/internal/testgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan_IndexFullText(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.IndexFullText = true
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+	bookDir := testgen.CreateSubDir(t, libraryPath, "[Test Author] Test Book")
+	testgen.GenerateEPUB(t, bookDir, "book.epub", testgen.EPUBOptions{Title: "Test Book"})
+
+	require.NoError(t, tc.runScan())
+
+	files := tc.listFiles()
+	require.Len(t, files, 1)
+	results, err := tc.worker.searchService.SearchContent(tc.ctx, files[0].LibraryID, "a test chapter", 5)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, files[0].BookID, results[0].ID)
+
+	hash, err := tc.worker.searchService.ContentHash(tc.ctx, files[0].ID)
+	require.NoError(t, err)
+	expected, err := tc.worker.fileSHA256(tc.ctx, files[0])
+	require.NoError(t, err)
+	assert.Equal(t, expected, hash)
+}
+
+func TestScan_IndexFullTextEnabledLater(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+	bookDir := testgen.CreateSubDir(t, libraryPath, "[Test Author] Test Book")
+	testgen.GenerateEPUB(t, bookDir, "book.epub", testgen.EPUBOptions{Title: "Test Book"})
+
+	require.NoError(t, tc.runScan())
+
+	files := tc.listFiles()
+	require.Len(t, files, 1)
+	hash, err := tc.worker.searchService.ContentHash(tc.ctx, files[0].ID)
+	require.NoError(t, err)
+	assert.Empty(t, hash, "nothing is indexed while the setting is off")
+
+	// The unchanged file is indexed on the next scan once it's turned on
+	tc.worker.config.IndexFullText = true
+	require.NoError(t, tc.runScan())
+	hash, err = tc.worker.searchService.ContentHash(tc.ctx, files[0].ID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, hash)
+}
@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/libraries"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// libraryScheduleInterval is how often library scan schedules are checked,
+// which is also the finest resolution a schedule can have.
+const libraryScheduleInterval = time.Minute
+
+// libraryScanSchedules tracks when each library with a scan schedule is due
+// next. Schedules are read from the libraries table on every check, so edits
+// take effect without a restart and survive one. The zero value is ready to
+// use.
+type libraryScanSchedules struct {
+	entries map[int]*libraryScanEntry
+}
+
+type libraryScanEntry struct {
+	spec     string
+	schedule cron.Schedule
+	next     time.Time
+}
+
+// due returns the libraries whose next scheduled scan is at or before now and
+// advances their schedules. A library seen for the first time (or whose
+// schedule changed) starts counting from now, so a restart doesn't trigger a
+// burst of scans for runs missed while the server was down. Libraries with
+// invalid schedules are skipped.
+func (s *libraryScanSchedules) due(libs []*models.Library, now time.Time) []*models.Library {
+	if s.entries == nil {
+		s.entries = make(map[int]*libraryScanEntry)
+	}
+
+	seen := make(map[int]struct{}, len(libs))
+	var due []*models.Library
+	for _, lib := range libs {
+		if lib.ScanSchedule == "" {
+			continue
+		}
+		seen[lib.ID] = struct{}{}
+
+		entry, ok := s.entries[lib.ID]
+		if !ok || entry.spec != lib.ScanSchedule {
+			schedule, err := libraries.ParseScanSchedule(lib.ScanSchedule)
+			if err != nil {
+				delete(s.entries, lib.ID)
+				continue
+			}
+			s.entries[lib.ID] = &libraryScanEntry{spec: lib.ScanSchedule, schedule: schedule, next: schedule.Next(now)}
+			continue
+		}
+
+		if now.Before(entry.next) {
+			continue
+		}
+		due = append(due, lib)
+		entry.next = entry.schedule.Next(now)
+	}
+
+	for id := range s.entries {
+		if _, ok := seen[id]; !ok {
+			delete(s.entries, id)
+		}
+	}
+	return due
+}
+
+// scheduleLibraryScans enqueues a scan of each library whose scan schedule is
+// due. It runs alongside the global sync interval, which still scans every
+// library.
+func (w *Worker) scheduleLibraryScans() {
+	ticker := time.NewTicker(libraryScheduleInterval)
+	defer ticker.Stop()
+
+	var schedules libraryScanSchedules
+	log := w.log.Root(logger.Data{"scheduler": "library_scan"})
+	check := func(now time.Time) {
+		libs, err := w.libraryService.ListLibraries(w.ctx, libraries.ListLibrariesOptions{})
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Err(err).Error("failed to list libraries for scheduled library scans")
+			}
+			return
+		}
+		for _, lib := range schedules.due(libs, now) {
+			w.enqueueScheduledLibraryScan(lib, log)
+		}
+	}
+
+	// Prime the schedules so the first runs are counted from startup.
+	check(time.Now())
+	for {
+		select {
+		case <-w.shutdown:
+			w.doneLibraryScheduling <- struct{}{}
+			return
+		case now := <-ticker.C:
+			check(now)
+		}
+	}
+}
+
+// enqueueScheduledLibraryScan creates a scan job for lib unless a scan that
+// covers it is already pending or running.
+func (w *Worker) enqueueScheduledLibraryScan(lib *models.Library, log logger.Logger) {
+	hasActive, err := w.jobService.HasActiveJob(w.ctx, models.JobTypeScan, &lib.ID)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Err(err).Error("failed to check for active scan job", logger.Data{"library_id": lib.ID})
+		}
+		return
+	}
+	if hasActive {
+		log.Debug("scan job already running or pending, skipping scheduled library scan", logger.Data{"library_id": lib.ID})
+		return
+	}
+
+	scanJob := &models.Job{
+		Type:       models.JobTypeScan,
+		Status:     models.JobStatusPending,
+		DataParsed: &models.JobScanData{},
+		LibraryID:  &lib.ID,
+	}
+	if err := w.jobService.CreateJob(w.ctx, scanJob); err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Err(err).Error("failed to create scheduled library scan job", logger.Data{"library_id": lib.ID})
+		}
+		return
+	}
+	w.publishJobEvent("job.created", scanJob)
+	log.Info("created scheduled library scan job", logger.Data{"library_id": lib.ID, "schedule": lib.ScanSchedule})
+}
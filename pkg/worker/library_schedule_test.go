@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLibraryScanSchedules_Due(t *testing.T) {
+	t.Parallel()
+
+	var s libraryScanSchedules
+	incoming := &models.Library{ID: 1, ScanSchedule: "*/15 * * * *"}
+	archive := &models.Library{ID: 2, ScanSchedule: "0 3 * * *"}
+	manual := &models.Library{ID: 3}
+	libs := []*models.Library{incoming, archive, manual}
+
+	start := time.Date(2026, 3, 4, 10, 7, 0, 0, time.UTC)
+	assert.Empty(t, s.due(libs, start), "schedules are primed, not run, when first seen")
+
+	assert.Empty(t, s.due(libs, start.Add(7*time.Minute)))
+	assert.Equal(t, []*models.Library{incoming}, s.due(libs, start.Add(8*time.Minute)))
+	assert.Empty(t, s.due(libs, start.Add(9*time.Minute)), "a run isn't repeated within the same slot")
+
+	// Missed slots collapse into a single run.
+	assert.Equal(t, []*models.Library{incoming, archive}, s.due(libs, start.Add(17*time.Hour)))
+
+	// Changing a schedule restarts it from the current check.
+	incoming.ScanSchedule = "@every 1h"
+	now := start.Add(18 * time.Hour)
+	assert.Empty(t, s.due(libs, now))
+	assert.Equal(t, []*models.Library{incoming}, s.due(libs, now.Add(time.Hour)))
+
+	// Removed schedules are forgotten.
+	incoming.ScanSchedule = ""
+	s.due(libs, now)
+	assert.NotContains(t, s.entries, incoming.ID)
+	assert.Contains(t, s.entries, archive.ID)
+}
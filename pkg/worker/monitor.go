@@ -594,7 +594,7 @@ func (m *Monitor) processPendingEvents() {
 				// for the old path skips it, and track for search indexing.
 				movedFileIDs[movedFile.ID] = struct{}{}
 				affectedBookIDs[movedFile.BookID] = struct{}{}
-				// If the library has organize_file_structure enabled, the
+				// If the library organizes its folders or files, the
 				// book should be re-organized back into the structured layout
 				// even though the user renamed the folder. organizeBooks
 				// re-checks the library setting and no-ops otherwise, so this
@@ -987,7 +987,7 @@ func (m *Monitor) processEvent(ctx context.Context, path string, event pendingEv
 }
 
 // organizeBooks runs file organization for newly created books, moving files into
-// organized directory structures when the library has OrganizeFileStructure enabled.
+// organized directory structures when the library organizes files or folders.
 // It uses IgnorePath to suppress fsnotify events generated by the file moves.
 func (m *Monitor) organizeBooks(ctx context.Context, bookIDs map[int]struct{}) {
 	for bookID := range bookIDs {
@@ -1003,7 +1003,7 @@ func (m *Monitor) organizeBooks(ctx context.Context, bookIDs map[int]struct{}) {
 		library, err := m.worker.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{
 			ID: &book.LibraryID,
 		})
-		if err != nil || !library.OrganizeFileStructure {
+		if err != nil || !library.OrganizeEnabled() {
 			continue
 		}
 
@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/mediafile"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// PreviewMetadata implements the books.Scanner interface. It parses the file
+// at path and runs the library's metadata enrichers against a synthetic
+// file/book built from the parsed metadata, without creating any records.
+// Callers are responsible for checking that path is inside the library.
+func (w *Worker) PreviewMetadata(ctx context.Context, libraryID int, path string) (*mediafile.ParsedMetadata, error) {
+	stat, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, errcodes.NotFound("File")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to stat file")
+	}
+	if stat.IsDir() {
+		return nil, errcodes.ValidationError("Path must be a file.")
+	}
+
+	fileType := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	metadata, err := w.parseFileMetadata(ctx, path, fileType)
+	if err != nil {
+		var unsupportedErr *errcodes.UnsupportedFileTypeError
+		if errors.As(err, &unsupportedErr) {
+			return nil, err
+		}
+		return nil, errcodes.ValidationError(err.Error())
+	}
+	if metadata == nil {
+		return &mediafile.ParsedMetadata{}, nil
+	}
+
+	file, book := previewFileAndBook(metadata, libraryID, path, fileType, stat.Size())
+	return w.runMetadataEnrichers(ctx, metadata, file, book, libraryID, nil), nil
+}
+
+// ParseMetadata implements the books.Scanner interface. It parses the
+// metadata embedded in the file at path (including via plugin file parsers)
+// without running enrichers or creating any records.
+func (w *Worker) ParseMetadata(ctx context.Context, path string) (*mediafile.ParsedMetadata, error) {
+	fileType := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	metadata, err := w.parseFileMetadata(ctx, path, fileType)
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil {
+		return &mediafile.ParsedMetadata{}, nil
+	}
+	return metadata, nil
+}
+
+// previewFileAndBook builds the unsaved file and book that enrichers see
+// during a metadata preview, mirroring what a scan would create.
+func previewFileAndBook(metadata *mediafile.ParsedMetadata, libraryID int, path, fileType string, size int64) (*models.File, *models.Book) {
+	file := &models.File{
+		LibraryID:     libraryID,
+		Filepath:      path,
+		FileType:      fileType,
+		FileRole:      models.FileRoleMain,
+		FilesizeBytes: size,
+		PageCount:     metadata.PageCount,
+		WordCount:     metadata.WordCount,
+	}
+	if metadata.Duration > 0 {
+		durationSeconds := metadata.Duration.Seconds()
+		file.AudiobookDurationSeconds = &durationSeconds
+	}
+	for _, id := range metadata.Identifiers {
+		file.Identifiers = append(file.Identifiers, &models.FileIdentifier{
+			Type:  id.Type,
+			Value: id.Value,
+		})
+	}
+
+	book := &models.Book{
+		LibraryID: libraryID,
+		Title:     metadata.Title,
+	}
+	for _, a := range metadata.Authors {
+		book.Authors = append(book.Authors, &models.Author{
+			Person: &models.Person{Name: a.Name},
+		})
+	}
+	return file, book
+}
@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shishobooks/shisho/internal/testgen"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewMetadata_ParsesWithoutImporting(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+	bookDir := testgen.CreateSubDir(t, libraryPath, "Preview Book")
+	path := testgen.GenerateEPUB(t, bookDir, "preview.epub", testgen.EPUBOptions{
+		Title:   "Preview Title",
+		Authors: []string{"Preview Author"},
+	})
+
+	metadata, err := tc.worker.PreviewMetadata(tc.ctx, 1, path)
+	require.NoError(t, err)
+	assert.Equal(t, "Preview Title", metadata.Title)
+	require.Len(t, metadata.Authors, 1)
+	assert.Equal(t, "Preview Author", metadata.Authors[0].Name)
+
+	assert.Empty(t, tc.listBooks())
+	assert.Empty(t, tc.listFiles())
+}
+
+func TestPreviewMetadata_MissingFile(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+
+	_, err := tc.worker.PreviewMetadata(tc.ctx, 1, filepath.Join(libraryPath, "missing.epub"))
+	require.ErrorIs(t, err, errcodes.NotFound("File"))
+}
+
+func TestPreviewMetadata_UnsupportedFileType(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+	path := filepath.Join(libraryPath, "notes.xyz")
+	require.NoError(t, os.WriteFile(path, []byte("not a book"), 0o644))
+
+	_, err := tc.worker.PreviewMetadata(tc.ctx, 1, path)
+	var unsupportedErr *errcodes.UnsupportedFileTypeError
+	require.ErrorAs(t, err, &unsupportedErr)
+	assert.Equal(t, "xyz", unsupportedErr.FileType)
+
+	var codeErr *errcodes.Error
+	require.ErrorAs(t, err, &codeErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, codeErr.HTTPCode)
+	assert.Equal(t, "unsupported_file_type", codeErr.Code)
+}
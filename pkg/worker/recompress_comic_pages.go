@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/segmentio/encoding/json"
+	"github.com/shishobooks/shisho/pkg/books"
+	"github.com/shishobooks/shisho/pkg/cbz"
+	"github.com/shishobooks/shisho/pkg/cbzpages"
+	"github.com/shishobooks/shisho/pkg/joblogs"
+	"github.com/shishobooks/shisho/pkg/jobs"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// ProcessRecompressComicPagesJob re-encodes the oversized PNG pages of the
+// job's CBZ files as JPEG. Copies are written to <cache_dir>/recompressed
+// unless the job asks for the files to be replaced in place. A file that
+// fails is recorded in the results and the job moves on to the next one.
+func (w *Worker) ProcessRecompressComicPagesJob(ctx context.Context, job *models.Job, jobLog *joblogs.JobLogger) error {
+	data, ok := job.DataParsed.(*models.JobRecompressComicPagesData)
+	if !ok || data == nil || len(data.FileIDs) == 0 {
+		return errors.New("invalid or missing job data for recompress comic pages job")
+	}
+	if !data.Confirm {
+		return errors.New("recompress comic pages job wasn't confirmed")
+	}
+	if data.Format != "" && data.Format != "jpeg" {
+		return errors.Errorf("unsupported recompress format %q", data.Format)
+	}
+
+	outputDir := filepath.Join(w.config.CacheDir, "recompressed")
+	if !data.InPlace {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	opts := cbz.RecompressOptions{Quality: data.Quality, MinPageBytes: data.MinPageBytes}
+
+	data.Results = make([]models.JobRecompressComicPagesResult, 0, len(data.FileIDs))
+	data.BytesSaved = 0
+	for _, fileID := range data.FileIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		result := models.JobRecompressComicPagesResult{FileID: fileID}
+		if err := w.recompressComicFile(ctx, &result, data.InPlace, outputDir, opts); err != nil {
+			result.Error = err.Error()
+			jobLog.Warn("failed to recompress comic pages", logger.Data{"file_id": fileID, "error": err.Error()})
+		} else {
+			data.BytesSaved += result.OriginalSizeBytes - result.NewSizeBytes
+			jobLog.Info("recompressed comic pages", logger.Data{
+				"file_id":     fileID,
+				"pages":       result.PagesRecompressed,
+				"output_path": result.OutputPath,
+				"saved_bytes": result.OriginalSizeBytes - result.NewSizeBytes,
+			})
+		}
+		data.Results = append(data.Results, result)
+	}
+
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	job.Data = string(dataBytes)
+	job.DataParsed = data
+	if err := w.jobService.UpdateJob(ctx, job, jobs.UpdateJobOptions{Columns: []string{"data"}}); err != nil {
+		return errors.Wrap(err, "save recompress comic pages result")
+	}
+
+	jobLog.Info(fmt.Sprintf("recompressed %d files, saving %d bytes", len(data.FileIDs), data.BytesSaved), nil)
+	return nil
+}
+
+// recompressComicFile recompresses one CBZ file and fills in result. In-place
+// runs also update the file's size and modification time, drop its
+// fingerprints since its content changed, and clear its cached reader pages.
+func (w *Worker) recompressComicFile(ctx context.Context, result *models.JobRecompressComicPagesResult, inPlace bool, outputDir string, opts cbz.RecompressOptions) error {
+	file, err := w.bookService.RetrieveFile(ctx, books.RetrieveFileOptions{ID: &result.FileID})
+	if err != nil {
+		return err
+	}
+	if file.FileType != models.FileTypeCBZ {
+		return errors.Errorf("file is a %s, not a cbz", file.FileType)
+	}
+
+	dst := filepath.Join(outputDir, fmt.Sprintf("%d-%s", file.ID, filepath.Base(file.Filepath)))
+	if inPlace {
+		dst = file.Filepath
+	}
+	recompressed, err := cbz.RecompressPages(file.Filepath, dst, opts)
+	if err != nil {
+		return err
+	}
+	result.OutputPath = dst
+	result.PagesRecompressed = recompressed.Recompressed
+	result.OriginalSizeBytes = recompressed.OriginalSizeBytes
+	result.NewSizeBytes = recompressed.NewSizeBytes
+
+	if !inPlace || recompressed.Recompressed == 0 {
+		return nil
+	}
+	info, err := os.Stat(file.Filepath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	modTime := info.ModTime()
+	file.FilesizeBytes = info.Size()
+	file.FileModifiedAt = &modTime
+	if err := w.bookService.UpdateFile(ctx, file, books.UpdateFileOptions{Columns: []string{"filesize_bytes", "file_modified_at"}}); err != nil {
+		return err
+	}
+	if err := w.fingerprintService.DeleteForFile(ctx, file.ID); err != nil {
+		return err
+	}
+	if err := cbzpages.NewCache(w.config.CacheDir).Invalidate(file.ID); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeNoisyCBZ writes a one-page CBZ whose page is a PNG of noise, which
+// JPEG shrinks a lot.
+func writeNoisyCBZ(t *testing.T, path string) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // deterministic test data
+	img := image.NewRGBA(image.Rect(0, 0, 128, 128))
+	for y := 0; y < 128; y++ {
+		for x := 0; x < 128; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(rng.Intn(256)), G: uint8(rng.Intn(256)), B: uint8(rng.Intn(256)), A: 255}) //nolint:gosec // 0-255
+		}
+	}
+	var page bytes.Buffer
+	require.NoError(t, png.Encode(&page, img))
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	entry, err := w.Create("ComicInfo.xml")
+	require.NoError(t, err)
+	_, err = entry.Write([]byte("<ComicInfo><Title>Noise</Title></ComicInfo>"))
+	require.NoError(t, err)
+	entry, err = w.Create("001.png")
+	require.NoError(t, err)
+	_, err = entry.Write(page.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+}
+
+func TestProcessRecompressComicPagesJob(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.CacheDir = t.TempDir()
+
+	library := &models.Library{Name: "L", CoverAspectRatio: "book"}
+	_, err := tc.db.NewInsert().Model(library).Exec(tc.ctx)
+	require.NoError(t, err)
+	bookDir := t.TempDir()
+	book := &models.Book{
+		LibraryID:       library.ID,
+		Title:           "Noise",
+		TitleSource:     models.DataSourceFilepath,
+		SortTitle:       "Noise",
+		SortTitleSource: models.DataSourceFilepath,
+		AuthorSource:    models.DataSourceFilepath,
+		Filepath:        bookDir,
+	}
+	_, err = tc.db.NewInsert().Model(book).Exec(tc.ctx)
+	require.NoError(t, err)
+
+	insertFile := func(name string) *models.File {
+		path := filepath.Join(bookDir, name)
+		writeNoisyCBZ(t, path)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		file := &models.File{
+			LibraryID:     library.ID,
+			BookID:        book.ID,
+			FileType:      models.FileTypeCBZ,
+			FileRole:      models.FileRoleMain,
+			Filepath:      path,
+			FilesizeBytes: info.Size(),
+		}
+		_, err = tc.db.NewInsert().Model(file).Exec(tc.ctx)
+		require.NoError(t, err)
+		return file
+	}
+	copied := insertFile("copy.cbz")
+	replaced := insertFile("in-place.cbz")
+	originalSize := copied.FilesizeBytes
+
+	run := func(data *models.JobRecompressComicPagesData) *models.JobRecompressComicPagesData {
+		job := &models.Job{
+			Type:       models.JobTypeRecompressComicPages,
+			Status:     models.JobStatusInProgress,
+			DataParsed: data,
+		}
+		require.NoError(t, tc.jobService.CreateJob(tc.ctx, job))
+		jobLog := tc.jobLogService.NewJobLogger(tc.ctx, job.ID, logger.FromContext(tc.ctx))
+		require.NoError(t, tc.worker.ProcessRecompressComicPagesJob(tc.ctx, job, jobLog))
+		result, ok := job.DataParsed.(*models.JobRecompressComicPagesData)
+		require.True(t, ok)
+		return result
+	}
+
+	// Copies go to the cache and leave the library file alone.
+	data := run(&models.JobRecompressComicPagesData{FileIDs: []int{copied.ID, 999999}, MinPageBytes: 1024, Confirm: true})
+	require.Len(t, data.Results, 2)
+	assert.Equal(t, 1, data.Results[0].PagesRecompressed)
+	assert.Equal(t, filepath.Join(tc.worker.config.CacheDir, "recompressed"), filepath.Dir(data.Results[0].OutputPath))
+	assert.FileExists(t, data.Results[0].OutputPath)
+	assert.NotEmpty(t, data.Results[1].Error, "missing files are reported, not fatal")
+	assert.Positive(t, data.BytesSaved)
+	info, err := os.Stat(copied.Filepath)
+	require.NoError(t, err)
+	assert.Equal(t, originalSize, info.Size())
+
+	// In place, the file and its recorded size change.
+	data = run(&models.JobRecompressComicPagesData{FileIDs: []int{replaced.ID}, MinPageBytes: 1024, InPlace: true, Confirm: true})
+	require.Len(t, data.Results, 1)
+	assert.Equal(t, replaced.Filepath, data.Results[0].OutputPath)
+	var size int64
+	require.NoError(t, tc.db.NewSelect().Table("files").Column("filesize_bytes").Where("id = ?", replaced.ID).Scan(tc.ctx, &size))
+	assert.Equal(t, data.Results[0].NewSizeBytes, size)
+	assert.Less(t, size, originalSize)
+}
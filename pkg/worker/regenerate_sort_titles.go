@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/segmentio/encoding/json"
+	"github.com/shishobooks/shisho/pkg/books"
+	"github.com/shishobooks/shisho/pkg/joblogs"
+	"github.com/shishobooks/shisho/pkg/jobs"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/sidecar"
+)
+
+// ProcessRegenerateSortTitlesJob recomputes the sort titles of every book in a
+// library, then rewrites the sidecars of the books whose sort title changed
+// and re-indexes them. The number of changed books is saved to the job's
+// data.
+func (w *Worker) ProcessRegenerateSortTitlesJob(ctx context.Context, job *models.Job, jobLog *joblogs.JobLogger) error {
+	data, ok := job.DataParsed.(*models.JobRegenerateSortTitlesData)
+	if !ok || data == nil || data.LibraryID == 0 {
+		return errors.New("invalid or missing job data for regenerate sort titles job")
+	}
+
+	jobLog.Info("regenerating sort titles", logger.Data{"library_id": data.LibraryID})
+	updated, err := w.bookService.RegenerateSortTitles(ctx, data.LibraryID)
+	if err != nil {
+		return errors.Wrap(err, "regenerate sort titles")
+	}
+
+	for _, id := range updated {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		book, err := w.bookService.RetrieveBook(ctx, books.RetrieveBookOptions{ID: &id})
+		if err != nil {
+			jobLog.Warn("failed to load book for re-indexing", logger.Data{"book_id": id, "error": err.Error()})
+			continue
+		}
+		if !book.Staged {
+			if err := sidecar.WriteBookSidecarFromModel(book); err != nil {
+				jobLog.Warn("failed to write book sidecar", logger.Data{"book_id": id, "error": err.Error()})
+			}
+		}
+		if err := w.searchService.IndexBook(ctx, book); err != nil {
+			jobLog.Warn("failed to update search index for book", logger.Data{"book_id": id, "error": err.Error()})
+		}
+	}
+
+	data.Updated = len(updated)
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	job.Data = string(dataBytes)
+	job.DataParsed = data
+	if err := w.jobService.UpdateJob(ctx, job, jobs.UpdateJobOptions{Columns: []string{"data"}}); err != nil {
+		return errors.Wrap(err, "save regenerate sort titles result")
+	}
+
+	jobLog.Info("regenerated sort titles", logger.Data{"library_id": data.LibraryID, "updated": data.Updated})
+	return nil
+}
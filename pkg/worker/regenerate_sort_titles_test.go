@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/sidecar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessRegenerateSortTitlesJob(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	library := &models.Library{Name: "L", CoverAspectRatio: "book"}
+	_, err := tc.db.NewInsert().Model(library).Exec(tc.ctx)
+	require.NoError(t, err)
+
+	insertBook := func(title, sortTitle, sortTitleSource string) *models.Book {
+		book := &models.Book{
+			LibraryID:       library.ID,
+			Title:           title,
+			TitleSource:     models.DataSourceFilepath,
+			SortTitle:       sortTitle,
+			SortTitleSource: sortTitleSource,
+			AuthorSource:    models.DataSourceFilepath,
+			Filepath:        t.TempDir(),
+		}
+		_, err := tc.db.NewInsert().Model(book).Exec(tc.ctx)
+		require.NoError(t, err)
+		return book
+	}
+	stale := insertBook("The Hobbit", "The Hobbit", models.DataSourceFilepath)
+	current := insertBook("Dune", "Dune", models.DataSourceFilepath)
+	manual := insertBook("The Stand", "The Stand", models.DataSourceManual)
+	fromSidecar := insertBook("The Road", "The Road", models.DataSourceSidecar)
+	fromPlugin := insertBook("The Shining", "The Shining", models.DataSourcePlugin+":shisho/goodreads")
+	portuguese := insertBook("A Rua", "A Rua", models.DataSourceEPUBMetadata)
+	language := "pt"
+	_, err = tc.db.NewInsert().Model(&models.File{
+		LibraryID:     library.ID,
+		BookID:        portuguese.ID,
+		Filepath:      filepath.Join(portuguese.Filepath, "a-rua.epub"),
+		FileType:      models.FileTypeEPUB,
+		FileRole:      models.FileRoleMain,
+		FilesizeBytes: 1,
+		Language:      &language,
+	}).Exec(tc.ctx)
+	require.NoError(t, err)
+
+	job := &models.Job{
+		Type:       models.JobTypeRegenerateSortTitles,
+		Status:     models.JobStatusInProgress,
+		DataParsed: &models.JobRegenerateSortTitlesData{LibraryID: library.ID},
+	}
+	require.NoError(t, tc.jobService.CreateJob(tc.ctx, job))
+	jobLog := tc.jobLogService.NewJobLogger(tc.ctx, job.ID, logger.FromContext(tc.ctx))
+	require.NoError(t, tc.worker.ProcessRegenerateSortTitlesJob(tc.ctx, job, jobLog))
+
+	sortTitle := func(id int) string {
+		var s string
+		require.NoError(t, tc.db.NewSelect().Table("books").Column("sort_title").Where("id = ?", id).Scan(tc.ctx, &s))
+		return s
+	}
+	assert.Equal(t, "Hobbit, The", sortTitle(stale.ID))
+	assert.Equal(t, "Dune", sortTitle(current.ID))
+	assert.Equal(t, "The Stand", sortTitle(manual.ID), "manual sort titles are kept")
+	assert.Equal(t, "The Road", sortTitle(fromSidecar.ID), "sidecar sort titles are kept")
+	assert.Equal(t, "The Shining", sortTitle(fromPlugin.ID), "plugin sort titles are kept")
+	assert.Equal(t, "A Rua", sortTitle(portuguese.ID), "English articles aren't moved in other languages")
+
+	// The changed book's sidecar is rewritten with the new sort title.
+	s, err := sidecar.ReadBookSidecar(stale.Filepath)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+	assert.Equal(t, "Hobbit, The", s.SortTitle)
+
+	data, ok := job.DataParsed.(*models.JobRegenerateSortTitlesData)
+	require.True(t, ok)
+	assert.Equal(t, 1, data.Updated)
+}
@@ -17,11 +17,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/robinjoseph08/golib/logger"
 	"github.com/shishobooks/shisho/pkg/books"
+	"github.com/shishobooks/shisho/pkg/errcodes"
 	"github.com/shishobooks/shisho/pkg/fileutils"
 	"github.com/shishobooks/shisho/pkg/joblogs"
 	"github.com/shishobooks/shisho/pkg/libraries"
 	"github.com/shishobooks/shisho/pkg/mediafile"
 	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/webhooks"
 )
 
 var extensionsToScan = map[string]map[string]struct{}{
@@ -29,6 +31,8 @@ var extensionsToScan = map[string]map[string]struct{}{
 	".m4b":  {"audio/x-m4a": {}, "video/mp4": {}},
 	".cbz":  {"application/zip": {}},
 	".pdf":  {"application/pdf": {}},
+	".mobi": {"application/x-mobipocket-ebook": {}},
+	".azw3": {"application/x-mobipocket-ebook": {}},
 }
 
 var (
@@ -39,13 +43,29 @@ var (
 	filepathParensRE = regexp.MustCompile(`\([^)]*\)`)
 	// Regex to collapse multiple whitespace to single space.
 	multiSpaceRE = regexp.MustCompile(`\s+`)
+	// Matches numbered volume directories like "01 - Title" or "3. Title",
+	// capturing the number and the remaining title.
+	volumeDirRE = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*[-–.]\s+(.+)$`)
 )
 
 // scanResult holds the result of a single file scan for the worker pool.
 type scanResult struct {
-	BookID int
-	Path   string
-	Err    error
+	BookID      int
+	Path        string
+	Err         error
+	FileCreated bool
+	BookCreated bool
+	FileDeleted bool
+	BookDeleted bool
+	// FileUpdated and BookUpdated are set when the scan wrote changes to an
+	// existing file or book.
+	FileUpdated bool
+	BookUpdated bool
+	// Skipped is set when the scan finished without an error but didn't
+	// produce or remove a file (e.g. it was excluded by the scan rules).
+	Skipped bool
+	// Warnings are non-fatal problems found while scanning the file.
+	Warnings []ScanWarning
 }
 
 // generateCBZFileName creates a clean file name for CBZ files.
@@ -157,7 +177,7 @@ func looksLikePDFSupplement(filename string, names []string) bool {
 
 // hasNonPDFMainSibling returns true if dir (recursive) contains at least one
 // file with a non-PDF main-eligible extension. Main-eligible means EPUB / CBZ /
-// M4B or any extension in pluginExts (which comes from
+// M4B / MOBI / AZW3 or any extension in pluginExts (which comes from
 // pluginManager.RegisteredFileExtensions() — keys are extensions without the
 // leading dot, lowercase). pluginExts may be nil. Hidden subdirectories
 // (e.g. .git, .calibre, .stversions) are skipped so a stray ebook inside an
@@ -183,7 +203,7 @@ func hasNonPDFMainSibling(dir string, pluginExts map[string]struct{}) (bool, err
 			return nil
 		}
 		switch ext {
-		case models.FileTypeEPUB, models.FileTypeCBZ, models.FileTypeM4B:
+		case models.FileTypeEPUB, models.FileTypeCBZ, models.FileTypeM4B, models.FileTypeMOBI, models.FileTypeAZW3:
 			found = true
 			return filepath.SkipAll
 		}
@@ -240,8 +260,10 @@ func matchesExcludePattern(filename string, patterns []string) bool {
 	return false
 }
 
-// discoverSupplements finds supplement files for a book directory.
-func discoverSupplements(bookDir string, excludePatterns []string) ([]string, error) {
+// discoverSupplements finds supplement files for a book directory. Files of a
+// main type the library doesn't allow are never imported as books, so they're
+// picked up as supplements like any other companion file.
+func discoverSupplements(bookDir string, library *models.Library, excludePatterns []string) ([]string, error) {
 	var supplements []string
 
 	err := filepath.WalkDir(bookDir, func(path string, d fs.DirEntry, err error) error {
@@ -256,7 +278,7 @@ func discoverSupplements(bookDir string, excludePatterns []string) ([]string, er
 		ext := filepath.Ext(path)
 
 		// Skip main file types
-		if isMainFileExtension(ext) {
+		if isMainFileExtension(ext) && library.AllowsFileType(strings.TrimPrefix(strings.ToLower(ext), ".")) {
 			return nil
 		}
 
@@ -278,7 +300,7 @@ func discoverSupplements(bookDir string, excludePatterns []string) ([]string, er
 }
 
 // discoverRootLevelSupplements finds supplements for root-level books by basename matching.
-func discoverRootLevelSupplements(mainFilePath string, libraryPath string, excludePatterns []string) ([]string, error) {
+func discoverRootLevelSupplements(mainFilePath string, libraryPath string, library *models.Library, excludePatterns []string) ([]string, error) {
 	var supplements []string
 
 	// Get basename without extension
@@ -306,7 +328,7 @@ func discoverRootLevelSupplements(mainFilePath string, libraryPath string, exclu
 		}
 
 		// Skip main file types
-		if isMainFileExtension(ext) {
+		if isMainFileExtension(ext) && library.AllowsFileType(strings.TrimPrefix(strings.ToLower(ext), ".")) {
 			continue
 		}
 
@@ -331,9 +353,131 @@ func discoverRootLevelSupplements(mainFilePath string, libraryPath string, exclu
 	return supplements, nil
 }
 
+// collectScanPaths walks dir, which must be libraryRoot or a directory below
+// it, and returns the files that should be handed to scanInternal. Paths
+// matched by .shishoignore rules, types the library doesn't allow, files below
+// the configured minimum size and files whose MIME type doesn't match their
+// extension are left out, unless the extension can be repaired (see
+// repairExtension), in which case the renamed path is returned instead. Each
+// left-out path is recorded on the cache with the reason (see
+// ScanCache.RecordSkip).
+func (w *Worker) collectScanPaths(ctx context.Context, library *models.Library, libraryRoot, dir string, cache *ScanCache, jobLog *joblogs.JobLogger) ([]string, error) {
+	filesToScan := make([]string, 0)
+	err := filepath.WalkDir(dir, func(path string, info fs.DirEntry, err error) error {
+		// Stop walking the tree if the worker is shutting down. Returning
+		// the cancellation error aborts the outer WalkDir call so we bail
+		// out of the library rather than enumerating thousands more paths.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		// Honor .shishoignore files. Ignored directories are skipped
+		// entirely so their contents never reach the scan pool.
+		if cache.ignores.Match(libraryRoot, path, info.IsDir()) {
+			cache.RecordSkip(path, models.ScanSkipReasonIgnored, "")
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			// We don't do anything explicitly to directories.
+			return nil
+		}
+		// TODO: support having cover.jpg and cover_audiobook.jpg
+		ext := filepath.Ext(path)
+		expectedMimeTypes, ok := extensionsToScan[ext]
+		if ok && !library.AllowsFileType(strings.TrimPrefix(ext, ".")) {
+			// Disallowed types are skipped entirely. Known files of
+			// that type fall through to orphan cleanup.
+			cache.RecordSkip(path, models.ScanSkipReasonDisallowedType, strings.TrimPrefix(ext, "."))
+			return nil
+		}
+		if !ok {
+			// Check plugin-registered extensions (file parsers and converter source types)
+			if w.pluginManager != nil {
+				extNoDot := strings.TrimPrefix(ext, ".")
+				pluginExts := w.pluginManager.RegisteredFileExtensions()
+				converterExts := w.pluginManager.RegisteredConverterExtensions()
+				if _, isParser := pluginExts[extNoDot]; isParser {
+					if !library.AllowsFileType(extNoDot) {
+						cache.RecordSkip(path, models.ScanSkipReasonDisallowedType, extNoDot)
+						return nil
+					}
+					filesToScan = append(filesToScan, path)
+					return nil
+				}
+				if _, isConverter := converterExts[extNoDot]; isConverter {
+					filesToScan = append(filesToScan, path)
+					return nil
+				}
+			}
+			// Not a built-in or plugin-registered extension, skip. Shisho's
+			// own files and the usual clutter aren't worth recording.
+			name := filepath.Base(path)
+			if !isShishoSpecialFile(name) && !matchesExcludePattern(name, w.config.SupplementExcludePatterns) {
+				cache.RecordSkip(path, models.ScanSkipReasonUnsupportedType, strings.TrimPrefix(ext, "."))
+			}
+			return nil
+		}
+		// Skip MIME detection for files we already know about — they were
+		// validated when first imported, so re-checking is redundant I/O.
+		if cache.GetKnownFile(path) != nil {
+			filesToScan = append(filesToScan, path)
+			return nil
+		}
+		// Skip new files below the configured minimum size for their
+		// type (thumbnails, truncated downloads) before any I/O on them.
+		if fi, infoErr := info.Info(); infoErr == nil && w.isBelowMinFileSize(path, fi.Size()) {
+			logger.FromContext(ctx).Debug("skipping file below minimum size", logger.Data{"path": path, "size": fi.Size()})
+			cache.RecordSkip(path, models.ScanSkipReasonTooSmall, fmt.Sprintf("%d bytes", fi.Size()))
+			return nil
+		}
+
+		mtype, err := mimetype.DetectFile(path)
+		if err != nil {
+			// We can't detect the mime type, so we just skip it.
+			jobLog.Warn("can't detect the mime type of a file with a valid extension", logger.Data{"path": path, "err": err.Error(), "reason": models.ScanSkipReasonUnreadable})
+			cache.RecordSkip(path, models.ScanSkipReasonUnreadable, err.Error())
+			return nil
+		}
+		if _, ok := expectedMimeTypes[mtype.String()]; !ok {
+			// Since files can have any extension, we try to check it against the mime type that we expect it to
+			// be. This might be overly restrictive in the future, so it might be something that we remove, but
+			// we can keep it for now.
+			if repaired, ok := w.repairExtension(library, path, mtype, jobLog); ok {
+				filesToScan = append(filesToScan, repaired)
+				return nil
+			}
+			jobLog.Warn("mime type is not expected for extension", logger.Data{"path": path, "mimetype": mtype.String(), "reason": models.ScanSkipReasonMimeMismatch})
+			cache.RecordSkip(path, models.ScanSkipReasonMimeMismatch, mtype.String())
+			return nil
+		}
+
+		// This is a file that we care about, so store it in the slice. We do this so that we can know the total
+		// number of files that we need to scan before we start doing any real work so that we can accurately
+		// update the progress of the job.
+		filesToScan = append(filesToScan, path)
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return filesToScan, nil
+}
+
 func (w *Worker) ProcessScanJob(ctx context.Context, job *models.Job, jobLog *joblogs.JobLogger) error {
 	jobLog.Info("processing scan job", nil)
 
+	if job != nil && job.LibraryID != nil {
+		if data, ok := job.DataParsed.(*models.JobScanData); ok && data.DirPath != "" {
+			return w.processDirectoryScanJob(ctx, *job.LibraryID, data.DirPath, jobLog)
+		}
+	}
+
 	allLibraries, err := w.libraryService.ListLibraries(ctx, libraries.ListLibrariesOptions{})
 	if err != nil {
 		return errors.WithStack(err)
@@ -353,6 +497,8 @@ func (w *Worker) ProcessScanJob(ctx context.Context, job *models.Job, jobLog *jo
 
 	jobLog.Info("processing libraries", logger.Data{"count": len(allLibraries)})
 
+	report := newScanReportBuilder()
+
 	for _, library := range allLibraries {
 		// Honor cancellation between libraries — if the worker is shutting
 		// down mid-scan, don't start a fresh per-library walk.
@@ -388,72 +534,11 @@ func (w *Worker) ProcessScanJob(ctx context.Context, job *models.Job, jobLog *jo
 		// Go through all the library paths to find all the .cbz files.
 		for _, libraryPath := range library.LibraryPaths {
 			jobLog.Info("processing library path", logger.Data{"library_path_id": libraryPath.ID, "library_path": libraryPath.Filepath})
-			err := filepath.WalkDir(libraryPath.Filepath, func(path string, info fs.DirEntry, err error) error {
-				// Stop walking the tree if the worker is shutting down. Returning
-				// the cancellation error aborts the outer WalkDir call so we bail
-				// out of the library rather than enumerating thousands more paths.
-				if ctxErr := ctx.Err(); ctxErr != nil {
-					return ctxErr
-				}
-				if err != nil {
-					return errors.WithStack(err)
-				}
-				if info.IsDir() {
-					// We don't do anything explicitly to directories.
-					return nil
-				}
-				// TODO: support having cover.jpg and cover_audiobook.jpg
-				ext := filepath.Ext(path)
-				expectedMimeTypes, ok := extensionsToScan[ext]
-				if !ok {
-					// Check plugin-registered extensions (file parsers and converter source types)
-					if w.pluginManager != nil {
-						extNoDot := strings.TrimPrefix(ext, ".")
-						pluginExts := w.pluginManager.RegisteredFileExtensions()
-						converterExts := w.pluginManager.RegisteredConverterExtensions()
-						if _, isParser := pluginExts[extNoDot]; isParser {
-							filesToScan = append(filesToScan, path)
-							return nil
-						}
-						if _, isConverter := converterExts[extNoDot]; isConverter {
-							filesToScan = append(filesToScan, path)
-							return nil
-						}
-					}
-					// Not a built-in or plugin-registered extension, skip.
-					return nil
-				}
-				// Skip MIME detection for files we already know about — they were
-				// validated when first imported, so re-checking is redundant I/O.
-				if cache.GetKnownFile(path) != nil {
-					filesToScan = append(filesToScan, path)
-					return nil
-				}
-
-				mtype, err := mimetype.DetectFile(path)
-				if err != nil {
-					// We can't detect the mime type, so we just skip it.
-					jobLog.Warn("can't detect the mime type of a file with a valid extension", logger.Data{"path": path, "err": err.Error()})
-					return nil
-				}
-				if _, ok := expectedMimeTypes[mtype.String()]; !ok {
-					// Since files can have any extension, we try to check it against the mime type that we expect it to
-					// be. This might be overly restrictive in the future, so it might be something that we remove, but
-					// we can keep it for now.
-					jobLog.Warn("mime type is not expected for extension", logger.Data{"path": path, "mimetype": mtype.String()})
-					return nil
-				}
-
-				// This is a file that we care about, so store it in the slice. We do this so that we can know the total
-				// number of files that we need to scan before we start doing any real work so that we can accurately
-				// update the progress of the job.
-				filesToScan = append(filesToScan, path)
-
-				return nil
-			})
+			paths, err := w.collectScanPaths(ctx, library, libraryPath.Filepath, libraryPath.Filepath, cache, jobLog)
 			if err != nil {
-				return errors.WithStack(err)
+				return err
 			}
+			filesToScan = append(filesToScan, paths...)
 		}
 
 		// Defer supplement-named PDFs so non-supplement files in the same
@@ -496,6 +581,9 @@ func (w *Worker) ProcessScanJob(ctx context.Context, job *models.Job, jobLog *jo
 		// Track books that need organization after scan completes.
 		// Organization is deferred to avoid breaking file paths during scan.
 		booksToOrganize := make(map[int]struct{})
+		// Books created by this scan, checked for a pair when the library
+		// pairs audiobooks with ebooks.
+		var createdBookIDs []int
 
 		// Parallel file processing with worker pool
 		workerCount := max(runtime.NumCPU(), 4)
@@ -526,10 +614,23 @@ func (w *Worker) ProcessScanJob(ctx context.Context, job *models.Job, jobLog *jo
 					}, cache)
 
 					sr := scanResult{Path: path}
-					if err != nil {
+					switch {
+					case err != nil:
 						sr.Err = err
-					} else if result != nil && result.Book != nil {
-						sr.BookID = result.Book.ID
+					case result == nil:
+						sr.Skipped = true
+					default:
+						if result.Book != nil {
+							sr.BookID = result.Book.ID
+						}
+						sr.FileCreated = result.FileCreated
+						sr.BookCreated = result.BookCreated
+						sr.FileDeleted = result.FileDeleted
+						sr.BookDeleted = result.BookDeleted
+						sr.FileUpdated = result.FileUpdated
+						sr.BookUpdated = result.BookUpdated
+						sr.Warnings = result.Warnings
+						sr.Skipped = result.File == nil && !result.FileDeleted
 					}
 					resultChan <- sr
 				}
@@ -554,13 +655,30 @@ func (w *Worker) ProcessScanJob(ctx context.Context, job *models.Job, jobLog *jo
 		}()
 
 		// Process results
+		var scanErrors []*models.ScanError
 		for result := range resultChan {
+			report.add(result)
 			if result.Err != nil {
+				if errors.Is(result.Err, errcodes.DRMProtected()) {
+					jobLog.Warn("skipping DRM-protected file", logger.Data{"path": result.Path, "reason": models.ScanSkipReasonDRMProtected})
+					cache.RecordSkip(result.Path, models.ScanSkipReasonDRMProtected, "")
+					continue
+				}
+				var unsupportedErr *errcodes.UnsupportedFileTypeError
+				if errors.As(result.Err, &unsupportedErr) {
+					jobLog.Warn("skipping unsupported file", logger.Data{"path": result.Path, "file_type": unsupportedErr.FileType, "reason": models.ScanSkipReasonUnsupportedType})
+					cache.RecordSkip(result.Path, models.ScanSkipReasonUnsupportedType, unsupportedErr.FileType)
+					continue
+				}
 				jobLog.Warn("failed to scan file", logger.Data{"path": result.Path, "error": result.Err.Error()})
+				scanErrors = append(scanErrors, newScanError(library.ID, result.Path, result.Err, cache.GetKnownFile(result.Path)))
 				continue
 			}
 			if result.BookID != 0 {
 				booksToOrganize[result.BookID] = struct{}{}
+				if result.BookCreated {
+					createdBookIDs = append(createdBookIDs, result.BookID)
+				}
 			}
 		}
 
@@ -578,12 +696,18 @@ func (w *Worker) ProcessScanJob(ctx context.Context, job *models.Job, jobLog *jo
 			return err
 		}
 
+		// Pairing runs after the parallel scan so that an audiobook and ebook
+		// imported in the same scan still find each other.
+		if library.AutoPairFormats && len(createdBookIDs) > 0 {
+			w.pairNewBooks(ctx, createdBookIDs, booksToOrganize, jobLog)
+		}
+
 		// Books whose files were reconciled as moves should also be organized
-		// so organize_file_structure can rename their folders back into the
-		// structured layout. Only merge these when the library actually has
-		// organize enabled — otherwise it's wasted work since the organize
-		// step below is gated on the same setting.
-		if library.OrganizeFileStructure {
+		// so organizing can rename their folders back into the structured
+		// layout. Only merge these when the library actually organizes —
+		// otherwise it's wasted work since the organize step below is gated
+		// on the same setting.
+		if library.OrganizeEnabled() {
 			for bookID := range cache.MovedBookIDs() {
 				booksToOrganize[bookID] = struct{}{}
 			}
@@ -597,11 +721,12 @@ func (w *Worker) ProcessScanJob(ctx context.Context, job *models.Job, jobLog *jo
 			for _, path := range filesToScan {
 				scannedPaths[path] = struct{}{}
 			}
-			w.cleanupOrphanedFiles(ctx, existingFiles, scannedPaths, library, jobLog, cache)
+			filesDeleted, booksDeleted := w.cleanupOrphanedFiles(ctx, existingFiles, scannedPaths, library, jobLog, cache)
+			report.addOrphans(filesDeleted, booksDeleted)
 		}
 
 		// Organize files after all scanning is complete
-		if library.OrganizeFileStructure && len(booksToOrganize) > 0 {
+		if library.OrganizeEnabled() && len(booksToOrganize) > 0 {
 			jobLog.Info("organizing books after scan", logger.Data{"count": len(booksToOrganize)})
 			for bookID := range booksToOrganize {
 				book, err := w.bookService.RetrieveBook(ctx, books.RetrieveBookOptions{ID: &bookID})
@@ -623,11 +748,38 @@ func (w *Worker) ProcessScanJob(ctx context.Context, job *models.Job, jobLog *jo
 			}
 		}
 
+		// Replace the library's skip list with this scan's. Done after
+		// organizing so supplements imported along the way are dropped.
+		var jobID *int
+		if job != nil {
+			jobID = &job.ID
+		}
+		skipCounts, err := w.libraryService.ReplaceScanSkips(ctx, library.ID, jobID, cache.Skips())
+		if err != nil {
+			jobLog.Warn("failed to save scan skips", logger.Data{"error": err.Error()})
+		} else {
+			report.addSkips(skipCounts)
+			if len(skipCounts) > 0 {
+				jobLog.Info("files not imported", logger.Data{"library_id": library.ID, "reasons": skipCounts})
+			}
+		}
+		if err := w.libraryService.ReplaceScanErrors(ctx, library.ID, scanErrors); err != nil {
+			jobLog.Warn("failed to save scan errors", logger.Data{"error": err.Error()})
+		}
+
 		// Queue async sha256 hash generation for files that still lack a fingerprint.
 		// Handles both initial backfill and newly-discovered files from this scan.
 		if err := EnsureHashGenerationJob(ctx, w.jobService, library.ID); err != nil {
 			jobLog.Warn("failed to ensure hash generation job", logger.Data{"error": err.Error()})
 		}
+
+		// Books scanned before metadata hashes existed don't go back through
+		// scanFileCore while their files are unchanged, so fill theirs in here.
+		if n, err := w.bookService.BackfillMetadataHashes(ctx, library.ID); err != nil {
+			jobLog.Warn("failed to backfill book metadata hashes", logger.Data{"error": err.Error()})
+		} else if n > 0 {
+			jobLog.Info("backfilled book metadata hashes", logger.Data{"count": n})
+		}
 	}
 
 	// Cleanup orphaned entities (series, people, genres, tags)
@@ -644,10 +796,57 @@ func (w *Worker) ProcessScanJob(ctx context.Context, job *models.Job, jobLog *jo
 		}
 	}
 
+	if job != nil {
+		scanReport := report.build(job.ID)
+		if err := w.jobService.CreateScanReport(ctx, scanReport); err != nil {
+			jobLog.Warn("failed to save scan report", logger.Data{"error": err.Error()})
+		}
+		jobLog.Info("scan summary", logger.Data{
+			"books_created": scanReport.BooksCreated,
+			"books_updated": scanReport.BooksUpdated,
+			"books_deleted": scanReport.BooksDeleted,
+			"files_created": scanReport.FilesCreated,
+			"files_updated": scanReport.FilesUpdated,
+			"files_deleted": scanReport.FilesDeleted,
+			"files_skipped": scanReport.FilesSkipped,
+			"files_errored": scanReport.FilesErrored,
+		})
+		w.webhooks.Send(webhooks.EventScanCompleted, webhooks.ScanData{
+			JobID:        job.ID,
+			LibraryID:    job.LibraryID,
+			BooksCreated: scanReport.BooksCreated,
+			BooksUpdated: scanReport.BooksUpdated,
+			BooksDeleted: scanReport.BooksDeleted,
+			FilesCreated: scanReport.FilesCreated,
+			FilesUpdated: scanReport.FilesUpdated,
+			FilesDeleted: scanReport.FilesDeleted,
+			FilesSkipped: scanReport.FilesSkipped,
+			FilesErrored: scanReport.FilesErrored,
+		})
+	}
+
 	jobLog.Info("finished scan job", nil)
 	return nil
 }
 
+// processDirectoryScanJob rescans a single directory of a library instead of
+// walking every library path.
+func (w *Worker) processDirectoryScanJob(ctx context.Context, libraryID int, dirPath string, jobLog *joblogs.JobLogger) error {
+	result, err := w.scanInternal(ctx, ScanOptions{
+		DirPath:   dirPath,
+		LibraryID: libraryID,
+		JobLog:    jobLog,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	w.cleanupOrphanedEntities(ctx, logger.FromContext(ctx))
+
+	jobLog.Info("finished directory scan", logger.Data{"dir": dirPath, "files_scanned": len(result.Files)})
+	return nil
+}
+
 // runInputConverters runs input converter plugins on discovered files.
 // It returns a list of newly converted files that should also be scanned.
 func (w *Worker) runInputConverters(ctx context.Context, filesToScan []string, jobLog *joblogs.JobLogger, libraryID int) []string {
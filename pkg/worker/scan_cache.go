@@ -6,6 +6,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/shishobooks/shisho/pkg/fileutils"
 	"github.com/shishobooks/shisho/pkg/models"
 )
 
@@ -81,7 +82,7 @@ type ScanCache struct {
 
 	// movedBookIDs holds book IDs whose files were matched by the move
 	// reconciliation phase. The parent scan loop merges this into its
-	// booksToOrganize set so organize_file_structure runs on these books
+	// booksToOrganize set so organizing runs on these books
 	// after the scan (renaming folders back into the structured layout).
 	movedBookIDs map[int]struct{}
 
@@ -94,6 +95,15 @@ type ScanCache struct {
 	// Nil means the caller should fall back to RetrieveLibrary.
 	libraryRootPaths []string
 
+	// ignores holds the compiled .shishoignore rules for every directory
+	// visited during this scan, shared by the walk and the scan workers.
+	ignores *fileutils.IgnoreMatcher
+
+	// skips collects the paths this scan left out and why. Both the walk
+	// and the scan workers record them, so access is guarded by skipMu.
+	skipMu sync.Mutex
+	skips  []*models.ScanSkip
+
 	// Counters for cache hits/misses (atomic for thread safety)
 	personCount    atomic.Int64
 	genreCount     atomic.Int64
@@ -106,6 +116,7 @@ type ScanCache struct {
 func NewScanCache() *ScanCache {
 	return &ScanCache{
 		knownFiles: make(map[string]*models.File),
+		ignores:    fileutils.NewIgnoreMatcher(),
 	}
 }
 
@@ -124,6 +135,25 @@ func (c *ScanCache) LoadKnownFiles(files []*models.File) {
 	}
 }
 
+// RecordSkip notes that path wasn't imported and why. detail is optional
+// context, like the MIME type of a mismatched file.
+func (c *ScanCache) RecordSkip(path, reason, detail string) {
+	skip := &models.ScanSkip{Path: path, Reason: reason}
+	if detail != "" {
+		skip.Detail = &detail
+	}
+	c.skipMu.Lock()
+	c.skips = append(c.skips, skip)
+	c.skipMu.Unlock()
+}
+
+// Skips returns the skips recorded so far.
+func (c *ScanCache) Skips() []*models.ScanSkip {
+	c.skipMu.Lock()
+	defer c.skipMu.Unlock()
+	return append([]*models.ScanSkip(nil), c.skips...)
+}
+
 // GetKnownFile returns a known file by path, or nil if not found.
 func (c *ScanCache) GetKnownFile(path string) *models.File {
 	return c.knownFiles[path]
@@ -405,7 +435,7 @@ func (c *ScanCache) IsMovedOrphan(id int) bool {
 
 // SetMovedBookIDs stores the set of book IDs whose files were matched by the
 // move reconciliation phase. The scan loop reads this after processing to
-// ensure organize_file_structure runs on the moved books.
+// ensure organizing runs on the moved books.
 func (c *ScanCache) SetMovedBookIDs(ids map[int]struct{}) {
 	c.movedBookIDs = ids
 }
@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/books"
+)
+
+var errScanAborted = errors.New("identical scan aborted")
+
+// scanCoalescer collapses identical single-target scans (resyncs, imports)
+// into one run. A scan requested while an identical one is in flight waits
+// for it and shares its result instead of racing it. With a non-zero dedup
+// window, a successful result is also reused for identical requests made
+// within the window after it finishes. The zero value is ready to use.
+type scanCoalescer struct {
+	mu    sync.Mutex
+	scans map[string]*coalescedScan
+}
+
+type coalescedScan struct {
+	done   chan struct{}
+	result *books.ScanResult
+	err    error
+}
+
+// scanKey identifies a single-target scan by its target and mode, so only
+// requests that would do exactly the same work are coalesced.
+func scanKey(opts books.ScanOptions) string {
+	var target string
+	switch {
+	case opts.FileID != 0:
+		target = fmt.Sprintf("file:%d", opts.FileID)
+	case opts.BookID != 0:
+		target = fmt.Sprintf("book:%d", opts.BookID)
+	default:
+		target = fmt.Sprintf("path:%d:%s", opts.LibraryID, opts.FilePath)
+	}
+	return fmt.Sprintf("%s:%t:%t:%t", target, opts.ForceRefresh, opts.SkipPlugins, opts.Reset)
+}
+
+// do runs fn for key unless an identical scan is in flight or finished
+// successfully less than window ago, in which case that scan's result is
+// returned instead. shared reports whether the result came from another
+// call.
+func (c *scanCoalescer) do(ctx context.Context, key string, window time.Duration, fn func() (*books.ScanResult, error)) (result *books.ScanResult, shared bool, err error) {
+	c.mu.Lock()
+	if existing, ok := c.scans[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-existing.done:
+			return existing.result, true, existing.err
+		case <-ctx.Done():
+			return nil, true, errors.WithStack(ctx.Err())
+		}
+	}
+	if c.scans == nil {
+		c.scans = make(map[string]*coalescedScan)
+	}
+	scan := &coalescedScan{done: make(chan struct{})}
+	c.scans[key] = scan
+	c.mu.Unlock()
+
+	completed := false
+	defer func() {
+		if !completed {
+			// fn panicked; don't hand waiters an empty result.
+			scan.err = errScanAborted
+		}
+		close(scan.done)
+		// Failed scans are only shared with the callers already waiting, so
+		// a retry runs again. Successful ones stick around for the window.
+		if scan.err != nil || window <= 0 {
+			c.forget(key, scan)
+			return
+		}
+		time.AfterFunc(window, func() { c.forget(key, scan) })
+	}()
+
+	scan.result, scan.err = fn()
+	completed = true
+	return scan.result, false, scan.err
+}
+
+// forget drops key's entry if it still belongs to scan.
+func (c *scanCoalescer) forget(key string, scan *coalescedScan) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.scans[key] == scan {
+		delete(c.scans, key)
+	}
+}
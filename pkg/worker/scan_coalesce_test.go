@@ -0,0 +1,131 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/internal/testgen"
+	"github.com/shishobooks/shisho/pkg/books"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanCoalescer_SharesInFlightScan(t *testing.T) {
+	t.Parallel()
+
+	var c scanCoalescer
+	var runs atomic.Int32
+	release := make(chan struct{})
+	want := &books.ScanResult{FileDeleted: true}
+	scan := func() (*books.ScanResult, error) {
+		runs.Add(1)
+		<-release
+		return want, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*books.ScanResult, 3)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, _, err := c.do(context.Background(), "book:1", time.Hour, scan)
+			assert.NoError(t, err)
+			results[i] = result
+		}()
+	}
+	require.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), runs.Load())
+	for _, result := range results {
+		assert.Same(t, want, result)
+	}
+}
+
+func TestScanCoalescer_Window(t *testing.T) {
+	t.Parallel()
+
+	var c scanCoalescer
+	var runs atomic.Int32
+	scan := func() (*books.ScanResult, error) {
+		runs.Add(1)
+		return &books.ScanResult{}, nil
+	}
+
+	_, shared, err := c.do(context.Background(), "file:1", time.Hour, scan)
+	require.NoError(t, err)
+	assert.False(t, shared)
+
+	_, shared, err = c.do(context.Background(), "file:1", time.Hour, scan)
+	require.NoError(t, err)
+	assert.True(t, shared, "an identical scan within the window reuses the result")
+
+	_, shared, err = c.do(context.Background(), "file:2", time.Hour, scan)
+	require.NoError(t, err)
+	assert.False(t, shared, "a different target runs its own scan")
+
+	_, _, err = c.do(context.Background(), "file:3", 0, scan)
+	require.NoError(t, err)
+	_, shared, err = c.do(context.Background(), "file:3", 0, scan)
+	require.NoError(t, err)
+	assert.False(t, shared, "with no window, a finished scan isn't reused")
+	assert.Equal(t, int32(4), runs.Load())
+}
+
+func TestScanCoalescer_ErrorsAreNotReused(t *testing.T) {
+	t.Parallel()
+
+	var c scanCoalescer
+	var runs atomic.Int32
+	scan := func() (*books.ScanResult, error) {
+		runs.Add(1)
+		return nil, errors.New("boom")
+	}
+
+	_, _, err := c.do(context.Background(), "book:1", time.Hour, scan)
+	require.Error(t, err)
+	_, shared, err := c.do(context.Background(), "book:1", time.Hour, scan)
+	require.Error(t, err)
+	assert.False(t, shared)
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestScanKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, scanKey(books.ScanOptions{BookID: 1}), scanKey(books.ScanOptions{BookID: 1}))
+	assert.NotEqual(t, scanKey(books.ScanOptions{BookID: 1}), scanKey(books.ScanOptions{FileID: 1}))
+	assert.NotEqual(t, scanKey(books.ScanOptions{BookID: 1}), scanKey(books.ScanOptions{BookID: 1, Reset: true}))
+	assert.NotEqual(t, scanKey(books.ScanOptions{FilePath: "/a", LibraryID: 1}), scanKey(books.ScanOptions{FilePath: "/a", LibraryID: 2}))
+}
+
+func TestScan_DetachedFromCallerContext(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+	bookDir := testgen.CreateSubDir(t, libraryPath, "[Test Author] Test Book")
+	testgen.GenerateEPUB(t, bookDir, "test.epub", testgen.EPUBOptions{
+		Title:   "Test Book",
+		Authors: []string{"Test Author"},
+	})
+	require.NoError(t, tc.runScan())
+	files := tc.listFiles()
+	require.Len(t, files, 1)
+
+	// Other callers may be waiting on this scan, so the caller that started
+	// it going away must not cut it short.
+	ctx, cancel := context.WithCancel(tc.ctx)
+	cancel()
+	result, err := tc.worker.Scan(ctx, books.ScanOptions{FileID: files[0].ID})
+	require.NoError(t, err)
+	require.NotNil(t, result.File)
+	assert.Equal(t, files[0].ID, result.File.ID)
+}
@@ -143,7 +143,7 @@ func TestEnricherCoverPluginSourceCheck(t *testing.T) {
 // test for a bug where upgradeEnricherCover silently failed to write the
 // upgraded cover when given a synthetic organized-folder bookPath that
 // did not yet exist on disk. For root-level new files in libraries with
-// OrganizeFileStructure enabled, scanFileCreateNew computes bookPath as
+// organizing enabled, scanFileCreateNew computes bookPath as
 // filepath.Join(libraryPath, organizedFolderName) — a planned path that
 // is not created until later in the batch. The cover dir must fall back
 // to filepath.Dir(file.Filepath) (the library dir where extractAndSaveCover
@@ -200,3 +200,200 @@ func TestUpgradeEnricherCover_RootLevelFile_SyntheticBookPath(t *testing.T) {
 	_, err = os.Stat(syntheticBookPath)
 	assert.True(t, os.IsNotExist(err), "synthetic bookPath must still not exist after upgrade")
 }
+
+func TestUpgradeEnricherCover_Preferred(t *testing.T) {
+	t.Parallel()
+
+	pluginSource := models.PluginDataSource("test", "enricher")
+
+	run := func(t *testing.T, currentSource string, preferred bool, coverData []byte) int {
+		t.Helper()
+		tc := newTestContext(t)
+
+		bookDir := t.TempDir()
+		filePath := filepath.Join(bookDir, "book.epub")
+		require.NoError(t, os.WriteFile(filePath, []byte("fake epub"), 0644))
+		coverPath := filepath.Join(bookDir, "book.epub.cover.jpg")
+		require.NoError(t, os.WriteFile(coverPath, makeJPEG(800, 1200), 0644))
+
+		coverFilename := "book.epub.cover.jpg"
+		file := &models.File{
+			Filepath:           filePath,
+			FileType:           models.FileTypeEPUB,
+			CoverImageFilename: &coverFilename,
+			CoverSource:        &currentSource,
+		}
+
+		// The enricher cover is smaller than the embedded one, so it only
+		// wins when preferred.
+		metadata := &mediafile.ParsedMetadata{
+			CoverData:      coverData,
+			CoverMimeType:  "image/jpeg",
+			CoverPreferred: preferred,
+			FieldDataSources: map[string]string{
+				"cover": pluginSource,
+			},
+		}
+
+		tc.worker.upgradeEnricherCover(tc.ctx, metadata, file, bookDir, nil)
+
+		data, err := os.ReadFile(coverPath)
+		require.NoError(t, err)
+		return fileutils.ImageResolution(data)
+	}
+
+	t.Run("preferred cover replaces file metadata cover", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, 200*300, run(t, models.DataSourceEPUBMetadata, true, makeJPEG(200, 300)))
+	})
+
+	t.Run("not preferred keeps larger file metadata cover", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, 800*1200, run(t, models.DataSourceEPUBMetadata, false, makeJPEG(200, 300)))
+	})
+
+	t.Run("preferred cover does not replace manual cover", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, 800*1200, run(t, models.DataSourceManual, true, makeJPEG(200, 300)))
+	})
+
+	t.Run("preferred cover does not replace sidecar cover", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, 800*1200, run(t, models.DataSourceSidecar, true, makeJPEG(200, 300)))
+	})
+
+	t.Run("preferred cover must still decode", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, 800*1200, run(t, models.DataSourceEPUBMetadata, true, []byte("not an image")))
+	})
+}
+
+func TestUpgradeEnricherCover_NeverReplacesManualCover(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	bookDir := t.TempDir()
+	filePath := filepath.Join(bookDir, "book.epub")
+	require.NoError(t, os.WriteFile(filePath, []byte("fake epub"), 0644))
+	coverPath := filepath.Join(bookDir, "book.epub.cover.jpg")
+	require.NoError(t, os.WriteFile(coverPath, makeJPEG(200, 300), 0644))
+
+	coverFilename := "book.epub.cover.jpg"
+	coverSource := models.DataSourceManual
+	file := &models.File{
+		Filepath:           filePath,
+		FileType:           models.FileTypeEPUB,
+		CoverImageFilename: &coverFilename,
+		CoverSource:        &coverSource,
+	}
+
+	// The enricher cover is larger, which would normally win the
+	// resolution gate.
+	metadata := &mediafile.ParsedMetadata{
+		CoverData:     makeJPEG(800, 1200),
+		CoverMimeType: "image/jpeg",
+		FieldDataSources: map[string]string{
+			"cover": models.PluginDataSource("test", "enricher"),
+		},
+	}
+
+	tc.worker.upgradeEnricherCover(tc.ctx, metadata, file, bookDir, nil)
+
+	data, err := os.ReadFile(coverPath)
+	require.NoError(t, err)
+	assert.Equal(t, 200*300, fileutils.ImageResolution(data))
+	assert.Equal(t, models.DataSourceManual, *file.CoverSource)
+}
+
+func TestUpgradeEnricherCover_KeepOriginalCover(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.KeepOriginalCover = true
+
+	bookDir := t.TempDir()
+	filePath := filepath.Join(bookDir, "book.epub")
+	require.NoError(t, os.WriteFile(filePath, []byte("fake epub"), 0644))
+	embedded := makeJPEG(200, 300)
+	require.NoError(t, os.WriteFile(filepath.Join(bookDir, "book.epub.cover.jpg"), embedded, 0644))
+
+	coverFilename := "book.epub.cover.jpg"
+	embeddedSource := models.DataSourceEPUBMetadata
+	file := &models.File{
+		Filepath:           filePath,
+		FileType:           models.FileTypeEPUB,
+		CoverImageFilename: &coverFilename,
+		CoverSource:        &embeddedSource,
+	}
+	metadata := &mediafile.ParsedMetadata{
+		CoverData:     makeJPEG(800, 1200),
+		CoverMimeType: "image/jpeg",
+		FieldDataSources: map[string]string{
+			"cover": models.PluginDataSource("test", "enricher"),
+		},
+	}
+
+	tc.worker.upgradeEnricherCover(tc.ctx, metadata, file, bookDir, nil)
+
+	current, err := os.ReadFile(filepath.Join(bookDir, "book.epub.cover.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, 800*1200, fileutils.ImageResolution(current))
+	original, err := os.ReadFile(filepath.Join(bookDir, "book.epub.cover.original.jpg"))
+	require.NoError(t, err, "the embedded cover should be kept")
+	assert.Equal(t, embedded, original)
+}
+
+func TestUpgradeEmbeddedCover(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		fileType      string
+		currentSource string
+		embedded      []byte
+		wantUpgrade   bool
+	}{
+		{"larger embedded cover replaces it", models.FileTypeEPUB, models.DataSourceEPUBMetadata, makeJPEG(400, 600), true},
+		{"replaces a cover found next to the file", models.FileTypeEPUB, models.DataSourceExistingCover, makeJPEG(400, 600), true},
+		{"same size is kept", models.FileTypeEPUB, models.DataSourceEPUBMetadata, makeJPEG(200, 300), false},
+		{"smaller is kept", models.FileTypeEPUB, models.DataSourceEPUBMetadata, makeJPEG(100, 150), false},
+		{"manual cover is kept", models.FileTypeEPUB, models.DataSourceManual, makeJPEG(400, 600), false},
+		{"plugin cover is kept", models.FileTypeEPUB, models.PluginDataSource("test", "enricher"), makeJPEG(400, 600), false},
+		{"page-based files are skipped", models.FileTypeCBZ, models.DataSourceCBZMetadata, makeJPEG(400, 600), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tc := newTestContext(t)
+
+			bookDir := t.TempDir()
+			filePath := filepath.Join(bookDir, "book."+tt.fileType)
+			require.NoError(t, os.WriteFile(filePath, []byte("fake book"), 0644))
+			coverFilename := "book." + tt.fileType + ".cover.jpg"
+			require.NoError(t, os.WriteFile(filepath.Join(bookDir, coverFilename), makeJPEG(200, 300), 0644))
+
+			currentSource := tt.currentSource
+			file := &models.File{
+				Filepath:           filePath,
+				FileType:           tt.fileType,
+				CoverImageFilename: &coverFilename,
+				CoverSource:        &currentSource,
+			}
+			metadata := &mediafile.ParsedMetadata{
+				CoverData:     tt.embedded,
+				CoverMimeType: "image/jpeg",
+				DataSource:    models.EmbeddedDataSource(tt.fileType),
+			}
+
+			tc.worker.upgradeEmbeddedCover(tc.ctx, metadata, file, nil)
+
+			data, err := os.ReadFile(filepath.Join(bookDir, coverFilename))
+			require.NoError(t, err)
+			if tt.wantUpgrade {
+				assert.Equal(t, fileutils.ImageResolution(tt.embedded), fileutils.ImageResolution(data))
+				assert.Equal(t, models.EmbeddedDataSource(tt.fileType), *file.CoverSource)
+			} else {
+				assert.Equal(t, 200*300, fileutils.ImageResolution(data))
+				assert.Equal(t, tt.currentSource, *file.CoverSource)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shishobooks/shisho/internal/testgen"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createLibraries creates one library per name and returns their paths.
+func createLibraries(t *testing.T, tc *testContext, names ...string) []string {
+	t.Helper()
+
+	var paths []string
+	for _, name := range names {
+		libraryPath := testgen.TempLibraryDir(t)
+		paths = append(paths, libraryPath)
+		require.NoError(t, tc.libraryService.CreateLibrary(tc.ctx, &models.Library{
+			Name:             name,
+			CoverAspectRatio: "book",
+			LibraryPaths:     []*models.LibraryPath{{Filepath: libraryPath}},
+		}))
+	}
+	return paths
+}
+
+// writeSharedEPUBs writes the same EPUB into each of the given directories,
+// each under its own book folder.
+func writeSharedEPUBs(t *testing.T, dirs ...string) {
+	t.Helper()
+
+	bookDir := testgen.CreateSubDir(t, dirs[0], "[Test Author] Shared Book")
+	epubPath := testgen.GenerateEPUB(t, bookDir, "shared.epub", testgen.EPUBOptions{
+		Title:   "Shared Book",
+		Authors: []string{"Test Author"},
+	})
+	data, err := os.ReadFile(epubPath)
+	require.NoError(t, err)
+	for i, dir := range dirs[1:] {
+		copyDir := testgen.CreateSubDir(t, dir, filepath.Join("copies", string(rune('a'+i)), "[Test Author] Shared Book"))
+		require.NoError(t, os.WriteFile(filepath.Join(copyDir, "shared.epub"), data, 0644))
+	}
+}
+
+func TestScan_DedupeIdenticalFiles(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.DedupeIdenticalFiles = true
+	paths := createLibraries(t, tc, "Library")
+	writeSharedEPUBs(t, paths[0], paths[0])
+
+	require.NoError(t, tc.runScan())
+
+	allBooks := tc.listBooks()
+	require.Len(t, allBooks, 1, "the identical file should be linked to the existing book")
+	files := tc.listFiles()
+	require.Len(t, files, 2)
+	for _, f := range files {
+		assert.Equal(t, allBooks[0].ID, f.BookID)
+
+		fps, err := tc.worker.fingerprintService.ListForFile(tc.ctx, f.ID, models.FingerprintAlgorithmSHA256)
+		require.NoError(t, err)
+		assert.Len(t, fps, 1, "the hash computed for dedupe should be stored")
+	}
+
+	// Rescanning finds the linked file instead of importing it again.
+	require.NoError(t, tc.runScan())
+	assert.Len(t, tc.listBooks(), 1)
+	assert.Len(t, tc.listFiles(), 2)
+}
+
+func TestScan_DedupeIdenticalFiles_NotAcrossLibraries(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.DedupeIdenticalFiles = true
+	paths := createLibraries(t, tc, "First Library", "Second Library")
+	writeSharedEPUBs(t, paths[0], paths[1])
+
+	require.NoError(t, tc.runScan())
+
+	allBooks := tc.listBooks()
+	require.Len(t, allBooks, 2, "each library should get its own book")
+	assert.NotEqual(t, allBooks[0].LibraryID, allBooks[1].LibraryID)
+}
+
+func TestScan_DedupeIdenticalFilesDisabled(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	paths := createLibraries(t, tc, "Library")
+	writeSharedEPUBs(t, paths[0], paths[0])
+
+	require.NoError(t, tc.runScan())
+
+	assert.Len(t, tc.listBooks(), 2)
+}
@@ -333,6 +333,22 @@ func TestMergeEnrichedMetadata_CopiesCompleteSeriesNumberGroup(t *testing.T) {
 	assert.Equal(t, unit, *target.SeriesNumberUnit)
 }
 
+func TestMergeEnrichedMetadata_FillsReadingOrder(t *testing.T) {
+	t.Parallel()
+
+	target := &mediafile.ParsedMetadata{SeriesNumber: pointerutil.Float64(4)}
+	enrichment := &mediafile.ParsedMetadata{
+		SeriesNumber:       pointerutil.Float64(4),
+		SeriesReadingOrder: pointerutil.Float64(0.5),
+	}
+
+	mergeEnrichedMetadata(target, enrichment, "plugin:test/enricher")
+
+	require.NotNil(t, target.SeriesReadingOrder)
+	assert.InDelta(t, 0.5, *target.SeriesReadingOrder, 0.001)
+	assert.Equal(t, "plugin:test/enricher", target.FieldDataSources["series"])
+}
+
 func TestMergeEnrichedMetadata_DiscardsMalformedSeriesNumberGroup(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/shishobooks/shisho/pkg/books"
+	"github.com/shishobooks/shisho/pkg/metrics"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// newScanError builds the scan error recorded for a file that failed to
+// scan. knownFile is the library's existing file at path, if any.
+func newScanError(libraryID int, path string, err error, knownFile *models.File) *models.ScanError {
+	scanError := &models.ScanError{
+		LibraryID: libraryID,
+		Path:      path,
+		Code:      metrics.ErrorCode(err),
+		Message:   err.Error(),
+	}
+	if knownFile != nil {
+		scanError.FileID = &knownFile.ID
+	}
+	return scanError
+}
+
+// RetryScanError scans the file behind a scan error again. Files already in
+// the library are resynced and new ones are imported by path. The error is
+// cleared if the scan succeeds, and otherwise updated and returned.
+func (w *Worker) RetryScanError(ctx context.Context, scanError *models.ScanError) (*models.ScanError, error) {
+	opts := books.ScanOptions{FilePath: scanError.Path, LibraryID: scanError.LibraryID}
+	if scanError.FileID != nil {
+		opts = books.ScanOptions{FileID: *scanError.FileID}
+	}
+
+	if _, err := w.Scan(ctx, opts); err != nil {
+		updated := newScanError(scanError.LibraryID, scanError.Path, err, nil)
+		updated.FileID = scanError.FileID
+		if err := w.libraryService.RecordScanError(ctx, updated); err != nil {
+			return nil, err
+		}
+		return updated, nil
+	}
+
+	if err := w.libraryService.DeleteScanError(ctx, scanError.ID); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
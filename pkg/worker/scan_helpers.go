@@ -94,8 +94,10 @@ func equalStringSlices(a, b []string) bool {
 
 // shouldApplySidecarScalar determines if a sidecar scalar value should be applied.
 // Sidecars have higher priority than file metadata and can override it.
+// sidecarSource is the source the value is applied with: sidecar, or the
+// source the field is pinned to in the sidecar's sources map.
 // When forceRefresh is true, sidecars are skipped entirely - the embedded file metadata wins.
-func shouldApplySidecarScalar(newValue, existingValue, existingSource string, forceRefresh bool) bool {
+func shouldApplySidecarScalar(newValue, existingValue, sidecarSource, existingSource string, forceRefresh bool) bool {
 	// Force refresh skips sidecars - embedded file metadata should win
 	if forceRefresh {
 		return false
@@ -117,7 +119,7 @@ func shouldApplySidecarScalar(newValue, existingValue, existingSource string, fo
 	}
 
 	// Sidecar has its own priority level, higher than file metadata
-	sidecarPriority := models.GetDataSourcePriority(models.DataSourceSidecar)
+	sidecarPriority := models.GetDataSourcePriority(sidecarSource)
 	existingPriority := models.GetDataSourcePriority(existingSource)
 
 	return sidecarPriority < existingPriority
@@ -125,8 +127,10 @@ func shouldApplySidecarScalar(newValue, existingValue, existingSource string, fo
 
 // shouldApplySidecarRelationship determines if a sidecar relationship should be applied.
 // Sidecars have higher priority than file metadata and can override it.
+// sidecarSource is the source the items are applied with: sidecar, or the
+// source the field is pinned to in the sidecar's sources map.
 // When forceRefresh is true, sidecars are skipped entirely - the embedded file metadata wins.
-func shouldApplySidecarRelationship(newItems, existingItems []string, existingSource string, forceRefresh bool) bool {
+func shouldApplySidecarRelationship(newItems, existingItems []string, sidecarSource, existingSource string, forceRefresh bool) bool {
 	// Force refresh skips sidecars - embedded file metadata should win
 	if forceRefresh {
 		return false
@@ -148,7 +152,7 @@ func shouldApplySidecarRelationship(newItems, existingItems []string, existingSo
 	}
 
 	// Sidecar has its own priority level, higher than file metadata
-	sidecarPriority := models.GetDataSourcePriority(models.DataSourceSidecar)
+	sidecarPriority := models.GetDataSourcePriority(sidecarSource)
 	existingPriority := models.GetDataSourcePriority(existingSource)
 
 	return sidecarPriority < existingPriority
@@ -159,25 +163,38 @@ func shouldUpdateParsedSeries(incoming *mediafile.ParsedMetadata, existing []*mo
 		return false
 	}
 	newSource := incoming.SourceForField("series")
-	if len(existing) != 1 || existing[0].Series == nil || existing[0].Series.Name != incoming.Series {
-		existingNames := make([]string, 0, len(existing))
-		for _, membership := range existing {
-			if membership.Series != nil {
-				existingNames = append(existingNames, membership.Series.Name)
-			}
+	incomingSeries := incoming.AllSeries()
+	incomingNames := make([]string, 0, len(incomingSeries))
+	for _, s := range incomingSeries {
+		incomingNames = append(incomingNames, s.Name)
+	}
+	existingNames := make([]string, 0, len(existing))
+	for _, membership := range existing {
+		if membership.Series != nil {
+			existingNames = append(existingNames, membership.Series.Name)
 		}
-		return shouldUpdateRelationship([]string{incoming.Series}, existingNames, newSource, existingSource, forceRefresh)
+	}
+	if len(existing) != len(incomingSeries) || !equalStringSlices(incomingNames, existingNames) {
+		return shouldUpdateRelationship(incomingNames, existingNames, newSource, existingSource, forceRefresh)
 	}
 
 	// A partially present or invalid external group must never mutate storage.
-	groupPresent := incoming.SeriesNumber != nil || incoming.SeriesNumberEnd != nil || incoming.SeriesNumberUnit != nil
-	if groupPresent && !validSeriesNumberGroup(incoming.SeriesNumber, incoming.SeriesNumberEnd, incoming.SeriesNumberUnit) {
-		return false
+	groupPresent := false
+	groupMatches := true
+	for i, s := range incomingSeries {
+		present := s.Number != nil || s.NumberEnd != nil || s.Unit != nil
+		if present && !validSeriesNumberGroup(s.Number, s.NumberEnd, s.Unit) {
+			return false
+		}
+		groupPresent = groupPresent || present || externalReadingOrder(s.ReadingOrder) != nil
+		current := existing[i]
+		if !equalFloatPointers(s.Number, current.SeriesNumber) ||
+			!equalFloatPointers(s.NumberEnd, current.SeriesNumberEnd) ||
+			!equalStringPointers(s.Unit, current.SeriesNumberUnit) ||
+			!equalFloatPointers(externalReadingOrder(s.ReadingOrder), current.ReadingOrder) {
+			groupMatches = false
+		}
 	}
-	current := existing[0]
-	groupMatches := equalFloatPointers(incoming.SeriesNumber, current.SeriesNumber) &&
-		equalFloatPointers(incoming.SeriesNumberEnd, current.SeriesNumberEnd) &&
-		equalStringPointers(incoming.SeriesNumberUnit, current.SeriesNumberUnit)
 	if groupMatches {
 		return forceRefresh && newSource != existingSource
 	}
@@ -193,7 +210,7 @@ func shouldUpdateParsedSeries(incoming *mediafile.ParsedMetadata, existing []*mo
 	return models.GetDataSourcePriority(newSource) <= models.GetDataSourcePriority(existingSource)
 }
 
-func shouldApplySeriesSidecar(incoming []sidecar.SeriesMetadata, existing []*models.BookSeries, existingSource string, forceRefresh bool) bool {
+func shouldApplySeriesSidecar(incoming []sidecar.SeriesMetadata, existing []*models.BookSeries, sidecarSource, existingSource string, forceRefresh bool) bool {
 	if forceRefresh || len(incoming) == 0 {
 		return false
 	}
@@ -209,7 +226,7 @@ func shouldApplySeriesSidecar(incoming []sidecar.SeriesMetadata, existing []*mod
 	if existingSource == "" {
 		existingSource = models.DataSourceFilepath
 	}
-	return models.GetDataSourcePriority(models.DataSourceSidecar) < models.GetDataSourcePriority(existingSource)
+	return models.GetDataSourcePriority(sidecarSource) < models.GetDataSourcePriority(existingSource)
 }
 
 func seriesSidecarMatches(incoming []sidecar.SeriesMetadata, existing []*models.BookSeries) bool {
@@ -220,7 +237,8 @@ func seriesSidecarMatches(incoming []sidecar.SeriesMetadata, existing []*models.
 		if existing[i].Series == nil || incoming[i].Name != existing[i].Series.Name ||
 			!equalFloatPointers(incoming[i].Number, existing[i].SeriesNumber) ||
 			!equalFloatPointers(incoming[i].NumberEnd, existing[i].SeriesNumberEnd) ||
-			!equalStringPointers(incoming[i].Unit, existing[i].SeriesNumberUnit) {
+			!equalStringPointers(incoming[i].Unit, existing[i].SeriesNumberUnit) ||
+			!equalFloatPointers(externalReadingOrder(incoming[i].ReadingOrder), existing[i].ReadingOrder) {
 			return false
 		}
 	}
@@ -283,6 +301,55 @@ func sidecarIdentifierKeys(ids []sidecar.IdentifierMetadata) []string {
 	return keys
 }
 
+// narratorKey returns the comparison key for a narrator credit. Roles are
+// part of the key so a rescan picks up a changed role even when the names
+// stay the same, while narrators without roles compare by name alone.
+func narratorKey(name, role string) string {
+	if role == "" {
+		return name
+	}
+	return name + " (" + role + ")"
+}
+
+// narratorKeys returns comparison keys for a file's stored narrators. Paired
+// with parsedNarratorKeys and sidecarNarratorKeys below.
+func narratorKeys(narrators []*models.Narrator) []string {
+	keys := make([]string, 0, len(narrators))
+	for _, n := range narrators {
+		if n.Person != nil {
+			role := ""
+			if n.Role != nil {
+				role = *n.Role
+			}
+			keys = append(keys, narratorKey(n.Person.Name, role))
+		}
+	}
+	return keys
+}
+
+// parsedNarratorKeys returns comparison keys for narrators parsed from file
+// metadata.
+func parsedNarratorKeys(narrators []mediafile.ParsedNarrator) []string {
+	keys := make([]string, 0, len(narrators))
+	for _, n := range narrators {
+		keys = append(keys, narratorKey(n.Name, n.Role))
+	}
+	return keys
+}
+
+// sidecarNarratorKeys returns comparison keys for narrators from a sidecar.
+func sidecarNarratorKeys(narrators []sidecar.NarratorMetadata) []string {
+	keys := make([]string, 0, len(narrators))
+	for _, n := range narrators {
+		role := ""
+		if n.Role != nil {
+			role = *n.Role
+		}
+		keys = append(keys, narratorKey(n.Name, role))
+	}
+	return keys
+}
+
 // appendIfMissing appends items to the slice only if they're not already present.
 // Used to avoid duplicating columns when sidecar and metadata both want to update the same field.
 func appendIfMissing(slice []string, items ...string) []string {
@@ -4,7 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/shishobooks/shisho/internal/testgen"
 	"github.com/shishobooks/shisho/pkg/mediafile"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/shishobooks/shisho/pkg/sidecar"
@@ -559,7 +561,7 @@ func TestShouldApplySidecarScalar(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := shouldApplySidecarScalar(tt.newValue, tt.existingValue, tt.existingSource, tt.forceRefresh)
+			got := shouldApplySidecarScalar(tt.newValue, tt.existingValue, models.DataSourceSidecar, tt.existingSource, tt.forceRefresh)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -590,6 +592,39 @@ func TestShouldUpdateParsedSeries_NumberGroupUsesSourcePriority(t *testing.T) {
 	assert.False(t, shouldUpdateParsedSeries(malformed, existing, models.DataSourceFileMetadata, true))
 }
 
+func TestShouldUpdateParsedSeries_AdditionalSeries(t *testing.T) {
+	t.Parallel()
+
+	existing := []*models.BookSeries{
+		{Series: &models.Series{Name: "Saga"}, SeriesNumber: seriesFloatPtr(1), SortOrder: 1},
+		{Series: &models.Series{Name: "The War for Phang"}, SeriesNumber: seriesFloatPtr(2), SortOrder: 2},
+	}
+	same := &mediafile.ParsedMetadata{
+		Series: "Saga", SeriesNumber: seriesFloatPtr(1),
+		AdditionalSeries: []mediafile.ParsedSeries{{Name: "The War for Phang", Number: seriesFloatPtr(2)}},
+		DataSource:       models.DataSourceFileMetadata,
+	}
+	newArc := &mediafile.ParsedMetadata{
+		Series: "Saga", SeriesNumber: seriesFloatPtr(1),
+		AdditionalSeries: []mediafile.ParsedSeries{{Name: "Homeworld"}},
+		DataSource:       models.DataSourceFileMetadata,
+	}
+	arcNumberChanged := &mediafile.ParsedMetadata{
+		Series: "Saga", SeriesNumber: seriesFloatPtr(1),
+		AdditionalSeries: []mediafile.ParsedSeries{{Name: "The War for Phang", Number: seriesFloatPtr(3)}},
+		DataSource:       models.DataSourceFileMetadata,
+	}
+	primaryOnly := &mediafile.ParsedMetadata{
+		Series: "Saga", SeriesNumber: seriesFloatPtr(1), DataSource: models.DataSourceFileMetadata,
+	}
+
+	assert.False(t, shouldUpdateParsedSeries(same, existing, models.DataSourceFileMetadata, false))
+	assert.True(t, shouldUpdateParsedSeries(newArc, existing, models.DataSourceFileMetadata, false))
+	assert.True(t, shouldUpdateParsedSeries(arcNumberChanged, existing, models.DataSourceFileMetadata, false))
+	assert.True(t, shouldUpdateParsedSeries(primaryOnly, existing, models.DataSourceFileMetadata, false))
+	assert.False(t, shouldUpdateParsedSeries(newArc, existing, models.DataSourceManual, false))
+}
+
 func TestShouldApplySeriesSidecar_NumberGroupChanges(t *testing.T) {
 	t.Parallel()
 
@@ -606,9 +641,9 @@ func TestShouldApplySeriesSidecar_NumberGroupChanges(t *testing.T) {
 		Unit:      &unit,
 	}}
 
-	assert.True(t, shouldApplySeriesSidecar(incoming, existing, models.DataSourceFileMetadata, false))
-	assert.False(t, shouldApplySeriesSidecar(incoming, existing, models.DataSourceManual, false))
-	assert.False(t, shouldApplySeriesSidecar(incoming, existing, models.DataSourceFileMetadata, true))
+	assert.True(t, shouldApplySeriesSidecar(incoming, existing, models.DataSourceSidecar, models.DataSourceFileMetadata, false))
+	assert.False(t, shouldApplySeriesSidecar(incoming, existing, models.DataSourceSidecar, models.DataSourceManual, false))
+	assert.False(t, shouldApplySeriesSidecar(incoming, existing, models.DataSourceSidecar, models.DataSourceFileMetadata, true))
 }
 
 func TestShouldApplySeriesSidecar_RejectsMalformedNumberGroup(t *testing.T) {
@@ -625,7 +660,42 @@ func TestShouldApplySeriesSidecar_RejectsMalformedNumberGroup(t *testing.T) {
 		NumberEnd: seriesFloatPtr(2),
 	}}
 
-	assert.False(t, shouldApplySeriesSidecar(incoming, existing, models.DataSourceFileMetadata, false))
+	assert.False(t, shouldApplySeriesSidecar(incoming, existing, models.DataSourceSidecar, models.DataSourceFileMetadata, false))
+}
+
+func TestShouldApplySeriesSidecar_ReadingOrderChanges(t *testing.T) {
+	t.Parallel()
+
+	existing := []*models.BookSeries{{
+		Series:       &models.Series{Name: "Saga"},
+		SeriesNumber: seriesFloatPtr(4),
+	}}
+	incoming := []sidecar.SeriesMetadata{{
+		Name:         "Saga",
+		Number:       seriesFloatPtr(4),
+		ReadingOrder: seriesFloatPtr(0.5),
+	}}
+
+	assert.True(t, shouldApplySeriesSidecar(incoming, existing, models.DataSourceSidecar, models.DataSourceFileMetadata, false))
+
+	existing[0].ReadingOrder = seriesFloatPtr(0.5)
+	assert.False(t, shouldApplySeriesSidecar(incoming, existing, models.DataSourceSidecar, models.DataSourceFileMetadata, false))
+}
+
+func TestShouldUpdateParsedSeries_ReadingOrderChanges(t *testing.T) {
+	t.Parallel()
+
+	existing := []*models.BookSeries{{
+		Series:       &models.Series{Name: "Saga"},
+		SeriesNumber: seriesFloatPtr(4),
+	}}
+	withReadingOrder := &mediafile.ParsedMetadata{
+		Series: "Saga", SeriesNumber: seriesFloatPtr(4), SeriesReadingOrder: seriesFloatPtr(0.5),
+		DataSource: models.DataSourcePlugin,
+	}
+
+	assert.True(t, shouldUpdateParsedSeries(withReadingOrder, existing, models.DataSourceFileMetadata, false))
+	assert.False(t, shouldUpdateParsedSeries(withReadingOrder, existing, models.DataSourceManual, false))
 }
 
 func TestApplySeriesNumberUnit_RequiresMatchingSource(t *testing.T) {
@@ -724,7 +794,7 @@ func TestShouldApplySidecarRelationship(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := shouldApplySidecarRelationship(tt.newItems, tt.existingItems, tt.existingSource, tt.forceRefresh)
+			got := shouldApplySidecarRelationship(tt.newItems, tt.existingItems, models.DataSourceSidecar, tt.existingSource, tt.forceRefresh)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -803,7 +873,7 @@ func TestIdentifierDiff_StableAcrossRescans(t *testing.T) {
 	assert.False(t, got, "rescan must not report identifier change when only cosmetic formatting differs")
 
 	// Sidecar path uses the same key-building helpers.
-	got = shouldApplySidecarRelationship(newKeys, existingKeys, models.DataSourceSidecar, false)
+	got = shouldApplySidecarRelationship(newKeys, existingKeys, models.DataSourceSidecar, models.DataSourceSidecar, false)
 	assert.False(t, got, "sidecar rescan must not report identifier change when only cosmetic formatting differs")
 
 	// A genuinely new identifier must still be detected as a change.
@@ -991,3 +1061,159 @@ func TestPartitionSupplementPDFsLast_DoesNotMutateInput(t *testing.T) {
 
 	assert.Equal(t, original, input, "input slice must not be mutated by partition")
 }
+
+// TestNarratorKeys verifies stored, parsed, and sidecar narrators compare
+// equal when their names and roles match, and differ when only a role
+// changes.
+func TestNarratorKeys(t *testing.T) {
+	t.Parallel()
+
+	kaladin := "Kaladin"
+	stored := []*models.Narrator{
+		{Person: &models.Person{Name: "Michael Kramer"}, Role: &kaladin},
+		{Person: &models.Person{Name: "Kate Reading"}},
+	}
+	parsed := []mediafile.ParsedNarrator{{Name: "Michael Kramer", Role: "Kaladin"}, {Name: "Kate Reading"}}
+	fromSidecar := []sidecar.NarratorMetadata{{Name: "Michael Kramer", Role: &kaladin}, {Name: "Kate Reading"}}
+
+	assert.Equal(t, narratorKeys(stored), parsedNarratorKeys(parsed))
+	assert.Equal(t, narratorKeys(stored), sidecarNarratorKeys(fromSidecar))
+
+	parsed[1].Role = "Shallan"
+	assert.NotEqual(t, narratorKeys(stored), parsedNarratorKeys(parsed))
+	assert.Equal(t, []string{"Kate Reading"}, parsedNarratorKeys([]mediafile.ParsedNarrator{{Name: "Kate Reading"}}))
+}
+
+func TestFileStatChanged(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := testgen.GenerateEPUB(t, dir, "book.epub", testgen.EPUBOptions{
+		Title:        "Test Book",
+		ModifiedDate: "2023-02-01T10:30:00Z",
+	})
+	stat, err := os.Stat(path)
+	require.NoError(t, err)
+
+	declared := time.Date(2023, 2, 1, 10, 30, 0, 0, time.UTC)
+	otherDate := declared.Add(time.Hour)
+	recorded := stat.ModTime()
+	copiedAt := recorded.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		file     models.File
+		expected bool
+	}{
+		{"size and mtime match", models.File{FileType: models.FileTypeEPUB, FilesizeBytes: stat.Size(), FileModifiedAt: &recorded}, false},
+		{"size differs", models.File{FileType: models.FileTypeEPUB, FilesizeBytes: stat.Size() + 1, FileModifiedAt: &recorded, ModifiedDate: &declared}, true},
+		{"mtime moved without a declared date", models.File{FileType: models.FileTypeEPUB, FilesizeBytes: stat.Size(), FileModifiedAt: &copiedAt}, true},
+		{"mtime moved but declared date is the same", models.File{FileType: models.FileTypeEPUB, FilesizeBytes: stat.Size(), FileModifiedAt: &copiedAt, ModifiedDate: &declared}, false},
+		{"mtime moved and declared date changed", models.File{FileType: models.FileTypeEPUB, FilesizeBytes: stat.Size(), FileModifiedAt: &copiedAt, ModifiedDate: &otherDate}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, fileStatChanged(path, stat, &tt.file))
+		})
+	}
+}
+
+func TestSidecarBookTitle(t *testing.T) {
+	t.Parallel()
+	raw := "Mistborn #2: The Well of Ascension"
+	edited := "The Well"
+	tests := []struct {
+		name        string
+		sidecar     *sidecar.BookSidecar
+		source      string
+		stripSeries bool
+		wantTitle   string
+		wantRaw     *string
+	}{
+		{
+			name:        "strips a series prefix",
+			sidecar:     &sidecar.BookSidecar{Title: raw},
+			source:      models.DataSourceSidecar,
+			stripSeries: true,
+			wantTitle:   "The Well of Ascension",
+			wantRaw:     &raw,
+		},
+		{
+			name:        "keeps an already stripped title",
+			sidecar:     &sidecar.BookSidecar{Title: "The Well of Ascension", RawTitle: &raw},
+			source:      models.DataSourceSidecar,
+			stripSeries: true,
+			wantTitle:   "The Well of Ascension",
+			wantRaw:     &raw,
+		},
+		{
+			name:      "restores the raw title when disabled",
+			sidecar:   &sidecar.BookSidecar{Title: "The Well of Ascension", RawTitle: &raw},
+			source:    models.DataSourceSidecar,
+			wantTitle: raw,
+		},
+		{
+			name:      "ignores a raw title that no longer matches",
+			sidecar:   &sidecar.BookSidecar{Title: edited, RawTitle: &raw},
+			source:    models.DataSourceSidecar,
+			wantTitle: edited,
+		},
+		{
+			name:        "leaves pinned titles alone",
+			sidecar:     &sidecar.BookSidecar{Title: raw},
+			source:      models.DataSourceManual,
+			stripSeries: true,
+			wantTitle:   raw,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			title, rawTitle := sidecarBookTitle(tt.sidecar, tt.source, tt.stripSeries)
+			assert.Equal(t, tt.wantTitle, title)
+			assert.Equal(t, tt.wantRaw, rawTitle)
+		})
+	}
+}
+
+func TestSplitParsedNames(t *testing.T) {
+	t.Parallel()
+	metadata := &mediafile.ParsedMetadata{
+		Authors: []mediafile.ParsedAuthor{
+			{Name: "Doe, John / Jane Smith", Role: models.AuthorRoleWriter},
+			{Name: "Bob Roe"},
+		},
+		Narrators: []string{"Ann Lee feat. Sam Poe"},
+		NarratorRoles: []mediafile.ParsedNarrator{
+			{Name: "Ann Lee feat. Sam Poe", Role: "Narrator"},
+		},
+	}
+	splitParsedNames(metadata, []string{" / ", " feat. "})
+
+	assert.Equal(t, []mediafile.ParsedAuthor{
+		{Name: "Doe, John", Role: models.AuthorRoleWriter},
+		{Name: "Jane Smith", Role: models.AuthorRoleWriter},
+		{Name: "Bob Roe"},
+	}, metadata.Authors)
+	assert.Equal(t, []string{"Ann Lee", "Sam Poe"}, metadata.Narrators)
+	assert.Equal(t, []mediafile.ParsedNarrator{
+		{Name: "Ann Lee", Role: "Narrator"},
+		{Name: "Sam Poe", Role: "Narrator"},
+	}, metadata.NarratorRoles)
+
+	// Without custom separators, names parsed from the file are left alone.
+	metadata = &mediafile.ParsedMetadata{Authors: []mediafile.ParsedAuthor{{Name: "Doe, John"}}}
+	splitParsedNames(metadata, nil)
+	assert.Equal(t, "Doe, John", metadata.Authors[0].Name)
+}
+
+func TestApplyFilepathFallbacks_NameSeparators(t *testing.T) {
+	t.Parallel()
+	metadata := &mediafile.ParsedMetadata{}
+	// "/" can't appear in a file or folder name, so use a separator that can.
+	applyFilepathFallbacks(metadata, "/library/[John Doe feat. Jane Smith] Title/Title {Ann Lee feat. Sam Poe}.m4b", "/library/[John Doe feat. Jane Smith] Title", "m4b", false, nil, []string{" feat. "})
+	require.Len(t, metadata.Authors, 2)
+	assert.Equal(t, "John Doe", metadata.Authors[0].Name)
+	assert.Equal(t, "Jane Smith", metadata.Authors[1].Name)
+	assert.Equal(t, []string{"Ann Lee", "Sam Poe"}, metadata.Narrators)
+}
@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/fileutils"
+	"github.com/shishobooks/shisho/pkg/libraries"
+)
+
+// isPathIgnored reports whether path is excluded by a .shishoignore file in
+// the library path that contains it. Uses the scan cache's matcher and root
+// paths when available, falling back to a library lookup for single-file scans.
+func (w *Worker) isPathIgnored(ctx context.Context, path string, libraryID int, cache *ScanCache) (bool, error) {
+	var roots []string
+	matcher := fileutils.NewIgnoreMatcher()
+	if cache != nil {
+		roots = cache.LibraryRootPaths()
+		matcher = cache.ignores
+	}
+	if roots == nil {
+		library, err := w.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{
+			ID: &libraryID,
+		})
+		if err != nil {
+			return false, errors.Wrap(err, "failed to retrieve library")
+		}
+		for _, libraryPath := range library.LibraryPaths {
+			roots = append(roots, libraryPath.Filepath)
+		}
+	}
+	for _, root := range roots {
+		if _, ok := fileutils.RelativeParts(root, path); ok {
+			return matcher.IsIgnored(root, path, false), nil
+		}
+	}
+	return false, nil
+}
@@ -202,7 +202,7 @@ func (w *Worker) reconcileMoves(
 
 		// Record as moved orphan so cleanup skips it, and record the book
 		// so the scan's post-processing can re-organize it into the
-		// structured layout (if organize_file_structure is enabled).
+		// structured layout (if the library organizes).
 		movedOrphanIDs[orphanFile.ID] = struct{}{}
 		if orphanFile.BookID != 0 {
 			movedBookIDs[orphanFile.BookID] = struct{}{}
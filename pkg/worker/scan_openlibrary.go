@@ -0,0 +1,179 @@
+package worker
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/identifiers"
+	"github.com/shishobooks/shisho/pkg/joblogs"
+	"github.com/shishobooks/shisho/pkg/libraries"
+	"github.com/shishobooks/shisho/pkg/mediafile"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/openlibrary"
+)
+
+// The built-in Open Library enricher is addressed like a plugin so its values
+// carry a "plugin:builtin/openlibrary" source with plugin priority, and its
+// fields go through the same field settings and filtering as plugin
+// enrichers.
+const (
+	openLibraryScope    = "builtin"
+	openLibraryPluginID = "openlibrary"
+)
+
+// openLibraryFields are the fields the Open Library enricher can fill.
+var openLibraryFields = []string{"title", "subtitle", "authors", "description", "publisher", "releaseDate", "cover"}
+
+// openLibraryDateLayouts are the publish_date formats Open Library commonly
+// uses. Anything else is ignored.
+var openLibraryDateLayouts = []string{
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"January 2006",
+	"Jan 2006",
+	"2006-01-02",
+	"2006",
+}
+
+// runOpenLibraryEnricher looks up the file's ISBN on Open Library and fills
+// fields that are still empty after the file parser and plugin enrichers ran.
+// It only runs for libraries with EnrichFromOpenLibrary set, and any lookup
+// failure (including having no network) leaves metadata unchanged.
+func (w *Worker) runOpenLibraryEnricher(ctx context.Context, metadata *mediafile.ParsedMetadata, file *models.File, libraryID int, jobLog *joblogs.JobLogger) *mediafile.ParsedMetadata {
+	if w.openLibrary == nil || metadata == nil || file == nil {
+		return metadata
+	}
+	isbn := fileISBN(file, metadata)
+	if isbn == "" || !needsOpenLibrary(metadata, file.FileType) {
+		return metadata
+	}
+
+	library, err := w.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{
+		ID: &libraryID,
+	})
+	if err != nil || !library.EnrichFromOpenLibrary {
+		return metadata
+	}
+
+	log := logger.FromContext(ctx)
+	logWarn := func(msg string, data logger.Data) {
+		log.Warn(msg, data)
+		if jobLog != nil {
+			jobLog.Warn(msg, data)
+		}
+	}
+
+	edition, err := w.openLibrary.LookupISBN(ctx, isbn)
+	if err != nil {
+		if !errors.Is(err, openlibrary.ErrNotFound) {
+			log.Debug("open library lookup skipped", logger.Data{"isbn": isbn, "error": err.Error()})
+		}
+		return metadata
+	}
+
+	enabledFields := make(map[string]bool, len(openLibraryFields))
+	for _, f := range openLibraryFields {
+		enabledFields[f] = true
+	}
+	if w.pluginService != nil {
+		settings, fErr := w.pluginService.GetEffectiveFieldSettings(ctx, libraryID, openLibraryScope, openLibraryPluginID, openLibraryFields)
+		if fErr != nil {
+			logWarn("failed to get field settings", logger.Data{
+				"plugin": openLibraryPluginID,
+				"error":  fErr.Error(),
+			})
+		} else {
+			enabledFields = settings
+		}
+	}
+
+	result := editionToMetadata(edition)
+
+	// Page-based formats always use a page as the cover, so only fetch a
+	// cover image for files that don't have one.
+	if enabledFields["cover"] && len(metadata.CoverData) == 0 && !models.IsPageBasedFileType(file.FileType) {
+		data, mimeType, cErr := w.openLibrary.Cover(ctx, edition.CoverID)
+		if cErr == nil {
+			result.CoverData = data
+			result.CoverMimeType = mimeType
+		} else if !errors.Is(cErr, openlibrary.ErrNotFound) {
+			log.Debug("open library cover skipped", logger.Data{"isbn": isbn, "error": cErr.Error()})
+		}
+	}
+
+	filtered := filterMetadataFields(result, openLibraryFields, enabledFields, openLibraryPluginID, logWarn)
+
+	merged := *metadata
+	merged.FieldDataSources = maps.Clone(metadata.FieldDataSources)
+	mergeEnrichedMetadata(&merged, filtered, models.PluginDataSource(openLibraryScope, openLibraryPluginID))
+	return &merged
+}
+
+// fileISBN returns the file's ISBN, preferring ISBN-13 over ISBN-10, from
+// its saved identifiers or, for files not yet saved, the parsed ones.
+func fileISBN(file *models.File, metadata *mediafile.ParsedMetadata) string {
+	var isbn10 string
+	check := func(idType, value string) string {
+		switch identifiers.Type(idType) {
+		case identifiers.TypeISBN13:
+			return value
+		case identifiers.TypeISBN10:
+			if isbn10 == "" {
+				isbn10 = value
+			}
+		}
+		return ""
+	}
+	for _, id := range file.Identifiers {
+		if id == nil {
+			continue
+		}
+		if v := check(id.Type, id.Value); v != "" {
+			return v
+		}
+	}
+	for _, id := range metadata.Identifiers {
+		if v := check(id.Type, id.Value); v != "" {
+			return v
+		}
+	}
+	return isbn10
+}
+
+// needsOpenLibrary reports whether any field Open Library can fill is still
+// empty. Subtitle is left out since most books don't have one.
+func needsOpenLibrary(md *mediafile.ParsedMetadata, fileType string) bool {
+	return md.Title == "" ||
+		len(md.Authors) == 0 ||
+		md.Description == "" ||
+		md.Publisher == "" ||
+		md.ReleaseDate == nil ||
+		(len(md.CoverData) == 0 && !models.IsPageBasedFileType(fileType))
+}
+
+// editionToMetadata converts an Open Library edition to ParsedMetadata.
+func editionToMetadata(edition *openlibrary.Edition) *mediafile.ParsedMetadata {
+	md := &mediafile.ParsedMetadata{
+		Title:       edition.Title,
+		Subtitle:    edition.Subtitle,
+		Description: edition.Description,
+		DataSource:  models.PluginDataSource(openLibraryScope, openLibraryPluginID),
+	}
+	for _, name := range edition.Authors {
+		md.Authors = append(md.Authors, mediafile.ParsedAuthor{Name: name})
+	}
+	if len(edition.Publishers) > 0 {
+		md.Publisher = edition.Publishers[0]
+	}
+	for _, layout := range openLibraryDateLayouts {
+		if t, err := time.Parse(layout, strings.TrimSpace(edition.PublishDate)); err == nil {
+			md.ReleaseDate = &t
+			break
+		}
+	}
+	return md
+}
@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shishobooks/shisho/pkg/mediafile"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/openlibrary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type openLibraryRoundTripper func(*http.Request) (*http.Response, error)
+
+func (f openLibraryRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func newStubOpenLibrary(t *testing.T) *openlibrary.Service {
+	t.Helper()
+	return openlibrary.NewService(openlibrary.ServiceConfig{
+		HTTPClient: &http.Client{Transport: openLibraryRoundTripper(func(r *http.Request) (*http.Response, error) {
+			body := `{"ISBN:9780316769488": {"details": {
+				"title": "Open Library Title",
+				"authors": [{"name": "J. D. Salinger"}],
+				"publishers": ["Little, Brown"],
+				"publish_date": "May 1991",
+				"description": "A classic."
+			}}}`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     make(http.Header),
+			}, nil
+		})},
+		CacheDir:    t.TempDir(),
+		MinInterval: time.Nanosecond,
+	})
+}
+
+func TestRunOpenLibraryEnricher_FillsEmptyFields(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.openLibrary = newStubOpenLibrary(t)
+
+	library := &models.Library{
+		Name:                  "Test Library",
+		CoverAspectRatio:      "book",
+		EnrichFromOpenLibrary: true,
+		LibraryPaths:          []*models.LibraryPath{{Filepath: t.TempDir()}},
+	}
+	require.NoError(t, tc.libraryService.CreateLibrary(tc.ctx, library))
+
+	file := &models.File{FileType: models.FileTypeEPUB}
+	metadata := &mediafile.ParsedMetadata{
+		Title:       "File Title",
+		Identifiers: []mediafile.ParsedIdentifier{{Type: "isbn_13", Value: "978-0-316-76948-8"}},
+		DataSource:  models.DataSourceEPUBMetadata,
+	}
+
+	result := tc.worker.runOpenLibraryEnricher(context.Background(), metadata, file, library.ID, nil)
+
+	// The file's own title is kept; only empty fields are filled.
+	assert.Equal(t, "File Title", result.Title)
+	require.Len(t, result.Authors, 1)
+	assert.Equal(t, "J. D. Salinger", result.Authors[0].Name)
+	assert.Equal(t, "Little, Brown", result.Publisher)
+	assert.Equal(t, "A classic.", result.Description)
+	require.NotNil(t, result.ReleaseDate)
+	assert.Equal(t, time.Date(1991, 5, 1, 0, 0, 0, 0, time.UTC), *result.ReleaseDate)
+	assert.Equal(t, "plugin:builtin/openlibrary", result.SourceForField("authors"))
+	assert.Equal(t, models.DataSourceEPUBMetadata, result.DataSource)
+	assert.Empty(t, metadata.Authors, "input metadata should not be modified")
+}
+
+func TestRunOpenLibraryEnricher_DisabledForLibrary(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.openLibrary = newStubOpenLibrary(t)
+
+	library := &models.Library{
+		Name:             "Test Library",
+		CoverAspectRatio: "book",
+		LibraryPaths:     []*models.LibraryPath{{Filepath: t.TempDir()}},
+	}
+	require.NoError(t, tc.libraryService.CreateLibrary(tc.ctx, library))
+
+	file := &models.File{FileType: models.FileTypeEPUB}
+	metadata := &mediafile.ParsedMetadata{
+		Identifiers: []mediafile.ParsedIdentifier{{Type: "isbn_13", Value: "9780316769488"}},
+	}
+
+	result := tc.worker.runOpenLibraryEnricher(context.Background(), metadata, file, library.ID, nil)
+	assert.Same(t, metadata, result)
+}
@@ -2,8 +2,10 @@ package worker
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/robinjoseph08/golib/logger"
@@ -22,6 +24,8 @@ import (
 // cache is optional (may be nil). When provided, files whose IDs appear in
 // cache.movedOrphanIDs are skipped — they were already reconciled by the move
 // reconciliation phase and must not be deleted.
+//
+// It returns how many main files and books were deleted, for the scan report.
 func (w *Worker) cleanupOrphanedFiles(
 	ctx context.Context,
 	existingFiles []*models.File,
@@ -29,7 +33,7 @@ func (w *Worker) cleanupOrphanedFiles(
 	library *models.Library,
 	jobLog *joblogs.JobLogger,
 	cache ...*ScanCache,
-) {
+) (filesDeleted, booksDeleted int) {
 	// Resolve optional cache argument.
 	var sc *ScanCache
 	if len(cache) > 0 {
@@ -53,12 +57,27 @@ func (w *Worker) cleanupOrphanedFiles(
 				})
 				continue
 			}
+			// Files that are merely missing from disk get a grace period;
+			// ignored or disallowed files that are still there don't.
+			if _, statErr := os.Stat(file.Filepath); os.IsNotExist(statErr) {
+				deferred, err := w.deferMissingFileDeletion(ctx, file)
+				if err != nil {
+					jobLog.Warn("failed to mark file missing", logger.Data{"file_id": file.ID, "error": err.Error()})
+				} else if deferred {
+					jobLog.Info("orphan cleanup: keeping missing file during grace period", logger.Data{
+						"file_id":       file.ID,
+						"filepath":      file.Filepath,
+						"missing_scans": file.MissingScans,
+					})
+					continue
+				}
+			}
 			orphansByBook[file.BookID] = append(orphansByBook[file.BookID], file)
 		}
 	}
 
 	if len(orphansByBook) == 0 {
-		return
+		return 0, 0
 	}
 
 	jobLog.Info("batch orphan cleanup starting", logger.Data{
@@ -75,8 +94,10 @@ func (w *Worker) cleanupOrphanedFiles(
 	// Also collect file IDs from full-orphan books where a supplement was promoted
 	var promotedBookOrphanFileIDs []int
 
-	// Collect book IDs for full deletion
+	// Collect book IDs for full deletion, and how many orphaned main files
+	// they account for
 	var bookIDsToDelete []int
+	var bookOrphanFileCount int
 
 	for bookID, orphans := range orphansByBook {
 		// Track directories for all orphans
@@ -97,6 +118,8 @@ func (w *Worker) cleanupOrphanedFiles(
 	if len(partialOrphanFileIDs) > 0 {
 		if err := w.bookService.DeleteFilesByIDs(ctx, partialOrphanFileIDs); err != nil {
 			jobLog.Warn("failed to batch-delete partial orphan files", logger.Data{"error": err.Error()})
+		} else {
+			filesDeleted += len(partialOrphanFileIDs)
 		}
 	}
 
@@ -107,6 +130,8 @@ func (w *Worker) cleanupOrphanedFiles(
 		models.FileTypeCBZ:  {},
 		models.FileTypeM4B:  {},
 		models.FileTypePDF:  {},
+		models.FileTypeMOBI: {},
+		models.FileTypeAZW3: {},
 	}
 	if w.pluginManager != nil {
 		for ext := range w.pluginManager.RegisteredFileExtensions() {
@@ -139,6 +164,8 @@ func (w *Worker) cleanupOrphanedFiles(
 				}
 				if delErr := w.bookService.DeleteOrphanedBookChildren(ctx, bookID); delErr != nil {
 					jobLog.Warn("failed to delete orphaned book children", logger.Data{"book_id": bookID, "error": delErr.Error()})
+				} else {
+					filesDeleted += len(orphans)
 				}
 				continue
 			}
@@ -205,6 +232,7 @@ func (w *Worker) cleanupOrphanedFiles(
 				}
 			}
 			bookIDsToDelete = append(bookIDsToDelete, bookID)
+			bookOrphanFileCount += len(orphans)
 			// Track book directory for cleanup
 			orphanDirs[book.Filepath] = struct{}{}
 			jobLog.Info("deleting orphaned book", logger.Data{"book_id": bookID})
@@ -215,6 +243,8 @@ func (w *Worker) cleanupOrphanedFiles(
 	if len(promotedBookOrphanFileIDs) > 0 {
 		if err := w.bookService.DeleteFilesByIDs(ctx, promotedBookOrphanFileIDs); err != nil {
 			jobLog.Warn("failed to batch-delete promoted book orphan files", logger.Data{"error": err.Error()})
+		} else {
+			filesDeleted += len(promotedBookOrphanFileIDs)
 		}
 	}
 
@@ -222,6 +252,9 @@ func (w *Worker) cleanupOrphanedFiles(
 	if len(bookIDsToDelete) > 0 {
 		if err := w.bookService.DeleteBooksByIDs(ctx, bookIDsToDelete); err != nil {
 			jobLog.Warn("failed to batch-delete orphaned books", logger.Data{"error": err.Error()})
+		} else {
+			filesDeleted += bookOrphanFileCount
+			booksDeleted += len(bookIDsToDelete)
 		}
 	}
 
@@ -246,4 +279,39 @@ func (w *Worker) cleanupOrphanedFiles(
 		"promoted_files_attempted": len(promotedBookOrphanFileIDs),
 		"books_attempted":          len(bookIDsToDelete),
 	})
+
+	return filesDeleted, booksDeleted
+}
+
+// deferMissingFileDeletion records another scan that found file missing from
+// disk. It returns true while the file is within config.MissingFileGraceScans,
+// in which case the caller keeps the record instead of deleting it.
+func (w *Worker) deferMissingFileDeletion(ctx context.Context, file *models.File) (bool, error) {
+	grace := w.config.MissingFileGraceScans
+	if grace <= 0 || file.MissingScans >= grace {
+		return false, nil
+	}
+
+	if file.MissingSince == nil {
+		now := time.Now()
+		file.MissingSince = &now
+	}
+	file.MissingScans++
+	err := w.bookService.UpdateFile(ctx, file, books.UpdateFileOptions{Columns: []string{"missing_since", "missing_scans"}})
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+// clearFileMissing resets the missing-from-disk tracking for a file that was
+// found again.
+func (w *Worker) clearFileMissing(ctx context.Context, file *models.File) error {
+	if file.MissingSince == nil && file.MissingScans == 0 {
+		return nil
+	}
+	file.MissingSince = nil
+	file.MissingScans = 0
+	err := w.bookService.UpdateFile(ctx, file, books.UpdateFileOptions{Columns: []string{"missing_since", "missing_scans"}})
+	return errors.WithStack(err)
 }
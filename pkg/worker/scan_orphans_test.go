@@ -408,3 +408,48 @@ func TestCleanupOrphanedFiles_MissingBookRow_SubsequentScanIsClean(t *testing.T)
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestProcessScanJob_MissingFileGraceScans(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.MissingFileGraceScans = 1
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+
+	bookDir := testgen.CreateSubDir(t, libraryPath, "[Author] Flaky Mount")
+	testgen.GenerateEPUB(t, bookDir, "book.epub", testgen.EPUBOptions{
+		Title:   "Flaky Mount",
+		Authors: []string{"Author"},
+	})
+
+	require.NoError(t, tc.runScan())
+	files := tc.listFiles()
+	require.Len(t, files, 1)
+	path := files[0].Filepath
+	hiddenPath := filepath.Join(t.TempDir(), "book.epub")
+
+	// Missing for one scan: the record is kept and marked missing.
+	require.NoError(t, os.Rename(path, hiddenPath))
+	require.NoError(t, tc.runScan())
+	files = tc.listFiles()
+	require.Len(t, files, 1)
+	require.NotNil(t, files[0].MissingSince)
+	assert.Equal(t, 1, files[0].MissingScans)
+
+	// The file coming back clears the mark.
+	require.NoError(t, os.Rename(hiddenPath, path))
+	require.NoError(t, tc.runScan())
+	files = tc.listFiles()
+	require.Len(t, files, 1)
+	assert.Nil(t, files[0].MissingSince)
+	assert.Equal(t, 0, files[0].MissingScans)
+
+	// Missing past the grace period: the book is deleted.
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, tc.runScan())
+	require.Len(t, tc.listFiles(), 1)
+	require.NoError(t, tc.runScan())
+	assert.Empty(t, tc.listFiles())
+	assert.Empty(t, tc.listBooks())
+}
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/books"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/joblogs"
+)
+
+// pairNewBooks joins each book created by a scan with an existing book in the
+// other format (audiobook vs ebook), moving the new book's files onto the
+// existing one. Paired books replace the new ones in booksToOrganize so the
+// moved files get organized with the book they now belong to.
+func (w *Worker) pairNewBooks(ctx context.Context, bookIDs []int, booksToOrganize map[int]struct{}, jobLog *joblogs.JobLogger) {
+	sort.Ints(bookIDs)
+	for _, bookID := range bookIDs {
+		book, err := w.bookService.RetrieveBook(ctx, books.RetrieveBookOptions{ID: &bookID})
+		if errors.Is(err, errcodes.NotFound("Book")) {
+			// Already paired into another book earlier in this loop.
+			continue
+		}
+		if err != nil {
+			jobLog.Warn("failed to retrieve book for pairing", logger.Data{"book_id": bookID, "error": err.Error()})
+			continue
+		}
+
+		pair, err := w.bookService.FindPairableBook(ctx, book)
+		if err != nil {
+			jobLog.Warn("failed to look up book to pair with", logger.Data{"book_id": bookID, "error": err.Error()})
+			continue
+		}
+		if pair == nil {
+			continue
+		}
+
+		result, err := w.bookService.PairBooks(ctx, pair.ID, book.ID, w.config.SupplementExcludePatterns...)
+		if err != nil {
+			jobLog.Warn("failed to pair books", logger.Data{"book_id": bookID, "pair_book_id": pair.ID, "error": err.Error()})
+			continue
+		}
+		jobLog.Info("paired book with the other format", logger.Data{"book_id": bookID, "pair_book_id": pair.ID, "files_moved": result.FilesMoved})
+
+		for _, deletedID := range result.DeletedBookIDs {
+			delete(booksToOrganize, deletedID)
+		}
+		booksToOrganize[pair.ID] = struct{}{}
+	}
+}
@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/shishobooks/shisho/internal/testgen"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSeparateFormats creates a library with the ebook and the audiobook of
+// the same title in different folders.
+func setupSeparateFormats(t *testing.T, tc *testContext, autoPair bool) {
+	t.Helper()
+
+	libraryPath := testgen.TempLibraryDir(t)
+	require.NoError(t, tc.libraryService.CreateLibrary(tc.ctx, &models.Library{
+		Name:             "Test Library",
+		CoverAspectRatio: "book",
+		AutoPairFormats:  autoPair,
+		LibraryPaths:     []*models.LibraryPath{{Filepath: libraryPath}},
+	}))
+
+	ebookDir := testgen.CreateSubDir(t, libraryPath, "Ebooks")
+	testgen.GenerateEPUB(t, ebookDir, "Wind and Truth.epub", testgen.EPUBOptions{
+		Title:   "Wind and Truth",
+		Authors: []string{"Brandon Sanderson"},
+	})
+	audioDir := testgen.CreateSubDir(t, libraryPath, "Audiobooks")
+	testgen.GenerateM4B(t, audioDir, "Wind and Truth.m4b", testgen.M4BOptions{
+		Title:  "Wind and Truth",
+		Artist: "Brandon Sanderson",
+	})
+}
+
+func TestScan_AutoPairFormats(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	setupSeparateFormats(t, tc, true)
+
+	require.NoError(t, tc.runScan())
+
+	allBooks := tc.listBooks()
+	require.Len(t, allBooks, 1, "the audiobook and ebook should share one book")
+	files := tc.listFiles()
+	require.Len(t, files, 2)
+	for _, f := range files {
+		assert.Equal(t, allBooks[0].ID, f.BookID)
+		assert.Equal(t, models.FileRoleMain, f.FileRole)
+	}
+
+	// Rescanning keeps them paired.
+	require.NoError(t, tc.runScan())
+	assert.Len(t, tc.listBooks(), 1)
+}
+
+func TestScan_AutoPairFormatsDisabled(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	setupSeparateFormats(t, tc, false)
+
+	require.NoError(t, tc.runScan())
+
+	assert.Len(t, tc.listBooks(), 2)
+}
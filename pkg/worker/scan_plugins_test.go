@@ -196,6 +196,43 @@ func TestScanWithPluginFileParser_MIMEValidation(t *testing.T) {
 	assert.Empty(t, allBooks, "file with wrong MIME type should not be parsed")
 }
 
+func TestCheckPluginParserContent(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	rar := filepath.Join(dir, "book.cbr")
+	require.NoError(t, os.WriteFile(rar, []byte("Rar!\x1a\x07\x00rest of archive"), 0644))
+	text := filepath.Join(dir, "notes.cbr")
+	require.NoError(t, os.WriteFile(text, []byte("plain text"), 0644))
+	short := filepath.Join(dir, "short.cbr")
+	require.NoError(t, os.WriteFile(short, []byte("Ra"), 0644))
+
+	tests := []struct {
+		name   string
+		cap    plugins.FileParserCap
+		path   string
+		wantOK bool
+	}{
+		{"nothing declared", plugins.FileParserCap{}, text, true},
+		{"skip check", plugins.FileParserCap{MIMETypes: []string{"application/pdf"}, SkipMIMECheck: true}, text, true},
+		{"magic match", plugins.FileParserCap{MagicBytes: []string{"52617221"}}, rar, true},
+		{"magic mismatch", plugins.FileParserCap{MagicBytes: []string{"52617221"}}, text, false},
+		{"file shorter than magic", plugins.FileParserCap{MagicBytes: []string{"52617221"}}, short, false},
+		{"mime match without magic match", plugins.FileParserCap{MIMETypes: []string{"text/plain"}, MagicBytes: []string{"52617221"}}, text, true},
+		{"mime mismatch", plugins.FileParserCap{MIMETypes: []string{"application/pdf"}}, text, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkPluginParserContent(tt.path, &tt.cap)
+			if tt.wantOK {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 // TestScanWithPluginFileParser_MIMEValidation_ValidFile verifies that files
 // with correct MIME types pass validation and get parsed.
 func TestScanWithPluginFileParser_MIMEValidation_ValidFile(t *testing.T) {
@@ -2049,9 +2086,10 @@ func TestScanWithEnricher_CoverPageForCBZ(t *testing.T) {
 	require.Len(t, files, 1)
 	file := files[0]
 
-	// The enricher's coverPage must land in the DB.
+	// The enricher's coverPage must land in the DB. Plugins use 0-indexed
+	// pages while cover_page is stored 1-indexed.
 	require.NotNil(t, file.CoverPage, "file.CoverPage should be set from enricher")
-	assert.Equal(t, 2, *file.CoverPage)
+	assert.Equal(t, 3, *file.CoverPage)
 
 	// The cover source must reflect the enricher.
 	require.NotNil(t, file.CoverSource)
@@ -2132,9 +2170,10 @@ func TestScanWithEnricher_CoverPageOutOfBounds(t *testing.T) {
 	require.Len(t, files, 1)
 	file := files[0]
 
-	// Enricher's out-of-range page is rejected; file parser's default (page 0) stands.
+	// Enricher's out-of-range page is rejected; file parser's default (the
+	// first page, stored 1-indexed) stands.
 	require.NotNil(t, file.CoverPage)
-	assert.Equal(t, 0, *file.CoverPage, "out-of-range coverPage should be skipped")
+	assert.Equal(t, 1, *file.CoverPage, "out-of-range coverPage should be skipped")
 
 	// Cover source must NOT be the enricher.
 	require.NotNil(t, file.CoverSource)
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/joblogs"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// repairExtensionsByMimeType maps a detected MIME type to the extension a file
+// of that type is renamed to when its extension is repaired. MOBI and AZW3
+// share a MIME type, so those files are repaired to .mobi.
+var repairExtensionsByMimeType = map[string]string{
+	"application/epub+zip":           ".epub",
+	"audio/x-m4a":                    ".m4b",
+	"video/mp4":                      ".m4b",
+	"application/zip":                ".cbz",
+	"application/pdf":                ".pdf",
+	"application/x-mobipocket-ebook": ".mobi",
+}
+
+// repairExtension renames a file whose detected MIME type doesn't match its
+// extension to the extension of its real type, when config.RepairExtensions
+// is on and the library organizes its file structure. It returns the new path
+// and true when the file was renamed, or false when it should be skipped.
+func (w *Worker) repairExtension(library *models.Library, path string, mtype *mimetype.MIME, jobLog *joblogs.JobLogger) (string, bool) {
+	if !w.config.RepairExtensions {
+		return "", false
+	}
+	ext, ok := repairExtensionsByMimeType[mtype.String()]
+	if !ok || !library.AllowsFileType(strings.TrimPrefix(ext, ".")) {
+		return "", false
+	}
+	// An EPUB whose mimetype entry isn't stored first is detected as a plain
+	// zip. Don't turn it into a CBZ.
+	if ext == ".cbz" && zipHasEntry(path, "META-INF/container.xml") {
+		return "", false
+	}
+	if !library.OrganizeFiles {
+		jobLog.Warn("not repairing file extension because the library doesn't organize its files", logger.Data{
+			"path":     path,
+			"mimetype": mtype.String(),
+		})
+		return "", false
+	}
+
+	newPath := strings.TrimSuffix(path, filepath.Ext(path)) + ext
+	if _, err := os.Stat(newPath); err == nil {
+		jobLog.Warn("not repairing file extension because the target already exists", logger.Data{
+			"path":     path,
+			"new_path": newPath,
+		})
+		return "", false
+	}
+	if err := os.Rename(path, newPath); err != nil {
+		jobLog.Warn("failed to repair file extension", logger.Data{"path": path, "error": err.Error()})
+		return "", false
+	}
+	jobLog.Info("repaired file extension", logger.Data{
+		"path":     path,
+		"new_path": newPath,
+		"mimetype": mtype.String(),
+	})
+	return newPath, true
+}
+
+// zipHasEntry reports whether the zip archive at path contains name.
+func zipHasEntry(path, name string) bool {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return false
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shishobooks/shisho/internal/testgen"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeMisnamedCBZ writes a CBZ under libraryPath with an .epub extension.
+func writeMisnamedCBZ(t *testing.T, libraryPath string) string {
+	t.Helper()
+
+	bookDir := testgen.CreateSubDir(t, libraryPath, "[Test Author] Misnamed Comic")
+	cbzPath := testgen.GenerateCBZ(t, bookDir, "comic.cbz", testgen.CBZOptions{Title: "Misnamed Comic"})
+	epubPath := filepath.Join(bookDir, "comic.epub")
+	require.NoError(t, os.Rename(cbzPath, epubPath))
+	return epubPath
+}
+
+func TestScan_RepairExtensions(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.RepairExtensions = true
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibraryWithOptions([]string{libraryPath}, true)
+	epubPath := writeMisnamedCBZ(t, libraryPath)
+
+	require.NoError(t, tc.runScan())
+
+	files := tc.listFiles()
+	require.Len(t, files, 1)
+	assert.Equal(t, models.FileTypeCBZ, files[0].FileType)
+	assert.True(t, strings.HasSuffix(files[0].Filepath, ".cbz"), "file should have been renamed: %s", files[0].Filepath)
+	_, err := os.Stat(epubPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestScan_RepairExtensionsRequiresOrganize(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.RepairExtensions = true
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+	epubPath := writeMisnamedCBZ(t, libraryPath)
+
+	require.NoError(t, tc.runScan())
+
+	assert.Empty(t, tc.listFiles())
+	_, err := os.Stat(epubPath)
+	assert.NoError(t, err, "the file shouldn't be renamed")
+}
+
+func TestScan_RepairExtensionsDisabled(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibraryWithOptions([]string{libraryPath}, true)
+	writeMisnamedCBZ(t, libraryPath)
+
+	require.NoError(t, tc.runScan())
+
+	assert.Empty(t, tc.listFiles())
+}
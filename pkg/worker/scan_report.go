@@ -0,0 +1,117 @@
+package worker
+
+import (
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/shishobooks/shisho/pkg/metrics"
+	"github.com/shishobooks/shisho/pkg/models"
+)
+
+// scanReportBuilder tallies the per-file results of a scan job into a
+// models.ScanReport.
+type scanReportBuilder struct {
+	report       models.ScanReport
+	createdBooks map[int]struct{}
+	updatedBooks map[int]struct{}
+}
+
+func newScanReportBuilder() *scanReportBuilder {
+	return &scanReportBuilder{
+		report:       models.ScanReport{ErrorsParsed: []models.ScanReportError{}, WarningsParsed: []models.ScanReportWarning{}},
+		createdBooks: make(map[int]struct{}),
+		updatedBooks: make(map[int]struct{}),
+	}
+}
+
+// add records the outcome of scanning a single path. DRM-protected and
+// unsupported files count as skipped rather than errored since they're
+// expected in most libraries. Existing files and books only count as updated
+// when the scan actually wrote changes to them.
+func (b *scanReportBuilder) add(result scanResult) {
+	for _, warning := range result.Warnings {
+		b.report.WarningsParsed = append(b.report.WarningsParsed, models.ScanReportWarning{
+			Path:    warning.Path,
+			Warning: warning.Message,
+		})
+	}
+
+	if result.Err != nil {
+		metrics.ScanErrors.WithLabelValues(metrics.ErrorCode(result.Err)).Inc()
+		var unsupportedErr *errcodes.UnsupportedFileTypeError
+		if errors.Is(result.Err, errcodes.DRMProtected()) || errors.As(result.Err, &unsupportedErr) {
+			b.report.FilesSkipped++
+			metrics.ScanFiles.WithLabelValues("skipped").Inc()
+			return
+		}
+		b.report.FilesErrored++
+		metrics.ScanFiles.WithLabelValues("errored").Inc()
+		b.report.ErrorsParsed = append(b.report.ErrorsParsed, models.ScanReportError{
+			Path:  result.Path,
+			Error: result.Err.Error(),
+		})
+		return
+	}
+
+	switch {
+	case result.FileDeleted:
+		b.report.FilesDeleted++
+		metrics.ScanFiles.WithLabelValues("deleted").Inc()
+		if result.BookDeleted {
+			b.report.BooksDeleted++
+		}
+		return
+	case result.Skipped:
+		b.report.FilesSkipped++
+		metrics.ScanFiles.WithLabelValues("skipped").Inc()
+		return
+	case result.FileCreated:
+		b.report.FilesCreated++
+		metrics.ScanFiles.WithLabelValues("created").Inc()
+	case result.FileUpdated:
+		b.report.FilesUpdated++
+		metrics.ScanFiles.WithLabelValues("updated").Inc()
+	default:
+		metrics.ScanFiles.WithLabelValues("unchanged").Inc()
+	}
+
+	if result.BookID == 0 {
+		return
+	}
+	if result.BookCreated {
+		b.createdBooks[result.BookID] = struct{}{}
+	} else if result.BookUpdated {
+		b.updatedBooks[result.BookID] = struct{}{}
+	}
+}
+
+// addOrphans records the files and books removed by orphan cleanup.
+func (b *scanReportBuilder) addOrphans(filesDeleted, booksDeleted int) {
+	b.report.FilesDeleted += filesDeleted
+	b.report.BooksDeleted += booksDeleted
+}
+
+// addSkips adds a library's recorded skip counts to the report's per-reason
+// tally.
+func (b *scanReportBuilder) addSkips(counts map[string]int) {
+	if b.report.SkipReasonsParsed == nil {
+		b.report.SkipReasonsParsed = make(map[string]int, len(counts))
+	}
+	for reason, n := range counts {
+		b.report.SkipReasonsParsed[reason] += n
+	}
+}
+
+// build returns the finished report for jobID. A book that was created
+// during the scan is only counted as created, even if later files in the
+// same scan were added to it.
+func (b *scanReportBuilder) build(jobID int) *models.ScanReport {
+	report := b.report
+	report.JobID = jobID
+	report.BooksCreated = len(b.createdBooks)
+	for bookID := range b.updatedBooks {
+		if _, created := b.createdBooks[bookID]; !created {
+			report.BooksUpdated++
+		}
+	}
+	return &report
+}
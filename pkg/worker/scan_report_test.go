@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shishobooks/shisho/pkg/errcodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanReportBuilder(t *testing.T) {
+	t.Parallel()
+
+	b := newScanReportBuilder()
+	// Two files created into the same new book.
+	b.add(scanResult{Path: "/lib/a/1.epub", BookID: 1, FileCreated: true, BookCreated: true})
+	b.add(scanResult{Path: "/lib/a/2.m4b", BookID: 1, FileCreated: true})
+	// Two changed files in one existing book.
+	b.add(scanResult{Path: "/lib/b/1.epub", BookID: 2, FileUpdated: true, BookUpdated: true})
+	b.add(scanResult{Path: "/lib/b/2.epub", BookID: 2, FileUpdated: true})
+	// An unchanged file in an unchanged book counts as neither, but its
+	// warnings are still reported.
+	b.add(scanResult{Path: "/lib/e/1.epub", BookID: 3, Warnings: []ScanWarning{
+		{Path: "/lib/e/e.metadata.json", Message: `sidecar ignored: unknown field "titel"`},
+	}})
+	// A file that disappeared and took its book with it.
+	b.add(scanResult{Path: "/lib/c/1.epub", FileDeleted: true, BookDeleted: true})
+	// Skips and errors.
+	b.add(scanResult{Path: "/lib/d.epub", Skipped: true})
+	b.add(scanResult{Path: "/lib/drm.epub", Err: errors.WithStack(errcodes.DRMProtected())})
+	b.add(scanResult{Path: "/lib/broken.epub", Err: errors.New("zip: not a valid zip file")})
+	b.addOrphans(2, 1)
+
+	report := b.build(42)
+	assert.Equal(t, 42, report.JobID)
+	assert.Equal(t, 1, report.BooksCreated)
+	assert.Equal(t, 1, report.BooksUpdated)
+	assert.Equal(t, 2, report.BooksDeleted)
+	assert.Equal(t, 2, report.FilesCreated)
+	assert.Equal(t, 2, report.FilesUpdated)
+	assert.Equal(t, 3, report.FilesDeleted)
+	assert.Equal(t, 2, report.FilesSkipped)
+	assert.Equal(t, 1, report.FilesErrored)
+	require.Len(t, report.ErrorsParsed, 1)
+	assert.Equal(t, "/lib/broken.epub", report.ErrorsParsed[0].Path)
+	require.Len(t, report.WarningsParsed, 1)
+	assert.Equal(t, "/lib/e/e.metadata.json", report.WarningsParsed[0].Path)
+}
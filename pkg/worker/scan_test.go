@@ -8,6 +8,7 @@ import (
 	"github.com/robinjoseph08/golib/pointerutil"
 	"github.com/shishobooks/shisho/internal/testgen"
 	"github.com/shishobooks/shisho/pkg/books"
+	"github.com/shishobooks/shisho/pkg/libraries"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/shishobooks/shisho/pkg/mp4"
 	"github.com/stretchr/testify/assert"
@@ -199,6 +200,47 @@ func TestProcessScanJob_CBZWithMinimalComicInfo(t *testing.T) {
 	assert.Equal(t, "Folder Author", book.Authors[0].Person.Name)
 }
 
+func TestProcessScanJob_AuthorMergeStrategy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		strategy string
+		want     []string
+	}{
+		{strategy: "replace", want: []string{"Jane Doe"}},
+		{strategy: "append", want: []string{"Jane Doe", "John Doe"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			t.Parallel()
+			tc := newTestContext(t)
+			tc.worker.config.AuthorMergeStrategy = tt.strategy
+
+			libraryPath := testgen.TempLibraryDir(t)
+			tc.createLibrary([]string{libraryPath})
+
+			// "jane doe" in the folder matches the metadata author and is
+			// only kept once.
+			bookDir := testgen.CreateSubDir(t, libraryPath, "[John Doe, jane doe] My Test Book")
+			testgen.GenerateEPUB(t, bookDir, "test.epub", testgen.EPUBOptions{
+				Title:   "My Test Book",
+				Authors: []string{"Jane Doe"},
+			})
+
+			require.NoError(t, tc.runScan())
+
+			allBooks := tc.listBooks()
+			require.Len(t, allBooks, 1)
+			names := make([]string, 0, len(allBooks[0].Authors))
+			for _, a := range allBooks[0].Authors {
+				require.NotNil(t, a.Person)
+				names = append(names, a.Person.Name)
+			}
+			assert.Equal(t, tt.want, names)
+			assert.Equal(t, models.DataSourceEPUBMetadata, allBooks[0].AuthorSource)
+		})
+	}
+}
+
 func TestProcessScanJob_M4BBasic(t *testing.T) {
 	t.Parallel()
 	testgen.SkipIfNoFFmpeg(t)
@@ -234,6 +276,33 @@ func TestProcessScanJob_M4BBasic(t *testing.T) {
 	assert.Equal(t, models.FileTypeM4B, files[0].FileType)
 }
 
+func TestProcessScanJob_M4BAutoChapters(t *testing.T) {
+	t.Parallel()
+	testgen.SkipIfNoFFmpeg(t)
+
+	tc := newTestContext(t)
+	tc.worker.config.AutoChapterIntervalMin = 1
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+
+	bookDir := testgen.CreateSubDir(t, libraryPath, "Chapterless Audiobook")
+	testgen.GenerateM4B(t, bookDir, "audiobook.m4b", testgen.M4BOptions{Duration: 150})
+
+	require.NoError(t, tc.runScan())
+
+	files := tc.listFiles()
+	require.Len(t, files, 1)
+	require.NotNil(t, files[0].ChapterSource)
+	assert.Equal(t, models.DataSourceGenerated, *files[0].ChapterSource)
+
+	chapters := tc.listChapters(files[0].ID)
+	require.Len(t, chapters, 3)
+	assert.Equal(t, "Chapter 1", chapters[0].Title)
+	require.NotNil(t, chapters[2].StartTimestampMs)
+	assert.Equal(t, int64(120000), *chapters[2].StartTimestampMs)
+}
+
 func TestProcessScanJob_M4BDurationAndBitrate(t *testing.T) {
 	t.Parallel()
 	testgen.SkipIfNoFFmpeg(t)
@@ -306,6 +375,179 @@ func TestProcessScanJob_MimeMismatch(t *testing.T) {
 	assert.Empty(t, allBooks)
 }
 
+func TestProcessScanJob_DisallowedFileTypesSkipped(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	err := tc.libraryService.CreateLibrary(tc.ctx, &models.Library{
+		Name:             "Audiobooks",
+		CoverAspectRatio: "audiobook",
+		AllowedFileTypes: []string{models.FileTypeM4B},
+		LibraryPaths:     []*models.LibraryPath{{Filepath: libraryPath}},
+	})
+	require.NoError(t, err)
+
+	// A stray EPUB on its own is never imported.
+	strayDir := testgen.CreateSubDir(t, libraryPath, "Stray")
+	testgen.GenerateEPUB(t, strayDir, "stray.epub", testgen.EPUBOptions{Title: "Stray"})
+
+	// An EPUB next to an allowed M4B is kept as a supplement.
+	bookDir := testgen.CreateSubDir(t, libraryPath, "[Author] My Book")
+	testgen.GenerateM4B(t, bookDir, "book.m4b", testgen.M4BOptions{Title: "My Book"})
+	testgen.GenerateEPUB(t, bookDir, "book.epub", testgen.EPUBOptions{Title: "My Book"})
+
+	require.NoError(t, tc.runScan())
+
+	allBooks := tc.listBooks()
+	require.Len(t, allBooks, 1)
+	assert.Equal(t, "My Book", allBooks[0].Title)
+
+	files := tc.listFiles()
+	require.Len(t, files, 2)
+	for _, f := range files {
+		if f.FileType == models.FileTypeM4B {
+			assert.Equal(t, models.FileRoleMain, f.FileRole)
+		} else {
+			assert.Equal(t, models.FileTypeEPUB, f.FileType)
+			assert.Equal(t, models.FileRoleSupplement, f.FileRole)
+		}
+	}
+}
+
+func TestProcessScanJob_BelowMinFileSizeSkipped(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.MinFileSizeBytes = map[string]int64{"epub": 10 * 1024 * 1024}
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+
+	bookDir := testgen.CreateSubDir(t, libraryPath, "Tiny Book")
+	testgen.GenerateEPUB(t, bookDir, "tiny.epub", testgen.EPUBOptions{Title: "Tiny"})
+	comicDir := testgen.CreateSubDir(t, libraryPath, "Comic")
+	testgen.GenerateCBZ(t, comicDir, "comic.cbz", testgen.CBZOptions{})
+
+	err := tc.runScan()
+	require.NoError(t, err)
+
+	// Only the EPUB threshold applies, so the CBZ is still imported.
+	files := tc.listFiles()
+	require.Len(t, files, 1)
+	assert.Equal(t, models.FileTypeCBZ, files[0].FileType)
+}
+
+func TestProcessScanJob_RecordsSkipReasons(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.MinFileSizeBytes = map[string]int64{"epub": 10 * 1024 * 1024}
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+
+	tinyDir := testgen.CreateSubDir(t, libraryPath, "Tiny Book")
+	testgen.GenerateEPUB(t, tinyDir, "tiny.epub", testgen.EPUBOptions{Title: "Tiny"})
+	comicDir := testgen.CreateSubDir(t, libraryPath, "Comic")
+	testgen.GenerateCBZ(t, comicDir, "comic.cbz", testgen.CBZOptions{})
+	fakeDir := testgen.CreateSubDir(t, libraryPath, "Fake")
+	require.NoError(t, os.WriteFile(filepath.Join(fakeDir, "fake.cbz"), []byte("just some text, not a zip"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(libraryPath, "scan.djvu"), []byte("djvu"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(libraryPath, ".DS_Store"), []byte("clutter"), 0600))
+
+	require.NoError(t, tc.runScan())
+	require.Len(t, tc.listFiles(), 1)
+
+	libs, err := tc.libraryService.ListLibraries(tc.ctx, libraries.ListLibrariesOptions{})
+	require.NoError(t, err)
+	require.Len(t, libs, 1)
+	skips, total, err := tc.libraryService.ListScanSkipsWithTotal(tc.ctx, libraries.ListScanSkipsOptions{LibraryID: libs[0].ID})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	reasons := make(map[string]string, len(skips))
+	for _, skip := range skips {
+		reasons[skip.Path] = skip.Reason
+	}
+	assert.Equal(t, map[string]string{
+		filepath.Join(fakeDir, "fake.cbz"):      models.ScanSkipReasonMimeMismatch,
+		filepath.Join(libraryPath, "scan.djvu"): models.ScanSkipReasonUnsupportedType,
+		filepath.Join(tinyDir, "tiny.epub"):     models.ScanSkipReasonTooSmall,
+	}, reasons)
+
+	// A rescan replaces the list rather than adding to it.
+	require.NoError(t, os.Remove(filepath.Join(libraryPath, "scan.djvu")))
+	require.NoError(t, tc.runScan())
+	_, total, err = tc.libraryService.ListScanSkipsWithTotal(tc.ctx, libraries.ListScanSkipsOptions{LibraryID: libs[0].ID})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+}
+
+func TestProcessScanJob_RecordsScanErrors(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+	bookDir := testgen.CreateSubDir(t, libraryPath, "Broken Book")
+	testgen.GenerateEPUB(t, bookDir, "broken.epub", testgen.EPUBOptions{Title: "Broken"})
+	require.NoError(t, tc.runScan())
+	files := tc.listFiles()
+	require.Len(t, files, 1)
+
+	// Known files skip MIME detection, so a corrupted one fails in the scan
+	// itself.
+	require.NoError(t, os.WriteFile(files[0].Filepath, []byte("this is not a valid epub file"), 0600))
+	require.NoError(t, tc.runScan())
+
+	libs, err := tc.libraryService.ListLibraries(tc.ctx, libraries.ListLibrariesOptions{})
+	require.NoError(t, err)
+	require.Len(t, libs, 1)
+	scanErrors, total, err := tc.libraryService.ListScanErrorsWithTotal(tc.ctx, libraries.ListScanErrorsOptions{LibraryID: libs[0].ID})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, files[0].Filepath, scanErrors[0].Path)
+	require.NotNil(t, scanErrors[0].FileID)
+	assert.Equal(t, files[0].ID, *scanErrors[0].FileID)
+	assert.NotEmpty(t, scanErrors[0].Message)
+
+	// Retrying while the file is still broken keeps the error.
+	updated, err := tc.worker.RetryScanError(tc.ctx, scanErrors[0])
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	_, total, err = tc.libraryService.ListScanErrorsWithTotal(tc.ctx, libraries.ListScanErrorsOptions{LibraryID: libs[0].ID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	// Once it's fixed, a retry clears it.
+	testgen.GenerateEPUB(t, bookDir, "broken.epub", testgen.EPUBOptions{Title: "Broken"})
+	updated, err = tc.worker.RetryScanError(tc.ctx, scanErrors[0])
+	require.NoError(t, err)
+	assert.Nil(t, updated)
+	_, total, err = tc.libraryService.ListScanErrorsWithTotal(tc.ctx, libraries.ListScanErrorsOptions{LibraryID: libs[0].ID})
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
+
+func TestProcessScanJob_MinFileSizeIgnoresKnownFiles(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+
+	bookDir := testgen.CreateSubDir(t, libraryPath, "Small Book")
+	testgen.GenerateEPUB(t, bookDir, "small.epub", testgen.EPUBOptions{Title: "Small"})
+
+	err := tc.runScan()
+	require.NoError(t, err)
+	require.Len(t, tc.listFiles(), 1)
+
+	// Raising the threshold afterwards doesn't remove already-imported files.
+	tc.worker.config.MinFileSizeBytes = map[string]int64{"epub": 10 * 1024 * 1024}
+	err = tc.runScan()
+	require.NoError(t, err)
+	assert.Len(t, tc.listFiles(), 1)
+}
+
 func TestProcessScanJob_ExistingFileSkipped(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
@@ -812,12 +1054,12 @@ func TestProcessScanJob_M4BWithCover(t *testing.T) {
 	assert.Equal(t, book.BookSeries[0].SeriesID, allSeries[0].ID)
 }
 
-func TestProcessScanJob_OrganizeFileStructure_RootLevelFile(t *testing.T) {
+func TestProcessScanJob_Organize_RootLevelFile(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
 	libraryPath := testgen.TempLibraryDir(t)
-	// Enable organize_file_structure
+	// Enable organizing
 	tc.createLibraryWithOptions([]string{libraryPath}, true)
 
 	// Create a root-level EPUB (directly in library path)
@@ -869,12 +1111,12 @@ func TestProcessScanJob_OrganizeFileStructure_RootLevelFile(t *testing.T) {
 	assert.True(t, testgen.FileExists(fileSidecarPath), "file sidecar should be inside organized folder")
 }
 
-func TestProcessScanJob_OrganizeFileStructure_Disabled(t *testing.T) {
+func TestProcessScanJob_Organize_Disabled(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
 	libraryPath := testgen.TempLibraryDir(t)
-	// Disable organize_file_structure (default behavior)
+	// Disable organizing (default behavior)
 	tc.createLibraryWithOptions([]string{libraryPath}, false)
 
 	// Create a root-level EPUB
@@ -910,12 +1152,48 @@ func TestProcessScanJob_OrganizeFileStructure_Disabled(t *testing.T) {
 	assert.Equal(t, originalPath, files[0].Filepath, "file should still be at original path")
 }
 
-func TestProcessScanJob_OrganizeFileStructure_DirectoryFile_Renamed(t *testing.T) {
+func TestProcessScanJob_StagingLibrary_LeavesFilesUntouched(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
 	libraryPath := testgen.TempLibraryDir(t)
-	// Enable organize_file_structure
+	tc.createLibraryWithOptions([]string{libraryPath}, true)
+	_, err := tc.db.NewUpdate().
+		Model((*models.Library)(nil)).
+		Set("staging = ?", true).
+		Where("id = ?", 1).
+		Exec(tc.ctx)
+	require.NoError(t, err)
+
+	bookDir := testgen.CreateSubDir(t, libraryPath, "Messy Folder")
+	testgen.GenerateEPUB(t, bookDir, "book.epub", testgen.EPUBOptions{
+		Title:   "Staged Book",
+		Authors: []string{"Author Name"},
+	})
+	originalPath := filepath.Join(bookDir, "book.epub")
+
+	err = tc.runScan()
+	require.NoError(t, err)
+
+	// The book is in the DB but nothing on disk was renamed
+	allBooks := tc.listBooks()
+	require.Len(t, allBooks, 1)
+	assert.Equal(t, "Staged Book", allBooks[0].Title)
+	assert.True(t, allBooks[0].Staged)
+	assert.True(t, testgen.FileExists(originalPath), "staged file should not be moved")
+	assert.False(t, testgen.FileExists(filepath.Join(libraryPath, "[Author Name] Staged Book")))
+
+	// No sidecars are written while staged
+	assert.False(t, testgen.FileExists(originalPath+".metadata.json"), "file sidecar should not be written")
+	assert.False(t, testgen.FileExists(filepath.Join(bookDir, "Messy Folder.metadata.json")), "book sidecar should not be written")
+}
+
+func TestProcessScanJob_Organize_DirectoryFile_Renamed(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	// Enable organizing
 	tc.createLibraryWithOptions([]string{libraryPath}, true)
 
 	// Create a file inside a directory (not root-level)
@@ -945,7 +1223,7 @@ func TestProcessScanJob_OrganizeFileStructure_DirectoryFile_Renamed(t *testing.T
 	assert.Equal(t, expectedFolder, allBooks[0].Filepath)
 }
 
-func TestProcessScanJob_OrganizeFileStructure_WithCover(t *testing.T) {
+func TestProcessScanJob_Organize_WithCover(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
@@ -1377,16 +1655,16 @@ func TestProcessScanJob_SameNameDifferentExtensions_SeparateCovers(t *testing.T)
 	assert.NotEmpty(t, m4bCoverData, "M4B cover should have content")
 }
 
-// TestProcessScanJob_OrganizeFileStructure_MultipleRootLevelFiles tests that multiple
+// TestProcessScanJob_Organize_MultipleRootLevelFiles tests that multiple
 // root-level files can be scanned and organized without path errors.
 // This is a regression test for the bug where organization during scan would move files
 // before subsequent files in the scan were processed, causing file-not-found errors.
-func TestProcessScanJob_OrganizeFileStructure_MultipleRootLevelFiles(t *testing.T) {
+func TestProcessScanJob_Organize_MultipleRootLevelFiles(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
 	libraryPath := testgen.TempLibraryDir(t)
-	// Enable organize_file_structure
+	// Enable organizing
 	tc.createLibraryWithOptions([]string{libraryPath}, true)
 
 	// Create multiple root-level files
@@ -1445,11 +1723,11 @@ func TestProcessScanJob_OrganizeFileStructure_MultipleRootLevelFiles(t *testing.
 	assert.True(t, testgen.FileExists(filepath.Join(organizedFolder3, "Book Three.epub")))
 }
 
-// TestProcessScanJob_OrganizeFileStructure_DeferredOrganization verifies that
+// TestProcessScanJob_Organize_DeferredOrganization verifies that
 // organization happens AFTER all files are scanned, not during scanning.
 // This is verified by checking that the database file paths reflect the ORIGINAL
 // locations during scan, and only get updated to organized paths after scan completes.
-func TestProcessScanJob_OrganizeFileStructure_DeferredOrganization(t *testing.T) {
+func TestProcessScanJob_Organize_DeferredOrganization(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
@@ -1933,11 +2211,11 @@ func TestProcessScanJob_LowerPriorityPopulatesEmptyField(t *testing.T) {
 	require.Len(t, files, 2)
 }
 
-// TestProcessScanJob_OrganizeFileStructure_DirectoryRenameDoesNotBreakScan tests that
+// TestProcessScanJob_Organize_DirectoryRenameDoesNotBreakScan tests that
 // directory renames during the post-scan organization phase don't break the scan.
 // This is a regression test for the bug where organization during scan would rename
 // directories before subsequent files in that directory were processed.
-func TestProcessScanJob_OrganizeFileStructure_DirectoryRenameDoesNotBreakScan(t *testing.T) {
+func TestProcessScanJob_Organize_DirectoryRenameDoesNotBreakScan(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
@@ -2122,9 +2400,9 @@ func TestProcessScanJob_CBZCoverPageExtraction(t *testing.T) {
 	file := files[0]
 	assert.Equal(t, models.FileTypeCBZ, file.FileType)
 
-	// Verify cover page index was extracted
-	require.NotNil(t, file.CoverPage, "file should have cover page index")
-	assert.Equal(t, 0, *file.CoverPage)
+	// Verify cover page was extracted (stored 1-indexed)
+	require.NotNil(t, file.CoverPage, "file should have cover page")
+	assert.Equal(t, 1, *file.CoverPage)
 }
 
 // TestProcessScanJob_CBZCoverPageNonZeroIndex tests that cover page extraction works
@@ -2153,9 +2431,9 @@ func TestProcessScanJob_CBZCoverPageNonZeroIndex(t *testing.T) {
 	require.Len(t, files, 1)
 
 	file := files[0]
-	// Verify cover page index was correctly extracted as page 2
-	require.NotNil(t, file.CoverPage, "file should have cover page index")
-	assert.Equal(t, 2, *file.CoverPage)
+	// Verify cover page index 2 was correctly stored as page 3
+	require.NotNil(t, file.CoverPage, "file should have cover page")
+	assert.Equal(t, 3, *file.CoverPage)
 }
 
 // TestProcessScanJob_CBZNoCoverPageType tests that when no cover page type is specified
@@ -2185,8 +2463,116 @@ func TestProcessScanJob_CBZNoCoverPageType(t *testing.T) {
 	file := files[0]
 	// When no FrontCover is specified, we fall back to first image (page 0)
 	// The CBZ parser uses the first image as cover when no explicit cover is defined
-	require.NotNil(t, file.CoverPage, "file should have cover page index from fallback")
-	assert.Equal(t, 0, *file.CoverPage, "cover page should be 0 (first image fallback)")
+	require.NotNil(t, file.CoverPage, "file should have cover page from fallback")
+	assert.Equal(t, 1, *file.CoverPage, "cover page should be 1 (first image fallback)")
+}
+
+// TestProcessScanJob_CBZLibraryCoverPageDefault tests that a library's default
+// cover page replaces the first-image fallback for new CBZ files, but not a
+// ComicInfo.xml cover or a page past the end of a short book.
+func TestProcessScanJob_CBZLibraryCoverPageDefault(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	library := &models.Library{
+		Name:                "Test Library",
+		CoverAspectRatio:    "book",
+		CBZCoverPageDefault: 3,
+		LibraryPaths:        []*models.LibraryPath{{Filepath: libraryPath}},
+	}
+	require.NoError(t, tc.libraryService.CreateLibrary(tc.ctx, library))
+
+	testgen.GenerateCBZ(t, testgen.CreateSubDir(t, libraryPath, "Credits First"), "credits.cbz", testgen.CBZOptions{
+		Title:        "Credits First",
+		HasComicInfo: true,
+		PageCount:    5,
+	})
+	testgen.GenerateCBZ(t, testgen.CreateSubDir(t, libraryPath, "Marked Cover"), "marked.cbz", testgen.CBZOptions{
+		Title:          "Marked Cover",
+		HasComicInfo:   true,
+		CoverPageType:  "FrontCover",
+		CoverPageIndex: 1,
+		PageCount:      5,
+	})
+	testgen.GenerateCBZ(t, testgen.CreateSubDir(t, libraryPath, "Short"), "short.cbz", testgen.CBZOptions{
+		Title:        "Short",
+		HasComicInfo: true,
+		PageCount:    2,
+	})
+
+	err := tc.runScan()
+	require.NoError(t, err)
+
+	files := tc.listFiles()
+	require.Len(t, files, 3)
+
+	coverPages := map[string]int{}
+	for _, file := range files {
+		require.NotNil(t, file.CoverPage, "file %s should have a cover page", file.Filepath)
+		require.NotNil(t, file.CoverImageFilename, "file %s should have a cover", file.Filepath)
+		coverPages[filepath.Base(file.Filepath)] = *file.CoverPage
+	}
+	assert.Equal(t, 3, coverPages["credits.cbz"])
+	assert.Equal(t, 2, coverPages["marked.cbz"])
+	assert.Equal(t, 1, coverPages["short.cbz"])
+}
+
+// TestProcessScanJob_CBZPreferExternalCover tests that a library preferring
+// external covers uses a cover image in the book folder for new CBZ files, and
+// still extracts the embedded cover when the folder has none.
+func TestProcessScanJob_CBZPreferExternalCover(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	library := &models.Library{
+		Name:                "Test Library",
+		CoverAspectRatio:    "book",
+		PreferExternalCover: true,
+		LibraryPaths:        []*models.LibraryPath{{Filepath: libraryPath}},
+	}
+	require.NoError(t, tc.libraryService.CreateLibrary(tc.ctx, library))
+
+	externalDir := testgen.CreateSubDir(t, libraryPath, "External")
+	testgen.GenerateCBZ(t, externalDir, "external.cbz", testgen.CBZOptions{
+		Title:        "External",
+		HasComicInfo: true,
+		PageCount:    3,
+	})
+	externalCover := []byte("external cover image")
+	require.NoError(t, os.WriteFile(filepath.Join(externalDir, "Cover.JPG"), externalCover, 0644))
+
+	testgen.GenerateCBZ(t, testgen.CreateSubDir(t, libraryPath, "Embedded"), "embedded.cbz", testgen.CBZOptions{
+		Title:        "Embedded",
+		HasComicInfo: true,
+		PageCount:    3,
+	})
+
+	err := tc.runScan()
+	require.NoError(t, err)
+
+	byName := map[string]*models.File{}
+	for _, file := range tc.listFiles() {
+		byName[filepath.Base(file.Filepath)] = file
+	}
+
+	external := byName["external.cbz"]
+	require.NotNil(t, external)
+	require.NotNil(t, external.CoverImageFilename)
+	assert.Equal(t, "external.cbz.cover.jpg", *external.CoverImageFilename)
+	require.NotNil(t, external.CoverSource)
+	assert.Equal(t, models.DataSourceExistingCover, *external.CoverSource)
+	assert.Nil(t, external.CoverPage)
+	data, err := os.ReadFile(filepath.Join(externalDir, *external.CoverImageFilename))
+	require.NoError(t, err)
+	assert.Equal(t, externalCover, data)
+
+	embedded := byName["embedded.cbz"]
+	require.NotNil(t, embedded)
+	require.NotNil(t, embedded.CoverImageFilename)
+	require.NotNil(t, embedded.CoverPage)
+	assert.Equal(t, 1, *embedded.CoverPage)
 }
 
 // TestProcessScanJob_CBZRolesPreservedOnRescan tests that author roles are preserved
@@ -2268,7 +2654,7 @@ func TestProcessScanJob_CBZCoverPagePreservedOnRescan(t *testing.T) {
 	files := tc.listFiles()
 	require.Len(t, files, 1)
 	require.NotNil(t, files[0].CoverPage)
-	assert.Equal(t, 1, *files[0].CoverPage)
+	assert.Equal(t, 2, *files[0].CoverPage)
 
 	// Second scan - cover page should remain unchanged
 	err = tc.runScan()
@@ -2277,7 +2663,7 @@ func TestProcessScanJob_CBZCoverPagePreservedOnRescan(t *testing.T) {
 	files = tc.listFiles()
 	require.Len(t, files, 1)
 	require.NotNil(t, files[0].CoverPage)
-	assert.Equal(t, 1, *files[0].CoverPage, "cover page should be preserved after rescan")
+	assert.Equal(t, 2, *files[0].CoverPage, "cover page should be preserved after rescan")
 }
 
 // TestProcessScanJob_CBZNoComicInfoNoRoles tests that CBZ files without ComicInfo.xml
@@ -2622,18 +3008,18 @@ func TestProcessScanJob_RescanUpdatesSortTitle(t *testing.T) {
 	assert.Equal(t, models.DataSourceEPUBMetadata, allBooks[0].SortTitleSource, "sort_title_source should match title_source")
 }
 
-// TestProcessScanJob_OrganizeFileStructure_RootLevelMultiFormatSameBook tests that when
+// TestProcessScanJob_Organize_RootLevelMultiFormatSameBook tests that when
 // organize file structure is enabled and there are multiple root-level files that belong
 // to the same book (e.g., "Wind and Truth.epub" and "Wind and Truth.m4b"), they should be
 // merged into the same book and organized into the same book directory after the scan.
-func TestProcessScanJob_OrganizeFileStructure_RootLevelMultiFormatSameBook(t *testing.T) {
+func TestProcessScanJob_Organize_RootLevelMultiFormatSameBook(t *testing.T) {
 	t.Parallel()
 	testgen.SkipIfNoFFmpeg(t)
 
 	tc := newTestContext(t)
 
 	libraryPath := testgen.TempLibraryDir(t)
-	// Enable organize_file_structure
+	// Enable organizing
 	tc.createLibraryWithOptions([]string{libraryPath}, true)
 
 	// Create two root-level files with matching titles - they should become the same book
@@ -2666,7 +3052,7 @@ func TestProcessScanJob_OrganizeFileStructure_RootLevelMultiFormatSameBook(t *te
 		assert.Equal(t, allBooks[0].ID, file.BookID, "file %s should belong to the same book", file.Filepath)
 	}
 
-	// With organize_file_structure enabled, both files should be in the same organized directory
+	// With organizing enabled, both files should be in the same organized directory
 	organizedDir := filepath.Join(libraryPath, "[Brandon Sanderson] Wind and Truth")
 	assert.True(t, testgen.FileExists(organizedDir), "organized directory should exist at %s", organizedDir)
 
@@ -9,13 +9,16 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/pkg/errors"
 	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/pkg/aliases"
 	"github.com/shishobooks/shisho/pkg/books"
 	"github.com/shishobooks/shisho/pkg/cbz"
 	"github.com/shishobooks/shisho/pkg/chapters"
@@ -26,6 +29,8 @@ import (
 	"github.com/shishobooks/shisho/pkg/joblogs"
 	"github.com/shishobooks/shisho/pkg/libraries"
 	"github.com/shishobooks/shisho/pkg/mediafile"
+	"github.com/shishobooks/shisho/pkg/metrics"
+	"github.com/shishobooks/shisho/pkg/mobi"
 	"github.com/shishobooks/shisho/pkg/models"
 	"github.com/shishobooks/shisho/pkg/mp4"
 	"github.com/shishobooks/shisho/pkg/pdf"
@@ -34,6 +39,10 @@ import (
 	"github.com/shishobooks/shisho/pkg/sortname"
 )
 
+// authorMergeStrategyAppend is the author_merge_strategy value that keeps
+// filepath authors as co-authors after the metadata authors.
+const authorMergeStrategyAppend = "append"
+
 // RelationshipUpdates holds all relationship data to be updated for a book.
 // This enables bulk inserts for better scan performance. When used in parallel
 // processing, callers should use ScanCache.LockBook to prevent race conditions.
@@ -107,24 +116,28 @@ func (w *Worker) UpdateBookRelationships(ctx context.Context, bookID int, update
 }
 
 // ErrInvalidScanOptions is returned when ScanOptions validation fails.
-var ErrInvalidScanOptions = errors.New("exactly one of FilePath, FileID, or BookID must be set")
+var ErrInvalidScanOptions = errors.New("exactly one of FilePath, FileID, BookID, or DirPath must be set")
 
 // ScanOptions configures a scan operation.
 //
-// Entry points are mutually exclusive - exactly one of FilePath, FileID, or BookID must be set:
+// Entry points are mutually exclusive - exactly one of FilePath, FileID, BookID, or DirPath must be set:
 //   - FilePath: Batch scan mode - discover or create file/book records by path.
 //     Requires LibraryID to be set.
 //   - FileID: Single file resync - file already exists in DB. If the file no longer
 //     exists on disk, it will be deleted from the database.
 //   - BookID: Book resync - scan all files belonging to the book. If the book has
 //     no files, it will be deleted.
+//   - DirPath: Directory rescan - scan every file under a directory inside a
+//     library path and clean up orphans under it. Requires LibraryID and JobLog
+//     to be set.
 type ScanOptions struct {
 	// Entry points (mutually exclusive - exactly one must be set)
 	FilePath string // Batch scan: discover/create by path
 	FileID   int    // Single file resync: file already in DB
 	BookID   int    // Book resync: scan all files in book
+	DirPath  string // Directory rescan: scan a subtree of a library path
 
-	// Context (required for FilePath mode)
+	// Context (required for FilePath and DirPath modes)
 	LibraryID int
 
 	// Behavior
@@ -133,7 +146,7 @@ type ScanOptions struct {
 	Reset         bool // Wipe all metadata before scanning (reset to file-only state)
 	BookResetDone bool // Book-level wipe already done by scanBook (skip in scanFileByID)
 
-	// Logging (optional, for batch scan job context)
+	// Logging (optional, for batch scan job context; required for DirPath mode)
 	JobLog *joblogs.JobLogger
 }
 
@@ -147,25 +160,42 @@ type ScanOptions struct {
 // For book scans (BookID mode), the Files slice contains the results for each
 // individual file in the book. The top-level Book field contains the updated book
 // record (unless BookDeleted is true).
+//
+// For directory scans (DirPath mode), the Files slice contains the results for
+// each file found under the directory.
 type ScanResult struct {
 	// For single file scans
 	File        *models.File // The scanned/updated file (nil if deleted)
 	Book        *models.Book // The parent book (nil if deleted)
 	FileCreated bool         // True if file was newly created (FilePath mode only)
+	BookCreated bool         // True if a new book was created for the file (FilePath mode only)
 	FileDeleted bool         // True if file was deleted (no longer on disk)
 	BookDeleted bool         // True if book was also deleted (was last file)
+	FileUpdated bool         // True if the scan wrote changes to an existing file
+	BookUpdated bool         // True if the scan wrote changes to an existing book
 
 	// For book scans (multiple files)
-	Files []*ScanResult // Results for each file in the book (BookID mode only)
+	Files []*ScanResult // Results for each file in the book or directory (BookID and DirPath modes only)
+
+	// Warnings are non-fatal problems found while scanning the file, such as
+	// a sidecar that failed validation and was ignored.
+	Warnings []ScanWarning
+}
+
+// ScanWarning is a non-fatal problem found while scanning a file.
+type ScanWarning struct {
+	Path    string // The file the problem is in
+	Message string
 }
 
 // scanInternal is the unified entry point for all scan operations using internal types.
 //
-// It validates that exactly one of FilePath, FileID, or BookID is set in options,
-// then routes to the appropriate internal handler:
+// It validates that exactly one of FilePath, FileID, BookID, or DirPath is set in
+// options, then routes to the appropriate internal handler:
 //   - FilePath: scanFileByPath (batch scan mode)
 //   - FileID: scanFileByID (single file resync)
 //   - BookID: scanBook (book resync)
+//   - DirPath: scanDirectory (directory rescan)
 //
 // The optional cache parameter enables shared entity lookups across parallel file processing.
 // When cache is nil, direct service calls are used (backward compatible).
@@ -185,6 +215,9 @@ func (w *Worker) scanInternal(ctx context.Context, opts ScanOptions, cache *Scan
 	if opts.BookID != 0 {
 		entryPoints++
 	}
+	if opts.DirPath != "" {
+		entryPoints++
+	}
 
 	// Validate exactly one entry point
 	if entryPoints != 1 {
@@ -196,21 +229,115 @@ func (w *Worker) scanInternal(ctx context.Context, opts ScanOptions, cache *Scan
 	case opts.FilePath != "":
 		return w.scanFileByPath(ctx, opts, cache)
 	case opts.FileID != 0:
-		return w.scanFileByID(ctx, opts, cache)
+		fileID := opts.FileID
+		return w.withMetadataSnapshot(ctx, 0, &fileID, func() (*ScanResult, error) {
+			return w.scanFileByID(ctx, opts, cache)
+		})
 	case opts.BookID != 0:
-		return w.scanBook(ctx, opts, cache)
+		return w.withMetadataSnapshot(ctx, opts.BookID, nil, func() (*ScanResult, error) {
+			return w.scanBook(ctx, opts, cache)
+		})
+	case opts.DirPath != "":
+		return w.scanDirectory(ctx, opts, cache)
 	default:
 		// This should never happen due to validation above
 		return nil, ErrInvalidScanOptions
 	}
 }
 
+// withMetadataSnapshot runs a book or file resync, keeping a snapshot of the
+// book's metadata from before it so the changes can be reverted. bookID is
+// looked up from fileID when it's 0. Snapshots are best-effort: failing to
+// take one is logged and never fails the scan.
+func (w *Worker) withMetadataSnapshot(ctx context.Context, bookID int, fileID *int, scan func() (*ScanResult, error)) (*ScanResult, error) {
+	keep := 0
+	if w.config != nil {
+		keep = w.config.MetadataSnapshotLimit
+	}
+	if keep <= 0 {
+		return scan()
+	}
+
+	log := logger.FromContext(ctx)
+	if bookID == 0 {
+		file, err := w.bookService.RetrieveFile(ctx, books.RetrieveFileOptions{ID: fileID})
+		if err != nil {
+			return scan()
+		}
+		bookID = file.BookID
+	}
+	book, err := w.bookService.RetrieveBook(ctx, books.RetrieveBookOptions{ID: &bookID})
+	if err != nil {
+		return scan()
+	}
+	before := books.MetadataSnapshotDataFromBook(book)
+
+	result, err := scan()
+	if err != nil || result == nil || result.BookDeleted || result.FileDeleted {
+		return result, err
+	}
+	if _, err := w.bookService.RecordMetadataSnapshot(ctx, bookID, fileID, before, keep); err != nil {
+		log.Warn("failed to record metadata snapshot", logger.Data{"book_id": bookID, "error": err.Error()})
+	}
+	return result, nil
+}
+
+// checkPluginParserContent makes sure a file handed to a plugin parser has the
+// content the parser declares, so a parser isn't fed arbitrary files that
+// happen to share its extension. A file passes when it starts with one of the
+// parser's magic byte prefixes or its detected MIME type matches one of the
+// parser's mimeTypes. Parsers that declare neither, or that set
+// skipMimeCheck, accept every file.
+func checkPluginParserContent(path string, fp *plugins.FileParserCap) error {
+	if fp.SkipMIMECheck || (len(fp.MIMETypes) == 0 && len(fp.MagicBytes) == 0) {
+		return nil
+	}
+
+	if prefixes := fp.MagicBytePrefixes(); len(prefixes) > 0 {
+		longest := 0
+		for _, p := range prefixes {
+			longest = max(longest, len(p))
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		head := make([]byte, longest)
+		n, err := io.ReadFull(f, head)
+		f.Close()
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+			return errors.Wrap(err, "failed to read file header")
+		}
+		for _, p := range prefixes {
+			if bytes.HasPrefix(head[:n], p) {
+				return nil
+			}
+		}
+		if len(fp.MIMETypes) == 0 {
+			return errors.Errorf("file %s: content doesn't start with any of the parser's magicBytes %v, skipping", path, fp.MagicBytes)
+		}
+	}
+
+	mtype, err := mimetype.DetectFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to detect MIME type")
+	}
+	detected := strings.ToLower(mtype.String())
+	for _, allowed := range fp.MIMETypes {
+		if strings.HasPrefix(detected, strings.ToLower(allowed)) {
+			return nil
+		}
+	}
+	return errors.Errorf("file %s: detected MIME type %s does not match parser mimeTypes %v, skipping", path, mtype.String(), fp.MIMETypes)
+}
+
 // fileContentChanged reports whether a file's on-disk content differs from
 // what was last scanned into the DB. It compares size + mtime (truncated to
-// seconds, since SQLite drops sub-second precision). ForceRefresh forces a
-// "changed" result so the caller re-parses metadata; a missing
-// FileModifiedAt on the existing row also forces "changed" since we can't
-// reason about it.
+// seconds, since SQLite drops sub-second precision), falling back to the
+// file's declared modified date when only the mtime moved (see
+// fileStatChanged). ForceRefresh forces a "changed" result so the caller
+// re-parses metadata; a missing FileModifiedAt on the existing row also
+// forces "changed" since we can't reason about it.
 //
 // Returns an error only if stat fails for a reason other than "not exists".
 func fileContentChanged(path string, existing *models.File, forceRefresh bool) (bool, error) {
@@ -224,13 +351,36 @@ func fileContentChanged(path string, existing *models.File, forceRefresh bool) (
 	if err != nil {
 		return true, err
 	}
+	return fileStatChanged(path, stat, existing), nil
+}
+
+// fileStatChanged compares a file's size and mtime with what's stored for
+// it. When the size matches but the mtime doesn't (a copy, restore, or sync
+// that doesn't preserve timestamps), an EPUB that declares the same
+// dcterms:modified as last time is treated as unchanged, since its package
+// document is a far better record of edits than the filesystem.
+func fileStatChanged(path string, stat os.FileInfo, existing *models.File) bool {
 	if stat.Size() != existing.FilesizeBytes {
-		return true, nil
+		return true
 	}
-	if !stat.ModTime().Truncate(time.Second).Equal(existing.FileModifiedAt.Truncate(time.Second)) {
-		return true, nil
+	if stat.ModTime().Truncate(time.Second).Equal(existing.FileModifiedAt.Truncate(time.Second)) {
+		return false
+	}
+	if existing.ModifiedDate == nil || existing.FileType != models.FileTypeEPUB {
+		return true
+	}
+	modifiedDate, err := epub.ParseModifiedDate(path)
+	if err != nil || modifiedDate == nil {
+		return true
 	}
-	return false, nil
+	return !modifiedDate.Equal(*existing.ModifiedDate)
+}
+
+// isBelowMinFileSize reports whether a file of the given size is smaller than
+// the configured minimum for its type (derived from the path's extension).
+func (w *Worker) isBelowMinFileSize(path string, size int64) bool {
+	minSize := w.config.MinFileSize(strings.TrimPrefix(filepath.Ext(path), "."))
+	return minSize > 0 && size < minSize
 }
 
 // scanFileByPath handles batch scan mode - discovering or creating file/book records by path.
@@ -243,6 +393,16 @@ func (w *Worker) scanFileByPath(ctx context.Context, opts ScanOptions, cache *Sc
 		return nil, errors.New("LibraryID required for FilePath mode")
 	}
 
+	// Paths excluded by a .shishoignore file are skipped like missing files.
+	// Known files that become ignored fall through to orphan cleanup.
+	ignored, err := w.isPathIgnored(ctx, opts.FilePath, opts.LibraryID, cache)
+	if err != nil {
+		return nil, err
+	}
+	if ignored {
+		return nil, nil
+	}
+
 	// Fast path: check pre-loaded cache for known file (avoids per-file DB query)
 	if cache != nil {
 		if existingFile := cache.GetKnownFile(opts.FilePath); existingFile != nil {
@@ -269,6 +429,10 @@ func (w *Worker) scanFileByPath(ctx context.Context, opts ScanOptions, cache *Sc
 			// both re-parsing and fingerprint invalidation.
 			changed, changedErr := fileContentChanged(opts.FilePath, existingFile, opts.ForceRefresh)
 			if changedErr == nil && !changed {
+				if err := w.clearFileMissing(ctx, existingFile); err != nil {
+					return nil, errors.Wrap(err, "failed to clear missing file state")
+				}
+				w.indexFileContent(ctx, existingFile, opts.JobLog)
 				return &ScanResult{File: existingFile, Book: existingFile.Book}, nil
 			}
 			// File content changed (or we can't tell) — invalidate stale
@@ -301,6 +465,10 @@ func (w *Worker) scanFileByPath(ctx context.Context, opts ScanOptions, cache *Sc
 			// unchanged and we must not invalidate its fingerprint.
 			changed, changedErr := fileContentChanged(opts.FilePath, existingFile, opts.ForceRefresh)
 			if changedErr == nil && !changed {
+				if err := w.clearFileMissing(ctx, existingFile); err != nil {
+					return nil, errors.Wrap(err, "failed to clear missing file state")
+				}
+				w.indexFileContent(ctx, existingFile, opts.JobLog)
 				return &ScanResult{File: existingFile, Book: existingFile.Book}, nil
 			}
 			// File content changed (or we can't tell) — invalidate stale
@@ -320,7 +488,7 @@ func (w *Worker) scanFileByPath(ctx context.Context, opts ScanOptions, cache *Sc
 	}
 
 	// File doesn't exist in DB - check if it exists on disk
-	_, err := os.Stat(opts.FilePath)
+	stat, err := os.Stat(opts.FilePath)
 	if os.IsNotExist(err) {
 		// File doesn't exist on disk - skip silently
 		return nil, nil
@@ -329,6 +497,13 @@ func (w *Worker) scanFileByPath(ctx context.Context, opts ScanOptions, cache *Sc
 		return nil, errors.Wrap(err, "failed to stat file")
 	}
 
+	// Files below the configured minimum size are never real books, so skip
+	// them before attempting to parse. Known files are not affected.
+	if w.isBelowMinFileSize(opts.FilePath, stat.Size()) {
+		logger.FromContext(ctx).Debug("skipping file below minimum size", logger.Data{"path": opts.FilePath, "size": stat.Size()})
+		return nil, nil
+	}
+
 	// File exists on disk but not in DB - parse metadata and create new record
 	return w.scanFileCreateNew(ctx, opts, cache)
 }
@@ -367,6 +542,19 @@ func (w *Worker) scanFileByID(ctx context.Context, opts ScanOptions, cache *Scan
 	// Check if file exists on disk
 	fileStat, err := os.Stat(file.Filepath)
 	if os.IsNotExist(err) {
+		deferred, deferErr := w.deferMissingFileDeletion(ctx, file)
+		if deferErr != nil {
+			return nil, errors.Wrap(deferErr, "failed to mark file missing")
+		}
+		if deferred {
+			logInfo("file no longer exists on disk, keeping record during grace period", logger.Data{
+				"file_id":       file.ID,
+				"path":          file.Filepath,
+				"missing_scans": file.MissingScans,
+			})
+			return &ScanResult{File: file, Book: file.Book}, nil
+		}
+
 		logInfo("file no longer exists on disk, deleting record", logger.Data{"file_id": file.ID, "path": file.Filepath})
 
 		// Get parent book to check file count
@@ -407,6 +595,8 @@ func (w *Worker) scanFileByID(ctx context.Context, opts ScanOptions, cache *Scan
 				models.FileTypeCBZ:  {},
 				models.FileTypeM4B:  {},
 				models.FileTypePDF:  {},
+				models.FileTypeMOBI: {},
+				models.FileTypeAZW3: {},
 			}
 			if w.pluginManager != nil {
 				for ext := range w.pluginManager.RegisteredFileExtensions() {
@@ -502,6 +692,10 @@ func (w *Worker) scanFileByID(ctx context.Context, opts ScanOptions, cache *Scan
 		return nil, errors.Wrap(err, "failed to stat file")
 	}
 
+	if err := w.clearFileMissing(ctx, file); err != nil {
+		logWarn("failed to clear missing file state", logger.Data{"file_id": file.ID, "error": err.Error()})
+	}
+
 	// Check and recover missing cover if needed
 	if err := w.recoverMissingCover(ctx, file, opts.JobLog); err != nil {
 		logWarn("failed to recover missing cover", logger.Data{"file_id": file.ID, "error": err.Error()})
@@ -522,9 +716,7 @@ func (w *Worker) scanFileByID(ctx context.Context, opts ScanOptions, cache *Scan
 		// Only treat the file as "swapped" on positive evidence of change. A
 		// nil FileModifiedAt means we don't know — could be a pre-migration
 		// row whose sidecar we shouldn't discard.
-		fileSwapped := file.FileModifiedAt != nil &&
-			(fileStat.Size() != file.FilesizeBytes ||
-				!fileStat.ModTime().Truncate(time.Second).Equal(file.FileModifiedAt.Truncate(time.Second)))
+		fileSwapped := file.FileModifiedAt != nil && fileStatChanged(file.Filepath, fileStat, file)
 		if opts.ForceRefresh || fileSwapped {
 			removeFileSidecar(file.Filepath, logWarn)
 		}
@@ -548,6 +740,10 @@ func (w *Worker) scanFileByID(ctx context.Context, opts ScanOptions, cache *Scan
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to parse file metadata")
 		}
+		// Reset mode re-extracts the cover from scratch below.
+		if w.config.UpgradeEmbeddedCovers && !opts.Reset {
+			w.upgradeEmbeddedCover(ctx, metadata, file, opts.JobLog)
+		}
 	}
 
 	// Get parent book for scanFileCore
@@ -555,6 +751,16 @@ func (w *Worker) scanFileByID(ctx context.Context, opts ScanOptions, cache *Scan
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to retrieve parent book")
 	}
+	// Every write bumps updated_at, so comparing against the values we started
+	// with tells us whether this scan changed the file or book.
+	origFileUpdatedAt := file.UpdatedAt
+	origBookUpdatedAt := book.UpdatedAt
+
+	library, err := w.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{ID: &book.LibraryID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve library")
+	}
+	splitParsedNames(metadata, library.NameSeparators)
 
 	// Reset mode: wipe metadata and apply filepath fallbacks
 	if opts.Reset {
@@ -563,8 +769,12 @@ func (w *Worker) scanFileByID(ctx context.Context, opts ScanOptions, cache *Scan
 		// For root-level files, the file's parent dir is a library path, not book.Filepath.
 		isRootLevelFile := filepath.Dir(file.Filepath) != book.Filepath
 
+		if !isRootLevelFile && library.InferSeriesFromParentDir {
+			applyParentDirSeries(metadata, book.Filepath, library.LibraryPaths)
+		}
+
 		// Apply filepath fallbacks so title/authors are populated even if file has none
-		applyFilepathFallbacks(metadata, file.Filepath, book.Filepath, file.FileType, isRootLevelFile)
+		applyFilepathFallbacks(metadata, file.Filepath, book.Filepath, file.FileType, isRootLevelFile, libraryFilenamePatterns(library, logWarn), library.NameSeparators)
 
 		// Wipe book and file metadata.
 		// If BookResetDone is set (called from scanBook), skip the book-level wipe
@@ -583,6 +793,10 @@ func (w *Worker) scanFileByID(ctx context.Context, opts ScanOptions, cache *Scan
 			return nil, errors.Wrap(err, "failed to reload file after reset")
 		}
 
+		if file.FileType == models.FileTypeCBZ {
+			adoptExternalCover(file.Filepath, book.Filepath, isRootLevelFile, library, metadata, logWarn)
+		}
+
 		// Re-extract cover from the already-parsed metadata (resetBookFileState
 		// deleted the cover file from disk and cleared the DB columns).
 		// We use extractAndSaveCover instead of recoverMissingCover to avoid
@@ -596,7 +810,7 @@ func (w *Worker) scanFileByID(ctx context.Context, opts ScanOptions, cache *Scan
 			file.CoverMimeType = &coverMime
 			file.CoverSource = &coverSource
 			if metadata.CoverPage != nil {
-				file.CoverPage = metadata.CoverPage
+				file.CoverPage = models.CoverPageFromIndex(metadata.CoverPage)
 			}
 			if err := w.bookService.UpdateFile(ctx, file, books.UpdateFileOptions{
 				Columns: []string{"cover_image_filename", "cover_mime_type", "cover_source", "cover_page"},
@@ -620,6 +834,12 @@ func (w *Worker) scanFileByID(ctx context.Context, opts ScanOptions, cache *Scan
 	if err != nil {
 		return nil, err
 	}
+	if result.File != nil && !result.File.UpdatedAt.Equal(origFileUpdatedAt) {
+		result.FileUpdated = true
+	}
+	if result.Book != nil && !result.Book.UpdatedAt.Equal(origBookUpdatedAt) {
+		result.BookUpdated = true
+	}
 
 	// Update stored mod time and size so future rescans can skip unchanged files
 	if fileStat != nil {
@@ -764,6 +984,167 @@ func (w *Worker) scanBook(ctx context.Context, opts ScanOptions, cache *ScanCach
 	}, nil
 }
 
+// scanDirectory handles directory rescan mode - scanning every file under
+// opts.DirPath, which must be a library path or a directory inside one. Known
+// main files under the directory that are gone from disk (or now ignored) are
+// cleaned up the same way a full library scan would; files elsewhere in the
+// library are left untouched. A directory that no longer exists is treated as
+// empty, so everything that was under it is cleaned up.
+func (w *Worker) scanDirectory(ctx context.Context, opts ScanOptions, cache *ScanCache) (*ScanResult, error) {
+	if opts.LibraryID == 0 {
+		return nil, errors.New("LibraryID required for DirPath mode")
+	}
+	if opts.JobLog == nil {
+		return nil, errors.New("JobLog required for DirPath mode")
+	}
+	jobLog := opts.JobLog
+
+	library, err := w.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{
+		ID: &opts.LibraryID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve library")
+	}
+
+	dir := filepath.Clean(opts.DirPath)
+	libraryRoot := ""
+	for _, libPath := range library.LibraryPaths {
+		if _, ok := fileutils.RelativeParts(libPath.Filepath, dir); ok || dir == filepath.Clean(libPath.Filepath) {
+			libraryRoot = libPath.Filepath
+			break
+		}
+	}
+	if libraryRoot == "" {
+		return nil, errcodes.ValidationError("Directory must be inside one of the library's paths")
+	}
+
+	exists := true
+	if info, err := os.Stat(dir); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.WithStack(err)
+		}
+		exists = false
+	} else if !info.IsDir() {
+		return nil, errcodes.ValidationError("Path is not a directory")
+	}
+
+	if cache == nil {
+		cache = NewScanCache()
+		cache.SetAliasLister(NewAliasServiceAdapter(w.aliasService))
+	}
+	roots := make([]string, 0, len(library.LibraryPaths))
+	for _, libPath := range library.LibraryPaths {
+		roots = append(roots, libPath.Filepath)
+	}
+	cache.SetLibraryRootPaths(roots)
+
+	allFiles, err := w.bookService.ListAllFilesForLibrary(ctx, library.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load library files")
+	}
+	cache.LoadKnownFiles(allFiles)
+	// Only main files under the directory are orphan candidates.
+	var existingFiles []*models.File
+	for _, f := range allFiles {
+		if f.FileRole != models.FileRoleMain {
+			continue
+		}
+		if _, ok := fileutils.RelativeParts(dir, f.Filepath); ok {
+			existingFiles = append(existingFiles, f)
+		}
+	}
+
+	jobLog.Info("processing directory", logger.Data{"library_id": library.ID, "dir": dir})
+
+	// Match only looks at the entries it walks, so check the directory's own
+	// ancestors up front.
+	var filesToScan []string
+	if exists && !cache.ignores.IsIgnored(libraryRoot, dir, true) {
+		filesToScan, err = w.collectScanPaths(ctx, library, libraryRoot, dir, cache, jobLog)
+		if err != nil {
+			return nil, err
+		}
+	}
+	filesToScan = partitionSupplementPDFsLast(filesToScan, w.config.PDFSupplementFilenames)
+	if w.pluginManager != nil {
+		filesToScan = append(filesToScan, w.runInputConverters(ctx, filesToScan, jobLog, library.ID)...)
+	}
+	if err := w.reconcileMoves(ctx, existingFiles, filesToScan, cache, jobLog); err != nil {
+		jobLog.Warn("move reconciliation encountered an error", logger.Data{"error": err.Error()})
+	}
+
+	// Existing files go through scanFileByID, which organizes and indexes their
+	// books itself. New books are handled below, like a library scan does.
+	newBookIDs := make(map[int]struct{})
+	fileResults := make([]*ScanResult, 0, len(filesToScan))
+	for _, path := range filesToScan {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		result, err := w.scanFileByPath(ctx, ScanOptions{
+			FilePath:     path,
+			LibraryID:    library.ID,
+			ForceRefresh: opts.ForceRefresh,
+			SkipPlugins:  opts.SkipPlugins,
+			JobLog:       jobLog,
+		}, cache)
+		if err != nil {
+			if errors.Is(err, errcodes.DRMProtected()) {
+				jobLog.Warn("skipping DRM-protected file", logger.Data{"path": path, "reason": "drm_protected"})
+				continue
+			}
+			var unsupportedErr *errcodes.UnsupportedFileTypeError
+			if errors.As(err, &unsupportedErr) {
+				jobLog.Warn("skipping unsupported file", logger.Data{"path": path, "file_type": unsupportedErr.FileType, "reason": "unsupported_file_type"})
+				continue
+			}
+			jobLog.Warn("failed to scan file", logger.Data{"path": path, "error": err.Error()})
+			continue
+		}
+		if result == nil {
+			continue
+		}
+		if result.BookCreated && result.Book != nil {
+			newBookIDs[result.Book.ID] = struct{}{}
+		}
+		fileResults = append(fileResults, result)
+	}
+
+	scannedPaths := make(map[string]struct{}, len(filesToScan))
+	for _, path := range filesToScan {
+		scannedPaths[path] = struct{}{}
+	}
+	w.cleanupOrphanedFiles(ctx, existingFiles, scannedPaths, library, jobLog, cache)
+
+	for bookID := range newBookIDs {
+		if library.OrganizeEnabled() {
+			book, err := w.bookService.RetrieveBook(ctx, books.RetrieveBookOptions{ID: &bookID})
+			if err != nil {
+				jobLog.Warn("failed to retrieve book for organization", logger.Data{"book_id": bookID, "error": err.Error()})
+				continue
+			}
+			if err := w.bookService.UpdateBook(ctx, book, books.UpdateBookOptions{OrganizeFiles: true}); err != nil {
+				jobLog.Warn("failed to organize book", logger.Data{"book_id": bookID, "error": err.Error()})
+			}
+		}
+		if w.searchService != nil {
+			book, err := w.bookService.RetrieveBook(ctx, books.RetrieveBookOptions{ID: &bookID})
+			if err != nil {
+				continue
+			}
+			if err := w.searchService.IndexBook(ctx, book); err != nil {
+				jobLog.Warn("failed to index book", logger.Data{"book_id": bookID, "error": err.Error()})
+			}
+		}
+	}
+
+	if err := EnsureHashGenerationJob(ctx, w.jobService, library.ID); err != nil {
+		jobLog.Warn("failed to ensure hash generation job", logger.Data{"error": err.Error()})
+	}
+
+	return &ScanResult{Files: fileResults}, nil
+}
+
 // scanFileCore updates a file and its parent book with parsed metadata.
 // This handles book scalar field updates (Title, SortTitle, Subtitle, Description)
 // and book relationship updates (Authors, Series, Genres, Tags).
@@ -794,6 +1175,10 @@ func (w *Worker) scanFileCore(
 ) (*ScanResult, error) {
 	log := logger.FromContext(ctx)
 
+	defer func(start time.Time) {
+		metrics.ScanFileDuration.WithLabelValues(file.FileType).Observe(time.Since(start).Seconds())
+	}(time.Now())
+
 	logWarn := func(msg string, data logger.Data) {
 		log.Warn(msg, data)
 		if jobLog != nil {
@@ -824,19 +1209,44 @@ func (w *Worker) scanFileCore(
 		oldRels = snapshotBookRelations(book, file)
 	}
 
-	sidecarSource := models.DataSourceSidecar
-
 	// Read sidecar files if they exist (higher priority than file metadata)
-	// Sidecars can override file metadata but not manual user edits
+	// Sidecars can override file metadata but not manual user edits.
+	// A sidecar that fails validation is ignored and left as it is on disk,
+	// so a typo in a hand-edited sidecar doesn't lose the rest of the edits.
+	var warnings []ScanWarning
 	bookSidecarData, err := sidecar.ReadBookSidecarFromModel(book, file)
+	bookSidecarInvalid := isSidecarValidationError(err)
 	if err != nil {
-		logWarn("failed to read book sidecar", logger.Data{"error": err.Error()})
+		logWarn("failed to read book sidecar", sidecarErrorData(err))
+		warnings = appendSidecarWarning(warnings, err)
 	}
 	fileSidecarData, err := sidecar.ReadFileSidecar(file.Filepath)
+	fileSidecarInvalid := isSidecarValidationError(err)
 	if err != nil {
-		logWarn("failed to read file sidecar", logger.Data{"error": err.Error()})
+		logWarn("failed to read file sidecar", sidecarErrorData(err))
+		warnings = appendSidecarWarning(warnings, err)
+	}
+	// Directory-based books without sidecars fall back to a release .nfo
+	// file. Its fields are pinned to the nfo source, which only beats
+	// filepath-derived values.
+	if bookSidecarData == nil || fileSidecarData == nil {
+		if info, statErr := os.Stat(book.Filepath); statErr == nil && info.IsDir() {
+			nfoBook, nfoFile, err := sidecar.ReadNFO(book.Filepath)
+			if err != nil {
+				logWarn("failed to read nfo file", logger.Data{"error": err.Error()})
+			}
+			if bookSidecarData == nil {
+				bookSidecarData = nfoBook
+			}
+			if fileSidecarData == nil {
+				fileSidecarData = nfoFile
+			}
+		}
 	}
 
+	// Per-field record of which source won, logged at the end of the scan.
+	decisions := newSourceDecisionLog(w.config.LockedFields)
+
 	bookUpdateOpts := books.UpdateBookOptions{Columns: []string{}}
 	bookTitleChanged := false
 	authorsChanged := false
@@ -851,9 +1261,38 @@ func (w *Worker) scanFileCore(
 
 	// Book-level updates: only for main files, not supplements
 	if isMainFile {
+		stripSeriesFromTitle := false
+		if library, err := w.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{ID: &book.LibraryID}); err != nil {
+			logWarn("failed to retrieve library for title normalization", logger.Data{"library_id": book.LibraryID, "error": err.Error()})
+		} else {
+			stripSeriesFromTitle = library.StripSeriesFromTitle
+		}
+
 		// Title (from metadata)
 		title := strings.TrimSpace(metadata.Title)
 		titleSource := metadata.SourceForField("title")
+		// Libraries that strip series from titles turn "Mistborn #2: The Well
+		// of Ascension" into "The Well of Ascension", filling in the series
+		// when the file didn't provide one. As with the normalization below,
+		// plugin/manual titles are left alone; sidecar titles are handled by
+		// sidecarBookTitle. The raw title is kept so turning the setting off
+		// restores it.
+		var rawTitle *string
+		if stripSeriesFromTitle && models.GetDataSourcePriority(titleSource) >= models.DataSourceFileMetadataPriority {
+			if seriesName, seriesNumber, rest, ok := fileutils.SplitSeriesPrefix(title); ok {
+				raw := title
+				rawTitle = &raw
+				title = rest
+				if metadata.Series == "" {
+					metadata.Series = seriesName
+					metadata.SeriesNumber = seriesNumber
+					if metadata.FieldDataSources == nil {
+						metadata.FieldDataSources = make(map[string]string)
+					}
+					metadata.FieldDataSources["series"] = titleSource
+				}
+			}
+		}
 		// Normalize series number indicators (e.g., "#007" -> "v007", "Ch.5" -> "c005") for CBZ files only when
 		// the title came from the file itself or its path. Plugin/sidecar/manual
 		// titles are user-curated and must not be rewritten
@@ -864,11 +1303,12 @@ func (w *Worker) scanFileCore(
 				applySeriesNumberUnit(metadata, unit, titleSource)
 			}
 		}
-		if shouldUpdateScalar(title, book.Title, titleSource, book.TitleSource, forceRefresh) {
+		if decisions.decide("title", titleSource, book.TitleSource, shouldUpdateScalar(title, book.Title, titleSource, book.TitleSource, forceRefresh)) {
 			logInfo("updating book title", logger.Data{"from": book.Title, "to": title})
 			book.Title = title
 			book.TitleSource = titleSource
-			bookUpdateOpts.Columns = append(bookUpdateOpts.Columns, "title", "title_source")
+			book.RawTitle = rawTitle
+			bookUpdateOpts.Columns = append(bookUpdateOpts.Columns, "title", "title_source", "raw_title")
 			bookTitleChanged = true
 
 			// Regenerate sort title
@@ -881,16 +1321,19 @@ func (w *Worker) scanFileCore(
 		}
 		// Title (from sidecar - can override filepath-sourced data)
 		if bookSidecarData != nil && bookSidecarData.Title != "" {
-			if shouldApplySidecarScalar(bookSidecarData.Title, book.Title, book.TitleSource, forceRefresh) {
-				logInfo("updating book title from sidecar", logger.Data{"from": book.Title, "to": bookSidecarData.Title})
-				book.Title = bookSidecarData.Title
+			sidecarSource := bookSidecarData.SourceFor("title")
+			sidecarTitle, sidecarRawTitle := sidecarBookTitle(bookSidecarData, sidecarSource, stripSeriesFromTitle)
+			if decisions.decide("title", sidecarSource, book.TitleSource, shouldApplySidecarScalar(sidecarTitle, book.Title, sidecarSource, book.TitleSource, forceRefresh)) {
+				logInfo("updating book title from sidecar", logger.Data{"from": book.Title, "to": sidecarTitle})
+				book.Title = sidecarTitle
 				book.TitleSource = sidecarSource
-				bookUpdateOpts.Columns = appendIfMissing(bookUpdateOpts.Columns, "title", "title_source")
+				book.RawTitle = sidecarRawTitle
+				bookUpdateOpts.Columns = appendIfMissing(bookUpdateOpts.Columns, "title", "title_source", "raw_title")
 				bookTitleChanged = true
 
 				// Regenerate sort title
-				newSortTitle := sortname.ForTitle(bookSidecarData.Title)
-				if shouldApplySidecarScalar(newSortTitle, book.SortTitle, book.SortTitleSource, forceRefresh) {
+				newSortTitle := sortname.ForTitle(sidecarTitle)
+				if shouldApplySidecarScalar(newSortTitle, book.SortTitle, sidecarSource, book.SortTitleSource, forceRefresh) {
 					book.SortTitle = newSortTitle
 					book.SortTitleSource = sidecarSource
 					bookUpdateOpts.Columns = appendIfMissing(bookUpdateOpts.Columns, "sort_title", "sort_title_source")
@@ -910,7 +1353,7 @@ func (w *Worker) scanFileCore(
 				existingSubtitleSource = *book.SubtitleSource
 			}
 			subtitleSource := metadata.SourceForField("subtitle")
-			if shouldUpdateScalar(subtitle, existingSubtitle, subtitleSource, existingSubtitleSource, forceRefresh) {
+			if decisions.decide("subtitle", subtitleSource, existingSubtitleSource, shouldUpdateScalar(subtitle, existingSubtitle, subtitleSource, existingSubtitleSource, forceRefresh)) {
 				logInfo("updating book subtitle", logger.Data{"from": existingSubtitle, "to": subtitle})
 				book.Subtitle = &subtitle
 				book.SubtitleSource = &subtitleSource
@@ -919,6 +1362,7 @@ func (w *Worker) scanFileCore(
 		}
 		// Subtitle (from sidecar)
 		if bookSidecarData != nil && bookSidecarData.Subtitle != nil && *bookSidecarData.Subtitle != "" {
+			sidecarSource := bookSidecarData.SourceFor("subtitle")
 			existingSubtitle := ""
 			existingSubtitleSource := ""
 			if book.Subtitle != nil {
@@ -927,7 +1371,7 @@ func (w *Worker) scanFileCore(
 			if book.SubtitleSource != nil {
 				existingSubtitleSource = *book.SubtitleSource
 			}
-			if shouldApplySidecarScalar(*bookSidecarData.Subtitle, existingSubtitle, existingSubtitleSource, forceRefresh) {
+			if decisions.decide("subtitle", sidecarSource, existingSubtitleSource, shouldApplySidecarScalar(*bookSidecarData.Subtitle, existingSubtitle, sidecarSource, existingSubtitleSource, forceRefresh)) {
 				logInfo("updating book subtitle from sidecar", logger.Data{"from": existingSubtitle, "to": *bookSidecarData.Subtitle})
 				book.Subtitle = bookSidecarData.Subtitle
 				book.SubtitleSource = &sidecarSource
@@ -949,7 +1393,7 @@ func (w *Worker) scanFileCore(
 				existingDescriptionSource = *book.DescriptionSource
 			}
 			descSource := metadata.SourceForField("description")
-			if shouldUpdateScalar(description, existingDescription, descSource, existingDescriptionSource, forceRefresh) {
+			if decisions.decide("description", descSource, existingDescriptionSource, shouldUpdateScalar(description, existingDescription, descSource, existingDescriptionSource, forceRefresh)) {
 				logInfo("updating book description", nil)
 				book.Description = &description
 				book.DescriptionSource = &descSource
@@ -958,6 +1402,7 @@ func (w *Worker) scanFileCore(
 		}
 		// Description (from sidecar, strip HTML for clean display)
 		if bookSidecarData != nil && bookSidecarData.Description != nil && *bookSidecarData.Description != "" {
+			sidecarSource := bookSidecarData.SourceFor("description")
 			sanitizedDesc := htmlutil.StripTags(strings.TrimSpace(*bookSidecarData.Description))
 			existingDescription := ""
 			existingDescriptionSource := ""
@@ -967,7 +1412,7 @@ func (w *Worker) scanFileCore(
 			if book.DescriptionSource != nil {
 				existingDescriptionSource = *book.DescriptionSource
 			}
-			if sanitizedDesc != "" && shouldApplySidecarScalar(sanitizedDesc, existingDescription, existingDescriptionSource, forceRefresh) {
+			if sanitizedDesc != "" && decisions.decide("description", sidecarSource, existingDescriptionSource, shouldApplySidecarScalar(sanitizedDesc, existingDescription, sidecarSource, existingDescriptionSource, forceRefresh)) {
 				logInfo("updating book description from sidecar", nil)
 				book.Description = &sanitizedDesc
 				book.DescriptionSource = &sidecarSource
@@ -983,9 +1428,27 @@ func (w *Worker) scanFileCore(
 		}
 
 		// Update authors relationship (from metadata)
-		if len(metadata.Authors) > 0 {
-			authorNames := make([]string, 0, len(metadata.Authors))
-			for _, a := range metadata.Authors {
+		authorSource := metadata.SourceForField("authors")
+		parsedAuthors := metadata.Authors
+		if w.config.AuthorMergeStrategy == authorMergeStrategyAppend && authorSource != models.DataSourceFilepath {
+			isRootLevelFile := filepath.Dir(file.Filepath) != book.Filepath
+			fpBookPath := book.Filepath
+			if isRootLevelFile {
+				fpBookPath = file.Filepath
+			}
+			var patterns fileutils.FilenamePatterns
+			var nameSeparators []string
+			if library, err := w.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{ID: &book.LibraryID}); err != nil {
+				logWarn("failed to retrieve library for filename patterns", logger.Data{"library_id": book.LibraryID, "error": err.Error()})
+			} else {
+				patterns = libraryFilenamePatterns(library, logWarn)
+				nameSeparators = library.NameSeparators
+			}
+			parsedAuthors = appendFilepathAuthors(parsedAuthors, extractAuthorsFromFilepath(fpBookPath, isRootLevelFile, patterns, nameSeparators))
+		}
+		if len(parsedAuthors) > 0 {
+			authorNames := make([]string, 0, len(parsedAuthors))
+			for _, a := range parsedAuthors {
 				authorNames = append(authorNames, a.Name)
 			}
 			existingAuthorNames := make([]string, 0, len(book.Authors))
@@ -995,14 +1458,13 @@ func (w *Worker) scanFileCore(
 				}
 			}
 
-			authorSource := metadata.SourceForField("authors")
-			if shouldUpdateRelationship(authorNames, existingAuthorNames, authorSource, book.AuthorSource, forceRefresh) {
-				logInfo("updating authors", logger.Data{"new_count": len(metadata.Authors), "old_count": len(book.Authors)})
+			if decisions.decide("authors", authorSource, book.AuthorSource, shouldUpdateRelationship(authorNames, existingAuthorNames, authorSource, book.AuthorSource, forceRefresh)) {
+				logInfo("updating authors", logger.Data{"new_count": len(parsedAuthors), "old_count": len(book.Authors)})
 
 				// Collect authors for batch insert (replaces immediate delete + create)
 				relUpdates.DeleteAuthors = true
 				relUpdates.Authors = nil // Clear any previous collection
-				for i, parsedAuthor := range metadata.Authors {
+				for i, parsedAuthor := range parsedAuthors {
 					var person *models.Person
 					var err error
 					if cache != nil {
@@ -1036,6 +1498,7 @@ func (w *Worker) scanFileCore(
 		}
 		// Update authors relationship (from sidecar)
 		if bookSidecarData != nil && len(bookSidecarData.Authors) > 0 {
+			sidecarSource := bookSidecarData.SourceFor("authors")
 			sidecarAuthorNames := make([]string, 0, len(bookSidecarData.Authors))
 			for _, a := range bookSidecarData.Authors {
 				sidecarAuthorNames = append(sidecarAuthorNames, a.Name)
@@ -1047,7 +1510,7 @@ func (w *Worker) scanFileCore(
 				}
 			}
 
-			if shouldApplySidecarRelationship(sidecarAuthorNames, existingAuthorNames, book.AuthorSource, forceRefresh) {
+			if decisions.decide("authors", sidecarSource, book.AuthorSource, shouldApplySidecarRelationship(sidecarAuthorNames, existingAuthorNames, sidecarSource, book.AuthorSource, forceRefresh)) {
 				logInfo("updating authors from sidecar", logger.Data{"new_count": len(bookSidecarData.Authors), "old_count": len(book.Authors)})
 
 				// Collect authors for batch insert (replaces any metadata collection)
@@ -1092,24 +1555,27 @@ func (w *Worker) scanFileCore(
 			}
 
 			seriesSource := metadata.SourceForField("series")
-			if shouldUpdateParsedSeries(metadata, book.BookSeries, existingSeriesSource, forceRefresh) {
-				logInfo("updating series", logger.Data{"new_count": 1, "old_count": len(book.BookSeries)})
+			if decisions.decide("series", seriesSource, existingSeriesSource, shouldUpdateParsedSeries(metadata, book.BookSeries, existingSeriesSource, forceRefresh)) {
+				parsedSeries := metadata.AllSeries()
+				logInfo("updating series", logger.Data{"new_count": len(parsedSeries), "old_count": len(book.BookSeries)})
 
 				// Collect series for batch insert (replaces immediate delete + create)
 				relUpdates.DeleteSeries = true
 				relUpdates.BookSeries = nil // Clear any previous collection
-				var seriesRecord *models.Series
-				var err error
-				if cache != nil {
-					seriesRecord, err = cache.GetOrCreateSeries(ctx, metadata.Series, book.LibraryID, seriesSource, w.seriesService)
-				} else {
-					seriesRecord, err = w.seriesService.FindOrCreateSeries(ctx, metadata.Series, book.LibraryID, seriesSource)
-				}
-				if err != nil {
-					logWarn("failed to find/create series", logger.Data{"name": metadata.Series, "error": err.Error()})
-				} else {
+				for i, parsed := range parsedSeries {
+					var seriesRecord *models.Series
+					var err error
+					if cache != nil {
+						seriesRecord, err = cache.GetOrCreateSeries(ctx, parsed.Name, book.LibraryID, seriesSource, w.seriesService)
+					} else {
+						seriesRecord, err = w.seriesService.FindOrCreateSeries(ctx, parsed.Name, book.LibraryID, seriesSource)
+					}
+					if err != nil {
+						logWarn("failed to find/create series", logger.Data{"name": parsed.Name, "error": err.Error()})
+						continue
+					}
 					seriesNumber, seriesNumberEnd, seriesNumberUnit := externalSeriesNumberGroup(
-						metadata.SeriesNumber, metadata.SeriesNumberEnd, metadata.SeriesNumberUnit,
+						parsed.Number, parsed.NumberEnd, parsed.Unit,
 					)
 					relUpdates.BookSeries = append(relUpdates.BookSeries, &models.BookSeries{
 						BookID:           book.ID,
@@ -1118,13 +1584,15 @@ func (w *Worker) scanFileCore(
 						SeriesNumber:     seriesNumber,
 						SeriesNumberEnd:  seriesNumberEnd,
 						SeriesNumberUnit: seriesNumberUnit,
-						SortOrder:        1,
+						ReadingOrder:     externalReadingOrder(parsed.ReadingOrder),
+						SortOrder:        i + 1,
 					})
 				}
 			}
 		}
 		// Update series relationship (from sidecar)
 		if bookSidecarData != nil && len(bookSidecarData.Series) > 0 {
+			sidecarSource := bookSidecarData.SourceFor("series")
 			sidecarSeriesNames := make([]string, 0, len(bookSidecarData.Series))
 			for _, s := range bookSidecarData.Series {
 				if s.Name != "" {
@@ -1146,7 +1614,7 @@ func (w *Worker) scanFileCore(
 				existingSeriesSource = metadata.SourceForField("series")
 			}
 
-			if len(sidecarSeriesNames) > 0 && shouldApplySeriesSidecar(bookSidecarData.Series, existingSeries, existingSeriesSource, forceRefresh) {
+			if len(sidecarSeriesNames) > 0 && decisions.decide("series", sidecarSource, existingSeriesSource, shouldApplySeriesSidecar(bookSidecarData.Series, existingSeries, sidecarSource, existingSeriesSource, forceRefresh)) {
 				logInfo("updating series from sidecar", logger.Data{"new_count": len(bookSidecarData.Series), "old_count": len(book.BookSeries)})
 
 				// Collect series for batch insert (replaces any metadata collection)
@@ -1177,6 +1645,7 @@ func (w *Worker) scanFileCore(
 						SeriesNumber:     seriesNumber,
 						SeriesNumberEnd:  seriesNumberEnd,
 						SeriesNumberUnit: seriesNumberUnit,
+						ReadingOrder:     externalReadingOrder(sidecarSeries.ReadingOrder),
 						SortOrder:        i + 1,
 					})
 				}
@@ -1203,7 +1672,7 @@ func (w *Worker) scanFileCore(
 			sort.Strings(existingGenreNames)
 
 			genreSource := metadata.SourceForField("genres")
-			if shouldUpdateRelationship(metadata.Genres, existingGenreNames, genreSource, existingGenreSource, forceRefresh) {
+			if decisions.decide("genres", genreSource, existingGenreSource, shouldUpdateRelationship(metadata.Genres, existingGenreNames, genreSource, existingGenreSource, forceRefresh)) {
 				logInfo("updating genres", logger.Data{"new_count": len(metadata.Genres), "old_count": len(book.BookGenres)})
 
 				// Collect genres for batch insert (replaces immediate delete + create)
@@ -1236,6 +1705,7 @@ func (w *Worker) scanFileCore(
 		}
 		// Update genres relationship (from sidecar)
 		if bookSidecarData != nil && len(bookSidecarData.Genres) > 0 {
+			sidecarSource := bookSidecarData.SourceFor("genres")
 			existingGenreNames := make([]string, 0, len(book.BookGenres))
 			existingGenreSource := ""
 			if book.GenreSource != nil {
@@ -1253,7 +1723,7 @@ func (w *Worker) scanFileCore(
 			sort.Strings(bookSidecarData.Genres)
 			sort.Strings(existingGenreNames)
 
-			if shouldApplySidecarRelationship(bookSidecarData.Genres, existingGenreNames, existingGenreSource, forceRefresh) {
+			if decisions.decide("genres", sidecarSource, existingGenreSource, shouldApplySidecarRelationship(bookSidecarData.Genres, existingGenreNames, sidecarSource, existingGenreSource, forceRefresh)) {
 				logInfo("updating genres from sidecar", logger.Data{"new_count": len(bookSidecarData.Genres), "old_count": len(book.BookGenres)})
 
 				// Collect genres for batch insert (replaces any metadata collection)
@@ -1304,7 +1774,7 @@ func (w *Worker) scanFileCore(
 			sort.Strings(existingTagNames)
 
 			tagSource := metadata.SourceForField("tags")
-			if shouldUpdateRelationship(metadata.Tags, existingTagNames, tagSource, existingTagSource, forceRefresh) {
+			if decisions.decide("tags", tagSource, existingTagSource, shouldUpdateRelationship(metadata.Tags, existingTagNames, tagSource, existingTagSource, forceRefresh)) {
 				logInfo("updating tags", logger.Data{"new_count": len(metadata.Tags), "old_count": len(book.BookTags)})
 
 				// Collect tags for batch insert (replaces immediate delete + create)
@@ -1337,6 +1807,7 @@ func (w *Worker) scanFileCore(
 		}
 		// Update tags relationship (from sidecar)
 		if bookSidecarData != nil && len(bookSidecarData.Tags) > 0 {
+			sidecarSource := bookSidecarData.SourceFor("tags")
 			existingTagNames := make([]string, 0, len(book.BookTags))
 			existingTagSource := ""
 			if book.TagSource != nil {
@@ -1353,7 +1824,7 @@ func (w *Worker) scanFileCore(
 			sort.Strings(bookSidecarData.Tags)
 			sort.Strings(existingTagNames)
 
-			if shouldApplySidecarRelationship(bookSidecarData.Tags, existingTagNames, existingTagSource, forceRefresh) {
+			if decisions.decide("tags", sidecarSource, existingTagSource, shouldApplySidecarRelationship(bookSidecarData.Tags, existingTagNames, sidecarSource, existingTagSource, forceRefresh)) {
 				logInfo("updating tags from sidecar", logger.Data{"new_count": len(bookSidecarData.Tags), "old_count": len(book.BookTags)})
 
 				// Collect tags for batch insert (replaces any metadata collection)
@@ -1413,7 +1884,7 @@ func (w *Worker) scanFileCore(
 			existingNameSource = *file.NameSource
 		}
 		nameSource := metadata.SourceForField("title")
-		if shouldUpdateScalar(newFileName, existingName, nameSource, existingNameSource, forceRefresh) {
+		if decisions.decide("name", nameSource, existingNameSource, shouldUpdateScalar(newFileName, existingName, nameSource, existingNameSource, forceRefresh)) {
 			logInfo("updating file name", logger.Data{"from": existingName, "to": newFileName})
 			file.Name = &newFileName
 			file.NameSource = &nameSource
@@ -1422,6 +1893,7 @@ func (w *Worker) scanFileCore(
 	}
 	// File name (from sidecar)
 	if fileSidecarData != nil && fileSidecarData.Name != nil && *fileSidecarData.Name != "" {
+		sidecarSource := fileSidecarData.SourceFor("name")
 		existingName := ""
 		existingNameSource := ""
 		if file.Name != nil {
@@ -1430,7 +1902,7 @@ func (w *Worker) scanFileCore(
 		if file.NameSource != nil {
 			existingNameSource = *file.NameSource
 		}
-		if shouldApplySidecarScalar(*fileSidecarData.Name, existingName, existingNameSource, forceRefresh) {
+		if decisions.decide("name", sidecarSource, existingNameSource, shouldApplySidecarScalar(*fileSidecarData.Name, existingName, sidecarSource, existingNameSource, forceRefresh)) {
 			logInfo("updating file name from sidecar", logger.Data{"from": existingName, "to": *fileSidecarData.Name})
 			file.Name = fileSidecarData.Name
 			file.NameSource = &sidecarSource
@@ -1449,7 +1921,7 @@ func (w *Worker) scanFileCore(
 			existingURLSource = *file.URLSource
 		}
 		urlSource := metadata.SourceForField("url")
-		if shouldUpdateScalar(metadata.URL, existingURL, urlSource, existingURLSource, forceRefresh) {
+		if decisions.decide("url", urlSource, existingURLSource, shouldUpdateScalar(metadata.URL, existingURL, urlSource, existingURLSource, forceRefresh)) {
 			logInfo("updating file URL", logger.Data{"from": existingURL, "to": metadata.URL})
 			file.URL = &metadata.URL
 			file.URLSource = &urlSource
@@ -1458,6 +1930,7 @@ func (w *Worker) scanFileCore(
 	}
 	// URL (from sidecar)
 	if fileSidecarData != nil && fileSidecarData.URL != nil && *fileSidecarData.URL != "" {
+		sidecarSource := fileSidecarData.SourceFor("url")
 		existingURL := ""
 		existingURLSource := ""
 		if file.URL != nil {
@@ -1466,7 +1939,7 @@ func (w *Worker) scanFileCore(
 		if file.URLSource != nil {
 			existingURLSource = *file.URLSource
 		}
-		if shouldApplySidecarScalar(*fileSidecarData.URL, existingURL, existingURLSource, forceRefresh) {
+		if decisions.decide("url", sidecarSource, existingURLSource, shouldApplySidecarScalar(*fileSidecarData.URL, existingURL, sidecarSource, existingURLSource, forceRefresh)) {
 			logInfo("updating file URL from sidecar", logger.Data{"from": existingURL, "to": *fileSidecarData.URL})
 			file.URL = fileSidecarData.URL
 			file.URLSource = &sidecarSource
@@ -1487,7 +1960,7 @@ func (w *Worker) scanFileCore(
 			existingDateStr = file.ReleaseDate.Format("2006-01-02")
 		}
 		releaseDateSource := metadata.SourceForField("releaseDate")
-		if shouldUpdateScalar(newDateStr, existingDateStr, releaseDateSource, existingReleaseDateSource, forceRefresh) {
+		if decisions.decide("release_date", releaseDateSource, existingReleaseDateSource, shouldUpdateScalar(newDateStr, existingDateStr, releaseDateSource, existingReleaseDateSource, forceRefresh)) {
 			logInfo("updating file release date", logger.Data{"from": existingDateStr, "to": newDateStr})
 			file.ReleaseDate = metadata.ReleaseDate
 			file.ReleaseDateSource = &releaseDateSource
@@ -1496,6 +1969,7 @@ func (w *Worker) scanFileCore(
 	}
 	// ReleaseDate (from sidecar)
 	if fileSidecarData != nil && fileSidecarData.ReleaseDate != nil && *fileSidecarData.ReleaseDate != "" {
+		sidecarSource := fileSidecarData.SourceFor("release_date")
 		existingReleaseDateSource := ""
 		if file.ReleaseDateSource != nil {
 			existingReleaseDateSource = *file.ReleaseDateSource
@@ -1504,7 +1978,7 @@ func (w *Worker) scanFileCore(
 		if file.ReleaseDate != nil {
 			existingDateStr = file.ReleaseDate.Format("2006-01-02")
 		}
-		if shouldApplySidecarScalar(*fileSidecarData.ReleaseDate, existingDateStr, existingReleaseDateSource, forceRefresh) {
+		if decisions.decide("release_date", sidecarSource, existingReleaseDateSource, shouldApplySidecarScalar(*fileSidecarData.ReleaseDate, existingDateStr, sidecarSource, existingReleaseDateSource, forceRefresh)) {
 			// Parse sidecar date string
 			if parsedDate, err := time.Parse("2006-01-02", *fileSidecarData.ReleaseDate); err == nil {
 				logInfo("updating file release date from sidecar", logger.Data{"from": existingDateStr, "to": *fileSidecarData.ReleaseDate})
@@ -1528,7 +2002,7 @@ func (w *Worker) scanFileCore(
 			existingLanguageSource = *file.LanguageSource
 		}
 		langSource := metadata.SourceForField("language")
-		if shouldUpdateScalar(*metadata.Language, existingLanguage, langSource, existingLanguageSource, forceRefresh) {
+		if decisions.decide("language", langSource, existingLanguageSource, shouldUpdateScalar(*metadata.Language, existingLanguage, langSource, existingLanguageSource, forceRefresh)) {
 			logInfo("updating file language", logger.Data{"from": existingLanguage, "to": *metadata.Language})
 			file.Language = metadata.Language
 			file.LanguageSource = &langSource
@@ -1537,6 +2011,7 @@ func (w *Worker) scanFileCore(
 	}
 	// Language (from sidecar)
 	if fileSidecarData != nil && fileSidecarData.Language != nil && *fileSidecarData.Language != "" {
+		sidecarSource := fileSidecarData.SourceFor("language")
 		existingLanguage := ""
 		existingLanguageSource := ""
 		if file.Language != nil {
@@ -1545,7 +2020,7 @@ func (w *Worker) scanFileCore(
 		if file.LanguageSource != nil {
 			existingLanguageSource = *file.LanguageSource
 		}
-		if shouldApplySidecarScalar(*fileSidecarData.Language, existingLanguage, existingLanguageSource, forceRefresh) {
+		if decisions.decide("language", sidecarSource, existingLanguageSource, shouldApplySidecarScalar(*fileSidecarData.Language, existingLanguage, sidecarSource, existingLanguageSource, forceRefresh)) {
 			logInfo("updating file language from sidecar", logger.Data{"from": existingLanguage, "to": *fileSidecarData.Language})
 			file.Language = fileSidecarData.Language
 			file.LanguageSource = &sidecarSource
@@ -1572,7 +2047,7 @@ func (w *Worker) scanFileCore(
 			}
 		}
 		abridgedSource := metadata.SourceForField("abridged")
-		if shouldUpdateScalar(newAbridgedStr, existingAbridgedStr, abridgedSource, existingAbridgedSource, forceRefresh) {
+		if decisions.decide("abridged", abridgedSource, existingAbridgedSource, shouldUpdateScalar(newAbridgedStr, existingAbridgedStr, abridgedSource, existingAbridgedSource, forceRefresh)) {
 			logInfo("updating file abridged", logger.Data{"from": existingAbridgedStr, "to": newAbridgedStr})
 			file.Abridged = metadata.Abridged
 			file.AbridgedSource = &abridgedSource
@@ -1581,6 +2056,7 @@ func (w *Worker) scanFileCore(
 	}
 	// Abridged (from sidecar)
 	if fileSidecarData != nil && fileSidecarData.Abridged != nil {
+		sidecarSource := fileSidecarData.SourceFor("abridged")
 		existingAbridgedSource := ""
 		if file.AbridgedSource != nil {
 			existingAbridgedSource = *file.AbridgedSource
@@ -1597,7 +2073,7 @@ func (w *Worker) scanFileCore(
 				existingAbridgedStr = "false"
 			}
 		}
-		if shouldApplySidecarScalar(newAbridgedStr, existingAbridgedStr, existingAbridgedSource, forceRefresh) {
+		if decisions.decide("abridged", sidecarSource, existingAbridgedSource, shouldApplySidecarScalar(newAbridgedStr, existingAbridgedStr, sidecarSource, existingAbridgedSource, forceRefresh)) {
 			logInfo("updating file abridged from sidecar", logger.Data{"from": existingAbridgedStr, "to": newAbridgedStr})
 			file.Abridged = fileSidecarData.Abridged
 			file.AbridgedSource = &sidecarSource
@@ -1617,7 +2093,7 @@ func (w *Worker) scanFileCore(
 			existingPublisherSource = *file.PublisherSource
 		}
 		pubSource := metadata.SourceForField("publisher")
-		if shouldUpdateScalar(publisherName, existingPublisherName, pubSource, existingPublisherSource, forceRefresh) {
+		if decisions.decide("publisher", pubSource, existingPublisherSource, shouldUpdateScalar(publisherName, existingPublisherName, pubSource, existingPublisherSource, forceRefresh)) {
 			var publisher *models.Publisher
 			var err error
 			if cache != nil {
@@ -1637,6 +2113,7 @@ func (w *Worker) scanFileCore(
 	}
 	// Publisher (from sidecar)
 	if fileSidecarData != nil && fileSidecarData.Publisher != nil && *fileSidecarData.Publisher != "" {
+		sidecarSource := fileSidecarData.SourceFor("publisher")
 		existingPublisherName := ""
 		existingPublisherSource := ""
 		if file.Publisher != nil {
@@ -1645,7 +2122,7 @@ func (w *Worker) scanFileCore(
 		if file.PublisherSource != nil {
 			existingPublisherSource = *file.PublisherSource
 		}
-		if shouldApplySidecarScalar(*fileSidecarData.Publisher, existingPublisherName, existingPublisherSource, forceRefresh) {
+		if decisions.decide("publisher", sidecarSource, existingPublisherSource, shouldApplySidecarScalar(*fileSidecarData.Publisher, existingPublisherName, sidecarSource, existingPublisherSource, forceRefresh)) {
 			var publisher *models.Publisher
 			var err error
 			if cache != nil {
@@ -1684,6 +2161,18 @@ func (w *Worker) scanFileCore(
 			fileUpdateOpts.Columns = appendIfMissing(fileUpdateOpts.Columns, "audiobook_codec")
 		}
 	}
+	if metadata.SampleRateHz > 0 {
+		if file.AudiobookSampleRate == nil || *file.AudiobookSampleRate != metadata.SampleRateHz {
+			file.AudiobookSampleRate = &metadata.SampleRateHz
+			fileUpdateOpts.Columns = appendIfMissing(fileUpdateOpts.Columns, "audiobook_sample_rate")
+		}
+	}
+	if metadata.Channels > 0 {
+		if file.AudiobookChannels == nil || *file.AudiobookChannels != metadata.Channels {
+			file.AudiobookChannels = &metadata.Channels
+			fileUpdateOpts.Columns = appendIfMissing(fileUpdateOpts.Columns, "audiobook_channels")
+		}
+	}
 
 	// Update page count (CBZ) - always comes from file metadata
 	if metadata.PageCount != nil {
@@ -1693,6 +2182,47 @@ func (w *Worker) scanFileCore(
 		}
 	}
 
+	// Update word count (EPUB) - always comes from file metadata
+	if metadata.WordCount != nil {
+		if file.WordCount == nil || *file.WordCount != *metadata.WordCount {
+			file.WordCount = metadata.WordCount
+			fileUpdateOpts.Columns = appendIfMissing(fileUpdateOpts.Columns, "word_count")
+		}
+	}
+
+	// Update modified date (EPUB) - always comes from file metadata
+	if metadata.ModifiedDate != nil {
+		if file.ModifiedDate == nil || !file.ModifiedDate.Equal(*metadata.ModifiedDate) {
+			file.ModifiedDate = metadata.ModifiedDate
+			fileUpdateOpts.Columns = appendIfMissing(fileUpdateOpts.Columns, "modified_date")
+		}
+	}
+
+	// Update reading direction (CBZ) - always comes from file metadata
+	if metadata.ReadingDirection != "" {
+		if file.ReadingDirection == nil || *file.ReadingDirection != metadata.ReadingDirection {
+			file.ReadingDirection = &metadata.ReadingDirection
+			fileUpdateOpts.Columns = appendIfMissing(fileUpdateOpts.Columns, "reading_direction")
+		}
+	}
+
+	// Update accessibility summary (EPUB) - always comes from file metadata
+	if metadata.Accessibility != nil {
+		hasTextToSpeech := mediafile.SupportsTextToSpeech(metadata.Accessibility)
+		if file.HasTextToSpeech != hasTextToSpeech {
+			file.HasTextToSpeech = hasTextToSpeech
+			fileUpdateOpts.Columns = appendIfMissing(fileUpdateOpts.Columns, "has_text_to_speech")
+		}
+		if accessModes := metadata.Accessibility[mediafile.AccessibilityAccessMode]; !slices.Equal(file.AccessModes, accessModes) {
+			file.AccessModes = accessModes
+			fileUpdateOpts.Columns = appendIfMissing(fileUpdateOpts.Columns, "access_modes")
+		}
+		if features := metadata.Accessibility[mediafile.AccessibilityFeature]; !slices.Equal(file.AccessibilityFeatures, features) {
+			file.AccessibilityFeatures = features
+			fileUpdateOpts.Columns = appendIfMissing(fileUpdateOpts.Columns, "accessibility_features")
+		}
+	}
+
 	// Apply file column updates
 	if len(fileUpdateOpts.Columns) > 0 {
 		if err := w.bookService.UpdateFile(ctx, file, fileUpdateOpts); err != nil {
@@ -1700,19 +2230,20 @@ func (w *Worker) scanFileCore(
 		}
 	}
 
-	// Reorganize file on disk if library has OrganizeFileStructure enabled.
+	// Rename the file on disk if the library has OrganizeFiles enabled.
 	// Only do this during resyncs - during full scans, organization is deferred to post-scan phase.
 	// This handles two cases:
 	// 1. fileNameChanged=true: the file.Name in DB changed, so we need to rename the file on disk
 	// 2. fileNameChanged=false but current filename differs from expected: e.g., stripping
 	//    author prefix from files that still have it (like "[Author] Title.epub" -> "Title.epub")
-	if isResync {
+	// Staged books are never renamed on disk until they're approved.
+	if isResync && !book.Staged {
 		library, err := w.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{
 			ID: &book.LibraryID,
 		})
 		if err != nil {
 			logWarn("failed to retrieve library for file organization", logger.Data{"error": err.Error()})
-		} else if library.OrganizeFileStructure {
+		} else if library.OrganizeFiles {
 			// Don't include author names in filenames - all files are inside the book folder
 			// which already has the author prefix (e.g., "[Author] Book Title/").
 			// Including author in the filename would be redundant.
@@ -1738,13 +2269,16 @@ func (w *Worker) scanFileCore(
 				NarratorNames: narratorNames,
 				Title:         title,
 				FileType:      file.FileType,
+				Sanitize:      fileutils.SanitizeOptions{Mode: w.config.OrganizeFilenameMode},
 			}
 
 			// Rename the file
-			// Use RenameOrganizedFileOnly to avoid renaming the book sidecar.
+			// RenameOrganizedFile avoids renaming the book sidecar.
 			// File-level changes (name) should not affect the book's sidecar -
 			// only book-level changes (title, author) should rename the book sidecar.
-			newPath, err := fileutils.RenameOrganizedFileOnly(file.Filepath, organizeOpts)
+			// When an organizer plugin picks the path instead, it also saves
+			// the new path, so file.Filepath already matches below.
+			newPath, err := w.bookService.RenameOrganizedFile(ctx, library, book, file, organizeOpts)
 			if err != nil {
 				logWarn("failed to rename file after name change", logger.Data{
 					"file_id": file.ID,
@@ -1781,36 +2315,36 @@ func (w *Worker) scanFileCore(
 		if file.NarratorSource != nil {
 			existingNarratorSource = *file.NarratorSource
 		}
-		existingNarratorNames := make([]string, 0, len(file.Narrators))
-		for _, n := range file.Narrators {
-			if n.Person != nil {
-				existingNarratorNames = append(existingNarratorNames, n.Person.Name)
-			}
-		}
+		parsedNarrators := metadata.NarratorsWithRoles()
 
 		narratorSource := metadata.SourceForField("narrators")
-		if shouldUpdateRelationship(metadata.Narrators, existingNarratorNames, narratorSource, existingNarratorSource, forceRefresh) {
-			logInfo("updating narrators", logger.Data{"new_count": len(metadata.Narrators), "old_count": len(file.Narrators)})
+		if decisions.decide("narrators", narratorSource, existingNarratorSource, shouldUpdateRelationship(parsedNarratorKeys(parsedNarrators), narratorKeys(file.Narrators), narratorSource, existingNarratorSource, forceRefresh)) {
+			logInfo("updating narrators", logger.Data{"new_count": len(parsedNarrators), "old_count": len(file.Narrators)})
 
 			// Collect narrators for batch insert (replaces immediate delete + create)
 			relUpdates.DeleteNarrators = true
 			relUpdates.Narrators = nil // Clear any previous collection
-			for i, narratorName := range metadata.Narrators {
+			for i, parsedNarrator := range parsedNarrators {
 				var person *models.Person
 				var err error
 				if cache != nil {
-					person, err = cache.GetOrCreatePerson(ctx, narratorName, book.LibraryID, w.personService)
+					person, err = cache.GetOrCreatePerson(ctx, parsedNarrator.Name, book.LibraryID, w.personService)
 				} else {
-					person, err = w.personService.FindOrCreatePerson(ctx, narratorName, book.LibraryID)
+					person, err = w.personService.FindOrCreatePerson(ctx, parsedNarrator.Name, book.LibraryID)
 				}
 				if err != nil {
-					logWarn("failed to find/create person for narrator", logger.Data{"name": narratorName, "error": err.Error()})
+					logWarn("failed to find/create person for narrator", logger.Data{"name": parsedNarrator.Name, "error": err.Error()})
 					continue
 				}
+				var role *string
+				if parsedNarrator.Role != "" {
+					role = &parsedNarrator.Role
+				}
 				relUpdates.Narrators = append(relUpdates.Narrators, &models.Narrator{
 					FileID:    file.ID,
 					PersonID:  person.ID,
 					SortOrder: i + 1,
+					Role:      role,
 				})
 			}
 
@@ -1823,22 +2357,12 @@ func (w *Worker) scanFileCore(
 	}
 	// Update narrators (from sidecar)
 	if fileSidecarData != nil && len(fileSidecarData.Narrators) > 0 {
-		sidecarNarratorNames := make([]string, 0, len(fileSidecarData.Narrators))
-		for _, n := range fileSidecarData.Narrators {
-			sidecarNarratorNames = append(sidecarNarratorNames, n.Name)
-		}
+		sidecarSource := fileSidecarData.SourceFor("narrators")
 		existingNarratorSource := ""
 		if file.NarratorSource != nil {
 			existingNarratorSource = *file.NarratorSource
 		}
-		existingNarratorNames := make([]string, 0, len(file.Narrators))
-		for _, n := range file.Narrators {
-			if n.Person != nil {
-				existingNarratorNames = append(existingNarratorNames, n.Person.Name)
-			}
-		}
-
-		if shouldApplySidecarRelationship(sidecarNarratorNames, existingNarratorNames, existingNarratorSource, forceRefresh) {
+		if decisions.decide("narrators", sidecarSource, existingNarratorSource, shouldApplySidecarRelationship(sidecarNarratorKeys(fileSidecarData.Narrators), narratorKeys(file.Narrators), sidecarSource, existingNarratorSource, forceRefresh)) {
 			logInfo("updating narrators from sidecar", logger.Data{"new_count": len(fileSidecarData.Narrators), "old_count": len(file.Narrators)})
 
 			// Collect narrators for batch insert (replaces any metadata collection)
@@ -1856,10 +2380,15 @@ func (w *Worker) scanFileCore(
 					logWarn("failed to find/create person for narrator", logger.Data{"name": sidecarNarrator.Name, "error": err.Error()})
 					continue
 				}
+				var role *string
+				if sidecarNarrator.Role != nil && *sidecarNarrator.Role != "" {
+					role = sidecarNarrator.Role
+				}
 				relUpdates.Narrators = append(relUpdates.Narrators, &models.Narrator{
 					FileID:    file.ID,
 					PersonID:  person.ID,
 					SortOrder: i + 1,
+					Role:      role,
 				})
 			}
 
@@ -1887,7 +2416,8 @@ func (w *Worker) scanFileCore(
 		}
 	}
 
-	// Reorganize book directory on disk if title or authors changed and library has OrganizeFileStructure enabled.
+	// Reorganize the book on disk if title or authors changed. organizeBookFiles checks the
+	// library's OrganizeFolders and OrganizeFiles settings to decide what gets renamed.
 	// Only do this during resyncs - during full scans, organization would rename directories while
 	// other files are still being discovered/processed, breaking the scan.
 	// This must run AFTER UpdateBookRelationships so the fresh DB read includes the new authors.
@@ -1924,7 +2454,7 @@ func (w *Worker) scanFileCore(
 		newIdentifierValues := parsedIdentifierKeys(metadata.Identifiers)
 
 		identifierSource := metadata.SourceForField("identifiers")
-		if shouldUpdateRelationship(newIdentifierValues, existingIdentifierValues, identifierSource, existingIdentifierSource, forceRefresh) {
+		if decisions.decide("identifiers", identifierSource, existingIdentifierSource, shouldUpdateRelationship(newIdentifierValues, existingIdentifierValues, identifierSource, existingIdentifierSource, forceRefresh)) {
 			logInfo("updating identifiers", logger.Data{"new_count": len(metadata.Identifiers), "old_count": len(file.Identifiers)})
 
 			// Delete existing identifiers
@@ -1955,6 +2485,7 @@ func (w *Worker) scanFileCore(
 	}
 	// Update identifiers (from sidecar)
 	if fileSidecarData != nil && len(fileSidecarData.Identifiers) > 0 {
+		sidecarSource := fileSidecarData.SourceFor("identifiers")
 		sidecarIdentifierValues := sidecarIdentifierKeys(fileSidecarData.Identifiers)
 		existingIdentifierSource := ""
 		if file.IdentifierSource != nil {
@@ -1962,7 +2493,7 @@ func (w *Worker) scanFileCore(
 		}
 		existingIdentifierValues := fileIdentifierKeys(file.Identifiers)
 
-		if shouldApplySidecarRelationship(sidecarIdentifierValues, existingIdentifierValues, existingIdentifierSource, forceRefresh) {
+		if decisions.decide("identifiers", sidecarSource, existingIdentifierSource, shouldApplySidecarRelationship(sidecarIdentifierValues, existingIdentifierValues, sidecarSource, existingIdentifierSource, forceRefresh)) {
 			logInfo("updating identifiers from sidecar", logger.Data{"new_count": len(fileSidecarData.Identifiers), "old_count": len(file.Identifiers)})
 
 			// Delete existing identifiers
@@ -1996,15 +2527,26 @@ func (w *Worker) scanFileCore(
 	// Update chapters (from metadata)
 	// ==========================================================================
 
-	if len(metadata.Chapters) > 0 {
+	parsedChapters := metadata.Chapters
+	chapterSource := metadata.SourceForField("chapters")
+	// Audiobooks without chapters get evenly spaced ones when configured. They
+	// carry their own source so real chapters replace them on a later scan.
+	if len(parsedChapters) == 0 && w.config.AutoChapterIntervalMin > 0 {
+		interval := time.Duration(w.config.AutoChapterIntervalMin) * time.Minute
+		if generated := chapters.GenerateChapters(metadata.Duration, interval); len(generated) > 0 {
+			parsedChapters = generated
+			chapterSource = models.DataSourceGenerated
+		}
+	}
+
+	if len(parsedChapters) > 0 {
 		existingChapterSource := file.ChapterSource
-		chapterSource := metadata.SourceForField("chapters")
 
-		if chapters.ShouldUpdateChapters(metadata.Chapters, chapterSource, existingChapterSource, forceRefresh) {
-			logInfo("updating chapters", logger.Data{"chapter_count": len(metadata.Chapters)})
+		if chapters.ShouldUpdateChapters(parsedChapters, chapterSource, existingChapterSource, forceRefresh) {
+			logInfo("updating chapters", logger.Data{"chapter_count": len(parsedChapters), "source": chapterSource})
 
 			// Replace all chapters with new ones from metadata
-			if err := w.chapterService.ReplaceChapters(ctx, file.ID, metadata.Chapters); err != nil {
+			if err := w.chapterService.ReplaceChapters(ctx, file.ID, parsedChapters); err != nil {
 				return nil, errors.Wrap(err, "failed to replace chapters")
 			}
 
@@ -2018,6 +2560,7 @@ func (w *Worker) scanFileCore(
 
 	// Update chapters (from sidecar)
 	if fileSidecarData != nil && len(fileSidecarData.Chapters) > 0 {
+		sidecarSource := fileSidecarData.SourceFor("chapters")
 		// Convert sidecar chapters to ParsedChapter format
 		sidecarChapters := convertSidecarChapters(fileSidecarData.Chapters)
 
@@ -2040,6 +2583,7 @@ func (w *Worker) scanFileCore(
 	// Update cover page (from sidecar) for page-based formats (CBZ, PDF).
 	// This restores user-selected cover page from sidecar after library rescans.
 	if fileSidecarData != nil && fileSidecarData.CoverPage != nil && models.IsPageBasedFileType(file.FileType) {
+		sidecarSource := fileSidecarData.SourceFor("cover_page")
 		existingCoverSource := ""
 		if file.CoverSource != nil {
 			existingCoverSource = *file.CoverSource
@@ -2047,7 +2591,7 @@ func (w *Worker) scanFileCore(
 
 		// Check if we should apply sidecar (don't override manual selections)
 		// Sidecar has priority 1, manual has priority 0 (lower = higher priority)
-		sidecarPriority := models.GetDataSourcePriority(models.DataSourceSidecar)
+		sidecarPriority := models.GetDataSourcePriority(sidecarSource)
 		existingPriority := models.GetDataSourcePriority(existingCoverSource)
 		if existingCoverSource == "" {
 			existingPriority = models.GetDataSourcePriority(models.DataSourceFilepath)
@@ -2061,20 +2605,28 @@ func (w *Worker) scanFileCore(
 		if shouldApply && isDifferent {
 			fromPage := file.CoverPage
 			page := *fileSidecarData.CoverPage
-			extractErr, updateErr := w.applyPageCover(ctx, file, book, page, sidecarSource)
 			switch {
-			case extractErr != nil:
-				logWarn("failed to extract cover page from sidecar", logger.Data{
-					"error":      extractErr.Error(),
+			case page < 1 || (file.PageCount != nil && page > *file.PageCount):
+				logWarn("sidecar cover page is out of range, skipping", logger.Data{
 					"cover_page": page,
+					"page_count": file.PageCount,
 				})
-			case updateErr != nil:
-				return nil, errors.Wrap(updateErr, "failed to update cover page from sidecar")
 			default:
-				logInfo("updating cover page from sidecar", logger.Data{
-					"from_page": fromPage,
-					"to_page":   page,
-				})
+				extractErr, updateErr := w.applyPageCover(ctx, file, book, page, sidecarSource)
+				switch {
+				case extractErr != nil:
+					logWarn("failed to extract cover page from sidecar", logger.Data{
+						"error":      extractErr.Error(),
+						"cover_page": page,
+					})
+				case updateErr != nil:
+					return nil, errors.Wrap(updateErr, "failed to update cover page from sidecar")
+				default:
+					logInfo("updating cover page from sidecar", logger.Data{
+						"from_page": fromPage,
+						"to_page":   page,
+					})
+				}
 			}
 		}
 	}
@@ -2082,7 +2634,8 @@ func (w *Worker) scanFileCore(
 	// Update cover page (from plugin-supplied metadata) for page-based formats.
 	// Plugin enrichers (and plugin fileParsers) can identify a cover page that
 	// isn't the file parser's default (typically page 0); apply their value
-	// when the source priority allows.
+	// when the source priority allows. Plugins use 0-indexed pages, so the
+	// value is converted to the stored 1-indexed form before applying.
 	//
 	// This branch only fires for plugin-sourced CoverPage. The file parser's
 	// own default (cbz_metadata / pdf_metadata) is already written by
@@ -2105,7 +2658,7 @@ func (w *Worker) scanFileCore(
 		}
 
 		shouldApply := metadataPriority <= existingPriority
-		isDifferent := file.CoverPage == nil || *file.CoverPage != *metadata.CoverPage
+		isDifferent := file.CoverPage == nil || *file.CoverPage != *metadata.CoverPage+1
 
 		if shouldApply && isDifferent {
 			page := *metadata.CoverPage
@@ -2130,7 +2683,7 @@ func (w *Worker) scanFileCore(
 				})
 			default:
 				fromPage := file.CoverPage
-				extractErr, updateErr := w.applyPageCover(ctx, file, book, page, metadataCoverSource)
+				extractErr, updateErr := w.applyPageCover(ctx, file, book, page+1, metadataCoverSource)
 				switch {
 				case extractErr != nil:
 					logWarn("failed to extract cover page from metadata", logger.Data{
@@ -2155,15 +2708,18 @@ func (w *Worker) scanFileCore(
 	// Write sidecar files
 	// ==========================================================================
 
-	// Reload book and file with full relations before writing sidecars
+	// Reload book and file with full relations before writing sidecars.
+	// Staged books don't get sidecars until they're approved.
 	reloadedBook, err := w.bookService.RetrieveBook(ctx, books.RetrieveBookOptions{ID: &book.ID})
 	if err != nil {
 		logWarn("failed to reload book for sidecar", logger.Data{"error": err.Error()})
+	} else if reloadedBook.Staged {
+		book = reloadedBook
 	} else {
-		// For root-level files with OrganizeFileStructure enabled, pre-create
-		// the synthetic organized folder so the soon-to-run organize step can
+		// For root-level files with OrganizeFolders enabled, pre-create the
+		// synthetic organized folder so the soon-to-run organize step can
 		// move the file into it and the book sidecar lands at the final path.
-		// When OrganizeFileStructure is disabled, WriteBookSidecarFromModel's
+		// When OrganizeFolders is disabled, WriteBookSidecarFromModel's
 		// fallback anchors the sidecar next to the file, so no MkdirAll here.
 		// If MkdirAll fails (permissions, etc.) we still attempt the write —
 		// the fallback will anchor next to the file so metadata isn't silently
@@ -2174,25 +2730,40 @@ func (w *Worker) scanFileCore(
 			})
 			if libErr != nil {
 				logWarn("failed to retrieve library for sidecar", logger.Data{"error": libErr.Error()})
-			} else if lib.OrganizeFileStructure {
+			} else if lib.OrganizeFolders {
 				if mkdirErr := os.MkdirAll(reloadedBook.Filepath, 0755); mkdirErr != nil {
 					logWarn("failed to create book directory for sidecar", logger.Data{"error": mkdirErr.Error()})
 				}
 			}
 		}
 
-		if err := sidecar.WriteBookSidecarFromModel(reloadedBook); err != nil {
+		if bookSidecarInvalid {
+			logInfo("not rewriting invalid book sidecar", logger.Data{"book_id": reloadedBook.ID})
+		} else if err := sidecar.WriteBookSidecarFromModel(reloadedBook); err != nil {
 			logWarn("failed to write book sidecar", logger.Data{"error": err.Error()})
 		}
 		book = reloadedBook
 	}
 
+	// Keep the stored metadata hash in step with whatever the scan changed.
+	if reloadedBook != nil && isMainFile {
+		if err := w.bookService.RefreshMetadataHash(ctx, reloadedBook); err != nil {
+			logWarn("failed to update book metadata hash", logger.Data{"error": err.Error()})
+		}
+	}
+
 	reloadedFile, err := w.bookService.RetrieveFileWithRelations(ctx, file.ID)
 	if err != nil {
 		logWarn("failed to reload file for sidecar", logger.Data{"error": err.Error()})
 	} else {
-		if err := sidecar.WriteFileSidecarFromModel(reloadedFile); err != nil {
-			logWarn("failed to write file sidecar", logger.Data{"error": err.Error()})
+		switch {
+		case book.Staged:
+		case fileSidecarInvalid:
+			logInfo("not rewriting invalid file sidecar", logger.Data{"file_id": reloadedFile.ID})
+		default:
+			if err := sidecar.WriteFileSidecarFromModel(reloadedFile); err != nil {
+				logWarn("failed to write file sidecar", logger.Data{"error": err.Error()})
+			}
 		}
 		file = reloadedFile
 	}
@@ -2240,7 +2811,49 @@ func (w *Worker) scanFileCore(
 	// mutation will correct it).
 	w.bookService.RecomputeReviewedForFile(ctx, file.ID)
 
-	return &ScanResult{File: file, Book: book, FileCreated: false}, nil
+	w.indexFileContent(ctx, file, jobLog)
+
+	decisions.emit(log, file.ID)
+
+	return &ScanResult{
+		File:        file,
+		Book:        book,
+		FileCreated: false,
+		// Relationship rows are replaced without touching updated_at.
+		FileUpdated: relUpdates.DeleteNarrators,
+		BookUpdated: relUpdates.DeleteAuthors || relUpdates.DeleteSeries || relUpdates.DeleteGenres || relUpdates.DeleteTags,
+		Warnings:    warnings,
+	}, nil
+}
+
+// sidecarErrorData returns the log fields for a sidecar read error, naming
+// the sidecar and the specific problem when it failed validation.
+func sidecarErrorData(err error) logger.Data {
+	var validationErr *sidecar.ValidationError
+	if errors.As(err, &validationErr) {
+		return logger.Data{"sidecar": validationErr.Path, "problem": validationErr.Problem}
+	}
+	return logger.Data{"error": err.Error()}
+}
+
+// isSidecarValidationError reports whether err is a sidecar that failed
+// validation, as opposed to one that couldn't be read at all.
+func isSidecarValidationError(err error) bool {
+	var validationErr *sidecar.ValidationError
+	return errors.As(err, &validationErr)
+}
+
+// appendSidecarWarning records a sidecar that failed validation as a scan
+// warning. Other read errors (e.g. permissions) are only logged.
+func appendSidecarWarning(warnings []ScanWarning, err error) []ScanWarning {
+	var validationErr *sidecar.ValidationError
+	if !errors.As(err, &validationErr) {
+		return warnings
+	}
+	return append(warnings, ScanWarning{
+		Path:    validationErr.Path,
+		Message: "sidecar ignored: " + validationErr.Problem,
+	})
 }
 
 // scanFileCreateNew creates a new file and book record for a file that exists on disk
@@ -2280,12 +2893,6 @@ func (w *Worker) scanFileCreateNew(ctx context.Context, opts ScanOptions, cache
 	modTime := stats.ModTime()
 	fileType := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
 
-	// Parse metadata from file
-	metadata, err := w.parseFileMetadata(ctx, path, fileType)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to parse file metadata")
-	}
-
 	// Get library to determine book path and check for root-level files
 	library, err := w.libraryService.RetrieveLibrary(ctx, libraries.RetrieveLibraryOptions{
 		ID: &opts.LibraryID,
@@ -2294,6 +2901,21 @@ func (w *Worker) scanFileCreateNew(ctx context.Context, opts ScanOptions, cache
 		return nil, errors.Wrap(err, "failed to retrieve library")
 	}
 
+	// Libraries can be limited to certain file types. The scan walk already
+	// filters these, but paths also arrive from the monitor, converters and
+	// URL imports.
+	if !library.AllowsFileType(fileType) {
+		logInfo("skipping file type not allowed in library", logger.Data{"path": path, "file_type": fileType})
+		return nil, nil
+	}
+
+	// Parse metadata from file
+	metadata, err := w.parseFileMetadata(ctx, path, fileType)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse file metadata")
+	}
+	splitParsedNames(metadata, library.NameSeparators)
+
 	// Determine if this is a root-level file (directly in library path)
 	tempBookPath := filepath.Dir(path)
 	isRootLevelFile := false
@@ -2312,8 +2934,10 @@ func (w *Worker) scanFileCreateNew(ctx context.Context, opts ScanOptions, cache
 	fpBookPath := tempBookPath
 	if isRootLevelFile {
 		fpBookPath = path
+	} else if library.InferSeriesFromParentDir {
+		applyParentDirSeries(metadata, tempBookPath, library.LibraryPaths)
 	}
-	applyFilepathFallbacks(metadata, path, fpBookPath, fileType, isRootLevelFile)
+	applyFilepathFallbacks(metadata, path, fpBookPath, fileType, isRootLevelFile, libraryFilenamePatterns(library, logWarn), library.NameSeparators)
 
 	// Determine book path
 	var bookPath string
@@ -2326,10 +2950,16 @@ func (w *Worker) scanFileCreateNew(ctx context.Context, opts ScanOptions, cache
 		for _, author := range metadata.Authors {
 			authorNames = append(authorNames, author.Name)
 		}
+		var subfolder string
+		if library.OrganizeFolders {
+			subfolder = library.OrganizeSubfolder(fileType)
+		}
 		organizedFolderName := fileutils.GenerateOrganizedFolderName(fileutils.OrganizedNameOptions{
 			AuthorNames: authorNames,
 			Title:       title,
 			FileType:    fileType,
+			Subfolder:   subfolder,
+			Sanitize:    fileutils.SanitizeOptions{Mode: w.config.OrganizeFilenameMode},
 		})
 		bookPath = filepath.Join(containingLibraryPath, organizedFolderName)
 	} else {
@@ -2353,11 +2983,24 @@ func (w *Worker) scanFileCreateNew(ctx context.Context, opts ScanOptions, cache
 		return nil, errors.Wrap(err, "failed to check for existing book")
 	}
 
+	// With identical-file dedupe on, a file that would start a new book is
+	// first checked against identical files already in this library. The
+	// hash is kept so it doesn't have to be recomputed by the hash
+	// generation job.
+	var contentHash string
+	var dedupedBook *models.Book
+	if existingBook == nil && w.config.DedupeIdenticalFiles {
+		contentHash, dedupedBook = w.findIdenticalFile(ctx, path, opts.LibraryID, logWarn)
+	}
+
 	// Create or reuse book
 	var book *models.Book
 	if existingBook != nil {
 		logInfo("using existing book for new file", logger.Data{"book_id": existingBook.ID, "path": path})
 		book = existingBook
+	} else if dedupedBook != nil {
+		logInfo("linking identical file to existing book", logger.Data{"book_id": dedupedBook.ID, "path": path})
+		book = dedupedBook
 	} else {
 		// Derive initial title from filepath or metadata
 		title := deriveInitialTitle(path, isRootLevelFile, metadata)
@@ -2375,6 +3018,7 @@ func (w *Worker) scanFileCreateNew(ctx context.Context, opts ScanOptions, cache
 			SortTitle:       sortname.ForTitle(title),
 			SortTitleSource: titleSource,
 			AuthorSource:    models.DataSourceFilepath,
+			Staged:          library.Staging,
 		}
 		if err := w.bookService.CreateBook(ctx, book); err != nil {
 			return nil, errors.Wrap(err, "failed to create book")
@@ -2425,6 +3069,10 @@ func (w *Worker) scanFileCreateNew(ctx context.Context, opts ScanOptions, cache
 	var coverPage *int
 
 	if !classifyAsSupplement {
+		if fileType == models.FileTypeCBZ {
+			applyLibraryCBZCoverPage(path, library, metadata, logWarn)
+			adoptExternalCover(path, bookPath, isRootLevelFile, library, metadata, logWarn)
+		}
 		coverFilename, extractedMimeType, wasPreExisting, err := w.extractAndSaveCover(ctx, path, bookPath, isRootLevelFile, metadata, opts.JobLog)
 		if err != nil {
 			logWarn("failed to extract cover", logger.Data{"error": err.Error()})
@@ -2442,15 +3090,17 @@ func (w *Worker) scanFileCreateNew(ctx context.Context, opts ScanOptions, cache
 			}
 		}
 		if metadata != nil && metadata.CoverPage != nil {
-			coverPage = metadata.CoverPage
+			coverPage = models.CoverPageFromIndex(metadata.CoverPage)
 		}
 	}
 
 	// Create file record
 	logInfo("creating file", logger.Data{"path": path, "filesize": size, "is_supplement": classifyAsSupplement})
 	fileRole := models.FileRoleMain
+	var supplementKind *string
 	if classifyAsSupplement {
 		fileRole = models.FileRoleSupplement
+		supplementKind = w.supplementKind(fileType)
 	}
 	file := &models.File{
 		LibraryID:          opts.LibraryID,
@@ -2458,6 +3108,7 @@ func (w *Worker) scanFileCreateNew(ctx context.Context, opts ScanOptions, cache
 		Filepath:           path,
 		FileType:           fileType,
 		FileRole:           fileRole,
+		SupplementKind:     supplementKind,
 		FilesizeBytes:      size,
 		FileModifiedAt:     &modTime,
 		CoverImageFilename: coverImagePath,
@@ -2478,14 +3129,37 @@ func (w *Worker) scanFileCreateNew(ctx context.Context, opts ScanOptions, cache
 		if metadata.Codec != "" {
 			file.AudiobookCodec = &metadata.Codec
 		}
+		if metadata.SampleRateHz > 0 {
+			file.AudiobookSampleRate = &metadata.SampleRateHz
+		}
+		if metadata.Channels > 0 {
+			file.AudiobookChannels = &metadata.Channels
+		}
 		if metadata.PageCount != nil {
 			file.PageCount = metadata.PageCount
 		}
+		if metadata.WordCount != nil {
+			file.WordCount = metadata.WordCount
+		}
+		file.ModifiedDate = metadata.ModifiedDate
+		if metadata.ReadingDirection != "" {
+			file.ReadingDirection = &metadata.ReadingDirection
+		}
+		if metadata.Accessibility != nil {
+			file.HasTextToSpeech = mediafile.SupportsTextToSpeech(metadata.Accessibility)
+			file.AccessModes = metadata.Accessibility[mediafile.AccessibilityAccessMode]
+			file.AccessibilityFeatures = metadata.Accessibility[mediafile.AccessibilityFeature]
+		}
 	}
 
 	if err := w.bookService.CreateFile(ctx, file); err != nil {
 		return nil, errors.Wrap(err, "failed to create file")
 	}
+	if contentHash != "" {
+		if err := w.fingerprintService.Insert(ctx, file.ID, models.FingerprintAlgorithmSHA256, contentHash); err != nil {
+			logWarn("failed to store file fingerprint", logger.Data{"file_id": file.ID, "error": err.Error()})
+		}
+	}
 	// Narrators from filepath are already populated on metadata by
 	// applyFilepathFallbacks above. scanFileCore will create the DB records.
 
@@ -2518,6 +3192,7 @@ func (w *Worker) scanFileCreateNew(ctx context.Context, opts ScanOptions, cache
 
 	// Mark as file created
 	result.FileCreated = true
+	result.BookCreated = existingBook == nil && dedupedBook == nil
 
 	// Discover and create supplement files
 	w.discoverAndCreateSupplements(ctx, book, path, isRootLevelFile, opts.LibraryID, library, opts.JobLog)
@@ -2525,6 +3200,13 @@ func (w *Worker) scanFileCreateNew(ctx context.Context, opts ScanOptions, cache
 	return result, nil
 }
 
+// supplementKind returns the configured kind for a supplement with the given
+// file type (extension without the dot).
+func (w *Worker) supplementKind(fileType string) *string {
+	kind := w.config.SupplementKind(fileType)
+	return &kind
+}
+
 // discoverAndCreateSupplements finds and creates supplement files for a book.
 // This is called after creating a new book/file to add any supplements in the same directory.
 func (w *Worker) discoverAndCreateSupplements(
@@ -2557,7 +3239,7 @@ func (w *Worker) discoverAndCreateSupplements(
 	if !isRootLevelFile {
 		// Directory-based book: scan directory for supplements
 		bookPath := book.Filepath
-		supplements, err := discoverSupplements(bookPath, w.config.SupplementExcludePatterns)
+		supplements, err := discoverSupplements(bookPath, library, w.config.SupplementExcludePatterns)
 		if err != nil {
 			logWarn("failed to discover supplements", logger.Data{"error": err.Error()})
 			return
@@ -2585,12 +3267,13 @@ func (w *Worker) discoverAndCreateSupplements(
 
 			suppExt := strings.TrimPrefix(strings.ToLower(filepath.Ext(suppPath)), ".")
 			suppFile := &models.File{
-				LibraryID:     libraryID,
-				BookID:        book.ID,
-				Filepath:      suppPath,
-				FileType:      suppExt,
-				FileRole:      models.FileRoleSupplement,
-				FilesizeBytes: suppStat.Size(),
+				LibraryID:      libraryID,
+				BookID:         book.ID,
+				Filepath:       suppPath,
+				FileType:       suppExt,
+				FileRole:       models.FileRoleSupplement,
+				SupplementKind: w.supplementKind(suppExt),
+				FilesizeBytes:  suppStat.Size(),
 			}
 
 			if err := w.bookService.CreateFile(ctx, suppFile); err != nil {
@@ -2603,7 +3286,7 @@ func (w *Worker) discoverAndCreateSupplements(
 		// Root-level book: find supplements by basename matching
 		for _, libraryPath := range library.LibraryPaths {
 			if filepath.Dir(mainFilePath) == libraryPath.Filepath {
-				supplements, err := discoverRootLevelSupplements(mainFilePath, libraryPath.Filepath, w.config.SupplementExcludePatterns)
+				supplements, err := discoverRootLevelSupplements(mainFilePath, libraryPath.Filepath, library, w.config.SupplementExcludePatterns)
 				if err != nil {
 					logWarn("failed to discover root supplements", logger.Data{"error": err.Error()})
 					break
@@ -2627,12 +3310,13 @@ func (w *Worker) discoverAndCreateSupplements(
 
 					suppExt := strings.TrimPrefix(strings.ToLower(filepath.Ext(suppPath)), ".")
 					suppFile := &models.File{
-						LibraryID:     libraryID,
-						BookID:        book.ID,
-						Filepath:      suppPath,
-						FileType:      suppExt,
-						FileRole:      models.FileRoleSupplement,
-						FilesizeBytes: suppStat.Size(),
+						LibraryID:      libraryID,
+						BookID:         book.ID,
+						Filepath:       suppPath,
+						FileType:       suppExt,
+						FileRole:       models.FileRoleSupplement,
+						SupplementKind: w.supplementKind(suppExt),
+						FilesizeBytes:  suppStat.Size(),
 					}
 
 					if err := w.bookService.CreateFile(ctx, suppFile); err != nil {
@@ -2753,11 +3437,37 @@ func removeBookSidecar(book *models.Book, logWarn func(msg string, data logger.D
 	}
 }
 
+// sidecarBookTitle returns the title to apply from a book sidecar and the raw
+// title to keep with it. A sidecar written after a scan stripped the series
+// from the title records the raw title as well, and that's used instead so
+// the library's current StripSeriesFromTitle setting decides again. Titles
+// pinned to another source, like manual edits, are returned as they are.
+func sidecarBookTitle(s *sidecar.BookSidecar, source string, stripSeries bool) (string, *string) {
+	if source != models.DataSourceSidecar {
+		return s.Title, nil
+	}
+	title := s.Title
+	if s.RawTitle != nil {
+		if _, _, rest, ok := fileutils.SplitSeriesPrefix(*s.RawTitle); ok && rest == s.Title {
+			title = *s.RawTitle
+		}
+	}
+	if stripSeries {
+		if _, _, rest, ok := fileutils.SplitSeriesPrefix(title); ok {
+			return rest, &title
+		}
+	}
+	return title, nil
+}
+
 // applyFilepathFallbacks populates empty metadata fields from the filepath.
 // This fills in title, authors, narrators, and series using the same logic
 // that scanFileCreateNew uses when creating a book for the first time.
-// Fields already present in metadata are not overwritten.
-func applyFilepathFallbacks(metadata *mediafile.ParsedMetadata, filePath, bookPath, fileType string, isRootLevelFile bool) {
+// Fields already present in metadata are not overwritten. The library's
+// custom filename patterns are tried before the built-in conventions, and
+// author and narrator lists are split on nameSeparators (the defaults when
+// empty).
+func applyFilepathFallbacks(metadata *mediafile.ParsedMetadata, filePath, bookPath, fileType string, isRootLevelFile bool, patterns fileutils.FilenamePatterns, nameSeparators []string) {
 	if metadata == nil {
 		return
 	}
@@ -2769,17 +3479,27 @@ func applyFilepathFallbacks(metadata *mediafile.ParsedMetadata, filePath, bookPa
 		metadata.FieldDataSources[field] = models.DataSourceFilepath
 	}
 
+	// The name custom patterns match the title and series against: the
+	// filename for root-level files, otherwise the book directory's name.
+	patternSource := filepath.Base(bookPath)
+	if isRootLevelFile {
+		patternSource = strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	}
+
 	// Title fallback
 	if strings.TrimSpace(metadata.Title) == "" {
-		// Pass nil metadata so deriveInitialTitle uses filepath only (we already confirmed Title is empty)
-		derivedTitle := deriveInitialTitle(filePath, isRootLevelFile, nil)
-		metadata.Title = derivedTitle
+		if title := patterns.Find(patternSource, fileutils.PatternGroupTitle); title != "" {
+			metadata.Title = title
+		} else {
+			// Pass nil metadata so deriveInitialTitle uses filepath only (we already confirmed Title is empty)
+			metadata.Title = deriveInitialTitle(filePath, isRootLevelFile, nil)
+		}
 		setSource("title")
 	}
 
 	// Authors fallback
 	if len(metadata.Authors) == 0 {
-		filepathAuthors := extractAuthorsFromFilepath(bookPath, isRootLevelFile)
+		filepathAuthors := extractAuthorsFromFilepath(bookPath, isRootLevelFile, patterns, nameSeparators)
 		for _, name := range filepathAuthors {
 			metadata.Authors = append(metadata.Authors, mediafile.ParsedAuthor{Name: name})
 		}
@@ -2790,17 +3510,22 @@ func applyFilepathFallbacks(metadata *mediafile.ParsedMetadata, filePath, bookPa
 
 	// Narrators fallback
 	if len(metadata.Narrators) == 0 {
-		filepathNarrators := extractNarratorsFromFilepath(filePath, bookPath, isRootLevelFile)
+		filepathNarrators := extractNarratorsFromFilepath(filePath, bookPath, isRootLevelFile, patterns, nameSeparators)
 		metadata.Narrators = append(metadata.Narrators, filepathNarrators...)
 		if len(metadata.Narrators) > 0 {
 			setSource("narrators")
 		}
 	}
 
-	// Series fallback from title (e.g., "My Series v3" → series="My Series", number=3)
+	// Series fallback from title (e.g., "My Series v3" → series="My Series", number=3).
+	// A custom pattern with a series group is matched against the filename
+	// first, since a pattern's title group may have left the series out.
 	if metadata.Series == "" {
 		title := metadata.Title
-		if seriesName, seriesNumber, unit, ok := fileutils.ExtractSeriesFromTitle(title, fileType); ok {
+		if patterns.Match(patternSource, fileutils.PatternGroupSeries) != nil {
+			title = patternSource
+		}
+		if seriesName, seriesNumber, unit, ok := fileutils.ExtractSeriesFromTitle(title, fileType, patterns); ok {
 			metadata.Series = seriesName
 			metadata.SeriesNumber = seriesNumber
 			if unit != "" && metadata.SeriesNumberUnit == nil {
@@ -2812,10 +3537,118 @@ func applyFilepathFallbacks(metadata *mediafile.ParsedMetadata, filePath, bookPa
 	}
 }
 
-// extractAuthorsFromFilepath extracts author names from a filepath using the [Author Name] pattern.
+// applyParentDirSeries infers the series from a "Series Name/01 - Title/"
+// layout. It only applies when the book directory looks like a numbered
+// volume and its parent is inside (not equal to) one of the library paths.
+// The leading number becomes the series number, and the rest of the
+// directory name is used as the title if the file didn't provide one.
+// Fields already present in metadata are not overwritten. Callers check the
+// library's InferSeriesFromParentDir setting before calling this.
+func applyParentDirSeries(metadata *mediafile.ParsedMetadata, bookPath string, libraryPaths []*models.LibraryPath) {
+	if metadata == nil || metadata.Series != "" {
+		return
+	}
+
+	matches := volumeDirRE.FindStringSubmatch(filepath.Base(bookPath))
+	if matches == nil {
+		return
+	}
+
+	seriesDir := filepath.Dir(bookPath)
+	insideLibrary := false
+	for _, libraryPath := range libraryPaths {
+		root := filepath.Clean(libraryPath.Filepath)
+		if strings.HasPrefix(seriesDir, root+string(filepath.Separator)) {
+			insideLibrary = true
+			break
+		}
+	}
+	if !insideLibrary {
+		return
+	}
+
+	stripPatterns := func(s string) string {
+		return strings.TrimSpace(filepathNarratorRE.ReplaceAllString(filepathAuthorRE.ReplaceAllString(s, ""), ""))
+	}
+
+	seriesName := stripPatterns(filepath.Base(seriesDir))
+	if seriesName == "" {
+		return
+	}
+	seriesNumber, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return
+	}
+
+	if metadata.FieldDataSources == nil {
+		metadata.FieldDataSources = make(map[string]string)
+	}
+	metadata.Series = seriesName
+	metadata.SeriesNumber = &seriesNumber
+	metadata.FieldDataSources["series"] = models.DataSourceFilepath
+
+	if strings.TrimSpace(metadata.Title) == "" {
+		if title := stripPatterns(matches[2]); title != "" {
+			metadata.Title = title
+			metadata.FieldDataSources["title"] = models.DataSourceFilepath
+		}
+	}
+}
+
+// libraryFilenamePatterns compiles the library's custom filename patterns.
+// They're validated when saved, so a pattern that fails to compile here is
+// only logged, and the built-in conventions are used alone.
+func libraryFilenamePatterns(library *models.Library, logWarn func(string, logger.Data)) fileutils.FilenamePatterns {
+	if len(library.FilenamePatterns) == 0 {
+		return nil
+	}
+	patterns, err := fileutils.CompileFilenamePatterns(library.FilenamePatterns)
+	if err != nil {
+		logWarn("ignoring invalid filename patterns", logger.Data{"library_id": library.ID, "error": err.Error()})
+		return nil
+	}
+	return patterns
+}
+
+// splitParsedNames splits the author and narrator names parsed from a file's
+// embedded metadata on the library's name separators, so "A / B" becomes two
+// people. Parsers already split on the format's own conventions, so nothing
+// changes for libraries without custom separators. Split names keep their
+// role.
+func splitParsedNames(metadata *mediafile.ParsedMetadata, nameSeparators []string) {
+	if metadata == nil || len(nameSeparators) == 0 {
+		return
+	}
+	if len(metadata.Authors) > 0 {
+		authors := make([]mediafile.ParsedAuthor, 0, len(metadata.Authors))
+		for _, author := range metadata.Authors {
+			for _, name := range fileutils.SplitNames(author.Name, nameSeparators...) {
+				authors = append(authors, mediafile.ParsedAuthor{Name: name, Role: author.Role})
+			}
+		}
+		metadata.Authors = authors
+	}
+	if len(metadata.Narrators) > 0 {
+		narrators := make([]string, 0, len(metadata.Narrators))
+		roles := make([]mediafile.ParsedNarrator, 0, len(metadata.Narrators))
+		for _, narrator := range metadata.NarratorsWithRoles() {
+			for _, name := range fileutils.SplitNames(narrator.Name, nameSeparators...) {
+				narrators = append(narrators, name)
+				roles = append(roles, mediafile.ParsedNarrator{Name: name, Role: narrator.Role})
+			}
+		}
+		metadata.Narrators = narrators
+		if len(metadata.NarratorRoles) > 0 {
+			metadata.NarratorRoles = roles
+		}
+	}
+}
+
+// extractAuthorsFromFilepath extracts author names from a filepath using the
+// library's custom patterns, falling back to the [Author Name] pattern.
 // For directory-based books, looks in the directory name.
 // For root-level files, looks in the filename.
-func extractAuthorsFromFilepath(bookPath string, isRootLevelFile bool) []string {
+func extractAuthorsFromFilepath(bookPath string, isRootLevelFile bool, patterns fileutils.FilenamePatterns, nameSeparators []string) []string {
 	var source string
 	if isRootLevelFile {
 		// For root-level files, the bookPath is the file path itself
@@ -2825,6 +3658,10 @@ func extractAuthorsFromFilepath(bookPath string, isRootLevelFile bool) []string
 		source = filepath.Base(bookPath)
 	}
 
+	if author := patterns.Find(source, fileutils.PatternGroupAuthor); author != "" {
+		return fileutils.SplitNames(author, nameSeparators...)
+	}
+
 	// Find [Author Name] pattern
 	if !filepathAuthorRE.MatchString(source) {
 		return nil
@@ -2838,28 +3675,67 @@ func extractAuthorsFromFilepath(bookPath string, isRootLevelFile bool) []string
 
 	// matches[0][1] is the first capture group (author name without brackets)
 	// Split on common separators to handle multiple authors
-	return fileutils.SplitNames(matches[0][1])
+	return fileutils.SplitNames(matches[0][1], nameSeparators...)
+}
+
+// appendFilepathAuthors returns authors followed by any filepath author names
+// it doesn't already contain, for the "append" author merge strategy. Names
+// are compared case-insensitively after collapsing whitespace.
+func appendFilepathAuthors(authors []mediafile.ParsedAuthor, filepathNames []string) []mediafile.ParsedAuthor {
+	if len(filepathNames) == 0 {
+		return authors
+	}
+	seen := make(map[string]struct{}, len(authors)+len(filepathNames))
+	merged := make([]mediafile.ParsedAuthor, 0, len(authors)+len(filepathNames))
+	for _, a := range authors {
+		seen[strings.ToLower(aliases.NormalizeName(a.Name))] = struct{}{}
+		merged = append(merged, a)
+	}
+	for _, name := range filepathNames {
+		key := strings.ToLower(aliases.NormalizeName(name))
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		merged = append(merged, mediafile.ParsedAuthor{Name: name})
+	}
+	return merged
 }
 
-// extractNarratorsFromFilepath extracts narrator names from a filepath using the {Narrator Name} pattern.
+// extractNarratorsFromFilepath extracts narrator names from a filepath using
+// the library's custom patterns, falling back to the {Narrator Name} pattern.
 // Checks both the directory name and the actual filename, preferring the filename.
-func extractNarratorsFromFilepath(filePath, bookPath string, isRootLevelFile bool) []string {
-	// First check the actual filename (without extension)
+func extractNarratorsFromFilepath(filePath, bookPath string, isRootLevelFile bool, patterns fileutils.FilenamePatterns, nameSeparators []string) []string {
 	actualFilename := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	dirName := ""
+	if !isRootLevelFile {
+		dirName = filepath.Base(bookPath)
+	}
+
+	// Custom patterns win over the built-in convention, filename first
+	for _, name := range []string{actualFilename, dirName} {
+		if narrator := patterns.Find(name, fileutils.PatternGroupNarrator); narrator != "" {
+			return fileutils.SplitNames(narrator, nameSeparators...)
+		}
+	}
+
+	// First check the actual filename (without extension)
 	if filepathNarratorRE.MatchString(actualFilename) {
 		matches := filepathNarratorRE.FindAllStringSubmatch(actualFilename, -1)
 		if len(matches) > 0 && len(matches[0]) > 1 {
-			return fileutils.SplitNames(matches[0][1])
+			return fileutils.SplitNames(matches[0][1], nameSeparators...)
 		}
 	}
 
 	// Fall back to directory name (only for directory-based books)
 	if !isRootLevelFile {
-		dirName := filepath.Base(bookPath)
 		if filepathNarratorRE.MatchString(dirName) {
 			matches := filepathNarratorRE.FindAllStringSubmatch(dirName, -1)
 			if len(matches) > 0 && len(matches[0]) > 1 {
-				return fileutils.SplitNames(matches[0][1])
+				return fileutils.SplitNames(matches[0][1], nameSeparators...)
 			}
 		}
 	}
@@ -2867,6 +3743,67 @@ func extractNarratorsFromFilepath(filePath, bookPath string, isRootLevelFile boo
 	return nil
 }
 
+// applyLibraryCBZCoverPage swaps a new CBZ's cover for the library's default
+// cover page (Library.CBZCoverPageDefault, 1-indexed) when the parser fell
+// back to the first page. A cover page picked by ComicInfo.xml is kept, as are
+// books too short to have the default page.
+func applyLibraryCBZCoverPage(path string, library *models.Library, metadata *mediafile.ParsedMetadata, logWarn func(string, logger.Data)) {
+	if metadata == nil || library.CBZCoverPageDefault <= 1 {
+		return
+	}
+	if metadata.CoverPage == nil || *metadata.CoverPage != 0 {
+		return
+	}
+	pageIndex := library.CBZCoverPageDefault - 1
+	if metadata.PageCount == nil || pageIndex >= *metadata.PageCount {
+		return
+	}
+
+	data, ext, err := readCBZPage(path, pageIndex)
+	if err != nil {
+		logWarn("failed to read default cover page", logger.Data{"path": path, "page": library.CBZCoverPageDefault, "error": err.Error()})
+		return
+	}
+	metadata.CoverData = data
+	metadata.CoverMimeType = fileutils.MimeTypeFromExtension(ext)
+	metadata.CoverPage = &pageIndex
+}
+
+// adoptExternalCover copies a cover image from a CBZ's book folder (see
+// fileutils.FindExternalCover) to <file>.cover.<ext> when the library prefers
+// external covers, so extractAndSaveCover picks it up as an existing cover
+// instead of saving the one from the archive. The cover then isn't one of the
+// pages, so metadata's cover page is cleared. Files at the root of a library
+// path are skipped, since a cover there isn't specific to one book, as are
+// files that already have a cover on disk.
+func adoptExternalCover(path, bookPath string, isRootLevelFile bool, library *models.Library, metadata *mediafile.ParsedMetadata, logWarn func(string, logger.Data)) {
+	if !library.PreferExternalCover || isRootLevelFile {
+		return
+	}
+	coverBaseName := filepath.Base(path) + ".cover"
+	if fileutils.CoverExistsWithBaseName(bookPath, coverBaseName) != "" {
+		return
+	}
+	externalPath := fileutils.FindExternalCover(bookPath)
+	if externalPath == "" {
+		return
+	}
+
+	data, err := os.ReadFile(externalPath)
+	if err != nil {
+		logWarn("failed to read external cover", logger.Data{"path": externalPath, "error": err.Error()})
+		return
+	}
+	ext := strings.ToLower(filepath.Ext(externalPath))
+	if err := os.WriteFile(filepath.Join(bookPath, coverBaseName+ext), data, 0644); err != nil {
+		logWarn("failed to copy external cover", logger.Data{"path": externalPath, "error": err.Error()})
+		return
+	}
+	if metadata != nil {
+		metadata.CoverPage = nil
+	}
+}
+
 // extractAndSaveCover extracts cover data from metadata and saves it to disk.
 // Returns the cover filename, mime type, whether it was pre-existing, and any error.
 func (w *Worker) extractAndSaveCover(
@@ -2903,6 +3840,17 @@ func (w *Worker) extractAndSaveCover(
 	if existingCoverPath != "" {
 		logInfo("cover already exists, using existing", logger.Data{"path": existingCoverPath})
 		existingMime := fileutils.MimeTypeFromExtension(filepath.Ext(existingCoverPath))
+		// The existing cover wins over the embedded one, which is set aside
+		// so it can be restored later.
+		if w.config.KeepOriginalCover && metadata != nil && len(metadata.CoverData) > 0 &&
+			fileutils.CoverExistsWithBaseName(coverDir, fileutils.OriginalCoverBaseName(filePath)) == "" {
+			originalFilename, _, err := writeCover(coverDir, fileutils.OriginalCoverBaseName(filePath), metadata)
+			if err != nil {
+				log.Warn("failed to keep original cover", logger.Data{"error": err.Error()})
+			} else {
+				logInfo("kept embedded cover as original", logger.Data{"filename": originalFilename})
+			}
+		}
 		return filepath.Base(existingCoverPath), existingMime, true, nil
 	}
 
@@ -2912,29 +3860,36 @@ func (w *Worker) extractAndSaveCover(
 		return "", "", false, nil
 	}
 
-	// Normalize the cover image
+	logInfo("saving cover", logger.Data{"dir": coverDir, "base_name": coverBaseName})
+	coverFilename, normalizedMime, err := writeCover(coverDir, coverBaseName, metadata)
+	if err != nil {
+		return "", "", false, err
+	}
+	return coverFilename, normalizedMime, false, nil
+}
+
+// writeCover normalizes metadata's cover image and saves it in dir as
+// baseName plus the image's extension. Returns the cover filename and its
+// mime type.
+func writeCover(dir, baseName string, metadata *mediafile.ParsedMetadata) (string, string, error) {
 	normalizedData, normalizedMime, _ := fileutils.NormalizeImage(metadata.CoverData, metadata.CoverMimeType)
 	coverExt := ".png"
 	if normalizedMime == metadata.CoverMimeType {
 		coverExt = metadata.CoverExtension()
 	}
 
-	// Save cover
-	coverFilename := coverBaseName + coverExt
-	coverFilepath := filepath.Join(coverDir, coverFilename)
-	logInfo("saving cover", logger.Data{"path": coverFilepath, "mime": normalizedMime})
-
-	coverFile, err := os.Create(coverFilepath)
+	coverFilename := baseName + coverExt
+	coverFile, err := os.Create(filepath.Join(dir, coverFilename))
 	if err != nil {
-		return "", "", false, errors.Wrap(err, "failed to create cover file")
+		return "", "", errors.Wrap(err, "failed to create cover file")
 	}
 	defer coverFile.Close()
 
 	if _, err := io.Copy(coverFile, bytes.NewReader(normalizedData)); err != nil {
-		return "", "", false, errors.Wrap(err, "failed to write cover data")
+		return "", "", errors.Wrap(err, "failed to write cover data")
 	}
 
-	return coverFilename, normalizedMime, false, nil
+	return coverFilename, normalizedMime, nil
 }
 
 // upgradeEnricherCover checks if an enricher provided a cover image that is
@@ -2946,6 +3901,12 @@ func (w *Worker) extractAndSaveCover(
 // field setting (already enforced by filterMetadataFields), and never replaces
 // covers for page-based formats (CBZ, PDF).
 //
+// When the enricher is configured to prefer its cover (metadata.CoverPreferred),
+// the resolution gate is skipped, though the cover still has to decode, for covers whose source has a lower priority
+// than the plugin (file metadata, filepath, or no cover). Sidecar and other
+// plugin covers still have to be beaten on resolution. Manual covers (uploaded
+// by a user) are never replaced.
+//
 // bookFilepath is the parent book's filepath. The cover directory is determined
 // automatically: if bookFilepath is a directory, covers are saved there; otherwise
 // (root-level files where the book path may not exist as a directory), covers are
@@ -3003,7 +3964,21 @@ func (w *Worker) upgradeEnricherCover(
 		currentResolution = fileutils.ImageFileResolution(existingCoverPath)
 	}
 
-	// 5. Resolution gate — enricher cover must be strictly larger
+	// 5. Resolution gate — enricher cover must be strictly larger, unless the
+	// enricher prefers its cover and outranks the current cover's source.
+	currentSource := models.DataSourceFilepath
+	if file.CoverSource != nil && existingCoverPath != "" {
+		currentSource = *file.CoverSource
+	}
+	if currentSource == models.DataSourceManual {
+		logInfo("file has a manual cover, skipping enricher cover", logger.Data{
+			"file_id": file.ID,
+			"source":  coverSource,
+		})
+		return
+	}
+	preferred := metadata.CoverPreferred &&
+		models.GetDataSourcePriority(coverSource) < models.GetDataSourcePriority(currentSource)
 	enricherResolution := fileutils.ImageResolution(metadata.CoverData)
 	if enricherResolution == 0 {
 		logWarn("enricher cover could not be decoded, skipping", logger.Data{
@@ -3012,7 +3987,7 @@ func (w *Worker) upgradeEnricherCover(
 		})
 		return
 	}
-	if enricherResolution <= currentResolution {
+	if !preferred && enricherResolution <= currentResolution {
 		logInfo("enricher cover not larger than current cover, skipping", logger.Data{
 			"file_id":             file.ID,
 			"enricher_resolution": enricherResolution,
@@ -3032,6 +4007,16 @@ func (w *Worker) upgradeEnricherCover(
 	coverFilename := coverBaseName + coverExt
 	coverFilepath := filepath.Join(coverDir, coverFilename)
 
+	// Set an embedded cover aside before replacing it so it can be restored.
+	if w.config.KeepOriginalCover && existingCoverPath != "" &&
+		file.CoverSource != nil && models.IsEmbeddedDataSource(*file.CoverSource) {
+		if err := fileutils.KeepOriginalCover(existingCoverPath, file.Filepath); err != nil {
+			logWarn("failed to keep original cover", logger.Data{"error": err.Error()})
+		} else {
+			existingCoverPath = ""
+		}
+	}
+
 	// Remove any existing cover file with a different extension
 	if existingCoverPath != "" && existingCoverPath != coverFilepath {
 		os.Remove(existingCoverPath)
@@ -3055,11 +4040,16 @@ func (w *Worker) upgradeEnricherCover(
 		return
 	}
 
-	logInfo("upgraded cover from enricher (higher resolution)", logger.Data{
+	reason := "higher resolution"
+	if preferred {
+		reason = "preferred"
+	}
+	logInfo("upgraded cover from enricher", logger.Data{
 		"file_id":             file.ID,
 		"enricher_resolution": enricherResolution,
 		"current_resolution":  currentResolution,
 		"source":              coverSource,
+		"reason":              reason,
 		"path":                coverFilepath,
 	})
 
@@ -3077,10 +4067,39 @@ func (w *Worker) upgradeEnricherCover(
 	}
 }
 
+// findIdenticalFile hashes the file at path and looks for an identical main
+// file in the same library. It returns the hash (empty if hashing failed) and
+// the matching file's book, or nil when there is no match.
+func (w *Worker) findIdenticalFile(ctx context.Context, path string, libraryID int, logWarn func(string, logger.Data)) (string, *models.Book) {
+	hash, err := computeFileSHA256(path)
+	if err != nil {
+		logWarn("failed to hash file for dedupe", logger.Data{"path": path, "error": err.Error()})
+		return "", nil
+	}
+	match, err := w.fingerprintService.FindMainFileByHash(ctx, libraryID, models.FingerprintAlgorithmSHA256, hash)
+	if err != nil {
+		logWarn("failed to look up identical files", logger.Data{"path": path, "error": err.Error()})
+		return hash, nil
+	}
+	if match == nil {
+		return hash, nil
+	}
+	book, err := w.bookService.RetrieveBook(ctx, books.RetrieveBookOptions{ID: &match.BookID})
+	if err != nil {
+		logWarn("failed to retrieve book of identical file", logger.Data{"book_id": match.BookID, "error": err.Error()})
+		return hash, nil
+	}
+	return hash, book
+}
+
 // parseFileMetadata extracts metadata from a file based on its type.
-// For built-in types (epub, cbz, m4b), uses the native parsers.
+// For built-in types (epub, cbz, m4b, pdf, mobi, azw3), uses the native parsers.
 // For other types, falls back to plugin file parsers if available.
 func (w *Worker) parseFileMetadata(ctx context.Context, path, fileType string) (*mediafile.ParsedMetadata, error) {
+	defer func(start time.Time) {
+		metrics.ParseDuration.WithLabelValues(fileType).Observe(time.Since(start).Seconds())
+	}(time.Now())
+
 	var metadata *mediafile.ParsedMetadata
 	var err error
 
@@ -3090,37 +4109,37 @@ func (w *Worker) parseFileMetadata(ctx context.Context, path, fileType string) (
 	case models.FileTypeCBZ:
 		metadata, err = cbz.Parse(path)
 	case models.FileTypeM4B:
-		metadata, err = mp4.Parse(path)
+		metadata, err = mp4.ParseWithOptions(path, mp4.ParseOptions{
+			NarratorFallbackAtoms: w.config.NarratorAtomFallback,
+		})
 	case models.FileTypePDF:
 		metadata, err = pdf.Parse(path)
+	case models.FileTypeMOBI, models.FileTypeAZW3:
+		metadata, err = mobi.Parse(path)
 	default:
 		// Check for plugin file parser
 		if w.pluginManager != nil {
 			rt := w.pluginManager.GetParserForType(fileType)
 			if rt != nil {
-				// MIME type validation if mimeTypes declared
-				declaredMIMEs := rt.Manifest().Capabilities.FileParser.MIMETypes
-				if len(declaredMIMEs) > 0 {
-					mtype, mErr := mimetype.DetectFile(path)
-					if mErr != nil {
-						return nil, errors.Wrap(mErr, "failed to detect MIME type")
-					}
-					detected := strings.ToLower(mtype.String())
-					mimeMatch := false
-					for _, allowed := range declaredMIMEs {
-						if strings.HasPrefix(detected, strings.ToLower(allowed)) {
-							mimeMatch = true
-							break
-						}
-					}
-					if !mimeMatch {
-						return nil, errors.Errorf("file %s: detected MIME type %s does not match parser mimeTypes %v, skipping", path, mtype.String(), declaredMIMEs)
-					}
+				if err := checkPluginParserContent(path, rt.Manifest().Capabilities.FileParser); err != nil {
+					return nil, err
+				}
+				metadata, err := w.pluginManager.RunFileParser(ctx, rt, path, fileType)
+				// A parser that hangs or returns an oversized result is skipped
+				// for this file so the scan can carry on with what the filepath
+				// provides.
+				if err != nil && ctx.Err() == nil && (errors.Is(err, context.DeadlineExceeded) || errors.Is(err, plugins.ErrOutputTooLarge)) {
+					logger.FromContext(ctx).Warn("skipping plugin file parser", logger.Data{
+						"plugin": rt.Manifest().ID,
+						"path":   path,
+						"error":  err.Error(),
+					})
+					return &mediafile.ParsedMetadata{DataSource: models.DataSourceFilepath}, nil
 				}
-				return w.pluginManager.RunFileParser(ctx, rt, path, fileType)
+				return metadata, err
 			}
 		}
-		return nil, errors.Errorf("unsupported file type: %s", fileType)
+		return nil, errors.WithStack(errcodes.UnsupportedFileType(fileType))
 	}
 
 	if err != nil {
@@ -3130,11 +4149,18 @@ func (w *Worker) parseFileMetadata(ctx context.Context, path, fileType string) (
 	return metadata, nil
 }
 
-// runMetadataEnrichers runs metadata enricher plugins on parsed metadata.
+// runMetadataEnrichers runs the enricher plugins followed by the built-in Open
+// Library enricher, which only fills fields that are still empty.
+func (w *Worker) runMetadataEnrichers(ctx context.Context, metadata *mediafile.ParsedMetadata, file *models.File, book *models.Book, libraryID int, jobLog *joblogs.JobLogger) *mediafile.ParsedMetadata {
+	metadata = w.runPluginEnrichers(ctx, metadata, file, book, libraryID, jobLog)
+	return w.runOpenLibraryEnricher(ctx, metadata, file, libraryID, jobLog)
+}
+
+// runPluginEnrichers runs metadata enricher plugins on parsed metadata.
 // Each enricher's search() is called with the book title as query, and the first
 // result is used directly as ParsedMetadata (no conversion needed).
 // Enrichers are called in user-defined order; first non-empty value per field wins.
-func (w *Worker) runMetadataEnrichers(ctx context.Context, metadata *mediafile.ParsedMetadata, file *models.File, book *models.Book, libraryID int, jobLog *joblogs.JobLogger) *mediafile.ParsedMetadata {
+func (w *Worker) runPluginEnrichers(ctx context.Context, metadata *mediafile.ParsedMetadata, file *models.File, book *models.Book, libraryID int, jobLog *joblogs.JobLogger) *mediafile.ParsedMetadata {
 	if w.pluginManager == nil || metadata == nil {
 		return metadata
 	}
@@ -3306,7 +4332,21 @@ func (w *Worker) runMetadataEnrichers(ctx context.Context, metadata *mediafile.P
 
 		// Merge: first non-empty wins per field, tracking source per field
 		enricherSource := models.PluginDataSource(rt.Scope(), rt.PluginID())
+		hadCover := len(enrichedMeta.CoverData) > 0
 		mergeEnrichedMetadata(&enrichedMeta, filteredMetadata, enricherSource)
+
+		// If this enricher supplied the cover, check whether it's configured
+		// to prefer its cover over the file's embedded one.
+		if !hadCover && len(enrichedMeta.CoverData) > 0 {
+			preferred, pErr := w.pluginService.GetPreferredFields(ctx, rt.Scope(), rt.PluginID())
+			if pErr != nil {
+				logWarn("failed to get preferred fields", logger.Data{
+					"plugin": rt.PluginID(),
+					"error":  pErr.Error(),
+				})
+			}
+			enrichedMeta.CoverPreferred = preferred["cover"]
+		}
 		if !modified {
 			enrichedMeta.DataSource = enricherSource
 		}
@@ -3321,7 +4361,13 @@ func (w *Worker) runMetadataEnrichers(ctx context.Context, metadata *mediafile.P
 	enrichedMeta.Duration = metadata.Duration
 	enrichedMeta.BitrateBps = metadata.BitrateBps
 	enrichedMeta.Codec = metadata.Codec
+	enrichedMeta.SampleRateHz = metadata.SampleRateHz
+	enrichedMeta.Channels = metadata.Channels
 	enrichedMeta.PageCount = metadata.PageCount
+	enrichedMeta.WordCount = metadata.WordCount
+	enrichedMeta.ModifiedDate = metadata.ModifiedDate
+	enrichedMeta.ReadingDirection = metadata.ReadingDirection
+	enrichedMeta.Accessibility = metadata.Accessibility
 
 	// Use file parser's DataSource as fallback if no enricher modified anything
 	if !modified {
@@ -3389,6 +4435,16 @@ func validSeriesNumberGroup(start, end *float64, unit *string) bool {
 	return unit == nil || *unit == models.SeriesNumberUnitVolume || *unit == models.SeriesNumberUnitChapter
 }
 
+// externalReadingOrder discards a non-finite reading order from file metadata,
+// a sidecar, or a plugin. Reading order doesn't depend on the series number
+// group, so it's validated on its own.
+func externalReadingOrder(order *float64) *float64 {
+	if order == nil || math.IsNaN(*order) || math.IsInf(*order, 0) {
+		return nil
+	}
+	return order
+}
+
 // mergeEnrichedMetadata applies fields from enrichment result to the target
 // only if the target field is currently empty/zero. Tracks which source
 // provided each field in target.FieldDataSources.
@@ -3410,10 +4466,12 @@ func mergeEnrichedMetadata(target, enrichment *mediafile.ParsedMetadata, source
 	}
 	if len(target.Narrators) == 0 && len(enrichment.Narrators) > 0 {
 		target.Narrators = enrichment.Narrators
+		target.NarratorRoles = enrichment.NarratorRoles
 		target.FieldDataSources["narrators"] = source
 	}
 	if target.Series == "" && enrichment.Series != "" {
 		target.Series = enrichment.Series
+		target.AdditionalSeries = enrichment.AdditionalSeries
 		target.FieldDataSources["series"] = source
 	}
 	if target.SeriesNumber == nil {
@@ -3428,6 +4486,10 @@ func mergeEnrichedMetadata(target, enrichment *mediafile.ParsedMetadata, source
 			target.FieldDataSources["series"] = source
 		}
 	}
+	if target.SeriesReadingOrder == nil && externalReadingOrder(enrichment.SeriesReadingOrder) != nil {
+		target.SeriesReadingOrder = enrichment.SeriesReadingOrder
+		target.FieldDataSources["series"] = source
+	}
 	if len(target.Genres) == 0 && len(enrichment.Genres) > 0 {
 		target.Genres = enrichment.Genres
 		target.FieldDataSources["genres"] = source
@@ -3568,12 +4630,19 @@ func filterMetadataFields(
 				"field":  "seriesNumberUnit",
 			})
 		}
+		if len(result.AdditionalSeries) > 0 {
+			logWarn("enricher returned undeclared field", logger.Data{
+				"plugin": pluginID,
+				"field":  "additionalSeries",
+			})
+		}
 	}
 	if !seriesAllowed {
 		result.Series = ""
 		result.SeriesNumber = nil
 		result.SeriesNumberEnd = nil
 		result.SeriesNumberUnit = nil
+		result.AdditionalSeries = nil
 	}
 
 	// Handle "cover" grouping
@@ -3655,6 +4724,7 @@ func convertSidecarChapters(chapters []sidecar.ChapterMetadata) []mediafile.Pars
 			StartPage:        ch.StartPage,
 			StartTimestampMs: ch.StartTimestampMs,
 			Href:             ch.Href,
+			CoverPageIndex:   ch.CoverPageIndex,
 			Children:         convertSidecarChapters(ch.Children),
 		}
 	}
@@ -3667,26 +4737,43 @@ func convertSidecarChapters(chapters []sidecar.ChapterMetadata) []mediafile.Pars
 func (w *Worker) Scan(ctx context.Context, opts books.ScanOptions) (*books.ScanResult, error) {
 	// Convert books.ScanOptions to internal ScanOptions
 	internalOpts := ScanOptions{
+		FilePath:     opts.FilePath,
 		FileID:       opts.FileID,
 		BookID:       opts.BookID,
+		LibraryID:    opts.LibraryID,
 		ForceRefresh: opts.ForceRefresh,
 		SkipPlugins:  opts.SkipPlugins,
 		Reset:        opts.Reset,
 	}
 
-	// Call internal unified Scan method (no cache for single-file rescans)
-	result, err := w.scanInternal(ctx, internalOpts, nil)
-	if err != nil {
-		return nil, err
-	}
+	// Identical scans (e.g. a resync clicked twice) share one run so they
+	// don't race each other creating the same people and series. The shared
+	// run is detached from the caller that started it, so that caller going
+	// away doesn't fail the scan for everyone waiting on it.
+	scanCtx := context.WithoutCancel(ctx)
+	result, shared, err := w.scans.do(ctx, scanKey(opts), w.config.ScanDedupWindow, func() (*books.ScanResult, error) {
+		// Call internal unified Scan method (no cache for single-file rescans)
+		result, err := w.scanInternal(scanCtx, internalOpts, nil)
+		if err != nil {
+			return nil, err
+		}
+		// Path-based scans return nil for missing or ignored files.
+		if result == nil {
+			return &books.ScanResult{}, nil
+		}
 
-	// Convert internal ScanResult to books.ScanResult
-	return &books.ScanResult{
-		File:        result.File,
-		Book:        result.Book,
-		FileDeleted: result.FileDeleted,
-		BookDeleted: result.BookDeleted,
-	}, nil
+		// Convert internal ScanResult to books.ScanResult
+		return &books.ScanResult{
+			File:        result.File,
+			Book:        result.Book,
+			FileDeleted: result.FileDeleted,
+			BookDeleted: result.BookDeleted,
+		}, nil
+	})
+	if shared {
+		logger.FromContext(ctx).Info("coalesced duplicate scan", logger.Data{"key": scanKey(opts)})
+	}
+	return result, err
 }
 
 // recoverMissingCover checks if the file's cover image is missing from disk
@@ -3756,7 +4843,7 @@ func (w *Worker) recoverMissingCover(ctx context.Context, file *models.File, job
 	// we'd write a page-0 cover to disk while file.CoverPage still points
 	// at the user's selection, leaving the two out of sync.
 	if models.IsPageBasedFileType(file.FileType) && file.CoverPage != nil {
-		pageNum := *file.CoverPage
+		pageNum := *file.CoverPageIndex()
 		var coverFilename, coverMimeType string
 		var err error
 		switch file.FileType {
@@ -3835,11 +4922,91 @@ func (w *Worker) recoverMissingCover(ctx context.Context, file *models.File, job
 	return nil
 }
 
-// applyPageCover renders `page` from the page-based file, writes it as the
-// cover image next to the book, and persists the cover_page /
-// cover_image_filename / cover_mime_type / cover_source update to the DB.
-// Returns (extractErr, updateErr). Callers typically treat extract errors as
-// non-fatal warnings and surface update errors.
+// upgradeEmbeddedCover replaces a file's stored cover with the cover embedded
+// in the file when the embedded one has strictly more pixels, so a file
+// swapped for a better copy gets the better cover on its next resync. Only
+// covers whose source doesn't outrank file metadata are replaced: manual,
+// sidecar, and plugin covers are left alone. Page-based formats are skipped
+// since their covers come from their pages.
+func (w *Worker) upgradeEmbeddedCover(ctx context.Context, metadata *mediafile.ParsedMetadata, file *models.File, jobLog *joblogs.JobLogger) {
+	log := logger.FromContext(ctx).Data(logger.Data{"file_id": file.ID, "filepath": file.Filepath})
+
+	logInfo := func(msg string, data logger.Data) {
+		log.Info(msg, data)
+		if jobLog != nil {
+			jobLog.Info(msg, data)
+		}
+	}
+
+	logWarn := func(msg string, data logger.Data) {
+		log.Warn(msg, data)
+		if jobLog != nil {
+			jobLog.Warn(msg, data)
+		}
+	}
+
+	if metadata == nil || len(metadata.CoverData) == 0 || models.IsPageBasedFileType(file.FileType) {
+		return
+	}
+	if file.CoverSource == nil || *file.CoverSource == models.DataSourceManual {
+		return
+	}
+	coverSource := metadata.SourceForField("cover")
+	if models.GetDataSourcePriority(*file.CoverSource) < models.GetDataSourcePriority(coverSource) {
+		return
+	}
+
+	coverDir := filepath.Dir(file.Filepath)
+	coverBaseName := filepath.Base(file.Filepath) + ".cover"
+	existingCoverPath := fileutils.CoverExistsWithBaseName(coverDir, coverBaseName)
+	if existingCoverPath == "" {
+		// recoverMissingCover already handled a missing cover.
+		return
+	}
+
+	currentResolution := fileutils.ImageFileResolution(existingCoverPath)
+	embeddedResolution := fileutils.ImageResolution(metadata.CoverData)
+	if embeddedResolution <= currentResolution {
+		return
+	}
+
+	normalizedData, normalizedMime, _ := fileutils.NormalizeImage(metadata.CoverData, metadata.CoverMimeType)
+	coverExt := ".png"
+	if normalizedMime == metadata.CoverMimeType {
+		coverExt = metadata.CoverExtension()
+	}
+	coverFilename := coverBaseName + coverExt
+	coverFilepath := filepath.Join(coverDir, coverFilename)
+
+	if err := os.WriteFile(coverFilepath, normalizedData, 0644); err != nil { //nolint:gosec // Cover files need to be readable by the HTTP server
+		logWarn("failed to save upgraded cover", logger.Data{"error": err.Error(), "path": coverFilepath})
+		return
+	}
+	if existingCoverPath != coverFilepath {
+		os.Remove(existingCoverPath)
+	}
+
+	logInfo("upgraded cover from embedded metadata", logger.Data{
+		"current_resolution":  currentResolution,
+		"embedded_resolution": embeddedResolution,
+		"path":                coverFilepath,
+	})
+
+	file.CoverImageFilename = &coverFilename
+	file.CoverMimeType = &normalizedMime
+	file.CoverSource = &coverSource
+	if err := w.bookService.UpdateFile(ctx, file, books.UpdateFileOptions{
+		Columns: []string{"cover_image_filename", "cover_mime_type", "cover_source"},
+	}); err != nil {
+		logWarn("failed to update file cover after upgrade", logger.Data{"error": err.Error()})
+	}
+}
+
+// applyPageCover renders `page` (1-indexed, as stored in cover_page) from the
+// page-based file, writes it as the cover image next to the book, and persists
+// the cover_page / cover_image_filename / cover_mime_type / cover_source update
+// to the DB. Returns (extractErr, updateErr). Callers typically treat extract
+// errors as non-fatal warnings and surface update errors.
 func (w *Worker) applyPageCover(ctx context.Context, file *models.File, book *models.Book, page int, source string) (extractErr, updateErr error) {
 	coverDir := fileutils.ResolveCoverDirForWrite(book.Filepath, file.Filepath)
 	coverBaseName := filepath.Base(file.Filepath) + ".cover"
@@ -3847,9 +5014,9 @@ func (w *Worker) applyPageCover(ctx context.Context, file *models.File, book *mo
 	var coverFilename, coverMimeType string
 	switch file.FileType {
 	case models.FileTypePDF:
-		coverFilename, coverMimeType, extractErr = extractPDFPageCover(file.Filepath, coverDir, coverBaseName, page)
+		coverFilename, coverMimeType, extractErr = extractPDFPageCover(file.Filepath, coverDir, coverBaseName, page-1)
 	case models.FileTypeCBZ:
-		coverFilename, coverMimeType, extractErr = extractCBZPageCover(file.Filepath, coverDir, coverBaseName, page)
+		coverFilename, coverMimeType, extractErr = extractCBZPageCover(file.Filepath, coverDir, coverBaseName, page-1)
 	default:
 		extractErr = errors.Errorf("unsupported page-based file type for cover extraction: %s", file.FileType)
 	}
@@ -3872,20 +5039,68 @@ func (w *Worker) applyPageCover(ctx context.Context, file *models.File, book *mo
 // Returns the cover filename (relative to coverDir), mime type, and any error.
 // pageNum is 0-indexed.
 func extractCBZPageCover(cbzPath string, coverDir string, coverBaseName string, pageNum int) (string, string, error) {
-	f, err := os.Open(cbzPath)
+	data, ext, err := readCBZPage(cbzPath, pageNum)
+	if err != nil {
+		return "", "", err
+	}
+	mimeType := fileutils.MimeTypeFromExtension(ext)
+
+	// Delete any existing cover with this base name (regardless of extension)
+	for _, existingExt := range fileutils.CoverImageExtensions {
+		existingPath := filepath.Join(coverDir, coverBaseName+existingExt)
+		if _, statErr := os.Stat(existingPath); statErr == nil {
+			_ = os.Remove(existingPath)
+		}
+	}
+
+	// Extract the page
+	coverFilePath := filepath.Join(coverDir, coverBaseName+ext)
+
+	// Normalize the image
+	normalizedData, normalizedMime, _ := fileutils.NormalizeImage(data, mimeType)
+	if normalizedMime != mimeType {
+		// Extension changed due to normalization
+		ext = ".png"
+		if normalizedMime == "image/jpeg" {
+			ext = ".jpg"
+		}
+		coverFilePath = filepath.Join(coverDir, coverBaseName+ext)
+		mimeType = normalizedMime
+	}
+
+	// Write the cover file
+	outFile, err := os.Create(coverFilePath)
 	if err != nil {
 		return "", "", errors.WithStack(err)
 	}
+	defer outFile.Close()
+
+	if _, err := io.Copy(outFile, bytes.NewReader(normalizedData)); err != nil {
+		return "", "", errors.WithStack(err)
+	}
+
+	return coverBaseName + ext, mimeType, nil
+}
+
+// readCBZPage returns the image data and lowercased extension of a page in a
+// CBZ file.
+// Pages are the archive's images sorted by name, as in the CBZ parser.
+// pageNum is 0-indexed.
+func readCBZPage(cbzPath string, pageNum int) ([]byte, string, error) {
+	f, err := os.Open(cbzPath)
+	if err != nil {
+		return nil, "", errors.WithStack(err)
+	}
 	defer f.Close()
 
 	stats, err := f.Stat()
 	if err != nil {
-		return "", "", errors.WithStack(err)
+		return nil, "", errors.WithStack(err)
 	}
 
 	zipReader, err := zip.NewReader(f, stats.Size())
 	if err != nil {
-		return "", "", errors.WithStack(err)
+		return nil, "", errors.WithStack(err)
 	}
 
 	// Get sorted image files
@@ -3901,72 +5116,23 @@ func extractCBZPageCover(cbzPath string, coverDir string, coverBaseName string,
 	})
 
 	if pageNum < 0 || pageNum >= len(imageFiles) {
-		return "", "", errors.Errorf("page %d out of range (0-%d)", pageNum, len(imageFiles)-1)
+		return nil, "", errors.Errorf("page %d out of range (0-%d)", pageNum, len(imageFiles)-1)
 	}
 
 	targetFile := imageFiles[pageNum]
 
-	// Determine extension and mime type
-	ext := strings.ToLower(filepath.Ext(targetFile.Name))
-	mimeType := ""
-	switch ext {
-	case ".jpg", ".jpeg":
-		mimeType = "image/jpeg"
-	case ".png":
-		mimeType = "image/png"
-	case ".gif":
-		mimeType = "image/gif"
-	case ".webp":
-		mimeType = "image/webp"
-	}
-
-	// Delete any existing cover with this base name (regardless of extension)
-	for _, existingExt := range fileutils.CoverImageExtensions {
-		existingPath := filepath.Join(coverDir, coverBaseName+existingExt)
-		if _, statErr := os.Stat(existingPath); statErr == nil {
-			_ = os.Remove(existingPath)
-		}
-	}
-
-	// Extract the page
-	coverFilePath := filepath.Join(coverDir, coverBaseName+ext)
-
 	r, err := targetFile.Open()
 	if err != nil {
-		return "", "", errors.WithStack(err)
+		return nil, "", errors.WithStack(err)
 	}
 	defer r.Close()
 
-	// Read the image data
 	data, err := io.ReadAll(r)
 	if err != nil {
-		return "", "", errors.WithStack(err)
+		return nil, "", errors.WithStack(err)
 	}
 
-	// Normalize the image
-	normalizedData, normalizedMime, _ := fileutils.NormalizeImage(data, mimeType)
-	if normalizedMime != mimeType {
-		// Extension changed due to normalization
-		ext = ".png"
-		if normalizedMime == "image/jpeg" {
-			ext = ".jpg"
-		}
-		coverFilePath = filepath.Join(coverDir, coverBaseName+ext)
-		mimeType = normalizedMime
-	}
-
-	// Write the cover file
-	outFile, err := os.Create(coverFilePath)
-	if err != nil {
-		return "", "", errors.WithStack(err)
-	}
-	defer outFile.Close()
-
-	if _, err := io.Copy(outFile, bytes.NewReader(normalizedData)); err != nil {
-		return "", "", errors.WithStack(err)
-	}
-
-	return coverBaseName + ext, mimeType, nil
+	return data, strings.ToLower(filepath.Ext(targetFile.Name)), nil
 }
 
 // extractPDFPageCover renders a specific page from a PDF file via pdfium and
@@ -4000,54 +5166,83 @@ func extractPDFPageCover(pdfPath string, coverDir string, coverBaseName string,
 // library_id) are preserved. Title and SortTitle values
 // are preserved (NOT NULL) but their source fields are reset to
 // DataSourceFilepath so scanFileCore can set the correct source from
-// the re-scanned metadata.
+// the re-scanned metadata. Fields in config.LockedFields are left untouched,
+// since the scan that follows won't write them back.
 func (w *Worker) resetBookState(ctx context.Context, book *models.Book) error {
 	// --- Book-level columns ---
-	book.Subtitle = nil
-	book.SubtitleSource = nil
-	book.Description = nil
-	book.DescriptionSource = nil
-	book.GenreSource = nil
-	book.TagSource = nil
+	var bookColumns []string
+	if !w.isFieldLocked("subtitle") {
+		book.Subtitle = nil
+		book.SubtitleSource = nil
+		bookColumns = append(bookColumns, "subtitle", "subtitle_source")
+	}
+	if !w.isFieldLocked("description") {
+		book.Description = nil
+		book.DescriptionSource = nil
+		bookColumns = append(bookColumns, "description", "description_source")
+	}
+	if !w.isFieldLocked("genres") {
+		book.GenreSource = nil
+		bookColumns = append(bookColumns, "genre_source")
+	}
+	if !w.isFieldLocked("tags") {
+		book.TagSource = nil
+		bookColumns = append(bookColumns, "tag_source")
+	}
 
 	// Reset NOT NULL source fields to filepath (lowest priority) so that
 	// scanFileCore can correct them. Without this, a stale high-priority
 	// source (e.g., "plugin:foo") would prevent future scans from updating.
-	book.TitleSource = models.DataSourceFilepath
-	book.SortTitleSource = models.DataSourceFilepath
-	book.AuthorSource = models.DataSourceFilepath
-
-	bookColumns := []string{
-		"subtitle", "subtitle_source",
-		"description", "description_source",
-		"genre_source", "tag_source",
-		"title_source", "sort_title_source", "author_source",
+	if !w.isFieldLocked("title") {
+		book.TitleSource = models.DataSourceFilepath
+		book.SortTitleSource = models.DataSourceFilepath
+		bookColumns = append(bookColumns, "title_source", "sort_title_source")
+	}
+	if !w.isFieldLocked("authors") {
+		book.AuthorSource = models.DataSourceFilepath
+		bookColumns = append(bookColumns, "author_source")
 	}
-	if err := w.bookService.UpdateBook(ctx, book, books.UpdateBookOptions{Columns: bookColumns}); err != nil {
-		return errors.Wrap(err, "failed to clear book metadata")
+
+	if len(bookColumns) > 0 {
+		if err := w.bookService.UpdateBook(ctx, book, books.UpdateBookOptions{Columns: bookColumns}); err != nil {
+			return errors.Wrap(err, "failed to clear book metadata")
+		}
 	}
 
 	// --- Book-level relations ---
-	if err := w.bookService.DeleteAuthors(ctx, book.ID); err != nil {
-		return errors.Wrap(err, "failed to delete book authors")
+	if !w.isFieldLocked("authors") {
+		if err := w.bookService.DeleteAuthors(ctx, book.ID); err != nil {
+			return errors.Wrap(err, "failed to delete book authors")
+		}
 	}
-	if err := w.bookService.DeleteBookSeries(ctx, book.ID); err != nil {
-		return errors.Wrap(err, "failed to delete book series")
+	if !w.isFieldLocked("series") {
+		if err := w.bookService.DeleteBookSeries(ctx, book.ID); err != nil {
+			return errors.Wrap(err, "failed to delete book series")
+		}
 	}
-	if err := w.bookService.DeleteBookGenres(ctx, book.ID); err != nil {
-		return errors.Wrap(err, "failed to delete book genres")
+	if !w.isFieldLocked("genres") {
+		if err := w.bookService.DeleteBookGenres(ctx, book.ID); err != nil {
+			return errors.Wrap(err, "failed to delete book genres")
+		}
 	}
-	if err := w.bookService.DeleteBookTags(ctx, book.ID); err != nil {
-		return errors.Wrap(err, "failed to delete book tags")
+	if !w.isFieldLocked("tags") {
+		if err := w.bookService.DeleteBookTags(ctx, book.ID); err != nil {
+			return errors.Wrap(err, "failed to delete book tags")
+		}
 	}
 
 	return nil
 }
 
+// isFieldLocked reports whether field is listed in config.LockedFields.
+func (w *Worker) isFieldLocked(field string) bool {
+	return slices.Contains(w.config.LockedFields, field)
+}
+
 // resetBookFileState wipes all scanned metadata from a book and its file,
 // preparing them for a fresh scan. It preserves identity fields (IDs, filepath,
 // file_type, file_role, library_id, book_id, filesize, duration,
-// bitrate, codec, page_count).
+// bitrate, codec, page_count) and any field in config.LockedFields.
 //
 // When skipBookWipe is true, only file-level state is reset. This is used by
 // scanBook which handles the book-level wipe once for all files rather than
@@ -4063,38 +5258,54 @@ func (w *Worker) resetBookFileState(ctx context.Context, book *models.Book, file
 	}
 
 	// --- File-level columns ---
-	file.Name = nil
-	file.NameSource = nil
-	file.URL = nil
-	file.URLSource = nil
-	file.ReleaseDate = nil
-	file.ReleaseDateSource = nil
-	file.PublisherID = nil
-	file.PublisherSource = nil
-	file.Language = nil
-	file.LanguageSource = nil
-	file.Abridged = nil
-	file.AbridgedSource = nil
 	file.ChapterSource = nil
-	file.NarratorSource = nil
-	file.IdentifierSource = nil
-
-	fileColumns := []string{
-		"name", "name_source",
-		"url", "url_source",
-		"release_date", "release_date_source",
-		"publisher_id", "publisher_source",
-		"language", "language_source",
-		"abridged", "abridged_source",
-		"chapter_source",
-		"narrator_source", "identifier_source",
-	}
-
-	// Delete cover from disk before clearing cover columns
+	fileColumns := []string{"chapter_source"}
+	if !w.isFieldLocked("name") {
+		file.Name = nil
+		file.NameSource = nil
+		fileColumns = append(fileColumns, "name", "name_source")
+	}
+	if !w.isFieldLocked("url") {
+		file.URL = nil
+		file.URLSource = nil
+		fileColumns = append(fileColumns, "url", "url_source")
+	}
+	if !w.isFieldLocked("release_date") {
+		file.ReleaseDate = nil
+		file.ReleaseDateSource = nil
+		fileColumns = append(fileColumns, "release_date", "release_date_source")
+	}
+	if !w.isFieldLocked("publisher") {
+		file.PublisherID = nil
+		file.PublisherSource = nil
+		fileColumns = append(fileColumns, "publisher_id", "publisher_source")
+	}
+	if !w.isFieldLocked("language") {
+		file.Language = nil
+		file.LanguageSource = nil
+		fileColumns = append(fileColumns, "language", "language_source")
+	}
+	if !w.isFieldLocked("abridged") {
+		file.Abridged = nil
+		file.AbridgedSource = nil
+		fileColumns = append(fileColumns, "abridged", "abridged_source")
+	}
+	if !w.isFieldLocked("narrators") {
+		file.NarratorSource = nil
+		fileColumns = append(fileColumns, "narrator_source")
+	}
+	if !w.isFieldLocked("identifiers") {
+		file.IdentifierSource = nil
+		fileColumns = append(fileColumns, "identifier_source")
+	}
+
+	// Delete cover from disk before clearing cover columns. A kept original
+	// goes too, since the embedded cover is extracted again.
 	if file.CoverImageFilename != nil && *file.CoverImageFilename != "" {
 		coverPath := filepath.Join(filepath.Dir(file.Filepath), *file.CoverImageFilename)
 		_ = os.Remove(coverPath)
 	}
+	_ = fileutils.RemoveOriginalCover(filepath.Dir(file.Filepath), file.Filepath)
 
 	file.CoverImageFilename = nil
 	file.CoverMimeType = nil
@@ -4109,11 +5320,15 @@ func (w *Worker) resetBookFileState(ctx context.Context, book *models.Book, file
 	}
 
 	// --- File-level relations ---
-	if _, err := w.bookService.DeleteNarratorsForFile(ctx, file.ID); err != nil {
-		return errors.Wrap(err, "failed to delete file narrators")
+	if !w.isFieldLocked("narrators") {
+		if _, err := w.bookService.DeleteNarratorsForFile(ctx, file.ID); err != nil {
+			return errors.Wrap(err, "failed to delete file narrators")
+		}
 	}
-	if _, err := w.bookService.DeleteIdentifiersForFile(ctx, file.ID); err != nil {
-		return errors.Wrap(err, "failed to delete file identifiers")
+	if !w.isFieldLocked("identifiers") {
+		if _, err := w.bookService.DeleteIdentifiersForFile(ctx, file.ID); err != nil {
+			return errors.Wrap(err, "failed to delete file identifiers")
+		}
 	}
 	if err := w.chapterService.DeleteChaptersForFile(ctx, file.ID); err != nil {
 		return errors.Wrap(err, "failed to delete file chapters")
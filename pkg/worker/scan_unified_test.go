@@ -62,7 +62,7 @@ func TestScan_ZeroEntryPoints(t *testing.T) {
 
 	// Should return validation error
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "exactly one of FilePath, FileID, or BookID must be set")
+	assert.Contains(t, err.Error(), "exactly one of FilePath, FileID, BookID, or DirPath must be set")
 }
 
 func TestScan_MultipleEntryPoints(t *testing.T) {
@@ -77,7 +77,7 @@ func TestScan_MultipleEntryPoints(t *testing.T) {
 
 	// Should return validation error
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "exactly one of FilePath, FileID, or BookID must be set")
+	assert.Contains(t, err.Error(), "exactly one of FilePath, FileID, BookID, or DirPath must be set")
 }
 
 func TestScan_SingleEntryPoint_FileID(t *testing.T) {
@@ -92,7 +92,7 @@ func TestScan_SingleEntryPoint_FileID(t *testing.T) {
 	// Should not return validation error
 	// May return other errors (like file not found), but not the validation error
 	if err != nil {
-		assert.NotContains(t, err.Error(), "exactly one of FilePath, FileID, or BookID must be set")
+		assert.NotContains(t, err.Error(), "exactly one of FilePath, FileID, BookID, or DirPath must be set")
 	}
 }
 
@@ -107,7 +107,7 @@ func TestScan_SingleEntryPoint_BookID(t *testing.T) {
 
 	// Should not return validation error
 	if err != nil {
-		assert.NotContains(t, err.Error(), "exactly one of FilePath, FileID, or BookID must be set")
+		assert.NotContains(t, err.Error(), "exactly one of FilePath, FileID, BookID, or DirPath must be set")
 	}
 }
 
@@ -123,7 +123,7 @@ func TestScan_SingleEntryPoint_FilePath(t *testing.T) {
 
 	// Should not return validation error
 	if err != nil {
-		assert.NotContains(t, err.Error(), "exactly one of FilePath, FileID, or BookID must be set")
+		assert.NotContains(t, err.Error(), "exactly one of FilePath, FileID, BookID, or DirPath must be set")
 	}
 }
 
@@ -140,7 +140,7 @@ func TestScan_MultipleEntryPoints_AllThree(t *testing.T) {
 
 	// Should return validation error
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "exactly one of FilePath, FileID, or BookID must be set")
+	assert.Contains(t, err.Error(), "exactly one of FilePath, FileID, BookID, or DirPath must be set")
 }
 
 func TestScan_MultipleEntryPoints_FilePathAndFileID(t *testing.T) {
@@ -155,7 +155,7 @@ func TestScan_MultipleEntryPoints_FilePathAndFileID(t *testing.T) {
 
 	// Should return validation error
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "exactly one of FilePath, FileID, or BookID must be set")
+	assert.Contains(t, err.Error(), "exactly one of FilePath, FileID, BookID, or DirPath must be set")
 }
 
 func TestScan_MultipleEntryPoints_FilePathAndBookID(t *testing.T) {
@@ -170,7 +170,7 @@ func TestScan_MultipleEntryPoints_FilePathAndBookID(t *testing.T) {
 
 	// Should return validation error
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "exactly one of FilePath, FileID, or BookID must be set")
+	assert.Contains(t, err.Error(), "exactly one of FilePath, FileID, BookID, or DirPath must be set")
 }
 
 // =============================================================================
@@ -309,6 +309,48 @@ func TestScanFileByID_MissingFile_LastFile_DeletesBook(t *testing.T) {
 	assert.Empty(t, tc.listBooks())
 }
 
+func TestScanFileByID_MissingFile_KeptDuringGracePeriod(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.MissingFileGraceScans = 2
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+
+	bookDir := testgen.CreateSubDir(t, libraryPath, "[Test Author] Grace Book")
+	testgen.GenerateEPUB(t, bookDir, "grace.epub", testgen.EPUBOptions{
+		Title:   "Grace Book",
+		Authors: []string{"Test Author"},
+	})
+
+	require.NoError(t, tc.runScan())
+	files := tc.listFiles()
+	require.Len(t, files, 1)
+	fileID := files[0].ID
+	require.NoError(t, os.Remove(files[0].Filepath))
+
+	// The first two rescans keep the record and count the misses.
+	for i := 1; i <= 2; i++ {
+		result, err := tc.worker.scanInternal(tc.ctx, ScanOptions{FileID: fileID}, nil)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.False(t, result.FileDeleted)
+
+		file, err := tc.bookService.RetrieveFileWithRelations(tc.ctx, fileID)
+		require.NoError(t, err)
+		require.NotNil(t, file.MissingSince)
+		assert.Equal(t, i, file.MissingScans)
+	}
+
+	// The third deletes it.
+	result, err := tc.worker.scanInternal(tc.ctx, ScanOptions{FileID: fileID}, nil)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.True(t, result.FileDeleted)
+	assert.True(t, result.BookDeleted)
+	assert.Empty(t, tc.listFiles())
+}
+
 func TestScanFileByID_MissingFile_LastFile_CleansUpBookDirectory(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
@@ -1990,6 +2032,45 @@ func TestScanFileByID_Integration_UpdatesMetadata(t *testing.T) {
 	assert.Equal(t, "File Author", updatedBook.Authors[0].Person.Name)
 }
 
+func TestScanFileByID_LockedFieldsSurviveForceRefresh(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.LockedFields = []string{"title"}
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+	bookDir := testgen.CreateSubDir(t, libraryPath, "[Test Author] Test Book")
+	testgen.GenerateEPUB(t, bookDir, "test.epub", testgen.EPUBOptions{
+		Title:   "File Title",
+		Authors: []string{"File Author"},
+	})
+	require.NoError(t, tc.runScan())
+
+	files := tc.listFiles()
+	require.Len(t, files, 1)
+	allBooks := tc.listBooks()
+	require.Len(t, allBooks, 1)
+	bookID := allBooks[0].ID
+
+	// Curate the title outside of the file's metadata.
+	allBooks[0].Title = "Curated Title"
+	allBooks[0].TitleSource = models.DataSourceSidecar
+	require.NoError(t, tc.bookService.UpdateBook(tc.ctx, allBooks[0], books.UpdateBookOptions{Columns: []string{"title", "title_source"}}))
+
+	_, err := tc.worker.scanInternal(tc.ctx, ScanOptions{
+		FileID:       files[0].ID,
+		ForceRefresh: true,
+	}, nil)
+	require.NoError(t, err)
+
+	book, err := tc.bookService.RetrieveBook(tc.ctx, books.RetrieveBookOptions{ID: &bookID})
+	require.NoError(t, err)
+	assert.Equal(t, "Curated Title", book.Title, "a locked field isn't touched even on a forced refresh")
+	assert.Equal(t, models.DataSourceSidecar, book.TitleSource)
+	require.Len(t, book.Authors, 1, "unlocked fields are still refreshed")
+	assert.Equal(t, "File Author", book.Authors[0].Person.Name)
+}
+
 // =============================================================================
 // scanBook tests
 // =============================================================================
@@ -2659,6 +2740,55 @@ func TestScanFileCore_SidecarReading_BookTitle(t *testing.T) {
 	assert.Equal(t, models.DataSourceSidecar, result.Book.TitleSource)
 }
 
+func TestScanFileCore_InvalidSidecarWarns(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+	bookDir := testgen.CreateSubDir(t, libraryPath, "Test Book")
+
+	book := &models.Book{
+		LibraryID:    1,
+		Filepath:     bookDir,
+		Title:        "Filepath Title",
+		TitleSource:  models.DataSourceFilepath,
+		SortTitle:    "Filepath Title",
+		AuthorSource: models.DataSourceFilepath,
+	}
+	require.NoError(t, tc.bookService.CreateBook(tc.ctx, book))
+
+	file := &models.File{
+		LibraryID:     1,
+		BookID:        book.ID,
+		Filepath:      filepath.Join(bookDir, "test.epub"),
+		FileType:      models.FileTypeEPUB,
+		FilesizeBytes: 1000,
+	}
+	require.NoError(t, tc.bookService.CreateFile(tc.ctx, file))
+
+	// A misspelled field makes the whole sidecar invalid
+	bookSidecarPath := filepath.Join(bookDir, "Test Book.metadata.json")
+	invalidSidecar := []byte(`{"version":2,"title":"Sidecar Title","autors":[]}`)
+	require.NoError(t, os.WriteFile(bookSidecarPath, invalidSidecar, 0644))
+
+	metadata := &mediafile.ParsedMetadata{
+		DataSource: models.DataSourceEPUBMetadata,
+	}
+	result, err := tc.worker.scanFileCore(tc.ctx, file, book, metadata, false, true, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Filepath Title", result.Book.Title)
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, bookSidecarPath, result.Warnings[0].Path)
+	assert.Contains(t, result.Warnings[0].Message, `unknown field "autors"`)
+
+	// The invalid sidecar is left for the user to fix instead of rewritten.
+	data, err := os.ReadFile(bookSidecarPath)
+	require.NoError(t, err)
+	assert.Equal(t, invalidSidecar, data)
+}
+
 func TestScanFileCore_SidecarSeriesRangeOverridesSameNamedFileMetadata(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
@@ -2920,8 +3050,9 @@ func TestRecoverMissingCover_RespectsCoverPage(t *testing.T) {
 	require.Len(t, files, 1)
 	file := files[0]
 
-	// Simulate the user picking page 2 (blue) via the page picker.
-	coverPage := 2
+	// Simulate the user picking page index 2 (blue) via the page picker,
+	// which is stored 1-indexed as page 3.
+	coverPage := 3
 	file.CoverPage = &coverPage
 	require.NoError(t, tc.bookService.UpdateFile(tc.ctx, file, books.UpdateFileOptions{
 		Columns: []string{"cover_page"},
@@ -2971,7 +3102,7 @@ func TestRecoverMissingCover_RespectsCoverPage(t *testing.T) {
 	refreshed, err := tc.bookService.RetrieveFileWithRelations(tc.ctx, file.ID)
 	require.NoError(t, err)
 	require.NotNil(t, refreshed.CoverPage)
-	assert.Equal(t, 2, *refreshed.CoverPage)
+	assert.Equal(t, 3, *refreshed.CoverPage)
 }
 
 // TestRecoverMissingCover_UnsupportedFileTypeDoesNotError verifies that
@@ -3438,13 +3569,13 @@ func TestScanFileCore_FileName_ForceRefresh_OverridesExisting(t *testing.T) {
 // =============================================================================
 
 // TestScanFileCore_FileOrganization_RenamesFileOnDisk verifies that when file.name
-// changes during resync and the library has OrganizeFileStructure enabled, the actual
+// changes during resync and the library has organizing enabled, the actual
 // file on disk is renamed (regression test for file organization during resync).
 func TestScanFileCore_FileOrganization_RenamesFileOnDisk(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
-	// Setup: Create library with OrganizeFileStructure enabled
+	// Setup: Create library with organizing enabled
 	libraryPath := testgen.TempLibraryDir(t)
 	tc.createLibraryWithOptions([]string{libraryPath}, true)
 
@@ -3542,13 +3673,13 @@ func TestScanFileCore_FileOrganization_RenamesFileOnDisk(t *testing.T) {
 }
 
 // TestScanFileCore_FileOrganization_SkipsWhenOrganizeDisabled verifies that when
-// OrganizeFileStructure is disabled, files are NOT renamed on disk even when file.name
+// organizing is disabled, files are NOT renamed on disk even when file.name
 // changes (regression test for file organization opt-in behavior).
 func TestScanFileCore_FileOrganization_SkipsWhenOrganizeDisabled(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
-	// Setup: Create library WITHOUT OrganizeFileStructure (default is false)
+	// Setup: Create library WITHOUT organizing (default is false)
 	libraryPath := testgen.TempLibraryDir(t)
 	tc.createLibrary([]string{libraryPath})
 
@@ -3609,22 +3740,22 @@ func TestScanFileCore_FileOrganization_SkipsWhenOrganizeDisabled(t *testing.T) {
 	require.NotNil(t, updatedFile.Name)
 	assert.Equal(t, "New Title From Metadata", *updatedFile.Name)
 
-	// File path should NOT be updated (OrganizeFileStructure is disabled)
+	// File path should NOT be updated (organizing is disabled)
 	assert.Equal(t, oldFilePath, updatedFile.Filepath)
 
 	// Original file should still exist at old path
 	_, err = os.Stat(oldFilePath)
-	assert.NoError(t, err, "original file should still exist when OrganizeFileStructure is disabled")
+	assert.NoError(t, err, "original file should still exist when organizing is disabled")
 }
 
 // TestScanFileCore_FileOrganization_SidecarNameChange verifies that when file.name
-// is updated from a sidecar file and the library has OrganizeFileStructure enabled,
+// is updated from a sidecar file and the library has organizing enabled,
 // the actual file on disk is renamed (regression test for sidecar-triggered file organization).
 func TestScanFileCore_FileOrganization_SidecarNameChange(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
-	// Setup: Create library with OrganizeFileStructure enabled
+	// Setup: Create library with organizing enabled
 	libraryPath := testgen.TempLibraryDir(t)
 	tc.createLibraryWithOptions([]string{libraryPath}, true)
 
@@ -3725,7 +3856,7 @@ func TestScanFileCore_FileOrganization_StripsAuthorPrefixOnResync(t *testing.T)
 	t.Parallel()
 	tc := newTestContext(t)
 
-	// Setup: Create library with OrganizeFileStructure enabled
+	// Setup: Create library with organizing enabled
 	libraryPath := testgen.TempLibraryDir(t)
 	tc.createLibraryWithOptions([]string{libraryPath}, true)
 
@@ -3828,13 +3959,13 @@ func TestScanFileCore_FileOrganization_StripsAuthorPrefixOnResync(t *testing.T)
 // =============================================================================
 
 // TestScanFileCore_BookOrganization_RenamesFolderOnDisk verifies that when book title
-// changes during resync (not full scan) and the library has OrganizeFileStructure enabled,
+// changes during resync (not full scan) and the library has organizing enabled,
 // the book folder is renamed on disk (regression test for book organization during resync).
 func TestScanFileCore_BookOrganization_RenamesFolderOnDisk(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
-	// Setup: Create library with OrganizeFileStructure enabled
+	// Setup: Create library with organizing enabled
 	libraryPath := testgen.TempLibraryDir(t)
 	tc.createLibraryWithOptions([]string{libraryPath}, true)
 
@@ -3930,7 +4061,7 @@ func TestScanFileCore_BookOrganization_SkippedDuringFullScan(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
-	// Setup: Create library with OrganizeFileStructure enabled
+	// Setup: Create library with organizing enabled
 	libraryPath := testgen.TempLibraryDir(t)
 	tc.createLibraryWithOptions([]string{libraryPath}, true)
 
@@ -4162,7 +4293,7 @@ func TestScanFileCore_Supplement_FileOrganization_NoAuthorPrefix(t *testing.T) {
 	bookDir := filepath.Join(libraryPath, "[Test Author] Test Book")
 	require.NoError(t, os.MkdirAll(bookDir, 0755))
 
-	// Create library with OrganizeFileStructure enabled
+	// Create library with organizing enabled
 	tc.createLibraryWithOptions([]string{libraryPath}, true)
 
 	// Create a real supplement file on disk
@@ -4675,7 +4806,7 @@ func TestScanFileCore_PDFCoverPageRestoredFromSidecar(t *testing.T) {
 	pdfPath := testgen.GeneratePDF(t, bookDir, "book.pdf", testgen.PDFOptions{PageCount: 5})
 
 	// Create book and file records simulating a prior scan where CoverPage
-	// started at 0 (the default from pdf.Parse).
+	// started at 1 (the first page, the default from pdf.Parse).
 	book := &models.Book{
 		LibraryID:    1,
 		Filepath:     bookDir,
@@ -4686,7 +4817,8 @@ func TestScanFileCore_PDFCoverPageRestoredFromSidecar(t *testing.T) {
 	}
 	require.NoError(t, tc.bookService.CreateBook(tc.ctx, book))
 
-	zero := 0
+	one := 1
+	pageCount := 5
 	filepathSource := models.DataSourceFilepath
 	file := &models.File{
 		LibraryID:     1,
@@ -4694,15 +4826,16 @@ func TestScanFileCore_PDFCoverPageRestoredFromSidecar(t *testing.T) {
 		Filepath:      pdfPath,
 		FileType:      models.FileTypePDF,
 		FilesizeBytes: 1000,
-		CoverPage:     &zero,
+		CoverPage:     &one,
+		PageCount:     &pageCount,
 		CoverSource:   &filepathSource,
 	}
 	require.NoError(t, tc.bookService.CreateFile(tc.ctx, file))
 
 	// Write a sidecar with a user-selected cover page (2) that differs from
-	// the default (0). On rescan, the scanner should honor this.
+	// the default (1). On rescan, the scanner should honor this.
 	sidecarPath := pdfPath + ".metadata.json"
-	sidecarContent := `{"version":1,"cover_page":2}`
+	sidecarContent := `{"version":2,"cover_page":2}`
 	require.NoError(t, os.WriteFile(sidecarPath, []byte(sidecarContent), 0644))
 
 	// Fresh metadata from a re-parse: pdf.Parse always returns CoverPage=0.
@@ -4741,7 +4874,7 @@ func TestApplyFilepathFallbacks_PopulatesTitleFromFilepath(t *testing.T) {
 		DataSource: models.DataSourceEPUBMetadata,
 	}
 
-	applyFilepathFallbacks(metadata, "/library/[Author Name] Book Title.epub", "/library/[Author Name] Book Title", "epub", true)
+	applyFilepathFallbacks(metadata, "/library/[Author Name] Book Title.epub", "/library/[Author Name] Book Title", "epub", true, nil, nil)
 
 	assert.Equal(t, "Book Title", metadata.Title)
 	assert.Equal(t, models.DataSourceFilepath, metadata.SourceForField("title"))
@@ -4755,7 +4888,7 @@ func TestApplyFilepathFallbacks_PreservesExistingTitle(t *testing.T) {
 		DataSource: models.DataSourceEPUBMetadata,
 	}
 
-	applyFilepathFallbacks(metadata, "/library/[Author] Something.epub", "/library/[Author] Something", "epub", true)
+	applyFilepathFallbacks(metadata, "/library/[Author] Something.epub", "/library/[Author] Something", "epub", true, nil, nil)
 
 	assert.Equal(t, "Embedded Title", metadata.Title)
 }
@@ -4767,7 +4900,7 @@ func TestApplyFilepathFallbacks_PopulatesAuthorsFromFilepath(t *testing.T) {
 		DataSource: models.DataSourceEPUBMetadata,
 	}
 
-	applyFilepathFallbacks(metadata, "/library/[Jane Doe] Book.epub", "/library/[Jane Doe] Book", "epub", true)
+	applyFilepathFallbacks(metadata, "/library/[Jane Doe] Book.epub", "/library/[Jane Doe] Book", "epub", true, nil, nil)
 
 	require.Len(t, metadata.Authors, 1)
 	assert.Equal(t, "Jane Doe", metadata.Authors[0].Name)
@@ -4781,12 +4914,26 @@ func TestApplyFilepathFallbacks_PreservesExistingAuthors(t *testing.T) {
 		DataSource: models.DataSourceEPUBMetadata,
 	}
 
-	applyFilepathFallbacks(metadata, "/library/[Other Author] Book.epub", "/library/[Other Author] Book", "epub", true)
+	applyFilepathFallbacks(metadata, "/library/[Other Author] Book.epub", "/library/[Other Author] Book", "epub", true, nil, nil)
 
 	require.Len(t, metadata.Authors, 1)
 	assert.Equal(t, "Embedded Author", metadata.Authors[0].Name)
 }
 
+func TestAppendFilepathAuthors(t *testing.T) {
+	t.Parallel()
+
+	authors := []mediafile.ParsedAuthor{{Name: "Jane Doe", Role: "writer"}}
+
+	got := appendFilepathAuthors(authors, []string{"jane  doe", "John Doe", "John Doe"})
+	assert.Equal(t, []mediafile.ParsedAuthor{
+		{Name: "Jane Doe", Role: "writer"},
+		{Name: "John Doe"},
+	}, got)
+
+	assert.Equal(t, authors, appendFilepathAuthors(authors, nil))
+}
+
 func TestApplyFilepathFallbacks_PopulatesNarratorsFromFilepath(t *testing.T) {
 	t.Parallel()
 
@@ -4794,7 +4941,7 @@ func TestApplyFilepathFallbacks_PopulatesNarratorsFromFilepath(t *testing.T) {
 		DataSource: models.DataSourceM4BMetadata,
 	}
 
-	applyFilepathFallbacks(metadata, "/library/[Author] Title {Narrator Name}.m4b", "/library/[Author] Title", "m4b", true)
+	applyFilepathFallbacks(metadata, "/library/[Author] Title {Narrator Name}.m4b", "/library/[Author] Title", "m4b", true, nil, nil)
 
 	require.Len(t, metadata.Narrators, 1)
 	assert.Equal(t, "Narrator Name", metadata.Narrators[0])
@@ -4807,7 +4954,7 @@ func TestApplyFilepathFallbacks_PopulatesSeriesFromTitle(t *testing.T) {
 		DataSource: models.DataSourceCBZMetadata,
 	}
 
-	applyFilepathFallbacks(metadata, "/library/My Series v3.cbz", "/library/My Series v3", "cbz", true)
+	applyFilepathFallbacks(metadata, "/library/My Series v3.cbz", "/library/My Series v3", "cbz", true, nil, nil)
 
 	assert.NotEmpty(t, metadata.Series)
 }
@@ -4821,12 +4968,79 @@ func TestApplyFilepathFallbacks_DoesNotMixUnitIntoPluginSeriesGroup(t *testing.T
 		DataSource:   models.DataSourcePlugin,
 	}
 
-	applyFilepathFallbacks(metadata, "/library/My Series v3.cbz", "/library/My Series v3", "cbz", true)
+	applyFilepathFallbacks(metadata, "/library/My Series v3.cbz", "/library/My Series v3", "cbz", true, nil, nil)
 
 	assert.Nil(t, metadata.SeriesNumberUnit)
 	assert.Equal(t, models.DataSourcePlugin, metadata.SourceForField("series"))
 }
 
+func TestApplyFilepathFallbacks_CustomPatterns(t *testing.T) {
+	t.Parallel()
+
+	patterns, err := fileutils.CompileFilenamePatterns([]string{
+		`^\((?P<author>[^)]+)\) - (?P<series>.+?) (?P<number>\d+) - (?P<title>.+)$`,
+		`^\((?P<author>[^)]+)\) - (?P<title>.+?)(?: read by (?P<narrator>.+))?$`,
+	})
+	require.NoError(t, err)
+
+	metadata := &mediafile.ParsedMetadata{DataSource: models.DataSourceM4BMetadata}
+	applyFilepathFallbacks(metadata,
+		"/library/(Frank Herbert) - Dune 2 - Dune Messiah/(Frank Herbert) - Dune Messiah read by Scott Brick.m4b",
+		"/library/(Frank Herbert) - Dune 2 - Dune Messiah", "m4b", false, patterns, nil)
+
+	assert.Equal(t, "Dune Messiah", metadata.Title)
+	require.Len(t, metadata.Authors, 1)
+	assert.Equal(t, "Frank Herbert", metadata.Authors[0].Name)
+	assert.Equal(t, []string{"Scott Brick"}, metadata.Narrators)
+	assert.Equal(t, "Dune", metadata.Series)
+	require.NotNil(t, metadata.SeriesNumber)
+	assert.InEpsilon(t, 2.0, *metadata.SeriesNumber, 0.0001)
+	assert.Equal(t, models.DataSourceFilepath, metadata.SourceForField("series"))
+
+	// Names the patterns don't match still use the built-in conventions
+	metadata = &mediafile.ParsedMetadata{DataSource: models.DataSourceEPUBMetadata}
+	applyFilepathFallbacks(metadata, "/library/[Jane Doe] Book.epub", "/library/[Jane Doe] Book.epub", "epub", true, patterns, nil)
+	require.Len(t, metadata.Authors, 1)
+	assert.Equal(t, "Jane Doe", metadata.Authors[0].Name)
+	assert.Equal(t, "Book", metadata.Title)
+}
+
+func TestApplyParentDirSeries(t *testing.T) {
+	t.Parallel()
+
+	libraryPaths := []*models.LibraryPath{{Filepath: "/library"}}
+
+	tests := []struct {
+		name       string
+		bookPath   string
+		title      string
+		series     string
+		wantSeries string
+		wantNumber *float64
+		wantTitle  string
+	}{
+		{"numbered volume", "/library/The Expanse/01 - Leviathan Wakes", "", "", "The Expanse", seriesFloatPtr(1), "Leviathan Wakes"},
+		{"dot separator and decimal", "/library/Discworld/2.5. Interlude", "", "", "Discworld", seriesFloatPtr(2.5), "Interlude"},
+		{"keeps embedded title", "/library/The Expanse/03 - Abaddons Gate", "Abaddon's Gate", "", "The Expanse", seriesFloatPtr(3), "Abaddon's Gate"},
+		{"strips author pattern from series dir", "/library/[James S. A. Corey] The Expanse/02 - Calibans War", "", "", "The Expanse", seriesFloatPtr(2), "Calibans War"},
+		{"keeps existing series", "/library/The Expanse/01 - Leviathan Wakes", "", "Embedded Series", "Embedded Series", nil, ""},
+		{"not a numbered volume", "/library/The Expanse/Leviathan Wakes", "", "", "", nil, ""},
+		{"series dir is the library path", "/library/01 - Leviathan Wakes", "", "", "", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			metadata := &mediafile.ParsedMetadata{Title: tt.title, Series: tt.series}
+
+			applyParentDirSeries(metadata, tt.bookPath, libraryPaths)
+
+			assert.Equal(t, tt.wantSeries, metadata.Series)
+			assert.Equal(t, tt.wantNumber, metadata.SeriesNumber)
+			assert.Equal(t, tt.wantTitle, metadata.Title)
+		})
+	}
+}
+
 // =============================================================================
 // resetBookFileState tests
 // =============================================================================
@@ -5143,6 +5357,72 @@ func TestScanFileByID_ResetMode_ClearsNonFileMetadata(t *testing.T) {
 	assert.FileExists(t, newCoverPath, "new cover file should exist on disk after reset")
 }
 
+func TestScanFileByID_ResetMode_KeepsLockedFields(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.LockedFields = []string{"description", "genres", "language"}
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+	bookDir := testgen.CreateSubDir(t, libraryPath, "[Test Author] Locked Book")
+	testgen.GenerateEPUB(t, bookDir, "locked-book.epub", testgen.EPUBOptions{
+		Title:   "Locked Book",
+		Authors: []string{"Test Author"},
+	})
+	require.NoError(t, tc.runScan())
+
+	allBooks := tc.listBooks()
+	require.Len(t, allBooks, 1)
+	bookID := allBooks[0].ID
+	files := tc.listFiles()
+	require.Len(t, files, 1)
+	fileID := files[0].ID
+
+	// Curate locked and unlocked fields by hand.
+	manualSource := models.DataSourceManual
+	subtitle := "Manual Subtitle"
+	description := "Manual Description"
+	book := allBooks[0]
+	book.Subtitle = &subtitle
+	book.SubtitleSource = &manualSource
+	book.Description = &description
+	book.DescriptionSource = &manualSource
+	require.NoError(t, tc.bookService.UpdateBook(tc.ctx, book, books.UpdateBookOptions{
+		Columns: []string{"subtitle", "subtitle_source", "description", "description_source"},
+	}))
+	genre, err := tc.worker.genreService.FindOrCreateGenre(tc.ctx, "Romance", book.LibraryID)
+	require.NoError(t, err)
+	require.NoError(t, tc.bookService.CreateBookGenre(tc.ctx, &models.BookGenre{BookID: bookID, GenreID: genre.ID}))
+	lang := "fr"
+	file := files[0]
+	file.Language = &lang
+	file.LanguageSource = &manualSource
+	require.NoError(t, tc.bookService.UpdateFile(tc.ctx, file, books.UpdateFileOptions{
+		Columns: []string{"language", "language_source"},
+	}))
+
+	_, err = tc.worker.scanInternal(tc.ctx, ScanOptions{
+		FileID:       fileID,
+		ForceRefresh: true,
+		SkipPlugins:  true,
+		Reset:        true,
+	}, nil)
+	require.NoError(t, err)
+
+	book, err = tc.bookService.RetrieveBook(tc.ctx, books.RetrieveBookOptions{ID: &bookID})
+	require.NoError(t, err)
+	file, err = tc.bookService.RetrieveFileWithRelations(tc.ctx, fileID)
+	require.NoError(t, err)
+
+	assert.Nil(t, book.Subtitle, "unlocked fields are still wiped")
+	require.NotNil(t, book.Description, "locked fields survive a reset")
+	assert.Equal(t, "Manual Description", *book.Description)
+	require.Len(t, book.BookGenres, 1)
+	assert.Equal(t, "Romance", book.BookGenres[0].Genre.Name)
+	require.NotNil(t, file.Language)
+	assert.Equal(t, "fr", *file.Language)
+}
+
 func TestScanFileByID_ResetMode_ReExtractsChapters(t *testing.T) {
 	t.Parallel()
 	testgen.SkipIfNoFFmpeg(t)
@@ -5363,7 +5643,7 @@ func TestScanFileByID_ResetMode_OrganizesWithAuthors(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
 
-	// Setup: Library with OrganizeFileStructure enabled
+	// Setup: Library with organizing enabled
 	libraryPath := testgen.TempLibraryDir(t)
 	tc.createLibraryWithOptions([]string{libraryPath}, true)
 
@@ -5728,3 +6008,85 @@ func TestScanFileCore_Description_StripsHTMLFromMetadata(t *testing.T) {
 	require.NotNil(t, reloaded.Description)
 	assert.Equal(t, "Hello world", *reloaded.Description, "description HTML must be stripped from scan metadata")
 }
+
+func TestScan_DirPath_ScopesToSubtree(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+
+	keepDir := testgen.CreateSubDir(t, libraryPath, "Kept Book")
+	testgen.GenerateEPUB(t, keepDir, "kept.epub", testgen.EPUBOptions{Title: "Kept Book"})
+	goneDir := testgen.CreateSubDir(t, libraryPath, "Series/Gone Book")
+	testgen.GenerateEPUB(t, goneDir, "gone.epub", testgen.EPUBOptions{Title: "Gone Book"})
+	require.NoError(t, tc.runScan())
+	require.Len(t, tc.listBooks(), 2)
+
+	// Remove both books on disk and add a new one inside the subtree.
+	require.NoError(t, os.RemoveAll(keepDir))
+	require.NoError(t, os.RemoveAll(goneDir))
+	newDir := testgen.CreateSubDir(t, libraryPath, "Series/New Book")
+	testgen.GenerateEPUB(t, newDir, "new.epub", testgen.EPUBOptions{Title: "New Book"})
+
+	jobLog := tc.jobLogService.NewJobLogger(tc.ctx, 0, tc.worker.log)
+	result, err := tc.worker.scanInternal(tc.ctx, ScanOptions{
+		DirPath:   filepath.Join(libraryPath, "Series"),
+		LibraryID: 1,
+		JobLog:    jobLog,
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	assert.True(t, result.Files[0].FileCreated)
+
+	// The book outside the subtree is left alone even though it's missing
+	// on disk; only the orphan inside the subtree is cleaned up.
+	titles := make([]string, 0)
+	for _, b := range tc.listBooks() {
+		titles = append(titles, b.Title)
+	}
+	assert.ElementsMatch(t, []string{"Kept Book", "New Book"}, titles)
+}
+
+func TestScan_DirPath_RejectsOutsideLibrary(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+
+	jobLog := tc.jobLogService.NewJobLogger(tc.ctx, 0, tc.worker.log)
+	_, err := tc.worker.scanInternal(tc.ctx, ScanOptions{
+		DirPath:   t.TempDir(),
+		LibraryID: 1,
+		JobLog:    jobLog,
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "inside one of the library's paths")
+}
+
+func TestProcessScanJob_DirPathScansOnlyThatDirectory(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+
+	outsideDir := testgen.CreateSubDir(t, libraryPath, "Outside Book")
+	testgen.GenerateEPUB(t, outsideDir, "outside.epub", testgen.EPUBOptions{Title: "Outside Book"})
+	seriesDir := testgen.CreateSubDir(t, libraryPath, "Series/Inside Book")
+	testgen.GenerateEPUB(t, seriesDir, "inside.epub", testgen.EPUBOptions{Title: "Inside Book"})
+
+	libraryID := 1
+	job := &models.Job{
+		Type:       models.JobTypeScan,
+		LibraryID:  &libraryID,
+		DataParsed: &models.JobScanData{DirPath: filepath.Join(libraryPath, "Series")},
+	}
+	jobLog := tc.jobLogService.NewJobLogger(tc.ctx, 0, tc.worker.log)
+	require.NoError(t, tc.worker.ProcessScanJob(tc.ctx, job, jobLog))
+
+	allBooks := tc.listBooks()
+	require.Len(t, allBooks, 1)
+	assert.Equal(t, "Inside Book", allBooks[0].Title)
+}
@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"github.com/robinjoseph08/golib/logger"
+)
+
+// Source decision results.
+const (
+	sourceDecisionUpdated = "updated"
+	sourceDecisionKept    = "kept"
+	sourceDecisionLocked  = "locked"
+)
+
+// sourceDecision records how scanFileCore resolved a single field: which
+// sources offered a value, and which one the stored field ended up with.
+type sourceDecision struct {
+	Field            string
+	ChosenSource     string
+	CandidateSources []string
+	Result           string
+}
+
+// sourceDecisionLog collects per-field source decisions during a scan so
+// they can be emitted as one structured "source_decision" entry per field.
+// Each field can be considered several times (e.g. from file metadata and
+// then from a sidecar); the last source that won is the chosen one.
+//
+// It is also where config.LockedFields is enforced: decide never lets a
+// locked field be updated, so every update block that goes through it is
+// guarded.
+type sourceDecisionLog struct {
+	order     []string
+	decisions map[string]*sourceDecision
+	locked    map[string]struct{}
+}
+
+func newSourceDecisionLog(lockedFields []string) *sourceDecisionLog {
+	locked := make(map[string]struct{}, len(lockedFields))
+	for _, field := range lockedFields {
+		locked[field] = struct{}{}
+	}
+	return &sourceDecisionLog{decisions: map[string]*sourceDecision{}, locked: locked}
+}
+
+// decide records that candidateSource offered a value for field and passes
+// through apply, the result of the priority check for that candidate, unless
+// field is locked, in which case it always returns false. existingSource is
+// the field's source before this scan; only the first call for a field
+// records it.
+func (l *sourceDecisionLog) decide(field, candidateSource, existingSource string, apply bool) bool {
+	d, ok := l.decisions[field]
+	if !ok {
+		d = &sourceDecision{
+			Field:        field,
+			ChosenSource: existingSource,
+			Result:       sourceDecisionKept,
+		}
+		l.decisions[field] = d
+		l.order = append(l.order, field)
+	}
+	d.CandidateSources = append(d.CandidateSources, candidateSource)
+	if _, ok := l.locked[field]; ok {
+		d.Result = sourceDecisionLocked
+		return false
+	}
+	if apply {
+		d.ChosenSource = candidateSource
+		d.Result = sourceDecisionUpdated
+	}
+	return apply
+}
+
+// list returns the decisions in the order their fields were first considered.
+func (l *sourceDecisionLog) list() []*sourceDecision {
+	out := make([]*sourceDecision, 0, len(l.order))
+	for _, field := range l.order {
+		out = append(out, l.decisions[field])
+	}
+	return out
+}
+
+// emit writes one "source_decision" debug entry per field. These only go to
+// the process log (visible with LOG_LEVEL=debug), not the job log, since a
+// full scan produces one per field per file.
+func (l *sourceDecisionLog) emit(log logger.Logger, fileID int) {
+	for _, d := range l.list() {
+		log.Debug("source_decision", logger.Data{
+			"file_id":           fileID,
+			"field":             d.Field,
+			"chosen_source":     d.ChosenSource,
+			"candidate_sources": d.CandidateSources,
+			"result":            d.Result,
+		})
+	}
+}
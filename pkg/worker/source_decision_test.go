@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceDecisionLog(t *testing.T) {
+	t.Parallel()
+
+	l := newSourceDecisionLog(nil)
+
+	// File metadata wins over the filepath-derived title, then the sidecar
+	// wins over that.
+	assert.True(t, l.decide("title", models.DataSourceEPUBMetadata, models.DataSourceFilepath, true))
+	assert.True(t, l.decide("title", models.DataSourceSidecar, models.DataSourceEPUBMetadata, true))
+	// A plugin value loses to a manual edit.
+	assert.False(t, l.decide("authors", models.DataSourcePluginPrefix+"test", models.DataSourceManual, false))
+
+	decisions := l.list()
+	require.Len(t, decisions, 2)
+
+	assert.Equal(t, "title", decisions[0].Field)
+	assert.Equal(t, models.DataSourceSidecar, decisions[0].ChosenSource)
+	assert.Equal(t, []string{models.DataSourceEPUBMetadata, models.DataSourceSidecar}, decisions[0].CandidateSources)
+	assert.Equal(t, sourceDecisionUpdated, decisions[0].Result)
+
+	assert.Equal(t, "authors", decisions[1].Field)
+	assert.Equal(t, models.DataSourceManual, decisions[1].ChosenSource)
+	assert.Equal(t, []string{models.DataSourcePluginPrefix + "test"}, decisions[1].CandidateSources)
+	assert.Equal(t, sourceDecisionKept, decisions[1].Result)
+}
+
+func TestSourceDecisionLog_LockedFields(t *testing.T) {
+	t.Parallel()
+
+	l := newSourceDecisionLog([]string{"description"})
+
+	assert.False(t, l.decide("description", models.DataSourceSidecar, models.DataSourceEPUBMetadata, true))
+	assert.True(t, l.decide("title", models.DataSourceSidecar, models.DataSourceEPUBMetadata, true))
+
+	decisions := l.list()
+	require.Len(t, decisions, 2)
+	assert.Equal(t, models.DataSourceEPUBMetadata, decisions[0].ChosenSource)
+	assert.Equal(t, sourceDecisionLocked, decisions[0].Result)
+	assert.Equal(t, sourceDecisionUpdated, decisions[1].Result)
+}
@@ -60,6 +60,38 @@ func TestProcessScanJob_SupplementsInDirectory(t *testing.T) {
 	assert.Equal(t, 2, supplementFiles)
 }
 
+func TestProcessScanJob_SupplementKinds(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	tc.worker.config.SupplementKinds = map[string]string{"mp3": models.SupplementKindAudio, "jpg": models.SupplementKindImage}
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+
+	bookDir := testgen.CreateSubDir(t, libraryPath, "[Author] My Book")
+	testgen.GenerateEPUB(t, bookDir, "book.epub", testgen.EPUBOptions{})
+	for _, name := range []string{"bonus.mp3", "map.jpg", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(bookDir, name), []byte(name), 0644))
+	}
+
+	require.NoError(t, tc.runScan())
+
+	kinds := map[string]string{}
+	for _, f := range tc.listFiles() {
+		if f.FileRole == models.FileRoleMain {
+			assert.Nil(t, f.SupplementKind, "main files have no supplement kind")
+			continue
+		}
+		require.NotNil(t, f.SupplementKind)
+		kinds[filepath.Base(f.Filepath)] = *f.SupplementKind
+	}
+	assert.Equal(t, map[string]string{
+		"bonus.mp3": models.SupplementKindAudio,
+		"map.jpg":   models.SupplementKindImage,
+		"notes.txt": models.SupplementKindDocument,
+	}, kinds)
+}
+
 func TestProcessScanJob_SupplementsExcludeHiddenFiles(t *testing.T) {
 	t.Parallel()
 	tc := newTestContext(t)
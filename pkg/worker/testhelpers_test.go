@@ -24,6 +24,7 @@ import (
 	"github.com/shishobooks/shisho/pkg/search"
 	"github.com/shishobooks/shisho/pkg/series"
 	"github.com/shishobooks/shisho/pkg/tags"
+	"github.com/shishobooks/shisho/pkg/thumbnails"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/sqlitedialect"
 	"github.com/uptrace/bun/driver/sqliteshim"
@@ -124,6 +125,7 @@ func newTestContext(t *testing.T) *testContext {
 		publisherService:   publisherService,
 		fingerprintService: fingerprintService,
 		appSettingsService: appSettingsService,
+		thumbnailCache:     thumbnails.NewCache(t.TempDir()),
 	}
 
 	// Create context with logger
@@ -164,6 +166,7 @@ func (tc *testContext) initWorkerRuntime() {
 	tc.worker.doneFetching = make(chan struct{})
 	tc.worker.doneProcessing = make(chan struct{}, tc.worker.config.WorkerProcesses)
 	tc.worker.doneScheduling = make(chan struct{})
+	tc.worker.doneLibraryScheduling = make(chan struct{})
 	tc.worker.doneUpdateCheck = make(chan struct{})
 	tc.worker.queue = make(chan *models.Job, tc.worker.config.WorkerProcesses)
 	tc.worker.ctx, tc.worker.cancel = context.WithCancel(context.Background())
@@ -193,7 +196,7 @@ func (tc *testContext) createLibrary(paths []string) {
 }
 
 // createLibraryWithOptions creates a test library with custom options.
-func (tc *testContext) createLibraryWithOptions(paths []string, organizeFileStructure bool) {
+func (tc *testContext) createLibraryWithOptions(paths []string, organize bool) {
 	tc.t.Helper()
 
 	libraryPaths := make([]*models.LibraryPath, len(paths))
@@ -204,10 +207,11 @@ func (tc *testContext) createLibraryWithOptions(paths []string, organizeFileStru
 	}
 
 	library := &models.Library{
-		Name:                  "Test Library",
-		OrganizeFileStructure: organizeFileStructure,
-		CoverAspectRatio:      "book",
-		LibraryPaths:          libraryPaths,
+		Name:             "Test Library",
+		OrganizeFiles:    organize,
+		OrganizeFolders:  organize,
+		CoverAspectRatio: "book",
+		LibraryPaths:     libraryPaths,
 	}
 
 	err := tc.libraryService.CreateLibrary(tc.ctx, library)
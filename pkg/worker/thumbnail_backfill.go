@@ -0,0 +1,193 @@
+package worker
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/segmentio/encoding/json"
+	"github.com/shishobooks/shisho/pkg/joblogs"
+	"github.com/shishobooks/shisho/pkg/jobs"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/thumbnails"
+)
+
+// thumbnailBackfillBatchSize is how many files are handled between
+// checkpoints.
+const thumbnailBackfillBatchSize = 100
+
+// thumbnailBackfillResumeTimeout bounds the write that re-queues a backfill
+// interrupted by shutdown, since the job's own context is already cancelled.
+const thumbnailBackfillResumeTimeout = 5 * time.Second
+
+// ProcessThumbnailBackfillJob generates cover thumbnails for every file with a
+// cover in a library. Files are handled in ID order in batches of
+// thumbnailBackfillBatchSize, at most data.Concurrency at a time, and the last
+// finished ID is saved to the job's data after each batch. A job cut off by
+// shutdown queues a new pending job that starts from that checkpoint, and
+// thumbnails that are already up to date are skipped, so no work is repeated.
+// Per-file errors are logged and skipped.
+func (w *Worker) ProcessThumbnailBackfillJob(ctx context.Context, job *models.Job, jobLog *joblogs.JobLogger) error {
+	data, ok := job.DataParsed.(*models.JobThumbnailBackfillData)
+	if !ok || data == nil || data.LibraryID == 0 {
+		return errors.New("invalid or missing job data for thumbnail backfill job")
+	}
+	if data.MaxDim == 0 {
+		data.MaxDim = thumbnails.DefaultMaxDim
+	}
+	if data.MaxDim < 0 || data.MaxDim > thumbnails.MaxDim {
+		return errors.Errorf("max_dim %d out of range (1-%d)", data.MaxDim, thumbnails.MaxDim)
+	}
+	concurrency := min(max(data.Concurrency, 1), runtime.NumCPU())
+
+	if err := ctx.Err(); err != nil {
+		w.requeueThumbnailBackfill(job, data, jobLog)
+		return err
+	}
+	total, done, err := w.bookService.CountFilesWithCovers(ctx, data.LibraryID, data.LastFileID)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			w.requeueThumbnailBackfill(job, data, jobLog)
+			return ctxErr
+		}
+		return errors.Wrap(err, "count files with covers")
+	}
+	jobLog.Info("processing thumbnail backfill job", logger.Data{
+		"library_id":   data.LibraryID,
+		"max_dim":      data.MaxDim,
+		"concurrency":  concurrency,
+		"files":        total,
+		"resume_after": data.LastFileID,
+	})
+
+	for {
+		if err := ctx.Err(); err != nil {
+			w.requeueThumbnailBackfill(job, data, jobLog)
+			return err
+		}
+
+		files, err := w.bookService.ListFilesWithCoversAfter(ctx, data.LibraryID, data.LastFileID, thumbnailBackfillBatchSize)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				w.requeueThumbnailBackfill(job, data, jobLog)
+				return ctxErr
+			}
+			return errors.Wrap(err, "list files with covers")
+		}
+		if len(files) == 0 {
+			break
+		}
+
+		data.Generated += w.runThumbnailBatch(ctx, files, data.MaxDim, concurrency, jobLog)
+		if err := ctx.Err(); err != nil {
+			// The batch may have been cut short, so don't checkpoint past it.
+			w.requeueThumbnailBackfill(job, data, jobLog)
+			return err
+		}
+
+		data.LastFileID = files[len(files)-1].ID
+		done += len(files)
+		if total > 0 {
+			job.Progress = min(done*100/total, 100)
+		}
+		if err := w.saveThumbnailBackfillCheckpoint(ctx, job, data); err != nil {
+			return err
+		}
+		jobLog.Info("thumbnail backfill progress", logger.Data{
+			"done":         done,
+			"total":        total,
+			"generated":    data.Generated,
+			"last_file_id": data.LastFileID,
+		})
+	}
+
+	jobLog.Info("thumbnail backfill complete", logger.Data{
+		"library_id": data.LibraryID,
+		"generated":  data.Generated,
+		"files":      total,
+	})
+	return nil
+}
+
+// runThumbnailBatch generates thumbnails for files using a pool of
+// concurrency workers and returns how many were written. Workers stop picking
+// up files once ctx is cancelled.
+func (w *Worker) runThumbnailBatch(ctx context.Context, files []*models.File, maxDim, concurrency int, jobLog *joblogs.JobLogger) int {
+	workCh := make(chan *models.File)
+	var generated int
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range workCh {
+				coverPath := filepath.Join(filepath.Dir(file.Filepath), *file.CoverImageFilename)
+				ok, err := w.thumbnailCache.Generate(coverPath, file.ID, maxDim)
+				if err != nil {
+					jobLog.Warn("failed to generate thumbnail", logger.Data{"file_id": file.ID, "error": err.Error()})
+					continue
+				}
+				if ok {
+					mu.Lock()
+					generated++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for _, file := range files {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case workCh <- file:
+		}
+	}
+	close(workCh)
+	wg.Wait()
+	return generated
+}
+
+// saveThumbnailBackfillCheckpoint persists the job's progress and data.
+func (w *Worker) saveThumbnailBackfillCheckpoint(ctx context.Context, job *models.Job, data *models.JobThumbnailBackfillData) error {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal thumbnail backfill checkpoint")
+	}
+	job.Data = string(dataBytes)
+	job.DataParsed = data
+	return w.jobService.UpdateJob(ctx, job, jobs.UpdateJobOptions{
+		Columns: []string{"data", "progress"},
+	})
+}
+
+// requeueThumbnailBackfill queues a pending copy of an interrupted backfill
+// that resumes from its last checkpoint. The interrupted job itself is marked
+// failed by the caller like any other job cancelled at shutdown.
+func (w *Worker) requeueThumbnailBackfill(job *models.Job, data *models.JobThumbnailBackfillData, jobLog *joblogs.JobLogger) {
+	ctx, cancel := context.WithTimeout(context.Background(), thumbnailBackfillResumeTimeout)
+	defer cancel()
+
+	resumed := *data
+	newJob := &models.Job{
+		Type:       models.JobTypeThumbnailBackfill,
+		Status:     models.JobStatusPending,
+		LibraryID:  job.LibraryID,
+		Progress:   job.Progress,
+		DataParsed: &resumed,
+	}
+	if err := w.jobService.CreateJob(ctx, newJob); err != nil {
+		jobLog.Warn("failed to queue resumed thumbnail backfill", logger.Data{"error": err.Error()})
+		return
+	}
+	jobLog.Info("queued thumbnail backfill to resume after restart", logger.Data{
+		"job_id":       newJob.ID,
+		"last_file_id": resumed.LastFileID,
+	})
+}
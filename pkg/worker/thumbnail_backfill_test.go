@@ -0,0 +1,129 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/robinjoseph08/golib/logger"
+	"github.com/shishobooks/shisho/internal/testgen"
+	"github.com/shishobooks/shisho/pkg/jobs"
+	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupThumbnailLibrary scans a library holding two EPUBs with covers and
+// returns their files in ID order.
+func setupThumbnailLibrary(t *testing.T, tc *testContext) []*models.File {
+	t.Helper()
+
+	libraryPath := testgen.TempLibraryDir(t)
+	tc.createLibrary([]string{libraryPath})
+	for _, title := range []string{"First Book", "Second Book"} {
+		bookDir := testgen.CreateSubDir(t, libraryPath, "[Test Author] "+title)
+		testgen.GenerateEPUB(t, bookDir, "book.epub", testgen.EPUBOptions{
+			Title:    title,
+			Authors:  []string{"Test Author"},
+			HasCover: true,
+		})
+	}
+	require.NoError(t, tc.runScan())
+
+	files := tc.listFiles()
+	require.Len(t, files, 2)
+	if files[0].ID > files[1].ID {
+		files[0], files[1] = files[1], files[0]
+	}
+	for _, f := range files {
+		require.NotNil(t, f.CoverImageFilename)
+	}
+	return files
+}
+
+// createThumbnailBackfillJob stores a backfill job so checkpoints can be saved.
+func createThumbnailBackfillJob(t *testing.T, tc *testContext, data *models.JobThumbnailBackfillData) *models.Job {
+	t.Helper()
+
+	job := &models.Job{
+		Type:       models.JobTypeThumbnailBackfill,
+		Status:     models.JobStatusInProgress,
+		DataParsed: data,
+	}
+	require.NoError(t, tc.jobService.CreateJob(tc.ctx, job))
+	return job
+}
+
+func TestThumbnailBackfillJob_GeneratesAndCheckpoints(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	files := setupThumbnailLibrary(t, tc)
+
+	job := createThumbnailBackfillJob(t, tc, &models.JobThumbnailBackfillData{LibraryID: 1, Concurrency: 2})
+	jobLog := tc.jobLogService.NewJobLogger(tc.ctx, job.ID, logger.FromContext(tc.ctx))
+	require.NoError(t, tc.worker.ProcessThumbnailBackfillJob(tc.ctx, job, jobLog))
+
+	for _, f := range files {
+		_, err := os.Stat(tc.worker.thumbnailCache.Path(f.ID, 400))
+		assert.NoError(t, err, "thumbnail should exist for file %d", f.ID)
+	}
+
+	saved, err := tc.jobService.RetrieveJob(tc.ctx, jobs.RetrieveJobOptions{ID: &job.ID})
+	require.NoError(t, err)
+	data, ok := saved.DataParsed.(*models.JobThumbnailBackfillData)
+	require.True(t, ok)
+	assert.Equal(t, files[1].ID, data.LastFileID)
+	assert.Equal(t, 2, data.Generated)
+	assert.Equal(t, 100, saved.Progress)
+
+	// Running again skips thumbnails that are already up to date.
+	job = createThumbnailBackfillJob(t, tc, &models.JobThumbnailBackfillData{LibraryID: 1})
+	require.NoError(t, tc.worker.ProcessThumbnailBackfillJob(tc.ctx, job, jobLog))
+	assert.Equal(t, 0, job.DataParsed.(*models.JobThumbnailBackfillData).Generated)
+}
+
+func TestThumbnailBackfillJob_ResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	files := setupThumbnailLibrary(t, tc)
+
+	job := createThumbnailBackfillJob(t, tc, &models.JobThumbnailBackfillData{
+		LibraryID:  1,
+		MaxDim:     200,
+		LastFileID: files[0].ID,
+	})
+	jobLog := tc.jobLogService.NewJobLogger(tc.ctx, job.ID, logger.FromContext(tc.ctx))
+	require.NoError(t, tc.worker.ProcessThumbnailBackfillJob(tc.ctx, job, jobLog))
+
+	_, err := os.Stat(tc.worker.thumbnailCache.Path(files[0].ID, 200))
+	assert.True(t, os.IsNotExist(err), "files before the checkpoint should be skipped")
+	_, err = os.Stat(tc.worker.thumbnailCache.Path(files[1].ID, 200))
+	assert.NoError(t, err)
+}
+
+func TestThumbnailBackfillJob_RequeuesOnShutdown(t *testing.T) {
+	t.Parallel()
+	tc := newTestContext(t)
+	files := setupThumbnailLibrary(t, tc)
+
+	job := createThumbnailBackfillJob(t, tc, &models.JobThumbnailBackfillData{
+		LibraryID:  1,
+		LastFileID: files[0].ID,
+	})
+	ctx, cancel := context.WithCancel(tc.ctx)
+	cancel()
+	jobLog := tc.jobLogService.NewJobLogger(tc.ctx, job.ID, logger.FromContext(tc.ctx))
+	err := tc.worker.ProcessThumbnailBackfillJob(ctx, job, jobLog)
+	require.ErrorIs(t, err, context.Canceled)
+
+	jobType := models.JobTypeThumbnailBackfill
+	pending, err := tc.jobService.ListJobs(tc.ctx, jobs.ListJobsOptions{
+		Type:     &jobType,
+		Statuses: []string{models.JobStatusPending},
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	data, ok := pending[0].DataParsed.(*models.JobThumbnailBackfillData)
+	require.True(t, ok)
+	assert.Equal(t, files[0].ID, data.LastFileID)
+}
@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"math/rand"
+	"sync/atomic"
 	"time"
 
 	"github.com/shishobooks/shisho/pkg/aliases"
@@ -11,18 +12,24 @@ import (
 	"github.com/shishobooks/shisho/pkg/chapters"
 	"github.com/shishobooks/shisho/pkg/downloadcache"
 	"github.com/shishobooks/shisho/pkg/events"
+	"github.com/shishobooks/shisho/pkg/fileutils"
 	"github.com/shishobooks/shisho/pkg/fingerprints"
 	"github.com/shishobooks/shisho/pkg/genres"
 	"github.com/shishobooks/shisho/pkg/joblogs"
 	"github.com/shishobooks/shisho/pkg/jobs"
 	"github.com/shishobooks/shisho/pkg/libraries"
+	"github.com/shishobooks/shisho/pkg/metrics"
 	"github.com/shishobooks/shisho/pkg/models"
+	"github.com/shishobooks/shisho/pkg/openlibrary"
 	"github.com/shishobooks/shisho/pkg/people"
 	"github.com/shishobooks/shisho/pkg/plugins"
 	"github.com/shishobooks/shisho/pkg/publishers"
 	"github.com/shishobooks/shisho/pkg/search"
 	"github.com/shishobooks/shisho/pkg/series"
 	"github.com/shishobooks/shisho/pkg/tags"
+	"github.com/shishobooks/shisho/pkg/thumbnails"
+	"github.com/shishobooks/shisho/pkg/version"
+	"github.com/shishobooks/shisho/pkg/webhooks"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -64,41 +71,66 @@ type Worker struct {
 	pluginService *plugins.Service
 	pluginManager *plugins.Manager
 
-	broker        *events.Broker
-	downloadCache *downloadcache.Cache
+	broker         *events.Broker
+	downloadCache  *downloadcache.Cache
+	thumbnailCache *thumbnails.Cache
+
+	// openLibrary backs the built-in Open Library enricher. Nil disables it.
+	openLibrary *openlibrary.Service
+
+	// webhooks delivers book and scan events to the configured webhooks. Nil
+	// when none are configured.
+	webhooks *webhooks.Dispatcher
 
 	monitor *Monitor
 
-	queue           chan *models.Job
-	shutdown        chan struct{}
-	doneFetching    chan struct{}
-	doneProcessing  chan struct{}
-	doneScheduling  chan struct{}
-	doneCleanup     chan struct{}
-	doneUpdateCheck chan struct{}
+	// scans coalesces identical single-target scans requested through Scan.
+	scans scanCoalescer
+
+	queue                 chan *models.Job
+	shutdown              chan struct{}
+	doneFetching          chan struct{}
+	doneProcessing        chan struct{}
+	doneScheduling        chan struct{}
+	doneLibraryScheduling chan struct{}
+	doneCleanup           chan struct{}
+	doneUpdateCheck       chan struct{}
 
 	// ctx is the worker-wide context cancelled by Shutdown. It is the parent
 	// for every job handler's context (hash generation, scans, bulk downloads)
 	// AND for the DB calls inside fetchJobs/scheduleScanJobs/cleanupOldJobs/
-	// checkPluginUpdates. Without this, a hash-gen job iterating a large
+	// checkPluginUpdates/scheduleLibraryScans. Without this, a hash-gen job iterating a large
 	// library would keep processJobs busy past air's 1s kill_delay (and the
 	// next `mise start` reload would race the outgoing process for port
 	// 3689), and a slow scheduler DB query would block the scheduler goroutine
 	// from observing shutdown until the query returned.
 	ctx    context.Context
 	cancel context.CancelFunc
+
+	// running is true between Start and Shutdown, and inFlight counts the
+	// job handlers currently executing. Both are reported by /readyz.
+	running  atomic.Bool
+	inFlight atomic.Int32
 }
 
 func New(cfg *config.Config, db *bun.DB, pm *plugins.Manager, broker *events.Broker, dlCache *downloadcache.Cache) *Worker {
 	aliasService := aliases.NewService(db)
 	appSettingsService := appsettings.NewService(db)
-	bookService := books.NewService(db).WithAppSettings(appSettingsService)
+	hooks := webhooks.NewDispatcher(cfg.Webhooks)
+	bookService := books.NewService(db).
+		WithAppSettings(appSettingsService).
+		WithFilenameSanitizer(fileutils.SanitizeOptions{Mode: cfg.OrganizeFilenameMode}).
+		WithWebhooks(hooks).
+		WithPersonNameLocale(cfg.PersonNameLocale)
+	if pm != nil {
+		bookService.WithPathOrganizer(pm)
+	}
 	chapterService := chapters.NewService(db)
 	genreService := genres.NewService(db)
 	jobService := jobs.NewService(db)
 	jobLogService := joblogs.NewService(db)
 	libraryService := libraries.NewService(db)
-	personService := people.NewService(db)
+	personService := people.NewService(db).WithNameLocale(cfg.PersonNameLocale)
 	publisherService := publishers.NewService(db)
 	searchService := search.NewService(db)
 	seriesService := series.NewService(db)
@@ -134,21 +166,31 @@ func New(cfg *config.Config, db *bun.DB, pm *plugins.Manager, broker *events.Bro
 		pluginManager:      pm,
 		broker:             broker,
 		downloadCache:      dlCache,
-
-		queue:           make(chan *models.Job, cfg.WorkerProcesses),
-		shutdown:        make(chan struct{}),
-		doneFetching:    make(chan struct{}),
-		doneProcessing:  make(chan struct{}, cfg.WorkerProcesses),
-		doneScheduling:  make(chan struct{}),
-		doneCleanup:     make(chan struct{}),
-		doneUpdateCheck: make(chan struct{}),
+		thumbnailCache:     thumbnails.NewCache(cfg.CacheDir),
+		webhooks:           hooks,
+		openLibrary: openlibrary.NewService(openlibrary.ServiceConfig{
+			UserAgent: "Shisho/" + version.Version,
+			CacheDir:  cfg.CacheDir,
+		}),
+
+		queue:                 make(chan *models.Job, cfg.WorkerProcesses),
+		shutdown:              make(chan struct{}),
+		doneFetching:          make(chan struct{}),
+		doneProcessing:        make(chan struct{}, cfg.WorkerProcesses),
+		doneScheduling:        make(chan struct{}),
+		doneLibraryScheduling: make(chan struct{}),
+		doneCleanup:           make(chan struct{}),
+		doneUpdateCheck:       make(chan struct{}),
 	}
 
 	w.processFuncs = map[string]func(ctx context.Context, job *models.Job, jobLog *joblogs.JobLogger) error{
-		models.JobTypeScan:            w.ProcessScanJob,
-		models.JobTypeBulkDownload:    w.ProcessBulkDownloadJob,
-		models.JobTypeHashGeneration:  w.ProcessHashGenerationJob,
-		models.JobTypeRecomputeReview: w.ProcessRecomputeReviewJob,
+		models.JobTypeScan:                 w.ProcessScanJob,
+		models.JobTypeBulkDownload:         w.ProcessBulkDownloadJob,
+		models.JobTypeHashGeneration:       w.ProcessHashGenerationJob,
+		models.JobTypeRecomputeReview:      w.ProcessRecomputeReviewJob,
+		models.JobTypeThumbnailBackfill:    w.ProcessThumbnailBackfillJob,
+		models.JobTypeRegenerateSortTitles: w.ProcessRegenerateSortTitlesJob,
+		models.JobTypeRecompressComicPages: w.ProcessRecompressComicPagesJob,
 	}
 
 	if dlCache != nil {
@@ -173,6 +215,7 @@ func New(cfg *config.Config, db *bun.DB, pm *plugins.Manager, broker *events.Bro
 }
 
 func (w *Worker) Start() {
+	w.running.Store(true)
 	go w.fetchJobs()
 	for i := 0; i < w.config.WorkerProcesses; i++ {
 		go w.processJobs()
@@ -185,6 +228,7 @@ func (w *Worker) Start() {
 			w.doneScheduling <- struct{}{}
 		}()
 	}
+	go w.scheduleLibraryScans()
 	if w.config.JobRetentionDays > 0 {
 		go w.cleanupOldJobs()
 	}
@@ -268,6 +312,11 @@ func (w *Worker) processJobs() {
 
 			// Process with panic recovery
 			func() {
+				w.inFlight.Add(1)
+				defer w.inFlight.Add(-1)
+				defer func(start time.Time) {
+					metrics.JobDuration.WithLabelValues(job.Type, job.Status).Observe(time.Since(start).Seconds())
+				}(time.Now())
 				defer func() {
 					if r := recover(); r != nil {
 						jobLog.Fatal("job panicked", errors.Wrapf(errJobPanicked, "%v", r), logger.Data{"panic": r})
@@ -302,6 +351,8 @@ func (w *Worker) processJobs() {
 					//   - bulk_download is NOT re-queued automatically; a
 					//     partial zip is cheaper to abandon than to re-render
 					//     unprompted on restart, so the user re-initiates it
+					//   - thumbnail_backfill queues its own pending copy
+					//     that resumes from the last checkpoint
 					if !errors.Is(err, context.Canceled) {
 						jobLog.Error("job failed", err, nil)
 					}
@@ -510,7 +561,19 @@ func (w *Worker) RefreshMonitorWatches() {
 	}
 }
 
+// Running reports whether the worker loop has been started and not yet shut
+// down.
+func (w *Worker) Running() bool {
+	return w.running.Load()
+}
+
+// InFlightJobs returns the number of jobs this process is currently running.
+func (w *Worker) InFlightJobs() int {
+	return int(w.inFlight.Load())
+}
+
 func (w *Worker) Shutdown() {
+	w.running.Store(false)
 	if w.monitor != nil {
 		w.monitor.stop()
 	}
@@ -529,6 +592,7 @@ func (w *Worker) Shutdown() {
 
 	<-w.doneFetching
 	<-w.doneScheduling
+	<-w.doneLibraryScheduling
 	for i := 0; i < w.config.WorkerProcesses; i++ {
 		<-w.doneProcessing
 	}
@@ -536,6 +600,14 @@ func (w *Worker) Shutdown() {
 		<-w.doneCleanup
 	}
 	<-w.doneUpdateCheck
+	w.webhooks.Close()
+}
+
+// Webhooks returns the dispatcher for the configured webhooks, so services
+// outside the worker send through the same queue. It's nil when none are
+// configured.
+func (w *Worker) Webhooks() *webhooks.Dispatcher {
+	return w.webhooks
 }
 
 func (w *Worker) publishJobEvent(eventType string, job *models.Job) {